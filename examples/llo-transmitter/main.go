@@ -0,0 +1,299 @@
+// llo-transmitter runs the full lifecycle of LLO reports in a single
+// process: a simulated DON of LLO plugins observes a fake data source,
+// comes to consensus, generates and attests JSON reports, and transmits
+// them with the gRPC transmitter client to an in-process reference server,
+// waiting until each report has been persisted.
+//
+// It is meant as a starting point for integrators and doubles as an
+// integration smoke test.
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+
+	"github.com/smartcontractkit/chainlink-data-streams/llo"
+	"github.com/smartcontractkit/chainlink-data-streams/llo/llotest"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc/server"
+)
+
+const (
+	n = 4
+	f = 1
+
+	channelID llotypes.ChannelID = 1
+	streamID  llotypes.StreamID  = 1
+
+	deliveryPollInterval = 50 * time.Millisecond
+)
+
+// configDigest has the LLO config digest prefix, as required by the plugin
+var configDigest = types.ConfigDigest{0x00, 0x09, 0xee}
+
+func main() {
+	rounds := flag.Int("rounds", 5, "number of OCR rounds to run")
+	roundInterval := flag.Duration("round-interval", time.Second, "time between rounds; observation timestamps have second resolution, so reports are only generated if this is at least 1s")
+	flag.Parse()
+
+	lggr, err := logger.New()
+	if err != nil {
+		log.Fatalf("failed to create logger: %v", err)
+	}
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	res, err := run(ctx, lggr, *rounds, *roundInterval)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("transmitted and persisted %d reports\n", len(res))
+	for _, r := range res {
+		fmt.Printf("seqNr=%d channelID=%d validAfterSeconds=%d observationTimestampSeconds=%d values=%v\n", r.SeqNr, r.Report.ChannelID, r.Report.ValidAfterSeconds, r.Report.ObservationTimestampSeconds, r.Report.Values)
+	}
+}
+
+// PersistedReport is a report as read back from the server's store
+type PersistedReport struct {
+	SeqNr  uint64
+	Report llo.Report
+}
+
+// run drives the DON for the given number of rounds, transmitting every
+// report it generates, and returns the reports that the server persisted
+func run(ctx context.Context, lggr logger.Logger, rounds int, roundInterval time.Duration) ([]PersistedReport, error) {
+	// Server
+	store := server.NewInMemoryReportStore()
+	srv, err := server.NewServer(lggr, server.Config{}, store)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create server: %w", err)
+	}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen: %w", err)
+	}
+	gs := rpc.NewGRPCServer(lggr, srv, rpc.GRPCServerConfig{})
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- gs.Serve(lis) }()
+	defer func() {
+		gs.Stop()
+		if err := <-serveErr; err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			lggr.Errorw("Server failed", "err", err)
+		}
+	}()
+
+	// Client
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial server: %w", err)
+	}
+	defer conn.Close()
+	client := rpc.NewClient(lggr, conn, rpc.ClientConfig{ServerURL: lis.Addr().String()})
+	if err = client.Start(ctx); err != nil {
+		return nil, fmt.Errorf("failed to start client: %w", err)
+	}
+	defer client.Close()
+
+	// DON
+	d, err := newDON(ctx, lggr)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	var outcome ocr3types.Outcome
+	for seqNr := uint64(1); seqNr <= uint64(rounds); seqNr++ {
+		if seqNr > 1 {
+			select {
+			case <-time.After(roundInterval):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		// the price moves every round
+		d.ds.Set(streamID, llo.ToDecimal(decimal.NewFromInt(int64(1000+seqNr))))
+
+		var reports []ocr3types.ReportPlus[llotypes.ReportInfo]
+		outcome, reports, err = d.round(ctx, seqNr, outcome)
+		if err != nil {
+			return nil, fmt.Errorf("round %d failed: %w", seqNr, err)
+		}
+		for _, r := range reports {
+			key, err := d.transmit(ctx, client, seqNr, r.ReportWithInfo)
+			if err != nil {
+				return nil, fmt.Errorf("round %d failed to transmit report: %w", seqNr, err)
+			}
+			keys = append(keys, key)
+		}
+	}
+
+	res := make([]PersistedReport, 0, len(keys))
+	for _, key := range keys {
+		req, ok := store.Get(key)
+		if !ok {
+			return nil, fmt.Errorf("report %s was acknowledged but is missing from the store", key)
+		}
+		_, seqNr, report, _, err := llo.JSONReportCodec{}.UnpackDecode(req.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode persisted report %s: %w", key, err)
+		}
+		res = append(res, PersistedReport{seqNr, report})
+	}
+	return res, nil
+}
+
+// don simulates the oracles of a DON. OCR itself is not run; instead, every
+// round each oracle observes, the leader computes the outcome, and f+1
+// oracles sign the resulting reports.
+type don struct {
+	ds       *llotest.DataSource
+	plugins  []ocr3types.ReportingPlugin[llotypes.ReportInfo]
+	keyrings []ocr3types.OnchainKeyring[llotypes.ReportInfo]
+}
+
+func newDON(ctx context.Context, lggr logger.Logger) (*don, error) {
+	ds := llotest.NewDataSource()
+	cdc := llotest.NewChannelDefinitionCache(llotypes.ChannelDefinitions{
+		channelID: {
+			ReportFormat: llotypes.ReportFormatJSON,
+			Streams:      []llotypes.Stream{{StreamID: streamID, Aggregator: llotypes.AggregatorMedian}},
+		},
+	})
+	factory := llo.NewPluginFactory(
+		llo.Config{},
+		llotest.NewPredecessorRetirementReportCache(),
+		llotest.NewShouldRetireCache(),
+		llo.StandardRetirementReportCodec{},
+		cdc,
+		ds,
+		lggr,
+		llo.EVMOnchainConfigCodec{},
+		map[llotypes.ReportFormat]llo.ReportCodec{llotypes.ReportFormatJSON: llo.JSONReportCodec{}},
+	)
+	onchainConfig, err := llo.EVMOnchainConfigCodec{}.Encode(llo.OnchainConfig{Version: 1})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode onchain config: %w", err)
+	}
+
+	d := &don{ds: ds}
+	for i := 0; i < n; i++ {
+		p, _, err := factory.NewReportingPlugin(ctx, ocr3types.ReportingPluginConfig{
+			ConfigDigest:           configDigest,
+			OracleID:               commontypes.OracleID(i),
+			N:                      n,
+			F:                      f,
+			OnchainConfig:          onchainConfig,
+			MaxDurationObservation: time.Second,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create plugin for oracle %d: %w", i, err)
+		}
+		_, privateKey, err := ed25519.GenerateKey(nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate key for oracle %d: %w", i, err)
+		}
+		kr, err := llo.NewEd25519OnchainKeyring(privateKey)
+		if err != nil {
+			return nil, err
+		}
+		d.plugins = append(d.plugins, p)
+		d.keyrings = append(d.keyrings, kr)
+	}
+	return d, nil
+}
+
+// round runs a single round, returning its outcome and reports
+func (d *don) round(ctx context.Context, seqNr uint64, previousOutcome ocr3types.Outcome) (ocr3types.Outcome, []ocr3types.ReportPlus[llotypes.ReportInfo], error) {
+	outctx := ocr3types.OutcomeContext{SeqNr: seqNr, PreviousOutcome: previousOutcome}
+	leader := d.plugins[0]
+
+	aos := make([]types.AttributedObservation, 0, n)
+	for i, p := range d.plugins {
+		obs, err := p.Observation(ctx, outctx, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("oracle %d failed to observe: %w", i, err)
+		}
+		ao := types.AttributedObservation{Observation: obs, Observer: commontypes.OracleID(i)}
+		if err := leader.ValidateObservation(ctx, outctx, nil, ao); err != nil {
+			return nil, nil, fmt.Errorf("invalid observation from oracle %d: %w", i, err)
+		}
+		aos = append(aos, ao)
+	}
+	outcome, err := leader.Outcome(ctx, outctx, nil, aos)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to compute outcome: %w", err)
+	}
+	reports, err := leader.Reports(ctx, seqNr, outcome)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate reports: %w", err)
+	}
+	return outcome, reports, nil
+}
+
+// transmit attests the report with f+1 signatures, transmits it and waits
+// until the server has persisted it. It returns the transmission's
+// idempotency key.
+func (d *don) transmit(ctx context.Context, client *rpc.Client, seqNr uint64, r ocr3types.ReportWithInfo[llotypes.ReportInfo]) (string, error) {
+	if ok, err := d.plugins[0].ShouldAcceptAttestedReport(ctx, seqNr, r); err != nil {
+		return "", err
+	} else if !ok {
+		return "", errors.New("report was not accepted")
+	}
+	if ok, err := d.plugins[0].ShouldTransmitAcceptedReport(ctx, seqNr, r); err != nil {
+		return "", err
+	} else if !ok {
+		return "", errors.New("report should not be transmitted")
+	}
+
+	sigs := make([]types.AttributedOnchainSignature, 0, f+1)
+	for i, kr := range d.keyrings[:f+1] {
+		sig, err := kr.Sign(configDigest, seqNr, r)
+		if err != nil {
+			return "", fmt.Errorf("oracle %d failed to sign report: %w", i, err)
+		}
+		sigs = append(sigs, types.AttributedOnchainSignature{Signature: sig, Signer: commontypes.OracleID(i)})
+	}
+	payload, err := llo.JSONReportCodec{}.Pack(configDigest, seqNr, r.Report, sigs)
+	if err != nil {
+		return "", fmt.Errorf("failed to pack report: %w", err)
+	}
+
+	req := &rpc.TransmitRequest{Payload: payload, ReportFormat: uint32(r.Info.ReportFormat), ConfigDigest: configDigest[:]}
+	res, err := client.Transmit(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	if res.Code != 0 {
+		return "", fmt.Errorf("server rejected report (code %d): %s", res.Code, res.Error)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	status, err := client.WaitForDelivery(ctx, req.IdempotencyKey, deliveryPollInterval)
+	if err != nil {
+		return "", err
+	}
+	if status.Status != rpc.TransmissionStatusResponse_Persisted {
+		return "", fmt.Errorf("report was not persisted: %s", status.Reason)
+	}
+	return req.IdempotencyKey, nil
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink-data-streams/llo"
+)
+
+func Test_run(t *testing.T) {
+	if testing.Short() {
+		t.Skip("takes several seconds")
+	}
+	ctx := tests.Context(t)
+
+	res, err := run(ctx, logger.Test(t), 5, time.Second)
+	require.NoError(t, err)
+
+	require.NotEmpty(t, res)
+	for _, r := range res {
+		assert.Equal(t, channelID, r.Report.ChannelID)
+		assert.Greater(t, r.Report.ObservationTimestampSeconds, r.Report.ValidAfterSeconds)
+		// values come from the data source as of the round that produced the
+		// outcome the report was generated from
+		require.Len(t, r.Report.Values, 1)
+		assert.Equal(t, fmt.Sprint(1000+r.SeqNr), r.Report.Values[0].(*llo.Decimal).String())
+	}
+}
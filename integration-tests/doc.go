@@ -0,0 +1,9 @@
+// Package integration contains end-to-end tests of the reference Transmitter
+// server, backed by Postgres running in a Docker container. They are only
+// compiled with the "integration" build tag and require a Docker daemon:
+//
+//	go test -tags integration ./...
+//
+// The tests live in their own module so that their container dependencies
+// are not pulled into the main module.
+package integration
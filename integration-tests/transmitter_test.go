@@ -0,0 +1,239 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib" // Postgres driver
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	tcpostgres "github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink-data-streams/llo"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc/server"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc/server/postgres"
+)
+
+const (
+	postgresImage = "postgres:15.2-alpine"
+
+	channelID llotypes.ChannelID = 1
+)
+
+var (
+	feedID       = llo.FeedID{0, 3, 0xaa}
+	configDigest = types.ConfigDigest{0x00, 0x09, 0xbb}
+
+	channelDefinitions = llotypes.ChannelDefinitions{
+		channelID: {
+			ReportFormat: llotypes.ReportFormatEVMPremiumLegacy,
+			Streams: []llotypes.Stream{
+				{StreamID: 1, Aggregator: llotypes.AggregatorMedian},
+				{StreamID: 2, Aggregator: llotypes.AggregatorMedian},
+				{StreamID: 3, Aggregator: llotypes.AggregatorQuote},
+			},
+			Opts: []byte(`{"feedID":"` + feedID.Hex() + `","baseUSDFee":"1","expirationWindow":3600,"multiplier":"1000000000000000000"}`),
+		},
+	}
+)
+
+// startPostgres runs Postgres in a container and returns a connection to
+// its migrated database
+func startPostgres(t *testing.T) *sql.DB {
+	t.Helper()
+	ctx := tests.Context(t)
+	c, err := tcpostgres.Run(ctx, postgresImage,
+		tcpostgres.WithDatabase("llo_integration"),
+		tcpostgres.WithUsername("llo"),
+		tcpostgres.WithPassword("llo"),
+		testcontainers.WithWaitStrategy(
+			// Postgres restarts once after initializing the database
+			wait.ForLog("database system is ready to accept connections").WithOccurrence(2).WithStartupTimeout(time.Minute),
+		),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, c.Terminate(context.Background())) })
+
+	dsn, err := c.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+	db, err := sql.Open("pgx", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, db.Close()) })
+	require.NoError(t, postgres.Migrate(ctx, db))
+	return db
+}
+
+// node is a reference server with a Postgres store, serving gRPC on a local
+// port
+type node struct {
+	db         *sql.DB
+	srv        *server.Server
+	replicator *server.Replicator
+	conn       *grpc.ClientConn
+	client     *rpc.Client
+}
+
+func startNode(t *testing.T) *node {
+	t.Helper()
+	ctx := tests.Context(t)
+	lggr := logger.Test(t)
+	n := &node{db: startPostgres(t), replicator: server.NewReplicator(lggr, 0)}
+
+	store, err := postgres.NewStore(lggr, n.db, postgres.Config{ChannelDefinitions: channelDefinitions})
+	require.NoError(t, err)
+	require.NoError(t, store.Start(ctx))
+	t.Cleanup(func() { assert.NoError(t, store.Close()) })
+
+	n.srv, err = server.NewServer(lggr, server.Config{Sinks: []server.Sink{n.replicator}}, store)
+	require.NoError(t, err)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	gs := rpc.NewGRPCServer(lggr, n.srv, rpc.GRPCServerConfig{})
+	rpc.RegisterReplicationServer(gs, n.replicator)
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- gs.Serve(lis) }()
+	t.Cleanup(func() {
+		gs.Stop()
+		if err := <-serveErr; err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			t.Errorf("server failed: %v", err)
+		}
+	})
+
+	n.conn, err = grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, n.conn.Close()) })
+	n.client = rpc.NewClient(lggr, n.conn, rpc.ClientConfig{ServerURL: lis.Addr().String()})
+	require.NoError(t, n.client.Start(ctx))
+	t.Cleanup(func() { assert.NoError(t, n.client.Close()) })
+	return n
+}
+
+// countReports returns the number of rows in the node's reports table
+func (n *node) countReports(t *testing.T) int {
+	t.Helper()
+	var count int
+	require.NoError(t, n.db.QueryRowContext(tests.Context(t), `SELECT COUNT(*) FROM llo_reports`).Scan(&count))
+	return count
+}
+
+// evmTransmitRequest returns a transmission of an attested EVM premium
+// legacy report of the channel
+func evmTransmitRequest(t *testing.T, seqNr uint64, ts uint32, price int64) *rpc.TransmitRequest {
+	t.Helper()
+	cdc := llo.EVMPremiumLegacyReportCodec{}
+	report := llo.Report{
+		ConfigDigest:                configDigest,
+		SeqNr:                       seqNr,
+		ChannelID:                   channelID,
+		ValidAfterSeconds:           ts - 1,
+		ObservationTimestampSeconds: ts,
+		Values: []llo.StreamValue{
+			llo.ToDecimal(decimal.NewFromInt(2000)),
+			llo.ToDecimal(decimal.NewFromInt(20)),
+			&llo.Quote{Bid: decimal.NewFromInt(price - 1), Benchmark: decimal.NewFromInt(price), Ask: decimal.NewFromInt(price + 1)},
+		},
+	}
+	encoded, err := cdc.Encode(tests.Context(t), report, channelDefinitions[channelID])
+	require.NoError(t, err)
+	sigs := []types.AttributedOnchainSignature{{Signer: 0, Signature: bytes.Repeat([]byte{1}, 65)}, {Signer: 1, Signature: bytes.Repeat([]byte{2}, 65)}}
+	payload, err := cdc.Pack(configDigest, seqNr, encoded, sigs)
+	require.NoError(t, err)
+	return &rpc.TransmitRequest{Payload: payload, ReportFormat: uint32(llotypes.ReportFormatEVMPremiumLegacy), ConfigDigest: configDigest[:]}
+}
+
+func Test_Transmitter(t *testing.T) {
+	if testing.Short() {
+		t.Skip("starts Postgres containers")
+	}
+	ctx := tests.Context(t)
+	n := startNode(t)
+	ts := uint32(time.Now().Unix())
+
+	t.Run("transmits and persists reports", func(t *testing.T) {
+		req := evmTransmitRequest(t, 1, ts, 100)
+		res, err := n.client.Transmit(ctx, req)
+		require.NoError(t, err)
+		require.Zero(t, res.Code, res.Error)
+
+		st, err := n.client.WaitForDelivery(ctx, req.IdempotencyKey, 50*time.Millisecond)
+		require.NoError(t, err)
+		assert.Equal(t, rpc.TransmissionStatusResponse_Persisted, st.Status)
+		assert.Equal(t, 1, n.countReports(t))
+	})
+
+	t.Run("deduplicates transmissions of the same report", func(t *testing.T) {
+		before := n.countReports(t)
+		// every node of a DON transmits the same report
+		for i := 0; i < 4; i++ {
+			res, err := n.client.Transmit(ctx, evmTransmitRequest(t, 2, ts+1, 101))
+			require.NoError(t, err)
+			require.Zero(t, res.Code, res.Error)
+		}
+		assert.Equal(t, before+1, n.countReports(t))
+	})
+
+	t.Run("serves the latest report of a feed", func(t *testing.T) {
+		// transmitted out of order
+		for _, req := range []*rpc.TransmitRequest{evmTransmitRequest(t, 4, ts+3, 103), evmTransmitRequest(t, 3, ts+2, 102)} {
+			res, err := n.client.Transmit(ctx, req)
+			require.NoError(t, err)
+			require.Zero(t, res.Code, res.Error)
+		}
+
+		res, err := n.client.LatestReport(ctx, &rpc.LatestReportRequest{FeedId: feedID[:]})
+		require.NoError(t, err)
+		assert.Equal(t, feedID[:], res.Report.FeedId)
+		assert.Equal(t, int64(ts+3), res.Report.ObservationsTimestamp)
+		epoch, round, err := llo.SeqNrToEpochAndRound(4)
+		require.NoError(t, err)
+		assert.Equal(t, epoch, res.Report.Epoch)
+		assert.Equal(t, uint32(round), res.Report.Round)
+
+		_, err = n.client.LatestReport(ctx, &rpc.LatestReportRequest{FeedId: make([]byte, 32)})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("replicates reports to subscribed peers", func(t *testing.T) {
+		follower := startNode(t)
+		f := server.NewReplicationFollower(logger.Test(t), follower.srv, "follower", []server.ReplicationPeer{{Name: "origin", Client: rpc.NewReplicationClient(n.conn)}})
+		require.NoError(t, f.Start(ctx))
+		t.Cleanup(func() { assert.NoError(t, f.Close()) })
+
+		// reports are only replicated once the follower has subscribed, so
+		// keep transmitting new reports until one arrives
+		seqNr := uint64(10)
+		require.Eventually(t, func() bool {
+			seqNr++
+			res, err := n.client.Transmit(ctx, evmTransmitRequest(t, seqNr, ts+uint32(seqNr), 100+int64(seqNr)))
+			require.NoError(t, err)
+			require.Zero(t, res.Code, res.Error)
+			return follower.countReports(t) > 0
+		}, tests.WaitTimeout(t), 100*time.Millisecond)
+
+		res, err := follower.client.LatestReport(ctx, &rpc.LatestReportRequest{FeedId: feedID[:]})
+		require.NoError(t, err)
+		assert.Greater(t, res.Report.ObservationsTimestamp, int64(ts+10))
+	})
+}
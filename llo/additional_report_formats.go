@@ -0,0 +1,61 @@
+package llo
+
+import (
+	"encoding/json"
+	"fmt"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+// AdditionalReportFormatsOpts are channel opts that make a channel emit a
+// report in each of the given formats besides its ReportFormat, e.g. a JSON
+// mirror of an onchain-verifiable EVM report for developers. All reports of
+// a round are generated from the same outcome values, which are validated
+// once for all formats. They may be combined with any codec-specific opts.
+type AdditionalReportFormatsOpts struct {
+	AdditionalReportFormats []llotypes.ReportFormat `json:"additionalReportFormats,omitempty"`
+}
+
+// ParseAdditionalReportFormats extracts the additional report formats from
+// a channel's opts, returning nil if none are set. Other fields in the opts
+// are ignored, and opts that are not a JSON object set no additional
+// formats.
+func ParseAdditionalReportFormats(cd llotypes.ChannelDefinition) ([]llotypes.ReportFormat, error) {
+	raw, ok := channelOptsField(cd.Opts, "additionalReportFormats")
+	if !ok {
+		return nil, nil
+	}
+	var formats []llotypes.ReportFormat
+	if err := json.Unmarshal(raw, &formats); err != nil {
+		return nil, fmt.Errorf("invalid channel opts: additionalReportFormats: %w", err)
+	}
+	seen := map[llotypes.ReportFormat]struct{}{cd.ReportFormat: {}}
+	for _, rf := range formats {
+		if rf == llotypes.ReportFormatRetirement {
+			return nil, fmt.Errorf("invalid channel opts: %s is not a channel report format", rf)
+		}
+		if _, exists := seen[rf]; exists {
+			return nil, fmt.Errorf("invalid channel opts: duplicate report format: %s", rf)
+		}
+		seen[rf] = struct{}{}
+	}
+	return formats, nil
+}
+
+// channelReportFormats returns the formats that reports on the channel are
+// emitted in, starting with its ReportFormat. Invalid additional formats
+// are ignored.
+func channelReportFormats(cache *channelOptsCache, cd llotypes.ChannelDefinition) []llotypes.ReportFormat {
+	opts := cache.of(cd)
+	if opts.additionalReportFormatsErr != nil {
+		return []llotypes.ReportFormat{cd.ReportFormat}
+	}
+	return append([]llotypes.ReportFormat{cd.ReportFormat}, opts.additionalReportFormats...)
+}
+
+// withReportFormat returns a copy of the channel definition as seen by the
+// codec of the given format
+func withReportFormat(cd llotypes.ChannelDefinition, rf llotypes.ReportFormat) llotypes.ChannelDefinition {
+	cd.ReportFormat = rf
+	return cd
+}
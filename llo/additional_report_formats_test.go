@@ -0,0 +1,116 @@
+package llo
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+)
+
+func Test_ParseAdditionalReportFormats(t *testing.T) {
+	cd := llotypes.ChannelDefinition{ReportFormat: llotypes.ReportFormatEVMPremiumLegacy}
+	formats, err := ParseAdditionalReportFormats(cd)
+	require.NoError(t, err)
+	assert.Nil(t, formats)
+	assert.Equal(t, []llotypes.ReportFormat{llotypes.ReportFormatEVMPremiumLegacy}, channelReportFormats(nil, cd))
+
+	cd.Opts = []byte(`{"additionalReportFormats":["json"],"foo":"bar"}`)
+	formats, err = ParseAdditionalReportFormats(cd)
+	require.NoError(t, err)
+	assert.Equal(t, []llotypes.ReportFormat{llotypes.ReportFormatJSON}, formats)
+	assert.Equal(t, []llotypes.ReportFormat{llotypes.ReportFormatEVMPremiumLegacy, llotypes.ReportFormatJSON}, channelReportFormats(nil, cd))
+
+	for opts, expectedErr := range map[string]string{
+		`{"additionalReportFormats":"json"}`:                 "invalid channel opts: additionalReportFormats: json: cannot unmarshal string",
+		`{"additionalReportFormats":["xml"]}`:                `invalid channel opts: additionalReportFormats: unknown report format: "xml"`,
+		`{"additionalReportFormats":["evm_premium_legacy"]}`: "invalid channel opts: duplicate report format: evm_premium_legacy",
+		`{"additionalReportFormats":["json","json"]}`:        "invalid channel opts: duplicate report format: json",
+		`{"additionalReportFormats":["retirement"]}`:         "invalid channel opts: retirement is not a channel report format",
+	} {
+		cd.Opts = []byte(opts)
+		_, err = ParseAdditionalReportFormats(cd)
+		assert.ErrorContains(t, err, expectedErr, opts)
+		// invalid additional formats are ignored when emitting reports...
+		assert.Equal(t, []llotypes.ReportFormat{llotypes.ReportFormatEVMPremiumLegacy}, channelReportFormats(nil, cd), opts)
+		// ...but make the channel definition invalid
+		err = VerifyChannelDefinitions(llotypes.ChannelDefinitions{1: {
+			ReportFormat: llotypes.ReportFormatJSON,
+			Streams:      []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}},
+			Opts:         cd.Opts,
+		}})
+		assert.Error(t, err, opts)
+	}
+
+	t.Run("opts without additional formats set none", func(t *testing.T) {
+		for _, opts := range []string{`{"foo":"bar"}`, `not json`, `["additionalReportFormats"]`} {
+			cd.Opts = []byte(opts)
+			formats, err := ParseAdditionalReportFormats(cd)
+			require.NoError(t, err, opts)
+			assert.Nil(t, formats, opts)
+		}
+	})
+}
+
+// prefixReportCodec encodes the channel ID of a report behind a prefix
+type prefixReportCodec string
+
+func (c prefixReportCodec) Encode(_ context.Context, r Report, cd llotypes.ChannelDefinition) ([]byte, error) {
+	return []byte(fmt.Sprintf("%s:%s:%d", c, cd.ReportFormat, r.ChannelID)), nil
+}
+
+func Test_Reports_AdditionalReportFormats(t *testing.T) {
+	ctx := tests.Context(t)
+	p := &Plugin{
+		Config:       Config{true},
+		OutcomeCodec: protoOutcomeCodec{},
+		Logger:       logger.Test(t),
+		ReportCodecs: map[llotypes.ReportFormat]ReportCodec{
+			llotypes.ReportFormatEVMPremiumLegacy: prefixReportCodec("evm"),
+			llotypes.ReportFormatJSON:             JSONReportCodec{},
+		},
+	}
+	outcome := Outcome{
+		LifeCycleStage:                   LifeCycleStageProduction,
+		ObservationsTimestampNanoseconds: int64(200 * time.Second),
+		ValidAfterSeconds:                map[llotypes.ChannelID]uint32{1: 100, 2: 100},
+		ChannelDefinitions: llotypes.ChannelDefinitions{
+			1: {
+				ReportFormat: llotypes.ReportFormatEVMPremiumLegacy,
+				Streams:      []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}},
+				Opts:         []byte(`{"additionalReportFormats":["json"]}`),
+			},
+			2: {
+				ReportFormat: llotypes.ReportFormatEVMPremiumLegacy,
+				Streams:      []llotypes.Stream{{StreamID: 2, Aggregator: llotypes.AggregatorMedian}},
+				Opts:         []byte(`{"additionalReportFormats":["json"],"streamValuePolicies":{"2":"positive"}}`),
+			},
+		},
+		StreamAggregates: map[llotypes.StreamID]map[llotypes.Aggregator]StreamValue{
+			1: {llotypes.AggregatorMedian: ToDecimal(decimal.NewFromFloat(1.1))},
+			2: {llotypes.AggregatorMedian: ToDecimal(decimal.NewFromInt(0))},
+		},
+	}
+	encoded, err := p.OutcomeCodec.Encode(outcome)
+	require.NoError(t, err)
+	violations := testutil.ToFloat64(promReportEncodeErrorsTotal.WithLabelValues(p.ConfigDigest.String(), "2", llotypes.ReportFormatJSON.String()))
+
+	rwis, err := p.Reports(ctx, 2, encoded)
+	require.NoError(t, err)
+
+	// channel 2 violates its value policy in all formats
+	require.Len(t, rwis, 2)
+	assert.Equal(t, "evm:evm_premium_legacy:1", string(rwis[0].ReportWithInfo.Report))
+	assert.Equal(t, llotypes.ReportInfo{LifeCycleStage: LifeCycleStageProduction, ReportFormat: llotypes.ReportFormatEVMPremiumLegacy}, rwis[0].ReportWithInfo.Info)
+	assert.Equal(t, `{"ConfigDigest":"0000000000000000000000000000000000000000000000000000000000000000","SeqNr":2,"ChannelID":1,"ValidAfterSeconds":100,"ObservationTimestampSeconds":200,"Values":[{"Type":0,"Value":"1.1"}],"Specimen":false}`, string(rwis[1].ReportWithInfo.Report))
+	assert.Equal(t, llotypes.ReportInfo{LifeCycleStage: LifeCycleStageProduction, ReportFormat: llotypes.ReportFormatJSON}, rwis[1].ReportWithInfo.Info)
+	assert.Equal(t, violations+1, testutil.ToFloat64(promReportEncodeErrorsTotal.WithLabelValues(p.ConfigDigest.String(), "2", llotypes.ReportFormatJSON.String())))
+}
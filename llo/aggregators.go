@@ -2,13 +2,20 @@ package llo
 
 import (
 	"fmt"
-	"sort"
 
 	"github.com/shopspring/decimal"
 
 	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
 )
 
+// AggregatorVolumeWeighted aggregates PriceVolumes observations into a
+// volume-weighted price (see VolumeWeightedAggregator).
+//
+// NOTE: This is not (yet) defined in llotypes, so it has no string
+// representation and channel definitions must refer to it by number. If it is
+// ever added there, the values must match.
+const AggregatorVolumeWeighted llotypes.Aggregator = 4
+
 type AggregatorFunc func(values []StreamValue, f int) (StreamValue, error)
 
 func GetAggregatorFunc(a llotypes.Aggregator) AggregatorFunc {
@@ -19,6 +26,8 @@ func GetAggregatorFunc(a llotypes.Aggregator) AggregatorFunc {
 		return ModeAggregator
 	case llotypes.AggregatorQuote:
 		return QuoteAggregator
+	case AggregatorVolumeWeighted:
+		return VolumeWeightedAggregator
 	default:
 		return nil
 	}
@@ -44,12 +53,11 @@ func MedianAggregator(values []StreamValue, f int) (StreamValue, error) {
 		// all.
 		return nil, fmt.Errorf("not enough observations to calculate median, expected at least f+1, got %d", len(observations))
 	}
-	sort.Slice(observations, func(i, j int) bool { return observations[i].Cmp(observations[j]) < 0 })
 	// We use a "rank-k" median here, instead one could average in case of
 	// an even number of observations.
 	// In the case of an even number, the higher value is chosen.
 	// e.g. [1, 2, 3, 4] -> 3
-	return ToDecimal(observations[len(observations)/2]), nil
+	return ToDecimal(selectKth(observations, len(observations)/2, compareDecimals)), nil
 }
 
 // ModeAggregator works on arbitrary StreamValue types
@@ -85,16 +93,17 @@ func ModeAggregator(values []StreamValue, f int) (StreamValue, error) {
 	// use serialized representation for comparison/equality
 	counts := make(map[string]int)
 	for _, value := range largestBucket {
-		b, err := value.MarshalBinary()
+		key, err := streamValueKey(value)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal value: %v", err)
 		}
-		counts[string(b)]++
+		counts[key]++
 	}
+	// tie-break on serialized representation, in canonical order
 	var modeSerialized []byte
 	var modeCount int
-	for value, count := range counts {
-		if count > modeCount {
+	for _, value := range sortedKeys(counts) {
+		if count := counts[value]; count > modeCount {
 			modeSerialized = []byte(value)
 			modeCount = count
 		}
@@ -134,12 +143,38 @@ func QuoteAggregator(values []StreamValue, f int) (StreamValue, error) {
 	// Calculate "rank-k" median for benchmark, bid and ask separately.
 	// This is guaranteed not to return values that violate bid<=mid<=ask due
 	// to the filter of observations above.
+	k := len(observations) / 2
 	q := Quote{}
-	sort.Slice(observations, func(i, j int) bool { return observations[i].Benchmark.Cmp(observations[j].Benchmark) < 0 })
-	q.Benchmark = observations[len(observations)/2].Benchmark
-	sort.Slice(observations, func(i, j int) bool { return observations[i].Bid.Cmp(observations[j].Bid) < 0 })
-	q.Bid = observations[len(observations)/2].Bid
-	sort.Slice(observations, func(i, j int) bool { return observations[i].Ask.Cmp(observations[j].Ask) < 0 })
-	q.Ask = observations[len(observations)/2].Ask
+	q.Benchmark = selectKth(observations, k, func(a, b *Quote) int { return a.Benchmark.Cmp(b.Benchmark) }).Benchmark
+	q.Bid = selectKth(observations, k, func(a, b *Quote) int { return a.Bid.Cmp(b.Bid) }).Bid
+	q.Ask = selectKth(observations, k, func(a, b *Quote) int { return a.Ask.Cmp(b.Ask) }).Ask
 	return &q, nil
 }
+
+// VolumeWeightedAggregator works on PriceVolumes observations. Each
+// observation is reduced to its own volume-weighted price, and the result is
+// the "rank-k" median of those prices.
+//
+// Weighting by volume only happens within an observation: a single oracle
+// cannot move the result by reporting outsized volumes, since the median
+// across oracles is robust to up to f faulty observations.
+func VolumeWeightedAggregator(values []StreamValue, f int) (StreamValue, error) {
+	prices := make([]decimal.Decimal, 0, len(values))
+	for _, value := range values {
+		v, ok := value.(*PriceVolumes)
+		if !ok {
+			// Unexpected type, skip
+			continue
+		}
+		price, ok := v.VolumeWeightedPrice()
+		if !ok {
+			// Exclude observations with negative or zero total volume
+			continue
+		}
+		prices = append(prices, price)
+	}
+	if len(prices) <= f {
+		return nil, fmt.Errorf("not enough valid observations to calculate volume-weighted price, expected at least f+1, got %d", len(prices))
+	}
+	return ToDecimal(selectKth(prices, len(prices)/2, compareDecimals)), nil
+}
@@ -224,3 +224,55 @@ func Test_QuoteAggregator(t *testing.T) {
 		assert.Equal(t, "6.6", q.Ask.String())
 	})
 }
+
+func Test_VolumeWeightedAggregator(t *testing.T) {
+	pv := func(pairs ...float64) *PriceVolumes {
+		v := PriceVolumes{}
+		for i := 0; i < len(pairs); i += 2 {
+			v = append(v, PriceVolume{Price: decimal.NewFromFloat(pairs[i]), Volume: decimal.NewFromFloat(pairs[i+1])})
+		}
+		return &v
+	}
+
+	t.Run("returns median of per-observation volume-weighted prices", func(t *testing.T) {
+		values := []StreamValue{
+			pv(10, 1, 20, 3),  // 17.5
+			pv(15, 2),         // 15
+			pv(16, 1, 18, 1),  // 17
+			pv(100, 0, 12, 5), // 12
+		}
+		sv, err := VolumeWeightedAggregator(values, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "17", sv.(*Decimal).Decimal().String())
+	})
+
+	t.Run("ignores observations with negative or zero total volume", func(t *testing.T) {
+		values := []StreamValue{
+			pv(10, 1),
+			pv(11, 1),
+			pv(1000, -1, 12, 5), // invalid
+			pv(1000, 0),         // invalid
+			pv(),                // invalid
+		}
+		sv, err := VolumeWeightedAggregator(values, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "11", sv.(*Decimal).Decimal().String())
+	})
+
+	t.Run("ignores non-PriceVolumes type", func(t *testing.T) {
+		values := []StreamValue{
+			pv(10, 1),
+			pv(11, 1),
+			ToDecimal(decimal.NewFromFloat(1000)),
+			&Quote{Bid: decimal.NewFromFloat(1000), Benchmark: decimal.NewFromFloat(1000), Ask: decimal.NewFromFloat(1000)},
+		}
+		sv, err := VolumeWeightedAggregator(values, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "11", sv.(*Decimal).Decimal().String())
+	})
+
+	t.Run("fails with fewer than f+1 values", func(t *testing.T) {
+		_, err := VolumeWeightedAggregator([]StreamValue{pv(10, 1), pv(11, 1), pv(12, 0)}, 2)
+		assert.EqualError(t, err, "not enough valid observations to calculate volume-weighted price, expected at least f+1, got 2")
+	})
+}
@@ -0,0 +1,174 @@
+package llo
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/big"
+
+	bn256 "github.com/umbracle/go-eth-bn256"
+)
+
+// BN254 hash-to-curve, as specified by RFC 9380 for the
+// BN254G1_XMD:SHA-256_SVDW_RO_ suite: messages are hashed to two field
+// elements with expand_message_xmd, each is mapped onto the curve with the
+// Shallue-van de Woestijne method, and the two points are added. This is the
+// suite implemented by gnark-crypto's bn254.HashToG1, so verifiers can use
+// any implementation of it.
+//
+// The arithmetic uses math/big and is not constant time, which is fine as
+// long as the messages are public.
+
+var (
+	// bn254P is the base field modulus of BN254
+	bn254P, _ = new(big.Int).SetString("21888242871839275222246405745257275088696311157297823662689037894645226208583", 10)
+	// bn254B is the b coefficient of y² = x³ + b
+	bn254B = big.NewInt(3)
+
+	// SvdW constants for Z = 1, see RFC 9380 section 6.6.1
+	svdwZ  = big.NewInt(1)
+	svdwC1 = svdwG(svdwZ)
+	svdwC2 = bn254Mod(new(big.Int).Neg(bn254Div(svdwZ, big.NewInt(2))))
+	svdwC3 = svdwSqrtSgn0(bn254Mod(new(big.Int).Neg(new(big.Int).Mul(svdwC1, new(big.Int).Mul(big.NewInt(3), new(big.Int).Mul(svdwZ, svdwZ))))))
+	svdwC4 = bn254Mod(new(big.Int).Neg(bn254Div(new(big.Int).Mul(big.NewInt(4), svdwC1), new(big.Int).Mul(big.NewInt(3), new(big.Int).Mul(svdwZ, svdwZ)))))
+)
+
+const (
+	// hashToFieldLength is L = ceil((ceil(log2(p)) + k) / 8) for k = 128
+	hashToFieldLength = 48
+	// xmdMaxDSTLength is the longest domain separation tag that
+	// expand_message_xmd accepts without hashing it first
+	xmdMaxDSTLength = 255
+)
+
+// hashToG1 hashes msg onto G1, using dst to separate its uses. It panics if
+// dst is longer than 255 bytes.
+func hashToG1(msg, dst []byte) *bn256.G1 {
+	u := hashToField(msg, dst, 2)
+	// The cofactor of G1 is one, so there is nothing to clear
+	return new(bn256.G1).Add(svdwMapToG1(u[0]), svdwMapToG1(u[1]))
+}
+
+// hashToField hashes msg to count elements of the base field
+func hashToField(msg, dst []byte, count int) []*big.Int {
+	b, err := expandMessageXMD(msg, dst, count*hashToFieldLength)
+	if err != nil {
+		panic(err)
+	}
+	u := make([]*big.Int, count)
+	for i := range u {
+		u[i] = bn254Mod(new(big.Int).SetBytes(b[i*hashToFieldLength : (i+1)*hashToFieldLength]))
+	}
+	return u
+}
+
+// expandMessageXMD implements expand_message_xmd with SHA-256
+func expandMessageXMD(msg, dst []byte, length int) ([]byte, error) {
+	ell := (length + sha256.Size - 1) / sha256.Size
+	if ell > 255 || length > 65535 {
+		return nil, errors.New("expand_message_xmd: requested length is too long")
+	}
+	if len(dst) > xmdMaxDSTLength {
+		return nil, errors.New("expand_message_xmd: domain separation tag is too long")
+	}
+	dstPrime := append(append([]byte{}, dst...), byte(len(dst)))
+
+	h := sha256.New()
+	h.Write(make([]byte, sha256.BlockSize))
+	h.Write(msg)
+	h.Write([]byte{byte(length >> 8), byte(length), 0})
+	h.Write(dstPrime)
+	b0 := h.Sum(nil)
+
+	out := make([]byte, 0, ell*sha256.Size)
+	bi := make([]byte, sha256.Size)
+	for i := 1; i <= ell; i++ {
+		// b_1 = H(b_0 || 1 || DST'), b_i = H((b_0 xor b_(i-1)) || i || DST')
+		for j := range bi {
+			bi[j] ^= b0[j]
+		}
+		h.Reset()
+		h.Write(bi)
+		h.Write([]byte{byte(i)})
+		h.Write(dstPrime)
+		bi = h.Sum(bi[:0])
+		out = append(out, bi...)
+	}
+	return out[:length], nil
+}
+
+// svdwMapToG1 maps a field element onto the curve with the Shallue-van de
+// Woestijne method, following the straight-line implementation of RFC 9380
+// appendix F.1
+func svdwMapToG1(u *big.Int) *bn256.G1 {
+	tv1 := bn254Mul(bn254Mul(u, u), svdwC1)
+	tv2 := bn254Mod(new(big.Int).Add(big.NewInt(1), tv1))
+	tv1 = bn254Mod(new(big.Int).Sub(big.NewInt(1), tv1))
+	tv3 := bn254Inv0(bn254Mul(tv1, tv2))
+	tv4 := bn254Mul(bn254Mul(bn254Mul(u, tv1), tv3), svdwC3)
+
+	var x *big.Int
+	if x1 := bn254Mod(new(big.Int).Sub(svdwC2, tv4)); bn254IsSquare(svdwG(x1)) {
+		x = x1
+	} else if x2 := bn254Mod(new(big.Int).Add(svdwC2, tv4)); bn254IsSquare(svdwG(x2)) {
+		x = x2
+	} else {
+		x3 := bn254Mul(tv2, tv2)
+		x3 = bn254Mul(x3, tv3)
+		x3 = bn254Mul(x3, x3)
+		x3 = bn254Mul(x3, svdwC4)
+		x = bn254Mod(x3.Add(x3, svdwZ))
+	}
+	// One of the three candidates is always on the curve, so g(x) is a
+	// square
+	y := new(big.Int).ModSqrt(svdwG(x), bn254P)
+	if u.Bit(0) != y.Bit(0) {
+		y = bn254Mod(y.Neg(y))
+	}
+
+	b := make([]byte, blsG1Length)
+	x.FillBytes(b[:blsG1Length/2])
+	y.FillBytes(b[blsG1Length/2:])
+	p, ok := unmarshalG1(b)
+	if !ok {
+		panic("svdw: mapped point is not on the curve")
+	}
+	return p
+}
+
+// svdwG is the curve equation g(x) = x³ + b
+func svdwG(x *big.Int) *big.Int {
+	return bn254Mod(new(big.Int).Add(bn254Mul(bn254Mul(x, x), x), bn254B))
+}
+
+// svdwSqrtSgn0 returns the square root of x whose sgn0 is 0
+func svdwSqrtSgn0(x *big.Int) *big.Int {
+	y := new(big.Int).ModSqrt(x, bn254P)
+	if y.Bit(0) == 1 {
+		y = bn254Mod(y.Neg(y))
+	}
+	return y
+}
+
+func bn254Mod(x *big.Int) *big.Int {
+	return x.Mod(x, bn254P)
+}
+
+func bn254Mul(a, b *big.Int) *big.Int {
+	return bn254Mod(new(big.Int).Mul(a, b))
+}
+
+func bn254Div(a, b *big.Int) *big.Int {
+	return bn254Mul(a, new(big.Int).ModInverse(b, bn254P))
+}
+
+// bn254Inv0 returns the inverse of x, or zero if x is zero
+func bn254Inv0(x *big.Int) *big.Int {
+	if x.Sign() == 0 {
+		return new(big.Int)
+	}
+	return new(big.Int).ModInverse(x, bn254P)
+}
+
+func bn254IsSquare(x *big.Int) bool {
+	return big.Jacobi(x, bn254P) >= 0
+}
@@ -0,0 +1,76 @@
+package llo
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_expandMessageXMD(t *testing.T) {
+	// RFC 9380 appendix K.1
+	dst := []byte("QUUX-V01-CS02-with-expander-SHA256-128")
+	for _, tc := range []struct {
+		msg      string
+		length   int
+		expected string
+	}{
+		{"", 0x20, "68a985b87eb6b46952128911f2a4412bbc302a9d759667f87f7a21d803f07235"},
+		{"abc", 0x20, "d8ccab23b5985ccea865c6c97b6e5b8350e794e603b4b97902f53a8a0d605615"},
+		{"abcdef0123456789", 0x20, "eff31487c770a893cfb36f912fbfcbff40d5661771ca4b2cb4eafe524333f5c1"},
+		{"", 0x80, "af84c27ccfd45d41914fdff5df25293e221afc53d8ad2ac06d5e3e29485dadbee0d121587713a3e0dd4d5e69e93eb7cd4f5df4cd103e188cf60cb02edc3edf18eda8576c412b18ffb658e3dd6ec849469b979d444cf7b26911a08e63cf31f9dcc541708d3491184472c2c29bb749d4286b004ceb5ee6b9a7fa5b646c993f0ced"},
+	} {
+		b, err := expandMessageXMD([]byte(tc.msg), dst, tc.length)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, hex.EncodeToString(b), tc.msg)
+	}
+
+	_, err := expandMessageXMD(nil, []byte(strings.Repeat("x", 256)), 32)
+	assert.EqualError(t, err, "expand_message_xmd: domain separation tag is too long")
+	_, err = expandMessageXMD(nil, dst, 256*32)
+	assert.EqualError(t, err, "expand_message_xmd: requested length is too long")
+}
+
+func Test_hashToG1(t *testing.T) {
+	// BN254G1_XMD:SHA-256_SVDW_RO_ vectors, as used by gnark-crypto
+	dst := []byte("QUUX-V01-CS02-with-BN254G1_XMD:SHA-256_SVDW_RO_")
+	for _, tc := range []struct {
+		msg  string
+		x, y string
+	}{
+		{"", "0a976ab906170db1f9638d376514dbf8c42aef256a54bbd48521f20749e59e86", "02925ead66b9e68bfc309b014398640ab55f6619ab59bc1fab2210ad4c4d53d5"},
+		{"abc", "23f717bee89b1003957139f193e6be7da1df5f1374b26a4643b0378b5baf53d1", "04142f826b71ee574452dbc47e05bc3e1a647478403a7ba38b7b93948f4e151d"},
+	} {
+		assert.Equal(t, tc.x+tc.y, hex.EncodeToString(hashToG1([]byte(tc.msg), dst).Marshal()), tc.msg)
+	}
+
+	assert.NotEqual(t, hashToG1([]byte("msg"), blsSignatureDST).Marshal(), hashToG1([]byte("msg"), blsPossessionDST).Marshal(), "domain separation")
+}
+
+func Test_svdwMapToG1(t *testing.T) {
+	t.Run("constants", func(t *testing.T) {
+		// c3² = -g(Z)·3Z² = -12 and sgn0(c3) = 0
+		assert.Equal(t, bn254Mod(big.NewInt(-12)), bn254Mul(svdwC3, svdwC3))
+		assert.Zero(t, svdwC3.Bit(0))
+		// c4 = -4g(Z)/3Z² = -16/3
+		assert.Equal(t, bn254Mod(big.NewInt(-16)), bn254Mul(svdwC4, big.NewInt(3)))
+	})
+	t.Run("maps exceptional inputs onto the curve", func(t *testing.T) {
+		// u = 0, and u² = 1/g(Z) where the denominator of tv3 is zero
+		uInvSqrtC1 := new(big.Int).ModSqrt(new(big.Int).ModInverse(svdwC1, bn254P), bn254P)
+		for _, u := range []*big.Int{big.NewInt(0), uInvSqrtC1, bn254Mod(new(big.Int).Neg(uInvSqrtC1))} {
+			assert.NotPanics(t, func() { svdwMapToG1(u) }, u.String())
+		}
+	})
+	t.Run("preserves the sign of the input", func(t *testing.T) {
+		for i := int64(1); i < 50; i++ {
+			u := big.NewInt(i)
+			b := svdwMapToG1(u).Marshal()
+			y := new(big.Int).SetBytes(b[blsG1Length/2:])
+			assert.Equal(t, u.Bit(0), y.Bit(0), i)
+		}
+	})
+}
@@ -0,0 +1,79 @@
+//go:build byzantine
+
+package llo
+
+// This file contains test-only hooks that let a simulation inject Byzantine
+// observations from selected oracles. It is only compiled with the
+// "byzantine" build tag so that it can never end up in a production binary.
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+// ByzantineBehavior determines the observation that a faulty oracle sends to
+// recipient, given the observation an honest oracle would have sent
+type ByzantineBehavior func(codec ObservationCodec, honest Observation, recipient commontypes.OracleID) (types.Observation, error)
+
+// MalformedObservation sends raw bytes instead of an encoded observation
+func MalformedObservation(raw []byte) ByzantineBehavior {
+	return func(ObservationCodec, Observation, commontypes.OracleID) (types.Observation, error) {
+		return raw, nil
+	}
+}
+
+// ExtremeStreamValues replaces every observed stream value with sv
+func ExtremeStreamValues(sv StreamValue) ByzantineBehavior {
+	return func(codec ObservationCodec, honest Observation, _ commontypes.OracleID) (types.Observation, error) {
+		obs := honest
+		obs.StreamValues = make(StreamValues, len(honest.StreamValues))
+		for id := range honest.StreamValues {
+			obs.StreamValues[id] = sv
+		}
+		return codec.Encode(obs)
+	}
+}
+
+// Equivocate sends a different observation to each recipient
+func Equivocate(byRecipient func(honest Observation, recipient commontypes.OracleID) Observation) ByzantineBehavior {
+	return func(codec ObservationCodec, honest Observation, recipient commontypes.OracleID) (types.Observation, error) {
+		return codec.Encode(byRecipient(honest, recipient))
+	}
+}
+
+// ByzantineInjector builds the attributed observations that each oracle
+// receives in a simulated round, substituting the observations of oracles
+// with a configured behavior
+type ByzantineInjector struct {
+	Codec     ObservationCodec
+	Behaviors map[commontypes.OracleID]ByzantineBehavior
+}
+
+// AttributedObservations returns the observations that recipient receives,
+// in oracle ID order, given the honest observation of every oracle
+func (b ByzantineInjector) AttributedObservations(honest map[commontypes.OracleID]Observation, recipient commontypes.OracleID) ([]types.AttributedObservation, error) {
+	oracleIDs := make([]commontypes.OracleID, 0, len(honest))
+	for id := range honest {
+		oracleIDs = append(oracleIDs, id)
+	}
+	sort.Slice(oracleIDs, func(i, j int) bool { return oracleIDs[i] < oracleIDs[j] })
+
+	aos := make([]types.AttributedObservation, 0, len(honest))
+	for _, id := range oracleIDs {
+		var obs types.Observation
+		var err error
+		if behavior, ok := b.Behaviors[id]; ok {
+			obs, err = behavior(b.Codec, honest[id], recipient)
+		} else {
+			obs, err = b.Codec.Encode(honest[id])
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate observation of oracle %d for oracle %d: %w", id, recipient, err)
+		}
+		aos = append(aos, types.AttributedObservation{Observation: obs, Observer: id})
+	}
+	return aos, nil
+}
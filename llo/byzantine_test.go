@@ -0,0 +1,299 @@
+//go:build byzantine
+
+package llo
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+)
+
+// These tests codify the threat model of the Outcome stage: with at most f
+// faulty oracles out of n = 3f+1, whatever the faulty oracles send, the
+// Outcome computed by every honest oracle must
+//   - contain a timestamp and aggregates within the range of honest values,
+//   - only apply channel changes and retirement if an honest oracle voted for
+//     them.
+
+var byzantineChannelDefinitions = llotypes.ChannelDefinitions{
+	1: {
+		ReportFormat: llotypes.ReportFormatJSON,
+		Streams:      []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}, {StreamID: 2, Aggregator: llotypes.AggregatorQuote}, {StreamID: 3, Aggregator: llotypes.AggregatorMode}},
+	},
+}
+
+const byzantineBaseTimestamp = int64(1726670490 * time.Second)
+
+// honestObservations returns observations from n oracles whose values are all
+// slightly different, as they would be in practice
+func honestObservations(n int) map[commontypes.OracleID]Observation {
+	obs := make(map[commontypes.OracleID]Observation, n)
+	for i := 0; i < n; i++ {
+		d := decimal.NewFromInt(int64(i))
+		obs[commontypes.OracleID(i)] = Observation{
+			UnixTimestampNanoseconds: byzantineBaseTimestamp + int64(i)*int64(time.Millisecond),
+			StreamValues: StreamValues{
+				1: ToDecimal(decimal.NewFromInt(100).Add(d)),
+				2: &Quote{Bid: decimal.NewFromInt(99).Add(d), Benchmark: decimal.NewFromInt(100).Add(d), Ask: decimal.NewFromInt(101).Add(d)},
+				3: ToDecimal(decimal.NewFromInt(7)),
+			},
+		}
+	}
+	return obs
+}
+
+type byzantineScenario struct {
+	f         int
+	behaviors map[commontypes.OracleID]ByzantineBehavior
+}
+
+// run computes the Outcome as seen by every honest oracle and checks that
+// each one is safe
+func (s byzantineScenario) run(t *testing.T) []Outcome {
+	t.Helper()
+	ctx := tests.Context(t)
+	n := 3*s.f + 1
+	p := &Plugin{
+		Config:           Config{true},
+		F:                s.f,
+		OutcomeCodec:     protoOutcomeCodec{},
+		Logger:           logger.Test(t),
+		ObservationCodec: protoObservationCodec{},
+	}
+	previousOutcome, err := p.OutcomeCodec.Encode(Outcome{
+		LifeCycleStage:                   LifeCycleStageProduction,
+		ObservationsTimestampNanoseconds: byzantineBaseTimestamp - int64(time.Second),
+		ChannelDefinitions:               byzantineChannelDefinitions,
+		ValidAfterSeconds:                map[llotypes.ChannelID]uint32{1: uint32(byzantineBaseTimestamp/int64(time.Second)) - 2},
+	})
+	require.NoError(t, err)
+
+	honest := honestObservations(n)
+	injector := ByzantineInjector{Codec: p.ObservationCodec, Behaviors: s.behaviors}
+	var outcomes []Outcome
+	for recipient := range honest {
+		if _, faulty := s.behaviors[recipient]; faulty {
+			continue
+		}
+		aos, err := injector.AttributedObservations(honest, recipient)
+		require.NoError(t, err)
+
+		encoded, err := p.Outcome(ctx, ocr3types.OutcomeContext{SeqNr: 3, PreviousOutcome: previousOutcome}, types.Query{}, aos)
+		require.NoErrorf(t, err, "honest oracle %d must produce an outcome", recipient)
+		outcome, err := p.OutcomeCodec.Decode(encoded)
+		require.NoError(t, err)
+		assertOutcomeSafe(t, outcome, honest, s.behaviors)
+		outcomes = append(outcomes, outcome)
+	}
+	return outcomes
+}
+
+func assertOutcomeSafe(t *testing.T, outcome Outcome, observations map[commontypes.OracleID]Observation, faulty map[commontypes.OracleID]ByzantineBehavior) {
+	t.Helper()
+	var honest []Observation
+	for id, obs := range observations {
+		if _, ok := faulty[id]; !ok {
+			honest = append(honest, obs)
+		}
+	}
+
+	minTs, maxTs := honest[0].UnixTimestampNanoseconds, honest[0].UnixTimestampNanoseconds
+	for _, obs := range honest {
+		minTs, maxTs = min(minTs, obs.UnixTimestampNanoseconds), max(maxTs, obs.UnixTimestampNanoseconds)
+	}
+	assert.GreaterOrEqual(t, outcome.ObservationsTimestampNanoseconds, minTs, "timestamp below honest range")
+	assert.LessOrEqual(t, outcome.ObservationsTimestampNanoseconds, maxTs, "timestamp above honest range")
+
+	assert.Equal(t, LifeCycleStageProduction, outcome.LifeCycleStage, "faulty oracles alone cannot retire the instance")
+	assert.Equal(t, byzantineChannelDefinitions, outcome.ChannelDefinitions, "faulty oracles alone cannot change channels")
+
+	inRange := func(name string, v decimal.Decimal, get func(Observation) decimal.Decimal) {
+		lo, hi := get(honest[0]), get(honest[0])
+		for _, obs := range honest {
+			lo, hi = decimal.Min(lo, get(obs)), decimal.Max(hi, get(obs))
+		}
+		assert.Truef(t, v.GreaterThanOrEqual(lo) && v.LessThanOrEqual(hi), "%s %s outside honest range [%s, %s]", name, v, lo, hi)
+	}
+	require.NotNil(t, outcome.StreamAggregates[1][llotypes.AggregatorMedian], "missing median")
+	median := outcome.StreamAggregates[1][llotypes.AggregatorMedian].(*Decimal).Decimal()
+	inRange("median", median, func(o Observation) decimal.Decimal { return o.StreamValues[1].(*Decimal).Decimal() })
+
+	require.NotNil(t, outcome.StreamAggregates[2][llotypes.AggregatorQuote], "missing quote")
+	q := outcome.StreamAggregates[2][llotypes.AggregatorQuote].(*Quote)
+	assert.Truef(t, q.IsValid(), "aggregated quote violates bid <= benchmark <= ask: %v", q)
+	inRange("bid", q.Bid, func(o Observation) decimal.Decimal { return o.StreamValues[2].(*Quote).Bid })
+	inRange("benchmark", q.Benchmark, func(o Observation) decimal.Decimal { return o.StreamValues[2].(*Quote).Benchmark })
+	inRange("ask", q.Ask, func(o Observation) decimal.Decimal { return o.StreamValues[2].(*Quote).Ask })
+
+	require.NotNil(t, outcome.StreamAggregates[3][llotypes.AggregatorMode], "missing mode")
+	assert.Equal(t, "7", outcome.StreamAggregates[3][llotypes.AggregatorMode].(*Decimal).Decimal().String())
+}
+
+// faultyOracles assigns behavior to the last f oracles
+func faultyOracles(f int, behavior ByzantineBehavior) map[commontypes.OracleID]ByzantineBehavior {
+	n := 3*f + 1
+	m := make(map[commontypes.OracleID]ByzantineBehavior, f)
+	for i := n - f; i < n; i++ {
+		m[commontypes.OracleID(i)] = behavior
+	}
+	return m
+}
+
+func Test_Outcome_Byzantine(t *testing.T) {
+	huge := decimal.RequireFromString("1e40")
+	behaviors := map[string]ByzantineBehavior{
+		"malformed":     MalformedObservation([]byte("not an observation")),
+		"empty":         MalformedObservation(nil),
+		"extreme high":  ExtremeStreamValues(ToDecimal(huge)),
+		"extreme low":   ExtremeStreamValues(ToDecimal(huge.Neg())),
+		"extreme quote": ExtremeStreamValues(&Quote{Bid: huge.Neg(), Benchmark: decimal.Zero, Ask: huge}),
+		"invalid quote": ExtremeStreamValues(&Quote{Bid: huge, Benchmark: decimal.Zero, Ask: huge.Neg()}),
+		"extreme timestamps": Equivocate(func(honest Observation, recipient commontypes.OracleID) Observation {
+			if recipient%2 == 0 {
+				honest.UnixTimestampNanoseconds = 0
+			} else {
+				honest.UnixTimestampNanoseconds = 1<<63 - 1
+			}
+			return honest
+		}),
+		"equivocating values": Equivocate(func(honest Observation, recipient commontypes.OracleID) Observation {
+			v := huge
+			if recipient%2 == 0 {
+				v = huge.Neg()
+			}
+			honest.StreamValues = StreamValues{1: ToDecimal(v), 2: &Quote{Bid: v, Benchmark: v, Ask: v}, 3: ToDecimal(v)}
+			return honest
+		}),
+		"votes to change channels and retire": Equivocate(func(honest Observation, _ commontypes.OracleID) Observation {
+			honest.ShouldRetire = true
+			honest.RemoveChannelIDs = map[llotypes.ChannelID]struct{}{1: {}}
+			honest.UpdateChannelDefinitions = llotypes.ChannelDefinitions{
+				2: {ReportFormat: llotypes.ReportFormatJSON, Streams: []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}}},
+			}
+			return honest
+		}),
+	}
+
+	for _, f := range []int{1, 2, 3} {
+		for name, behavior := range behaviors {
+			t.Run(fmt.Sprintf("f=%d/%s", f, name), func(t *testing.T) {
+				outcomes := byzantineScenario{f, faultyOracles(f, behavior)}.run(t)
+				assert.Len(t, outcomes, 2*f+1)
+			})
+		}
+	}
+
+	t.Run("mixed behaviors", func(t *testing.T) {
+		f := 3
+		byzantineScenario{f, map[commontypes.OracleID]ByzantineBehavior{
+			0: behaviors["malformed"],
+			4: behaviors["extreme high"],
+			9: behaviors["equivocating values"],
+		}}.run(t)
+	})
+}
+
+func Test_ByzantineInjector(t *testing.T) {
+	codec := protoObservationCodec{}
+	honest := honestObservations(4)
+	injector := ByzantineInjector{Codec: codec, Behaviors: map[commontypes.OracleID]ByzantineBehavior{
+		1: MalformedObservation([]byte{0xff}),
+		3: Equivocate(func(honest Observation, recipient commontypes.OracleID) Observation {
+			honest.StreamValues = StreamValues{1: ToDecimal(decimal.NewFromInt(int64(recipient)))}
+			return honest
+		}),
+	}}
+
+	aos, err := injector.AttributedObservations(honest, 2)
+	require.NoError(t, err)
+	require.Len(t, aos, 4)
+	for i, ao := range aos {
+		assert.Equal(t, commontypes.OracleID(i), ao.Observer)
+	}
+	assert.Equal(t, types.Observation{0xff}, aos[1].Observation)
+
+	obs, err := codec.Decode(aos[0].Observation)
+	require.NoError(t, err)
+	assert.Equal(t, honest[0].StreamValues, obs.StreamValues)
+
+	obs, err = codec.Decode(aos[3].Observation)
+	require.NoError(t, err)
+	assert.Equal(t, "2", obs.StreamValues[1].(*Decimal).Decimal().String())
+	aos, err = injector.AttributedObservations(honest, 0)
+	require.NoError(t, err)
+	obs, err = codec.Decode(aos[3].Observation)
+	require.NoError(t, err)
+	assert.Equal(t, "0", obs.StreamValues[1].(*Decimal).Decimal().String(), "equivocating oracle sends a different observation to each recipient")
+}
+
+// Test_Outcome_Byzantine_RepeatedVotes checks that a faulty oracle gains
+// nothing by having its votes counted more than once, whether its attributed
+// observation appears repeatedly or it votes for several definitions of the
+// same channel
+func Test_Outcome_Byzantine_RepeatedVotes(t *testing.T) {
+	ctx := tests.Context(t)
+	for _, f := range []int{1, 2, 3} {
+		t.Run(fmt.Sprintf("f=%d", f), func(t *testing.T) {
+			n := 3*f + 1
+			p := &Plugin{
+				Config:           Config{true},
+				F:                f,
+				OutcomeCodec:     protoOutcomeCodec{},
+				Logger:           logger.Test(t),
+				ObservationCodec: protoObservationCodec{},
+			}
+			previousOutcome, err := p.OutcomeCodec.Encode(Outcome{
+				LifeCycleStage:                   LifeCycleStageProduction,
+				ObservationsTimestampNanoseconds: byzantineBaseTimestamp - int64(time.Second),
+				ChannelDefinitions:               byzantineChannelDefinitions,
+				ValidAfterSeconds:                map[llotypes.ChannelID]uint32{1: uint32(byzantineBaseTimestamp/int64(time.Second)) - 2},
+			})
+			require.NoError(t, err)
+
+			honest := honestObservations(n)
+			faulty := commontypes.OracleID(n - 1)
+			var aos []types.AttributedObservation
+			for i := 0; i < n-1; i++ {
+				encoded, err := p.ObservationCodec.Encode(honest[commontypes.OracleID(i)])
+				require.NoError(t, err)
+				aos = append(aos, types.AttributedObservation{Observation: encoded, Observer: commontypes.OracleID(i)})
+			}
+			// The faulty oracle's observation appears f+1 times. Every copy
+			// votes for the same definition of channel 2 and for a different
+			// definition of channel 3.
+			for i := 0; i <= f; i++ {
+				encoded, err := p.ObservationCodec.Encode(Observation{
+					UnixTimestampNanoseconds: byzantineBaseTimestamp,
+					ShouldRetire:             true,
+					RemoveChannelIDs:         map[llotypes.ChannelID]struct{}{1: {}},
+					UpdateChannelDefinitions: llotypes.ChannelDefinitions{
+						2: {ReportFormat: llotypes.ReportFormatJSON, Streams: []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}}},
+						3: {ReportFormat: llotypes.ReportFormatJSON, Streams: []llotypes.Stream{{StreamID: llotypes.StreamID(i + 1), Aggregator: llotypes.AggregatorMedian}}},
+					},
+				})
+				require.NoError(t, err)
+				aos = append(aos, types.AttributedObservation{Observation: encoded, Observer: faulty})
+			}
+
+			encoded, err := p.Outcome(ctx, ocr3types.OutcomeContext{SeqNr: 3, PreviousOutcome: previousOutcome}, types.Query{}, aos)
+			require.NoError(t, err)
+			outcome, err := p.OutcomeCodec.Decode(encoded)
+			require.NoError(t, err)
+
+			assert.Equal(t, LifeCycleStageProduction, outcome.LifeCycleStage, "repeated votes cannot retire the instance")
+			assert.Equal(t, byzantineChannelDefinitions, outcome.ChannelDefinitions, "repeated votes cannot change channels")
+		})
+	}
+}
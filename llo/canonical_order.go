@@ -0,0 +1,51 @@
+package llo
+
+import (
+	"bytes"
+	"cmp"
+	"slices"
+)
+
+// Go randomizes the iteration order of maps. Every oracle must compute the
+// same Outcome from the same observations, so consensus-critical code should
+// iterate maps in the canonical order given by these helpers, even where the
+// current logic happens not to depend on the order.
+
+// sortedKeys returns the keys of m in ascending order
+func sortedKeys[M ~map[K]V, K cmp.Ordered, V any](m M) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// channelDefinitionUpdate is a proposed channel definition together with the
+// number of oracles that voted for it
+type channelDefinitionUpdate struct {
+	ChannelHash
+	ChannelDefinitionWithID
+	Votes int
+}
+
+// sortedChannelDefinitionUpdates returns the proposed channel definitions
+// in ascending order of channel ID, then votes, then hash. Applying them in
+// this order means that if several definitions for the same channel have
+// enough votes, the one with the most votes wins, with ties broken by hash.
+func sortedChannelDefinitionUpdates(definitionsByHash map[ChannelHash]ChannelDefinitionWithID, votesByHash map[ChannelHash]int) []channelDefinitionUpdate {
+	updates := make([]channelDefinitionUpdate, 0, len(definitionsByHash))
+	for hash, dfnWithID := range definitionsByHash {
+		updates = append(updates, channelDefinitionUpdate{hash, dfnWithID, votesByHash[hash]})
+	}
+	slices.SortFunc(updates, func(a, b channelDefinitionUpdate) int {
+		if c := cmp.Compare(a.ChannelID, b.ChannelID); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.Votes, b.Votes); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.ChannelHash[:], b.ChannelHash[:])
+	})
+	return updates
+}
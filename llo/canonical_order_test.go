@@ -0,0 +1,164 @@
+package llo
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+)
+
+func Test_sortedKeys(t *testing.T) {
+	assert.Empty(t, sortedKeys(map[llotypes.ChannelID]int(nil)))
+	assert.Equal(t, []llotypes.ChannelID{1, 2, 3}, sortedKeys(map[llotypes.ChannelID]int{3: 0, 1: 0, 2: 0}))
+	assert.Equal(t, []llotypes.ChannelID{4, 5}, sortedKeys(llotypes.ChannelDefinitions{5: {}, 4: {}}))
+}
+
+func Test_sortedChannelDefinitionUpdates(t *testing.T) {
+	a := ChannelDefinitionWithID{llotypes.ChannelDefinition{ReportFormat: 1}, 2}
+	b := ChannelDefinitionWithID{llotypes.ChannelDefinition{ReportFormat: 2}, 2}
+	c := ChannelDefinitionWithID{llotypes.ChannelDefinition{ReportFormat: 3}, 2}
+	d := ChannelDefinitionWithID{llotypes.ChannelDefinition{ReportFormat: 1}, 1}
+	ha, hb, hc, hd := MakeChannelHash(a), MakeChannelHash(b), MakeChannelHash(c), MakeChannelHash(d)
+	if bytes.Compare(ha[:], hb[:]) > 0 {
+		a, b, ha, hb = b, a, hb, ha
+	}
+
+	updates := sortedChannelDefinitionUpdates(
+		map[ChannelHash]ChannelDefinitionWithID{ha: a, hb: b, hc: c, hd: d},
+		map[ChannelHash]int{ha: 2, hb: 2, hc: 3, hd: 1},
+	)
+	assert.Equal(t, []channelDefinitionUpdate{
+		{hd, d, 1},
+		// a and b tie on votes
+		{ha, a, 2},
+		{hb, b, 2},
+		{hc, c, 3},
+	}, updates)
+}
+
+// Test_Outcome_Deterministic checks that oracles agree on the outcome even
+// though Go randomizes map iteration and each oracle decodes observations
+// into maps with different layouts
+func Test_Outcome_Deterministic(t *testing.T) {
+	ctx := tests.Context(t)
+	p := &Plugin{
+		Config:           Config{false},
+		OutcomeCodec:     protoOutcomeCodec{},
+		Logger:           logger.Nop(),
+		ObservationCodec: protoObservationCodec{},
+		F:                1,
+	}
+	r := rand.New(rand.NewPCG(1, 2))
+
+	dfn := func(rf llotypes.ReportFormat, sids ...llotypes.StreamID) llotypes.ChannelDefinition {
+		cd := llotypes.ChannelDefinition{ReportFormat: rf}
+		for _, sid := range sids {
+			cd.Streams = append(cd.Streams, llotypes.Stream{StreamID: sid, Aggregator: llotypes.AggregatorMedian})
+		}
+		return cd
+	}
+	previous := Outcome{
+		LifeCycleStage:                   LifeCycleStageProduction,
+		ObservationsTimestampNanoseconds: int64(100 * time.Second),
+		ChannelDefinitions:               llotypes.ChannelDefinitions{},
+		ValidAfterSeconds:                map[llotypes.ChannelID]uint32{},
+	}
+	for cid := llotypes.ChannelID(1); cid <= 20; cid++ {
+		previous.ChannelDefinitions[cid] = dfn(llotypes.ReportFormatJSON, llotypes.StreamID(cid), llotypes.StreamID(cid+1))
+		previous.ValidAfterSeconds[cid] = uint32(90 + cid%2*20)
+	}
+	previousOutcome, err := p.OutcomeCodec.Encode(previous)
+	require.NoError(t, err)
+
+	// Five oracles; two competing definitions for channel 42 tie on votes,
+	// while channel 43 has a clear winner
+	const n = 5
+	observations := make([]Observation, n)
+	for i := range observations {
+		obs := Observation{
+			UnixTimestampNanoseconds: int64(200*time.Second) + r.Int64N(int64(time.Second)),
+			RemoveChannelIDs:         map[llotypes.ChannelID]struct{}{3: {}, 4: {}, llotypes.ChannelID(10 + i): {}},
+			UpdateChannelDefinitions: llotypes.ChannelDefinitions{
+				42: dfn(llotypes.ReportFormatJSON, llotypes.StreamID(100+i%2)),
+				43: dfn(llotypes.ReportFormatJSON, llotypes.StreamID(200+min(i%3, 1))),
+			},
+			StreamValues: StreamValues{},
+		}
+		if i == n-1 {
+			delete(obs.UpdateChannelDefinitions, 42)
+		}
+		for sid := llotypes.StreamID(1); sid <= 21; sid++ {
+			obs.StreamValues[sid] = ToDecimal(decimal.New(r.Int64N(1000), -2))
+		}
+		for _, sid := range []llotypes.StreamID{100, 101, 200, 201} {
+			obs.StreamValues[sid] = ToDecimal(decimal.New(r.Int64N(1000), -2))
+		}
+		observations[i] = obs
+	}
+
+	// Rebuild maps with a random insertion order, giving them different
+	// internal layouts
+	shuffledStreamValues := func(m StreamValues) StreamValues {
+		keys := sortedKeys(m)
+		r.Shuffle(len(keys), func(i, j int) { keys[i], keys[j] = keys[j], keys[i] })
+		out := make(StreamValues, len(m))
+		for _, k := range keys {
+			out[k] = m[k]
+		}
+		return out
+	}
+	shuffledChannelIDs := func(m map[llotypes.ChannelID]struct{}) map[llotypes.ChannelID]struct{} {
+		keys := sortedKeys(m)
+		r.Shuffle(len(keys), func(i, j int) { keys[i], keys[j] = keys[j], keys[i] })
+		out := make(map[llotypes.ChannelID]struct{}, len(m))
+		for _, k := range keys {
+			out[k] = struct{}{}
+		}
+		return out
+	}
+
+	var expected ocr3types.Outcome
+	for run := 0; run < 50; run++ {
+		aos := make([]types.AttributedObservation, n)
+		for i, obs := range observations {
+			obs.StreamValues = shuffledStreamValues(obs.StreamValues)
+			obs.RemoveChannelIDs = shuffledChannelIDs(obs.RemoveChannelIDs)
+			encoded, err := p.ObservationCodec.Encode(obs)
+			require.NoError(t, err)
+			aos[i] = types.AttributedObservation{Observation: encoded, Observer: commontypes.OracleID(i)}
+		}
+		outcome, err := p.Outcome(ctx, ocr3types.OutcomeContext{PreviousOutcome: previousOutcome, SeqNr: 2}, types.Query{}, aos)
+		require.NoError(t, err)
+		if run == 0 {
+			expected = outcome
+			continue
+		}
+		require.Equal(t, expected, outcome, fmt.Sprintf("outcome diverged on run %d", run))
+	}
+
+	decoded, err := p.OutcomeCodec.Decode(expected)
+	require.NoError(t, err)
+	assert.NotContains(t, decoded.ChannelDefinitions, llotypes.ChannelID(3))
+	assert.NotContains(t, decoded.ChannelDefinitions, llotypes.ChannelID(4))
+	assert.Contains(t, decoded.ChannelDefinitions, llotypes.ChannelID(10), "removal needs f+1 votes")
+	assert.Equal(t, dfn(llotypes.ReportFormatJSON, 201), decoded.ChannelDefinitions[43], "definition with most votes wins")
+	h100, h101 := MakeChannelHash(ChannelDefinitionWithID{dfn(llotypes.ReportFormatJSON, 100), 42}), MakeChannelHash(ChannelDefinitionWithID{dfn(llotypes.ReportFormatJSON, 101), 42})
+	if bytes.Compare(h100[:], h101[:]) > 0 {
+		assert.Equal(t, dfn(llotypes.ReportFormatJSON, 100), decoded.ChannelDefinitions[42], "ties are broken by hash")
+	} else {
+		assert.Equal(t, dfn(llotypes.ReportFormatJSON, 101), decoded.ChannelDefinitions[42], "ties are broken by hash")
+	}
+}
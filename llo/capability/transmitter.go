@@ -0,0 +1,84 @@
+package capability
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+
+	"github.com/smartcontractkit/chainlink-data-streams/llo"
+)
+
+// ReportDecoder decodes the channel reports of a report format
+type ReportDecoder interface {
+	Decode([]byte) (llo.Report, error)
+}
+
+var _ llo.Transmitter = (*Transmitter)(nil)
+
+// Transmitter is an LLO transmitter that hands attested reports to a
+// TriggerService instead of sending them to a server. It can be combined
+// with other transmitters to serve workflows alongside Mercury servers.
+//
+// Reports are decoded to find their channel, so only report formats with a
+// ReportDecoder are delivered; others, including retirement reports, are
+// ignored. Specimen reports are ignored too.
+type Transmitter struct {
+	lggr        logger.Logger
+	trigger     *TriggerService
+	decoders    map[llotypes.ReportFormat]ReportDecoder
+	fromAccount types.Account
+}
+
+// NewTransmitter creates a transmitter. If decoders is nil, only JSON reports
+// are delivered.
+func NewTransmitter(lggr logger.Logger, trigger *TriggerService, fromAccount types.Account, decoders map[llotypes.ReportFormat]ReportDecoder) *Transmitter {
+	if decoders == nil {
+		decoders = map[llotypes.ReportFormat]ReportDecoder{
+			llotypes.ReportFormatJSON: llo.JSONReportCodec{},
+		}
+	}
+	return &Transmitter{logger.Named(lggr, "LLOCapabilityTransmitter"), trigger, decoders, fromAccount}
+}
+
+func (t *Transmitter) Transmit(ctx context.Context, digest types.ConfigDigest, seqNr uint64, rwi ocr3types.ReportWithInfo[llotypes.ReportInfo], sigs []types.AttributedOnchainSignature) error {
+	decoder, exists := t.decoders[rwi.Info.ReportFormat]
+	if !exists {
+		t.lggr.Debugw("Ignoring report without decoder", "reportFormat", rwi.Info.ReportFormat, "seqNr", seqNr)
+		return nil
+	}
+	decoded, err := decoder.Decode(rwi.Report)
+	if err != nil {
+		return fmt.Errorf("failed to decode %s report: %w", rwi.Info.ReportFormat, err)
+	}
+	if decoded.Specimen {
+		// specimen reports are for testing only and must not trigger
+		// workflows
+		return nil
+	}
+
+	r := Report{
+		ConfigDigest:                digest[:],
+		SeqNr:                       seqNr,
+		ChannelID:                   decoded.ChannelID,
+		ReportFormat:                rwi.Info.ReportFormat.String(),
+		ValidAfterSeconds:           decoded.ValidAfterSeconds,
+		ObservationTimestampSeconds: decoded.ObservationTimestampSeconds,
+		Report:                      rwi.Report,
+		Signatures:                  make([][]byte, len(sigs)),
+		Signers:                     make([]uint32, len(sigs)),
+	}
+	for i, sig := range sigs {
+		r.Signatures[i] = sig.Signature
+		r.Signers[i] = uint32(sig.Signer)
+	}
+	return t.trigger.ProcessReport(r)
+}
+
+func (t *Transmitter) FromAccount(context.Context) (types.Account, error) {
+	return t.fromAccount, nil
+}
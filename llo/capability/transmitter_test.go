@@ -0,0 +1,100 @@
+package capability
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink-data-streams/llo"
+)
+
+func Test_Transmitter(t *testing.T) {
+	ctx := tests.Context(t)
+	s, err := NewTriggerService(logger.Test(t), "", "")
+	require.NoError(t, err)
+	require.NoError(t, s.Start(ctx))
+	t.Cleanup(func() { assert.NoError(t, s.Close()) })
+	ch, err := s.RegisterTrigger(ctx, newRegistrationRequest(t, "1", map[string]any{"channelIDs": []uint32{1}}))
+	require.NoError(t, err)
+
+	tr := NewTransmitter(logger.Test(t), s, "from", nil)
+	digest := types.ConfigDigest{1}
+	encode := func(r llo.Report) ocr3types.ReportWithInfo[llotypes.ReportInfo] {
+		encoded, err2 := llo.JSONReportCodec{}.Encode(ctx, r, llotypes.ChannelDefinition{})
+		require.NoError(t, err2)
+		return ocr3types.ReportWithInfo[llotypes.ReportInfo]{
+			Report: encoded,
+			Info:   llotypes.ReportInfo{LifeCycleStage: llo.LifeCycleStageProduction, ReportFormat: llotypes.ReportFormatJSON},
+		}
+	}
+	report := llo.Report{
+		ConfigDigest:                digest,
+		SeqNr:                       2,
+		ChannelID:                   1,
+		ValidAfterSeconds:           3,
+		ObservationTimestampSeconds: 4,
+		Values:                      []llo.StreamValue{llo.ToDecimal(decimal.NewFromInt(5))},
+	}
+	sigs := []types.AttributedOnchainSignature{
+		{Signature: []byte{6}, Signer: commontypes.OracleID(0)},
+		{Signature: []byte{7}, Signer: commontypes.OracleID(2)},
+	}
+
+	account, err := tr.FromAccount(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, types.Account("from"), account)
+
+	t.Run("delivers decoded reports to the trigger", func(t *testing.T) {
+		rwi := encode(report)
+		require.NoError(t, tr.Transmit(ctx, digest, 2, rwi, sigs))
+		require.Len(t, ch, 1)
+		resp := <-ch
+		var event TriggerEvent
+		require.NoError(t, resp.Event.Outputs.UnwrapTo(&event))
+		assert.Equal(t, TriggerEvent{Payload: []Report{{
+			ConfigDigest:                digest[:],
+			SeqNr:                       2,
+			ChannelID:                   1,
+			ReportFormat:                "json",
+			ValidAfterSeconds:           3,
+			ObservationTimestampSeconds: 4,
+			Report:                      rwi.Report,
+			Signatures:                  [][]byte{{6}, {7}},
+			Signers:                     []uint32{0, 2},
+		}}}, event)
+	})
+
+	t.Run("ignores specimen reports", func(t *testing.T) {
+		specimen := report
+		specimen.Specimen = true
+		require.NoError(t, tr.Transmit(ctx, digest, 2, encode(specimen), sigs))
+		assert.Empty(t, ch)
+	})
+
+	t.Run("ignores report formats without decoder", func(t *testing.T) {
+		rwi := ocr3types.ReportWithInfo[llotypes.ReportInfo]{
+			Report: []byte(`{"ValidAfterSeconds":null}`),
+			Info:   llotypes.ReportInfo{LifeCycleStage: llo.LifeCycleStageRetired, ReportFormat: llotypes.ReportFormatRetirement},
+		}
+		require.NoError(t, tr.Transmit(ctx, digest, 2, rwi, sigs))
+		assert.Empty(t, ch)
+	})
+
+	t.Run("errors on invalid reports", func(t *testing.T) {
+		rwi := ocr3types.ReportWithInfo[llotypes.ReportInfo]{
+			Report: []byte("foo"),
+			Info:   llotypes.ReportInfo{ReportFormat: llotypes.ReportFormatJSON},
+		}
+		err := tr.Transmit(ctx, digest, 2, rwi, sigs)
+		assert.ErrorContains(t, err, "failed to decode json report")
+	})
+}
@@ -0,0 +1,213 @@
+// Package capability exposes LLO reports to workflows as a chainlink-common
+// trigger capability. Attested channel reports are fed into a
+// TriggerService, usually by a Transmitter, and pushed as trigger events to
+// the workflows that subscribed to their channels.
+package capability
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities"
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+)
+
+const (
+	DefaultCapabilityName    = "llo-trigger"
+	DefaultCapabilityVersion = "1.0.0"
+
+	// subscriberBufferSize is the number of events buffered per subscriber
+	// before events are dropped
+	subscriberBufferSize = 1000
+)
+
+// TriggerConfig is the config of a workflow's trigger registration
+type TriggerConfig struct {
+	// ChannelIDs are the channels whose reports trigger the workflow
+	ChannelIDs []llotypes.ChannelID `json:"channelIDs"`
+}
+
+func (c TriggerConfig) validate() error {
+	if len(c.ChannelIDs) == 0 {
+		return errors.New("channelIDs must not be empty")
+	}
+	seen := make(map[llotypes.ChannelID]struct{}, len(c.ChannelIDs))
+	for _, cid := range c.ChannelIDs {
+		if _, exists := seen[cid]; exists {
+			return fmt.Errorf("duplicate channelID: %d", cid)
+		}
+		seen[cid] = struct{}{}
+	}
+	return nil
+}
+
+// Report is an attested channel report, as delivered to workflows
+type Report struct {
+	ConfigDigest []byte
+	SeqNr        uint64
+	ChannelID    llotypes.ChannelID
+	ReportFormat string
+	// Fields below are decoded from Report
+	ValidAfterSeconds           uint32
+	ObservationTimestampSeconds uint32
+	// Report is the encoded report that was signed
+	Report []byte
+	// Signatures and the oracle IDs of their Signers, in the same order
+	Signatures [][]byte
+	Signers    []uint32
+}
+
+// TriggerEvent is the output of the trigger
+type TriggerEvent struct {
+	Payload []Report
+}
+
+var _ capabilities.TriggerCapability = (*TriggerService)(nil)
+var _ services.Service = (*TriggerService)(nil)
+
+// TriggerService is a trigger capability that sends every report of the
+// subscribed channels to the workflow as soon as it is processed
+type TriggerService struct {
+	services.StateMachine
+	capabilities.CapabilityInfo
+
+	lggr logger.Logger
+
+	mu          sync.Mutex
+	subscribers map[string]*subscriber
+}
+
+type subscriber struct {
+	ch         chan capabilities.TriggerResponse
+	workflowID string
+	channelIDs map[llotypes.ChannelID]struct{}
+}
+
+// NewTriggerService creates a trigger service. An empty name or version
+// selects the default.
+func NewTriggerService(lggr logger.Logger, name, version string) (*TriggerService, error) {
+	if name == "" {
+		name = DefaultCapabilityName
+	}
+	if version == "" {
+		version = DefaultCapabilityVersion
+	}
+	info, err := capabilities.NewCapabilityInfo(name+"@"+version, capabilities.CapabilityTypeTrigger, "LLO Streams Trigger")
+	if err != nil {
+		return nil, err
+	}
+	return &TriggerService{
+		CapabilityInfo: info,
+		lggr:           logger.Named(lggr, "LLOTriggerService"),
+		subscribers:    make(map[string]*subscriber),
+	}, nil
+}
+
+func (s *TriggerService) Name() string { return s.lggr.Name() }
+
+func (s *TriggerService) Start(context.Context) error {
+	return s.StartOnce("LLOTriggerService", func() error { return nil })
+}
+
+// Close unregisters all triggers, closing their channels
+func (s *TriggerService) Close() error {
+	return s.StopOnce("LLOTriggerService", func() error {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for id, sub := range s.subscribers {
+			close(sub.ch)
+			delete(s.subscribers, id)
+		}
+		return nil
+	})
+}
+
+func (s *TriggerService) HealthReport() map[string]error {
+	return map[string]error{s.Name(): s.Healthy()}
+}
+
+func (s *TriggerService) RegisterTrigger(ctx context.Context, req capabilities.TriggerRegistrationRequest) (<-chan capabilities.TriggerResponse, error) {
+	cfg, err := ValidateConfig(req.Config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.subscribers[req.TriggerID]; exists {
+		return nil, fmt.Errorf("triggerID %s already registered", req.TriggerID)
+	}
+	sub := &subscriber{
+		ch:         make(chan capabilities.TriggerResponse, subscriberBufferSize),
+		workflowID: req.Metadata.WorkflowID,
+		channelIDs: make(map[llotypes.ChannelID]struct{}, len(cfg.ChannelIDs)),
+	}
+	for _, cid := range cfg.ChannelIDs {
+		sub.channelIDs[cid] = struct{}{}
+	}
+	s.subscribers[req.TriggerID] = sub
+	s.lggr.Infow("Registered trigger", "triggerID", req.TriggerID, "workflowID", sub.workflowID, "channelIDs", cfg.ChannelIDs)
+	return sub.ch, nil
+}
+
+func (s *TriggerService) UnregisterTrigger(ctx context.Context, req capabilities.TriggerRegistrationRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sub, exists := s.subscribers[req.TriggerID]
+	if !exists {
+		return fmt.Errorf("triggerID %s not registered", req.TriggerID)
+	}
+	close(sub.ch)
+	delete(s.subscribers, req.TriggerID)
+	s.lggr.Infow("Unregistered trigger", "triggerID", req.TriggerID, "workflowID", sub.workflowID)
+	return nil
+}
+
+// ValidateConfig decodes and validates the config of a trigger registration
+func ValidateConfig(config *values.Map) (TriggerConfig, error) {
+	var cfg TriggerConfig
+	if config == nil {
+		return cfg, errors.New("config is required")
+	}
+	if err := config.UnwrapTo(&cfg); err != nil {
+		return cfg, err
+	}
+	return cfg, cfg.validate()
+}
+
+// ProcessReport sends the report to every workflow subscribed to its
+// channel. Subscribers that are not keeping up miss the event.
+func (s *TriggerService) ProcessReport(r Report) error {
+	eventID := fmt.Sprintf("llo_%s_%d_%d", hex.EncodeToString(r.ConfigDigest), r.SeqNr, r.ChannelID)
+	outputs, err := values.WrapMap(TriggerEvent{Payload: []Report{r}})
+	if err != nil {
+		return fmt.Errorf("failed to wrap report: %w", err)
+	}
+	resp := capabilities.TriggerResponse{
+		Event: capabilities.TriggerEvent{
+			TriggerType: s.ID,
+			ID:          eventID,
+			Outputs:     outputs,
+		},
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, sub := range s.subscribers {
+		if _, subscribed := sub.channelIDs[r.ChannelID]; !subscribed {
+			continue
+		}
+		select {
+		case sub.ch <- resp:
+		default:
+			s.lggr.Errorw("Subscriber channel full, dropping event", "eventID", eventID, "triggerID", id, "workflowID", sub.workflowID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package capability
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities"
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+)
+
+func newRegistrationRequest(t *testing.T, triggerID string, config map[string]any) capabilities.TriggerRegistrationRequest {
+	cfg, err := values.WrapMap(config)
+	require.NoError(t, err)
+	return capabilities.TriggerRegistrationRequest{
+		TriggerID: triggerID,
+		Metadata:  capabilities.RequestMetadata{WorkflowID: "workflow-" + triggerID},
+		Config:    cfg,
+	}
+}
+
+func Test_NewTriggerService(t *testing.T) {
+	s, err := NewTriggerService(logger.Test(t), "", "")
+	require.NoError(t, err)
+	assert.Equal(t, "llo-trigger@1.0.0", s.ID)
+	assert.Equal(t, capabilities.CapabilityTypeTrigger, s.CapabilityType)
+
+	s, err = NewTriggerService(logger.Test(t), "my-trigger", "2.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "my-trigger@2.0.0", s.ID)
+
+	_, err = NewTriggerService(logger.Test(t), "Invalid Name", "")
+	assert.ErrorContains(t, err, "invalid id")
+}
+
+func Test_ValidateConfig(t *testing.T) {
+	m, err := values.WrapMap(map[string]any{"channelIDs": []uint32{1, 2}})
+	require.NoError(t, err)
+	cfg, err := ValidateConfig(m)
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{1, 2}, cfg.ChannelIDs)
+
+	_, err = ValidateConfig(nil)
+	assert.EqualError(t, err, "config is required")
+
+	m, err = values.WrapMap(map[string]any{"channelIDs": []uint32{}})
+	require.NoError(t, err)
+	_, err = ValidateConfig(m)
+	assert.EqualError(t, err, "channelIDs must not be empty")
+
+	m, err = values.WrapMap(map[string]any{"channelIDs": []uint32{1, 1}})
+	require.NoError(t, err)
+	_, err = ValidateConfig(m)
+	assert.EqualError(t, err, "duplicate channelID: 1")
+}
+
+func Test_TriggerService(t *testing.T) {
+	ctx := tests.Context(t)
+	s, err := NewTriggerService(logger.Test(t), "", "")
+	require.NoError(t, err)
+	require.NoError(t, s.Start(ctx))
+
+	ch1, err := s.RegisterTrigger(ctx, newRegistrationRequest(t, "1", map[string]any{"channelIDs": []uint32{1, 2}}))
+	require.NoError(t, err)
+	ch2, err := s.RegisterTrigger(ctx, newRegistrationRequest(t, "2", map[string]any{"channelIDs": []uint32{2}}))
+	require.NoError(t, err)
+
+	t.Run("rejects duplicate and invalid registrations", func(t *testing.T) {
+		_, err := s.RegisterTrigger(ctx, newRegistrationRequest(t, "1", map[string]any{"channelIDs": []uint32{3}}))
+		assert.EqualError(t, err, "triggerID 1 already registered")
+		_, err = s.RegisterTrigger(ctx, newRegistrationRequest(t, "3", map[string]any{}))
+		assert.EqualError(t, err, "invalid config: channelIDs must not be empty")
+	})
+
+	t.Run("sends reports to the subscribers of their channel", func(t *testing.T) {
+		r := Report{
+			ConfigDigest:                []byte{1, 2},
+			SeqNr:                       3,
+			ChannelID:                   1,
+			ReportFormat:                "json",
+			ValidAfterSeconds:           4,
+			ObservationTimestampSeconds: 5,
+			Report:                      []byte("report"),
+			Signatures:                  [][]byte{{6}, {7}},
+			Signers:                     []uint32{0, 1},
+		}
+		require.NoError(t, s.ProcessReport(r))
+
+		require.Len(t, ch1, 1)
+		assert.Empty(t, ch2)
+		resp := <-ch1
+		require.NoError(t, resp.Err)
+		assert.Equal(t, "llo-trigger@1.0.0", resp.Event.TriggerType)
+		assert.Equal(t, "llo_0102_3_1", resp.Event.ID)
+		var event TriggerEvent
+		require.NoError(t, resp.Event.Outputs.UnwrapTo(&event))
+		assert.Equal(t, TriggerEvent{Payload: []Report{r}}, event)
+
+		r.ChannelID = 2
+		require.NoError(t, s.ProcessReport(r))
+		assert.Len(t, ch1, 1)
+		assert.Len(t, ch2, 1)
+		<-ch1
+		<-ch2
+
+		r.ChannelID = 3
+		require.NoError(t, s.ProcessReport(r))
+		assert.Empty(t, ch1)
+		assert.Empty(t, ch2)
+	})
+
+	t.Run("drops events if a subscriber is not keeping up", func(t *testing.T) {
+		r := Report{ConfigDigest: []byte{1}, ChannelID: 2}
+		for i := 0; i < subscriberBufferSize+1; i++ {
+			r.SeqNr = uint64(i)
+			require.NoError(t, s.ProcessReport(r))
+		}
+		assert.Len(t, ch2, subscriberBufferSize)
+		for len(ch1) > 0 {
+			<-ch1
+		}
+		for len(ch2) > 0 {
+			<-ch2
+		}
+	})
+
+	t.Run("unregisters triggers", func(t *testing.T) {
+		require.NoError(t, s.UnregisterTrigger(ctx, capabilities.TriggerRegistrationRequest{TriggerID: "2"}))
+		_, ok := <-ch2
+		assert.False(t, ok)
+		assert.EqualError(t, s.UnregisterTrigger(ctx, capabilities.TriggerRegistrationRequest{TriggerID: "2"}), "triggerID 2 not registered")
+	})
+
+	t.Run("closes remaining triggers on close", func(t *testing.T) {
+		require.NoError(t, s.Close())
+		_, ok := <-ch1
+		assert.False(t, ok)
+	})
+}
@@ -1,6 +1,7 @@
 package llo
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
 
@@ -20,16 +21,26 @@ func VerifyChannelDefinitions(channelDefs llotypes.ChannelDefinitions) error {
 			if strm.Aggregator == 0 {
 				return fmt.Errorf("ChannelDefinition with ID %d has stream %d with zero aggregator (this may indicate an uninitialized struct)", channelID, strm.StreamID)
 			}
+		}
+		for _, strm := range channelStreams(nil, cd) {
 			uniqueStreamIDs[strm.StreamID] = struct{}{}
 		}
-		switch cd.ReportFormat {
-		case llotypes.ReportFormatEVMPremiumLegacy:
-			if err := VerifyEVMPremiumLegacyChannelDefinition(cd); err != nil {
-				return fmt.Errorf("invalid ChannelDefinition with ID %d: %v", channelID, err)
+		if err := VerifyStreamValuePolicies(cd); err != nil {
+			return fmt.Errorf("invalid ChannelDefinition with ID %d: %v", channelID, err)
+		}
+		if err := parseChannelOpts(cd).additionalReportFormatsErr; err != nil {
+			return fmt.Errorf("invalid ChannelDefinition with ID %d: %v", channelID, err)
+		}
+		for _, rf := range channelReportFormats(nil, cd) {
+			switch rf {
+			case llotypes.ReportFormatEVMPremiumLegacy:
+				if err := VerifyEVMPremiumLegacyChannelDefinition(withReportFormat(cd, rf)); err != nil {
+					return fmt.Errorf("invalid ChannelDefinition with ID %d: %v", channelID, err)
+				}
+			default:
+				// NOTE: Could add further report-format-specific validation here
+				// for future report formats
 			}
-		default:
-			// NOTE: Could add further report-format-specific validation here
-			// for future report formats
 		}
 	}
 	if len(uniqueStreamIDs) > MaxObservationStreamValuesLength {
@@ -42,6 +53,16 @@ func VerifyEVMPremiumLegacyChannelDefinition(cd llotypes.ChannelDefinition) erro
 	if cd.ReportFormat != llotypes.ReportFormatEVMPremiumLegacy {
 		return fmt.Errorf("expected ReportFormatEVMPremiumLegacy, got: %v", cd.ReportFormat)
 	}
+	feeStreams, err := ParseFeeStreams(cd.Opts)
+	if err != nil {
+		return err
+	}
+	if len(feeStreams) > 0 {
+		if len(cd.Streams) != 1 {
+			return fmt.Errorf("ReportFormatEVMPremiumLegacy with fee streams requires exactly 1 stream (Quote); got: %v", cd.Streams)
+		}
+		return nil
+	}
 	if len(cd.Streams) != 3 {
 		return fmt.Errorf("ReportFormatEVMPremiumLegacy requires exactly 3 streams (NativePrice, LinkPrice, Quote); got: %v", cd.Streams)
 	}
@@ -72,3 +93,18 @@ func subtractChannelDefinitions(minuend llotypes.ChannelDefinitions, subtrahend
 
 	return difference
 }
+
+// channelOptsField returns the raw value of the key in a channel's opts. It
+// returns false if the key is absent, or if the opts are not a JSON object,
+// e.g. because the channel's report codec uses opts of another encoding.
+func channelOptsField(opts llotypes.ChannelOpts, key string) (json.RawMessage, bool) {
+	if len(opts) == 0 {
+		return nil, false
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(opts, &fields); err != nil {
+		return nil, false
+	}
+	v, ok := fields[key]
+	return v, ok
+}
@@ -0,0 +1,69 @@
+package llo
+
+import (
+	"time"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+// ChannelDefinitionsSyncStatus is the difference between the channel
+// definitions that an oracle's ChannelDefinitionCache wants and those of the
+// previous outcome, i.e. how far along a rollout of new definitions is
+type ChannelDefinitionsSyncStatus struct {
+	// SeqNr of the round whose observation computed the status
+	SeqNr uint64 `json:"seqNr"`
+	// PendingAdds is the number of desired channels missing from the outcome
+	PendingAdds int `json:"pendingAdds"`
+	// PendingUpdates is the number of channels whose definition in the
+	// outcome differs from the desired one
+	PendingUpdates int `json:"pendingUpdates"`
+	// PendingRemoves is the number of channels in the outcome that are no
+	// longer desired
+	PendingRemoves int `json:"pendingRemoves"`
+	// RoundsRemaining is the least number of rounds needed to apply all
+	// pending changes, given that every round adds or updates at most
+	// MaxObservationUpdateChannelDefinitionsLength channels and removes at
+	// most MaxObservationRemoveChannelIDsLength channels
+	RoundsRemaining int       `json:"roundsRemaining"`
+	RecordedAt      time.Time `json:"recordedAt"`
+}
+
+// InSync returns true if there are no pending changes
+func (s ChannelDefinitionsSyncStatus) InSync() bool {
+	return s.PendingAdds == 0 && s.PendingUpdates == 0 && s.PendingRemoves == 0
+}
+
+func (s *ChannelDefinitionsSyncStatus) computeRoundsRemaining() {
+	updateRounds := ceilDiv(s.PendingAdds+s.PendingUpdates, MaxObservationUpdateChannelDefinitionsLength)
+	removeRounds := ceilDiv(s.PendingRemoves, MaxObservationRemoveChannelIDsLength)
+	// Adds/updates and removes are voted on in the same rounds
+	s.RoundsRemaining = max(updateRounds, removeRounds)
+}
+
+// countRemovedChannels returns the number of channels in current that are
+// not in desired
+func countRemovedChannels(current, desired llotypes.ChannelDefinitions) (n int) {
+	for channelID := range current {
+		if _, ok := desired[channelID]; !ok {
+			n++
+		}
+	}
+	return n
+}
+
+func ceilDiv(a, b int) int {
+	return (a + b - 1) / b
+}
+
+// recordChannelDefinitionsSync exports the sync status through metrics and
+// the introspector
+func (p *Plugin) recordChannelDefinitionsSync(status ChannelDefinitionsSyncStatus) {
+	configDigest := p.ConfigDigest.String()
+	promChannelDefinitionsPending.WithLabelValues(configDigest, "add").Set(float64(status.PendingAdds))
+	promChannelDefinitionsPending.WithLabelValues(configDigest, "update").Set(float64(status.PendingUpdates))
+	promChannelDefinitionsPending.WithLabelValues(configDigest, "remove").Set(float64(status.PendingRemoves))
+	promChannelDefinitionsSyncRoundsRemaining.WithLabelValues(configDigest).Set(float64(status.RoundsRemaining))
+	if p.Introspector != nil {
+		p.Introspector.recordChannelDefinitionsSync(status)
+	}
+}
@@ -0,0 +1,94 @@
+package llo
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+)
+
+func Test_ChannelDefinitionsSyncStatus(t *testing.T) {
+	t.Run("computeRoundsRemaining", func(t *testing.T) {
+		s := ChannelDefinitionsSyncStatus{}
+		s.computeRoundsRemaining()
+		assert.Zero(t, s.RoundsRemaining)
+		assert.True(t, s.InSync())
+
+		s = ChannelDefinitionsSyncStatus{PendingAdds: MaxObservationUpdateChannelDefinitionsLength, PendingUpdates: 1}
+		s.computeRoundsRemaining()
+		assert.Equal(t, 2, s.RoundsRemaining)
+		assert.False(t, s.InSync())
+
+		s = ChannelDefinitionsSyncStatus{PendingAdds: 1, PendingRemoves: 3*MaxObservationRemoveChannelIDsLength - 1}
+		s.computeRoundsRemaining()
+		assert.Equal(t, 3, s.RoundsRemaining)
+	})
+
+	t.Run("is recorded on observation", func(t *testing.T) {
+		ctx := tests.Context(t)
+		desired := make(llotypes.ChannelDefinitions)
+		current := make(llotypes.ChannelDefinitions)
+		for i := 0; i < 12; i++ {
+			cd := llotypes.ChannelDefinition{
+				ReportFormat: llotypes.ReportFormatJSON,
+				Streams:      []llotypes.Stream{{StreamID: uint32(i), Aggregator: llotypes.AggregatorMedian}},
+			}
+			switch {
+			case i < 2:
+				// unchanged
+				desired[llotypes.ChannelID(i)] = cd
+				current[llotypes.ChannelID(i)] = cd
+			case i < 4:
+				// updated
+				desired[llotypes.ChannelID(i)] = cd
+				cd.Opts = []byte(`{"foo":"bar"}`)
+				current[llotypes.ChannelID(i)] = cd
+			case i < 11:
+				// added
+				desired[llotypes.ChannelID(i)] = cd
+			default:
+				// removed
+				current[llotypes.ChannelID(i)] = cd
+			}
+		}
+		i := NewPluginIntrospector()
+		p := &Plugin{
+			ConfigDigest:           types.ConfigDigest{0x00, 0x09, 0xaa},
+			Config:                 Config{true},
+			OutcomeCodec:           protoOutcomeCodec{},
+			ShouldRetireCache:      &mockShouldRetireCache{},
+			ChannelDefinitionCache: &mockChannelDefinitionCache{definitions: desired},
+			Logger:                 logger.Test(t),
+			ObservationCodec:       protoObservationCodec{},
+			DataSource:             &mockDataSource{},
+			Introspector:           i,
+		}
+		previousOutcome, err := p.OutcomeCodec.Encode(Outcome{LifeCycleStage: LifeCycleStageProduction, ChannelDefinitions: current})
+		require.NoError(t, err)
+
+		_, err = p.Observation(ctx, ocr3types.OutcomeContext{SeqNr: 3, PreviousOutcome: previousOutcome}, nil)
+		require.NoError(t, err)
+
+		status := i.State().ChannelDefinitionsSync
+		require.NotNil(t, status)
+		assert.Equal(t, uint64(3), status.SeqNr)
+		assert.Equal(t, 7, status.PendingAdds)
+		assert.Equal(t, 2, status.PendingUpdates)
+		assert.Equal(t, 1, status.PendingRemoves)
+		assert.Equal(t, 2, status.RoundsRemaining)
+		assert.False(t, status.RecordedAt.IsZero())
+
+		configDigest := p.ConfigDigest.String()
+		assert.Equal(t, float64(7), testutil.ToFloat64(promChannelDefinitionsPending.WithLabelValues(configDigest, "add")))
+		assert.Equal(t, float64(2), testutil.ToFloat64(promChannelDefinitionsPending.WithLabelValues(configDigest, "update")))
+		assert.Equal(t, float64(1), testutil.ToFloat64(promChannelDefinitionsPending.WithLabelValues(configDigest, "remove")))
+		assert.Equal(t, float64(2), testutil.ToFloat64(promChannelDefinitionsSyncRoundsRemaining.WithLabelValues(configDigest)))
+	})
+}
@@ -67,6 +67,21 @@ func Test_VerifyChannelDefinitions(t *testing.T) {
 		err := VerifyChannelDefinitions(channelDefs)
 		assert.EqualError(t, err, "invalid ChannelDefinition with ID 1: ReportFormatEVMPremiumLegacy requires exactly 3 streams (NativePrice, LinkPrice, Quote); got: [{1 median}]")
 	})
+	t.Run("succeeds for ReportFormatEVMPremiumLegacy with one stream and fee streams", func(t *testing.T) {
+		channelDefs := llotypes.ChannelDefinitions{
+			1: llotypes.ChannelDefinition{
+				ReportFormat: llotypes.ReportFormatEVMPremiumLegacy,
+				Streams: []llotypes.Stream{
+					llotypes.Stream{
+						StreamID:   3,
+						Aggregator: llotypes.AggregatorQuote,
+					},
+				},
+				Opts: []byte(`{"nativeFeeStreamID":1,"linkFeeStreamID":2}`),
+			},
+		}
+		assert.NoError(t, VerifyChannelDefinitions(channelDefs))
+	})
 
 	t.Run("succeeds with valid channel definitions", func(t *testing.T) {
 		channelDefs := llotypes.ChannelDefinitions{
@@ -83,6 +98,18 @@ func Test_VerifyChannelDefinitions(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("succeeds for channels whose opts are not JSON", func(t *testing.T) {
+		channelDefs := llotypes.ChannelDefinitions{
+			1: {
+				ReportFormat: llotypes.ReportFormatJSON,
+				Streams:      []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}},
+				Opts:         []byte{0x01, 0x02},
+			},
+		}
+		err := VerifyChannelDefinitions(channelDefs)
+		assert.NoError(t, err)
+	})
+
 	t.Run("succeeds with exact maxes", func(t *testing.T) {
 		streams := make([]llotypes.Stream, MaxObservationStreamValuesLength)
 		for i := 0; i < MaxObservationStreamValuesLength; i++ {
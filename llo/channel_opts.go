@@ -0,0 +1,85 @@
+package llo
+
+import (
+	"sync"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+// channelOpts are the opts of a channel definition that the plugin acts on,
+// parsed once per definition rather than every time they are used. Each
+// value is kept along with the error that parsing it returned, so that
+// callers handle invalid opts as if they had parsed them. The values are
+// shared between callers and must not be modified.
+type channelOpts struct {
+	dispersion                 DispersionMetric
+	dispersionErr              error
+	feeStreams                 []llotypes.Stream
+	feeStreamsErr              error
+	additionalReportFormats    []llotypes.ReportFormat
+	additionalReportFormatsErr error
+	valuePolicies              map[llotypes.StreamID]ValuePolicy
+	valuePoliciesErr           error
+	priority                   int32
+	priorityErr                error
+	targets                    []string
+	targetsErr                 error
+	hints                      TransmissionHintsOpts
+	hintsErr                   error
+}
+
+func parseChannelOpts(cd llotypes.ChannelDefinition) *channelOpts {
+	o := &channelOpts{}
+	o.dispersion, o.dispersionErr = ParseDispersionMetric(cd.Opts)
+	o.feeStreams, o.feeStreamsErr = ParseFeeStreams(cd.Opts)
+	o.additionalReportFormats, o.additionalReportFormatsErr = ParseAdditionalReportFormats(cd)
+	o.valuePolicies, o.valuePoliciesErr = ParseStreamValuePolicies(cd.Opts)
+	o.priority, o.priorityErr = ParseChannelPriority(cd.Opts)
+	o.targets, o.targetsErr = ParseTransmissionTargets(cd.Opts)
+	o.hints, o.hintsErr = ParseTransmissionHints(cd.Opts)
+	return o
+}
+
+// channelOptsCacheSize bounds the number of distinct definitions whose opts
+// are cached. Definitions change rarely, so the cache is simply cleared
+// once it is full.
+const channelOptsCacheSize = 2 * MaxOutcomeChannelDefinitionsLength
+
+// channelOptsKey identifies the definitions whose opts parse the same way;
+// the additional report formats are validated against the report format
+type channelOptsKey struct {
+	reportFormat llotypes.ReportFormat
+	opts         string
+}
+
+// channelOptsCache caches the parsed opts of the channel definitions that a
+// plugin acts on. Each plugin has its own cache, so that plugins neither
+// contend on it nor evict each other's definitions. A nil cache parses the
+// opts every time.
+type channelOptsCache struct {
+	mu      sync.Mutex
+	entries map[channelOptsKey]*channelOpts
+}
+
+func newChannelOptsCache() *channelOptsCache {
+	return &channelOptsCache{entries: make(map[channelOptsKey]*channelOpts)}
+}
+
+// of returns the parsed opts of the channel definition
+func (c *channelOptsCache) of(cd llotypes.ChannelDefinition) *channelOpts {
+	if c == nil {
+		return parseChannelOpts(cd)
+	}
+	key := channelOptsKey{cd.ReportFormat, string(cd.Opts)}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if o, exists := c.entries[key]; exists {
+		return o
+	}
+	if len(c.entries) >= channelOptsCacheSize {
+		clear(c.entries)
+	}
+	o := parseChannelOpts(cd)
+	c.entries[key] = o
+	return o
+}
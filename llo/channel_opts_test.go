@@ -0,0 +1,45 @@
+package llo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+func Test_channelOptsCache(t *testing.T) {
+	cd := llotypes.ChannelDefinition{
+		ReportFormat: llotypes.ReportFormatJSON,
+		Streams:      []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}},
+		Opts:         []byte(`{"priority":2,"dispersion":"iqr","transmissionTargets":["a"],"streamValuePolicies":{"1":"positive"}}`),
+	}
+
+	t.Run("parses the opts once per definition", func(t *testing.T) {
+		cache := newChannelOptsCache()
+		o := cache.of(cd)
+		assert.Same(t, o, cache.of(cd))
+		assert.Same(t, o, cache.of(llotypes.ChannelDefinition{ReportFormat: cd.ReportFormat, Opts: []byte(string(cd.Opts))}), "definitions with equal opts share them")
+		assert.NotSame(t, o, cache.of(withReportFormat(cd, llotypes.ReportFormatEVMPremiumLegacy)), "additional report formats depend on the report format")
+		assert.NotSame(t, o, newChannelOptsCache().of(cd), "caches are not shared")
+
+		assert.Equal(t, int32(2), o.priority)
+		assert.Equal(t, DispersionMetricIQR, o.dispersion)
+		assert.Equal(t, []string{"a"}, o.targets)
+		require.Contains(t, o.valuePolicies, llotypes.StreamID(1))
+	})
+	t.Run("nil cache parses the opts every time", func(t *testing.T) {
+		var cache *channelOptsCache
+		o := cache.of(cd)
+		assert.NotSame(t, o, cache.of(cd))
+		assert.Equal(t, o, cache.of(cd))
+	})
+	t.Run("keeps the errors of invalid opts", func(t *testing.T) {
+		o := newChannelOptsCache().of(llotypes.ChannelDefinition{Opts: []byte(`{"priority":"high","transmissionTargets":["a","a"],"additionalReportFormats":"json"}`)})
+		assert.Error(t, o.priorityErr)
+		assert.Error(t, o.targetsErr)
+		assert.Error(t, o.additionalReportFormatsErr)
+		assert.NoError(t, o.valuePoliciesErr)
+	})
+}
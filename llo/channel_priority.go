@@ -0,0 +1,104 @@
+package llo
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+// ChannelPriorityOpts are channel opts that rank a channel's streams against
+// those of other channels. If a round has more streams to observe than
+// MaxObservationStreamValuesLength, the streams of high priority channels
+// are the last to be dropped. They may be combined with any codec-specific
+// opts.
+//
+// Channels with the same priority form a priority class. If
+// OffchainConfig.ChannelPriorityClasses is set, higher classes also win
+// contention for the other bounded resources: their definitions are voted
+// in first when more channels are pending than fit into a round, their
+// reports come first in the output of Reports and are the last to be
+// deferred by OffchainConfig.MaxReportsPerRound, and their reports are
+// transmitted first unless the channel sets a transmission priority of its
+// own.
+type ChannelPriorityOpts struct {
+	// Priority of the channel; higher is more important. Defaults to 0.
+	Priority int32 `json:"priority,omitempty"`
+}
+
+// ParseChannelPriority extracts the priority from a channel's opts,
+// returning 0 if none is set. Other fields in the opts are ignored.
+func ParseChannelPriority(opts llotypes.ChannelOpts) (int32, error) {
+	if len(opts) == 0 {
+		return 0, nil
+	}
+	var o ChannelPriorityOpts
+	if err := json.Unmarshal(opts, &o); err != nil {
+		return 0, fmt.Errorf("invalid channel opts: %w", err)
+	}
+	return o.Priority, nil
+}
+
+// prioritizedStreamIDs returns the IDs of the streams used by channelDefs in
+// descending order of priority, then ascending order of stream ID. A
+// stream's priority is the highest priority of the channels that use it.
+// Channels whose opts cannot be parsed have the default priority. Fee
+// streams have the priority of the channels that use them.
+func prioritizedStreamIDs(cache *channelOptsCache, channelDefs llotypes.ChannelDefinitions) []llotypes.StreamID {
+	priorities := make(map[llotypes.StreamID]int32)
+	for _, cd := range channelDefs {
+		priority := cache.of(cd).priority
+		for _, strm := range channelStreams(cache, cd) {
+			if current, exists := priorities[strm.StreamID]; !exists || priority > current {
+				priorities[strm.StreamID] = priority
+			}
+		}
+	}
+	streamIDs := sortedKeys(priorities)
+	slices.SortStableFunc(streamIDs, func(a, b llotypes.StreamID) int {
+		return cmp.Compare(priorities[b], priorities[a])
+	})
+	return streamIDs
+}
+
+// prioritizeChannelIDs stably sorts channelIDs in descending order of the
+// priority of their definitions in channelDefs, so that channel IDs that
+// are in ascending order stay in that order within each priority class.
+// Channels that are missing from channelDefs, or whose opts cannot be
+// parsed, have the default priority.
+func prioritizeChannelIDs(cache *channelOptsCache, channelIDs []llotypes.ChannelID, channelDefs llotypes.ChannelDefinitions) {
+	priorities := make(map[llotypes.ChannelID]int32, len(channelIDs))
+	for _, cid := range channelIDs {
+		priorities[cid] = cache.of(channelDefs[cid]).priority
+	}
+	slices.SortStableFunc(channelIDs, func(a, b llotypes.ChannelID) int {
+		return cmp.Compare(priorities[b], priorities[a])
+	})
+}
+
+// prioritizeChannelDefinitionUpdates stably sorts updates, which must be in
+// canonical order, in descending order of channel priority. A channel's
+// priority is the highest priority of the definitions proposed for it, so
+// that the updates of a channel stay together and in canonical order.
+func prioritizeChannelDefinitionUpdates(cache *channelOptsCache, updates []channelDefinitionUpdate) {
+	priorities := make(map[llotypes.ChannelID]int32)
+	for _, update := range updates {
+		priority := cache.of(update.ChannelDefinition).priority
+		if current, exists := priorities[update.ChannelID]; !exists || priority > current {
+			priorities[update.ChannelID] = priority
+		}
+	}
+	slices.SortStableFunc(updates, func(a, b channelDefinitionUpdate) int {
+		return cmp.Compare(priorities[b.ChannelID], priorities[a.ChannelID])
+	})
+}
+
+// prioritizeChannels orders channelIDs, which must be in ascending order, by
+// priority class if OffchainConfig.ChannelPriorityClasses is set
+func (p *Plugin) prioritizeChannels(channelIDs []llotypes.ChannelID, channelDefs llotypes.ChannelDefinitions) {
+	if p.OffchainConfig.ChannelPriorityClasses {
+		prioritizeChannelIDs(p.channelOpts, channelIDs, channelDefs)
+	}
+}
@@ -0,0 +1,219 @@
+package llo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+func Test_ParseChannelPriority(t *testing.T) {
+	t.Run("defaults to 0", func(t *testing.T) {
+		priority, err := ParseChannelPriority(nil)
+		require.NoError(t, err)
+		assert.Equal(t, int32(0), priority)
+
+		priority, err = ParseChannelPriority(llotypes.ChannelOpts(`{"feedId":"0x01"}`))
+		require.NoError(t, err)
+		assert.Equal(t, int32(0), priority)
+	})
+	t.Run("parses priority alongside other opts", func(t *testing.T) {
+		priority, err := ParseChannelPriority(llotypes.ChannelOpts(`{"feedId":"0x01","priority":10}`))
+		require.NoError(t, err)
+		assert.Equal(t, int32(10), priority)
+
+		priority, err = ParseChannelPriority(llotypes.ChannelOpts(`{"priority":-1}`))
+		require.NoError(t, err)
+		assert.Equal(t, int32(-1), priority)
+	})
+	t.Run("invalid opts", func(t *testing.T) {
+		_, err := ParseChannelPriority(llotypes.ChannelOpts(`{"priority":"high"}`))
+		assert.ErrorContains(t, err, "invalid channel opts")
+		_, err = ParseChannelPriority(llotypes.ChannelOpts(`not json`))
+		assert.ErrorContains(t, err, "invalid channel opts")
+	})
+}
+
+func Test_prioritizedStreamIDs(t *testing.T) {
+	streams := func(ids ...llotypes.StreamID) []llotypes.Stream {
+		var s []llotypes.Stream
+		for _, id := range ids {
+			s = append(s, llotypes.Stream{StreamID: id, Aggregator: llotypes.AggregatorMedian})
+		}
+		return s
+	}
+
+	t.Run("orders by stream ID if no priorities are set", func(t *testing.T) {
+		assert.Equal(t, []llotypes.StreamID{1, 2, 3, 4}, prioritizedStreamIDs(nil, llotypes.ChannelDefinitions{
+			1: {Streams: streams(4, 2)},
+			2: {Streams: streams(3, 1, 2)},
+		}))
+	})
+	t.Run("orders by descending channel priority, then stream ID", func(t *testing.T) {
+		assert.Equal(t, []llotypes.StreamID{5, 6, 1, 2, 3, 4, 7}, prioritizedStreamIDs(nil, llotypes.ChannelDefinitions{
+			1: {Streams: streams(1, 2), Opts: llotypes.ChannelOpts(`{"priority":1}`)},
+			2: {Streams: streams(6, 5), Opts: llotypes.ChannelOpts(`{"priority":100}`)},
+			3: {Streams: streams(4, 3)},
+			4: {Streams: streams(7), Opts: llotypes.ChannelOpts(`{"priority":-5}`)},
+		}))
+	})
+	t.Run("a stream shared by several channels takes the highest priority", func(t *testing.T) {
+		assert.Equal(t, []llotypes.StreamID{3, 1, 2}, prioritizedStreamIDs(nil, llotypes.ChannelDefinitions{
+			1: {Streams: streams(1, 2, 3)},
+			2: {Streams: streams(3), Opts: llotypes.ChannelOpts(`{"priority":1}`)},
+			3: {Streams: streams(3), Opts: llotypes.ChannelOpts(`{"priority":-1}`)},
+		}))
+	})
+	t.Run("channels with invalid opts have the default priority", func(t *testing.T) {
+		assert.Equal(t, []llotypes.StreamID{2, 1}, prioritizedStreamIDs(nil, llotypes.ChannelDefinitions{
+			1: {Streams: streams(1), Opts: llotypes.ChannelOpts(`not json`)},
+			2: {Streams: streams(2), Opts: llotypes.ChannelOpts(`{"priority":1}`)},
+		}))
+	})
+	t.Run("includes fee streams", func(t *testing.T) {
+		assert.Equal(t, []llotypes.StreamID{1, 8, 9, 2}, prioritizedStreamIDs(nil, llotypes.ChannelDefinitions{
+			1: {Streams: streams(1), Opts: llotypes.ChannelOpts(`{"priority":1,"nativeFeeStreamID":8,"linkFeeStreamID":9}`)},
+			2: {Streams: streams(2), Opts: llotypes.ChannelOpts(`{"nativeFeeStreamID":8,"linkFeeStreamID":9}`)},
+		}))
+	})
+	t.Run("empty", func(t *testing.T) {
+		assert.Empty(t, prioritizedStreamIDs(nil, nil))
+	})
+}
+
+func Test_prioritizeChannelIDs(t *testing.T) {
+	channelDefs := llotypes.ChannelDefinitions{
+		1: {},
+		2: {Opts: llotypes.ChannelOpts(`{"priority":1}`)},
+		3: {Opts: llotypes.ChannelOpts(`{"priority":-1}`)},
+		4: {Opts: llotypes.ChannelOpts(`{"priority":1}`)},
+		5: {Opts: llotypes.ChannelOpts(`not json`)},
+	}
+	channelIDs := []llotypes.ChannelID{1, 2, 3, 4, 5, 6}
+	prioritizeChannelIDs(nil, channelIDs, channelDefs)
+	// 6 has no definition
+	assert.Equal(t, []llotypes.ChannelID{2, 4, 1, 5, 6, 3}, channelIDs)
+
+	prioritizeChannelIDs(nil, nil, channelDefs)
+}
+
+func Test_prioritizeChannelDefinitionUpdates(t *testing.T) {
+	update := func(cid llotypes.ChannelID, votes int, opts string) channelDefinitionUpdate {
+		return channelDefinitionUpdate{ChannelDefinitionWithID: ChannelDefinitionWithID{llotypes.ChannelDefinition{Opts: llotypes.ChannelOpts(opts)}, cid}, Votes: votes}
+	}
+	updates := []channelDefinitionUpdate{
+		update(1, 3, ``),
+		update(2, 2, ``),
+		// a channel's priority is the highest of its proposed definitions,
+		// so its updates stay in canonical order
+		update(2, 3, `{"priority":2}`),
+		update(3, 3, `{"priority":1}`),
+		update(4, 3, `{"priority":-1}`),
+	}
+	prioritizeChannelDefinitionUpdates(nil, updates)
+	var order []llotypes.ChannelID
+	var votes []int
+	for _, u := range updates {
+		order = append(order, u.ChannelID)
+		votes = append(votes, u.Votes)
+	}
+	assert.Equal(t, []llotypes.ChannelID{2, 2, 3, 1, 4}, order)
+	assert.Equal(t, []int{2, 3, 3, 3, 3}, votes)
+}
+
+func Test_Plugin_ChannelPriorityClasses(t *testing.T) {
+	ctx := tests.Context(t)
+	streams := []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}}
+
+	t.Run("votes for the definitions of the highest priority channels first", func(t *testing.T) {
+		definitions := llotypes.ChannelDefinitions{}
+		for i := 1; i <= MaxObservationUpdateChannelDefinitionsLength+3; i++ {
+			definitions[llotypes.ChannelID(i)] = llotypes.ChannelDefinition{ReportFormat: llotypes.ReportFormatJSON, Streams: streams}
+		}
+		flagship := llotypes.ChannelID(MaxObservationUpdateChannelDefinitionsLength + 2)
+		definitions[flagship] = llotypes.ChannelDefinition{ReportFormat: llotypes.ReportFormatJSON, Streams: streams, Opts: llotypes.ChannelOpts(`{"priority":10}`)}
+		p := &Plugin{
+			Config:                 Config{true},
+			OutcomeCodec:           protoOutcomeCodec{},
+			ShouldRetireCache:      &mockShouldRetireCache{},
+			ChannelDefinitionCache: &mockChannelDefinitionCache{definitions},
+			Logger:                 logger.Test(t),
+			ObservationCodec:       protoObservationCodec{},
+			DataSource:             &mockDataSource{},
+		}
+		previousOutcome, err := p.OutcomeCodec.Encode(Outcome{LifeCycleStage: LifeCycleStageProduction})
+		require.NoError(t, err)
+		outctx := ocr3types.OutcomeContext{SeqNr: 2, PreviousOutcome: previousOutcome}
+
+		obs, err := p.Observation(ctx, outctx, types.Query{})
+		require.NoError(t, err)
+		decoded, err := p.ObservationCodec.Decode(obs)
+		require.NoError(t, err)
+		assert.Len(t, decoded.UpdateChannelDefinitions, MaxObservationUpdateChannelDefinitionsLength)
+		assert.NotContains(t, decoded.UpdateChannelDefinitions, flagship)
+
+		p.OffchainConfig.ChannelPriorityClasses = true
+		obs, err = p.Observation(ctx, outctx, types.Query{})
+		require.NoError(t, err)
+		decoded, err = p.ObservationCodec.Decode(obs)
+		require.NoError(t, err)
+		assert.Len(t, decoded.UpdateChannelDefinitions, MaxObservationUpdateChannelDefinitionsLength)
+		assert.Contains(t, decoded.UpdateChannelDefinitions, flagship)
+		assert.NotContains(t, decoded.UpdateChannelDefinitions, llotypes.ChannelID(MaxObservationUpdateChannelDefinitionsLength))
+	})
+
+	t.Run("emits and transmits reports of the highest priority channels first", func(t *testing.T) {
+		p := &Plugin{
+			ConfigDigest: types.ConfigDigest{1},
+			OutcomeCodec: protoOutcomeCodec{},
+			Logger:       logger.Test(t),
+			ReportCodecs: map[llotypes.ReportFormat]ReportCodec{
+				llotypes.ReportFormatJSON: JSONReportCodec{},
+			},
+			TransmissionTargets: NewTransmissionTargets(),
+		}
+		encoded, err := p.OutcomeCodec.Encode(Outcome{
+			LifeCycleStage:                   LifeCycleStageProduction,
+			ObservationsTimestampNanoseconds: int64(200 * time.Second),
+			ValidAfterSeconds:                map[llotypes.ChannelID]uint32{1: 100, 2: 100, 3: 100},
+			ChannelDefinitions: llotypes.ChannelDefinitions{
+				1: {ReportFormat: llotypes.ReportFormatJSON, Streams: streams},
+				2: {ReportFormat: llotypes.ReportFormatJSON, Streams: streams, Opts: llotypes.ChannelOpts(`{"priority":5}`)},
+				3: {ReportFormat: llotypes.ReportFormatJSON, Streams: streams, Opts: llotypes.ChannelOpts(`{"priority":1,"transmissionPriority":-1}`)},
+			},
+			StreamAggregates: StreamAggregates{1: {llotypes.AggregatorMedian: ToDecimal(decimal.NewFromInt(1))}},
+		})
+		require.NoError(t, err)
+
+		reportedChannels := func() (channelIDs []llotypes.ChannelID, priorities []int32) {
+			rwis, err := p.Reports(ctx, 2, encoded)
+			require.NoError(t, err)
+			for _, rwi := range rwis {
+				r, err := JSONReportCodec{}.Decode(rwi.ReportWithInfo.Report)
+				require.NoError(t, err)
+				channelIDs = append(channelIDs, r.ChannelID)
+				priorities = append(priorities, p.TransmissionTargets.Hints(p.ConfigDigest, 2, rwi.ReportWithInfo.Report).Priority)
+			}
+			return
+		}
+
+		channelIDs, priorities := reportedChannels()
+		assert.Equal(t, []llotypes.ChannelID{1, 2, 3}, channelIDs)
+		assert.Equal(t, []int32{0, 0, -1}, priorities)
+
+		p.OffchainConfig.ChannelPriorityClasses = true
+		channelIDs, priorities = reportedChannels()
+		assert.Equal(t, []llotypes.ChannelID{2, 3, 1}, channelIDs)
+		// a channel's own transmission priority takes precedence
+		assert.Equal(t, []int32{5, -1, 0}, priorities)
+	})
+}
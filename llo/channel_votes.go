@@ -0,0 +1,81 @@
+package llo
+
+import (
+	"github.com/smartcontractkit/libocr/commontypes"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+// channelVotes tallies the oracles' votes to remove channels and to add or
+// replace channel definitions.
+//
+// Votes are keyed by oracle, so that each oracle has at most one removal
+// vote and one update vote per channel no matter how its observation is
+// crafted, or how often it appears among the attributed observations. A
+// single byzantine oracle therefore cannot push a channel past the f+1
+// threshold on its own.
+type channelVotes struct {
+	removals map[llotypes.ChannelID]map[commontypes.OracleID]struct{}
+	// channel => oracle => hash of the definition the oracle voted for
+	updates     map[llotypes.ChannelID]map[commontypes.OracleID]ChannelHash
+	definitions map[ChannelHash]ChannelDefinitionWithID
+}
+
+func newChannelVotes() *channelVotes {
+	return &channelVotes{
+		removals:    make(map[llotypes.ChannelID]map[commontypes.OracleID]struct{}),
+		updates:     make(map[llotypes.ChannelID]map[commontypes.OracleID]ChannelHash),
+		definitions: make(map[ChannelHash]ChannelDefinitionWithID),
+	}
+}
+
+// voteRemove records the oracle's vote to remove the channel. Repeated votes
+// are ignored.
+func (v *channelVotes) voteRemove(oracle commontypes.OracleID, channelID llotypes.ChannelID) {
+	oracles, exists := v.removals[channelID]
+	if !exists {
+		oracles = make(map[commontypes.OracleID]struct{})
+		v.removals[channelID] = oracles
+	}
+	oracles[oracle] = struct{}{}
+}
+
+// voteUpdate records the oracle's vote for a channel definition. Only the
+// oracle's first vote for each channel counts; it returns false if a later
+// vote is ignored.
+func (v *channelVotes) voteUpdate(oracle commontypes.OracleID, dfn ChannelDefinitionWithID) bool {
+	oracles, exists := v.updates[dfn.ChannelID]
+	if !exists {
+		oracles = make(map[commontypes.OracleID]ChannelHash)
+		v.updates[dfn.ChannelID] = oracles
+	}
+	if _, voted := oracles[oracle]; voted {
+		return false
+	}
+	hash := MakeChannelHash(dfn)
+	oracles[oracle] = hash
+	v.definitions[hash] = dfn
+	return true
+}
+
+// removeVotesByID returns the number of distinct oracles that voted to
+// remove each channel
+func (v *channelVotes) removeVotesByID() map[llotypes.ChannelID]int {
+	counts := make(map[llotypes.ChannelID]int, len(v.removals))
+	for channelID, oracles := range v.removals {
+		counts[channelID] = len(oracles)
+	}
+	return counts
+}
+
+// updateVotesByHash returns the number of distinct oracles that voted for
+// each channel definition
+func (v *channelVotes) updateVotesByHash() map[ChannelHash]int {
+	counts := make(map[ChannelHash]int, len(v.definitions))
+	for _, oracles := range v.updates {
+		for _, hash := range oracles {
+			counts[hash]++
+		}
+	}
+	return counts
+}
@@ -0,0 +1,52 @@
+package llo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+func Test_channelVotes(t *testing.T) {
+	cd1 := ChannelDefinitionWithID{llotypes.ChannelDefinition{ReportFormat: llotypes.ReportFormatJSON, Streams: []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}}}, 1}
+	cd1Alt := ChannelDefinitionWithID{llotypes.ChannelDefinition{ReportFormat: llotypes.ReportFormatJSON, Streams: []llotypes.Stream{{StreamID: 2, Aggregator: llotypes.AggregatorMedian}}}, 1}
+	cd2 := ChannelDefinitionWithID{cd1.ChannelDefinition, 2}
+
+	t.Run("counts removal votes from distinct oracles", func(t *testing.T) {
+		votes := newChannelVotes()
+		votes.voteRemove(0, 1)
+		votes.voteRemove(0, 1)
+		votes.voteRemove(0, 2)
+		votes.voteRemove(1, 1)
+
+		assert.Equal(t, map[llotypes.ChannelID]int{1: 2, 2: 1}, votes.removeVotesByID())
+	})
+
+	t.Run("counts only the first update vote of each oracle for each channel", func(t *testing.T) {
+		votes := newChannelVotes()
+		assert.True(t, votes.voteUpdate(0, cd1))
+		assert.False(t, votes.voteUpdate(0, cd1))
+		assert.False(t, votes.voteUpdate(0, cd1Alt))
+		assert.True(t, votes.voteUpdate(0, cd2))
+		assert.True(t, votes.voteUpdate(1, cd1Alt))
+		assert.True(t, votes.voteUpdate(2, cd1Alt))
+
+		assert.Equal(t, map[ChannelHash]int{
+			MakeChannelHash(cd1):    1,
+			MakeChannelHash(cd1Alt): 2,
+			MakeChannelHash(cd2):    1,
+		}, votes.updateVotesByHash())
+		assert.Equal(t, map[ChannelHash]ChannelDefinitionWithID{
+			MakeChannelHash(cd1):    cd1,
+			MakeChannelHash(cd1Alt): cd1Alt,
+			MakeChannelHash(cd2):    cd2,
+		}, votes.definitions)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		votes := newChannelVotes()
+		assert.Empty(t, votes.removeVotesByID())
+		assert.Empty(t, votes.updateVotesByHash())
+	})
+}
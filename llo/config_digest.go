@@ -0,0 +1,36 @@
+package llo
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	ocr2plustypes "github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// configDigestPrefixes are the config digest prefixes produced by LLO
+// contracts. A config digest with any other prefix belongs to a different
+// product (e.g. OCR2 feeds or legacy Mercury) and was applied to this plugin
+// by mistake.
+var configDigestPrefixes = map[ocr2plustypes.ConfigDigestPrefix]struct{}{
+	ocr2plustypes.ConfigDigestPrefixLLO: {},
+}
+
+// VerifyConfigDigestPrefix returns an error if the config digest was not
+// produced by an LLO contract
+func VerifyConfigDigestPrefix(cd types.ConfigDigest) error {
+	prefix := ocr2plustypes.ConfigDigestPrefixFromConfigDigest(cd)
+	if _, ok := configDigestPrefixes[prefix]; !ok {
+		return fmt.Errorf("config digest %s has prefix 0x%s, expected one of: %v", cd, prefix, expectedConfigDigestPrefixes())
+	}
+	return nil
+}
+
+func expectedConfigDigestPrefixes() []string {
+	prefixes := make([]string, 0, len(configDigestPrefixes))
+	for p := range configDigestPrefixes {
+		prefixes = append(prefixes, "0x"+p.String())
+	}
+	sort.Strings(prefixes)
+	return prefixes
+}
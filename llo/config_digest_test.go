@@ -0,0 +1,22 @@
+package llo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+func Test_VerifyConfigDigestPrefix(t *testing.T) {
+	assert.NoError(t, VerifyConfigDigestPrefix(types.ConfigDigest{0x00, 0x09, 1}))
+
+	for _, cd := range []types.ConfigDigest{
+		{},           // zero
+		{0x00, 0x01}, // EVM OCR2
+		{0x00, 0x06}, // Mercury v0.2/v0.3
+		{0x09, 0x00}, // byte-swapped
+	} {
+		assert.EqualError(t, VerifyConfigDigestPrefix(cd), "config digest "+cd.Hex()+" has prefix 0x"+cd.Hex()[:4]+", expected one of: [0x0009]")
+	}
+}
@@ -0,0 +1,326 @@
+package llo
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+)
+
+var updateConformance = flag.Bool("update-conformance", false, "overwrite the expected outcomes and reports in testdata/conformance with the ones produced by this implementation")
+
+// conformanceCase is one round of the conformance suite in
+// testdata/conformance; see the README there for the format
+type conformanceCase struct {
+	Description     string                    `json:"description"`
+	ConfigDigest    string                    `json:"configDigest"`
+	N               int                       `json:"n"`
+	F               int                       `json:"f"`
+	OffchainConfig  conformanceOffchainConfig `json:"offchainConfig"`
+	SeqNr           uint64                    `json:"seqNr"`
+	PreviousOutcome *conformanceOutcome       `json:"previousOutcome,omitempty"`
+	Observations    []conformanceObservation  `json:"observations"`
+	Expected        conformanceExpected       `json:"expected"`
+}
+
+type conformanceOffchainConfig struct {
+	SkipUnchangedStreamValues       bool   `json:"skipUnchangedStreamValues,omitempty"`
+	UnchangedStreamValueEpsilon     string `json:"unchangedStreamValueEpsilon,omitempty"`
+	StreamStalenessBoundNanoseconds int64  `json:"streamStalenessBoundNanoseconds,omitempty"`
+	RetirementWindDownRounds        uint32 `json:"retirementWindDownRounds,omitempty"`
+	DegradedModeRounds              uint32 `json:"degradedModeRounds,omitempty"`
+	ReducedConfidenceReports        bool   `json:"reducedConfidenceReports,omitempty"`
+	ChannelPriorityClasses          bool   `json:"channelPriorityClasses,omitempty"`
+	MaxReportsPerRound              int    `json:"maxReportsPerRound,omitempty"`
+}
+
+func (c conformanceOffchainConfig) offchainConfig() (o OffchainConfig, err error) {
+	o.SkipUnchangedStreamValues = c.SkipUnchangedStreamValues
+	if c.UnchangedStreamValueEpsilon != "" {
+		o.UnchangedStreamValueEpsilon, err = decimal.NewFromString(c.UnchangedStreamValueEpsilon)
+		if err != nil {
+			return o, fmt.Errorf("invalid unchangedStreamValueEpsilon: %w", err)
+		}
+	}
+	o.StreamStalenessBound = time.Duration(c.StreamStalenessBoundNanoseconds)
+	o.RetirementWindDownRounds = c.RetirementWindDownRounds
+	o.DegradedModeRounds = c.DegradedModeRounds
+	o.ReducedConfidenceReports = c.ReducedConfidenceReports
+	o.ChannelPriorityClasses = c.ChannelPriorityClasses
+	o.MaxReportsPerRound = c.MaxReportsPerRound
+	return o, o.Validate()
+}
+
+type conformanceObservation struct {
+	Observer                 commontypes.OracleID                   `json:"observer"`
+	UnixTimestampNanoseconds int64                                  `json:"unixTimestampNanoseconds"`
+	ShouldRetire             bool                                   `json:"shouldRetire,omitempty"`
+	RemoveChannelIDs         []llotypes.ChannelID                   `json:"removeChannelIDs,omitempty"`
+	UpdateChannelDefinitions llotypes.ChannelDefinitions            `json:"updateChannelDefinitions,omitempty"`
+	StreamValues             map[llotypes.StreamID]*JSONStreamValue `json:"streamValues,omitempty"`
+	UnchangedStreamIDs       []llotypes.StreamID                    `json:"unchangedStreamIDs,omitempty"`
+	StreamTimestamps         StreamTimestamps                       `json:"streamTimestamps,omitempty"`
+}
+
+func (c conformanceObservation) observation() (o Observation, err error) {
+	o.UnixTimestampNanoseconds = c.UnixTimestampNanoseconds
+	o.ShouldRetire = c.ShouldRetire
+	if len(c.RemoveChannelIDs) > 0 {
+		o.RemoveChannelIDs = make(map[llotypes.ChannelID]struct{}, len(c.RemoveChannelIDs))
+		for _, id := range c.RemoveChannelIDs {
+			o.RemoveChannelIDs[id] = struct{}{}
+		}
+	}
+	o.UpdateChannelDefinitions = c.UpdateChannelDefinitions
+	if len(c.StreamValues) > 0 {
+		o.StreamValues = make(StreamValues, len(c.StreamValues))
+		for id, enc := range c.StreamValues {
+			if o.StreamValues[id], err = UnmarshalJSONStreamValue(enc); err != nil {
+				return o, fmt.Errorf("invalid value for stream %d: %w", id, err)
+			}
+		}
+	}
+	if len(c.UnchangedStreamIDs) > 0 {
+		o.UnchangedStreamIDs = make(map[llotypes.StreamID]struct{}, len(c.UnchangedStreamIDs))
+		for _, id := range c.UnchangedStreamIDs {
+			o.UnchangedStreamIDs[id] = struct{}{}
+		}
+	}
+	o.StreamTimestamps = c.StreamTimestamps
+	return o, nil
+}
+
+type conformanceOutcome struct {
+	LifeCycleStage                   llotypes.LifeCycleStage                                        `json:"lifeCycleStage"`
+	ObservationsTimestampNanoseconds int64                                                          `json:"observationsTimestampNanoseconds"`
+	ChannelDefinitions               llotypes.ChannelDefinitions                                    `json:"channelDefinitions,omitempty"`
+	ValidAfterSeconds                map[llotypes.ChannelID]uint32                                  `json:"validAfterSeconds,omitempty"`
+	StreamAggregates                 map[llotypes.StreamID]map[llotypes.Aggregator]*JSONStreamValue `json:"streamAggregates,omitempty"`
+	StreamDispersions                map[llotypes.StreamID]*JSONStreamValue                         `json:"streamDispersions,omitempty"`
+	WindDownRoundsRemaining          uint32                                                         `json:"windDownRoundsRemaining,omitempty"`
+	DegradedRounds                   uint32                                                         `json:"degradedRounds,omitempty"`
+}
+
+func newConformanceOutcome(o Outcome) (c conformanceOutcome, err error) {
+	c.LifeCycleStage = o.LifeCycleStage
+	c.ObservationsTimestampNanoseconds = o.ObservationsTimestampNanoseconds
+	if len(o.ChannelDefinitions) > 0 {
+		c.ChannelDefinitions = o.ChannelDefinitions
+	}
+	if len(o.ValidAfterSeconds) > 0 {
+		c.ValidAfterSeconds = o.ValidAfterSeconds
+	}
+	if len(o.StreamAggregates) > 0 {
+		c.StreamAggregates = make(map[llotypes.StreamID]map[llotypes.Aggregator]*JSONStreamValue, len(o.StreamAggregates))
+		for id, aggregates := range o.StreamAggregates {
+			c.StreamAggregates[id] = make(map[llotypes.Aggregator]*JSONStreamValue, len(aggregates))
+			for agg, sv := range aggregates {
+				if c.StreamAggregates[id][agg], err = newConformanceStreamValue(sv); err != nil {
+					return c, err
+				}
+			}
+		}
+	}
+	if len(o.StreamDispersions) > 0 {
+		c.StreamDispersions = make(map[llotypes.StreamID]*JSONStreamValue, len(o.StreamDispersions))
+		for id, sv := range o.StreamDispersions {
+			if c.StreamDispersions[id], err = newConformanceStreamValue(sv); err != nil {
+				return c, err
+			}
+		}
+	}
+	c.WindDownRoundsRemaining = o.WindDownRoundsRemaining
+	c.DegradedRounds = o.DegradedRounds
+	return c, nil
+}
+
+func newConformanceStreamValue(sv StreamValue) (*JSONStreamValue, error) {
+	if sv == nil {
+		return nil, ErrNilStreamValue
+	}
+	b, err := sv.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return &JSONStreamValue{Type: sv.Type(), Value: string(b)}, nil
+}
+
+func (c conformanceOutcome) outcome() (o Outcome, err error) {
+	o.LifeCycleStage = c.LifeCycleStage
+	o.ObservationsTimestampNanoseconds = c.ObservationsTimestampNanoseconds
+	o.ChannelDefinitions = c.ChannelDefinitions
+	o.ValidAfterSeconds = c.ValidAfterSeconds
+	if len(c.StreamAggregates) > 0 {
+		o.StreamAggregates = make(StreamAggregates, len(c.StreamAggregates))
+		for id, aggregates := range c.StreamAggregates {
+			o.StreamAggregates[id] = make(map[llotypes.Aggregator]StreamValue, len(aggregates))
+			for agg, enc := range aggregates {
+				if o.StreamAggregates[id][agg], err = UnmarshalJSONStreamValue(enc); err != nil {
+					return o, fmt.Errorf("invalid %s aggregate for stream %d: %w", agg, id, err)
+				}
+			}
+		}
+	}
+	if len(c.StreamDispersions) > 0 {
+		o.StreamDispersions = make(map[llotypes.StreamID]StreamValue, len(c.StreamDispersions))
+		for id, enc := range c.StreamDispersions {
+			if o.StreamDispersions[id], err = UnmarshalJSONStreamValue(enc); err != nil {
+				return o, fmt.Errorf("invalid dispersion for stream %d: %w", id, err)
+			}
+		}
+	}
+	o.WindDownRoundsRemaining = c.WindDownRoundsRemaining
+	o.DegradedRounds = c.DegradedRounds
+	return o, nil
+}
+
+type conformanceExpected struct {
+	Outcome conformanceOutcome `json:"outcome"`
+	// EncodedOutcome is the hex-encoded outcome, which must match byte for
+	// byte since oracles sign its hash
+	EncodedOutcome string              `json:"encodedOutcome"`
+	Reports        []conformanceReport `json:"reports"`
+}
+
+type conformanceReport struct {
+	LifeCycleStage llotypes.LifeCycleStage `json:"lifeCycleStage"`
+	ReportFormat   llotypes.ReportFormat   `json:"reportFormat"`
+	// Report is the hex-encoded report
+	Report string `json:"report"`
+}
+
+// runConformanceCase runs the round of the case through the plugin, and
+// returns what it produced
+func runConformanceCase(ctx context.Context, lggr logger.Logger, c conformanceCase) (out conformanceExpected, err error) {
+	b, err := hex.DecodeString(c.ConfigDigest)
+	if err != nil {
+		return out, fmt.Errorf("invalid configDigest: %w", err)
+	}
+	configDigest, err := types.BytesToConfigDigest(b)
+	if err != nil {
+		return out, fmt.Errorf("invalid configDigest: %w", err)
+	}
+	offchainConfig, err := c.OffchainConfig.offchainConfig()
+	if err != nil {
+		return out, fmt.Errorf("invalid offchainConfig: %w", err)
+	}
+	p := &Plugin{
+		ConfigDigest:          configDigest,
+		Logger:                lggr,
+		N:                     c.N,
+		F:                     c.F,
+		ObservationCodec:      protoObservationCodec{},
+		OutcomeCodec:          protoOutcomeCodec{},
+		RetirementReportCodec: StandardRetirementReportCodec{},
+		ReportCodecs: map[llotypes.ReportFormat]ReportCodec{
+			llotypes.ReportFormatEVMPremiumLegacy: EVMPremiumLegacyReportCodec{},
+			llotypes.ReportFormatJSON:             JSONReportCodec{},
+		},
+		OffchainConfig: offchainConfig,
+	}
+
+	outctx := ocr3types.OutcomeContext{SeqNr: c.SeqNr}
+	if c.PreviousOutcome != nil {
+		previousOutcome, err2 := c.PreviousOutcome.outcome()
+		if err2 != nil {
+			return out, fmt.Errorf("invalid previousOutcome: %w", err2)
+		}
+		if outctx.PreviousOutcome, err = p.OutcomeCodec.Encode(previousOutcome); err != nil {
+			return out, fmt.Errorf("failed to encode previousOutcome: %w", err)
+		}
+	}
+	aos := make([]types.AttributedObservation, len(c.Observations))
+	for i, co := range c.Observations {
+		obs, err2 := co.observation()
+		if err2 != nil {
+			return out, fmt.Errorf("invalid observation %d: %w", i, err2)
+		}
+		aos[i].Observer = co.Observer
+		if aos[i].Observation, err = p.ObservationCodec.Encode(obs); err != nil {
+			return out, fmt.Errorf("failed to encode observation %d: %w", i, err)
+		}
+	}
+
+	encodedOutcome, err := p.Outcome(ctx, outctx, types.Query{}, aos)
+	if err != nil {
+		return out, fmt.Errorf("failed to generate outcome: %w", err)
+	}
+	outcome, err := p.OutcomeCodec.Decode(encodedOutcome)
+	if err != nil {
+		return out, fmt.Errorf("failed to decode outcome: %w", err)
+	}
+	if out.Outcome, err = newConformanceOutcome(outcome); err != nil {
+		return out, err
+	}
+	out.EncodedOutcome = hex.EncodeToString(encodedOutcome)
+
+	rwis, err := p.Reports(ctx, c.SeqNr, encodedOutcome)
+	if err != nil {
+		return out, fmt.Errorf("failed to generate reports: %w", err)
+	}
+	out.Reports = make([]conformanceReport, len(rwis))
+	for i, rwi := range rwis {
+		out.Reports[i] = conformanceReport{
+			LifeCycleStage: rwi.ReportWithInfo.Info.LifeCycleStage,
+			ReportFormat:   rwi.ReportWithInfo.Info.ReportFormat,
+			Report:         hex.EncodeToString(rwi.ReportWithInfo.Report),
+		}
+	}
+	return out, nil
+}
+
+func Test_Conformance(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "conformance", "*.json"))
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+
+	for _, path := range paths {
+		t.Run(strings.TrimSuffix(filepath.Base(path), ".json"), func(t *testing.T) {
+			b, err := os.ReadFile(path)
+			require.NoError(t, err)
+			var c conformanceCase
+			require.NoError(t, json.Unmarshal(b, &c))
+
+			actual, err := runConformanceCase(tests.Context(t), logger.Test(t), c)
+			require.NoError(t, err)
+
+			if *updateConformance {
+				c.Expected = actual
+				b, err = json.MarshalIndent(c, "", "  ")
+				require.NoError(t, err)
+				require.NoError(t, os.WriteFile(path, append(b, '\n'), 0o600))
+				return
+			}
+
+			assert.Equal(t, c.Expected.Outcome, actual.Outcome)
+			assert.Equal(t, c.Expected.EncodedOutcome, actual.EncodedOutcome)
+			assert.Equal(t, c.Expected.Reports, actual.Reports)
+
+			// the expected outcome must describe the encoded outcome
+			// completely, so that implementations can check either one
+			expectedOutcome, err := c.Expected.Outcome.outcome()
+			require.NoError(t, err)
+			encoded, err := protoOutcomeCodec{}.Encode(expectedOutcome)
+			require.NoError(t, err)
+			assert.Equal(t, c.Expected.EncodedOutcome, hex.EncodeToString(encoded))
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package llo
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// DefaultPhaseSafetyMargin is how long before the end of an OCR phase work
+// on it is abandoned, leaving time to serialize and send its result
+const DefaultPhaseSafetyMargin = 50 * time.Millisecond
+
+// ErrPhaseDeadlineExceeded is the cause of contexts returned by
+// WithPhaseDeadline that hit their deadline
+var ErrPhaseDeadlineExceeded = errors.New("phase deadline exceeded")
+
+// PhaseDeadline returns when work in an OCR phase that started at phaseStart
+// and may last at most maxDuration must be done: the earlier of the end of
+// the phase and the deadline of ctx, minus the safety margin. The margin is
+// capped at a quarter of maxDuration so that short phases keep most of their
+// time. A non-positive maxDuration means that the phase is only bounded by
+// ctx. It returns false if there is no deadline at all.
+func PhaseDeadline(ctx context.Context, phaseStart time.Time, maxDuration, safetyMargin time.Duration) (time.Time, bool) {
+	deadline, ok := ctx.Deadline()
+	if maxDuration > 0 {
+		if end := phaseStart.Add(maxDuration); !ok || end.Before(deadline) {
+			deadline, ok = end, true
+		}
+		safetyMargin = min(safetyMargin, maxDuration/4)
+	}
+	if !ok {
+		return time.Time{}, false
+	}
+	return deadline.Add(-max(safetyMargin, 0)), true
+}
+
+// WithPhaseDeadline returns a copy of ctx that is cancelled with
+// ErrPhaseDeadlineExceeded at the PhaseDeadline
+func WithPhaseDeadline(ctx context.Context, phaseStart time.Time, maxDuration, safetyMargin time.Duration) (context.Context, context.CancelFunc) {
+	deadline, ok := PhaseDeadline(ctx, phaseStart, maxDuration, safetyMargin)
+	if !ok {
+		return context.WithCancel(ctx)
+	}
+	return context.WithDeadlineCause(ctx, deadline, ErrPhaseDeadlineExceeded)
+}
+
+// CallContext calls f with ctx and returns its error, or the cause of ctx
+// being done if that happens first. f should return promptly once ctx is
+// done, but if it does not, e.g. because it is stuck in an HTTP request
+// without a timeout, it is abandoned so that it cannot block the caller
+// past the deadline. An abandoned f must not touch state that the caller
+// still uses once CallContext has returned.
+func CallContext(ctx context.Context, f func(context.Context) error) error {
+	if ctx.Err() != nil {
+		return context.Cause(ctx)
+	}
+	// Buffered so that an abandoned f does not leak blocked forever
+	done := make(chan error, 1)
+	go func() {
+		done <- f(ctx)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	}
+}
+
+// errObservationAbandoned is returned by observeWithinDeadline if the
+// observation did not complete in time
+type errObservationAbandoned struct {
+	cause error
+}
+
+func (e *errObservationAbandoned) Error() string {
+	return "observation abandoned: " + e.cause.Error()
+}
+
+func (e *errObservationAbandoned) Unwrap() error {
+	return e.cause
+}
+
+// observeWithinDeadline calls observe with copies of streamValues and
+// timestamps (which may be nil) and copies the results back once it returns.
+// If ctx is done first, observe is abandoned, streamValues and timestamps
+// are left untouched and an *errObservationAbandoned is returned. Working on
+// copies means that a DataSource call that ignores ctx and finishes late
+// cannot race with the plugin serializing the observation.
+func observeWithinDeadline(ctx context.Context, streamValues StreamValues, timestamps StreamTimestamps, observe func(context.Context, StreamValues, StreamTimestamps) error) error {
+	if ctx.Err() != nil {
+		return &errObservationAbandoned{context.Cause(ctx)}
+	}
+	sv := make(StreamValues, len(streamValues))
+	for id, v := range streamValues {
+		sv[id] = v
+	}
+	var ts StreamTimestamps
+	if timestamps != nil {
+		ts = make(StreamTimestamps)
+	}
+
+	// Buffered so that an abandoned observe does not leak blocked forever
+	done := make(chan error, 1)
+	go func() {
+		done <- observe(ctx, sv, ts)
+	}()
+	select {
+	case err := <-done:
+		// The DataSource may have deleted streams as well as set them
+		clear(streamValues)
+		for id, v := range sv {
+			streamValues[id] = v
+		}
+		for id, t := range ts {
+			timestamps[id] = t
+		}
+		return err
+	case <-ctx.Done():
+		return &errObservationAbandoned{context.Cause(ctx)}
+	}
+}
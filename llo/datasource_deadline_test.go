@@ -0,0 +1,196 @@
+package llo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+)
+
+func Test_PhaseDeadline(t *testing.T) {
+	start := time.Unix(1_000, 0)
+
+	t.Run("no deadline if neither the phase nor ctx has one", func(t *testing.T) {
+		_, ok := PhaseDeadline(context.Background(), start, 0, DefaultPhaseSafetyMargin)
+		assert.False(t, ok)
+	})
+	t.Run("end of the phase minus the safety margin", func(t *testing.T) {
+		deadline, ok := PhaseDeadline(context.Background(), start, time.Second, 50*time.Millisecond)
+		require.True(t, ok)
+		assert.Equal(t, start.Add(950*time.Millisecond), deadline)
+	})
+	t.Run("safety margin is capped for short phases", func(t *testing.T) {
+		deadline, ok := PhaseDeadline(context.Background(), start, 100*time.Millisecond, time.Second)
+		require.True(t, ok)
+		assert.Equal(t, start.Add(75*time.Millisecond), deadline)
+	})
+	t.Run("an earlier ctx deadline wins", func(t *testing.T) {
+		ctx, cancel := context.WithDeadline(context.Background(), start.Add(500*time.Millisecond))
+		defer cancel()
+		deadline, ok := PhaseDeadline(ctx, start, time.Second, 50*time.Millisecond)
+		require.True(t, ok)
+		assert.Equal(t, start.Add(450*time.Millisecond), deadline)
+
+		deadline, ok = PhaseDeadline(ctx, start, 0, 50*time.Millisecond)
+		require.True(t, ok)
+		assert.Equal(t, start.Add(450*time.Millisecond), deadline)
+	})
+}
+
+func Test_WithPhaseDeadline(t *testing.T) {
+	ctx, cancel := WithPhaseDeadline(tests.Context(t), time.Now(), 40*time.Millisecond, 0)
+	defer cancel()
+	<-ctx.Done()
+	assert.ErrorIs(t, context.Cause(ctx), ErrPhaseDeadlineExceeded)
+
+	ctx, cancel = WithPhaseDeadline(tests.Context(t), time.Now(), 0, DefaultPhaseSafetyMargin)
+	cancel()
+	assert.ErrorIs(t, context.Cause(ctx), context.Canceled)
+}
+
+func Test_CallContext(t *testing.T) {
+	t.Run("returns the error of f", func(t *testing.T) {
+		err := CallContext(tests.Context(t), func(context.Context) error { return errors.New("boom") })
+		assert.EqualError(t, err, "boom")
+	})
+	t.Run("returns at the deadline if f ignores ctx", func(t *testing.T) {
+		ctx, cancel := WithPhaseDeadline(tests.Context(t), time.Now(), 100*time.Millisecond, 0)
+		defer cancel()
+		hung := make(chan struct{})
+		t.Cleanup(func() { close(hung) })
+
+		start := time.Now()
+		err := CallContext(ctx, func(context.Context) error {
+			<-hung
+			return nil
+		})
+		assert.ErrorIs(t, err, ErrPhaseDeadlineExceeded)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+	t.Run("does not call f if ctx is already done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(tests.Context(t))
+		cancel()
+		err := CallContext(ctx, func(context.Context) error {
+			t.Fatal("f must not be called")
+			return nil
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+// httpDataSource observes streams by fetching them from an HTTP server. It
+// deliberately does not pass ctx to the request, like a careless adapter
+// would.
+type httpDataSource struct {
+	url string
+}
+
+func (h *httpDataSource) Observe(ctx context.Context, streamValues StreamValues, opts DSOpts) error {
+	res, err := http.Get(h.url)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	for id := range streamValues {
+		streamValues[id] = ToDecimal(decimal.NewFromInt(int64(id)))
+	}
+	return nil
+}
+
+func Test_Observation_DeadlineWithHungDataSource(t *testing.T) {
+	hung := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-hung
+	}))
+	// Unblock the handler before closing the server, which waits for it
+	t.Cleanup(srv.Close)
+	t.Cleanup(func() { close(hung) })
+
+	definitions := llotypes.ChannelDefinitions{
+		1: {
+			ReportFormat: llotypes.ReportFormatJSON,
+			Streams:      []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}, {StreamID: 2, Aggregator: llotypes.AggregatorMedian}},
+		},
+	}
+	p := &Plugin{
+		Config:                 Config{true},
+		ConfigDigest:           types.ConfigDigest{0x00, 0x09, 0xdd},
+		OutcomeCodec:           protoOutcomeCodec{},
+		ShouldRetireCache:      &mockShouldRetireCache{},
+		ChannelDefinitionCache: &mockChannelDefinitionCache{definitions: definitions},
+		Logger:                 logger.Test(t),
+		ObservationCodec:       protoObservationCodec{},
+		DataSource:             &httpDataSource{url: srv.URL},
+		MaxDurationObservation: 200 * time.Millisecond,
+	}
+	encodedPreviousOutcome, err := p.OutcomeCodec.Encode(Outcome{LifeCycleStage: LifeCycleStageProduction, ChannelDefinitions: definitions})
+	require.NoError(t, err)
+	outctx := ocr3types.OutcomeContext{SeqNr: 2, PreviousOutcome: encodedPreviousOutcome}
+	before := testutil.ToFloat64(promObservationDeadlineExceededTotal.WithLabelValues(p.ConfigDigest.String()))
+
+	start := time.Now()
+	obs, err := p.Observation(tests.Context(t), outctx, types.Query{})
+	require.NoError(t, err)
+	// The round goes on without the hung fetch
+	assert.Less(t, time.Since(start), 2*p.MaxDurationObservation)
+
+	decoded, err := p.ObservationCodec.Decode(obs)
+	require.NoError(t, err)
+	// Streams without a value are not serialized
+	assert.Empty(t, decoded.StreamValues)
+	assert.Equal(t, before+1, testutil.ToFloat64(promObservationDeadlineExceededTotal.WithLabelValues(p.ConfigDigest.String())))
+}
+
+func Test_observeWithinDeadline(t *testing.T) {
+	ctx := tests.Context(t)
+
+	t.Run("copies the results of a completed observation", func(t *testing.T) {
+		sv := StreamValues{1: nil, 2: nil}
+		ts := StreamTimestamps{}
+		err := observeWithinDeadline(ctx, sv, ts, func(_ context.Context, sv StreamValues, ts StreamTimestamps) error {
+			sv[1] = ToDecimal(decimal.NewFromInt(1))
+			delete(sv, 2)
+			ts[1] = 100
+			return errors.New("partial failure")
+		})
+		assert.EqualError(t, err, "partial failure")
+		assert.Equal(t, StreamValues{1: ToDecimal(decimal.NewFromInt(1))}, sv)
+		assert.Equal(t, StreamTimestamps{1: 100}, ts)
+	})
+	t.Run("leaves values untouched if the observation is abandoned", func(t *testing.T) {
+		ctx, cancel := WithPhaseDeadline(ctx, time.Now(), 50*time.Millisecond, 0)
+		defer cancel()
+		late := make(chan struct{})
+		finished := make(chan struct{})
+
+		sv := StreamValues{1: nil}
+		err := observeWithinDeadline(ctx, sv, nil, func(_ context.Context, sv StreamValues, _ StreamTimestamps) error {
+			defer close(finished)
+			<-late
+			// Writes after the deadline must not reach the caller
+			sv[1] = ToDecimal(decimal.NewFromInt(1))
+			return nil
+		})
+		var abandoned *errObservationAbandoned
+		require.ErrorAs(t, err, &abandoned)
+		assert.ErrorIs(t, err, ErrPhaseDeadlineExceeded)
+
+		close(late)
+		<-finished
+		assert.Equal(t, StreamValues{1: nil}, sv)
+	})
+}
@@ -0,0 +1,243 @@
+package llo
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+// HealthConfig configures the health scoring of a HealthScoringDataSource.
+// Zero values are replaced with defaults.
+type HealthConfig struct {
+	// Window is the number of most recent observations of a stream its
+	// health is scored on
+	Window int
+	// MinSamples is the number of observations of a stream needed before it
+	// can be quarantined
+	MinSamples int
+	// QuarantineErrorRate is the error rate, between 0 and 1, at which a
+	// stream is quarantined
+	QuarantineErrorRate float64
+	// ProbeInterval is the number of rounds between observations of a
+	// quarantined stream. A successful observation releases the stream from
+	// quarantine.
+	ProbeInterval uint64
+}
+
+const (
+	defaultHealthWindow              = 20
+	defaultHealthMinSamples          = 10
+	defaultHealthQuarantineErrorRate = 0.9
+	defaultHealthProbeInterval       = 10
+)
+
+func (c HealthConfig) withDefaults() HealthConfig {
+	if c.Window <= 0 {
+		c.Window = defaultHealthWindow
+	}
+	if c.MinSamples <= 0 {
+		c.MinSamples = defaultHealthMinSamples
+	}
+	if c.MinSamples > c.Window {
+		c.MinSamples = c.Window
+	}
+	if c.QuarantineErrorRate <= 0 {
+		c.QuarantineErrorRate = defaultHealthQuarantineErrorRate
+	}
+	if c.ProbeInterval == 0 {
+		c.ProbeInterval = defaultHealthProbeInterval
+	}
+	return c
+}
+
+// StreamHealth is the health score of a stream
+type StreamHealth struct {
+	// Samples is the number of observations the score is based on
+	Samples int `json:"samples"`
+	// ErrorRate is the fraction of those observations that failed
+	ErrorRate float64 `json:"errorRate"`
+	// LatencyP50 and LatencyP99 are percentiles of the duration of the
+	// DataSource calls that observed the stream successfully
+	LatencyP50 time.Duration `json:"latencyP50"`
+	LatencyP99 time.Duration `json:"latencyP99"`
+	// Quarantined streams are only observed every ProbeInterval rounds
+	Quarantined bool `json:"quarantined"`
+}
+
+type streamHealthSample struct {
+	ok      bool
+	latency time.Duration
+}
+
+type streamHealth struct {
+	// ring buffer of the most recent samples
+	samples []streamHealthSample
+	next    int
+
+	quarantined  bool
+	lastObserved uint64
+}
+
+func (h *streamHealth) add(s streamHealthSample, window int) {
+	if len(h.samples) < window {
+		h.samples = append(h.samples, s)
+		return
+	}
+	h.samples[h.next] = s
+	h.next = (h.next + 1) % window
+}
+
+func (h *streamHealth) score() StreamHealth {
+	sh := StreamHealth{Samples: len(h.samples), Quarantined: h.quarantined}
+	if len(h.samples) == 0 {
+		return sh
+	}
+	var errs int
+	latencies := make([]time.Duration, 0, len(h.samples))
+	for _, s := range h.samples {
+		if s.ok {
+			latencies = append(latencies, s.latency)
+		} else {
+			errs++
+		}
+	}
+	sh.ErrorRate = float64(errs) / float64(len(h.samples))
+	if len(latencies) > 0 {
+		slices.Sort(latencies)
+		sh.LatencyP50 = latencies[(len(latencies)-1)*50/100]
+		sh.LatencyP99 = latencies[(len(latencies)-1)*99/100]
+	}
+	return sh
+}
+
+var _ TimestampedDataSource = &HealthScoringDataSource{}
+
+// HealthScoringDataSource is DataSource middleware that scores the health of
+// every stream by the error rate and latency of its recent observations.
+//
+// Streams that fail persistently are quarantined: they are only observed
+// every ProbeInterval rounds, so that a dead upstream does not consume the
+// observation deadline every round. Quarantined streams are observed in the
+// same call as the healthy ones, so latency is measured per DataSource call,
+// not per stream.
+//
+// A stream counts as failed if it has no value after the underlying
+// DataSource returns.
+type HealthScoringDataSource struct {
+	lggr logger.Logger
+	ds   DataSource
+	cfg  HealthConfig
+
+	mu      sync.Mutex
+	streams map[llotypes.StreamID]*streamHealth
+}
+
+func NewHealthScoringDataSource(lggr logger.Logger, ds DataSource, cfg HealthConfig) *HealthScoringDataSource {
+	return &HealthScoringDataSource{
+		lggr:    logger.Named(lggr, "HealthScoringDataSource"),
+		ds:      ds,
+		cfg:     cfg.withDefaults(),
+		streams: make(map[llotypes.StreamID]*streamHealth),
+	}
+}
+
+// Health returns the health score of every stream observed so far
+func (h *HealthScoringDataSource) Health() map[llotypes.StreamID]StreamHealth {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	health := make(map[llotypes.StreamID]StreamHealth, len(h.streams))
+	for id, sh := range h.streams {
+		health[id] = sh.score()
+	}
+	return health
+}
+
+func (h *HealthScoringDataSource) Observe(ctx context.Context, streamValues StreamValues, opts DSOpts) error {
+	return h.observe(ctx, streamValues, opts, func(ctx context.Context, sv StreamValues) error {
+		return h.ds.Observe(ctx, sv, opts)
+	})
+}
+
+// ObserveWithTimestamps calls ObserveWithTimestamps on the underlying
+// DataSource if it is a TimestampedDataSource, or Observe otherwise, in
+// which case no timestamps are set.
+func (h *HealthScoringDataSource) ObserveWithTimestamps(ctx context.Context, streamValues StreamValues, timestamps StreamTimestamps, opts DSOpts) error {
+	tds, ok := h.ds.(TimestampedDataSource)
+	if !ok {
+		return h.Observe(ctx, streamValues, opts)
+	}
+	return h.observe(ctx, streamValues, opts, func(ctx context.Context, sv StreamValues) error {
+		return tds.ObserveWithTimestamps(ctx, sv, timestamps, opts)
+	})
+}
+
+func (h *HealthScoringDataSource) observe(ctx context.Context, streamValues StreamValues, opts DSOpts, observe func(context.Context, StreamValues) error) error {
+	seqNr := opts.SeqNr()
+	active := h.activeStreams(streamValues, seqNr)
+	if len(active) == 0 {
+		return nil
+	}
+
+	start := time.Now()
+	err := observe(ctx, active)
+	latency := time.Since(start)
+
+	for id := range active {
+		streamValues[id] = active[id]
+	}
+	h.record(active, latency, seqNr)
+	return err
+}
+
+// activeStreams returns the streams in streamValues which are due to be
+// observed in round seqNr
+func (h *HealthScoringDataSource) activeStreams(streamValues StreamValues, seqNr uint64) StreamValues {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	active := make(StreamValues, len(streamValues))
+	for id := range streamValues {
+		sh, exists := h.streams[id]
+		// seqNr starts over with every new protocol instance, so a probe is
+		// also due if seqNr went backwards
+		if exists && sh.quarantined && seqNr >= sh.lastObserved && seqNr < sh.lastObserved+h.cfg.ProbeInterval {
+			continue
+		}
+		active[id] = nil
+	}
+	return active
+}
+
+func (h *HealthScoringDataSource) record(observed StreamValues, latency time.Duration, seqNr uint64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, id := range sortedKeys(observed) {
+		sh, exists := h.streams[id]
+		if !exists {
+			sh = &streamHealth{}
+			h.streams[id] = sh
+		}
+		ok := observed[id] != nil
+		sh.lastObserved = seqNr
+		if sh.quarantined && ok {
+			h.lggr.Infow("Stream recovered, releasing from quarantine", "streamID", id, "seqNr", seqNr)
+			*sh = streamHealth{lastObserved: seqNr}
+			promDataSourceStreamQuarantined.WithLabelValues(FormatStreamID(id)).Set(0)
+		}
+		sh.add(streamHealthSample{ok, latency}, h.cfg.Window)
+
+		if sh.quarantined {
+			continue
+		}
+		score := sh.score()
+		if score.Samples >= h.cfg.MinSamples && score.ErrorRate >= h.cfg.QuarantineErrorRate {
+			h.lggr.Warnw("Stream is failing persistently, quarantining", "streamID", id, "seqNr", seqNr, "errorRate", score.ErrorRate, "samples", score.Samples, "probeInterval", h.cfg.ProbeInterval)
+			sh.quarantined = true
+			promDataSourceStreamQuarantined.WithLabelValues(FormatStreamID(id)).Set(1)
+			promDataSourceStreamQuarantinesTotal.WithLabelValues(FormatStreamID(id)).Inc()
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package llo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/shopspring/decimal"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+// requestRecordingDataSource only sets values for requested streams and
+// records which streams were requested
+type requestRecordingDataSource struct {
+	s         StreamValues
+	err       error
+	requested [][]llotypes.StreamID
+}
+
+func (r *requestRecordingDataSource) Observe(ctx context.Context, streamValues StreamValues, opts DSOpts) error {
+	r.requested = append(r.requested, sortedKeys(streamValues))
+	for id := range streamValues {
+		if v, ok := r.s[id]; ok {
+			streamValues[id] = v
+		}
+	}
+	return r.err
+}
+
+func Test_HealthScoringDataSource(t *testing.T) {
+	ctx := tests.Context(t)
+	underlying := &requestRecordingDataSource{s: StreamValues{
+		1: ToDecimal(decimal.NewFromInt(1)),
+	}}
+	ds := NewHealthScoringDataSource(logger.Test(t), underlying, HealthConfig{Window: 4, MinSamples: 3, QuarantineErrorRate: 0.75, ProbeInterval: 5})
+	observe := func(seqNr uint64) StreamValues {
+		sv := StreamValues{1: nil, 2: nil}
+		require.NoError(t, ds.Observe(ctx, sv, &dsOpts{outCtx: ocr3types.OutcomeContext{SeqNr: seqNr}}))
+		return sv
+	}
+
+	t.Run("scores streams", func(t *testing.T) {
+		sv := observe(1)
+		assert.Equal(t, ToDecimal(decimal.NewFromInt(1)), sv[1])
+		assert.Nil(t, sv[2])
+
+		health := ds.Health()
+		assert.Equal(t, 1, health[1].Samples)
+		assert.Zero(t, health[1].ErrorRate)
+		assert.False(t, health[1].Quarantined)
+		assert.Equal(t, 1, health[2].Samples)
+		assert.Equal(t, float64(1), health[2].ErrorRate)
+		assert.Zero(t, health[2].LatencyP99)
+		assert.False(t, health[2].Quarantined)
+	})
+
+	t.Run("quarantines persistently failing streams once it has enough samples", func(t *testing.T) {
+		observe(2)
+		assert.False(t, ds.Health()[2].Quarantined)
+		observe(3)
+		assert.True(t, ds.Health()[2].Quarantined)
+		assert.False(t, ds.Health()[1].Quarantined)
+		assert.Equal(t, float64(1), testutil.ToFloat64(promDataSourceStreamQuarantined.WithLabelValues("2")))
+	})
+
+	t.Run("only observes quarantined streams every ProbeInterval rounds", func(t *testing.T) {
+		underlying.requested = nil
+		for seqNr := uint64(4); seqNr <= 8; seqNr++ {
+			observe(seqNr)
+		}
+		assert.Equal(t, [][]llotypes.StreamID{{1}, {1}, {1}, {1}, {1, 2}}, underlying.requested)
+		assert.True(t, ds.Health()[2].Quarantined)
+	})
+
+	t.Run("probes quarantined streams if seqNr goes backwards", func(t *testing.T) {
+		underlying.requested = nil
+		observe(1)
+		assert.Equal(t, [][]llotypes.StreamID{{1, 2}}, underlying.requested)
+	})
+
+	t.Run("releases streams from quarantine once they recover", func(t *testing.T) {
+		underlying.s[2] = ToDecimal(decimal.NewFromInt(2))
+		sv := observe(6)
+		assert.Equal(t, ToDecimal(decimal.NewFromInt(2)), sv[2])
+		health := ds.Health()[2]
+		assert.False(t, health.Quarantined)
+		assert.Equal(t, 1, health.Samples)
+		assert.Zero(t, health.ErrorRate)
+		assert.Equal(t, float64(0), testutil.ToFloat64(promDataSourceStreamQuarantined.WithLabelValues("2")))
+	})
+
+	t.Run("returns errors from the underlying data source", func(t *testing.T) {
+		underlying.err = errors.New("foo")
+		sv := StreamValues{1: nil}
+		err := ds.Observe(ctx, sv, &dsOpts{outCtx: ocr3types.OutcomeContext{SeqNr: 7}})
+		assert.EqualError(t, err, "foo")
+		// values observed before the error are kept
+		assert.Equal(t, ToDecimal(decimal.NewFromInt(1)), sv[1])
+	})
+
+	t.Run("falls back to Observe if the underlying data source has no timestamps", func(t *testing.T) {
+		underlying.err = nil
+		sv, ts := StreamValues{1: nil}, StreamTimestamps{}
+		require.NoError(t, ds.ObserveWithTimestamps(ctx, sv, ts, &dsOpts{outCtx: ocr3types.OutcomeContext{SeqNr: 8}}))
+		assert.Equal(t, ToDecimal(decimal.NewFromInt(1)), sv[1])
+		assert.Empty(t, ts)
+	})
+
+	t.Run("passes timestamps through", func(t *testing.T) {
+		ds := NewHealthScoringDataSource(logger.Test(t), &mockDataSource{s: StreamValues{1: ToDecimal(decimal.NewFromInt(1))}, ts: StreamTimestamps{1: 1_000}}, HealthConfig{})
+		sv, ts := StreamValues{1: nil}, StreamTimestamps{}
+		require.NoError(t, ds.ObserveWithTimestamps(ctx, sv, ts, &dsOpts{outCtx: ocr3types.OutcomeContext{SeqNr: 1}}))
+		assert.Equal(t, ToDecimal(decimal.NewFromInt(1)), sv[1])
+		assert.Equal(t, StreamTimestamps{1: 1_000}, ts)
+	})
+}
@@ -0,0 +1,155 @@
+package llo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+)
+
+// LatencyBudgetConfig configures a LatencyBudgetDataSource. Zero values are
+// replaced with defaults.
+type LatencyBudgetConfig struct {
+	// SoftDeadline is how long to wait for observations before returning the
+	// values that are ready. It should be well below MaxDurationObservation.
+	SoftDeadline time.Duration
+	// BatchSize is the number of streams observed per call to the underlying
+	// DataSource. Smaller batches make more values available at the soft
+	// deadline, larger batches reduce the overhead per call.
+	BatchSize int
+}
+
+const (
+	defaultLatencyBudgetSoftDeadline = 250 * time.Millisecond
+	defaultLatencyBudgetBatchSize    = 1
+)
+
+func (c LatencyBudgetConfig) withDefaults() LatencyBudgetConfig {
+	if c.SoftDeadline <= 0 {
+		c.SoftDeadline = defaultLatencyBudgetSoftDeadline
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = defaultLatencyBudgetBatchSize
+	}
+	return c
+}
+
+var _ TimestampedDataSource = &LatencyBudgetDataSource{}
+
+// LatencyBudgetDataSource is DataSource middleware that keeps the observation
+// phase fast when some upstreams are slow. Streams are observed in
+// concurrent batches, and once the soft deadline is hit, the values of the
+// batches that completed are returned without waiting for the rest, which
+// are cancelled.
+//
+// Streams whose batch did not complete in time are left without a value,
+// like any other failed observation, and counted in the
+// llo_datasource_latency_budget_unobserved_streams metric.
+type LatencyBudgetDataSource struct {
+	lggr logger.Logger
+	ds   DataSource
+	cfg  LatencyBudgetConfig
+}
+
+func NewLatencyBudgetDataSource(lggr logger.Logger, ds DataSource, cfg LatencyBudgetConfig) *LatencyBudgetDataSource {
+	return &LatencyBudgetDataSource{
+		lggr: logger.Named(lggr, "LatencyBudgetDataSource"),
+		ds:   ds,
+		cfg:  cfg.withDefaults(),
+	}
+}
+
+func (l *LatencyBudgetDataSource) Observe(ctx context.Context, streamValues StreamValues, opts DSOpts) error {
+	return l.observe(ctx, streamValues, nil, opts, func(ctx context.Context, sv StreamValues, _ StreamTimestamps) error {
+		return l.ds.Observe(ctx, sv, opts)
+	})
+}
+
+// ObserveWithTimestamps calls ObserveWithTimestamps on the underlying
+// DataSource if it is a TimestampedDataSource, or Observe otherwise, in
+// which case no timestamps are set.
+func (l *LatencyBudgetDataSource) ObserveWithTimestamps(ctx context.Context, streamValues StreamValues, timestamps StreamTimestamps, opts DSOpts) error {
+	tds, ok := l.ds.(TimestampedDataSource)
+	if !ok {
+		return l.Observe(ctx, streamValues, opts)
+	}
+	return l.observe(ctx, streamValues, timestamps, opts, func(ctx context.Context, sv StreamValues, ts StreamTimestamps) error {
+		return tds.ObserveWithTimestamps(ctx, sv, ts, opts)
+	})
+}
+
+type latencyBudgetBatch struct {
+	streamValues StreamValues
+	timestamps   StreamTimestamps
+	err          error
+}
+
+func (l *LatencyBudgetDataSource) observe(ctx context.Context, streamValues StreamValues, timestamps StreamTimestamps, opts DSOpts, observe func(context.Context, StreamValues, StreamTimestamps) error) error {
+	streamIDs := sortedKeys(streamValues)
+	if len(streamIDs) == 0 {
+		return nil
+	}
+
+	// Batches that are still running when we return are abandoned, cancel
+	// them so they don't keep querying upstreams
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	nBatches := (len(streamIDs) + l.cfg.BatchSize - 1) / l.cfg.BatchSize
+	// Buffered so that abandoned batches don't block forever
+	results := make(chan latencyBudgetBatch, nBatches)
+	for start := 0; start < len(streamIDs); start += l.cfg.BatchSize {
+		batch := latencyBudgetBatch{streamValues: make(StreamValues, l.cfg.BatchSize)}
+		for _, id := range streamIDs[start:min(start+l.cfg.BatchSize, len(streamIDs))] {
+			batch.streamValues[id] = nil
+		}
+		if timestamps != nil {
+			batch.timestamps = make(StreamTimestamps, l.cfg.BatchSize)
+		}
+		go func() {
+			batch.err = observe(ctx, batch.streamValues, batch.timestamps)
+			results <- batch
+		}()
+	}
+
+	deadline := time.NewTimer(l.cfg.SoftDeadline)
+	defer deadline.Stop()
+
+	var errs []error
+	pending := nBatches
+	observedStreams := 0
+collect:
+	for pending > 0 {
+		select {
+		case batch := <-results:
+			pending--
+			if batch.err != nil {
+				errs = append(errs, batch.err)
+			}
+			for id, sv := range batch.streamValues {
+				streamValues[id] = sv
+				if sv == nil {
+					continue
+				}
+				if ts, ok := batch.timestamps[id]; ok {
+					timestamps[id] = ts
+				}
+			}
+			observedStreams += len(batch.streamValues)
+		case <-deadline.C:
+			break collect
+		case <-ctx.Done():
+			break collect
+		}
+	}
+
+	configDigest := opts.ConfigDigest().Hex()
+	unobserved := len(streamIDs) - observedStreams
+	promDataSourceLatencyBudgetUnobservedStreams.WithLabelValues(configDigest).Set(float64(unobserved))
+	if pending > 0 {
+		promDataSourceLatencyBudgetExceededTotal.WithLabelValues(configDigest).Inc()
+		l.lggr.Debugw("Soft deadline hit, returning incomplete observation", "seqNr", opts.SeqNr(), "softDeadline", l.cfg.SoftDeadline, "streams", len(streamIDs), "unobservedStreams", unobserved, "pendingBatches", pending)
+	}
+	return errors.Join(errs...)
+}
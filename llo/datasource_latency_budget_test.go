@@ -0,0 +1,118 @@
+package llo
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+// slowDataSource sets a value for every stream, except that it blocks on
+// slow streams until the context is done, and fails on failing streams
+type slowDataSource struct {
+	slow    map[llotypes.StreamID]struct{}
+	failing map[llotypes.StreamID]struct{}
+
+	mu        sync.Mutex
+	cancelled int
+}
+
+func (s *slowDataSource) ObserveWithTimestamps(ctx context.Context, streamValues StreamValues, timestamps StreamTimestamps, opts DSOpts) error {
+	for id := range streamValues {
+		if _, slow := s.slow[id]; slow {
+			<-ctx.Done()
+			s.mu.Lock()
+			s.cancelled++
+			s.mu.Unlock()
+			return ctx.Err()
+		}
+		if _, failing := s.failing[id]; failing {
+			return errors.New("upstream failed")
+		}
+		streamValues[id] = ToDecimal(decimal.NewFromInt(int64(id)))
+		if timestamps != nil {
+			timestamps[id] = int64(id) * 10
+		}
+	}
+	return nil
+}
+
+func (s *slowDataSource) Observe(ctx context.Context, streamValues StreamValues, opts DSOpts) error {
+	return s.ObserveWithTimestamps(ctx, streamValues, nil, opts)
+}
+
+func Test_LatencyBudgetDataSource(t *testing.T) {
+	ctx := tests.Context(t)
+	opts := &dsOpts{}
+	configDigest := opts.ConfigDigest().Hex()
+
+	t.Run("returns all values if they are ready before the soft deadline", func(t *testing.T) {
+		ds := NewLatencyBudgetDataSource(logger.Test(t), &slowDataSource{}, LatencyBudgetConfig{SoftDeadline: tests.WaitTimeout(t)})
+		sv := StreamValues{1: nil, 2: nil, 3: nil}
+		start := time.Now()
+		require.NoError(t, ds.Observe(ctx, sv, opts))
+		assert.Less(t, time.Since(start), tests.WaitTimeout(t))
+		assert.Equal(t, StreamValues{
+			1: ToDecimal(decimal.NewFromInt(1)),
+			2: ToDecimal(decimal.NewFromInt(2)),
+			3: ToDecimal(decimal.NewFromInt(3)),
+		}, sv)
+		assert.Zero(t, testutil.ToFloat64(promDataSourceLatencyBudgetUnobservedStreams.WithLabelValues(configDigest)))
+	})
+
+	t.Run("returns ready values at the soft deadline", func(t *testing.T) {
+		underlying := &slowDataSource{slow: map[llotypes.StreamID]struct{}{2: {}}}
+		ds := NewLatencyBudgetDataSource(logger.Test(t), underlying, LatencyBudgetConfig{SoftDeadline: 50 * time.Millisecond})
+		exceeded := testutil.ToFloat64(promDataSourceLatencyBudgetExceededTotal.WithLabelValues(configDigest))
+
+		sv := StreamValues{1: nil, 2: nil, 3: nil}
+		ts := StreamTimestamps{}
+		require.NoError(t, ds.ObserveWithTimestamps(ctx, sv, ts, opts))
+		assert.Equal(t, StreamValues{
+			1: ToDecimal(decimal.NewFromInt(1)),
+			2: nil,
+			3: ToDecimal(decimal.NewFromInt(3)),
+		}, sv)
+		assert.Equal(t, StreamTimestamps{1: 10, 3: 30}, ts)
+
+		assert.Equal(t, exceeded+1, testutil.ToFloat64(promDataSourceLatencyBudgetExceededTotal.WithLabelValues(configDigest)))
+		assert.Equal(t, float64(1), testutil.ToFloat64(promDataSourceLatencyBudgetUnobservedStreams.WithLabelValues(configDigest)))
+		// the straggler is cancelled
+		require.Eventually(t, func() bool {
+			underlying.mu.Lock()
+			defer underlying.mu.Unlock()
+			return underlying.cancelled == 1
+		}, tests.WaitTimeout(t), 10*time.Millisecond)
+	})
+
+	t.Run("observes streams in batches", func(t *testing.T) {
+		underlying := &slowDataSource{slow: map[llotypes.StreamID]struct{}{2: {}}}
+		ds := NewLatencyBudgetDataSource(logger.Test(t), underlying, LatencyBudgetConfig{SoftDeadline: 50 * time.Millisecond, BatchSize: 2})
+
+		// streams 1 and 2 share a batch, so 1 is lost with 2
+		sv := StreamValues{1: nil, 2: nil, 3: nil}
+		require.NoError(t, ds.Observe(ctx, sv, opts))
+		assert.Equal(t, StreamValues{1: nil, 2: nil, 3: ToDecimal(decimal.NewFromInt(3))}, sv)
+		assert.Equal(t, float64(2), testutil.ToFloat64(promDataSourceLatencyBudgetUnobservedStreams.WithLabelValues(configDigest)))
+	})
+
+	t.Run("returns errors of completed batches", func(t *testing.T) {
+		underlying := &slowDataSource{failing: map[llotypes.StreamID]struct{}{2: {}}}
+		ds := NewLatencyBudgetDataSource(logger.Test(t), underlying, LatencyBudgetConfig{SoftDeadline: tests.WaitTimeout(t)})
+
+		sv := StreamValues{1: nil, 2: nil}
+		assert.EqualError(t, ds.Observe(ctx, sv, opts), "upstream failed")
+		assert.Equal(t, StreamValues{1: ToDecimal(decimal.NewFromInt(1)), 2: nil}, sv)
+	})
+}
@@ -0,0 +1,132 @@
+package llo
+
+import (
+	"math"
+	"sync"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+// DefaultDegradedModeRounds is the number of consecutive degraded rounds
+// that enter degraded mode, and of healthy rounds that leave it, if not set
+// in the OffchainConfig
+const DefaultDegradedModeRounds = 3
+
+// degradedModeTracker decides whether an oracle is in degraded mode, i.e.
+// whether the protocol instance repeatedly failed to tolerate f faulty
+// oracles. A round is degraded if its committed outcome is (see
+// Outcome.DegradedRounds), or if this oracle missed it. A nil tracker
+// tracks nothing.
+type degradedModeTracker struct {
+	mu       sync.Mutex
+	degraded bool
+	// consecutive is the number of consecutive rounds that were healthy if
+	// degraded, or degraded otherwise
+	consecutive uint64
+	// seqNr is the last round that was recorded
+	seqNr uint64
+}
+
+// record records rounds up to and including seqNr that were all either
+// healthy or degraded, and returns whether the oracle is in degraded mode
+// afterwards and whether that changed. Degraded mode is entered and left
+// after threshold consecutive rounds, so that a single slow round does not
+// flap the signal. Rounds are only recorded once, so ok is false if seqNr
+// was recorded already, e.g. because reports were generated again for it.
+func (t *degradedModeTracker) record(seqNr uint64, rounds uint64, healthy bool, threshold uint32) (degraded, changed, ok bool) {
+	if t == nil {
+		return false, false, false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if seqNr <= t.seqNr {
+		return t.degraded, false, false
+	}
+	t.seqNr = seqNr
+	if healthy != t.degraded {
+		// The rounds continue the current mode
+		t.consecutive = 0
+		return t.degraded, false, true
+	}
+	t.consecutive += rounds
+	if t.consecutive >= uint64(threshold) {
+		t.degraded, t.consecutive = !t.degraded, 0
+		return t.degraded, true, true
+	}
+	return t.degraded, false, true
+}
+
+// recordDegradedRounds feeds rounds up to and including seqNr into the
+// degraded mode tracker and updates the degraded mode metric. It returns
+// false if the rounds were recorded already.
+func (p *Plugin) recordDegradedRounds(seqNr uint64, rounds uint64, healthy bool) bool {
+	degraded, changed, ok := p.degraded.record(seqNr, rounds, healthy, p.OffchainConfig.DegradedModeThreshold())
+	if !changed {
+		return ok
+	}
+	if degraded {
+		promDegradedMode.WithLabelValues(p.ConfigDigest.String()).Set(1)
+		p.Logger.Warnw("Entering degraded mode, rounds repeatedly had streams with fewer than 2f+1 contributors or were missed", "seqNr", seqNr, "threshold", p.OffchainConfig.DegradedModeThreshold())
+	} else {
+		promDegradedMode.WithLabelValues(p.ConfigDigest.String()).Set(0)
+		p.Logger.Infow("Leaving degraded mode", "seqNr", seqNr)
+	}
+	return ok
+}
+
+// recordDegradedOutcome records whether the committed outcome of round seqNr
+// was degraded in the metrics and the degraded mode tracker
+func (p *Plugin) recordDegradedOutcome(seqNr uint64, outcome *Outcome) {
+	healthy := outcome.DegradedRounds == 0
+	if !p.recordDegradedRounds(seqNr, 1, healthy) {
+		return
+	}
+	promOutcomeDegradedRounds.WithLabelValues(p.ConfigDigest.String()).Set(float64(outcome.DegradedRounds))
+	if !healthy {
+		promDegradedRoundsTotal.WithLabelValues(p.ConfigDigest.String()).Inc()
+	}
+}
+
+// roundContributors returns the number of oracles that the weakest stream of
+// an outcome is based on, i.e. the fewest values that any stream of its
+// channels that was aggregated has. Streams that could not be aggregated
+// have no value in the outcome, and streams of no channel are not part of
+// it, so neither counts; otherwise a single oracle could make every round
+// degraded by observing a stream that no other oracle does. If there are
+// none, e.g. because there are no channels, it is the number of valid
+// observations.
+func roundContributors(cache *channelOptsCache, validObservations int, outcome *Outcome, streamObservations map[llotypes.StreamID][]StreamValue) int {
+	contributors := -1
+	for _, cd := range outcome.ChannelDefinitions {
+		for _, strm := range channelStreams(cache, cd) {
+			if _, ok := outcome.StreamAggregates[strm.StreamID][strm.Aggregator]; !ok {
+				continue
+			}
+			if n := len(streamObservations[strm.StreamID]); contributors < 0 || n < contributors {
+				contributors = n
+			}
+		}
+	}
+	if contributors < 0 {
+		return validObservations
+	}
+	return contributors
+}
+
+// degradedRounds returns the Outcome.DegradedRounds of a round whose weakest
+// stream is based on contributors oracles
+func (p *Plugin) degradedRounds(previousOutcome Outcome, contributors int) uint32 {
+	if contributors >= 2*p.F+1 {
+		return 0
+	}
+	if previousOutcome.DegradedRounds == math.MaxUint32 {
+		return math.MaxUint32
+	}
+	return previousOutcome.DegradedRounds + 1
+}
+
+// reducedConfidence returns whether reports of the outcome are marked
+// ReducedConfidence
+func (p *Plugin) reducedConfidence(outcome *Outcome) bool {
+	return p.OffchainConfig.ReducedConfidenceReports && outcome.DegradedRounds >= p.OffchainConfig.DegradedModeThreshold()
+}
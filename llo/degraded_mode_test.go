@@ -0,0 +1,224 @@
+package llo
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+)
+
+func Test_degradedModeTracker(t *testing.T) {
+	t.Run("nil tracker is never degraded", func(t *testing.T) {
+		var tr *degradedModeTracker
+		degraded, changed, ok := tr.record(1, 100, false, 1)
+		assert.False(t, degraded)
+		assert.False(t, changed)
+		assert.False(t, ok)
+	})
+	t.Run("enters and leaves degraded mode after threshold consecutive rounds", func(t *testing.T) {
+		tr := &degradedModeTracker{}
+		seqNr := uint64(0)
+		for _, step := range []struct {
+			rounds                     uint64
+			healthy, degraded, changed bool
+		}{
+			{1, false, false, false},
+			{1, false, false, false},
+			// a healthy round resets the count
+			{1, true, false, false},
+			{1, false, false, false},
+			{1, false, false, false},
+			{1, false, true, true},
+			{1, false, true, false},
+			{1, true, true, false},
+			{1, true, true, false},
+			// a missed round resets the count
+			{1, false, true, false},
+			{1, true, true, false},
+			{1, true, true, false},
+			{1, true, false, true},
+			// a gap of at least threshold rounds enters degraded mode at once
+			{5, false, true, true},
+		} {
+			seqNr += step.rounds
+			degraded, changed, ok := tr.record(seqNr, step.rounds, step.healthy, 3)
+			assert.True(t, ok)
+			assert.Equal(t, step.degraded, degraded, "%+v", step)
+			assert.Equal(t, step.changed, changed, "%+v", step)
+		}
+	})
+	t.Run("records each round once", func(t *testing.T) {
+		tr := &degradedModeTracker{}
+		_, _, ok := tr.record(5, 1, false, 2)
+		assert.True(t, ok)
+		for _, seqNr := range []uint64{5, 4} {
+			degraded, changed, ok := tr.record(seqNr, 1, false, 2)
+			assert.False(t, ok)
+			assert.False(t, degraded)
+			assert.False(t, changed)
+		}
+		degraded, changed, ok := tr.record(6, 1, false, 2)
+		assert.True(t, ok)
+		assert.True(t, degraded)
+		assert.True(t, changed)
+	})
+}
+
+func Test_Outcome_DegradedRounds(t *testing.T) {
+	ctx := tests.Context(t)
+	cd := types.ConfigDigest{0xd3}
+	p := &Plugin{
+		Config:           Config{true},
+		ConfigDigest:     cd,
+		OutcomeCodec:     protoOutcomeCodec{},
+		Logger:           logger.Test(t),
+		ObservationCodec: protoObservationCodec{},
+		F:                1,
+		OffchainConfig:   OffchainConfig{DegradedModeRounds: 2, ReducedConfidenceReports: true},
+		degraded:         &degradedModeTracker{},
+		ReportCodecs: map[llotypes.ReportFormat]ReportCodec{
+			llotypes.ReportFormatJSON: JSONReportCodec{},
+		},
+		TransmissionTargets: NewTransmissionTargets(),
+	}
+	definitions := llotypes.ChannelDefinitions{
+		1: {
+			ReportFormat: llotypes.ReportFormatJSON,
+			Streams:      []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}},
+		},
+	}
+	previousOutcome, err := p.OutcomeCodec.Encode(Outcome{
+		LifeCycleStage:                   LifeCycleStageProduction,
+		ObservationsTimestampNanoseconds: int64(100 * time.Second),
+		ChannelDefinitions:               definitions,
+		ValidAfterSeconds:                map[llotypes.ChannelID]uint32{1: 99},
+	})
+	require.NoError(t, err)
+
+	// round generates the outcome and reports of a round in which only the
+	// first valid observers of the 4 oracles send a valid observation, and
+	// only the first contributors of them observe the stream
+	round := func(seqNr uint64, valid, contributors int) (Outcome, Report) {
+		aos := []types.AttributedObservation{}
+		for i := 0; i < 4; i++ {
+			// a truncated varint, which does not decode
+			encoded := []byte{0xff}
+			if i < valid {
+				obs := Observation{UnixTimestampNanoseconds: int64(time.Duration(100+seqNr) * time.Second)}
+				if i < contributors {
+					obs.StreamValues = StreamValues{1: ToDecimal(decimal.NewFromInt(100))}
+				}
+				encoded, err = p.ObservationCodec.Encode(obs)
+				require.NoError(t, err)
+			}
+			aos = append(aos, types.AttributedObservation{Observation: encoded, Observer: commontypes.OracleID(i)})
+		}
+		outcome, err2 := p.Outcome(ctx, ocr3types.OutcomeContext{SeqNr: seqNr, PreviousOutcome: previousOutcome}, types.Query{}, aos)
+		require.NoError(t, err2)
+		previousOutcome = outcome
+		decoded, err2 := p.OutcomeCodec.Decode(outcome)
+		require.NoError(t, err2)
+
+		rwis, err2 := p.Reports(ctx, seqNr, outcome)
+		require.NoError(t, err2)
+		require.Len(t, rwis, 1)
+		report, err2 := JSONReportCodec{}.Decode(rwis[0].ReportWithInfo.Report)
+		require.NoError(t, err2)
+		assert.Equal(t, report.ReducedConfidence, p.TransmissionTargets.ReducedConfidence(p.ConfigDigest, seqNr, rwis[0].ReportWithInfo.Report), "the transmitter can pass the flag on")
+		return decoded, report
+	}
+
+	outcome, report := round(2, 3, 3)
+	assert.Zero(t, outcome.DegradedRounds)
+	assert.False(t, report.ReducedConfidence)
+	assert.Equal(t, float64(0), testutil.ToFloat64(promOutcomeDegradedRounds.WithLabelValues(cd.String())))
+
+	outcome, report = round(3, 2, 2)
+	assert.Equal(t, uint32(1), outcome.DegradedRounds)
+	assert.False(t, report.ReducedConfidence)
+	assert.Equal(t, float64(1), testutil.ToFloat64(promOutcomeDegradedRounds.WithLabelValues(cd.String())))
+	assert.Equal(t, float64(0), testutil.ToFloat64(promDegradedMode.WithLabelValues(cd.String())))
+
+	// all observations are valid, but the stream is only based on 2
+	outcome, report = round(4, 4, 2)
+	assert.Equal(t, uint32(2), outcome.DegradedRounds)
+	assert.True(t, report.ReducedConfidence)
+	assert.Equal(t, float64(1), testutil.ToFloat64(promDegradedMode.WithLabelValues(cd.String())))
+	assert.Equal(t, float64(2), testutil.ToFloat64(promDegradedRoundsTotal.WithLabelValues(cd.String())))
+
+	// generating the reports of a round again does not count it twice
+	rwis, err := p.Reports(ctx, 4, previousOutcome)
+	require.NoError(t, err)
+	require.Len(t, rwis, 1)
+	assert.Equal(t, float64(2), testutil.ToFloat64(promDegradedRoundsTotal.WithLabelValues(cd.String())))
+
+	// a single healthy outcome stops marking reports, but degraded mode
+	// lasts for DegradedModeRounds healthy rounds
+	outcome, report = round(5, 4, 4)
+	assert.Zero(t, outcome.DegradedRounds)
+	assert.False(t, report.ReducedConfidence)
+	assert.Equal(t, float64(1), testutil.ToFloat64(promDegradedMode.WithLabelValues(cd.String())))
+	round(6, 4, 4)
+	assert.Equal(t, float64(0), testutil.ToFloat64(promDegradedMode.WithLabelValues(cd.String())))
+
+	t.Run("DegradedRounds saturates", func(t *testing.T) {
+		assert.Equal(t, uint32(math.MaxUint32), p.degradedRounds(Outcome{DegradedRounds: math.MaxUint32}, 0))
+	})
+	t.Run("streams of no channel do not degrade the round", func(t *testing.T) {
+		aos := []types.AttributedObservation{}
+		for i := 0; i < 4; i++ {
+			obs := Observation{UnixTimestampNanoseconds: int64(200 * time.Second), StreamValues: StreamValues{1: ToDecimal(decimal.NewFromInt(100))}}
+			if i == 0 {
+				// a faulty oracle observes a stream that no channel has
+				obs.StreamValues[99] = ToDecimal(decimal.NewFromInt(1))
+			}
+			encoded, err2 := p.ObservationCodec.Encode(obs)
+			require.NoError(t, err2)
+			aos = append(aos, types.AttributedObservation{Observation: encoded, Observer: commontypes.OracleID(i)})
+		}
+		encoded, err2 := p.Outcome(ctx, ocr3types.OutcomeContext{SeqNr: 100, PreviousOutcome: previousOutcome}, types.Query{}, aos)
+		require.NoError(t, err2)
+		outcome, err2 := p.OutcomeCodec.Decode(encoded)
+		require.NoError(t, err2)
+		assert.Zero(t, outcome.DegradedRounds)
+	})
+	t.Run("reports are not marked unless ReducedConfidenceReports is set", func(t *testing.T) {
+		p.OffchainConfig.ReducedConfidenceReports = false
+		round(7, 2, 2)
+		outcome, report := round(8, 2, 2)
+		assert.Equal(t, uint32(2), outcome.DegradedRounds)
+		assert.False(t, report.ReducedConfidence)
+	})
+}
+
+func Test_roundContributors(t *testing.T) {
+	v := ToDecimal(decimal.NewFromInt(1))
+	outcome := &Outcome{
+		ChannelDefinitions: llotypes.ChannelDefinitions{
+			1: {Streams: []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}, {StreamID: 2, Aggregator: llotypes.AggregatorMedian}}},
+			2: {Streams: []llotypes.Stream{{StreamID: 3, Aggregator: llotypes.AggregatorMedian}}},
+		},
+		StreamAggregates: StreamAggregates{
+			1: {llotypes.AggregatorMedian: v},
+			2: {llotypes.AggregatorMedian: v},
+		},
+	}
+	assert.Equal(t, 2, roundContributors(nil, 4, outcome, map[llotypes.StreamID][]StreamValue{1: {v, v, v, v}, 2: {v, v}}))
+	// streams that could not be aggregated do not count
+	assert.Equal(t, 4, roundContributors(nil, 4, outcome, map[llotypes.StreamID][]StreamValue{1: {v, v, v, v}, 2: {v, v, v, v}, 3: {v}}))
+	// neither do streams of no channel
+	assert.Equal(t, 4, roundContributors(nil, 4, outcome, map[llotypes.StreamID][]StreamValue{1: {v, v, v, v}, 2: {v, v, v, v}, 4: {v}}))
+	assert.Equal(t, 3, roundContributors(nil, 3, &Outcome{}, nil))
+}
@@ -0,0 +1,97 @@
+package llo
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+// DispersionMetric measures how far apart the oracles' observations of a
+// stream are
+type DispersionMetric string
+
+const (
+	// DispersionMetricIQR is the interquartile range of the observations
+	DispersionMetricIQR DispersionMetric = "iqr"
+)
+
+// DispersionOpts are channel opts that append a dispersion metric of the
+// channel's primary (i.e. first) stream to its report values, giving
+// consumers a signal of how tight consensus was.
+//
+// They may be combined with any codec-specific opts, but are only useful for
+// report formats that accept a variable number of values, such as JSON, or
+// that encode the dispersion, such as EVMPremiumLegacyReportCodec.
+type DispersionOpts struct {
+	Dispersion DispersionMetric `json:"dispersion,omitempty"`
+}
+
+func (o DispersionOpts) validate() error {
+	if o.Dispersion != "" && GetDispersionFunc(o.Dispersion) == nil {
+		return fmt.Errorf("unknown dispersion metric: %q", o.Dispersion)
+	}
+	return nil
+}
+
+// ParseDispersionMetric extracts the dispersion metric from a channel's
+// opts, returning an empty metric if none is set. Other fields in the opts
+// are ignored.
+func ParseDispersionMetric(opts llotypes.ChannelOpts) (DispersionMetric, error) {
+	if len(opts) == 0 {
+		return "", nil
+	}
+	var o DispersionOpts
+	if err := json.Unmarshal(opts, &o); err != nil {
+		return "", fmt.Errorf("invalid channel opts: %w", err)
+	}
+	if err := o.validate(); err != nil {
+		return "", fmt.Errorf("invalid channel opts: %w", err)
+	}
+	return o.Dispersion, nil
+}
+
+func GetDispersionFunc(m DispersionMetric) AggregatorFunc {
+	switch m {
+	case DispersionMetricIQR:
+		return InterquartileRangeAggregator
+	default:
+		return nil
+	}
+}
+
+// InterquartileRangeAggregator returns Q3-Q1 of the observations, where the
+// quartiles are picked by rank in the same way as MedianAggregator picks the
+// median.
+//
+// Quartile ranks are clamped to [f, n-1-f] so that both quartiles are bounded
+// by honest observations even if f of them are faulty. This requires at
+// least 2f+1 observations.
+func InterquartileRangeAggregator(values []StreamValue, f int) (StreamValue, error) {
+	observations := make([]decimal.Decimal, 0, len(values))
+	for _, value := range values {
+		switch v := value.(type) {
+		case *Decimal:
+			observations = append(observations, v.Decimal())
+		case *Quote:
+			observations = append(observations, v.Benchmark)
+		default:
+			// Unexpected type, skip
+			continue
+		}
+	}
+	n := len(observations)
+	if n < 2*f+1 {
+		return nil, fmt.Errorf("not enough observations to calculate interquartile range, expected at least 2f+1, got %d", n)
+	}
+	lo, hi := max(n/4, f), min(3*n/4, n-1-f)
+	// Selecting hi leaves the lo-th smallest in observations[:hi]
+	q3 := selectKth(observations, hi, compareDecimals)
+	q1 := q3
+	if lo < hi {
+		q1 = selectKth(observations[:hi], lo, compareDecimals)
+	}
+	return ToDecimal(q3.Sub(q1)), nil
+}
@@ -0,0 +1,92 @@
+package llo
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseDispersionMetric(t *testing.T) {
+	t.Run("empty opts", func(t *testing.T) {
+		m, err := ParseDispersionMetric(nil)
+		require.NoError(t, err)
+		assert.Equal(t, DispersionMetric(""), m)
+	})
+	t.Run("opts without dispersion", func(t *testing.T) {
+		m, err := ParseDispersionMetric([]byte(`{"foo":"bar"}`))
+		require.NoError(t, err)
+		assert.Equal(t, DispersionMetric(""), m)
+	})
+	t.Run("iqr", func(t *testing.T) {
+		m, err := ParseDispersionMetric([]byte(`{"dispersion":"iqr","foo":"bar"}`))
+		require.NoError(t, err)
+		assert.Equal(t, DispersionMetricIQR, m)
+	})
+	t.Run("unknown metric", func(t *testing.T) {
+		_, err := ParseDispersionMetric([]byte(`{"dispersion":"stddev"}`))
+		assert.EqualError(t, err, `invalid channel opts: unknown dispersion metric: "stddev"`)
+	})
+	t.Run("invalid JSON", func(t *testing.T) {
+		_, err := ParseDispersionMetric([]byte(`not json`))
+		assert.ErrorContains(t, err, "invalid channel opts")
+	})
+}
+
+func Test_InterquartileRangeAggregator(t *testing.T) {
+	values := []StreamValue{
+		ToDecimal(decimal.NewFromInt(5)),
+		ToDecimal(decimal.NewFromInt(1)),
+		ToDecimal(decimal.NewFromInt(7)),
+		ToDecimal(decimal.NewFromInt(3)),
+		ToDecimal(decimal.NewFromInt(2)),
+		ToDecimal(decimal.NewFromInt(8)),
+		ToDecimal(decimal.NewFromInt(4)),
+		ToDecimal(decimal.NewFromInt(6)),
+	}
+
+	t.Run("returns interquartile range", func(t *testing.T) {
+		// sorted: 1 2 3 4 5 6 7 8; Q1 = 3, Q3 = 7
+		sv, err := InterquartileRangeAggregator(values, 1)
+		require.NoError(t, err)
+		assert.IsType(t, &Decimal{}, sv)
+		assert.Equal(t, "4", sv.(*Decimal).String())
+	})
+
+	t.Run("clamps quartiles so that f faulty values cannot be picked", func(t *testing.T) {
+		// sorted: 1 2 3 4 5 6 7 8; ranks clamped to [3, 4]
+		sv, err := InterquartileRangeAggregator(values, 3)
+		require.NoError(t, err)
+		assert.Equal(t, "1", sv.(*Decimal).String())
+
+		extreme := append([]StreamValue{ToDecimal(decimal.NewFromInt(-1_000_000))}, values[:3]...)
+		sv, err = InterquartileRangeAggregator(extreme, 1)
+		require.NoError(t, err)
+		// sorted: -1000000 1 5 7; ranks clamped to [1, 2]
+		assert.Equal(t, "4", sv.(*Decimal).String())
+	})
+
+	t.Run("for stream values of type *Quote, uses the Benchmark value", func(t *testing.T) {
+		mixedValues := []StreamValue{
+			&Quote{Benchmark: decimal.NewFromFloat(1.1)},
+			&Quote{Benchmark: decimal.NewFromFloat(2.2)},
+			ToDecimal(decimal.NewFromFloat(3.3)),
+			ToDecimal(decimal.NewFromFloat(4.4)),
+		}
+		sv, err := InterquartileRangeAggregator(mixedValues, 1)
+		require.NoError(t, err)
+		// ranks clamped to [1, 2]
+		assert.Equal(t, "1.1", sv.(*Decimal).String())
+	})
+
+	t.Run("fails with fewer than 2f+1 values", func(t *testing.T) {
+		_, err := InterquartileRangeAggregator(values[:4], 2)
+		assert.EqualError(t, err, "not enough observations to calculate interquartile range, expected at least 2f+1, got 4")
+	})
+
+	t.Run("ignores unsupported StreamValue types", func(t *testing.T) {
+		_, err := InterquartileRangeAggregator([]StreamValue{nil, nil, nil}, 1)
+		assert.EqualError(t, err, "not enough observations to calculate interquartile range, expected at least 2f+1, got 0")
+	})
+}
@@ -0,0 +1,180 @@
+package llo
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Minimal helpers for Solidity ABI encoding of the static types and byte
+// strings used by EVM report formats. Every value occupies one or more
+// 32-byte words.
+
+const abiWordSize = 32
+
+type abiWord [abiWordSize]byte
+
+func abiUint64(v uint64) (w abiWord) {
+	binary.BigEndian.PutUint64(w[24:], v)
+	return w
+}
+
+// abiInt encodes v as a two's complement integer of the given bit size,
+// returning an error if it does not fit
+func abiInt(v *big.Int, bits int, signed bool) (w abiWord, err error) {
+	if v == nil {
+		return w, errors.New("nil value")
+	}
+	var lo, hi *big.Int
+	if signed {
+		hi = new(big.Int).Lsh(big.NewInt(1), uint(bits-1))
+		lo = new(big.Int).Neg(hi)
+		hi.Sub(hi, big.NewInt(1))
+	} else {
+		lo = new(big.Int)
+		hi = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(bits)), big.NewInt(1))
+	}
+	if v.Cmp(lo) < 0 || v.Cmp(hi) > 0 {
+		kind := "uint"
+		if signed {
+			kind = "int"
+		}
+		return w, fmt.Errorf("value %s overflows %s%d", v, kind, bits)
+	}
+	if v.Sign() >= 0 {
+		v.FillBytes(w[:])
+		return w, nil
+	}
+	// two's complement over the full word; sign extension is implied
+	twos := new(big.Int).Add(new(big.Int).Lsh(big.NewInt(1), 256), v)
+	twos.FillBytes(w[:])
+	return w, nil
+}
+
+// abiDecodeInt is the inverse of abiInt
+func abiDecodeInt(w abiWord, signed bool) *big.Int {
+	v := new(big.Int).SetBytes(w[:])
+	if signed && w[0]&0x80 != 0 {
+		v.Sub(v, new(big.Int).Lsh(big.NewInt(1), 256))
+	}
+	return v
+}
+
+// abiBytesTail encodes a dynamic byte string as its length followed by the
+// data right-padded to a multiple of the word size
+func abiBytesTail(b []byte) []byte {
+	padded := (len(b) + abiWordSize - 1) / abiWordSize * abiWordSize
+	out := make([]byte, abiWordSize+padded)
+	l := abiUint64(uint64(len(b)))
+	copy(out, l[:])
+	copy(out[abiWordSize:], b)
+	return out
+}
+
+// abiWordsTail encodes a dynamic array of 32-byte values as its length
+// followed by the values
+func abiWordsTail(ws []abiWord) []byte {
+	out := make([]byte, 0, abiWordSize*(1+len(ws)))
+	l := abiUint64(uint64(len(ws)))
+	out = append(out, l[:]...)
+	for _, w := range ws {
+		out = append(out, w[:]...)
+	}
+	return out
+}
+
+// abiReadWord returns the word at index i
+func abiReadWord(b []byte, i int) (w abiWord, err error) {
+	start := i * abiWordSize
+	if i < 0 || len(b) < start+abiWordSize {
+		return w, fmt.Errorf("word %d out of bounds (len: %d)", i, len(b))
+	}
+	copy(w[:], b[start:])
+	return w, nil
+}
+
+// abiReadUint reads the word at index i as an unsigned integer that must fit
+// in max
+func abiReadUint(b []byte, i int, max uint64) (uint64, error) {
+	w, err := abiReadWord(b, i)
+	if err != nil {
+		return 0, err
+	}
+	for _, c := range w[:24] {
+		if c != 0 {
+			return 0, fmt.Errorf("word %d overflows uint64", i)
+		}
+	}
+	v := binary.BigEndian.Uint64(w[24:])
+	if v > max {
+		return 0, fmt.Errorf("word %d value %d exceeds maximum %d", i, v, max)
+	}
+	return v, nil
+}
+
+// abiReadBytes reads the dynamic byte string whose offset is stored in the
+// word at index i
+func abiReadBytes(b []byte, i int) ([]byte, error) {
+	offset, err := abiReadUint(b, i, uint64(len(b)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid offset: %w", err)
+	}
+	rest := b[offset:]
+	l, err := abiReadUint(rest, 0, uint64(len(rest)-abiWordSize))
+	if err != nil {
+		return nil, fmt.Errorf("invalid length: %w", err)
+	}
+	return rest[abiWordSize : abiWordSize+l], nil
+}
+
+// abiReadWords reads the dynamic array of 32-byte values whose offset is
+// stored in the word at index i
+func abiReadWords(b []byte, i int) ([]abiWord, error) {
+	offset, err := abiReadUint(b, i, uint64(len(b)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid offset: %w", err)
+	}
+	rest := b[offset:]
+	l, err := abiReadUint(rest, 0, uint64(len(rest)/abiWordSize))
+	if err != nil {
+		return nil, fmt.Errorf("invalid length: %w", err)
+	}
+	ws := make([]abiWord, l)
+	for j := range ws {
+		if ws[j], err = abiReadWord(rest, j+1); err != nil {
+			return nil, err
+		}
+	}
+	return ws, nil
+}
+
+// abiTupleEncoder encodes a tuple of static values, retaining the first error
+type abiTupleEncoder struct {
+	b   []byte
+	err error
+}
+
+func (e *abiTupleEncoder) word(w abiWord) {
+	e.b = append(e.b, w[:]...)
+}
+
+func (e *abiTupleEncoder) uint32(v uint32) {
+	e.word(abiUint64(uint64(v)))
+}
+
+func (e *abiTupleEncoder) int(name string, v *big.Int, bits int, signed bool) {
+	if e.err != nil {
+		return
+	}
+	w, err := abiInt(v, bits, signed)
+	if err != nil {
+		e.err = fmt.Errorf("failed to encode %s: %w", name, err)
+		return
+	}
+	e.word(w)
+}
+
+func (e *abiTupleEncoder) bytes() ([]byte, error) {
+	return e.b, e.err
+}
@@ -0,0 +1,62 @@
+package llo
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_abiInt(t *testing.T) {
+	t.Run("encodes positive and negative values", func(t *testing.T) {
+		w, err := abiInt(big.NewInt(1), 192, true)
+		require.NoError(t, err)
+		assert.Equal(t, abiUint64(1), w)
+
+		w, err = abiInt(big.NewInt(-1), 192, true)
+		require.NoError(t, err)
+		for _, c := range w {
+			assert.Equal(t, byte(0xff), c)
+		}
+		assert.Equal(t, big.NewInt(-1), abiDecodeInt(w, true))
+
+		w, err = abiInt(big.NewInt(-256), 192, true)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(-256), abiDecodeInt(w, true))
+	})
+	t.Run("checks bounds", func(t *testing.T) {
+		maxInt192 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 191), big.NewInt(1))
+		_, err := abiInt(maxInt192, 192, true)
+		require.NoError(t, err)
+		_, err = abiInt(new(big.Int).Add(maxInt192, big.NewInt(1)), 192, true)
+		assert.EqualError(t, err, "value 3138550867693340381917894711603833208051177722232017256448 overflows int192")
+
+		minInt192 := new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 191))
+		_, err = abiInt(minInt192, 192, true)
+		require.NoError(t, err)
+		_, err = abiInt(new(big.Int).Sub(minInt192, big.NewInt(1)), 192, true)
+		assert.Error(t, err)
+
+		_, err = abiInt(big.NewInt(-1), 192, false)
+		assert.EqualError(t, err, "value -1 overflows uint192")
+		_, err = abiInt(nil, 192, false)
+		assert.EqualError(t, err, "nil value")
+	})
+}
+
+func Test_abiBytesTail(t *testing.T) {
+	assert.Len(t, abiBytesTail(nil), 32)
+	assert.Len(t, abiBytesTail([]byte{1}), 64)
+	assert.Len(t, abiBytesTail(make([]byte, 32)), 64)
+	assert.Len(t, abiBytesTail(make([]byte, 33)), 96)
+
+	offset := abiUint64(32)
+	b := append(offset[:], abiBytesTail([]byte("foo"))...)
+	decoded, err := abiReadBytes(b, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("foo"), decoded)
+
+	_, err = abiReadBytes(b[:40], 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,184 @@
+package llo
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	ocr2types "github.com/smartcontractkit/libocr/offchainreporting2/types"
+	bn256 "github.com/umbracle/go-eth-bn256"
+	"golang.org/x/crypto/sha3"
+)
+
+// EVM BLS payloads replace the per-oracle signatures of EVM payloads with a
+// single aggregate BLS signature, so that verification costs one pairing
+// check regardless of the number of signers:
+//
+//	abi.encode(bytes32[3] reportContext, bytes report, uint256 signers, uint256[2] signature, uint256[4] aggregateG2PublicKey)
+//
+// Bit i of signers is set if oracle i contributed to the signature.
+// Points are encoded as for the EIP-196/197 precompiles.
+
+const (
+	// signers is a single uint256 bitmap
+	evmBLSMaxSigners = 256
+	// reportContext (3 words) + offset + signers + signature (2 words) +
+	// aggregateG2PublicKey (4 words)
+	evmBLSPayloadHeadWords = 11
+)
+
+// EVMBLSPayloadPacker packs reports attested by BLSOnchainKeyring into EVM
+// BLS payloads. It is an alternative to PackEVMPayload for verifiers on
+// chains with BN254 precompiles.
+//
+// A verifier contract holding the G1 halves of the signer keys checks a
+// payload as follows (see Verify):
+//
+//  1. apk1 = sum of the G1 public keys of the signers (ecAdd)
+//  2. h = hash_to_curve(EVMReportSigningHash(reportContext, report)), as
+//     specified by RFC 9380 for BN254G1_XMD:SHA-256_SVDW_RO_ with the
+//     signature domain separation tag of BLSOnchainKeyring
+//  3. γ = keccak256(signingHash || apk1 || aggregateG2PublicKey || signature) mod n
+//  4. e(signature + γ·apk1, -g2)·e(h + γ·g1, aggregateG2PublicKey) == 1 (ecPairing)
+//
+// The last check proves both that aggregateG2PublicKey matches apk1 and that
+// the signature is valid, so the contract never needs G2 arithmetic.
+type EVMBLSPayloadPacker struct {
+	// SignerKeys are the BLSOnchainKeyring public keys of the oracles,
+	// indexed by oracle ID
+	SignerKeys []types.OnchainPublicKey
+}
+
+func (p EVMBLSPayloadPacker) Pack(digest types.ConfigDigest, seqNr uint64, report ocr2types.Report, sigs []types.AttributedOnchainSignature) ([]byte, error) {
+	rc, err := EVMReportContext(digest, seqNr)
+	if err != nil {
+		return nil, err
+	}
+	if len(p.SignerKeys) > evmBLSMaxSigners {
+		return nil, fmt.Errorf("too many signer keys; got: %d, max: %d", len(p.SignerKeys), evmBLSMaxSigners)
+	}
+	if len(sigs) == 0 {
+		return nil, errors.New("no signatures to aggregate")
+	}
+
+	var signers abiWord
+	signatures := make([][]byte, len(sigs))
+	keys := make([]types.OnchainPublicKey, len(sigs))
+	for i, as := range sigs {
+		if int(as.Signer) >= len(p.SignerKeys) {
+			return nil, fmt.Errorf("unknown signer %d", as.Signer)
+		}
+		if len(as.Signature) != BLSSignatureLength {
+			return nil, fmt.Errorf("invalid signature length for signer %d; expected: %d, got: %d", as.Signer, BLSSignatureLength, len(as.Signature))
+		}
+		byteIdx, bit := abiWordSize-1-int(as.Signer)/8, byte(1)<<(as.Signer%8)
+		if signers[byteIdx]&bit != 0 {
+			return nil, fmt.Errorf("duplicate signature for signer %d", as.Signer)
+		}
+		signers[byteIdx] |= bit
+		signatures[i] = as.Signature
+		keys[i] = p.SignerKeys[as.Signer]
+	}
+	signature, err := AggregateBLSSignatures(signatures)
+	if err != nil {
+		return nil, err
+	}
+	apk, err := AggregateBLSPublicKeys(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	reportTail := abiBytesTail(report)
+	b := make([]byte, 0, evmBLSPayloadHeadWords*abiWordSize+len(reportTail))
+	for _, w := range rc {
+		b = append(b, w[:]...)
+	}
+	reportOffset := abiUint64(evmBLSPayloadHeadWords * abiWordSize)
+	b = append(b, reportOffset[:]...)
+	b = append(b, signers[:]...)
+	b = append(b, signature...)
+	b = append(b, apk[blsG1Length:]...)
+	b = append(b, reportTail...)
+	return b, nil
+}
+
+// Verify checks an EVM BLS payload in the same way as a verifier contract
+// would. At least minSigners distinct signers must have contributed.
+func (p EVMBLSPayloadPacker) Verify(b []byte, minSigners int) error {
+	digest, seqNr, report, signers, signature, err := UnpackEVMBLSPayload(b)
+	if err != nil {
+		return err
+	}
+	if len(signers) < minSigners {
+		return fmt.Errorf("not enough signers; got: %d, expected at least: %d", len(signers), minSigners)
+	}
+	keys := make([]types.OnchainPublicKey, len(signers))
+	for i, s := range signers {
+		if int(s) >= len(p.SignerKeys) {
+			return fmt.Errorf("unknown signer %d", s)
+		}
+		keys[i] = p.SignerKeys[s]
+	}
+	apk, err := AggregateBLSPublicKeys(keys)
+	if err != nil {
+		return err
+	}
+	apk1, _ := unmarshalG1(apk[:blsG1Length])
+	apk2Bytes := b[(evmBLSPayloadHeadWords-4)*abiWordSize : evmBLSPayloadHeadWords*abiWordSize]
+	apk2, ok := unmarshalG2(apk2Bytes)
+	if !ok {
+		return errors.New("invalid aggregate G2 public key")
+	}
+	sig, ok := unmarshalG1(signature)
+	if !ok {
+		return errors.New("invalid aggregate signature")
+	}
+	signingHash, err := EVMReportSigningHash(digest, seqNr, report)
+	if err != nil {
+		return err
+	}
+	h := hashToG1(signingHash[:], blsSignatureDST)
+
+	gh := sha3.NewLegacyKeccak256()
+	gh.Write(signingHash[:])
+	gh.Write(apk1.Marshal())
+	gh.Write(apk2Bytes)
+	gh.Write(signature)
+	gamma := new(big.Int).SetBytes(gh.Sum(nil))
+	gamma.Mod(gamma, bn256.Order)
+
+	lhs := new(bn256.G1).Add(sig, new(bn256.G1).ScalarMult(apk1, gamma))
+	rhs := new(bn256.G1).Add(h, new(bn256.G1).ScalarBaseMult(gamma))
+	if !blsPairingCheck(lhs, rhs, apk2) {
+		return errors.New("invalid aggregate signature")
+	}
+	return nil
+}
+
+// UnpackEVMBLSPayload is the inverse of EVMBLSPayloadPacker.Pack. It does not
+// verify the signature.
+func UnpackEVMBLSPayload(b []byte) (digest types.ConfigDigest, seqNr uint64, report ocr2types.Report, signers []commontypes.OracleID, signature []byte, err error) {
+	if len(b) < evmBLSPayloadHeadWords*abiWordSize {
+		return digest, seqNr, report, signers, signature, fmt.Errorf("failed to unpack EVM BLS payload: too short (len: %d)", len(b))
+	}
+	rc0, _ := abiReadWord(b, 0)
+	rc1, _ := abiReadWord(b, 1)
+	bitmap, _ := abiReadWord(b, 4)
+	digest = types.ConfigDigest(rc0)
+	seqNr = EpochAndRoundToSeqNr(binary.BigEndian.Uint32(rc1[27:31]), rc1[31])
+
+	report, err = abiReadBytes(b, 3)
+	if err != nil {
+		return digest, seqNr, report, signers, signature, fmt.Errorf("failed to unpack EVM BLS payload report: %w", err)
+	}
+	for i := 0; i < evmBLSMaxSigners; i++ {
+		if bitmap[abiWordSize-1-i/8]&(1<<(i%8)) != 0 {
+			signers = append(signers, commontypes.OracleID(i))
+		}
+	}
+	signature = b[5*abiWordSize : 7*abiWordSize]
+	return digest, seqNr, report, signers, signature, nil
+}
@@ -0,0 +1,121 @@
+package llo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+func Test_EVMBLSPayloadPacker(t *testing.T) {
+	digest := types.ConfigDigest{1, 2, 3}
+	report := []byte("report")
+	rwi := ocr3types.ReportWithInfo[llotypes.ReportInfo]{Report: report}
+
+	const n = 4
+	keyrings := make([]*BLSOnchainKeyring, n)
+	packer := EVMBLSPayloadPacker{SignerKeys: make([]types.OnchainPublicKey, n)}
+	for i := range keyrings {
+		keyrings[i] = newTestBLSOnchainKeyring(t)
+		packer.SignerKeys[i] = keyrings[i].PublicKey()
+	}
+	sign := func(t *testing.T, seqNr uint64, signers ...commontypes.OracleID) []types.AttributedOnchainSignature {
+		sigs := make([]types.AttributedOnchainSignature, len(signers))
+		for i, s := range signers {
+			sig, err := keyrings[s].Sign(digest, seqNr, rwi)
+			require.NoError(t, err)
+			sigs[i] = types.AttributedOnchainSignature{Signature: sig, Signer: s}
+		}
+		return sigs
+	}
+
+	t.Run("packs, unpacks and verifies", func(t *testing.T) {
+		b, err := packer.Pack(digest, 258, report, sign(t, 258, 3, 0, 2))
+		require.NoError(t, err)
+		assert.Len(t, b, evmBLSPayloadHeadWords*abiWordSize+2*abiWordSize)
+
+		cd, seqNr, r, signers, signature, err := UnpackEVMBLSPayload(b)
+		require.NoError(t, err)
+		assert.Equal(t, digest, cd)
+		assert.Equal(t, uint64(258), seqNr)
+		assert.Equal(t, report, []byte(r))
+		assert.Equal(t, []commontypes.OracleID{0, 2, 3}, signers)
+		assert.Len(t, signature, BLSSignatureLength)
+
+		// The report context is the same as for EVM payloads
+		rc, err := EVMReportContext(digest, 258)
+		require.NoError(t, err)
+		assert.Equal(t, rc[0][:], b[0:32])
+		assert.Equal(t, rc[1][:], b[32:64])
+		assert.Equal(t, rc[2][:], b[64:96])
+		assert.Equal(t, byte(0b1101), b[5*abiWordSize-1], "signers bitmap")
+
+		require.NoError(t, packer.Verify(b, 3))
+		assert.EqualError(t, packer.Verify(b, 4), "not enough signers; got: 3, expected at least: 4")
+	})
+	t.Run("verify rejects tampered payloads", func(t *testing.T) {
+		b, err := packer.Pack(digest, 258, report, sign(t, 258, 0, 1))
+		require.NoError(t, err)
+
+		tampered := append([]byte{}, b...)
+		tampered[len(tampered)-1] ^= 1 // report padding is not part of the report
+		tampered[evmBLSPayloadHeadWords*abiWordSize+abiWordSize] ^= 1
+		assert.EqualError(t, packer.Verify(tampered, 2), "invalid aggregate signature")
+
+		// claim a signer that did not sign
+		tampered = append([]byte{}, b...)
+		tampered[5*abiWordSize-1] |= 0b100
+		assert.EqualError(t, packer.Verify(tampered, 2), "invalid aggregate signature")
+
+		// different seqNr
+		tampered = append([]byte{}, b...)
+		tampered[2*abiWordSize-1]++
+		assert.EqualError(t, packer.Verify(tampered, 2), "invalid aggregate signature")
+
+		// G2 key does not match the signers
+		other, err := EVMBLSPayloadPacker{SignerKeys: []types.OnchainPublicKey{packer.SignerKeys[2], packer.SignerKeys[3]}}.Pack(digest, 258, report, []types.AttributedOnchainSignature{{Signature: sign(t, 258, 2)[0].Signature, Signer: 0}, {Signature: sign(t, 258, 3)[0].Signature, Signer: 1}})
+		require.NoError(t, err)
+		tampered = append([]byte{}, b...)
+		copy(tampered[7*abiWordSize:11*abiWordSize], other[7*abiWordSize:11*abiWordSize])
+		assert.EqualError(t, packer.Verify(tampered, 2), "invalid aggregate signature")
+
+		tampered[7*abiWordSize]++
+		assert.EqualError(t, packer.Verify(tampered, 2), "invalid aggregate G2 public key")
+
+		tampered = append([]byte{}, b...)
+		tampered[5*abiWordSize-1] |= 0b10000
+		assert.EqualError(t, packer.Verify(tampered, 2), "unknown signer 4")
+	})
+	t.Run("validates signatures", func(t *testing.T) {
+		_, err := packer.Pack(digest, 1, report, nil)
+		assert.EqualError(t, err, "no signatures to aggregate")
+
+		_, err = packer.Pack(digest, 1, report, []types.AttributedOnchainSignature{{Signature: []byte{1}, Signer: 2}})
+		assert.EqualError(t, err, "invalid signature length for signer 2; expected: 64, got: 1")
+
+		_, err = packer.Pack(digest, 1, report, []types.AttributedOnchainSignature{{Signature: make([]byte, 64), Signer: 4}})
+		assert.EqualError(t, err, "unknown signer 4")
+
+		sigs := sign(t, 1, 1)
+		_, err = packer.Pack(digest, 1, report, append(sigs, sigs...))
+		assert.EqualError(t, err, "duplicate signature for signer 1")
+
+		_, err = EVMBLSPayloadPacker{SignerKeys: make([]types.OnchainPublicKey, 257)}.Pack(digest, 1, report, sigs)
+		assert.EqualError(t, err, "too many signer keys; got: 257, max: 256")
+	})
+	t.Run("unpack rejects garbage", func(t *testing.T) {
+		_, _, _, _, _, err := UnpackEVMBLSPayload([]byte("foo"))
+		assert.EqualError(t, err, "failed to unpack EVM BLS payload: too short (len: 3)")
+
+		b := make([]byte, evmBLSPayloadHeadWords*abiWordSize)
+		b[3*32+30] = 0xff
+		_, _, _, _, _, err = UnpackEVMBLSPayload(b)
+		assert.ErrorContains(t, err, "failed to unpack EVM BLS payload report")
+	})
+}
@@ -0,0 +1,106 @@
+package llo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+// FeedID is the 32-byte identifier that legacy Mercury consumers use to key
+// reports. The first two bytes encode the report schema version.
+//
+// Mapping an LLO channel to a FeedID allows reports for that channel to be
+// verified and consumed by existing contracts without changes.
+type FeedID [32]byte
+
+func (f FeedID) Hex() string {
+	return "0x" + hex.EncodeToString(f[:])
+}
+
+func (f FeedID) String() string {
+	return f.Hex()
+}
+
+// Version returns the report schema version encoded in the feed ID, e.g. 3
+// for Mercury v0.3 feeds
+func (f FeedID) Version() uint16 {
+	return binary.BigEndian.Uint16(f[:2])
+}
+
+func (f FeedID) MarshalText() ([]byte, error) {
+	return []byte(f.Hex()), nil
+}
+
+func (f *FeedID) UnmarshalText(text []byte) error {
+	s := strings.TrimPrefix(string(text), "0x")
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return fmt.Errorf("invalid feed ID %q: %w", text, err)
+	}
+	if len(b) != len(f) {
+		return fmt.Errorf("invalid feed ID %q: expected 32 bytes, got %d", text, len(b))
+	}
+	copy(f[:], b)
+	return nil
+}
+
+// EVMFeedIDOpts are the channel opts common to all EVM report formats that
+// embed a feed ID
+type EVMFeedIDOpts struct {
+	SchemaVersionOpts
+	TransmissionTargetsOpts
+	ChannelPriorityOpts
+	AdditionalReportFormatsOpts
+	TransmissionHintsOpts
+	// FeedID is embedded as the first field of every report for the channel
+	FeedID FeedID `json:"feedID"`
+}
+
+// ParseEVMFeedID extracts the feed ID from a channel's opts. Other fields in
+// the opts are ignored.
+func ParseEVMFeedID(opts llotypes.ChannelOpts) (FeedID, error) {
+	if len(opts) == 0 {
+		return FeedID{}, errors.New("missing channel opts: feedID is required")
+	}
+	var o EVMFeedIDOpts
+	if err := json.Unmarshal(opts, &o); err != nil {
+		return FeedID{}, fmt.Errorf("invalid channel opts: %w", err)
+	}
+	if o.FeedID == (FeedID{}) {
+		return FeedID{}, errors.New("invalid channel opts: feedID is required")
+	}
+	return o.FeedID, nil
+}
+
+// decodeEVMChannelOpts strictly decodes channel opts into o, which must embed
+// feedIDOpts, and checks that a feed ID was provided
+func decodeEVMChannelOpts(opts llotypes.ChannelOpts, o any, feedIDOpts *EVMFeedIDOpts) error {
+	if len(opts) == 0 {
+		return errors.New("missing channel opts")
+	}
+	decoder := json.NewDecoder(bytes.NewReader(opts))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(o); err != nil {
+		return fmt.Errorf("invalid channel opts: %w", err)
+	}
+	if feedIDOpts.FeedID == (FeedID{}) {
+		return errors.New("invalid channel opts: feedID is required")
+	}
+	return nil
+}
+
+// FeedIDFromEVMReport returns the feed ID embedded in an ABI-encoded EVM
+// report, which is always its first word
+func FeedIDFromEVMReport(report []byte) (FeedID, error) {
+	w, err := abiReadWord(report, 0)
+	if err != nil {
+		return FeedID{}, fmt.Errorf("report too short to contain feed ID: %w", err)
+	}
+	return FeedID(w), nil
+}
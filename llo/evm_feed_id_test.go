@@ -0,0 +1,52 @@
+package llo
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FeedID(t *testing.T) {
+	const hexFeedID = "0x0003fbba4fce42f65d6032b18aee53efdf526cc734ad296cb57565979d883bdd"
+
+	t.Run("text round trip", func(t *testing.T) {
+		var f FeedID
+		require.NoError(t, f.UnmarshalText([]byte(hexFeedID)))
+		assert.Equal(t, hexFeedID, f.String())
+		assert.Equal(t, uint16(3), f.Version())
+
+		require.NoError(t, f.UnmarshalText([]byte(hexFeedID[2:])))
+		assert.Equal(t, hexFeedID, f.String())
+
+		assert.EqualError(t, f.UnmarshalText([]byte("0x0003")), `invalid feed ID "0x0003": expected 32 bytes, got 2`)
+		assert.Error(t, f.UnmarshalText([]byte("0xzz")))
+	})
+	t.Run("ParseEVMFeedID", func(t *testing.T) {
+		f, err := ParseEVMFeedID([]byte(`{"feedID":"` + hexFeedID + `","other":1}`))
+		require.NoError(t, err)
+		assert.Equal(t, hexFeedID, f.Hex())
+
+		_, err = ParseEVMFeedID(nil)
+		assert.EqualError(t, err, "missing channel opts: feedID is required")
+		_, err = ParseEVMFeedID([]byte(`{}`))
+		assert.EqualError(t, err, "invalid channel opts: feedID is required")
+		_, err = ParseEVMFeedID([]byte(`{"feedID":"0x01"}`))
+		assert.ErrorContains(t, err, "invalid channel opts")
+	})
+	t.Run("JSON", func(t *testing.T) {
+		b, err := json.Marshal(EVMFeedIDOpts{FeedID: FeedID{0, 3}})
+		require.NoError(t, err)
+		assert.Equal(t, `{"feedID":"0x0003000000000000000000000000000000000000000000000000000000000000"}`, string(b))
+	})
+	t.Run("FeedIDFromEVMReport", func(t *testing.T) {
+		feedID := FeedID{0, 3, 1}
+		f, err := FeedIDFromEVMReport(append(feedID[:], 1, 2, 3))
+		require.NoError(t, err)
+		assert.Equal(t, FeedID{0, 3, 1}, f)
+
+		_, err = FeedIDFromEVMReport(make([]byte, 31))
+		assert.Error(t, err)
+	})
+}
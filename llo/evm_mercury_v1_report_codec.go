@@ -0,0 +1,182 @@
+package llo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	ocr2types "github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+	v1 "github.com/smartcontractkit/chainlink-common/pkg/types/mercury/v1"
+)
+
+var _ ReportCodec = EVMMercuryV1ReportCodec{}
+
+// EVMMercuryV1ReportCodec renders channel data in the Mercury v0.1 (v1
+// schema) report format, which is keyed on block ranges rather than
+// timestamps:
+//
+//	abi.encode(bytes32 feedId, uint32 observationsTimestamp, int192 benchmarkPrice, int192 bid, int192 ask, uint64 currentBlockNum, bytes32 currentBlockHash, uint64 validFromBlockNum, uint64 currentBlockTimestamp)
+//
+// Channels must have exactly four streams, in order:
+//  1. The price being reported (Quote)
+//  2. Current block number (Decimal)
+//  3. Current block hash, as an unsigned 256-bit integer (Decimal)
+//  4. Current block timestamp (Decimal)
+//
+// There is no ReportFormat for this schema in chainlink-common; callers
+// should register the codec under whichever format they use for v1 feeds.
+type EVMMercuryV1ReportCodec struct{}
+
+type EVMMercuryV1ReportCodecOpts struct {
+	EVMFeedIDOpts
+	StreamDecimalsOpts
+	StreamValuePolicyOpts
+	// Multiplier scales the quote before it is truncated to an integer, e.g.
+	// 1e18 for 18 decimal places of precision. It is optional if
+	// streamDecimals are set for the quote stream.
+	Multiplier decimal.Decimal `json:"multiplier"`
+	// ValidFromBlockRange is the number of blocks before the current block
+	// from which the report is valid. Legacy v1 reports were valid from the
+	// block after the previous report's current block, which LLO does not
+	// track, so a fixed range is used instead.
+	ValidFromBlockRange uint64 `json:"validFromBlockRange"`
+}
+
+func (o *EVMMercuryV1ReportCodecOpts) Decode(opts []byte) error {
+	if err := decodeEVMChannelOpts(opts, o, &o.EVMFeedIDOpts); err != nil {
+		return err
+	}
+	if o.Multiplier.IsNegative() || (o.Multiplier.IsZero() && len(o.StreamDecimals) == 0) {
+		return errors.New("invalid channel opts: multiplier must be positive")
+	}
+	return nil
+}
+
+// Verify checks that a channel definition can be encoded by this codec
+func (r EVMMercuryV1ReportCodec) Verify(cd llotypes.ChannelDefinition) error {
+	var opts EVMMercuryV1ReportCodecOpts
+	if err := opts.Decode(cd.Opts); err != nil {
+		return err
+	}
+	if len(cd.Streams) != 4 {
+		return fmt.Errorf("expected exactly 4 streams (quote, blockNumber, blockHash, blockTimestamp), got: %d", len(cd.Streams))
+	}
+	if err := opts.StreamDecimalsOpts.verify(cd); err != nil {
+		return err
+	}
+	_, err := opts.priceMultiplier(cd, 0, opts.Multiplier, "quote")
+	return err
+}
+
+func (r EVMMercuryV1ReportCodec) Encode(_ context.Context, report Report, cd llotypes.ChannelDefinition) ([]byte, error) {
+	var opts EVMMercuryV1ReportCodecOpts
+	if err := opts.Decode(cd.Opts); err != nil {
+		return nil, err
+	}
+	if len(report.Values) != 4 {
+		return nil, fmt.Errorf("expected exactly 4 values (quote, blockNumber, blockHash, blockTimestamp), got: %d", len(report.Values))
+	}
+	quote, ok := report.Values[0].(*Quote)
+	if !ok || quote == nil {
+		return nil, fmt.Errorf("expected quote to be Quote, got: %T", report.Values[0])
+	}
+	multiplier, err := opts.priceMultiplier(cd, 0, opts.Multiplier, "quote")
+	if err != nil {
+		return nil, err
+	}
+	blockNum, err := decimalStreamValueToBigInt("blockNumber", report.Values[1], 63)
+	if err != nil {
+		return nil, err
+	}
+	blockHash, err := decimalStreamValueToBigInt("blockHash", report.Values[2], 256)
+	if err != nil {
+		return nil, err
+	}
+	blockTs, err := decimalStreamValueToBigInt("blockTimestamp", report.Values[3], 64)
+	if err != nil {
+		return nil, err
+	}
+
+	validFromBlockNum := int64(0)
+	if opts.ValidFromBlockRange < uint64(blockNum.Int64()) {
+		validFromBlockNum = blockNum.Int64() - int64(opts.ValidFromBlockRange)
+	}
+
+	rf := v1.ReportFields{
+		Timestamp:             report.ObservationTimestampSeconds,
+		BenchmarkPrice:        quote.Benchmark.Mul(multiplier).BigInt(),
+		Bid:                   quote.Bid.Mul(multiplier).BigInt(),
+		Ask:                   quote.Ask.Mul(multiplier).BigInt(),
+		CurrentBlockNum:       blockNum.Int64(),
+		CurrentBlockHash:      blockHash.FillBytes(make([]byte, 32)),
+		ValidFromBlockNum:     validFromBlockNum,
+		CurrentBlockTimestamp: blockTs.Uint64(),
+	}
+	return encodeMercuryV1Report(opts.FeedID, rf)
+}
+
+// Decode parses an encoded report back into its fields
+func (r EVMMercuryV1ReportCodec) Decode(b []byte) (feedID FeedID, rf v1.ReportFields, err error) {
+	if len(b) != 9*abiWordSize {
+		return feedID, rf, fmt.Errorf("failed to decode report: expected %d bytes, got %d", 9*abiWordSize, len(b))
+	}
+	w := make([]abiWord, 9)
+	for i := range w {
+		w[i], _ = abiReadWord(b, i)
+	}
+	feedID = FeedID(w[0])
+	rf.Timestamp = uint32(abiDecodeInt(w[1], false).Uint64())
+	rf.BenchmarkPrice = abiDecodeInt(w[2], true)
+	rf.Bid = abiDecodeInt(w[3], true)
+	rf.Ask = abiDecodeInt(w[4], true)
+	rf.CurrentBlockNum = int64(abiDecodeInt(w[5], false).Uint64())
+	rf.CurrentBlockHash = w[6][:]
+	rf.ValidFromBlockNum = int64(abiDecodeInt(w[7], false).Uint64())
+	rf.CurrentBlockTimestamp = abiDecodeInt(w[8], false).Uint64()
+	return feedID, rf, nil
+}
+
+func (r EVMMercuryV1ReportCodec) Pack(digest types.ConfigDigest, seqNr uint64, report ocr2types.Report, sigs []types.AttributedOnchainSignature) ([]byte, error) {
+	return PackEVMPayload(digest, seqNr, report, sigs)
+}
+
+func encodeMercuryV1Report(feedID FeedID, rf v1.ReportFields) ([]byte, error) {
+	if len(rf.CurrentBlockHash) != abiWordSize {
+		return nil, fmt.Errorf("failed to encode currentBlockHash: expected 32 bytes, got %d", len(rf.CurrentBlockHash))
+	}
+	e := &abiTupleEncoder{}
+	e.word(abiWord(feedID))
+	e.uint32(rf.Timestamp)
+	e.int("benchmarkPrice", rf.BenchmarkPrice, 192, true)
+	e.int("bid", rf.Bid, 192, true)
+	e.int("ask", rf.Ask, 192, true)
+	e.int("currentBlockNum", big.NewInt(rf.CurrentBlockNum), 64, false)
+	e.word(abiWord(rf.CurrentBlockHash))
+	e.int("validFromBlockNum", big.NewInt(rf.ValidFromBlockNum), 64, false)
+	e.int("currentBlockTimestamp", new(big.Int).SetUint64(rf.CurrentBlockTimestamp), 64, false)
+	return e.bytes()
+}
+
+// decimalStreamValueToBigInt converts a Decimal stream value holding a
+// non-negative integer to a big.Int of at most the given bit length
+func decimalStreamValueToBigInt(name string, sv StreamValue, bits int) (*big.Int, error) {
+	d, ok := sv.(*Decimal)
+	if !ok || d == nil {
+		return nil, fmt.Errorf("expected %s to be Decimal, got: %T", name, sv)
+	}
+	dec := d.Decimal()
+	if !dec.IsInteger() || dec.IsNegative() {
+		return nil, fmt.Errorf("expected %s to be a non-negative integer, got: %s", name, dec)
+	}
+	i := dec.BigInt()
+	if i.BitLen() > bits {
+		return nil, fmt.Errorf("%s %s overflows %d bits", name, i, bits)
+	}
+	return i, nil
+}
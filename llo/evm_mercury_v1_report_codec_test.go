@@ -0,0 +1,93 @@
+package llo
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+func Test_EVMMercuryV1ReportCodec(t *testing.T) {
+	ctx := tests.Context(t)
+	cdc := EVMMercuryV1ReportCodec{}
+	feedID := FeedID{0, 1, 0xaa}
+	blockHash := new(big.Int).Lsh(big.NewInt(0xbeef), 240)
+	cd := llotypes.ChannelDefinition{
+		Streams: []llotypes.Stream{{StreamID: 1}, {StreamID: 2}, {StreamID: 3}, {StreamID: 4}},
+		Opts:    []byte(`{"feedID":"` + feedID.Hex() + `","multiplier":"100","validFromBlockRange":10}`),
+	}
+	report := Report{
+		ObservationTimestampSeconds: 1726670490,
+		Values: []StreamValue{
+			&Quote{Bid: decimal.RequireFromString("1.1"), Benchmark: decimal.RequireFromString("1.2"), Ask: decimal.RequireFromString("1.3")},
+			ToDecimal(decimal.NewFromInt(1000)),
+			ToDecimal(decimal.NewFromBigInt(blockHash, 0)),
+			ToDecimal(decimal.NewFromInt(1726670480)),
+		},
+	}
+
+	t.Run("Verify", func(t *testing.T) {
+		require.NoError(t, cdc.Verify(cd))
+
+		invalid := cd
+		invalid.Streams = invalid.Streams[:3]
+		assert.EqualError(t, cdc.Verify(invalid), "expected exactly 4 streams (quote, blockNumber, blockHash, blockTimestamp), got: 3")
+
+		invalid = cd
+		invalid.Opts = []byte(`{"feedID":"` + feedID.Hex() + `"}`)
+		assert.EqualError(t, cdc.Verify(invalid), "invalid channel opts: multiplier must be positive")
+
+		invalid.Opts = []byte(`{"feedID":"` + feedID.Hex() + `","multiplier":"1","baseUSDFee":"1"}`)
+		assert.EqualError(t, cdc.Verify(invalid), `invalid channel opts: json: unknown field "baseUSDFee"`)
+	})
+	t.Run("Encode and Decode", func(t *testing.T) {
+		b, err := cdc.Encode(ctx, report, cd)
+		require.NoError(t, err)
+		assert.Len(t, b, 9*32)
+
+		decodedFeedID, rf, err := cdc.Decode(b)
+		require.NoError(t, err)
+		assert.Equal(t, feedID, decodedFeedID)
+		assert.Equal(t, uint32(1726670490), rf.Timestamp)
+		assert.Equal(t, "110", rf.Bid.String())
+		assert.Equal(t, "120", rf.BenchmarkPrice.String())
+		assert.Equal(t, "130", rf.Ask.String())
+		assert.Equal(t, int64(1000), rf.CurrentBlockNum)
+		assert.Equal(t, blockHash.FillBytes(make([]byte, 32)), rf.CurrentBlockHash)
+		assert.Equal(t, int64(990), rf.ValidFromBlockNum)
+		assert.Equal(t, uint64(1726670480), rf.CurrentBlockTimestamp)
+	})
+	t.Run("validFromBlockNum does not underflow", func(t *testing.T) {
+		r := report
+		r.Values = []StreamValue{r.Values[0], ToDecimal(decimal.NewFromInt(5)), r.Values[2], r.Values[3]}
+		b, err := cdc.Encode(ctx, r, cd)
+		require.NoError(t, err)
+		_, rf, err := cdc.Decode(b)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), rf.ValidFromBlockNum)
+	})
+	t.Run("Encode errors", func(t *testing.T) {
+		r := report
+		r.Values = []StreamValue{nil, r.Values[1], r.Values[2], r.Values[3]}
+		_, err := cdc.Encode(ctx, r, cd)
+		assert.EqualError(t, err, "expected quote to be Quote, got: <nil>")
+
+		r.Values = []StreamValue{report.Values[0], ToDecimal(decimal.RequireFromString("1.5")), r.Values[2], r.Values[3]}
+		_, err = cdc.Encode(ctx, r, cd)
+		assert.EqualError(t, err, "expected blockNumber to be a non-negative integer, got: 1.5")
+
+		r.Values = []StreamValue{report.Values[0], report.Values[1], ToDecimal(decimal.NewFromBigInt(new(big.Int).Lsh(big.NewInt(1), 256), 0)), r.Values[3]}
+		_, err = cdc.Encode(ctx, r, cd)
+		assert.ErrorContains(t, err, "overflows 256 bits")
+
+		r.Values = []StreamValue{report.Values[0], report.Values[1], report.Values[2], &Quote{}}
+		_, err = cdc.Encode(ctx, r, cd)
+		assert.EqualError(t, err, "expected blockTimestamp to be Decimal, got: *llo.Quote")
+	})
+}
@@ -0,0 +1,133 @@
+package llo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	ocr2types "github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+	v2 "github.com/smartcontractkit/chainlink-common/pkg/types/mercury/v2"
+)
+
+var _ ReportCodec = EVMMercuryV2ReportCodec{}
+
+// EVMMercuryV2ReportCodec renders channel data in the Mercury v0.2 (v2
+// schema) report format, which carries a benchmark price and fees but no
+// bid/ask:
+//
+//	abi.encode(bytes32 feedId, uint32 validFromTimestamp, uint32 observationsTimestamp, uint192 nativeFee, uint192 linkFee, uint32 expiresAt, int192 benchmarkPrice)
+//
+// Channels must have exactly three streams, in order:
+//  1. Native token price in USD (Decimal)
+//  2. LINK price in USD (Decimal)
+//  3. The price being reported (Decimal, or Quote in which case only the
+//     benchmark is used)
+//
+// Channel opts are the same as for EVMPremiumLegacyReportCodec, including
+// fee streams, in which case channels only have the benchmarkPrice stream,
+// but excluding dispersion, which the v2 schema has no room for.
+// There is no ReportFormat for this schema in chainlink-common; callers
+// should register the codec under whichever format they use for v2 feeds.
+type EVMMercuryV2ReportCodec struct{}
+
+// Verify checks that a channel definition can be encoded by this codec
+func (r EVMMercuryV2ReportCodec) Verify(cd llotypes.ChannelDefinition) error {
+	var opts EVMPremiumLegacyReportCodecOpts
+	if err := opts.Decode(cd.Opts); err != nil {
+		return err
+	}
+	if opts.Dispersion != "" {
+		return errors.New("invalid channel opts: dispersion is not supported by this report format")
+	}
+	return opts.verifyStreams(cd, "benchmarkPrice")
+}
+
+func (r EVMMercuryV2ReportCodec) Encode(_ context.Context, report Report, cd llotypes.ChannelDefinition) ([]byte, error) {
+	var opts EVMPremiumLegacyReportCodecOpts
+	if err := opts.Decode(cd.Opts); err != nil {
+		return nil, err
+	}
+	values := opts.values(report.Values)
+	if len(values) != 3 {
+		return nil, fmt.Errorf("expected exactly 3 values (nativePrice, linkPrice, benchmarkPrice), got: %d", len(values))
+	}
+	nativePrice, linkPrice, err := extractTokenPrices(values[0], values[1])
+	if err != nil {
+		return nil, err
+	}
+	var benchmark decimal.Decimal
+	switch v := values[2].(type) {
+	case *Decimal:
+		if v == nil {
+			return nil, fmt.Errorf("missing benchmarkPrice: %w", ErrNilStreamValue)
+		}
+		benchmark = v.Decimal()
+	case *Quote:
+		if v == nil {
+			return nil, fmt.Errorf("missing benchmarkPrice: %w", ErrNilStreamValue)
+		}
+		benchmark = v.Benchmark
+	case nil:
+		return nil, fmt.Errorf("missing benchmarkPrice: %w", ErrNilStreamValue)
+	default:
+		return nil, fmt.Errorf("expected benchmarkPrice to be Decimal or Quote, got: %T", v)
+	}
+	multiplier, err := opts.priceMultiplier(cd, "benchmarkPrice")
+	if err != nil {
+		return nil, err
+	}
+	if uint64(report.ObservationTimestampSeconds)+uint64(opts.ExpirationWindow) > math.MaxUint32 {
+		return nil, fmt.Errorf("expiresAt overflows uint32 (observationTimestamp: %d, expirationWindow: %d)", report.ObservationTimestampSeconds, opts.ExpirationWindow)
+	}
+
+	rf := v2.ReportFields{
+		ValidFromTimestamp: report.ValidAfterSeconds + 1,
+		Timestamp:          report.ObservationTimestampSeconds,
+		NativeFee:          calculateFee(nativePrice, opts.BaseUSDFee),
+		LinkFee:            calculateFee(linkPrice, opts.BaseUSDFee),
+		ExpiresAt:          report.ObservationTimestampSeconds + opts.ExpirationWindow,
+		BenchmarkPrice:     benchmark.Mul(multiplier).BigInt(),
+	}
+	return encodeMercuryV2Report(opts.FeedID, rf)
+}
+
+// Decode parses an encoded report back into its fields
+func (r EVMMercuryV2ReportCodec) Decode(b []byte) (feedID FeedID, rf v2.ReportFields, err error) {
+	if len(b) != 7*abiWordSize {
+		return feedID, rf, fmt.Errorf("failed to decode report: expected %d bytes, got %d", 7*abiWordSize, len(b))
+	}
+	w := make([]abiWord, 7)
+	for i := range w {
+		w[i], _ = abiReadWord(b, i)
+	}
+	feedID = FeedID(w[0])
+	rf.ValidFromTimestamp = uint32(abiDecodeInt(w[1], false).Uint64())
+	rf.Timestamp = uint32(abiDecodeInt(w[2], false).Uint64())
+	rf.NativeFee = abiDecodeInt(w[3], false)
+	rf.LinkFee = abiDecodeInt(w[4], false)
+	rf.ExpiresAt = uint32(abiDecodeInt(w[5], false).Uint64())
+	rf.BenchmarkPrice = abiDecodeInt(w[6], true)
+	return feedID, rf, nil
+}
+
+func (r EVMMercuryV2ReportCodec) Pack(digest types.ConfigDigest, seqNr uint64, report ocr2types.Report, sigs []types.AttributedOnchainSignature) ([]byte, error) {
+	return PackEVMPayload(digest, seqNr, report, sigs)
+}
+
+func encodeMercuryV2Report(feedID FeedID, rf v2.ReportFields) ([]byte, error) {
+	e := &abiTupleEncoder{}
+	e.word(abiWord(feedID))
+	e.uint32(rf.ValidFromTimestamp)
+	e.uint32(rf.Timestamp)
+	e.int("nativeFee", rf.NativeFee, 192, false)
+	e.int("linkFee", rf.LinkFee, 192, false)
+	e.uint32(rf.ExpiresAt)
+	e.int("benchmarkPrice", rf.BenchmarkPrice, 192, true)
+	return e.bytes()
+}
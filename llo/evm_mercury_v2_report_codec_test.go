@@ -0,0 +1,95 @@
+package llo
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+func Test_EVMMercuryV2ReportCodec(t *testing.T) {
+	ctx := tests.Context(t)
+	cdc := EVMMercuryV2ReportCodec{}
+	feedID := FeedID{0, 2, 0xaa}
+	cd := llotypes.ChannelDefinition{
+		Streams: []llotypes.Stream{{StreamID: 1}, {StreamID: 2}, {StreamID: 3}},
+		Opts:    []byte(`{"feedID":"` + feedID.Hex() + `","baseUSDFee":"1","expirationWindow":60,"multiplier":"1000"}`),
+	}
+	report := Report{
+		ValidAfterSeconds:           1726670480,
+		ObservationTimestampSeconds: 1726670490,
+		Values: []StreamValue{
+			ToDecimal(decimal.NewFromInt(2000)),
+			ToDecimal(decimal.NewFromInt(20)),
+			ToDecimal(decimal.RequireFromString("123.4567")),
+		},
+	}
+
+	t.Run("Verify", func(t *testing.T) {
+		require.NoError(t, cdc.Verify(cd))
+
+		invalid := cd
+		invalid.Streams = invalid.Streams[:2]
+		assert.EqualError(t, cdc.Verify(invalid), "expected exactly 3 streams (nativePrice, linkPrice, benchmarkPrice), got: 2")
+
+		withFeeStreams := cd
+		withFeeStreams.Streams = cd.Streams[2:]
+		withFeeStreams.Opts = []byte(`{"feedID":"` + feedID.Hex() + `","multiplier":"1","nativeFeeStreamID":1,"linkFeeStreamID":2}`)
+		require.NoError(t, cdc.Verify(withFeeStreams))
+		withFeeStreams.Opts = []byte(`{"feedID":"` + feedID.Hex() + `","multiplier":"1","nativeFeeStreamID":1,"linkFeeStreamID":2,"dispersion":"iqr"}`)
+		assert.EqualError(t, cdc.Verify(withFeeStreams), "invalid channel opts: dispersion is not supported by this report format")
+	})
+	t.Run("Encode with fee streams", func(t *testing.T) {
+		withFeeStreams := cd
+		withFeeStreams.Streams = cd.Streams[2:]
+		withFeeStreams.Opts = []byte(`{"feedID":"` + feedID.Hex() + `","baseUSDFee":"1","expirationWindow":60,"multiplier":"1000","nativeFeeStreamID":1,"linkFeeStreamID":2}`)
+		r := report
+		r.Values = []StreamValue{report.Values[2], report.Values[0], report.Values[1]}
+
+		b, err := cdc.Encode(ctx, r, withFeeStreams)
+		require.NoError(t, err)
+		expected, err := cdc.Encode(ctx, report, cd)
+		require.NoError(t, err)
+		assert.Equal(t, expected, b)
+	})
+	t.Run("Encode and Decode", func(t *testing.T) {
+		b, err := cdc.Encode(ctx, report, cd)
+		require.NoError(t, err)
+		assert.Len(t, b, 7*32)
+
+		decodedFeedID, rf, err := cdc.Decode(b)
+		require.NoError(t, err)
+		assert.Equal(t, feedID, decodedFeedID)
+		assert.Equal(t, uint32(1726670481), rf.ValidFromTimestamp)
+		assert.Equal(t, uint32(1726670490), rf.Timestamp)
+		assert.Equal(t, uint32(1726670550), rf.ExpiresAt)
+		assert.Equal(t, "500000000000000", rf.NativeFee.String())
+		assert.Equal(t, "50000000000000000", rf.LinkFee.String())
+		// truncated after applying multiplier
+		assert.Equal(t, "123456", rf.BenchmarkPrice.String())
+	})
+	t.Run("accepts Quote as benchmark", func(t *testing.T) {
+		r := report
+		r.Values = []StreamValue{r.Values[0], r.Values[1], &Quote{Bid: decimal.NewFromInt(1), Benchmark: decimal.NewFromInt(2), Ask: decimal.NewFromInt(3)}}
+		b, err := cdc.Encode(ctx, r, cd)
+		require.NoError(t, err)
+		_, rf, err := cdc.Decode(b)
+		require.NoError(t, err)
+		assert.Equal(t, "2000", rf.BenchmarkPrice.String())
+	})
+	t.Run("Encode errors", func(t *testing.T) {
+		r := report
+		r.Values = []StreamValue{r.Values[0], r.Values[1], nil}
+		_, err := cdc.Encode(ctx, r, cd)
+		assert.EqualError(t, err, "missing benchmarkPrice: nil stream value")
+
+		r.Values = []StreamValue{&Quote{}, report.Values[1], report.Values[2]}
+		_, err = cdc.Encode(ctx, r, cd)
+		assert.EqualError(t, err, "expected nativePrice to be Decimal, got: *llo.Quote")
+	})
+}
@@ -0,0 +1,173 @@
+package llo
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	ocr2types "github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"golang.org/x/crypto/sha3"
+)
+
+// EVM payloads use the same layout as legacy Mercury, so that existing
+// verifier contracts can verify LLO reports:
+//
+//	abi.encode(bytes32[3] reportContext, bytes report, bytes32[] rs, bytes32[] ss, bytes32 rawVs)
+//
+// OCR3 has no concept of epoch/round, so the sequence number is mapped onto
+// them, simulating 256 rounds per epoch.
+
+const (
+	evmSignatureLength = 65
+	// rawVs packs one v per byte
+	evmMaxSignatures = abiWordSize
+	// reportContext (3 words) + 3 offsets + rawVs
+	evmPayloadHeadWords = 7
+)
+
+// SeqNrToEpochAndRound maps an OCR3 sequence number onto the OCR2
+// epoch/round used by the legacy report context
+func SeqNrToEpochAndRound(seqNr uint64) (epoch uint32, round uint8, err error) {
+	if seqNr/256 > math.MaxUint32 {
+		return 0, 0, fmt.Errorf("seqNr %d is too large to map to epoch and round", seqNr)
+	}
+	return uint32(seqNr / 256), uint8(seqNr % 256), nil
+}
+
+// EpochAndRoundToSeqNr is the inverse of SeqNrToEpochAndRound
+func EpochAndRoundToSeqNr(epoch uint32, round uint8) uint64 {
+	return uint64(epoch)*256 + uint64(round)
+}
+
+// EVMReportContext returns the report context that EVM verifier contracts
+// expect alongside a report:
+//
+//	[configDigest, epoch and round, extraHash]
+//
+// The epoch and round are derived from seqNr using SeqNrToEpochAndRound and
+// the extra hash is always zero.
+func EVMReportContext(digest types.ConfigDigest, seqNr uint64) (rc [3][32]byte, err error) {
+	epoch, round, err := SeqNrToEpochAndRound(seqNr)
+	if err != nil {
+		return rc, err
+	}
+	rc[0] = digest
+	binary.BigEndian.PutUint32(rc[1][27:31], epoch)
+	rc[1][31] = round
+	return rc, nil
+}
+
+// EVMReportSigningHash is the digest that EVM onchain keyrings sign, and
+// that verifier contracts recover signers from:
+//
+//	keccak256(keccak256(report) || reportContext[0] || reportContext[1] || reportContext[2])
+func EVMReportSigningHash(digest types.ConfigDigest, seqNr uint64, report []byte) (out [32]byte, err error) {
+	rc, err := EVMReportContext(digest, seqNr)
+	if err != nil {
+		return out, err
+	}
+	reportHash := sha3.NewLegacyKeccak256()
+	reportHash.Write(report)
+	h := sha3.NewLegacyKeccak256()
+	h.Write(reportHash.Sum(nil))
+	for _, w := range rc {
+		h.Write(w[:])
+	}
+	copy(out[:], h.Sum(nil))
+	return out, nil
+}
+
+// PackEVMPayload packs an encoded EVM report and its signatures into a
+// payload that can be verified onchain
+func PackEVMPayload(digest types.ConfigDigest, seqNr uint64, report ocr2types.Report, sigs []types.AttributedOnchainSignature) ([]byte, error) {
+	rc, err := EVMReportContext(digest, seqNr)
+	if err != nil {
+		return nil, err
+	}
+	if len(sigs) > evmMaxSignatures {
+		return nil, fmt.Errorf("too many signatures; got: %d, max: %d", len(sigs), evmMaxSignatures)
+	}
+
+	var rs, ss []abiWord
+	var rawVs abiWord
+	for i, as := range sigs {
+		if len(as.Signature) != evmSignatureLength {
+			return nil, fmt.Errorf("invalid signature length for signer %d; expected: %d, got: %d", as.Signer, evmSignatureLength, len(as.Signature))
+		}
+		var r, s abiWord
+		copy(r[:], as.Signature[:32])
+		copy(s[:], as.Signature[32:64])
+		rs = append(rs, r)
+		ss = append(ss, s)
+		rawVs[i] = as.Signature[64]
+	}
+
+	reportTail := abiBytesTail(report)
+	rsTail := abiWordsTail(rs)
+	ssTail := abiWordsTail(ss)
+
+	reportOffset := evmPayloadHeadWords * abiWordSize
+	rsOffset := reportOffset + len(reportTail)
+	ssOffset := rsOffset + len(rsTail)
+
+	head := []abiWord{
+		rc[0],
+		rc[1],
+		rc[2],
+		abiUint64(uint64(reportOffset)),
+		abiUint64(uint64(rsOffset)),
+		abiUint64(uint64(ssOffset)),
+		rawVs,
+	}
+	b := make([]byte, 0, ssOffset+len(ssTail))
+	for _, w := range head {
+		b = append(b, w[:]...)
+	}
+	b = append(b, reportTail...)
+	b = append(b, rsTail...)
+	b = append(b, ssTail...)
+	return b, nil
+}
+
+// UnpackEVMPayload is the inverse of PackEVMPayload. Signers are not
+// encoded in the payload so the returned signatures are attributed in order.
+func UnpackEVMPayload(b []byte) (digest types.ConfigDigest, seqNr uint64, report ocr2types.Report, sigs []types.AttributedOnchainSignature, err error) {
+	if len(b) < evmPayloadHeadWords*abiWordSize {
+		return digest, seqNr, report, sigs, fmt.Errorf("failed to unpack EVM payload: too short (len: %d)", len(b))
+	}
+	rc0, _ := abiReadWord(b, 0)
+	rc1, _ := abiReadWord(b, 1)
+	rawVs, _ := abiReadWord(b, 6)
+	digest = types.ConfigDigest(rc0)
+	seqNr = EpochAndRoundToSeqNr(binary.BigEndian.Uint32(rc1[27:31]), rc1[31])
+
+	report, err = abiReadBytes(b, 3)
+	if err != nil {
+		return digest, seqNr, report, sigs, fmt.Errorf("failed to unpack EVM payload report: %w", err)
+	}
+	rs, err := abiReadWords(b, 4)
+	if err != nil {
+		return digest, seqNr, report, sigs, fmt.Errorf("failed to unpack EVM payload rs: %w", err)
+	}
+	ss, err := abiReadWords(b, 5)
+	if err != nil {
+		return digest, seqNr, report, sigs, fmt.Errorf("failed to unpack EVM payload ss: %w", err)
+	}
+	if len(rs) != len(ss) {
+		return digest, seqNr, report, sigs, errors.New("failed to unpack EVM payload: mismatched rs and ss lengths")
+	}
+	if len(rs) > evmMaxSignatures {
+		return digest, seqNr, report, sigs, fmt.Errorf("failed to unpack EVM payload: too many signatures (%d)", len(rs))
+	}
+	for i := range rs {
+		sig := make([]byte, 0, evmSignatureLength)
+		sig = append(sig, rs[i][:]...)
+		sig = append(sig, ss[i][:]...)
+		sig = append(sig, rawVs[i])
+		sigs = append(sigs, types.AttributedOnchainSignature{Signature: sig, Signer: commontypes.OracleID(i)})
+	}
+	return digest, seqNr, report, sigs, nil
+}
@@ -0,0 +1,140 @@
+package llo
+
+import (
+	"encoding/hex"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+func Test_SeqNrToEpochAndRound(t *testing.T) {
+	epoch, round, err := SeqNrToEpochAndRound(0)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0), epoch)
+	assert.Equal(t, uint8(0), round)
+
+	epoch, round, err = SeqNrToEpochAndRound(257)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), epoch)
+	assert.Equal(t, uint8(1), round)
+	assert.Equal(t, uint64(257), EpochAndRoundToSeqNr(epoch, round))
+
+	_, _, err = SeqNrToEpochAndRound(math.MaxUint64)
+	assert.Error(t, err)
+}
+
+func Test_PackEVMPayload(t *testing.T) {
+	digest := types.ConfigDigest{1, 2, 3}
+	sig := func(b byte) []byte {
+		s := make([]byte, 65)
+		for i := range s[:64] {
+			s[i] = b
+		}
+		s[64] = b % 2
+		return s
+	}
+
+	t.Run("encodes legacy Mercury payload layout", func(t *testing.T) {
+		report := []byte{0xaa, 0xbb}
+		sigs := []types.AttributedOnchainSignature{{Signature: sig(1), Signer: 3}}
+		b, err := PackEVMPayload(digest, 258, report, sigs)
+		require.NoError(t, err)
+
+		expected := "" +
+			"0102030000000000000000000000000000000000000000000000000000000000" + // reportContext[0]: config digest
+			"0000000000000000000000000000000000000000000000000000000000000102" + // reportContext[1]: epoch 1, round 2
+			"0000000000000000000000000000000000000000000000000000000000000000" + // reportContext[2]: extra hash
+			"00000000000000000000000000000000000000000000000000000000000000e0" + // report offset
+			"0000000000000000000000000000000000000000000000000000000000000120" + // rs offset
+			"0000000000000000000000000000000000000000000000000000000000000160" + // ss offset
+			"0100000000000000000000000000000000000000000000000000000000000000" + // rawVs
+			"0000000000000000000000000000000000000000000000000000000000000002" + // report length
+			"aabb000000000000000000000000000000000000000000000000000000000000" + // report
+			"0000000000000000000000000000000000000000000000000000000000000001" + // rs length
+			"0101010101010101010101010101010101010101010101010101010101010101" + // rs[0]
+			"0000000000000000000000000000000000000000000000000000000000000001" + // ss length
+			"0101010101010101010101010101010101010101010101010101010101010101" //  ss[0]
+		assert.Equal(t, expected, hex.EncodeToString(b))
+	})
+	t.Run("round trips", func(t *testing.T) {
+		report := make([]byte, 100)
+		report[99] = 1
+		sigs := []types.AttributedOnchainSignature{{Signature: sig(1)}, {Signature: sig(2), Signer: 1}, {Signature: sig(3), Signer: 2}}
+		b, err := PackEVMPayload(digest, 12345, report, sigs)
+		require.NoError(t, err)
+
+		cd, seqNr, r, s, err := UnpackEVMPayload(b)
+		require.NoError(t, err)
+		assert.Equal(t, digest, cd)
+		assert.Equal(t, uint64(12345), seqNr)
+		assert.Equal(t, report, []byte(r))
+		require.Len(t, s, 3)
+		for i := range s {
+			assert.Equal(t, sigs[i].Signature, s[i].Signature)
+			assert.Equal(t, commontypes.OracleID(i), s[i].Signer)
+		}
+	})
+	t.Run("validates signatures", func(t *testing.T) {
+		_, err := PackEVMPayload(digest, 1, nil, []types.AttributedOnchainSignature{{Signature: []byte{1}, Signer: 2}})
+		assert.EqualError(t, err, "invalid signature length for signer 2; expected: 65, got: 1")
+
+		_, err = PackEVMPayload(digest, 1, nil, make([]types.AttributedOnchainSignature, 33))
+		assert.EqualError(t, err, "too many signatures; got: 33, max: 32")
+	})
+	t.Run("unpack rejects garbage", func(t *testing.T) {
+		_, _, _, _, err := UnpackEVMPayload([]byte("foo"))
+		assert.EqualError(t, err, "failed to unpack EVM payload: too short (len: 3)")
+
+		b := make([]byte, 7*32)
+		b[3*32+31] = 0xff
+		_, _, _, _, err = UnpackEVMPayload(b)
+		assert.ErrorContains(t, err, "failed to unpack EVM payload report")
+	})
+}
+
+func Test_EVMReportContext(t *testing.T) {
+	digest := types.ConfigDigest{1, 2, 3}
+
+	rc, err := EVMReportContext(digest, 258)
+	require.NoError(t, err)
+	assert.Equal(t, "0102030000000000000000000000000000000000000000000000000000000000", hex.EncodeToString(rc[0][:]))
+	assert.Equal(t, "0000000000000000000000000000000000000000000000000000000000000102", hex.EncodeToString(rc[1][:]))
+	assert.Equal(t, "0000000000000000000000000000000000000000000000000000000000000000", hex.EncodeToString(rc[2][:]))
+
+	t.Run("matches the packed payload", func(t *testing.T) {
+		b, err := PackEVMPayload(digest, 258, []byte{0xaa, 0xbb}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, rc[0][:], b[0:32])
+		assert.Equal(t, rc[1][:], b[32:64])
+		assert.Equal(t, rc[2][:], b[64:96])
+	})
+
+	_, err = EVMReportContext(digest, math.MaxUint64)
+	assert.Error(t, err)
+}
+
+func Test_EVMReportSigningHash(t *testing.T) {
+	digest := types.ConfigDigest{1, 2, 3}
+
+	h, err := EVMReportSigningHash(digest, 258, []byte{0xaa, 0xbb})
+	require.NoError(t, err)
+	assert.Equal(t, "4a7e8d349eb4185fec7ed13861347b49a504ab81416e773bc63de12ef1fd81d9", hex.EncodeToString(h[:]))
+
+	t.Run("commits to the report context", func(t *testing.T) {
+		h2, err := EVMReportSigningHash(digest, 259, []byte{0xaa, 0xbb})
+		require.NoError(t, err)
+		assert.NotEqual(t, h, h2)
+
+		h2, err = EVMReportSigningHash(types.ConfigDigest{1, 2, 4}, 258, []byte{0xaa, 0xbb})
+		require.NoError(t, err)
+		assert.NotEqual(t, h, h2)
+	})
+
+	_, err = EVMReportSigningHash(digest, math.MaxUint64, []byte{0xaa, 0xbb})
+	assert.Error(t, err)
+}
@@ -0,0 +1,279 @@
+package llo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	ocr2types "github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+	v3 "github.com/smartcontractkit/chainlink-common/pkg/types/mercury/v3"
+
+	"github.com/smartcontractkit/chainlink-data-streams/mercury"
+)
+
+var _ ReportCodec = EVMPremiumLegacyReportCodec{}
+
+// EVMPremiumLegacyReportCodec renders channel data in the Mercury v0.3 (v3
+// schema) report format, so that existing v0.3 feeds can be served from LLO
+// without any changes to consumers:
+//
+//	abi.encode(bytes32 feedId, uint32 validFromTimestamp, uint32 observationsTimestamp, uint192 nativeFee, uint192 linkFee, uint32 expiresAt, int192 benchmarkPrice, int192 bid, int192 ask)
+//
+// Channels must have exactly three streams, in order:
+//  1. Native token price in USD (Decimal)
+//  2. LINK price in USD (Decimal)
+//  3. The price being reported (Quote)
+//
+// Alternatively, channels that designate fee streams in their opts (see
+// FeeStreamsOpts) only have the stream of the price being reported.
+//
+// Channels with fee streams may also set a dispersion metric (see
+// DispersionOpts), in which case the dispersion of the quote's benchmark,
+// scaled like the quote, is appended as an extra word:
+//
+//	abi.encode(..., int192 ask, int192 dispersion)
+type EVMPremiumLegacyReportCodec struct{}
+
+type EVMPremiumLegacyReportCodecOpts struct {
+	EVMFeedIDOpts
+	FeeStreamsOpts
+	DispersionOpts
+	StreamDecimalsOpts
+	StreamValuePolicyOpts
+	// BaseUSDFee is the cost in USD of verifying a report, converted to
+	// native and LINK fees using the native and LINK prices
+	BaseUSDFee decimal.Decimal `json:"baseUSDFee"`
+	// ExpirationWindow is the number of seconds after the observations
+	// timestamp that the report can be verified
+	ExpirationWindow uint32 `json:"expirationWindow"`
+	// Multiplier scales the quote before it is truncated to an integer, e.g.
+	// 1e18 for 18 decimal places of precision. It is optional if
+	// streamDecimals are set for the quote stream.
+	Multiplier decimal.Decimal `json:"multiplier"`
+}
+
+func (o *EVMPremiumLegacyReportCodecOpts) Decode(opts []byte) error {
+	if err := decodeEVMChannelOpts(opts, o, &o.EVMFeedIDOpts); err != nil {
+		return err
+	}
+	if o.BaseUSDFee.IsNegative() {
+		return errors.New("invalid channel opts: baseUSDFee must not be negative")
+	}
+	if o.Multiplier.IsNegative() || (o.Multiplier.IsZero() && len(o.StreamDecimals) == 0) {
+		return errors.New("invalid channel opts: multiplier must be positive")
+	}
+	if err := o.DispersionOpts.validate(); err != nil {
+		return fmt.Errorf("invalid channel opts: %w", err)
+	}
+	return o.FeeStreamsOpts.validate()
+}
+
+// verifyStreams checks the number of streams of a channel that has three
+// streams, the first two being the native and LINK prices, unless the opts
+// designate fee streams, and the scaling of the price being reported
+func (o *EVMPremiumLegacyReportCodecOpts) verifyStreams(cd llotypes.ChannelDefinition, priceName string) error {
+	if o.hasFeeStreams() {
+		if len(cd.Streams) != 1 {
+			return fmt.Errorf("expected exactly 1 stream (%s) with fee streams, got: %d", priceName, len(cd.Streams))
+		}
+	} else if len(cd.Streams) != 3 {
+		return fmt.Errorf("expected exactly 3 streams (nativePrice, linkPrice, %s), got: %d", priceName, len(cd.Streams))
+	}
+	if o.Dispersion != "" && !o.hasFeeStreams() {
+		// The dispersion is that of the channel's first stream, which is
+		// only the price being reported if the fees come from fee streams
+		return fmt.Errorf("invalid channel opts: dispersion requires fee streams, so that %s is the channel's primary stream", priceName)
+	}
+	if err := o.StreamDecimalsOpts.verify(cd); err != nil {
+		return err
+	}
+	_, err := o.priceMultiplier(cd, priceName)
+	return err
+}
+
+// priceMultiplier returns the factor by which the price being reported,
+// which is always the channel's last stream, is scaled
+func (o *EVMPremiumLegacyReportCodecOpts) priceMultiplier(cd llotypes.ChannelDefinition, priceName string) (decimal.Decimal, error) {
+	return o.StreamDecimalsOpts.priceMultiplier(cd, len(cd.Streams)-1, o.Multiplier, priceName)
+}
+
+// values returns the report values with the native and LINK prices first,
+// wherever they come from
+func (o *EVMPremiumLegacyReportCodecOpts) values(values []StreamValue) []StreamValue {
+	if o.hasFeeStreams() {
+		return withFeeStreamsFirst(values)
+	}
+	return values
+}
+
+// Verify checks that a channel definition can be encoded by this codec
+func (r EVMPremiumLegacyReportCodec) Verify(cd llotypes.ChannelDefinition) error {
+	var opts EVMPremiumLegacyReportCodecOpts
+	if err := opts.Decode(cd.Opts); err != nil {
+		return err
+	}
+	return opts.verifyStreams(cd, "quote")
+}
+
+func (r EVMPremiumLegacyReportCodec) Encode(_ context.Context, report Report, cd llotypes.ChannelDefinition) ([]byte, error) {
+	var opts EVMPremiumLegacyReportCodecOpts
+	if err := opts.Decode(cd.Opts); err != nil {
+		return nil, err
+	}
+	values := opts.values(report.Values)
+	var dispersion *Decimal
+	if opts.Dispersion != "" {
+		if len(values) != 4 {
+			return nil, fmt.Errorf("expected exactly 4 values (nativePrice, linkPrice, quote, dispersion), got: %d", len(values))
+		}
+		var err error
+		if dispersion, err = extractDispersion(values[3]); err != nil {
+			return nil, err
+		}
+		values = values[:3]
+	}
+	nativePrice, linkPrice, quote, err := extractPremiumLegacyValues(values)
+	if err != nil {
+		return nil, err
+	}
+	multiplier, err := opts.priceMultiplier(cd, "quote")
+	if err != nil {
+		return nil, err
+	}
+	if uint64(report.ObservationTimestampSeconds)+uint64(opts.ExpirationWindow) > math.MaxUint32 {
+		return nil, fmt.Errorf("expiresAt overflows uint32 (observationTimestamp: %d, expirationWindow: %d)", report.ObservationTimestampSeconds, opts.ExpirationWindow)
+	}
+
+	rf := v3.ReportFields{
+		ValidFromTimestamp: report.ValidAfterSeconds + 1,
+		Timestamp:          report.ObservationTimestampSeconds,
+		NativeFee:          calculateFee(nativePrice, opts.BaseUSDFee),
+		LinkFee:            calculateFee(linkPrice, opts.BaseUSDFee),
+		ExpiresAt:          report.ObservationTimestampSeconds + opts.ExpirationWindow,
+		BenchmarkPrice:     quote.Benchmark.Mul(multiplier).BigInt(),
+		Bid:                quote.Bid.Mul(multiplier).BigInt(),
+		Ask:                quote.Ask.Mul(multiplier).BigInt(),
+	}
+	var scaledDispersion *big.Int
+	if dispersion != nil {
+		scaledDispersion = dispersion.Decimal().Mul(multiplier).BigInt()
+	}
+	return encodePremiumLegacyReport(opts.FeedID, rf, scaledDispersion)
+}
+
+// Decode parses an encoded report back into its fields. The dispersion of
+// reports that carry one is ignored; see DecodeDispersion.
+func (r EVMPremiumLegacyReportCodec) Decode(b []byte) (feedID FeedID, rf v3.ReportFields, err error) {
+	if len(b) != 9*abiWordSize && len(b) != 10*abiWordSize {
+		return feedID, rf, fmt.Errorf("failed to decode report: expected %d or %d bytes, got %d", 9*abiWordSize, 10*abiWordSize, len(b))
+	}
+	w := make([]abiWord, 9)
+	for i := range w {
+		w[i], _ = abiReadWord(b, i)
+	}
+	feedID = FeedID(w[0])
+	rf.ValidFromTimestamp = uint32(abiDecodeInt(w[1], false).Uint64())
+	rf.Timestamp = uint32(abiDecodeInt(w[2], false).Uint64())
+	rf.NativeFee = abiDecodeInt(w[3], false)
+	rf.LinkFee = abiDecodeInt(w[4], false)
+	rf.ExpiresAt = uint32(abiDecodeInt(w[5], false).Uint64())
+	rf.BenchmarkPrice = abiDecodeInt(w[6], true)
+	rf.Bid = abiDecodeInt(w[7], true)
+	rf.Ask = abiDecodeInt(w[8], true)
+	return feedID, rf, nil
+}
+
+// DecodeDispersion returns the dispersion appended to a report of a channel
+// with DispersionOpts
+func (r EVMPremiumLegacyReportCodec) DecodeDispersion(b []byte) (*big.Int, error) {
+	if len(b) != 10*abiWordSize {
+		return nil, fmt.Errorf("failed to decode dispersion: expected %d bytes, got %d", 10*abiWordSize, len(b))
+	}
+	w, _ := abiReadWord(b, 9)
+	return abiDecodeInt(w, true), nil
+}
+
+func (r EVMPremiumLegacyReportCodec) Pack(digest types.ConfigDigest, seqNr uint64, report ocr2types.Report, sigs []types.AttributedOnchainSignature) ([]byte, error) {
+	return PackEVMPayload(digest, seqNr, report, sigs)
+}
+
+func extractDispersion(v StreamValue) (*Decimal, error) {
+	switch v := v.(type) {
+	case nil:
+		return nil, fmt.Errorf("missing dispersion: %w", ErrNilStreamValue)
+	case *Decimal:
+		return v, nil
+	default:
+		return nil, fmt.Errorf("expected dispersion to be Decimal, got: %T", v)
+	}
+}
+
+func extractPremiumLegacyValues(values []StreamValue) (nativePrice, linkPrice *Decimal, quote *Quote, err error) {
+	if len(values) != 3 {
+		return nil, nil, nil, fmt.Errorf("expected exactly 3 values (nativePrice, linkPrice, quote), got: %d", len(values))
+	}
+	nativePrice, linkPrice, err = extractTokenPrices(values[0], values[1])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if values[2] == nil {
+		return nil, nil, nil, fmt.Errorf("missing quote: %w", ErrNilStreamValue)
+	}
+	var ok bool
+	if quote, ok = values[2].(*Quote); !ok {
+		return nil, nil, nil, fmt.Errorf("expected quote to be Quote, got: %T", values[2])
+	}
+	return nativePrice, linkPrice, quote, nil
+}
+
+// extractTokenPrices extracts the USD prices used to calculate fees. They
+// may be missing, in which case the fee is zero.
+func extractTokenPrices(native, link StreamValue) (nativePrice, linkPrice *Decimal, err error) {
+	var ok bool
+	if native != nil {
+		if nativePrice, ok = native.(*Decimal); !ok {
+			return nil, nil, fmt.Errorf("expected nativePrice to be Decimal, got: %T", native)
+		}
+	}
+	if link != nil {
+		if linkPrice, ok = link.(*Decimal); !ok {
+			return nil, nil, fmt.Errorf("expected linkPrice to be Decimal, got: %T", link)
+		}
+	}
+	return nativePrice, linkPrice, nil
+}
+
+// calculateFee converts baseUSDFee to a fee denominated in a token with the
+// given USD price
+func calculateFee(tokenPriceInUSD *Decimal, baseUSDFee decimal.Decimal) *big.Int {
+	if tokenPriceInUSD == nil || !tokenPriceInUSD.Decimal().IsPositive() {
+		return big.NewInt(0)
+	}
+	return mercury.CalculateFee(tokenPriceInUSD.Decimal().Mul(mercury.PriceScalingFactor).BigInt(), baseUSDFee)
+}
+
+// encodePremiumLegacyReport encodes the report fields, followed by the
+// dispersion unless it is nil
+func encodePremiumLegacyReport(feedID FeedID, rf v3.ReportFields, dispersion *big.Int) ([]byte, error) {
+	e := &abiTupleEncoder{}
+	e.word(abiWord(feedID))
+	e.uint32(rf.ValidFromTimestamp)
+	e.uint32(rf.Timestamp)
+	e.int("nativeFee", rf.NativeFee, 192, false)
+	e.int("linkFee", rf.LinkFee, 192, false)
+	e.uint32(rf.ExpiresAt)
+	e.int("benchmarkPrice", rf.BenchmarkPrice, 192, true)
+	e.int("bid", rf.Bid, 192, true)
+	e.int("ask", rf.Ask, 192, true)
+	if dispersion != nil {
+		e.int("dispersion", dispersion, 192, true)
+	}
+	return e.bytes()
+}
@@ -0,0 +1,234 @@
+package llo
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+func Test_EVMPremiumLegacyReportCodec(t *testing.T) {
+	ctx := tests.Context(t)
+	cdc := EVMPremiumLegacyReportCodec{}
+	feedID := FeedID{0, 3, 0xaa}
+	cd := llotypes.ChannelDefinition{
+		ReportFormat: llotypes.ReportFormatEVMPremiumLegacy,
+		Streams:      []llotypes.Stream{{StreamID: 1}, {StreamID: 2}, {StreamID: 3}},
+		Opts:         []byte(`{"feedID":"` + feedID.Hex() + `","baseUSDFee":"1","expirationWindow":3600,"multiplier":"1000000000000000000"}`),
+	}
+	report := Report{
+		ConfigDigest:                types.ConfigDigest{1},
+		SeqNr:                       42,
+		ChannelID:                   1,
+		ValidAfterSeconds:           1726670489,
+		ObservationTimestampSeconds: 1726670490,
+		Values: []StreamValue{
+			ToDecimal(decimal.NewFromInt(2000)),
+			ToDecimal(decimal.NewFromInt(20)),
+			&Quote{Bid: decimal.RequireFromString("1.1"), Benchmark: decimal.RequireFromString("1.2"), Ask: decimal.RequireFromString("1.3")},
+		},
+	}
+
+	t.Run("Verify", func(t *testing.T) {
+		require.NoError(t, cdc.Verify(cd))
+
+		withCommonOpts := cd
+		withCommonOpts.Opts = []byte(`{"feedID":"` + feedID.Hex() + `","multiplier":"1","transmissionTargets":["premium"],"priority":10}`)
+		require.NoError(t, cdc.Verify(withCommonOpts))
+
+		invalid := cd
+		invalid.Streams = invalid.Streams[:2]
+		assert.EqualError(t, cdc.Verify(invalid), "expected exactly 3 streams (nativePrice, linkPrice, quote), got: 2")
+
+		withFeeStreams := cd
+		withFeeStreams.Streams = cd.Streams[2:]
+		withFeeStreams.Opts = []byte(`{"feedID":"` + feedID.Hex() + `","multiplier":"1","nativeFeeStreamID":1,"linkFeeStreamID":2}`)
+		require.NoError(t, cdc.Verify(withFeeStreams))
+		withFeeStreams.Streams = cd.Streams
+		assert.EqualError(t, cdc.Verify(withFeeStreams), "expected exactly 1 stream (quote) with fee streams, got: 3")
+		withFeeStreams.Opts = []byte(`{"feedID":"` + feedID.Hex() + `","multiplier":"1","nativeFeeStreamID":1}`)
+		assert.EqualError(t, cdc.Verify(withFeeStreams), "invalid channel opts: nativeFeeStreamID and linkFeeStreamID must be set together")
+
+		withDispersion := cd
+		withDispersion.Streams = cd.Streams[2:]
+		withDispersion.Opts = []byte(`{"feedID":"` + feedID.Hex() + `","multiplier":"1","nativeFeeStreamID":1,"linkFeeStreamID":2,"dispersion":"iqr"}`)
+		require.NoError(t, cdc.Verify(withDispersion))
+		withDispersion.Opts = []byte(`{"feedID":"` + feedID.Hex() + `","multiplier":"1","nativeFeeStreamID":1,"linkFeeStreamID":2,"dispersion":"stddev"}`)
+		assert.EqualError(t, cdc.Verify(withDispersion), `invalid channel opts: unknown dispersion metric: "stddev"`)
+		withDispersion.Streams = cd.Streams
+		withDispersion.Opts = []byte(`{"feedID":"` + feedID.Hex() + `","multiplier":"1","dispersion":"iqr"}`)
+		assert.EqualError(t, cdc.Verify(withDispersion), "invalid channel opts: dispersion requires fee streams, so that quote is the channel's primary stream")
+
+		withStreamDecimals := cd
+		withStreamDecimals.Opts = []byte(`{"feedID":"` + feedID.Hex() + `","streamDecimals":{"3":18}}`)
+		require.NoError(t, cdc.Verify(withStreamDecimals))
+		withStreamDecimals.Opts = []byte(`{"feedID":"` + feedID.Hex() + `","streamDecimals":{"1":18}}`)
+		assert.EqualError(t, cdc.Verify(withStreamDecimals), "invalid channel opts: multiplier must be positive unless streamDecimals are set for the quote stream (3)")
+		withStreamDecimals.Opts = []byte(`{"feedID":"` + feedID.Hex() + `","multiplier":"1","streamDecimals":{"9":18}}`)
+		assert.EqualError(t, cdc.Verify(withStreamDecimals), "invalid channel opts: streamDecimals set for stream 9, which is not a stream of the channel")
+
+		for opts, expectedErr := range map[string]string{
+			``:                                    "missing channel opts",
+			`{"baseUSDFee":"1","multiplier":"1"}`: "invalid channel opts: feedID is required",
+			`{"feedID":"` + feedID.Hex() + `","baseUSDFee":"1"}`:                           "invalid channel opts: multiplier must be positive",
+			`{"feedID":"` + feedID.Hex() + `","baseUSDFee":"-1","multiplier":"1"}`:         "invalid channel opts: baseUSDFee must not be negative",
+			`{"feedID":"` + feedID.Hex() + `","multiplier":"1","unknown":true}`:            `invalid channel opts: json: unknown field "unknown"`,
+			`{"feedID":"0x01","multiplier":"1"}`:                                           `invalid channel opts: invalid feed ID "0x01": expected 32 bytes, got 1`,
+			`{"feedID":"` + feedID.Hex() + `","multiplier":"1","expirationWindow":"soon"}`: "invalid channel opts: json: cannot unmarshal string into Go struct field EVMPremiumLegacyReportCodecOpts.expirationWindow of type uint32",
+		} {
+			invalid := cd
+			invalid.Opts = []byte(opts)
+			assert.EqualError(t, cdc.Verify(invalid), expectedErr, opts)
+		}
+	})
+	t.Run("Encode and Decode", func(t *testing.T) {
+		b, err := cdc.Encode(ctx, report, cd)
+		require.NoError(t, err)
+		assert.Len(t, b, 9*32)
+
+		decodedFeedID, rf, err := cdc.Decode(b)
+		require.NoError(t, err)
+		assert.Equal(t, feedID, decodedFeedID)
+		assert.Equal(t, uint32(1726670490), rf.ValidFromTimestamp)
+		assert.Equal(t, uint32(1726670490), rf.Timestamp)
+		assert.Equal(t, uint32(1726670490+3600), rf.ExpiresAt)
+		// 1 USD / 2000 USD per native token
+		assert.Equal(t, "500000000000000", rf.NativeFee.String())
+		// 1 USD / 20 USD per LINK
+		assert.Equal(t, "50000000000000000", rf.LinkFee.String())
+		assert.Equal(t, "1100000000000000000", rf.Bid.String())
+		assert.Equal(t, "1200000000000000000", rf.BenchmarkPrice.String())
+		assert.Equal(t, "1300000000000000000", rf.Ask.String())
+
+		// feed ID is embedded so legacy consumers can key on it
+		embedded, err := FeedIDFromEVMReport(b)
+		require.NoError(t, err)
+		assert.Equal(t, feedID, embedded)
+	})
+	t.Run("Encode with fee streams", func(t *testing.T) {
+		withFeeStreams := cd
+		withFeeStreams.Streams = cd.Streams[2:]
+		withFeeStreams.Opts = []byte(`{"feedID":"` + feedID.Hex() + `","baseUSDFee":"1","expirationWindow":3600,"multiplier":"1000000000000000000","nativeFeeStreamID":1,"linkFeeStreamID":2}`)
+		// fee stream values are appended to the report values
+		r := report
+		r.Values = []StreamValue{report.Values[2], report.Values[0], report.Values[1]}
+
+		b, err := cdc.Encode(ctx, r, withFeeStreams)
+		require.NoError(t, err)
+		expected, err := cdc.Encode(ctx, report, cd)
+		require.NoError(t, err)
+		assert.Equal(t, expected, b)
+	})
+	t.Run("Encode with dispersion", func(t *testing.T) {
+		withDispersion := cd
+		withDispersion.Streams = cd.Streams[2:]
+		withDispersion.Opts = []byte(`{"feedID":"` + feedID.Hex() + `","baseUSDFee":"1","expirationWindow":3600,"multiplier":"1000000000000000000","nativeFeeStreamID":1,"linkFeeStreamID":2,"dispersion":"iqr"}`)
+		// the dispersion follows the channel's streams, and precedes the fee
+		// streams
+		r := report
+		r.Values = []StreamValue{report.Values[2], ToDecimal(decimal.RequireFromString("0.05")), report.Values[0], report.Values[1]}
+
+		b, err := cdc.Encode(ctx, r, withDispersion)
+		require.NoError(t, err)
+		require.Len(t, b, 10*32)
+		withoutDispersion, err := cdc.Encode(ctx, report, cd)
+		require.NoError(t, err)
+		assert.Equal(t, withoutDispersion, b[:9*32], "the dispersion is appended to the v3 schema")
+
+		_, rf, err := cdc.Decode(b)
+		require.NoError(t, err)
+		assert.Equal(t, "1200000000000000000", rf.BenchmarkPrice.String())
+		dispersion, err := cdc.DecodeDispersion(b)
+		require.NoError(t, err)
+		assert.Equal(t, "50000000000000000", dispersion.String(), "scaled like the quote")
+
+		_, err = cdc.DecodeDispersion(withoutDispersion)
+		assert.EqualError(t, err, "failed to decode dispersion: expected 320 bytes, got 288")
+
+		r.Values = []StreamValue{report.Values[2], nil, report.Values[0], report.Values[1]}
+		_, err = cdc.Encode(ctx, r, withDispersion)
+		assert.EqualError(t, err, "missing dispersion: nil stream value")
+		r.Values = []StreamValue{report.Values[2], report.Values[0], report.Values[1]}
+		_, err = cdc.Encode(ctx, r, withDispersion)
+		assert.EqualError(t, err, "expected exactly 4 values (nativePrice, linkPrice, quote, dispersion), got: 3")
+	})
+	t.Run("Encode with stream decimals", func(t *testing.T) {
+		expected, err := cdc.Encode(ctx, report, cd)
+		require.NoError(t, err)
+
+		withStreamDecimals := cd
+		withStreamDecimals.Opts = []byte(`{"feedID":"` + feedID.Hex() + `","baseUSDFee":"1","expirationWindow":3600,"streamDecimals":{"3":18}}`)
+		b, err := cdc.Encode(ctx, report, withStreamDecimals)
+		require.NoError(t, err)
+		assert.Equal(t, expected, b)
+
+		// stream decimals take precedence over the multiplier
+		withStreamDecimals.Opts = []byte(`{"feedID":"` + feedID.Hex() + `","baseUSDFee":"1","expirationWindow":3600,"multiplier":"1000","streamDecimals":{"3":18}}`)
+		b, err = cdc.Encode(ctx, report, withStreamDecimals)
+		require.NoError(t, err)
+		assert.Equal(t, expected, b)
+	})
+	t.Run("Encode handles negative prices", func(t *testing.T) {
+		r := report
+		r.Values = []StreamValue{r.Values[0], r.Values[1], &Quote{Bid: decimal.NewFromInt(-3), Benchmark: decimal.NewFromInt(-2), Ask: decimal.NewFromInt(-1)}}
+		b, err := cdc.Encode(ctx, r, cd)
+		require.NoError(t, err)
+		_, rf, err := cdc.Decode(b)
+		require.NoError(t, err)
+		assert.Equal(t, new(big.Int).Mul(big.NewInt(-2), big.NewInt(1e18)), rf.BenchmarkPrice)
+	})
+	t.Run("Encode with missing token prices charges zero fees", func(t *testing.T) {
+		r := report
+		r.Values = []StreamValue{nil, nil, r.Values[2]}
+		b, err := cdc.Encode(ctx, r, cd)
+		require.NoError(t, err)
+		_, rf, err := cdc.Decode(b)
+		require.NoError(t, err)
+		assert.Zero(t, rf.NativeFee.Sign())
+		assert.Zero(t, rf.LinkFee.Sign())
+	})
+	t.Run("Encode errors", func(t *testing.T) {
+		r := report
+		r.Values = []StreamValue{r.Values[0], r.Values[1], nil}
+		_, err := cdc.Encode(ctx, r, cd)
+		assert.EqualError(t, err, "missing quote: nil stream value")
+
+		r.Values = []StreamValue{r.Values[0], r.Values[1], ToDecimal(decimal.NewFromInt(1))}
+		_, err = cdc.Encode(ctx, r, cd)
+		assert.EqualError(t, err, "expected quote to be Quote, got: *llo.Decimal")
+
+		r.Values = report.Values[:2]
+		_, err = cdc.Encode(ctx, r, cd)
+		assert.EqualError(t, err, "expected exactly 3 values (nativePrice, linkPrice, quote), got: 2")
+
+		r = report
+		r.Values = []StreamValue{r.Values[0], r.Values[1], &Quote{Benchmark: decimal.New(1, 60)}}
+		_, err = cdc.Encode(ctx, r, cd)
+		assert.ErrorContains(t, err, "failed to encode benchmarkPrice")
+
+		r = report
+		r.ObservationTimestampSeconds = 1<<32 - 1
+		_, err = cdc.Encode(ctx, r, cd)
+		assert.ErrorContains(t, err, "expiresAt overflows uint32")
+	})
+	t.Run("Pack", func(t *testing.T) {
+		b, err := cdc.Encode(ctx, report, cd)
+		require.NoError(t, err)
+		payload, err := cdc.Pack(report.ConfigDigest, report.SeqNr, b, nil)
+		require.NoError(t, err)
+
+		digest, seqNr, r, _, err := UnpackEVMPayload(payload)
+		require.NoError(t, err)
+		assert.Equal(t, report.ConfigDigest, digest)
+		assert.Equal(t, report.SeqNr, seqNr)
+		assert.Equal(t, b, []byte(r))
+	})
+}
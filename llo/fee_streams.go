@@ -0,0 +1,87 @@
+package llo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+// FeeStreamsOpts are channel opts that designate the streams carrying the
+// USD prices of the native token and LINK, from which the fees for verifying
+// the channel's reports onchain are calculated. Fee streams are observed and
+// aggregated (by median) like the channel's own streams, and their consensus
+// values are appended to the channel's report values, native price first.
+//
+// This way, channels of report formats with fees (e.g. Mercury v0.3) only
+// need to list the stream being reported, and many channels can share the
+// same fee streams. They may be combined with any codec-specific opts.
+type FeeStreamsOpts struct {
+	// NativeFeeStreamID is the stream with the USD price of the native token
+	NativeFeeStreamID *llotypes.StreamID `json:"nativeFeeStreamID,omitempty"`
+	// LinkFeeStreamID is the stream with the USD price of LINK
+	LinkFeeStreamID *llotypes.StreamID `json:"linkFeeStreamID,omitempty"`
+}
+
+func (o FeeStreamsOpts) validate() error {
+	if (o.NativeFeeStreamID == nil) != (o.LinkFeeStreamID == nil) {
+		return errors.New("invalid channel opts: nativeFeeStreamID and linkFeeStreamID must be set together")
+	}
+	return nil
+}
+
+// hasFeeStreams returns true if the opts designate fee streams
+func (o FeeStreamsOpts) hasFeeStreams() bool {
+	return o.NativeFeeStreamID != nil
+}
+
+// ParseFeeStreams extracts the fee streams from a channel's opts, returning
+// nil if none are set, or else the native and LINK fee streams in that
+// order. Other fields in the opts are ignored.
+func ParseFeeStreams(opts llotypes.ChannelOpts) ([]llotypes.Stream, error) {
+	if len(opts) == 0 {
+		return nil, nil
+	}
+	var o FeeStreamsOpts
+	if err := json.Unmarshal(opts, &o); err != nil {
+		return nil, fmt.Errorf("invalid channel opts: %w", err)
+	}
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	if !o.hasFeeStreams() {
+		return nil, nil
+	}
+	return []llotypes.Stream{
+		{StreamID: *o.NativeFeeStreamID, Aggregator: llotypes.AggregatorMedian},
+		{StreamID: *o.LinkFeeStreamID, Aggregator: llotypes.AggregatorMedian},
+	}, nil
+}
+
+// channelStreams returns all streams that must be observed and aggregated
+// for a channel: its own streams, followed by its fee streams. Invalid fee
+// stream opts are ignored.
+func channelStreams(cache *channelOptsCache, cd llotypes.ChannelDefinition) []llotypes.Stream {
+	opts := cache.of(cd)
+	if opts.feeStreamsErr != nil || len(opts.feeStreams) == 0 {
+		return cd.Streams
+	}
+	feeStreams := opts.feeStreams
+	streams := make([]llotypes.Stream, 0, len(cd.Streams)+len(feeStreams))
+	streams = append(streams, cd.Streams...)
+	return append(streams, feeStreams...)
+}
+
+// withFeeStreamsFirst moves the fee stream values, which are appended to the
+// report values, to the front. This turns the values of channels with fee
+// streams into the layout of channels that list the fee streams first, as
+// required by the legacy Mercury formats.
+func withFeeStreamsFirst(values []StreamValue) []StreamValue {
+	if len(values) < 2 {
+		return values
+	}
+	reordered := make([]StreamValue, 0, len(values))
+	reordered = append(reordered, values[len(values)-2:]...)
+	return append(reordered, values[:len(values)-2]...)
+}
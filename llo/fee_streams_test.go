@@ -0,0 +1,73 @@
+package llo
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+func Test_ParseFeeStreams(t *testing.T) {
+	t.Run("empty opts", func(t *testing.T) {
+		streams, err := ParseFeeStreams(nil)
+		require.NoError(t, err)
+		assert.Nil(t, streams)
+	})
+	t.Run("opts without fee streams", func(t *testing.T) {
+		streams, err := ParseFeeStreams([]byte(`{"foo":"bar"}`))
+		require.NoError(t, err)
+		assert.Nil(t, streams)
+	})
+	t.Run("fee streams", func(t *testing.T) {
+		streams, err := ParseFeeStreams([]byte(`{"nativeFeeStreamID":1,"linkFeeStreamID":2,"foo":"bar"}`))
+		require.NoError(t, err)
+		assert.Equal(t, []llotypes.Stream{
+			{StreamID: 1, Aggregator: llotypes.AggregatorMedian},
+			{StreamID: 2, Aggregator: llotypes.AggregatorMedian},
+		}, streams)
+	})
+	t.Run("only one fee stream", func(t *testing.T) {
+		_, err := ParseFeeStreams([]byte(`{"nativeFeeStreamID":1}`))
+		assert.EqualError(t, err, "invalid channel opts: nativeFeeStreamID and linkFeeStreamID must be set together")
+	})
+	t.Run("invalid JSON", func(t *testing.T) {
+		_, err := ParseFeeStreams([]byte(`not json`))
+		assert.ErrorContains(t, err, "invalid channel opts")
+	})
+}
+
+func Test_channelStreams(t *testing.T) {
+	streams := []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorQuote}}
+	assert.Equal(t, streams, channelStreams(nil, llotypes.ChannelDefinition{Streams: streams}))
+	assert.Equal(t, streams, channelStreams(nil, llotypes.ChannelDefinition{Streams: streams, Opts: []byte(`{"linkFeeStreamID":3}`)}))
+	assert.Equal(t, []llotypes.Stream{
+		{StreamID: 1, Aggregator: llotypes.AggregatorQuote},
+		{StreamID: 2, Aggregator: llotypes.AggregatorMedian},
+		{StreamID: 3, Aggregator: llotypes.AggregatorMedian},
+	}, channelStreams(nil, llotypes.ChannelDefinition{Streams: streams, Opts: []byte(`{"nativeFeeStreamID":2,"linkFeeStreamID":3}`)}))
+}
+
+func Test_Outcome_hasStreamAggregates_FeeStreams(t *testing.T) {
+	cd := llotypes.ChannelDefinition{
+		Streams: []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorQuote}},
+		Opts:    []byte(`{"nativeFeeStreamID":2,"linkFeeStreamID":3}`),
+	}
+	out := &Outcome{}
+	assert.False(t, out.hasStreamAggregates(nil, cd))
+
+	// fee streams count like any other stream of the channel
+	out.StreamAggregates = map[llotypes.StreamID]map[llotypes.Aggregator]StreamValue{
+		3: {llotypes.AggregatorMedian: ToDecimal(decimal.NewFromInt(1))},
+	}
+	assert.True(t, out.hasStreamAggregates(nil, cd))
+}
+
+func Test_withFeeStreamsFirst(t *testing.T) {
+	price, native, link := ToDecimal(decimal.NewFromInt(1)), ToDecimal(decimal.NewFromInt(2)), ToDecimal(decimal.NewFromInt(3))
+	assert.Equal(t, []StreamValue{native, link, price}, withFeeStreamsFirst([]StreamValue{price, native, link}))
+	assert.Equal(t, []StreamValue{native, link}, withFeeStreamsFirst([]StreamValue{native, link}))
+	assert.Equal(t, []StreamValue{price}, withFeeStreamsFirst([]StreamValue{price}))
+}
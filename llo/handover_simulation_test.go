@@ -0,0 +1,303 @@
+package llo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+)
+
+// The handover simulation runs a predecessor and a successor protocol
+// instance side by side on a simulated clock, one round per tick, and
+// retires the predecessor at a random point relative to the successor's
+// progress: before the successor starts, while it is still syncing channel
+// definitions, or after it is fully synced. Whatever the timing, the
+// validity windows of the verifiable reports on each channel must chain
+// from one instance to the next without gaps or overlaps.
+
+const (
+	handoverSimulations     = 200
+	handoverSimulationN     = 4
+	handoverSimulationF     = 1
+	handoverSimulationTicks = 80
+)
+
+// simulatedRetirementReportCache hands the retirement report of the
+// predecessor to the successor. Reports are not attested in the simulation,
+// so the attested retirement report is just the encoded retirement report.
+type simulatedRetirementReportCache struct {
+	reports map[types.ConfigDigest][]byte
+}
+
+func (c *simulatedRetirementReportCache) AttestedRetirementReport(digest types.ConfigDigest) ([]byte, error) {
+	return c.reports[digest], nil
+}
+
+func (c *simulatedRetirementReportCache) CheckAttestedRetirementReport(digest types.ConfigDigest, attested []byte) (RetirementReport, error) {
+	if !bytes.Equal(c.reports[digest], attested) {
+		return RetirementReport{}, errors.New("unknown retirement report")
+	}
+	return StandardRetirementReportCodec{}.Decode(attested)
+}
+
+type simulatedReport struct {
+	instance string
+	tick     int
+	stage    llotypes.LifeCycleStage
+	report   Report
+}
+
+type simulatedInstance struct {
+	name    string
+	p       *Plugin
+	cdc     *mockChannelDefinitionCache
+	src     *mockShouldRetireCache
+	seqNr   uint64
+	outcome ocr3types.Outcome
+
+	// tick of the first retirement report, or -1
+	retiredAt        int
+	retirementReport []byte
+}
+
+func newSimulatedInstance(name string, digest types.ConfigDigest, predecessor *types.ConfigDigest, prrc PredecessorRetirementReportCache, ds DataSource, offchainConfig OffchainConfig) *simulatedInstance {
+	s := &simulatedInstance{
+		name:      name,
+		cdc:       &mockChannelDefinitionCache{llotypes.ChannelDefinitions{}},
+		src:       &mockShouldRetireCache{},
+		retiredAt: -1,
+	}
+	s.p = &Plugin{
+		PredecessorConfigDigest:          predecessor,
+		ConfigDigest:                     digest,
+		PredecessorRetirementReportCache: prrc,
+		ShouldRetireCache:                s.src,
+		ChannelDefinitionCache:           s.cdc,
+		DataSource:                       ds,
+		Logger:                           logger.Nop(),
+		N:                                handoverSimulationN,
+		F:                                handoverSimulationF,
+		ObservationCodec:                 protoObservationCodec{},
+		OutcomeCodec:                     protoOutcomeCodec{},
+		RetirementReportCodec:            StandardRetirementReportCodec{},
+		ReportCodecs:                     map[llotypes.ReportFormat]ReportCodec{llotypes.ReportFormatJSON: JSONReportCodec{}},
+		OffchainConfig:                   offchainConfig,
+	}
+	return s
+}
+
+// round runs one round of the protocol instance with observations made at
+// now, and returns the channel reports it generated
+func (s *simulatedInstance) round(ctx context.Context, r *rand.Rand, tick int, now time.Time) ([]simulatedReport, error) {
+	s.seqNr++
+	outctx := ocr3types.OutcomeContext{SeqNr: s.seqNr, PreviousOutcome: s.outcome}
+	aos := make([]types.AttributedObservation, 0, handoverSimulationN)
+	for oracleID := range handoverSimulationN {
+		// observations of all but 2f+1 oracles may miss the round
+		if len(aos) >= 2*handoverSimulationF+1 && r.IntN(3) == 0 {
+			continue
+		}
+		obs, err := s.p.Observation(ctx, outctx, types.Query{})
+		if err != nil {
+			return nil, fmt.Errorf("%s: Observation failed: %w", s.name, err)
+		}
+		if len(obs) > 0 {
+			// oracles' clocks are skewed by up to a second
+			decoded, err := s.p.ObservationCodec.Decode(obs)
+			if err != nil {
+				return nil, err
+			}
+			skew := time.Duration(r.IntN(int(time.Second))) - 500*time.Millisecond
+			decoded.UnixTimestampNanoseconds = now.Add(skew).UnixNano()
+			if obs, err = s.p.ObservationCodec.Encode(decoded); err != nil {
+				return nil, err
+			}
+		}
+		aos = append(aos, types.AttributedObservation{Observation: obs, Observer: commontypes.OracleID(oracleID)})
+	}
+	outcome, err := s.p.Outcome(ctx, outctx, types.Query{}, aos)
+	if err != nil {
+		return nil, fmt.Errorf("%s: Outcome failed in round %d: %w", s.name, s.seqNr, err)
+	}
+	s.outcome = outcome
+
+	rwis, err := s.p.Reports(ctx, s.seqNr, outcome)
+	if err != nil {
+		return nil, fmt.Errorf("%s: Reports failed in round %d: %w", s.name, s.seqNr, err)
+	}
+	var reports []simulatedReport
+	for _, rwi := range rwis {
+		info := rwi.ReportWithInfo.Info
+		if info.ReportFormat == llotypes.ReportFormatRetirement {
+			if s.retirementReport == nil {
+				s.retiredAt = tick
+				s.retirementReport = rwi.ReportWithInfo.Report
+			} else if !bytes.Equal(s.retirementReport, rwi.ReportWithInfo.Report) {
+				return nil, fmt.Errorf("%s: retirement report changed in round %d", s.name, s.seqNr)
+			}
+			continue
+		}
+		report, err := JSONReportCodec{}.Decode(rwi.ReportWithInfo.Report)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, simulatedReport{s.name, tick, info.LifeCycleStage, report})
+	}
+	return reports, nil
+}
+
+func Test_HandoverSimulation(t *testing.T) {
+	simulations := handoverSimulations
+	if testing.Short() {
+		simulations = 20
+	}
+	for seed := range uint64(simulations) {
+		t.Run(fmt.Sprintf("seed=%d", seed), func(t *testing.T) {
+			runHandoverSimulation(t, seed)
+		})
+	}
+}
+
+func runHandoverSimulation(t *testing.T, seed uint64) {
+	ctx := tests.Context(t)
+	r := rand.New(rand.NewPCG(seed, 4963))
+
+	channels := llotypes.ChannelDefinitions{}
+	ds := &mockDataSource{s: StreamValues{}}
+	for i := range 4 + r.IntN(13) {
+		id := llotypes.ChannelID(i + 1)
+		channels[id] = llotypes.ChannelDefinition{
+			ReportFormat: llotypes.ReportFormatJSON,
+			Streams:      []llotypes.Stream{{StreamID: id, Aggregator: llotypes.AggregatorMedian}},
+		}
+		ds.s[id] = ToDecimal(decimal.NewFromInt(int64(id)))
+	}
+	predecessorDigest := types.ConfigDigest{0, 9, 1}
+	prrc := &simulatedRetirementReportCache{reports: map[types.ConfigDigest][]byte{}}
+	// the JSON codec cannot encode missing values, so channels that were
+	// not aggregated yet must not advance their validity windows, or the
+	// handover would leave a gap
+	offchainConfig := OffchainConfig{RetirementWindDownRounds: uint32(r.IntN(3)), RequireStreamAggregates: true}
+
+	predecessor := newSimulatedInstance("predecessor", predecessorDigest, nil, prrc, ds, offchainConfig)
+	predecessor.cdc.definitions = maps.Clone(channels)
+	successor := newSimulatedInstance("successor", types.ConfigDigest{0, 9, 2}, &predecessorDigest, prrc, ds, offchainConfig)
+	// the successor starts out with some of the channels, and learns of
+	// the rest over time. Channels are iterated in order so that
+	// simulations are reproducible from their seed.
+	for _, id := range sortedKeys(channels) {
+		if r.IntN(2) == 0 {
+			successor.cdc.definitions[id] = channels[id]
+		}
+	}
+
+	// Retirement may be triggered before the successor starts, while it is
+	// still syncing channels or once it is fully synced
+	successorStartsAt := r.IntN(handoverSimulationTicks / 3)
+	retireAt := r.IntN(handoverSimulationTicks / 2)
+	syncedBy := handoverSimulationTicks * 2 / 3
+	t.Logf("channels=%d successorStartsAt=%d retireAt=%d windDownRounds=%d", len(channels), successorStartsAt, retireAt, offchainConfig.RetirementWindDownRounds)
+
+	now := time.Unix(1_700_000_000, 0)
+	var reports []simulatedReport
+	for tick := range handoverSimulationTicks {
+		// rounds may be less than a second apart, so that channels are
+		// sometimes not reportable yet
+		now = now.Add(time.Duration(1+r.IntN(2500)) * time.Millisecond)
+		if tick == retireAt {
+			predecessor.src.shouldRetire = true
+		}
+		if (tick < syncedBy && r.IntN(3) == 0) || tick == syncedBy {
+			definitions := maps.Clone(successor.cdc.definitions)
+			for _, id := range sortedKeys(channels) {
+				if _, exists := definitions[id]; !exists && (tick == syncedBy || r.IntN(2) == 0) {
+					definitions[id] = channels[id]
+				}
+			}
+			successor.cdc.definitions = definitions
+		}
+
+		instances := []*simulatedInstance{predecessor, successor}
+		if r.IntN(2) == 0 {
+			// either instance may complete its round first
+			instances[0], instances[1] = instances[1], instances[0]
+		}
+		for _, s := range instances {
+			if s == successor && tick < successorStartsAt {
+				continue
+			}
+			rs, err := s.round(ctx, r, tick, now)
+			require.NoError(t, err)
+			reports = append(reports, rs...)
+			if s == predecessor && s.retirementReport != nil {
+				prrc.reports[predecessorDigest] = s.retirementReport
+			}
+		}
+	}
+
+	require.GreaterOrEqual(t, predecessor.retiredAt, 0, "predecessor never retired")
+	checkHandoverInvariants(t, predecessor, successor, channels, reports)
+}
+
+// checkHandoverInvariants checks the reports generated by both instances
+func checkHandoverInvariants(t *testing.T, predecessor, successor *simulatedInstance, channels llotypes.ChannelDefinitions, reports []simulatedReport) {
+	promotedAt := -1
+	// verifiable reports of each channel, in the order they were generated
+	verifiable := make(map[llotypes.ChannelID][]simulatedReport)
+	for _, sr := range reports {
+		r := sr.report
+		require.Equal(t, sr.stage != LifeCycleStageProduction, r.Specimen, "only production reports are verifiable: %+v", sr)
+		if r.Specimen {
+			continue
+		}
+		require.Less(t, r.ValidAfterSeconds, r.ObservationTimestampSeconds, "report covers an empty validity window: %+v", sr)
+
+		switch sr.instance {
+		case predecessor.name:
+			require.Less(t, sr.tick, predecessor.retiredAt, "predecessor generated a verifiable report after retiring: %+v", sr)
+		case successor.name:
+			require.GreaterOrEqual(t, sr.tick, predecessor.retiredAt, "successor generated a verifiable report before the predecessor retired: %+v", sr)
+			if promotedAt < 0 {
+				promotedAt = sr.tick
+			}
+		}
+		verifiable[r.ChannelID] = append(verifiable[r.ChannelID], sr)
+	}
+
+	for id, rs := range verifiable {
+		for i := 1; i < len(rs); i++ {
+			prev, next := rs[i-1], rs[i]
+			if prev.instance == successor.name {
+				require.Equal(t, successor.name, next.instance, "channel %d: predecessor reported after the successor took over", id)
+			}
+			// Gapless, non-overlapping chain of validity windows, across
+			// both instances
+			require.Equal(t, prev.report.ObservationTimestampSeconds, next.report.ValidAfterSeconds, "channel %d: validity windows of consecutive reports do not chain:\n%+v\n%+v", id, prev, next)
+		}
+	}
+
+	// Liveness: the successor took over and reported on every channel
+	// once it was fully synced
+	require.GreaterOrEqual(t, promotedAt, 0, "successor was never promoted")
+	for id := range channels {
+		rs := verifiable[id]
+		if assert.NotEmpty(t, rs, "channel %d was never reported", id) {
+			assert.Equal(t, successor.name, rs[len(rs)-1].instance, "channel %d was not taken over by the successor", id)
+		}
+	}
+}
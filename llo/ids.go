@@ -0,0 +1,72 @@
+package llo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+// ParseChannelID parses a channel ID in decimal, or in hex with a 0x prefix,
+// e.g. from a CLI flag or config file. It errors on values that do not fit
+// a ChannelID rather than truncating them.
+func ParseChannelID(s string) (llotypes.ChannelID, error) {
+	n, err := parseID("channel", s)
+	return llotypes.ChannelID(n), err
+}
+
+// ParseStreamID parses a stream ID like ParseChannelID
+func ParseStreamID(s string) (llotypes.StreamID, error) {
+	n, err := parseID("stream", s)
+	return llotypes.StreamID(n), err
+}
+
+// ChannelIDFromInt64 converts an integer of a wider type, e.g. a Postgres
+// bigint, to a ChannelID, checking that it is in range
+func ChannelIDFromInt64(n int64) (llotypes.ChannelID, error) {
+	id, err := idFromInt64("channel", n)
+	return llotypes.ChannelID(id), err
+}
+
+// StreamIDFromInt64 converts an integer to a StreamID like
+// ChannelIDFromInt64
+func StreamIDFromInt64(n int64) (llotypes.StreamID, error) {
+	id, err := idFromInt64("stream", n)
+	return llotypes.StreamID(id), err
+}
+
+// FormatChannelID formats a channel ID in decimal, e.g. for metric labels
+func FormatChannelID(id llotypes.ChannelID) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+// FormatStreamID formats a stream ID in decimal, e.g. for metric labels
+func FormatStreamID(id llotypes.StreamID) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+func parseID(kind, s string) (uint32, error) {
+	trimmed := strings.TrimSpace(s)
+	base := 10
+	if hex, ok := strings.CutPrefix(strings.ToLower(trimmed), "0x"); ok {
+		trimmed, base = hex, 16
+	}
+	n, err := strconv.ParseUint(trimmed, base, 32)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, fmt.Errorf("invalid %s ID %q: out of range, max is %d", kind, s, uint32(math.MaxUint32))
+		}
+		return 0, fmt.Errorf("invalid %s ID %q: expected a decimal or 0x-prefixed hex integer", kind, s)
+	}
+	return uint32(n), nil
+}
+
+func idFromInt64(kind string, n int64) (uint32, error) {
+	if n < 0 || n > math.MaxUint32 {
+		return 0, fmt.Errorf("invalid %s ID %d: out of range, max is %d", kind, n, uint32(math.MaxUint32))
+	}
+	return uint32(n), nil
+}
@@ -0,0 +1,64 @@
+package llo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+func Test_ParseChannelID(t *testing.T) {
+	for s, expected := range map[string]llotypes.ChannelID{
+		"0":          0,
+		"42":         42,
+		" 42 ":       42,
+		"0x2a":       42,
+		"0X2A":       42,
+		"4294967295": math.MaxUint32,
+		"0xffffffff": math.MaxUint32,
+	} {
+		id, err := ParseChannelID(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, expected, id, s)
+	}
+
+	_, err := ParseChannelID("4294967296")
+	assert.EqualError(t, err, `invalid channel ID "4294967296": out of range, max is 4294967295`)
+	_, err = ParseChannelID("0x100000000")
+	assert.ErrorContains(t, err, "out of range")
+	for _, s := range []string{"", "-1", "1.5", "foo", "0x", "2a"} {
+		_, err = ParseChannelID(s)
+		assert.ErrorContains(t, err, "expected a decimal or 0x-prefixed hex integer", s)
+	}
+}
+
+func Test_ParseStreamID(t *testing.T) {
+	id, err := ParseStreamID("0x10")
+	require.NoError(t, err)
+	assert.Equal(t, llotypes.StreamID(16), id)
+
+	_, err = ParseStreamID("-1")
+	assert.EqualError(t, err, `invalid stream ID "-1": expected a decimal or 0x-prefixed hex integer`)
+}
+
+func Test_IDFromInt64(t *testing.T) {
+	cid, err := ChannelIDFromInt64(math.MaxUint32)
+	require.NoError(t, err)
+	assert.Equal(t, llotypes.ChannelID(math.MaxUint32), cid)
+	_, err = ChannelIDFromInt64(math.MaxUint32 + 1)
+	assert.EqualError(t, err, "invalid channel ID 4294967296: out of range, max is 4294967295")
+
+	sid, err := StreamIDFromInt64(7)
+	require.NoError(t, err)
+	assert.Equal(t, llotypes.StreamID(7), sid)
+	_, err = StreamIDFromInt64(-1)
+	assert.EqualError(t, err, "invalid stream ID -1: out of range, max is 4294967295")
+}
+
+func Test_FormatIDs(t *testing.T) {
+	assert.Equal(t, "4294967295", FormatChannelID(math.MaxUint32))
+	assert.Equal(t, "7", FormatStreamID(7))
+}
@@ -0,0 +1,187 @@
+// Package poseidon implements the Poseidon hash function over the scalar
+// field of BN254, with the parameters used by circomlib: the x⁵ S-box, 8 full
+// rounds, and round constants and MDS matrices generated by the Grain LFSR of
+// the Poseidon reference implementation.
+//
+// Hashes therefore match circomlib's Poseidon(n) templates, so that values
+// hashed here can be recomputed cheaply inside circuits.
+package poseidon
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+)
+
+// MaxInputs is the maximum number of field elements that Hash accepts
+const MaxInputs = 8
+
+// Modulus is the order of the BN254 scalar field. Inputs must be smaller.
+var Modulus, _ = new(big.Int).SetString("21888242871839275222246405745257275088548364400416034343698204186575808495617", 10)
+
+const (
+	fieldBits  = 254
+	fullRounds = 8
+)
+
+// partialRounds is indexed by the number of inputs minus one
+var partialRounds = [MaxInputs]int{56, 57, 56, 60, 60, 63, 64, 63}
+
+type parameters struct {
+	constants []*big.Int
+	mds       [][]*big.Int
+}
+
+var (
+	paramsOnce [MaxInputs]sync.Once
+	params     [MaxInputs]*parameters
+)
+
+// Hash returns the Poseidon hash of between 1 and MaxInputs field elements
+func Hash(inputs ...*big.Int) (*big.Int, error) {
+	if len(inputs) == 0 || len(inputs) > MaxInputs {
+		return nil, fmt.Errorf("invalid number of inputs; got: %d, expected between 1 and %d", len(inputs), MaxInputs)
+	}
+	for i, in := range inputs {
+		if in == nil || in.Sign() < 0 || in.Cmp(Modulus) >= 0 {
+			return nil, fmt.Errorf("input %d is not a field element", i)
+		}
+	}
+	n := len(inputs) - 1
+	paramsOnce[n].Do(func() {
+		params[n] = newParameters(len(inputs)+1, partialRounds[n])
+	})
+	return permute(params[n], inputs, partialRounds[n]), nil
+}
+
+func permute(p *parameters, inputs []*big.Int, rp int) *big.Int {
+	t := len(inputs) + 1
+	state := make([]*big.Int, t)
+	state[0] = new(big.Int)
+	for i, in := range inputs {
+		state[i+1] = new(big.Int).Set(in)
+	}
+	next := make([]*big.Int, t)
+	for i := range next {
+		next[i] = new(big.Int)
+	}
+	tmp := new(big.Int)
+	for r := 0; r < fullRounds+rp; r++ {
+		for i := range state {
+			state[i].Add(state[i], p.constants[r*t+i])
+		}
+		if r < fullRounds/2 || r >= fullRounds/2+rp {
+			for i := range state {
+				sbox(state[i], tmp)
+			}
+		} else {
+			sbox(state[0], tmp)
+		}
+		for i := range next {
+			next[i].SetInt64(0)
+			for j := range state {
+				next[i].Add(next[i], tmp.Mul(p.mds[i][j], state[j]))
+			}
+			next[i].Mod(next[i], Modulus)
+		}
+		state, next = next, state
+	}
+	return state[0]
+}
+
+// sbox sets x to x⁵ mod Modulus
+func sbox(x, tmp *big.Int) {
+	tmp.Mul(x, x)
+	tmp.Mod(tmp, Modulus)
+	tmp.Mul(tmp, tmp)
+	tmp.Mod(tmp, Modulus)
+	x.Mul(x, tmp)
+	x.Mod(x, Modulus)
+}
+
+// newParameters derives the round constants and the Cauchy MDS matrix for a
+// state of width t from the Grain LFSR, as generate_parameters_grain.sage
+// does. As in circomlib, the first matrix generated is used.
+func newParameters(t, rp int) *parameters {
+	g := newGrain(t, rp)
+	p := &parameters{constants: make([]*big.Int, 0, (fullRounds+rp)*t)}
+	for len(p.constants) < cap(p.constants) {
+		// rejection sampling
+		if c := g.element(); c.Cmp(Modulus) < 0 {
+			p.constants = append(p.constants, c)
+		}
+	}
+	xs := make([]*big.Int, 2*t)
+	for i := range xs {
+		xs[i] = g.element()
+		xs[i].Mod(xs[i], Modulus)
+	}
+	p.mds = make([][]*big.Int, t)
+	for i := range p.mds {
+		p.mds[i] = make([]*big.Int, t)
+		for j := range p.mds[i] {
+			e := new(big.Int).Add(xs[i], xs[t+j])
+			p.mds[i][j] = e.ModInverse(e.Mod(e, Modulus), Modulus)
+		}
+	}
+	return p
+}
+
+// grain is the 80-bit Grain LFSR of the Poseidon reference implementation,
+// used as a self-shrinking generator
+type grain struct {
+	state [80]byte
+}
+
+func newGrain(t, rp int) *grain {
+	g := &grain{}
+	i := 0
+	put := func(v, width int) {
+		for b := width - 1; b >= 0; b-- {
+			g.state[i] = byte(v>>b) & 1
+			i++
+		}
+	}
+	put(1, 2) // prime field
+	put(0, 4) // x^α S-box
+	put(fieldBits, 12)
+	put(t, 12)
+	put(fullRounds, 10)
+	put(rp, 10)
+	for ; i < len(g.state); i++ {
+		g.state[i] = 1
+	}
+	for j := 0; j < 160; j++ {
+		g.update()
+	}
+	return g
+}
+
+func (g *grain) update() byte {
+	s := &g.state
+	b := s[62] ^ s[51] ^ s[38] ^ s[23] ^ s[13] ^ s[0]
+	copy(s[:], s[1:])
+	s[len(s)-1] = b
+	return b
+}
+
+// bit returns the second bit of the next pair of LFSR bits whose first bit
+// is set
+func (g *grain) bit() byte {
+	for g.update() == 0 {
+		g.update()
+	}
+	return g.update()
+}
+
+// element returns fieldBits bits, most significant first
+func (g *grain) element() *big.Int {
+	e := new(big.Int)
+	for i := 0; i < fieldBits; i++ {
+		e.Lsh(e, 1)
+		if g.bit() == 1 {
+			e.SetBit(e, 0, 1)
+		}
+	}
+	return e
+}
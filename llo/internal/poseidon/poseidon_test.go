@@ -0,0 +1,53 @@
+package poseidon
+
+import (
+	"math/big"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Hash(t *testing.T) {
+	t.Run("matches circomlib", func(t *testing.T) {
+		expected := []string{
+			"29176100eaa962bdc1fe6c654d6a3c130e96a4d1168b33848b897dc502820133",
+			"115cc0f5e7d690413df64c6b9662e9cf2a3617f2743245519e19607a4417189a",
+			"e7732d89e6939c0ff03d5e58dab6302f3230e269dc5b968f725df34ab36d732",
+			"299c867db6c1fdd79dcefa40e4510b9837e60ebb1ce0663dbaa525df65250465",
+			"dab9449e4a1398a15224c0b15a49d598b2174d305a316c918125f8feeb123c0",
+			"2d1a03850084442813c8ebf094dea47538490a68b05f2239134a4cca2f6302e1",
+			"1c2f3482dbb140c4ebb9ada49abdbc374a9a85fcfc6533ec2e9df45b4921c318",
+			"2921ab9bd0140cbc98e40395c0fefb40337a4d54fbbecd9a4d43b3d8d0c4d8d1",
+		}
+		for n := 1; n <= MaxInputs; n++ {
+			inputs := make([]*big.Int, n)
+			for i := range inputs {
+				inputs[i] = big.NewInt(int64(i + 1))
+			}
+			h, err := Hash(inputs...)
+			require.NoError(t, err)
+			assert.Equal(t, expected[n-1], h.Text(16), "Poseidon(1.."+strconv.Itoa(n)+")")
+		}
+	})
+	t.Run("does not modify inputs", func(t *testing.T) {
+		a, b := big.NewInt(1), big.NewInt(2)
+		_, err := Hash(a, b)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), a.Int64())
+		assert.Equal(t, int64(2), b.Int64())
+	})
+	t.Run("rejects invalid inputs", func(t *testing.T) {
+		_, err := Hash()
+		assert.EqualError(t, err, "invalid number of inputs; got: 0, expected between 1 and 8")
+		_, err = Hash(make([]*big.Int, MaxInputs+1)...)
+		assert.EqualError(t, err, "invalid number of inputs; got: 9, expected between 1 and 8")
+		_, err = Hash(big.NewInt(1), Modulus)
+		assert.EqualError(t, err, "input 1 is not a field element")
+		_, err = Hash(big.NewInt(-1))
+		assert.EqualError(t, err, "input 0 is not a field element")
+		_, err = Hash(nil)
+		assert.EqualError(t, err, "input 0 is not a field element")
+	})
+}
@@ -0,0 +1,157 @@
+package llo
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+// maxIntrospectionErrors is the number of most recent errors kept by
+// PluginIntrospector
+const maxIntrospectionErrors = 10
+
+// Introspector gives operators read-only access to the live state of a
+// plugin
+type Introspector interface {
+	State() PluginState
+}
+
+// PluginState is a snapshot of a plugin's state
+type PluginState struct {
+	// ConfigDigest of the plugin that produced LatestOutcome
+	ConfigDigest types.ConfigDigest `json:"configDigest"`
+	// LatestOutcome is nil until the plugin has generated reports for a
+	// committed outcome
+	LatestOutcome *OutcomeSummary `json:"latestOutcome,omitempty"`
+	// LastErrors are the most recent errors returned by the plugin, oldest
+	// first
+	LastErrors []PluginError `json:"lastErrors"`
+	// LastTransmissions is the last transmitted report of every channel, if
+	// the introspector tracks them
+	LastTransmissions map[llotypes.ChannelID]LastTransmission `json:"lastTransmissions,omitempty"`
+	// ChannelDefinitionsSync is nil until the plugin has compared its
+	// desired channel definitions with those of an outcome
+	ChannelDefinitionsSync *ChannelDefinitionsSyncStatus `json:"channelDefinitionsSync,omitempty"`
+}
+
+// OutcomeSummary summarizes a committed Outcome
+type OutcomeSummary struct {
+	SeqNr                            uint64                  `json:"seqNr"`
+	LifeCycleStage                   llotypes.LifeCycleStage `json:"lifeCycleStage"`
+	ObservationsTimestampNanoseconds int64                   `json:"observationsTimestampNanoseconds"`
+	ChannelCount                     int                     `json:"channelCount"`
+	ReportableChannelCount           int                     `json:"reportableChannelCount"`
+	StreamAggregateCount             int                     `json:"streamAggregateCount"`
+	ReportCount                      int                     `json:"reportCount"`
+	RecordedAt                       time.Time               `json:"recordedAt"`
+}
+
+type PluginError struct {
+	Stage      string    `json:"stage"`
+	SeqNr      uint64    `json:"seqNr"`
+	Error      string    `json:"error"`
+	RecordedAt time.Time `json:"recordedAt"`
+}
+
+var _ Introspector = &PluginIntrospector{}
+var _ http.Handler = &PluginIntrospector{}
+
+// PluginIntrospector records the state of the plugins created by a
+// PluginFactory. It also serves the state as JSON, for mounting on a debug
+// HTTP server.
+type PluginIntrospector struct {
+	mu    sync.RWMutex
+	state PluginState
+
+	lastTransmissions *LastTransmissions
+}
+
+func NewPluginIntrospector() *PluginIntrospector {
+	return &PluginIntrospector{}
+}
+
+// TrackLastTransmissions includes the last transmissions recorded by lt in
+// the introspected state
+func (i *PluginIntrospector) TrackLastTransmissions(lt *LastTransmissions) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.lastTransmissions = lt
+}
+
+func (i *PluginIntrospector) State() PluginState {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	state := i.state
+	if state.LatestOutcome != nil {
+		summary := *state.LatestOutcome
+		state.LatestOutcome = &summary
+	}
+	if state.ChannelDefinitionsSync != nil {
+		status := *state.ChannelDefinitionsSync
+		state.ChannelDefinitionsSync = &status
+	}
+	state.LastErrors = append([]PluginError{}, state.LastErrors...)
+	if i.lastTransmissions != nil {
+		state.LastTransmissions = i.lastTransmissions.Get()
+	}
+	return state
+}
+
+func (i *PluginIntrospector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	// PluginState always marshals successfully
+	_ = json.NewEncoder(w).Encode(i.State())
+}
+
+func (i *PluginIntrospector) recordOutcome(cd types.ConfigDigest, summary OutcomeSummary) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	summary.RecordedAt = time.Now()
+	i.state.ConfigDigest = cd
+	i.state.LatestOutcome = &summary
+}
+
+// restoreOutcome records a summary restored from an outcome snapshot, unless
+// a live outcome was recorded already
+func (i *PluginIntrospector) restoreOutcome(cd types.ConfigDigest, summary OutcomeSummary) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.state.LatestOutcome != nil {
+		return
+	}
+	i.state.ConfigDigest = cd
+	i.state.LatestOutcome = &summary
+}
+
+func (i *PluginIntrospector) recordChannelDefinitionsSync(status ChannelDefinitionsSyncStatus) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	status.RecordedAt = time.Now()
+	i.state.ChannelDefinitionsSync = &status
+}
+
+func (i *PluginIntrospector) recordError(stage string, seqNr uint64, err error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if len(i.state.LastErrors) == maxIntrospectionErrors {
+		i.state.LastErrors = append(i.state.LastErrors[:0], i.state.LastErrors[1:]...)
+	}
+	i.state.LastErrors = append(i.state.LastErrors, PluginError{stage, seqNr, err.Error(), time.Now()})
+}
+
+// recordError records a non-nil err returned by the plugin in the given stage
+func (p *Plugin) recordError(stage string, seqNr uint64, err error) {
+	if p.Introspector != nil && err != nil {
+		p.Introspector.recordError(stage, seqNr, err)
+	}
+}
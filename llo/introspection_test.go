@@ -0,0 +1,128 @@
+package llo
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+func Test_PluginIntrospector(t *testing.T) {
+	ctx := tests.Context(t)
+	i := NewPluginIntrospector()
+	p := &Plugin{
+		ConfigDigest: types.ConfigDigest{1},
+		OutcomeCodec: protoOutcomeCodec{},
+		Logger:       logger.Test(t),
+		ReportCodecs: map[llotypes.ReportFormat]ReportCodec{
+			llotypes.ReportFormatJSON: JSONReportCodec{},
+		},
+		Introspector: i,
+	}
+
+	t.Run("is empty initially", func(t *testing.T) {
+		state := i.State()
+		assert.Nil(t, state.LatestOutcome)
+		assert.Empty(t, state.LastErrors)
+	})
+	t.Run("records the latest committed outcome", func(t *testing.T) {
+		outcome := Outcome{
+			LifeCycleStage:                   LifeCycleStageProduction,
+			ObservationsTimestampNanoseconds: int64(200 * time.Second),
+			ValidAfterSeconds: map[llotypes.ChannelID]uint32{
+				1: 100,
+				2: 200,
+			},
+			ChannelDefinitions: map[llotypes.ChannelID]llotypes.ChannelDefinition{
+				1: {ReportFormat: llotypes.ReportFormatJSON, Streams: []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}}},
+				2: {ReportFormat: llotypes.ReportFormatJSON, Streams: []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}}},
+			},
+			StreamAggregates: map[llotypes.StreamID]map[llotypes.Aggregator]StreamValue{
+				1: {llotypes.AggregatorMedian: ToDecimal(decimal.NewFromFloat(1.1))},
+			},
+		}
+		encoded, err := p.OutcomeCodec.Encode(outcome)
+		require.NoError(t, err)
+		rwis, err := p.Reports(ctx, 3, encoded)
+		require.NoError(t, err)
+		require.Len(t, rwis, 1)
+
+		state := i.State()
+		assert.Equal(t, types.ConfigDigest{1}, state.ConfigDigest)
+		require.NotNil(t, state.LatestOutcome)
+		assert.WithinDuration(t, time.Now(), state.LatestOutcome.RecordedAt, time.Minute)
+		state.LatestOutcome.RecordedAt = time.Time{}
+		assert.Equal(t, OutcomeSummary{
+			SeqNr:                            3,
+			LifeCycleStage:                   LifeCycleStageProduction,
+			ObservationsTimestampNanoseconds: int64(200 * time.Second),
+			ChannelCount:                     2,
+			ReportableChannelCount:           1,
+			StreamAggregateCount:             1,
+			ReportCount:                      1,
+		}, *state.LatestOutcome)
+
+		// State returns a copy
+		assert.Equal(t, uint64(3), i.State().LatestOutcome.SeqNr)
+		assert.False(t, i.State().LatestOutcome.RecordedAt.IsZero())
+	})
+	t.Run("records errors", func(t *testing.T) {
+		_, err := p.Reports(ctx, 4, []byte("invalid"))
+		require.Error(t, err)
+		_, err = p.Outcome(ctx, ocr3types.OutcomeContext{SeqNr: 5}, nil, nil)
+		require.Error(t, err)
+
+		state := i.State()
+		require.Len(t, state.LastErrors, 2)
+		assert.Equal(t, "Report", state.LastErrors[0].Stage)
+		assert.Equal(t, uint64(4), state.LastErrors[0].SeqNr)
+		assert.Contains(t, state.LastErrors[0].Error, "error unmarshalling outcome")
+		assert.Equal(t, "Outcome", state.LastErrors[1].Stage)
+		assert.Equal(t, uint64(5), state.LastErrors[1].SeqNr)
+		assert.Contains(t, state.LastErrors[1].Error, "expected at least 2f+1 attributed observations")
+		assert.Equal(t, uint64(3), state.LatestOutcome.SeqNr, "failed rounds do not replace the latest outcome")
+	})
+	t.Run("keeps only the most recent errors", func(t *testing.T) {
+		for n := 0; n < maxIntrospectionErrors+5; n++ {
+			i.recordError("Observation", uint64(n), errors.New("data source failed"))
+		}
+		state := i.State()
+		require.Len(t, state.LastErrors, maxIntrospectionErrors)
+		assert.Equal(t, uint64(5), state.LastErrors[0].SeqNr)
+		assert.Equal(t, uint64(maxIntrospectionErrors+4), state.LastErrors[maxIntrospectionErrors-1].SeqNr)
+	})
+	t.Run("ServeHTTP", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		i.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/llo", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		var state struct {
+			ConfigDigest  string
+			LatestOutcome OutcomeSummary
+			LastErrors    []PluginError
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &state))
+		assert.Equal(t, types.ConfigDigest{1}.Hex(), state.ConfigDigest)
+		assert.Equal(t, uint64(3), state.LatestOutcome.SeqNr)
+		assert.Equal(t, 2, state.LatestOutcome.ChannelCount)
+		assert.Len(t, state.LastErrors, maxIntrospectionErrors)
+
+		rec = httptest.NewRecorder()
+		i.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/debug/llo", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+		assert.Equal(t, "GET, HEAD", rec.Header().Get("Allow"))
+	})
+}
@@ -36,6 +36,12 @@ func UnmarshalJSONStreamValue(enc *JSONStreamValue) (StreamValue, error) {
 			return nil, err
 		}
 		return sv, nil
+	case LLOStreamValue_PriceVolumes:
+		sv := new(PriceVolumes)
+		if err := (sv).UnmarshalText([]byte(enc.Value)); err != nil {
+			return nil, err
+		}
+		return sv, nil
 	default:
 		return nil, fmt.Errorf("unknown StreamValueType %d", enc.Type)
 	}
@@ -54,6 +60,7 @@ func (cdc JSONReportCodec) Encode(_ context.Context, r Report, _ llotypes.Channe
 		ObservationTimestampSeconds uint32
 		Values                      []JSONStreamValue
 		Specimen                    bool
+		ReducedConfidence           bool `json:",omitempty"`
 	}
 	values := make([]JSONStreamValue, len(r.Values))
 	for i, sv := range r.Values {
@@ -77,6 +84,7 @@ func (cdc JSONReportCodec) Encode(_ context.Context, r Report, _ llotypes.Channe
 		ObservationTimestampSeconds: r.ObservationTimestampSeconds,
 		Values:                      values,
 		Specimen:                    r.Specimen,
+		ReducedConfidence:           r.ReducedConfidence,
 	}
 	return json.Marshal(e)
 }
@@ -90,6 +98,7 @@ func (cdc JSONReportCodec) Decode(b []byte) (r Report, err error) {
 		ObservationTimestampSeconds uint32
 		Values                      []JSONStreamValue
 		Specimen                    bool
+		ReducedConfidence           bool
 	}
 	d := decode{}
 	err = json.Unmarshal(b, &d)
@@ -125,6 +134,7 @@ func (cdc JSONReportCodec) Decode(b []byte) (r Report, err error) {
 		ObservationTimestampSeconds: d.ObservationTimestampSeconds,
 		Values:                      values,
 		Specimen:                    d.Specimen,
+		ReducedConfidence:           d.ReducedConfidence,
 	}, err
 }
 
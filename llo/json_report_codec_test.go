@@ -110,6 +110,7 @@ func Test_JSONCodec_Properties(t *testing.T) {
 			"ObservationTimestampSeconds": gen.UInt32(),
 			"Values":                      genStreamValues(),
 			"Specimen":                    gen.Bool(),
+			"ReducedConfidence":           gen.Bool(),
 		}),
 	))
 
@@ -172,7 +173,7 @@ func equalReports(r, r2 Report) bool {
 			return false
 		}
 	}
-	return r.Specimen == r2.Specimen
+	return r.Specimen == r2.Specimen && r.ReducedConfidence == r2.ReducedConfidence
 }
 
 func equalStreamValues(sv, sv2 StreamValue) bool {
@@ -247,14 +248,30 @@ func genQuote() gopter.Gen {
 	}
 }
 
+func genPriceVolumes() gopter.Gen {
+	return func(p *gopter.GenParameters) *gopter.GenResult {
+		pvs := make(PriceVolumes, p.Rng.Intn(MaxPriceVolumePairs+1))
+		for i := range pvs {
+			pvs[i] = PriceVolume{
+				Price:  decimal.NewFromFloat(p.Rng.Float64()),
+				Volume: decimal.NewFromFloat(p.Rng.Float64()),
+			}
+		}
+		var sv StreamValue = &pvs
+		return gopter.NewGenResult(sv, gopter.NoShrinker)
+	}
+}
+
 func genStreamValue() gopter.Gen {
 	return func(p *gopter.GenParameters) *gopter.GenResult {
-		switch p.Rng.Intn(3) {
+		switch p.Rng.Intn(4) {
 		case 0:
 			return genDecimalValue()(p)
 		case 1:
 			return genQuote()(p)
 		case 2:
+			return genPriceVolumes()(p)
+		case 3:
 			return gopter.NewGenResult((StreamValue)(nil), gopter.NoShrinker)
 		}
 		return nil
@@ -291,6 +308,14 @@ func Test_JSONCodec(t *testing.T) {
 		require.NoError(t, err)
 
 		assert.Equal(t, r, decoded)
+
+		r.ReducedConfidence = true
+		encoded, err = cdc.Encode(ctx, r, llo.ChannelDefinition{})
+		require.NoError(t, err)
+		assert.Contains(t, string(encoded), `"Specimen":true,"ReducedConfidence":true}`)
+		decoded, err = cdc.Decode(encoded)
+		require.NoError(t, err)
+		assert.Equal(t, r, decoded)
 	})
 	t.Run("Pack=>Unpack", func(t *testing.T) {
 		t.Run("report is not valid JSON", func(t *testing.T) {
@@ -0,0 +1,264 @@
+package llo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+// LastTransmission is the most recent report of a channel that was handed to
+// the transmitter
+type LastTransmission struct {
+	ConfigDigest                types.ConfigDigest `json:"configDigest"`
+	SeqNr                       uint64             `json:"seqNr"`
+	ObservationTimestampSeconds uint32             `json:"observationTimestampSeconds"`
+	TransmittedAt               time.Time          `json:"transmittedAt"`
+}
+
+// UnmarshalJSON decodes the hex ConfigDigest written by its MarshalText
+func (t *LastTransmission) UnmarshalJSON(b []byte) error {
+	type lastTransmission LastTransmission
+	var d struct {
+		lastTransmission
+		ConfigDigest string `json:"configDigest"`
+	}
+	if err := json.Unmarshal(b, &d); err != nil {
+		return err
+	}
+	cd, err := decodeConfigDigestHex(d.ConfigDigest)
+	if err != nil {
+		return err
+	}
+	*t = LastTransmission(d.lastTransmission)
+	t.ConfigDigest = cd
+	return nil
+}
+
+// decodeConfigDigestHex is the inverse of types.ConfigDigest.MarshalText
+func decodeConfigDigestHex(s string) (types.ConfigDigest, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return types.ConfigDigest{}, fmt.Errorf("invalid ConfigDigest; %w", err)
+	}
+	cd, err := types.BytesToConfigDigest(b)
+	if err != nil {
+		return types.ConfigDigest{}, fmt.Errorf("invalid ConfigDigest; %w", err)
+	}
+	return cd, nil
+}
+
+// LastTransmissionStore persists the last transmission of every channel, so
+// that channels which stopped reporting remain visible across restarts
+type LastTransmissionStore interface {
+	// LastTransmissions returns the last transmission of every channel
+	LastTransmissions() (map[llotypes.ChannelID]LastTransmission, error)
+	// SetLastTransmissions persists the last transmission of every channel
+	SetLastTransmissions(map[llotypes.ChannelID]LastTransmission) error
+}
+
+var _ LastTransmissionStore = &FileLastTransmissionStore{}
+
+// FileLastTransmissionStore persists last transmissions as a JSON file
+type FileLastTransmissionStore struct {
+	path string
+}
+
+func NewFileLastTransmissionStore(path string) *FileLastTransmissionStore {
+	return &FileLastTransmissionStore{path}
+}
+
+// LastTransmissions returns an empty map if the file does not exist yet
+func (s *FileLastTransmissionStore) LastTransmissions() (map[llotypes.ChannelID]LastTransmission, error) {
+	b, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[llotypes.ChannelID]LastTransmission{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	m := map[llotypes.ChannelID]LastTransmission{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", s.path, err)
+	}
+	return m, nil
+}
+
+// SetLastTransmissions replaces the file atomically
+func (s *FileLastTransmissionStore) SetLastTransmissions(m map[llotypes.ChannelID]LastTransmission) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(s.path, b)
+}
+
+// writeFileAtomic writes to a temporary file which is renamed into place
+// once complete, so that a crash never leaves a partially written file
+func writeFileAtomic(path string, b []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*.tmp")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	defer os.Remove(tmp) //nolint:errcheck // no-op once renamed
+
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// lastTransmissionsPersistInterval limits how often LastTransmissions writes
+// to its store; every channel transmits about once per round, so persisting
+// on every transmission would rewrite the store many times per second
+const lastTransmissionsPersistInterval = 10 * time.Second
+
+// LastTransmissions records the last transmitted report of every channel,
+// so that operators can detect channels that silently stopped reporting. It
+// exports the last transmission as metrics and, if given to a
+// PluginIntrospector, through the introspection API.
+//
+// A single LastTransmissions may be shared by plugins for several config
+// digests.
+type LastTransmissions struct {
+	lggr  logger.Logger
+	store LastTransmissionStore
+
+	mu   sync.Mutex
+	last map[llotypes.ChannelID]LastTransmission
+	// config digest => seqNr => report hash => channel report generated by
+	// Plugin.Reports, awaiting transmission
+	pending       map[types.ConfigDigest]map[uint64]map[[32]byte]pendingTransmission
+	lastPersisted time.Time
+	dirty         bool
+}
+
+type pendingTransmission struct {
+	channelID                   llotypes.ChannelID
+	observationTimestampSeconds uint32
+}
+
+// NewLastTransmissions loads the last transmissions from the store. The
+// store may be nil, in which case last transmissions are kept in memory
+// only.
+func NewLastTransmissions(lggr logger.Logger, store LastTransmissionStore) (*LastTransmissions, error) {
+	last := map[llotypes.ChannelID]LastTransmission{}
+	if store != nil {
+		var err error
+		if last, err = store.LastTransmissions(); err != nil {
+			return nil, fmt.Errorf("failed to load last transmissions: %w", err)
+		}
+	}
+	for cid, lt := range last {
+		setLastTransmissionMetrics(cid, lt)
+	}
+	return &LastTransmissions{
+		lggr:    logger.Named(lggr, "LastTransmissions"),
+		store:   store,
+		last:    last,
+		pending: make(map[types.ConfigDigest]map[uint64]map[[32]byte]pendingTransmission),
+	}, nil
+}
+
+// Get returns the last transmission of every channel
+func (l *LastTransmissions) Get() map[llotypes.ChannelID]LastTransmission {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	last := make(map[llotypes.ChannelID]LastTransmission, len(l.last))
+	for cid, lt := range l.last {
+		last[cid] = lt
+	}
+	return last
+}
+
+// Flush persists any last transmissions that have not been written to the
+// store yet, e.g. on shutdown
+func (l *LastTransmissions) Flush() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.persist()
+}
+
+// generated remembers a channel report generated by Plugin.Reports until it
+// is transmitted
+func (l *LastTransmissions) generated(digest types.ConfigDigest, seqNr uint64, report types.Report, cid llotypes.ChannelID, observationTimestampSeconds uint32) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	bySeqNr, exists := l.pending[digest]
+	if !exists {
+		bySeqNr = make(map[uint64]map[[32]byte]pendingTransmission)
+		l.pending[digest] = bySeqNr
+	}
+	byReport, exists := bySeqNr[seqNr]
+	if !exists {
+		byReport = make(map[[32]byte]pendingTransmission)
+		bySeqNr[seqNr] = byReport
+		for s := range bySeqNr {
+			if s+transmissionTargetsRetention <= seqNr {
+				delete(bySeqNr, s)
+			}
+		}
+	}
+	byReport[sha256.Sum256(report)] = pendingTransmission{cid, observationTimestampSeconds}
+}
+
+// transmitted records the transmission of a report. Reports that were not
+// generated by Plugin.Reports, e.g. retirement reports, are ignored.
+func (l *LastTransmissions) transmitted(digest types.ConfigDigest, seqNr uint64, report types.Report) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	byReport := l.pending[digest][seqNr]
+	hash := sha256.Sum256(report)
+	pt, exists := byReport[hash]
+	if !exists {
+		return
+	}
+	delete(byReport, hash)
+
+	// OCR may transmit reports out of order; never go backwards
+	if prev, exists := l.last[pt.channelID]; exists && prev.ConfigDigest == digest && prev.SeqNr >= seqNr {
+		return
+	}
+	lt := LastTransmission{digest, seqNr, pt.observationTimestampSeconds, time.Now()}
+	l.last[pt.channelID] = lt
+	l.dirty = true
+	setLastTransmissionMetrics(pt.channelID, lt)
+
+	if time.Since(l.lastPersisted) >= lastTransmissionsPersistInterval {
+		if err := l.persist(); err != nil {
+			l.lggr.Errorw("Failed to persist last transmissions", "err", err)
+		}
+	}
+}
+
+func (l *LastTransmissions) persist() error {
+	if l.store == nil || !l.dirty {
+		return nil
+	}
+	if err := l.store.SetLastTransmissions(l.last); err != nil {
+		return err
+	}
+	l.lastPersisted = time.Now()
+	l.dirty = false
+	return nil
+}
+
+func setLastTransmissionMetrics(cid llotypes.ChannelID, lt LastTransmission) {
+	channelID := FormatChannelID(cid)
+	promLastTransmittedSeqNr.WithLabelValues(channelID).Set(float64(lt.SeqNr))
+	promLastTransmittedObservationTimestampSeconds.WithLabelValues(channelID).Set(float64(lt.ObservationTimestampSeconds))
+}
@@ -0,0 +1,147 @@
+package llo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/shopspring/decimal"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+func Test_FileLastTransmissionStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "last_transmissions.json")
+	s := NewFileLastTransmissionStore(path)
+
+	t.Run("returns empty map if file does not exist", func(t *testing.T) {
+		m, err := s.LastTransmissions()
+		require.NoError(t, err)
+		assert.Empty(t, m)
+	})
+	t.Run("round trips", func(t *testing.T) {
+		m := map[llotypes.ChannelID]LastTransmission{
+			1: {types.ConfigDigest{1}, 2, 3, time.Unix(4, 0).UTC()},
+			5: {types.ConfigDigest{6}, 7, 8, time.Unix(9, 0).UTC()},
+		}
+		require.NoError(t, s.SetLastTransmissions(m))
+		actual, err := s.LastTransmissions()
+		require.NoError(t, err)
+		assert.Equal(t, m, actual)
+
+		// no temporary files are left behind
+		entries, err := os.ReadDir(filepath.Dir(path))
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+	t.Run("errors on corrupt file", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte("foo"), 0o600))
+		_, err := s.LastTransmissions()
+		assert.ErrorContains(t, err, "failed to decode")
+	})
+}
+
+func Test_LastTransmissions(t *testing.T) {
+	ctx := tests.Context(t)
+	store := NewFileLastTransmissionStore(filepath.Join(t.TempDir(), "last_transmissions.json"))
+	lt, err := NewLastTransmissions(logger.Test(t), store)
+	require.NoError(t, err)
+	i := NewPluginIntrospector()
+	i.TrackLastTransmissions(lt)
+
+	digest := types.ConfigDigest{1}
+	p := &Plugin{
+		ConfigDigest: digest,
+		OutcomeCodec: protoOutcomeCodec{},
+		Logger:       logger.Test(t),
+		ReportCodecs: map[llotypes.ReportFormat]ReportCodec{
+			llotypes.ReportFormatJSON: JSONReportCodec{},
+		},
+		RetirementReportCodec: StandardRetirementReportCodec{},
+		LastTransmissions:     lt,
+	}
+	reports := func(seqNr uint64, observationsTimestampSeconds uint32, stage llotypes.LifeCycleStage) []ocr3types.ReportPlus[llotypes.ReportInfo] {
+		encoded, err2 := p.OutcomeCodec.Encode(Outcome{
+			LifeCycleStage:                   stage,
+			ObservationsTimestampNanoseconds: int64(observationsTimestampSeconds) * int64(time.Second),
+			ValidAfterSeconds:                map[llotypes.ChannelID]uint32{1: 100, 2: 100},
+			ChannelDefinitions: map[llotypes.ChannelID]llotypes.ChannelDefinition{
+				1: {ReportFormat: llotypes.ReportFormatJSON, Streams: []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}}},
+				2: {ReportFormat: llotypes.ReportFormatJSON, Streams: []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}}},
+			},
+			StreamAggregates: map[llotypes.StreamID]map[llotypes.Aggregator]StreamValue{
+				1: {llotypes.AggregatorMedian: ToDecimal(decimal.NewFromFloat(1.1))},
+			},
+		})
+		require.NoError(t, err2)
+		rwis, err2 := p.Reports(ctx, seqNr, encoded)
+		require.NoError(t, err2)
+		return rwis
+	}
+	transmit := func(seqNr uint64, rwi ocr3types.ReportPlus[llotypes.ReportInfo]) {
+		ok, err2 := p.ShouldTransmitAcceptedReport(ctx, seqNr, rwi.ReportWithInfo)
+		require.NoError(t, err2)
+		require.True(t, ok)
+	}
+
+	t.Run("is empty initially", func(t *testing.T) {
+		assert.Empty(t, lt.Get())
+		assert.Empty(t, i.State().LastTransmissions)
+	})
+
+	t.Run("does not record generated reports until they are transmitted", func(t *testing.T) {
+		rwis := reports(2, 200, LifeCycleStageProduction)
+		require.Len(t, rwis, 2)
+		assert.Empty(t, lt.Get())
+
+		transmit(2, rwis[0])
+		last := lt.Get()
+		require.Len(t, last, 1)
+		assert.Equal(t, digest, last[1].ConfigDigest)
+		assert.Equal(t, uint64(2), last[1].SeqNr)
+		assert.Equal(t, uint32(200), last[1].ObservationTimestampSeconds)
+		assert.WithinDuration(t, time.Now(), last[1].TransmittedAt, time.Minute)
+
+		assert.Equal(t, float64(2), testutil.ToFloat64(promLastTransmittedSeqNr.WithLabelValues("1")))
+		assert.Equal(t, float64(200), testutil.ToFloat64(promLastTransmittedObservationTimestampSeconds.WithLabelValues("1")))
+		assert.Equal(t, last, i.State().LastTransmissions)
+	})
+
+	t.Run("does not go backwards if transmissions are out of order", func(t *testing.T) {
+		rwis3 := reports(3, 201, LifeCycleStageProduction)
+		rwis4 := reports(4, 202, LifeCycleStageProduction)
+		transmit(4, rwis4[0])
+		transmit(3, rwis3[0])
+		assert.Equal(t, uint64(4), lt.Get()[1].SeqNr)
+		assert.Equal(t, uint32(202), lt.Get()[1].ObservationTimestampSeconds)
+	})
+
+	t.Run("ignores retirement reports and unknown reports", func(t *testing.T) {
+		rwis := reports(5, 203, LifeCycleStageRetired)
+		require.Len(t, rwis, 1)
+		transmit(5, rwis[0])
+		transmit(5, ocr3types.ReportPlus[llotypes.ReportInfo]{ReportWithInfo: ocr3types.ReportWithInfo[llotypes.ReportInfo]{Report: types.Report("foo")}})
+		assert.Equal(t, uint64(4), lt.Get()[1].SeqNr)
+	})
+
+	t.Run("persists last transmissions across restarts", func(t *testing.T) {
+		require.NoError(t, lt.Flush())
+		restarted, err := NewLastTransmissions(logger.Test(t), store)
+		require.NoError(t, err)
+		expected := lt.Get()
+		actual := restarted.Get()
+		require.Len(t, actual, 1)
+		assert.Equal(t, expected[1].SeqNr, actual[1].SeqNr)
+		assert.Equal(t, expected[1].ObservationTimestampSeconds, actual[1].ObservationTimestampSeconds)
+		assert.True(t, expected[1].TransmittedAt.Equal(actual[1].TransmittedAt))
+	})
+}
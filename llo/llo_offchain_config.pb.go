@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.28.1
+// 	protoc-gen-go v1.34.2
 // 	protoc        v4.23.2
 // source: llo_offchain_config.proto
 
@@ -24,6 +24,41 @@ type LLOOffchainConfigProto struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// Added in version 2
+	SkipUnchangedStreamValues bool `protobuf:"varint,1,opt,name=skipUnchangedStreamValues,proto3" json:"skipUnchangedStreamValues,omitempty"`
+	// Decimal string; empty means zero. Added in version 2.
+	UnchangedStreamValueEpsilon string `protobuf:"bytes,2,opt,name=unchangedStreamValueEpsilon,proto3" json:"unchangedStreamValueEpsilon,omitempty"`
+	// Zero disables staleness checks. Added in version 3.
+	StreamStalenessBoundNanoseconds uint64 `protobuf:"varint,3,opt,name=streamStalenessBoundNanoseconds,proto3" json:"streamStalenessBoundNanoseconds,omitempty"`
+	// Zero means the maximum observation length allowed by libocr. Added
+	// in version 4.
+	MaxObservationBytes uint32 `protobuf:"varint,4,opt,name=maxObservationBytes,proto3" json:"maxObservationBytes,omitempty"`
+	// Number of rounds for which a retired instance keeps generating
+	// specimen reports; zero stops reporting immediately. Added in
+	// version 5.
+	RetirementWindDownRounds uint32 `protobuf:"varint,5,opt,name=retirementWindDownRounds,proto3" json:"retirementWindDownRounds,omitempty"`
+	// Zero means the default number of rounds. Added in version 6.
+	DegradedModeRounds uint32 `protobuf:"varint,6,opt,name=degradedModeRounds,proto3" json:"degradedModeRounds,omitempty"`
+	// Added in version 6
+	ReducedConfidenceReports bool `protobuf:"varint,7,opt,name=reducedConfidenceReports,proto3" json:"reducedConfidenceReports,omitempty"`
+	// Added in version 7
+	ChannelPriorityClasses bool `protobuf:"varint,8,opt,name=channelPriorityClasses,proto3" json:"channelPriorityClasses,omitempty"`
+	// Version of the schema that the config was encoded with. Zero for
+	// configs of the original schema, which had no fields and is version 1.
+	Version uint32 `protobuf:"varint,9,opt,name=version,proto3" json:"version,omitempty"`
+	// Zero means the maximum report count allowed by libocr. Added in
+	// version 8.
+	MaxReportsPerRound uint32 `protobuf:"varint,10,opt,name=maxReportsPerRound,proto3" json:"maxReportsPerRound,omitempty"`
+	// Seconds by which each report on a channel overlaps the previous one;
+	// zero chains reports without gaps or overlap. Added in version 9.
+	ValidAfterSecondsOverlapSeconds uint32 `protobuf:"varint,11,opt,name=validAfterSecondsOverlapSeconds,proto3" json:"validAfterSecondsOverlapSeconds,omitempty"`
+	// Seconds before it was added from which the first report of a new
+	// channel is valid. Added in version 9.
+	NewChannelGracePeriodSeconds uint32 `protobuf:"varint,12,opt,name=newChannelGracePeriodSeconds,proto3" json:"newChannelGracePeriodSeconds,omitempty"`
+	// Makes channels unreportable while none of their streams were
+	// aggregated. Added in version 10.
+	RequireStreamAggregates bool `protobuf:"varint,13,opt,name=requireStreamAggregates,proto3" json:"requireStreamAggregates,omitempty"`
 }
 
 func (x *LLOOffchainConfigProto) Reset() {
@@ -58,14 +93,152 @@ func (*LLOOffchainConfigProto) Descriptor() ([]byte, []int) {
 	return file_llo_offchain_config_proto_rawDescGZIP(), []int{0}
 }
 
+func (x *LLOOffchainConfigProto) GetSkipUnchangedStreamValues() bool {
+	if x != nil {
+		return x.SkipUnchangedStreamValues
+	}
+	return false
+}
+
+func (x *LLOOffchainConfigProto) GetUnchangedStreamValueEpsilon() string {
+	if x != nil {
+		return x.UnchangedStreamValueEpsilon
+	}
+	return ""
+}
+
+func (x *LLOOffchainConfigProto) GetStreamStalenessBoundNanoseconds() uint64 {
+	if x != nil {
+		return x.StreamStalenessBoundNanoseconds
+	}
+	return 0
+}
+
+func (x *LLOOffchainConfigProto) GetMaxObservationBytes() uint32 {
+	if x != nil {
+		return x.MaxObservationBytes
+	}
+	return 0
+}
+
+func (x *LLOOffchainConfigProto) GetRetirementWindDownRounds() uint32 {
+	if x != nil {
+		return x.RetirementWindDownRounds
+	}
+	return 0
+}
+
+func (x *LLOOffchainConfigProto) GetDegradedModeRounds() uint32 {
+	if x != nil {
+		return x.DegradedModeRounds
+	}
+	return 0
+}
+
+func (x *LLOOffchainConfigProto) GetReducedConfidenceReports() bool {
+	if x != nil {
+		return x.ReducedConfidenceReports
+	}
+	return false
+}
+
+func (x *LLOOffchainConfigProto) GetChannelPriorityClasses() bool {
+	if x != nil {
+		return x.ChannelPriorityClasses
+	}
+	return false
+}
+
+func (x *LLOOffchainConfigProto) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *LLOOffchainConfigProto) GetMaxReportsPerRound() uint32 {
+	if x != nil {
+		return x.MaxReportsPerRound
+	}
+	return 0
+}
+
+func (x *LLOOffchainConfigProto) GetValidAfterSecondsOverlapSeconds() uint32 {
+	if x != nil {
+		return x.ValidAfterSecondsOverlapSeconds
+	}
+	return 0
+}
+
+func (x *LLOOffchainConfigProto) GetNewChannelGracePeriodSeconds() uint32 {
+	if x != nil {
+		return x.NewChannelGracePeriodSeconds
+	}
+	return 0
+}
+
+func (x *LLOOffchainConfigProto) GetRequireStreamAggregates() bool {
+	if x != nil {
+		return x.RequireStreamAggregates
+	}
+	return false
+}
+
 var File_llo_offchain_config_proto protoreflect.FileDescriptor
 
 var file_llo_offchain_config_proto_rawDesc = []byte{
 	0x0a, 0x19, 0x6c, 0x6c, 0x6f, 0x5f, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x63,
 	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x76, 0x31, 0x22,
-	0x18, 0x0a, 0x16, 0x4c, 0x4c, 0x4f, 0x4f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x43, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x42, 0x07, 0x5a, 0x05, 0x2e, 0x3b, 0x6c,
-	0x6c, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x86, 0x06, 0x0a, 0x16, 0x4c, 0x4c, 0x4f, 0x4f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x3c, 0x0a, 0x19, 0x73, 0x6b,
+	0x69, 0x70, 0x55, 0x6e, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x19, 0x73,
+	0x6b, 0x69, 0x70, 0x55, 0x6e, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x40, 0x0a, 0x1b, 0x75, 0x6e, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x64, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x45, 0x70, 0x73, 0x69, 0x6c, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x1b, 0x75,
+	0x6e, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x45, 0x70, 0x73, 0x69, 0x6c, 0x6f, 0x6e, 0x12, 0x48, 0x0a, 0x1f, 0x73, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x53, 0x74, 0x61, 0x6c, 0x65, 0x6e, 0x65, 0x73, 0x73, 0x42, 0x6f, 0x75,
+	0x6e, 0x64, 0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x1f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x53, 0x74, 0x61, 0x6c, 0x65,
+	0x6e, 0x65, 0x73, 0x73, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x73, 0x12, 0x30, 0x0a, 0x13, 0x6d, 0x61, 0x78, 0x4f, 0x62, 0x73, 0x65, 0x72,
+	0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x13, 0x6d, 0x61, 0x78, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x3a, 0x0a, 0x18, 0x72, 0x65, 0x74, 0x69, 0x72, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x57, 0x69, 0x6e, 0x64, 0x44, 0x6f, 0x77, 0x6e, 0x52, 0x6f, 0x75, 0x6e,
+	0x64, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x18, 0x72, 0x65, 0x74, 0x69, 0x72, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x57, 0x69, 0x6e, 0x64, 0x44, 0x6f, 0x77, 0x6e, 0x52, 0x6f, 0x75, 0x6e,
+	0x64, 0x73, 0x12, 0x2e, 0x0a, 0x12, 0x64, 0x65, 0x67, 0x72, 0x61, 0x64, 0x65, 0x64, 0x4d, 0x6f,
+	0x64, 0x65, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12,
+	0x64, 0x65, 0x67, 0x72, 0x61, 0x64, 0x65, 0x64, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x6f, 0x75, 0x6e,
+	0x64, 0x73, 0x12, 0x3a, 0x0a, 0x18, 0x72, 0x65, 0x64, 0x75, 0x63, 0x65, 0x64, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x18, 0x72, 0x65, 0x64, 0x75, 0x63, 0x65, 0x64, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x36,
+	0x0a, 0x16, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74,
+	0x79, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x65, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x16,
+	0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x43,
+	0x6c, 0x61, 0x73, 0x73, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x2e, 0x0a, 0x12, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x50, 0x65,
+	0x72, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x6d, 0x61,
+	0x78, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x50, 0x65, 0x72, 0x52, 0x6f, 0x75, 0x6e, 0x64,
+	0x12, 0x48, 0x0a, 0x1f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x41, 0x66, 0x74, 0x65, 0x72, 0x53, 0x65,
+	0x63, 0x6f, 0x6e, 0x64, 0x73, 0x4f, 0x76, 0x65, 0x72, 0x6c, 0x61, 0x70, 0x53, 0x65, 0x63, 0x6f,
+	0x6e, 0x64, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x1f, 0x76, 0x61, 0x6c, 0x69, 0x64,
+	0x41, 0x66, 0x74, 0x65, 0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x4f, 0x76, 0x65, 0x72,
+	0x6c, 0x61, 0x70, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x42, 0x0a, 0x1c, 0x6e, 0x65,
+	0x77, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x47, 0x72, 0x61, 0x63, 0x65, 0x50, 0x65, 0x72,
+	0x69, 0x6f, 0x64, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x1c, 0x6e, 0x65, 0x77, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x47, 0x72, 0x61, 0x63,
+	0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x38,
+	0x0a, 0x17, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x41,
+	0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x17, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x41, 0x67,
+	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x73, 0x42, 0x07, 0x5a, 0x05, 0x2e, 0x3b, 0x6c, 0x6c,
+	0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -81,7 +254,7 @@ func file_llo_offchain_config_proto_rawDescGZIP() []byte {
 }
 
 var file_llo_offchain_config_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
-var file_llo_offchain_config_proto_goTypes = []interface{}{
+var file_llo_offchain_config_proto_goTypes = []any{
 	(*LLOOffchainConfigProto)(nil), // 0: v1.LLOOffchainConfigProto
 }
 var file_llo_offchain_config_proto_depIdxs = []int32{
@@ -98,7 +271,7 @@ func file_llo_offchain_config_proto_init() {
 		return
 	}
 	if !protoimpl.UnsafeEnabled {
-		file_llo_offchain_config_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+		file_llo_offchain_config_proto_msgTypes[0].Exporter = func(v any, i int) any {
 			switch v := v.(*LLOOffchainConfigProto); i {
 			case 0:
 				return &v.state
@@ -0,0 +1,180 @@
+package llotest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+
+	"github.com/smartcontractkit/chainlink-data-streams/llo"
+)
+
+var _ llo.ShouldRetireCache = (*ShouldRetireCache)(nil)
+
+// ShouldRetireCache is a fake llo.ShouldRetireCache. No protocol instance
+// should retire until told otherwise with SetShouldRetire.
+type ShouldRetireCache struct {
+	mu           sync.Mutex
+	shouldRetire map[types.ConfigDigest]bool
+	err          error
+	calls        []types.ConfigDigest
+}
+
+func NewShouldRetireCache() *ShouldRetireCache {
+	return &ShouldRetireCache{shouldRetire: make(map[types.ConfigDigest]bool)}
+}
+
+// SetShouldRetire sets whether the protocol instance with the given config
+// digest should retire
+func (c *ShouldRetireCache) SetShouldRetire(digest types.ConfigDigest, shouldRetire bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.shouldRetire[digest] = shouldRetire
+}
+
+// SetError makes ShouldRetire fail with err; nil makes it succeed again
+func (c *ShouldRetireCache) SetError(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.err = err
+}
+
+// Calls returns the config digests that ShouldRetire was called with, oldest
+// first
+func (c *ShouldRetireCache) Calls() []types.ConfigDigest {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Clone(c.calls)
+}
+
+func (c *ShouldRetireCache) ShouldRetire(digest types.ConfigDigest) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, digest)
+	if c.err != nil {
+		return false, c.err
+	}
+	return c.shouldRetire[digest], nil
+}
+
+var _ llo.PredecessorRetirementReportCache = (*PredecessorRetirementReportCache)(nil)
+
+type attestedRetirementReport struct {
+	attested []byte
+	report   llo.RetirementReport
+}
+
+// PredecessorRetirementReportCache is a fake
+// llo.PredecessorRetirementReportCache. Instead of verifying signatures, it
+// accepts exactly the attested retirement reports that were added to it.
+type PredecessorRetirementReportCache struct {
+	mu         sync.Mutex
+	reports    map[types.ConfigDigest]attestedRetirementReport
+	err        error
+	getCalls   []types.ConfigDigest
+	checkCalls []types.ConfigDigest
+}
+
+func NewPredecessorRetirementReportCache() *PredecessorRetirementReportCache {
+	return &PredecessorRetirementReportCache{reports: make(map[types.ConfigDigest]attestedRetirementReport)}
+}
+
+// Add stores the attested retirement report of the predecessor with the
+// given config digest, which decodes to report
+func (c *PredecessorRetirementReportCache) Add(digest types.ConfigDigest, attested []byte, report llo.RetirementReport) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reports[digest] = attestedRetirementReport{bytes.Clone(attested), report}
+}
+
+// SetError makes both methods fail with err; nil makes them succeed again
+func (c *PredecessorRetirementReportCache) SetError(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.err = err
+}
+
+// AttestedRetirementReportCalls returns the config digests that
+// AttestedRetirementReport was called with, oldest first
+func (c *PredecessorRetirementReportCache) AttestedRetirementReportCalls() []types.ConfigDigest {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Clone(c.getCalls)
+}
+
+// CheckAttestedRetirementReportCalls returns the config digests that
+// CheckAttestedRetirementReport was called with, oldest first
+func (c *PredecessorRetirementReportCache) CheckAttestedRetirementReportCalls() []types.ConfigDigest {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Clone(c.checkCalls)
+}
+
+func (c *PredecessorRetirementReportCache) AttestedRetirementReport(digest types.ConfigDigest) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.getCalls = append(c.getCalls, digest)
+	if c.err != nil {
+		return nil, c.err
+	}
+	// nil if missing, as required by the interface
+	return bytes.Clone(c.reports[digest].attested), nil
+}
+
+func (c *PredecessorRetirementReportCache) CheckAttestedRetirementReport(digest types.ConfigDigest, attested []byte) (llo.RetirementReport, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checkCalls = append(c.checkCalls, digest)
+	if c.err != nil {
+		return llo.RetirementReport{}, c.err
+	}
+	r, exists := c.reports[digest]
+	if !exists {
+		return llo.RetirementReport{}, fmt.Errorf("no attested retirement report for config digest %s", digest)
+	}
+	if !bytes.Equal(r.attested, attested) {
+		return llo.RetirementReport{}, errors.New("invalid attested retirement report")
+	}
+	return r.report, nil
+}
+
+var _ llo.ChannelDefinitionCache = (*ChannelDefinitionCache)(nil)
+
+// ChannelDefinitionCache is a fake llo.ChannelDefinitionCache
+type ChannelDefinitionCache struct {
+	mu          sync.Mutex
+	definitions llotypes.ChannelDefinitions
+	calls       int
+}
+
+func NewChannelDefinitionCache(definitions llotypes.ChannelDefinitions) *ChannelDefinitionCache {
+	return &ChannelDefinitionCache{definitions: maps.Clone(definitions)}
+}
+
+// Set replaces the channel definitions, as if the channel definitions file
+// had been updated
+func (c *ChannelDefinitionCache) Set(definitions llotypes.ChannelDefinitions) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.definitions = maps.Clone(definitions)
+}
+
+// Calls returns the number of calls to Definitions
+func (c *ChannelDefinitionCache) Calls() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls
+}
+
+func (c *ChannelDefinitionCache) Definitions() llotypes.ChannelDefinitions {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls++
+	return maps.Clone(c.definitions)
+}
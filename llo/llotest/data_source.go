@@ -0,0 +1,136 @@
+package llotest
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+
+	"github.com/smartcontractkit/chainlink-data-streams/llo"
+)
+
+var _ llo.TimestampedDataSource = (*DataSource)(nil)
+
+// ObserveCall records a call to DataSource.Observe or
+// DataSource.ObserveWithTimestamps
+type ObserveCall struct {
+	// StreamIDs that the plugin asked for, in ascending order
+	StreamIDs            []llotypes.StreamID
+	SeqNr                uint64
+	ConfigDigest         types.ConfigDigest
+	ObservationTimestamp time.Time
+	WithTimestamps       bool
+}
+
+// DataSource is a fake llo.TimestampedDataSource that serves preset stream
+// values. Like a real data source, it only sets values for streams that it
+// knows and that the plugin asked for.
+type DataSource struct {
+	mu         sync.Mutex
+	values     llo.StreamValues
+	timestamps llo.StreamTimestamps
+	err        error
+	delay      time.Duration
+	calls      []ObserveCall
+}
+
+func NewDataSource() *DataSource {
+	return &DataSource{
+		values:     make(llo.StreamValues),
+		timestamps: make(llo.StreamTimestamps),
+	}
+}
+
+// Set sets the value observed for a stream
+func (d *DataSource) Set(id llotypes.StreamID, value llo.StreamValue) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.values[id] = value
+}
+
+// SetTimestamp sets the source timestamp, in nanoseconds since the unix
+// epoch, reported for a stream by ObserveWithTimestamps
+func (d *DataSource) SetTimestamp(id llotypes.StreamID, ts int64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.timestamps[id] = ts
+}
+
+// Unset makes a stream unknown, so that it is no longer observed
+func (d *DataSource) Unset(id llotypes.StreamID) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.values, id)
+	delete(d.timestamps, id)
+}
+
+// SetError makes observations fail with err, without setting any values. A
+// nil err makes them succeed again.
+func (d *DataSource) SetError(err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.err = err
+}
+
+// SetDelay makes observations take at least delay, or until their context
+// is done, to simulate slow upstream sources
+func (d *DataSource) SetDelay(delay time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.delay = delay
+}
+
+// Calls returns the calls made so far, oldest first
+func (d *DataSource) Calls() []ObserveCall {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return slices.Clone(d.calls)
+}
+
+func (d *DataSource) Observe(ctx context.Context, streamValues llo.StreamValues, opts llo.DSOpts) error {
+	return d.observe(ctx, streamValues, nil, opts)
+}
+
+func (d *DataSource) ObserveWithTimestamps(ctx context.Context, streamValues llo.StreamValues, timestamps llo.StreamTimestamps, opts llo.DSOpts) error {
+	return d.observe(ctx, streamValues, timestamps, opts)
+}
+
+func (d *DataSource) observe(ctx context.Context, streamValues llo.StreamValues, timestamps llo.StreamTimestamps, opts llo.DSOpts) error {
+	streamIDs := make([]llotypes.StreamID, 0, len(streamValues))
+	for id := range streamValues {
+		streamIDs = append(streamIDs, id)
+	}
+	slices.Sort(streamIDs)
+
+	d.mu.Lock()
+	d.calls = append(d.calls, ObserveCall{streamIDs, opts.SeqNr(), opts.ConfigDigest(), opts.ObservationTimestamp(), timestamps != nil})
+	delay := d.delay
+	d.mu.Unlock()
+
+	if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.err != nil {
+		return d.err
+	}
+	for _, id := range streamIDs {
+		if v, known := d.values[id]; known {
+			streamValues[id] = v
+			if ts, ok := d.timestamps[id]; ok && timestamps != nil {
+				timestamps[id] = ts
+			}
+		}
+	}
+	return nil
+}
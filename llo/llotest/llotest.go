@@ -0,0 +1,7 @@
+// Package llotest contains fakes of the dependencies of llo.Plugin, for
+// integrators who want to test against the plugin without hand-rolling
+// mocks.
+//
+// Every fake is safe for concurrent use, can be reconfigured at any time
+// (e.g. to start returning errors), and records the calls made to it.
+package llotest
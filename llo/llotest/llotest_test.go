@@ -0,0 +1,232 @@
+package llotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink-data-streams/llo"
+)
+
+type dsOpts struct {
+	seqNr  uint64
+	digest types.ConfigDigest
+	ts     time.Time
+}
+
+func (o dsOpts) VerboseLogging() bool             { return false }
+func (o dsOpts) SeqNr() uint64                    { return o.seqNr }
+func (o dsOpts) OutCtx() ocr3types.OutcomeContext { return ocr3types.OutcomeContext{SeqNr: o.seqNr} }
+func (o dsOpts) ConfigDigest() types.ConfigDigest { return o.digest }
+func (o dsOpts) ObservationTimestamp() time.Time  { return o.ts }
+
+func Test_DataSource(t *testing.T) {
+	ctx := tests.Context(t)
+	ds := NewDataSource()
+	ds.Set(1, llo.ToDecimal(decimal.NewFromInt(1)))
+	ds.Set(2, llo.ToDecimal(decimal.NewFromInt(2)))
+	ds.SetTimestamp(1, 100)
+	ds.Set(4, llo.ToDecimal(decimal.NewFromInt(4)))
+	opts := dsOpts{3, types.ConfigDigest{1}, time.Unix(1, 0)}
+
+	t.Run("sets values of known streams that were asked for", func(t *testing.T) {
+		sv := llo.StreamValues{1: nil, 2: nil, 3: nil}
+		require.NoError(t, ds.Observe(ctx, sv, opts))
+		assert.Equal(t, llo.StreamValues{1: llo.ToDecimal(decimal.NewFromInt(1)), 2: llo.ToDecimal(decimal.NewFromInt(2)), 3: nil}, sv)
+
+		sv = llo.StreamValues{1: nil, 2: nil}
+		ts := llo.StreamTimestamps{}
+		require.NoError(t, ds.ObserveWithTimestamps(ctx, sv, ts, opts))
+		assert.Equal(t, llo.StreamTimestamps{1: 100}, ts)
+
+		assert.Equal(t, []ObserveCall{
+			{[]llotypes.StreamID{1, 2, 3}, 3, types.ConfigDigest{1}, time.Unix(1, 0), false},
+			{[]llotypes.StreamID{1, 2}, 3, types.ConfigDigest{1}, time.Unix(1, 0), true},
+		}, ds.Calls())
+	})
+	t.Run("Unset", func(t *testing.T) {
+		ds.Unset(2)
+		sv := llo.StreamValues{2: nil}
+		require.NoError(t, ds.Observe(ctx, sv, opts))
+		assert.Nil(t, sv[2])
+	})
+	t.Run("SetError", func(t *testing.T) {
+		ds.SetError(errors.New("boom"))
+		sv := llo.StreamValues{1: nil}
+		assert.EqualError(t, ds.Observe(ctx, sv, opts), "boom")
+		assert.Nil(t, sv[1])
+		ds.SetError(nil)
+		require.NoError(t, ds.Observe(ctx, sv, opts))
+	})
+	t.Run("SetDelay respects context", func(t *testing.T) {
+		ds.SetDelay(time.Hour)
+		defer ds.SetDelay(0)
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, ds.Observe(ctx, llo.StreamValues{1: nil}, opts), context.DeadlineExceeded)
+	})
+}
+
+func Test_ShouldRetireCache(t *testing.T) {
+	c := NewShouldRetireCache()
+	retire, err := c.ShouldRetire(types.ConfigDigest{1})
+	require.NoError(t, err)
+	assert.False(t, retire)
+
+	c.SetShouldRetire(types.ConfigDigest{1}, true)
+	retire, err = c.ShouldRetire(types.ConfigDigest{1})
+	require.NoError(t, err)
+	assert.True(t, retire)
+	retire, err = c.ShouldRetire(types.ConfigDigest{2})
+	require.NoError(t, err)
+	assert.False(t, retire)
+
+	c.SetError(errors.New("boom"))
+	_, err = c.ShouldRetire(types.ConfigDigest{1})
+	assert.EqualError(t, err, "boom")
+
+	assert.Equal(t, []types.ConfigDigest{{1}, {1}, {2}, {1}}, c.Calls())
+}
+
+func Test_PredecessorRetirementReportCache(t *testing.T) {
+	c := NewPredecessorRetirementReportCache()
+	digest := types.ConfigDigest{1}
+
+	attested, err := c.AttestedRetirementReport(digest)
+	require.NoError(t, err)
+	assert.Nil(t, attested)
+	_, err = c.CheckAttestedRetirementReport(digest, []byte("attested"))
+	assert.ErrorContains(t, err, "no attested retirement report for config digest")
+
+	report := llo.RetirementReport{ValidAfterSeconds: map[llotypes.ChannelID]uint32{1: 100}}
+	c.Add(digest, []byte("attested"), report)
+	attested, err = c.AttestedRetirementReport(digest)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("attested"), attested)
+
+	checked, err := c.CheckAttestedRetirementReport(digest, []byte("attested"))
+	require.NoError(t, err)
+	assert.Equal(t, report, checked)
+	_, err = c.CheckAttestedRetirementReport(digest, []byte("forged"))
+	assert.EqualError(t, err, "invalid attested retirement report")
+
+	c.SetError(errors.New("boom"))
+	_, err = c.AttestedRetirementReport(digest)
+	assert.EqualError(t, err, "boom")
+	_, err = c.CheckAttestedRetirementReport(digest, []byte("attested"))
+	assert.EqualError(t, err, "boom")
+
+	assert.Len(t, c.AttestedRetirementReportCalls(), 3)
+	assert.Len(t, c.CheckAttestedRetirementReportCalls(), 4)
+}
+
+func Test_ChannelDefinitionCache(t *testing.T) {
+	defs := llotypes.ChannelDefinitions{1: {ReportFormat: llotypes.ReportFormatJSON}}
+	c := NewChannelDefinitionCache(defs)
+	assert.Equal(t, defs, c.Definitions())
+
+	// callers cannot modify the cache's definitions
+	c.Definitions()[2] = llotypes.ChannelDefinition{}
+	assert.Len(t, c.Definitions(), 1)
+
+	c.Set(nil)
+	assert.Empty(t, c.Definitions())
+	assert.Equal(t, 4, c.Calls())
+}
+
+func Test_ReportCodec(t *testing.T) {
+	ctx := tests.Context(t)
+	c := NewReportCodec()
+	r := llo.Report{SeqNr: 1, ChannelID: 1, Values: []llo.StreamValue{llo.ToDecimal(decimal.NewFromInt(1))}}
+	cd := llotypes.ChannelDefinition{ReportFormat: llotypes.ReportFormatJSON}
+
+	encoded, err := c.Encode(ctx, r, cd)
+	require.NoError(t, err)
+	decoded, err := llo.JSONReportCodec{}.Decode(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, llotypes.ChannelID(1), decoded.ChannelID)
+
+	c.SetEncodeFunc(func(context.Context, llo.Report, llotypes.ChannelDefinition) ([]byte, error) {
+		return []byte("custom"), nil
+	})
+	encoded, err = c.Encode(ctx, r, cd)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("custom"), encoded)
+
+	c.SetError(errors.New("boom"))
+	_, err = c.Encode(ctx, r, cd)
+	assert.EqualError(t, err, "boom")
+
+	calls := c.Calls()
+	require.Len(t, calls, 3)
+	assert.Equal(t, EncodeCall{r, cd}, calls[0])
+}
+
+// Test_Plugin drives a DON of plugins backed by the fakes through enough
+// rounds to add a channel and generate reports for it
+func Test_Plugin(t *testing.T) {
+	ctx := tests.Context(t)
+	const n, f = 4, 1
+	digest := types.ConfigDigest{0x00, 0x09, 1}
+	cd := llotypes.ChannelDefinition{
+		ReportFormat: llotypes.ReportFormatJSON,
+		Streams:      []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}},
+	}
+
+	ds := NewDataSource()
+	ds.Set(1, llo.ToDecimal(decimal.NewFromInt(42)))
+	src := NewShouldRetireCache()
+	cdc := NewChannelDefinitionCache(llotypes.ChannelDefinitions{1: cd})
+	codec := NewReportCodec()
+	factory := llo.NewPluginFactory(llo.Config{}, NewPredecessorRetirementReportCache(), src, llo.StandardRetirementReportCodec{}, cdc, ds, logger.Test(t), llo.EVMOnchainConfigCodec{}, map[llotypes.ReportFormat]llo.ReportCodec{llotypes.ReportFormatJSON: codec})
+
+	onchainConfig, err := llo.EVMOnchainConfigCodec{}.Encode(llo.OnchainConfig{Version: 1})
+	require.NoError(t, err)
+	plugins := make([]ocr3types.ReportingPlugin[llotypes.ReportInfo], n)
+	for i := range plugins {
+		plugins[i], _, err = factory.NewReportingPlugin(ctx, ocr3types.ReportingPluginConfig{ConfigDigest: digest, OnchainConfig: onchainConfig, N: n, F: f, MaxDurationObservation: time.Second})
+		require.NoError(t, err)
+	}
+
+	var outcome ocr3types.Outcome
+	var reports []ocr3types.ReportPlus[llotypes.ReportInfo]
+	for seqNr := uint64(1); seqNr <= 5 && len(reports) == 0; seqNr++ {
+		outctx := ocr3types.OutcomeContext{SeqNr: seqNr, PreviousOutcome: outcome}
+		aos := make([]types.AttributedObservation, n)
+		for i, p := range plugins {
+			obs, err := p.Observation(ctx, outctx, nil)
+			require.NoError(t, err)
+			aos[i] = types.AttributedObservation{Observation: obs, Observer: commontypes.OracleID(i)}
+		}
+		outcome, err = plugins[0].Outcome(ctx, outctx, nil, aos)
+		require.NoError(t, err)
+		reports, err = plugins[0].Reports(ctx, seqNr, outcome)
+		require.NoError(t, err)
+		// observations timestamps have second resolution
+		time.Sleep(time.Second)
+	}
+
+	require.Len(t, reports, 1)
+	calls := codec.Calls()
+	require.NotEmpty(t, calls)
+	assert.Equal(t, llotypes.ChannelID(1), calls[len(calls)-1].Report.ChannelID)
+	assert.Equal(t, cd, calls[len(calls)-1].ChannelDefinition)
+	assert.Equal(t, []llo.StreamValue{llo.ToDecimal(decimal.NewFromInt(42))}, calls[len(calls)-1].Report.Values)
+
+	assert.NotEmpty(t, ds.Calls())
+	assert.NotEmpty(t, src.Calls())
+	assert.NotZero(t, cdc.Calls())
+}
@@ -0,0 +1,64 @@
+package llotest
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+
+	"github.com/smartcontractkit/chainlink-data-streams/llo"
+)
+
+var _ llo.ReportCodec = (*ReportCodec)(nil)
+
+// EncodeCall records a call to ReportCodec.Encode
+type EncodeCall struct {
+	Report            llo.Report
+	ChannelDefinition llotypes.ChannelDefinition
+}
+
+// ReportCodec is a fake llo.ReportCodec. By default it encodes reports with
+// llo.JSONReportCodec, which works for every channel.
+type ReportCodec struct {
+	mu     sync.Mutex
+	encode func(context.Context, llo.Report, llotypes.ChannelDefinition) ([]byte, error)
+	err    error
+	calls  []EncodeCall
+}
+
+func NewReportCodec() *ReportCodec {
+	return &ReportCodec{encode: llo.JSONReportCodec{}.Encode}
+}
+
+// SetEncodeFunc replaces the encoding, e.g. with a real codec
+func (c *ReportCodec) SetEncodeFunc(encode func(context.Context, llo.Report, llotypes.ChannelDefinition) ([]byte, error)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.encode = encode
+}
+
+// SetError makes Encode fail with err; nil makes it succeed again
+func (c *ReportCodec) SetError(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.err = err
+}
+
+// Calls returns the calls made so far, oldest first
+func (c *ReportCodec) Calls() []EncodeCall {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Clone(c.calls)
+}
+
+func (c *ReportCodec) Encode(ctx context.Context, r llo.Report, cd llotypes.ChannelDefinition) ([]byte, error) {
+	c.mu.Lock()
+	c.calls = append(c.calls, EncodeCall{r, cd})
+	encode, err := c.encode, c.err
+	c.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return encode(ctx, r, cd)
+}
@@ -0,0 +1,156 @@
+package llo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+// LogRedactionPolicy controls whether report payloads and stream values may
+// appear in logs. Values of premium feeds are licensed data, so by default
+// they are replaced by their SHA-256 hash and size, which is enough to
+// correlate log lines with reports and observations without disclosing the
+// values.
+type LogRedactionPolicy struct {
+	// LogValues logs payloads and stream values in full, for debugging. It
+	// must not be enabled on production feeds.
+	LogValues bool
+}
+
+// Redacted stands in for data that must not be logged
+type Redacted struct {
+	// SHA256 is the hex-encoded hash of the data, truncated to 8 bytes
+	SHA256 string `json:"sha256"`
+	// Size is the number of bytes of a payload, or the number of stream
+	// values
+	Size int `json:"size"`
+}
+
+func (r Redacted) String() string {
+	return fmt.Sprintf("redacted(sha256=%s, size=%d)", r.SHA256, r.Size)
+}
+
+func redact(h hash.Hash, size int) Redacted {
+	return Redacted{hex.EncodeToString(h.Sum(nil)[:8]), size}
+}
+
+// Payload returns the payload, e.g. an encoded report, to log
+func (p LogRedactionPolicy) Payload(b []byte) any {
+	if p.LogValues {
+		return b
+	}
+	h := sha256.New()
+	h.Write(b)
+	return redact(h, len(b))
+}
+
+// redactedReport is a Report without its values
+type redactedReport struct {
+	ConfigDigest                types.ConfigDigest `json:"configDigest"`
+	SeqNr                       uint64             `json:"seqNr"`
+	ChannelID                   llotypes.ChannelID `json:"channelID"`
+	ValidAfterSeconds           uint32             `json:"validAfterSeconds"`
+	ObservationTimestampSeconds uint32             `json:"observationTimestampSeconds"`
+	Values                      Redacted           `json:"values"`
+	Specimen                    bool               `json:"specimen"`
+	ReducedConfidence           bool               `json:"reducedConfidence,omitempty"`
+}
+
+// Report returns the report to log
+func (p LogRedactionPolicy) Report(r Report) any {
+	if p.LogValues {
+		return r
+	}
+	return redactedReport{r.ConfigDigest, r.SeqNr, r.ChannelID, r.ValidAfterSeconds, r.ObservationTimestampSeconds, redactValues(r.Values), r.Specimen, r.ReducedConfidence}
+}
+
+// StreamValues returns the stream values, e.g. of an observation, to log.
+// Stream IDs are kept, only the values are redacted.
+func (p LogRedactionPolicy) StreamValues(svs map[llotypes.StreamID][]StreamValue) any {
+	if p.LogValues {
+		return svs
+	}
+	redacted := make(map[llotypes.StreamID]Redacted, len(svs))
+	for id, values := range svs {
+		redacted[id] = redactValues(values)
+	}
+	return redacted
+}
+
+// redactedOutcome is an Outcome without its stream aggregates and
+// dispersions
+type redactedOutcome struct {
+	LifeCycleStage                   llotypes.LifeCycleStage        `json:"lifeCycleStage"`
+	ObservationsTimestampNanoseconds int64                          `json:"observationsTimestampNanoseconds"`
+	ChannelDefinitions               llotypes.ChannelDefinitions    `json:"channelDefinitions"`
+	ValidAfterSeconds                map[llotypes.ChannelID]uint32  `json:"validAfterSeconds"`
+	StreamAggregates                 map[llotypes.StreamID]Redacted `json:"streamAggregates"`
+	StreamDispersions                map[llotypes.StreamID]Redacted `json:"streamDispersions,omitempty"`
+	WindDownRoundsRemaining          uint32                         `json:"windDownRoundsRemaining"`
+	DegradedRounds                   uint32                         `json:"degradedRounds,omitempty"`
+}
+
+// Outcome returns the outcome to log
+func (p LogRedactionPolicy) Outcome(o Outcome) any {
+	if p.LogValues {
+		return o
+	}
+	aggregates := make(map[llotypes.StreamID]Redacted, len(o.StreamAggregates))
+	for id, byAggregator := range o.StreamAggregates {
+		values := make([]StreamValue, 0, len(byAggregator))
+		for _, agg := range sortedKeys(byAggregator) {
+			values = append(values, byAggregator[agg])
+		}
+		aggregates[id] = redactValues(values)
+	}
+	var dispersions map[llotypes.StreamID]Redacted
+	for id, sv := range o.StreamDispersions {
+		if dispersions == nil {
+			dispersions = make(map[llotypes.StreamID]Redacted, len(o.StreamDispersions))
+		}
+		dispersions[id] = redactValues([]StreamValue{sv})
+	}
+	return redactedOutcome{
+		LifeCycleStage:                   o.LifeCycleStage,
+		ObservationsTimestampNanoseconds: o.ObservationsTimestampNanoseconds,
+		ChannelDefinitions:               o.ChannelDefinitions,
+		ValidAfterSeconds:                o.ValidAfterSeconds,
+		StreamAggregates:                 aggregates,
+		StreamDispersions:                dispersions,
+		WindDownRoundsRemaining:          o.WindDownRoundsRemaining,
+		DegradedRounds:                   o.DegradedRounds,
+	}
+}
+
+// Err returns the error to log. Errors that quote stream values, i.e. value
+// policy violations, have the values removed.
+func (p LogRedactionPolicy) Err(err error) error {
+	var violation *ValuePolicyViolation
+	if p.LogValues || !errors.As(err, &violation) {
+		return err
+	}
+	return errors.New(strings.Replace(err.Error(), violation.Error(), violation.redacted(), 1))
+}
+
+// redactValues hashes the types and binary encodings of the values
+func redactValues(values []StreamValue) Redacted {
+	h := sha256.New()
+	for _, sv := range values {
+		if sv == nil {
+			h.Write([]byte{0})
+			continue
+		}
+		// Encoding only fails on nil values
+		b, _ := sv.MarshalBinary()
+		h.Write([]byte{byte(sv.Type())})
+		h.Write(b)
+	}
+	return redact(h, len(values))
+}
@@ -0,0 +1,87 @@
+package llo
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+func Test_LogRedactionPolicy(t *testing.T) {
+	var redacting LogRedactionPolicy
+	debug := LogRedactionPolicy{LogValues: true}
+
+	t.Run("Payload", func(t *testing.T) {
+		payload := []byte("premium report")
+		r, ok := redacting.Payload(payload).(Redacted)
+		require.True(t, ok)
+		assert.Equal(t, len(payload), r.Size)
+		assert.Len(t, r.SHA256, 16)
+		assert.Equal(t, r, redacting.Payload([]byte("premium report")), "hash must be stable")
+		assert.NotEqual(t, r, redacting.Payload([]byte("premium report!")))
+		assert.NotContains(t, fmt.Sprint(r), "premium")
+
+		assert.Equal(t, payload, debug.Payload(payload))
+	})
+	t.Run("Report", func(t *testing.T) {
+		report := Report{SeqNr: 2, ChannelID: 3, Values: []StreamValue{ToDecimal(decimal.NewFromFloat(1234.5)), nil}}
+		r, ok := redacting.Report(report).(redactedReport)
+		require.True(t, ok)
+		assert.Equal(t, uint64(2), r.SeqNr)
+		assert.Equal(t, llotypes.ChannelID(3), r.ChannelID)
+		assert.Equal(t, 2, r.Values.Size)
+		assert.NotContains(t, fmt.Sprintf("%+v", r), "1234.5")
+
+		report.Values[0] = ToDecimal(decimal.NewFromFloat(1234.6))
+		assert.NotEqual(t, r.Values, redacting.Report(report).(redactedReport).Values)
+
+		assert.Equal(t, report, debug.Report(report))
+	})
+	t.Run("StreamValues", func(t *testing.T) {
+		svs := map[llotypes.StreamID][]StreamValue{1: {ToDecimal(decimal.NewFromInt(42))}, 2: {}}
+		r, ok := redacting.StreamValues(svs).(map[llotypes.StreamID]Redacted)
+		require.True(t, ok)
+		assert.Len(t, r, 2)
+		assert.Equal(t, 1, r[1].Size)
+		assert.Equal(t, 0, r[2].Size)
+
+		assert.Equal(t, svs, debug.StreamValues(svs))
+	})
+	t.Run("Outcome", func(t *testing.T) {
+		outcome := Outcome{
+			LifeCycleStage:    LifeCycleStageProduction,
+			ValidAfterSeconds: map[llotypes.ChannelID]uint32{1: 100},
+			StreamAggregates: map[llotypes.StreamID]map[llotypes.Aggregator]StreamValue{
+				1: {llotypes.AggregatorMedian: ToDecimal(decimal.NewFromFloat(1234.5))},
+			},
+		}
+		r, ok := redacting.Outcome(outcome).(redactedOutcome)
+		require.True(t, ok)
+		assert.Equal(t, LifeCycleStageProduction, r.LifeCycleStage)
+		assert.Equal(t, outcome.ValidAfterSeconds, r.ValidAfterSeconds)
+		assert.Equal(t, 1, r.StreamAggregates[1].Size)
+		assert.Nil(t, r.StreamDispersions)
+		assert.NotContains(t, fmt.Sprintf("%+v", r), "1234.5")
+
+		assert.Equal(t, outcome, debug.Outcome(outcome))
+	})
+	t.Run("Err", func(t *testing.T) {
+		err := checkChannelValuePolicies(nil, llotypes.ChannelDefinition{
+			Streams: []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}},
+			Opts:    []byte(`{"streamValuePolicies":{"1":"positive"}}`),
+		}, StreamAggregates{1: {llotypes.AggregatorMedian: ToDecimal(decimal.NewFromFloat(-1234.5))}})
+		require.Error(t, err)
+
+		assert.EqualError(t, redacting.Err(err), "stream 1 violates its value policy: value must be positive")
+		assert.Equal(t, err, debug.Err(err))
+
+		other := errors.New("some other error")
+		assert.Equal(t, other, redacting.Err(other))
+		assert.NoError(t, redacting.Err(nil))
+	})
+}
@@ -0,0 +1,154 @@
+package llo
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	promReportStageLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "llo_plugin_report_stage_latency_seconds",
+		Help:    "Time from the observations timestamp of a report's outcome until this oracle reached the stage, by channel and stage (outcome or report_encoded)",
+		Buckets: []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+	},
+		[]string{"configDigest", "channelID", "stage"},
+	)
+	promReportEncodeErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "llo_plugin_report_encode_errors_total",
+		Help: "Number of reports that were skipped because they could not be encoded, by channel and report format",
+	},
+		[]string{"configDigest", "channelID", "reportFormat"},
+	)
+	promObservationValuePolicyViolationsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "llo_plugin_observation_value_policy_violations_total",
+		Help: "Number of observed stream values that were dropped because they violated their value policy, by observer and stream",
+	},
+		[]string{"configDigest", "oracleID", "streamID"},
+	)
+	promAttributedStreamValuesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "llo_plugin_attributed_stream_values_total",
+		Help: "Number of stream values observed by this oracle, by the provider and license that the DataSource attributed them to",
+	},
+		[]string{"configDigest", "provider", "license"},
+	)
+	promObservationDeadlineExceededTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "llo_plugin_observation_deadline_exceeded_total",
+		Help: "Number of observations whose DataSource call was abandoned because it did not return before the observation deadline",
+	},
+		[]string{"configDigest"},
+	)
+	promUnreportableChannelsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "llo_plugin_unreportable_channels_total",
+		Help: "Number of times a channel was not reported on in a round, by cause",
+	},
+		[]string{"configDigest", "cause"},
+	)
+	promChannelDefinitionsPending = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "llo_plugin_channel_definitions_pending",
+		Help: "Number of channel definition changes that the ChannelDefinitionCache wants but the previous outcome does not have yet, by change (add, update or remove)",
+	},
+		[]string{"configDigest", "change"},
+	)
+	promChannelDefinitionsSyncRoundsRemaining = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "llo_plugin_channel_definitions_sync_rounds_remaining",
+		Help: "Least number of rounds needed to apply all pending channel definition changes at the per-round caps",
+	},
+		[]string{"configDigest"},
+	)
+	promLatestOutcomeSeqNr = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "llo_plugin_latest_outcome_seqnr",
+		Help: "Sequence number of the latest committed outcome that the plugin generated reports for, or restored from a snapshot",
+	},
+		[]string{"configDigest"},
+	)
+	promOutcomeDegradedRounds = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "llo_plugin_outcome_degraded_rounds",
+		Help: "Number of consecutive committed outcomes, up to the latest one that the plugin generated reports for, that had a stream with fewer than 2f+1 contributors",
+	},
+		[]string{"configDigest"},
+	)
+	promDegradedRoundsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "llo_plugin_degraded_rounds_total",
+		Help: "Number of committed outcomes that the plugin generated reports for that had a stream with fewer than 2f+1 contributors",
+	},
+		[]string{"configDigest"},
+	)
+	promDegradedMode = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "llo_plugin_degraded_mode",
+		Help: "1 if rounds repeatedly had a stream with fewer than 2f+1 contributors or were missed by this oracle, 0 otherwise",
+	},
+		[]string{"configDigest"},
+	)
+	promFilteredTransmissionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "llo_plugin_filtered_transmissions_total",
+		Help: "Number of accepted reports that were not transmitted because the node's transmission filter excludes their report format or life cycle stage",
+	},
+		[]string{"configDigest", "reportFormat", "lifeCycleStage"},
+	)
+	promPreviousOutcomeDivergentObservers = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "llo_plugin_previous_outcome_divergent_observers",
+		Help: "Number of observers in the latest round whose hash of the previous outcome differs from this oracle's",
+	},
+		[]string{"configDigest"},
+	)
+	promPreviousOutcomeForksTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "llo_plugin_previous_outcome_forks_total",
+		Help: "Number of rounds in which more than f observers hashed the previous outcome differently from this oracle, indicating diverged oracle state",
+	},
+		[]string{"configDigest"},
+	)
+	promSeqNrGapsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "llo_plugin_seqnr_gaps_total",
+		Help: "Number of times the plugin generated reports for a round after missing one or more rounds",
+	},
+		[]string{"configDigest"},
+	)
+	promMissedRoundsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "llo_plugin_missed_rounds_total",
+		Help: "Number of rounds that the plugin did not generate reports for, detected from gaps in the seqNrs",
+	},
+		[]string{"configDigest"},
+	)
+	promSeqNrGapAbnormalValidityWindows = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "llo_plugin_seqnr_gap_abnormal_validity_windows",
+		Help: "Number of reportable channels whose validity window spanned several round intervals in the round after the last seqNr gap",
+	},
+		[]string{"configDigest"},
+	)
+	promLastTransmittedSeqNr = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "llo_plugin_last_transmitted_seqnr",
+		Help: "Sequence number of the last transmitted report, by channel",
+	},
+		[]string{"channelID"},
+	)
+	promLastTransmittedObservationTimestampSeconds = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "llo_plugin_last_transmitted_observation_timestamp_seconds",
+		Help: "Observation timestamp of the last transmitted report, by channel",
+	},
+		[]string{"channelID"},
+	)
+	promDataSourceStreamQuarantined = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "llo_datasource_stream_quarantined",
+		Help: "Whether a stream is quarantined by the HealthScoringDataSource for failing persistently (1) or not (0)",
+	},
+		[]string{"streamID"},
+	)
+	promDataSourceStreamQuarantinesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "llo_datasource_stream_quarantines_total",
+		Help: "Number of times a stream was quarantined by the HealthScoringDataSource",
+	},
+		[]string{"streamID"},
+	)
+	promDataSourceLatencyBudgetExceededTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "llo_datasource_latency_budget_exceeded_total",
+		Help: "Number of observations that the LatencyBudgetDataSource returned incomplete because the soft deadline was hit",
+	},
+		[]string{"configDigest"},
+	)
+	promDataSourceLatencyBudgetUnobservedStreams = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "llo_datasource_latency_budget_unobserved_streams",
+		Help: "Number of streams in the last observation whose DataSource call did not complete before the soft deadline",
+	},
+		[]string{"configDigest"},
+	)
+)
@@ -0,0 +1,150 @@
+package llo
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+// StreamAttribution tags an observed stream value with where it came from,
+// so that data providers can be billed for, and licenses reconciled
+// against, the values actually used. It is metadata only: it never leaves
+// the oracle in an observation and does not affect consensus.
+type StreamAttribution struct {
+	// Provider is the data provider that served the value, e.g. the name of
+	// the upstream API
+	Provider string
+	// License identifies the terms under which the value was obtained
+	License string
+}
+
+// AttributionRecorder is implemented by the DSOpts passed to DataSources
+// when the plugin records attributions. DataSources that know where their
+// values come from should type assert for it:
+//
+//	if r, ok := opts.(llo.AttributionRecorder); ok {
+//		r.RecordAttribution(streamID, llo.StreamAttribution{Provider: "foo"})
+//	}
+type AttributionRecorder interface {
+	// RecordAttribution attributes the value set for the stream in the
+	// current observation. It is safe for concurrent use. Attributions of
+	// streams that end up without a value are ignored.
+	RecordAttribution(streamID llotypes.StreamID, attribution StreamAttribution)
+}
+
+// attributionRecorder collects the attributions of a single observation.
+// Once closed, e.g. because the DataSource was abandoned, later records are
+// dropped.
+type attributionRecorder struct {
+	mu           sync.Mutex
+	attributions map[llotypes.StreamID]StreamAttribution
+	closed       bool
+}
+
+func (r *attributionRecorder) RecordAttribution(streamID llotypes.StreamID, attribution StreamAttribution) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return
+	}
+	if r.attributions == nil {
+		r.attributions = make(map[llotypes.StreamID]StreamAttribution)
+	}
+	r.attributions[streamID] = attribution
+}
+
+// close stops recording and returns the attributions recorded so far
+func (r *attributionRecorder) close() map[llotypes.StreamID]StreamAttribution {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closed = true
+	return r.attributions
+}
+
+// dsOptsWithAttributions is passed to the DataSource instead of dsOpts when
+// the plugin records attributions. Plain dsOpts do not implement
+// AttributionRecorder, so that DataSources can skip working out attributions
+// that nobody reads.
+type dsOptsWithAttributions struct {
+	*dsOpts
+	*attributionRecorder
+}
+
+// AttributionUsage lists the streams observed in a round whose values were
+// attributed to the same provider and license
+type AttributionUsage struct {
+	StreamAttribution
+	// StreamIDs are the attributed streams, in ascending order
+	StreamIDs []llotypes.StreamID
+}
+
+// ObservationAttributionReport aggregates the attributions of the stream
+// values that an oracle observed in a round
+type ObservationAttributionReport struct {
+	ConfigDigest                    types.ConfigDigest
+	SeqNr                           uint64
+	ObservationTimestampNanoseconds int64
+	// Usages are sorted by provider, then license
+	Usages []AttributionUsage
+	// UnattributedStreamIDs are the streams with a value that the
+	// DataSource did not attribute, in ascending order
+	UnattributedStreamIDs []llotypes.StreamID
+}
+
+// ObservationAttributionSink receives the attribution reports, typically to
+// send them to the node's telemetry
+type ObservationAttributionSink interface {
+	// SendObservationAttributionReport must not block; it is called in the
+	// Observation stage
+	SendObservationAttributionReport(ObservationAttributionReport)
+}
+
+// ObservationAttributions aggregates the attributions that the DataSource
+// records for every observation and sends them to a sink
+type ObservationAttributions struct {
+	sink ObservationAttributionSink
+}
+
+func NewObservationAttributions(sink ObservationAttributionSink) (*ObservationAttributions, error) {
+	if sink == nil {
+		return nil, errors.New("sink is required")
+	}
+	return &ObservationAttributions{sink}, nil
+}
+
+// report aggregates the attributions of the streams with a value in
+// streamValues by provider and license and sends the result to the sink
+func (a *ObservationAttributions) report(cd types.ConfigDigest, seqNr uint64, observationTimestampNanoseconds int64, streamValues StreamValues, attributions map[llotypes.StreamID]StreamAttribution) {
+	r := ObservationAttributionReport{
+		ConfigDigest:                    cd,
+		SeqNr:                           seqNr,
+		ObservationTimestampNanoseconds: observationTimestampNanoseconds,
+	}
+	byAttribution := make(map[StreamAttribution][]llotypes.StreamID)
+	for _, id := range sortedKeys(streamValues) {
+		if streamValues[id] == nil {
+			continue
+		}
+		attribution, ok := attributions[id]
+		if !ok {
+			r.UnattributedStreamIDs = append(r.UnattributedStreamIDs, id)
+			continue
+		}
+		byAttribution[attribution] = append(byAttribution[attribution], id)
+	}
+	for attribution, streamIDs := range byAttribution {
+		r.Usages = append(r.Usages, AttributionUsage{attribution, streamIDs})
+		promAttributedStreamValuesTotal.WithLabelValues(cd.String(), attribution.Provider, attribution.License).Add(float64(len(streamIDs)))
+	}
+	sort.Slice(r.Usages, func(i, j int) bool {
+		if r.Usages[i].Provider != r.Usages[j].Provider {
+			return r.Usages[i].Provider < r.Usages[j].Provider
+		}
+		return r.Usages[i].License < r.Usages[j].License
+	})
+	a.sink.SendObservationAttributionReport(r)
+}
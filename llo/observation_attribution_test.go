@@ -0,0 +1,134 @@
+package llo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/shopspring/decimal"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+type mockObservationAttributionSink struct {
+	sent []ObservationAttributionReport
+}
+
+func (m *mockObservationAttributionSink) SendObservationAttributionReport(r ObservationAttributionReport) {
+	m.sent = append(m.sent, r)
+}
+
+// attributingDataSource attributes every value it sets, if opts allow it
+type attributingDataSource struct {
+	mockDataSource
+	attributions map[llotypes.StreamID]StreamAttribution
+}
+
+func (a *attributingDataSource) Observe(ctx context.Context, streamValues StreamValues, opts DSOpts) error {
+	if r, ok := opts.(AttributionRecorder); ok {
+		for id, attribution := range a.attributions {
+			r.RecordAttribution(id, attribution)
+		}
+	}
+	return a.mockDataSource.Observe(ctx, streamValues, opts)
+}
+
+func Test_NewObservationAttributions(t *testing.T) {
+	_, err := NewObservationAttributions(nil)
+	assert.EqualError(t, err, "sink is required")
+}
+
+func Test_attributionRecorder(t *testing.T) {
+	r := &attributionRecorder{}
+	r.RecordAttribution(1, StreamAttribution{Provider: "a"})
+	r.RecordAttribution(1, StreamAttribution{Provider: "b"})
+	assert.Equal(t, map[llotypes.StreamID]StreamAttribution{1: {Provider: "b"}}, r.close())
+
+	// late records are dropped
+	r.RecordAttribution(2, StreamAttribution{Provider: "a"})
+	assert.Equal(t, map[llotypes.StreamID]StreamAttribution{1: {Provider: "b"}}, r.close())
+}
+
+func Test_Observation_ObservationAttributions(t *testing.T) {
+	ctx := tests.Context(t)
+	sink := &mockObservationAttributionSink{}
+	attributions, err := NewObservationAttributions(sink)
+	require.NoError(t, err)
+
+	licensed := StreamAttribution{Provider: "provider-a", License: "premium"}
+	free := StreamAttribution{Provider: "provider-b", License: "free"}
+	ds := &attributingDataSource{
+		mockDataSource: mockDataSource{s: StreamValues{
+			1: ToDecimal(decimal.NewFromInt(1000)),
+			2: ToDecimal(decimal.NewFromInt(2000)),
+			3: ToDecimal(decimal.NewFromInt(3000)),
+			4: ToDecimal(decimal.NewFromInt(4000)),
+		}},
+		attributions: map[llotypes.StreamID]StreamAttribution{
+			1: licensed,
+			2: free,
+			3: licensed,
+			// stream 5 has no value, so its attribution is ignored
+			5: free,
+		},
+	}
+	streams := []llotypes.Stream{}
+	for id := llotypes.StreamID(1); id <= 5; id++ {
+		streams = append(streams, llotypes.Stream{StreamID: id, Aggregator: llotypes.AggregatorMedian})
+	}
+	definitions := llotypes.ChannelDefinitions{
+		1: {ReportFormat: llotypes.ReportFormatJSON, Streams: streams},
+	}
+	cd := types.ConfigDigest{0xa7}
+	p := &Plugin{
+		Config:                  Config{true},
+		ConfigDigest:            cd,
+		OutcomeCodec:            protoOutcomeCodec{},
+		ShouldRetireCache:       &mockShouldRetireCache{},
+		ChannelDefinitionCache:  &mockChannelDefinitionCache{definitions: definitions},
+		Logger:                  logger.Test(t),
+		ObservationCodec:        protoObservationCodec{},
+		DataSource:              ds,
+		ObservationAttributions: attributions,
+	}
+	previousOutcome, err := p.OutcomeCodec.Encode(Outcome{
+		LifeCycleStage:     LifeCycleStageProduction,
+		ChannelDefinitions: definitions,
+	})
+	require.NoError(t, err)
+
+	obs, err := p.Observation(ctx, ocr3types.OutcomeContext{SeqNr: 3, PreviousOutcome: previousOutcome}, types.Query{})
+	require.NoError(t, err)
+	decoded, err := p.ObservationCodec.Decode(obs)
+	require.NoError(t, err)
+	// attributions are not part of the observation
+	assert.Equal(t, ds.s, decoded.StreamValues)
+
+	require.Len(t, sink.sent, 1)
+	r := sink.sent[0]
+	assert.Equal(t, cd, r.ConfigDigest)
+	assert.Equal(t, uint64(3), r.SeqNr)
+	assert.Equal(t, decoded.UnixTimestampNanoseconds, r.ObservationTimestampNanoseconds)
+	assert.Equal(t, []AttributionUsage{
+		{licensed, []llotypes.StreamID{1, 3}},
+		{free, []llotypes.StreamID{2}},
+	}, r.Usages)
+	assert.Equal(t, []llotypes.StreamID{4}, r.UnattributedStreamIDs)
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(promAttributedStreamValuesTotal.WithLabelValues(cd.String(), "provider-a", "premium")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(promAttributedStreamValuesTotal.WithLabelValues(cd.String(), "provider-b", "free")))
+
+	t.Run("nothing is reported without ObservationAttributions", func(t *testing.T) {
+		p.ObservationAttributions = nil
+		_, err := p.Observation(ctx, ocr3types.OutcomeContext{SeqNr: 4, PreviousOutcome: previousOutcome}, types.Query{})
+		require.NoError(t, err)
+		assert.Len(t, sink.sent, 1)
+	})
+}
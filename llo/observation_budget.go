@@ -0,0 +1,116 @@
+package llo
+
+import (
+	"slices"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+// observationSizeEstimator is implemented by ObservationCodecs that can
+// estimate the encoded size of each part of an observation, so that the
+// plugin can trim an observation to its size budget before encoding it.
+// Estimates may be larger than the encoded size, but never smaller.
+type observationSizeEstimator interface {
+	// estimateBaseSize estimates the size of obs without any stream values,
+	// unchanged stream IDs, stream timestamps or channel definition votes
+	estimateBaseSize(obs Observation) int
+	// estimateStreamSize estimates the size that the value, unchanged stream
+	// ID and timestamp of stream id add to obs
+	estimateStreamSize(obs Observation, id llotypes.StreamID) int
+	// estimateChannelDefinitionSize estimates the size that a vote for a
+	// channel definition adds to an observation
+	estimateChannelDefinitionSize(id llotypes.ChannelID, cd llotypes.ChannelDefinition) int
+}
+
+// trimObservation drops parts of obs until its estimated encoded size is
+// within budget, and returns what it dropped.
+//
+// Votes for channel definitions go first, since oracles vote for them again
+// in the next round anyway. They are dropped in reverse order of
+// channelIDs, which lists channels from highest to lowest priority. Streams
+// go next, in reverse order of streamIDs, which likewise lists streams from
+// highest to lowest priority. Votes and streams in obs that are missing from
+// channelIDs or streamIDs have the lowest priority of all.
+func trimObservation(estimator observationSizeEstimator, obs *Observation, channelIDs []llotypes.ChannelID, streamIDs []llotypes.StreamID, budget int) (droppedChannelIDs []llotypes.ChannelID, droppedStreamIDs []llotypes.StreamID) {
+	size := estimator.estimateBaseSize(*obs)
+	channelSizes := make(map[llotypes.ChannelID]int, len(obs.UpdateChannelDefinitions))
+	for id, cd := range obs.UpdateChannelDefinitions {
+		channelSizes[id] = estimator.estimateChannelDefinitionSize(id, cd)
+		size += channelSizes[id]
+	}
+	streamIDs = withUnlistedStreams(*obs, streamIDs)
+	streamSizes := make([]int, len(streamIDs))
+	for i, id := range streamIDs {
+		streamSizes[i] = estimator.estimateStreamSize(*obs, id)
+		size += streamSizes[i]
+	}
+	if size <= budget {
+		return nil, nil
+	}
+
+	channelIDs = withUnlistedChannels(*obs, channelIDs)
+	for i := len(channelIDs) - 1; i >= 0; i-- {
+		if size <= budget {
+			return
+		}
+		id := channelIDs[i]
+		if _, ok := obs.UpdateChannelDefinitions[id]; !ok {
+			continue
+		}
+		delete(obs.UpdateChannelDefinitions, id)
+		droppedChannelIDs = append(droppedChannelIDs, id)
+		size -= channelSizes[id]
+	}
+	for i := len(streamIDs) - 1; i >= 0 && size > budget; i-- {
+		id := streamIDs[i]
+		delete(obs.StreamValues, id)
+		delete(obs.UnchangedStreamIDs, id)
+		delete(obs.StreamTimestamps, id)
+		droppedStreamIDs = append(droppedStreamIDs, id)
+		size -= streamSizes[i]
+	}
+	return
+}
+
+// withUnlistedChannels returns channelIDs followed by the IDs of any other
+// channels voted for in obs, in ascending order
+func withUnlistedChannels(obs Observation, channelIDs []llotypes.ChannelID) []llotypes.ChannelID {
+	listed := make(map[llotypes.ChannelID]struct{}, len(channelIDs))
+	for _, id := range channelIDs {
+		listed[id] = struct{}{}
+	}
+	var unlisted []llotypes.ChannelID
+	for _, id := range sortedKeys(obs.UpdateChannelDefinitions) {
+		if _, ok := listed[id]; !ok {
+			unlisted = append(unlisted, id)
+		}
+	}
+	if len(unlisted) == 0 {
+		return channelIDs
+	}
+	return append(slices.Clip(channelIDs), unlisted...)
+}
+
+// withUnlistedStreams returns streamIDs followed by the IDs of any other
+// streams in obs, in ascending order
+func withUnlistedStreams(obs Observation, streamIDs []llotypes.StreamID) []llotypes.StreamID {
+	listed := make(map[llotypes.StreamID]struct{}, len(streamIDs))
+	for _, id := range streamIDs {
+		listed[id] = struct{}{}
+	}
+	unlisted := make(map[llotypes.StreamID]struct{})
+	for id := range obs.StreamValues {
+		if _, ok := listed[id]; !ok {
+			unlisted[id] = struct{}{}
+		}
+	}
+	for id := range obs.UnchangedStreamIDs {
+		if _, ok := listed[id]; !ok {
+			unlisted[id] = struct{}{}
+		}
+	}
+	if len(unlisted) == 0 {
+		return streamIDs
+	}
+	return append(slices.Clip(streamIDs), sortedKeys(unlisted)...)
+}
@@ -0,0 +1,221 @@
+package llo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+func makeBudgetTestObservation() Observation {
+	obs := Observation{
+		UnixTimestampNanoseconds: time.Now().UnixNano(),
+		RemoveChannelIDs:         map[llotypes.ChannelID]struct{}{7: {}},
+		UpdateChannelDefinitions: llotypes.ChannelDefinitions{},
+		StreamValues:             StreamValues{},
+		StreamTimestamps:         StreamTimestamps{},
+		PreviousOutcomeHash:      [32]byte{1},
+	}
+	for i := 1; i <= 5; i++ {
+		obs.UpdateChannelDefinitions[llotypes.ChannelID(i)] = llotypes.ChannelDefinition{
+			ReportFormat: llotypes.ReportFormatJSON,
+			Streams:      []llotypes.Stream{{StreamID: llotypes.StreamID(i), Aggregator: llotypes.AggregatorMedian}},
+			Opts:         llotypes.ChannelOpts(`{"priority":1}`),
+		}
+	}
+	for i := 1; i <= 100; i++ {
+		id := llotypes.StreamID(i)
+		obs.StreamValues[id] = ToDecimal(decimal.NewFromFloat(1234.5678).Mul(decimal.NewFromInt(int64(i))))
+		obs.StreamTimestamps[id] = obs.UnixTimestampNanoseconds - int64(i)
+	}
+	obs.StreamValues[101] = &Quote{Bid: decimal.NewFromInt(99), Benchmark: decimal.NewFromInt(100), Ask: decimal.NewFromInt(101)}
+	// nil values are not encoded
+	obs.StreamValues[102] = nil
+	return obs
+}
+
+func estimateObservationSize(obs Observation) int {
+	c := protoObservationCodec{}
+	size := c.estimateBaseSize(obs)
+	for id, cd := range obs.UpdateChannelDefinitions {
+		size += c.estimateChannelDefinitionSize(id, cd)
+	}
+	for _, id := range withUnlistedStreams(obs, nil) {
+		size += c.estimateStreamSize(obs, id)
+	}
+	return size
+}
+
+func Test_protoObservationCodec_estimateSize(t *testing.T) {
+	c := protoObservationCodec{}
+
+	t.Run("estimate is exact without unchanged streams", func(t *testing.T) {
+		obs := makeBudgetTestObservation()
+		encoded, err := c.Encode(obs)
+		require.NoError(t, err)
+		assert.Equal(t, len(encoded), estimateObservationSize(obs))
+
+		encoded, err = c.Encode(Observation{})
+		require.NoError(t, err)
+		assert.Equal(t, len(encoded), estimateObservationSize(Observation{}))
+	})
+	t.Run("estimate is an upper bound with unchanged streams", func(t *testing.T) {
+		obs := makeBudgetTestObservation()
+		obs.UnchangedStreamIDs = map[llotypes.StreamID]struct{}{}
+		for i := 1; i <= 50; i++ {
+			delete(obs.StreamValues, llotypes.StreamID(i))
+			obs.UnchangedStreamIDs[llotypes.StreamID(i)] = struct{}{}
+		}
+		// timestamps of streams that are neither observed nor unchanged are
+		// not encoded
+		obs.StreamTimestamps[1000] = 1
+
+		encoded, err := c.Encode(obs)
+		require.NoError(t, err)
+		estimate := estimateObservationSize(obs)
+		assert.GreaterOrEqual(t, estimate, len(encoded))
+		assert.LessOrEqual(t, estimate, len(encoded)+5)
+	})
+}
+
+func Test_trimObservation(t *testing.T) {
+	c := protoObservationCodec{}
+	// highest priority first
+	streamIDs := make([]llotypes.StreamID, 0, 102)
+	for i := 102; i >= 1; i-- {
+		streamIDs = append(streamIDs, llotypes.StreamID(i))
+	}
+
+	t.Run("leaves observation within budget unchanged", func(t *testing.T) {
+		obs := makeBudgetTestObservation()
+		droppedChannelIDs, droppedStreamIDs := trimObservation(c, &obs, nil, streamIDs, estimateObservationSize(obs))
+		assert.Empty(t, droppedChannelIDs)
+		assert.Empty(t, droppedStreamIDs)
+		assert.Equal(t, makeBudgetTestObservation().StreamValues, obs.StreamValues)
+		assert.Len(t, obs.UpdateChannelDefinitions, 5)
+	})
+	t.Run("drops channel definition votes first, highest channel ID first", func(t *testing.T) {
+		obs := makeBudgetTestObservation()
+		budget := estimateObservationSize(obs) - 1
+		droppedChannelIDs, droppedStreamIDs := trimObservation(c, &obs, nil, streamIDs, budget)
+		assert.Equal(t, []llotypes.ChannelID{5}, droppedChannelIDs)
+		assert.Empty(t, droppedStreamIDs)
+		assert.Len(t, obs.UpdateChannelDefinitions, 4)
+
+		encoded, err := c.Encode(obs)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(encoded), budget)
+	})
+	t.Run("drops channel definition votes in reverse order of channelIDs", func(t *testing.T) {
+		obs := makeBudgetTestObservation()
+		budget := estimateObservationSize(obs) - 1
+		droppedChannelIDs, _ := trimObservation(c, &obs, []llotypes.ChannelID{5, 4, 3, 2, 1}, streamIDs, budget)
+		assert.Equal(t, []llotypes.ChannelID{1}, droppedChannelIDs)
+
+		// votes missing from channelIDs go first
+		obs = makeBudgetTestObservation()
+		droppedChannelIDs, _ = trimObservation(c, &obs, []llotypes.ChannelID{1, 2}, streamIDs, budget)
+		assert.Equal(t, []llotypes.ChannelID{5}, droppedChannelIDs)
+	})
+	t.Run("drops lowest priority streams once all channel definition votes are dropped", func(t *testing.T) {
+		obs := makeBudgetTestObservation()
+		budget := estimateObservationSize(obs) / 2
+		droppedChannelIDs, droppedStreamIDs := trimObservation(c, &obs, nil, streamIDs, budget)
+		assert.Equal(t, []llotypes.ChannelID{5, 4, 3, 2, 1}, droppedChannelIDs)
+		require.NotEmpty(t, droppedStreamIDs)
+		for i, id := range droppedStreamIDs {
+			assert.Equal(t, llotypes.StreamID(i+1), id)
+			assert.NotContains(t, obs.StreamValues, id)
+			assert.NotContains(t, obs.StreamTimestamps, id)
+		}
+		assert.Contains(t, obs.StreamValues, llotypes.StreamID(101))
+		assert.Contains(t, obs.StreamValues, llotypes.StreamID(100))
+
+		encoded, err := c.Encode(obs)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(encoded), budget)
+	})
+	t.Run("drops unchanged streams", func(t *testing.T) {
+		obs := makeBudgetTestObservation()
+		delete(obs.StreamValues, 1)
+		obs.UnchangedStreamIDs = map[llotypes.StreamID]struct{}{1: {}}
+		_, droppedStreamIDs := trimObservation(c, &obs, nil, streamIDs, estimateObservationSize(obs)/2)
+		assert.Contains(t, droppedStreamIDs, llotypes.StreamID(1))
+		assert.Empty(t, obs.UnchangedStreamIDs)
+	})
+	t.Run("drops streams missing from streamIDs first", func(t *testing.T) {
+		obs := makeBudgetTestObservation()
+		obs.StreamValues[500] = ToDecimal(decimal.NewFromInt(500))
+		obs.UpdateChannelDefinitions = nil
+		budget := estimateObservationSize(obs) - 1
+		_, droppedStreamIDs := trimObservation(c, &obs, nil, streamIDs, budget)
+		assert.Equal(t, []llotypes.StreamID{500}, droppedStreamIDs)
+	})
+	t.Run("drops everything if the base observation exceeds the budget", func(t *testing.T) {
+		obs := makeBudgetTestObservation()
+		droppedChannelIDs, droppedStreamIDs := trimObservation(c, &obs, nil, streamIDs, 1)
+		assert.Len(t, droppedChannelIDs, 5)
+		assert.Len(t, droppedStreamIDs, 102)
+		assert.Empty(t, obs.StreamValues)
+		assert.Empty(t, obs.StreamTimestamps)
+	})
+}
+
+func Test_Observation_SizeBudget(t *testing.T) {
+	definitions := llotypes.ChannelDefinitions{}
+	ds := &mockDataSource{s: StreamValues{}}
+	for i := 1; i <= 100; i++ {
+		definitions[llotypes.ChannelID(i)] = llotypes.ChannelDefinition{
+			ReportFormat: llotypes.ReportFormatJSON,
+			Streams:      []llotypes.Stream{{StreamID: llotypes.StreamID(i), Aggregator: llotypes.AggregatorMedian}},
+		}
+		ds.s[llotypes.StreamID(i)] = ToDecimal(decimal.NewFromInt(int64(i) * 1_000_000))
+	}
+	// channel 100 is the most important
+	definitions[100] = llotypes.ChannelDefinition{
+		ReportFormat: llotypes.ReportFormatJSON,
+		Streams:      []llotypes.Stream{{StreamID: 100, Aggregator: llotypes.AggregatorMedian}},
+		Opts:         llotypes.ChannelOpts(`{"priority":1}`),
+	}
+	budget := 500
+	p := &Plugin{
+		Config:                 Config{true},
+		OutcomeCodec:           protoOutcomeCodec{},
+		ShouldRetireCache:      &mockShouldRetireCache{},
+		ChannelDefinitionCache: &mockChannelDefinitionCache{definitions},
+		Logger:                 logger.Test(t),
+		ObservationCodec:       protoObservationCodec{},
+		DataSource:             ds,
+		OffchainConfig:         OffchainConfig{MaxObservationBytes: budget},
+	}
+	previousOutcome, err := p.OutcomeCodec.Encode(Outcome{
+		LifeCycleStage:     LifeCycleStageProduction,
+		ChannelDefinitions: definitions,
+	})
+	require.NoError(t, err)
+
+	obs, err := p.Observation(context.Background(), ocr3types.OutcomeContext{SeqNr: 2, PreviousOutcome: previousOutcome}, types.Query{})
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(obs), budget)
+
+	decoded, err := p.ObservationCodec.Decode(obs)
+	require.NoError(t, err)
+	require.NotEmpty(t, decoded.StreamValues)
+	assert.Less(t, len(decoded.StreamValues), 100)
+	assert.Equal(t, ds.s[100], decoded.StreamValues[100])
+	// the remaining streams are kept in ascending order of stream ID
+	for id := range decoded.StreamValues {
+		if id != 100 {
+			assert.LessOrEqual(t, int(id), len(decoded.StreamValues)-1)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package llo
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+// observationProvenanceDomain separates provenance signatures from any other
+// signatures made with the same key
+const observationProvenanceDomain = "llo-observation-provenance-v1"
+
+// ObservationProvenance is an oracle's signed claim of the stream values it
+// observed in a round. It is not part of consensus; it is kept as an audit
+// trail, e.g. to settle disputes over a bad report.
+type ObservationProvenance struct {
+	ConfigDigest                    types.ConfigDigest
+	SeqNr                           uint64
+	ObservationTimestampNanoseconds int64
+	// StreamValues are the raw values returned by the DataSource, before
+	// the observation is trimmed to size or unchanged values are skipped.
+	// Streams without a value are omitted.
+	StreamValues StreamValues
+	PublicKey    ed25519.PublicKey
+	Signature    []byte
+}
+
+// SigningHash returns the hash that is signed, which commits to the config
+// digest, seqNr, observation timestamp and every stream value.
+//
+// The encoding is <domain><configDigest><seqNr><timestamp> followed by
+// <streamID><type><length><value> for each stream in ascending order of
+// stream ID, where integers are big-endian.
+func (o ObservationProvenance) SigningHash() ([32]byte, error) {
+	h := sha256.New()
+	h.Write([]byte(observationProvenanceDomain))
+	h.Write(o.ConfigDigest[:])
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], o.SeqNr)
+	h.Write(b[:])
+	binary.BigEndian.PutUint64(b[:], uint64(o.ObservationTimestampNanoseconds))
+	h.Write(b[:])
+	for _, id := range sortedKeys(o.StreamValues) {
+		sv := o.StreamValues[id]
+		if sv == nil {
+			continue
+		}
+		enc, err := sv.MarshalBinary()
+		if err != nil {
+			return [32]byte{}, fmt.Errorf("failed to encode stream %d: %w", id, err)
+		}
+		binary.BigEndian.PutUint32(b[:4], id)
+		h.Write(b[:4])
+		binary.BigEndian.PutUint32(b[:4], uint32(sv.Type()))
+		h.Write(b[:4])
+		binary.BigEndian.PutUint32(b[:4], uint32(len(enc)))
+		h.Write(b[:4])
+		h.Write(enc)
+	}
+	var out [32]byte
+	copy(out[:], h.Sum(nil))
+	return out, nil
+}
+
+// Verify checks that Signature is a valid signature by PublicKey
+func (o ObservationProvenance) Verify() error {
+	if len(o.PublicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("invalid ed25519 public key length; got: %d, expected: %d", len(o.PublicKey), ed25519.PublicKeySize)
+	}
+	h, err := o.SigningHash()
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(o.PublicKey, h[:], o.Signature) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+// ObservationProvenanceSink persists signed observations, typically by
+// sending them to the node's telemetry
+type ObservationProvenanceSink interface {
+	// SendObservationProvenance must not block; it is called in the
+	// Observation stage
+	SendObservationProvenance(ObservationProvenance)
+}
+
+// ObservationProvenanceSigner signs the raw stream values that an oracle
+// observed with its local key and sends them to a sink
+type ObservationProvenanceSigner struct {
+	privateKey ed25519.PrivateKey
+	sink       ObservationProvenanceSink
+}
+
+func NewObservationProvenanceSigner(privateKey ed25519.PrivateKey, sink ObservationProvenanceSink) (*ObservationProvenanceSigner, error) {
+	if len(privateKey) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("invalid ed25519 private key length; got: %d, expected: %d", len(privateKey), ed25519.PrivateKeySize)
+	}
+	if sink == nil {
+		return nil, errors.New("sink is required")
+	}
+	return &ObservationProvenanceSigner{privateKey, sink}, nil
+}
+
+// sign signs the stream values and sends them to the sink. The stream values
+// are copied, so the caller may modify them afterwards.
+func (s *ObservationProvenanceSigner) sign(cd types.ConfigDigest, seqNr uint64, observationTimestampNanoseconds int64, streamValues StreamValues) error {
+	o := ObservationProvenance{
+		ConfigDigest:                    cd,
+		SeqNr:                           seqNr,
+		ObservationTimestampNanoseconds: observationTimestampNanoseconds,
+		StreamValues:                    make(StreamValues, len(streamValues)),
+		PublicKey:                       s.privateKey.Public().(ed25519.PublicKey),
+	}
+	for id, sv := range streamValues {
+		if sv != nil {
+			o.StreamValues[id] = sv
+		}
+	}
+	h, err := o.SigningHash()
+	if err != nil {
+		return err
+	}
+	o.Signature = ed25519.Sign(s.privateKey, h[:])
+	s.sink.SendObservationProvenance(o)
+	return nil
+}
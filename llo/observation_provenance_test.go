@@ -0,0 +1,155 @@
+package llo
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+type mockObservationProvenanceSink struct {
+	sent []ObservationProvenance
+}
+
+func (m *mockObservationProvenanceSink) SendObservationProvenance(o ObservationProvenance) {
+	m.sent = append(m.sent, o)
+}
+
+func Test_ObservationProvenanceSigner(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	t.Run("validates arguments", func(t *testing.T) {
+		_, err := NewObservationProvenanceSigner(privateKey[:10], &mockObservationProvenanceSink{})
+		assert.EqualError(t, err, "invalid ed25519 private key length; got: 10, expected: 64")
+		_, err = NewObservationProvenanceSigner(privateKey, nil)
+		assert.EqualError(t, err, "sink is required")
+	})
+
+	sink := &mockObservationProvenanceSink{}
+	s, err := NewObservationProvenanceSigner(privateKey, sink)
+	require.NoError(t, err)
+	streamValues := StreamValues{
+		1: ToDecimal(decimal.NewFromInt(1)),
+		2: nil,
+		3: &Quote{Bid: decimal.NewFromInt(1), Benchmark: decimal.NewFromInt(2), Ask: decimal.NewFromInt(3)},
+	}
+	require.NoError(t, s.sign(types.ConfigDigest{1}, 2, 3, streamValues))
+	require.Len(t, sink.sent, 1)
+	o := sink.sent[0]
+
+	t.Run("signs stream values with a value", func(t *testing.T) {
+		assert.Equal(t, types.ConfigDigest{1}, o.ConfigDigest)
+		assert.Equal(t, uint64(2), o.SeqNr)
+		assert.Equal(t, int64(3), o.ObservationTimestampNanoseconds)
+		assert.Equal(t, StreamValues{1: streamValues[1], 3: streamValues[3]}, o.StreamValues)
+		assert.Equal(t, privateKey.Public(), o.PublicKey)
+		require.NoError(t, o.Verify())
+
+		// the signed values are a copy
+		delete(streamValues, 1)
+		assert.Len(t, o.StreamValues, 2)
+	})
+
+	t.Run("signature does not verify if anything is tampered with", func(t *testing.T) {
+		tampered := o
+		tampered.SeqNr++
+		assert.EqualError(t, tampered.Verify(), "invalid signature")
+
+		tampered = o
+		tampered.ConfigDigest = types.ConfigDigest{2}
+		assert.EqualError(t, tampered.Verify(), "invalid signature")
+
+		tampered = o
+		tampered.ObservationTimestampNanoseconds++
+		assert.EqualError(t, tampered.Verify(), "invalid signature")
+
+		tampered = o
+		tampered.StreamValues = StreamValues{1: ToDecimal(decimal.NewFromInt(2)), 3: o.StreamValues[3]}
+		assert.EqualError(t, tampered.Verify(), "invalid signature")
+
+		tampered = o
+		tampered.StreamValues = StreamValues{1: o.StreamValues[1]}
+		assert.EqualError(t, tampered.Verify(), "invalid signature")
+
+		// same value, different stream
+		tampered = o
+		tampered.StreamValues = StreamValues{2: o.StreamValues[1], 3: o.StreamValues[3]}
+		assert.EqualError(t, tampered.Verify(), "invalid signature")
+
+		tampered = o
+		tampered.PublicKey = o.PublicKey[:10]
+		assert.EqualError(t, tampered.Verify(), "invalid ed25519 public key length; got: 10, expected: 32")
+	})
+
+	t.Run("nil stream values do not change the signing hash", func(t *testing.T) {
+		withNil := o
+		withNil.StreamValues = StreamValues{1: o.StreamValues[1], 2: nil, 3: o.StreamValues[3]}
+		require.NoError(t, withNil.Verify())
+	})
+}
+
+func Test_Observation_ObservationProvenance(t *testing.T) {
+	ctx := tests.Context(t)
+	_, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	sink := &mockObservationProvenanceSink{}
+	signer, err := NewObservationProvenanceSigner(privateKey, sink)
+	require.NoError(t, err)
+
+	ds := &mockDataSource{s: StreamValues{
+		1: ToDecimal(decimal.NewFromInt(1000)),
+		2: ToDecimal(decimal.NewFromInt(2000)),
+	}}
+	definitions := llotypes.ChannelDefinitions{
+		1: {
+			ReportFormat: llotypes.ReportFormatJSON,
+			Streams:      []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}, {StreamID: 2, Aggregator: llotypes.AggregatorMedian}},
+		},
+	}
+	p := &Plugin{
+		Config:                 Config{true},
+		ConfigDigest:           types.ConfigDigest{1},
+		OutcomeCodec:           protoOutcomeCodec{},
+		ShouldRetireCache:      &mockShouldRetireCache{},
+		ChannelDefinitionCache: &mockChannelDefinitionCache{definitions: definitions},
+		Logger:                 logger.Test(t),
+		ObservationCodec:       protoObservationCodec{},
+		DataSource:             ds,
+		ObservationProvenance:  signer,
+		OffchainConfig:         OffchainConfig{SkipUnchangedStreamValues: true},
+	}
+	previousOutcome, err := p.OutcomeCodec.Encode(Outcome{
+		LifeCycleStage:     LifeCycleStageProduction,
+		ChannelDefinitions: definitions,
+		StreamAggregates: StreamAggregates{
+			1: {llotypes.AggregatorMedian: ToDecimal(decimal.NewFromInt(1000))},
+		},
+	})
+	require.NoError(t, err)
+
+	obs, err := p.Observation(ctx, ocr3types.OutcomeContext{SeqNr: 3, PreviousOutcome: previousOutcome}, types.Query{})
+	require.NoError(t, err)
+	decoded, err := p.ObservationCodec.Decode(obs)
+	require.NoError(t, err)
+	// stream 1 is unchanged, so it is not in the observation
+	assert.Equal(t, StreamValues{2: ds.s[2]}, decoded.StreamValues)
+
+	require.Len(t, sink.sent, 1)
+	o := sink.sent[0]
+	assert.Equal(t, types.ConfigDigest{1}, o.ConfigDigest)
+	assert.Equal(t, uint64(3), o.SeqNr)
+	assert.Equal(t, decoded.UnixTimestampNanoseconds, o.ObservationTimestampNanoseconds)
+	// but the provenance has the raw values
+	assert.Equal(t, ds.s, o.StreamValues)
+	assert.NoError(t, o.Verify())
+}
@@ -1,25 +1,288 @@
 package llo
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"time"
 
+	"github.com/shopspring/decimal"
 	"google.golang.org/protobuf/proto"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
 )
 
+// OffchainConfigVersion is the version of the offchain config schema that
+// this package encodes and decodes.
+//
+// Fields are only ever added to the schema, never renumbered or reused, so a
+// config of any version decodes the same with every later version. Since
+// all settings are consensus-critical, the version is bumped whenever a
+// field is added: an older decoder would otherwise silently ignore the new
+// field and disagree with up-to-date oracles, whereas it now rejects the
+// config with ErrUnsupportedOffchainConfigVersion. Encode tags each config
+// with the lowest version that has all of the settings it uses, so configs
+// that do not use new settings can still be decoded by older oracles.
+//
+// Version 1 is the original schema, which has no settings; its decoder
+// predates versioning and ignores every field, so configs of any later
+// version must only be used once all oracles decode versions. Version 2
+// added SkipUnchangedStreamValues and UnchangedStreamValueEpsilon, 3
+// StreamStalenessBound, 4 MaxObservationBytes, 5 RetirementWindDownRounds,
+// 6 DegradedModeRounds and ReducedConfidenceReports, 7
+// ChannelPriorityClasses, 8 MaxReportsPerRound, 9
+// ValidAfterSecondsOverlapSeconds and NewChannelGracePeriodSeconds, and 10
+// RequireStreamAggregates.
+const OffchainConfigVersion = 10
+
+// ErrUnsupportedOffchainConfigVersion is returned when decoding a config
+// that was encoded with a newer version of the schema than
+// OffchainConfigVersion
+var ErrUnsupportedOffchainConfigVersion = errors.New("unsupported offchain config version")
+
+// OffchainConfig is shared by all oracles of a protocol instance, so it may
+// contain settings that must be identical across nodes for them to come to
+// consensus.
 type OffchainConfig struct {
-	// NOTE: Currently OffchainConfig does not contain anything, and is not used
+	// SkipUnchangedStreamValues enables omitting stream values from
+	// observations if they are within UnchangedStreamValueEpsilon of the
+	// previous outcome's median. Oracles list omitted streams in
+	// Observation.UnchangedStreamIDs, which the Outcome counts as an
+	// observation of the previous median.
+	//
+	// Only streams that are exclusively aggregated with
+	// AggregatorMedian are eligible.
+	SkipUnchangedStreamValues bool
+	// UnchangedStreamValueEpsilon is the maximum relative difference from
+	// the previous median for a value to count as unchanged, e.g. 0.0001
+	// for 1 basis point. Zero requires an exact match.
+	UnchangedStreamValueEpsilon decimal.Decimal
+	// StreamStalenessBound is the maximum age of a stream value, as measured
+	// from the source timestamp reported by a TimestampedDataSource to the
+	// time of observation. Older values are excluded from aggregation so
+	// that a stuck upstream source cannot freeze the aggregate at an old
+	// value. Zero disables staleness checks.
+	StreamStalenessBound time.Duration
+	// MaxObservationBytes is the size budget of an encoded observation. If
+	// an observation would exceed it, the lowest priority stream values and
+	// channel definition votes are dropped so that it fits, rather than
+	// being rejected as a whole. Zero means MaxObservationLength.
+	MaxObservationBytes int
+	// RetirementWindDownRounds is the number of rounds for which a retired
+	// protocol instance keeps generating specimen reports for its channels,
+	// alongside the retirement report. This avoids a monitoring blind spot
+	// while the successor takes over. Specimen reports are not verified
+	// onchain, so they do not advance ValidAfterSeconds and the handover
+	// stays gapless. Zero stops reporting as soon as the instance retires.
+	RetirementWindDownRounds uint32
+	// DegradedModeRounds is the number of consecutive rounds that must
+	// each have a stream whose value is based on fewer than 2f+1 oracles, or
+	// be missed by this oracle, for the oracle to report degraded mode in its metrics.
+	// As many consecutive healthy rounds end degraded mode again. Zero
+	// means DefaultDegradedModeRounds.
+	DegradedModeRounds uint32
+	// ReducedConfidenceReports marks reports as ReducedConfidence while at
+	// least DegradedModeRounds consecutive outcomes, up to and including
+	// the reported one, each had a stream whose value was based on fewer
+	// than 2f+1 oracles, so that consumers can react to the reduced fault
+	// tolerance. Report formats that cannot encode the flag, such as EVM
+	// reports, carry it in their transmission (see
+	// TransmissionTargets.ReducedConfidence).
+	ReducedConfidenceReports bool
+	// ChannelPriorityClasses lets the priority classes of channels (see
+	// ChannelPriorityOpts) decide which channel definitions are voted in
+	// first, the order of reports and their default transmission priority.
+	// Otherwise channels are synced and reported in order of channel ID.
+	ChannelPriorityClasses bool
+	// MaxReportsPerRound caps the number of reports generated per round,
+	// including the retirement report. If the reportable channels would
+	// generate more, the reports of the lowest priority channels that have
+	// been reported on most recently are deferred to later rounds. Zero
+	// means MaxReportCount, which is also the highest allowed value.
+	MaxReportsPerRound int
+	// ValidAfterSecondsOverlapSeconds makes each report on a channel
+	// overlap the previous one by up to this many seconds, e.g. for
+	// consumers that tolerate clock skew by requiring overlapping ranges.
+	// Zero chains reports without gaps or overlap. See
+	// FixedOverlapValidAfterSecondsPolicy.
+	ValidAfterSecondsOverlapSeconds uint32
+	// NewChannelGracePeriodSeconds makes the first report of a new channel
+	// valid from this many seconds before the channel was added. See
+	// NewChannelGracePeriodPolicy.
+	NewChannelGracePeriodSeconds uint32
+	// RequireStreamAggregates makes channels unreportable, with
+	// UnreportableCauseMissingMedian, in rounds in which none of their
+	// streams, including fee streams, were aggregated, e.g. because fewer
+	// than f+1 oracles observed them. Their ValidAfterSeconds then carries
+	// over to the next report. Otherwise such channels are reported, and
+	// it is up to the report codec to handle the missing values.
+	RequireStreamAggregates bool
 }
 
+// DecodeOffchainConfig decodes a config that was encoded with any version of
+// the schema up to OffchainConfigVersion, and validates it
 func DecodeOffchainConfig(b []byte) (o OffchainConfig, err error) {
 	pbuf := &LLOOffchainConfigProto{}
 	err = proto.Unmarshal(b, pbuf)
 	if err != nil {
 		return o, fmt.Errorf("failed to decode offchain config: expected protobuf (got: 0x%x); %w", b, err)
 	}
+	if pbuf.Version > OffchainConfigVersion {
+		return o, fmt.Errorf("failed to decode offchain config: %w; got: %d, max supported: %d", ErrUnsupportedOffchainConfigVersion, pbuf.Version, OffchainConfigVersion)
+	}
+	o.SkipUnchangedStreamValues = pbuf.SkipUnchangedStreamValues
+	if pbuf.UnchangedStreamValueEpsilon != "" {
+		o.UnchangedStreamValueEpsilon, err = decimal.NewFromString(pbuf.UnchangedStreamValueEpsilon)
+		if err != nil {
+			return o, fmt.Errorf("failed to decode offchain config: invalid UnchangedStreamValueEpsilon: %w", err)
+		}
+	}
+	if pbuf.StreamStalenessBoundNanoseconds > math.MaxInt64 {
+		return o, fmt.Errorf("failed to decode offchain config: StreamStalenessBoundNanoseconds overflows int64; got: %d", pbuf.StreamStalenessBoundNanoseconds)
+	}
+	o.StreamStalenessBound = time.Duration(pbuf.StreamStalenessBoundNanoseconds)
+	o.MaxObservationBytes = int(pbuf.MaxObservationBytes)
+	o.RetirementWindDownRounds = pbuf.RetirementWindDownRounds
+	o.DegradedModeRounds = pbuf.DegradedModeRounds
+	o.ReducedConfidenceReports = pbuf.ReducedConfidenceReports
+	o.ChannelPriorityClasses = pbuf.ChannelPriorityClasses
+	o.MaxReportsPerRound = int(pbuf.MaxReportsPerRound)
+	o.ValidAfterSecondsOverlapSeconds = pbuf.ValidAfterSecondsOverlapSeconds
+	o.NewChannelGracePeriodSeconds = pbuf.NewChannelGracePeriodSeconds
+	o.RequireStreamAggregates = pbuf.RequireStreamAggregates
+	if err = o.Validate(); err != nil {
+		return o, fmt.Errorf("failed to decode offchain config: %w", err)
+	}
+	if version := max(pbuf.Version, 1); version < o.version() {
+		return o, fmt.Errorf("failed to decode offchain config: settings require version %d; got: %d", o.version(), version)
+	}
 	return
 }
 
+// DecodeOffchainConfigForDigest decodes the offchain config of the protocol
+// instance with the given config digest. It fails if the config digest was
+// not produced by an LLO contract, since the config is then meant for a
+// different plugin.
+func DecodeOffchainConfigForDigest(cd types.ConfigDigest, b []byte) (OffchainConfig, error) {
+	if err := VerifyConfigDigestPrefix(cd); err != nil {
+		return OffchainConfig{}, fmt.Errorf("refusing to decode offchain config: %w", err)
+	}
+	return DecodeOffchainConfig(b)
+}
+
+func (c OffchainConfig) Validate() error {
+	if c.UnchangedStreamValueEpsilon.IsNegative() {
+		return fmt.Errorf("UnchangedStreamValueEpsilon must not be negative; got: %s", c.UnchangedStreamValueEpsilon)
+	}
+	if c.StreamStalenessBound < 0 {
+		return fmt.Errorf("StreamStalenessBound must not be negative; got: %s", c.StreamStalenessBound)
+	}
+	if c.MaxObservationBytes < 0 || c.MaxObservationBytes > MaxObservationLength {
+		return fmt.Errorf("MaxObservationBytes must be between 0 and %d; got: %d", MaxObservationLength, c.MaxObservationBytes)
+	}
+	if c.MaxReportsPerRound < 0 || c.MaxReportsPerRound > MaxReportCount {
+		return fmt.Errorf("MaxReportsPerRound must be between 0 and %d; got: %d", MaxReportCount, c.MaxReportsPerRound)
+	}
+	return nil
+}
+
+// Encode encodes the config as LLOOffchainConfigProto, tagged with the
+// lowest version of the schema that can represent it. The zero config
+// encodes to empty bytes, which every version decodes to the zero config.
 func (c OffchainConfig) Encode() ([]byte, error) {
-	pbuf := LLOOffchainConfigProto{}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	if c.isZero() {
+		return nil, nil
+	}
+	pbuf := LLOOffchainConfigProto{
+		Version:                         c.version(),
+		SkipUnchangedStreamValues:       c.SkipUnchangedStreamValues,
+		StreamStalenessBoundNanoseconds: uint64(c.StreamStalenessBound),
+		MaxObservationBytes:             uint32(c.MaxObservationBytes),
+		RetirementWindDownRounds:        c.RetirementWindDownRounds,
+		DegradedModeRounds:              c.DegradedModeRounds,
+		ReducedConfidenceReports:        c.ReducedConfidenceReports,
+		ChannelPriorityClasses:          c.ChannelPriorityClasses,
+		MaxReportsPerRound:              uint32(c.MaxReportsPerRound),
+		ValidAfterSecondsOverlapSeconds: c.ValidAfterSecondsOverlapSeconds,
+		NewChannelGracePeriodSeconds:    c.NewChannelGracePeriodSeconds,
+		RequireStreamAggregates:         c.RequireStreamAggregates,
+	}
+	if !c.UnchangedStreamValueEpsilon.IsZero() {
+		pbuf.UnchangedStreamValueEpsilon = c.UnchangedStreamValueEpsilon.String()
+	}
 	return proto.Marshal(&pbuf)
 }
+
+// version returns the lowest version of the schema that has all of the
+// settings that the config uses
+func (c OffchainConfig) version() uint32 {
+	switch {
+	case c.RequireStreamAggregates:
+		return 10
+	case c.ValidAfterSecondsOverlapSeconds != 0 || c.NewChannelGracePeriodSeconds != 0:
+		return 9
+	case c.MaxReportsPerRound != 0:
+		return 8
+	case c.ChannelPriorityClasses:
+		return 7
+	case c.DegradedModeRounds != 0 || c.ReducedConfidenceReports:
+		return 6
+	case c.RetirementWindDownRounds != 0:
+		return 5
+	case c.MaxObservationBytes != 0:
+		return 4
+	case c.StreamStalenessBound != 0:
+		return 3
+	case c.SkipUnchangedStreamValues || !c.UnchangedStreamValueEpsilon.IsZero():
+		return 2
+	}
+	return 1
+}
+
+func (c OffchainConfig) isZero() bool {
+	// decimals are compared by value, not by their internal pointer
+	epsilon := c.UnchangedStreamValueEpsilon
+	c.UnchangedStreamValueEpsilon = decimal.Decimal{}
+	return epsilon.IsZero() && c == OffchainConfig{}
+}
+
+// DegradedModeThreshold returns the number of consecutive rounds that enter
+// or leave degraded mode
+func (c OffchainConfig) DegradedModeThreshold() uint32 {
+	if c.DegradedModeRounds == 0 {
+		return DefaultDegradedModeRounds
+	}
+	return c.DegradedModeRounds
+}
+
+// ObservationBudget returns the maximum size of an encoded observation
+func (c OffchainConfig) ObservationBudget() int {
+	if c.MaxObservationBytes == 0 {
+		return MaxObservationLength
+	}
+	return c.MaxObservationBytes
+}
+
+// ReportCountLimit returns the maximum number of reports per round
+func (c OffchainConfig) ReportCountLimit() int {
+	if c.MaxReportsPerRound == 0 {
+		return MaxReportCount
+	}
+	return c.MaxReportsPerRound
+}
+
+// ValidAfterSecondsPolicy returns the policy that chains the validity ranges
+// of consecutive reports on a channel
+func (c OffchainConfig) ValidAfterSecondsPolicy() ValidAfterSecondsPolicy {
+	var policy ValidAfterSecondsPolicy = GaplessValidAfterSecondsPolicy{}
+	if c.ValidAfterSecondsOverlapSeconds > 0 {
+		policy = FixedOverlapValidAfterSecondsPolicy{OverlapSeconds: c.ValidAfterSecondsOverlapSeconds}
+	}
+	if c.NewChannelGracePeriodSeconds > 0 {
+		policy = NewChannelGracePeriodPolicy{policy, c.NewChannelGracePeriodSeconds}
+	}
+	return policy
+}
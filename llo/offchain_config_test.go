@@ -1,10 +1,17 @@
 package llo
 
 import (
+	"fmt"
+	"math"
 	"testing"
+	"time"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
 )
 
 func Test_OffchainConfig(t *testing.T) {
@@ -18,4 +25,180 @@ func Test_OffchainConfig(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, cfg, cfgDecoded)
 	})
+	t.Run("default config encodes to empty bytes", func(t *testing.T) {
+		b, err := OffchainConfig{}.Encode()
+		require.NoError(t, err)
+		assert.Len(t, b, 0)
+	})
+	t.Run("encode and decode with unchanged stream value skipping", func(t *testing.T) {
+		cfg := OffchainConfig{
+			SkipUnchangedStreamValues:   true,
+			UnchangedStreamValueEpsilon: decimal.RequireFromString("0.0001"),
+		}
+
+		b, err := cfg.Encode()
+		require.NoError(t, err)
+
+		cfgDecoded, err := DecodeOffchainConfig(b)
+		require.NoError(t, err)
+		assert.True(t, cfgDecoded.SkipUnchangedStreamValues)
+		assert.True(t, cfg.UnchangedStreamValueEpsilon.Equal(cfgDecoded.UnchangedStreamValueEpsilon))
+	})
+	t.Run("negative epsilon is invalid", func(t *testing.T) {
+		cfg := OffchainConfig{UnchangedStreamValueEpsilon: decimal.NewFromInt(-1)}
+		_, err := cfg.Encode()
+		assert.EqualError(t, err, "UnchangedStreamValueEpsilon must not be negative; got: -1")
+
+		b, err := proto.Marshal(&LLOOffchainConfigProto{UnchangedStreamValueEpsilon: "-1"})
+		require.NoError(t, err)
+		_, err = DecodeOffchainConfig(b)
+		assert.EqualError(t, err, "failed to decode offchain config: UnchangedStreamValueEpsilon must not be negative; got: -1")
+	})
+	t.Run("encode and decode with staleness bound", func(t *testing.T) {
+		cfg := OffchainConfig{StreamStalenessBound: 5 * time.Second}
+
+		b, err := cfg.Encode()
+		require.NoError(t, err)
+
+		cfgDecoded, err := DecodeOffchainConfig(b)
+		require.NoError(t, err)
+		assert.Equal(t, cfg, cfgDecoded)
+	})
+	t.Run("negative staleness bound is invalid", func(t *testing.T) {
+		_, err := OffchainConfig{StreamStalenessBound: -time.Second}.Encode()
+		assert.EqualError(t, err, "StreamStalenessBound must not be negative; got: -1s")
+	})
+	t.Run("staleness bound that overflows int64 is invalid", func(t *testing.T) {
+		b, err := proto.Marshal(&LLOOffchainConfigProto{StreamStalenessBoundNanoseconds: math.MaxUint64})
+		require.NoError(t, err)
+		_, err = DecodeOffchainConfig(b)
+		assert.EqualError(t, err, "failed to decode offchain config: StreamStalenessBoundNanoseconds overflows int64; got: 18446744073709551615")
+	})
+	t.Run("encode and decode with observation budget", func(t *testing.T) {
+		cfg := OffchainConfig{MaxObservationBytes: 100_000}
+
+		b, err := cfg.Encode()
+		require.NoError(t, err)
+
+		cfgDecoded, err := DecodeOffchainConfig(b)
+		require.NoError(t, err)
+		assert.Equal(t, cfg, cfgDecoded)
+		assert.Equal(t, 100_000, cfgDecoded.ObservationBudget())
+	})
+	t.Run("observation budget defaults to MaxObservationLength", func(t *testing.T) {
+		assert.Equal(t, MaxObservationLength, OffchainConfig{}.ObservationBudget())
+	})
+	t.Run("observation budget above MaxObservationLength is invalid", func(t *testing.T) {
+		b, err := proto.Marshal(&LLOOffchainConfigProto{MaxObservationBytes: MaxObservationLength + 1})
+		require.NoError(t, err)
+		_, err = DecodeOffchainConfig(b)
+		assert.EqualError(t, err, fmt.Sprintf("failed to decode offchain config: MaxObservationBytes must be between 0 and %d; got: %d", MaxObservationLength, MaxObservationLength+1))
+	})
+	t.Run("encode and decode with retirement wind-down", func(t *testing.T) {
+		cfg := OffchainConfig{RetirementWindDownRounds: 10}
+
+		b, err := cfg.Encode()
+		require.NoError(t, err)
+
+		cfgDecoded, err := DecodeOffchainConfig(b)
+		require.NoError(t, err)
+		assert.Equal(t, cfg, cfgDecoded)
+	})
+	t.Run("encode and decode with degraded mode", func(t *testing.T) {
+		cfg := OffchainConfig{DegradedModeRounds: 5, ReducedConfidenceReports: true}
+
+		b, err := cfg.Encode()
+		require.NoError(t, err)
+
+		cfgDecoded, err := DecodeOffchainConfig(b)
+		require.NoError(t, err)
+		assert.Equal(t, cfg, cfgDecoded)
+		assert.Equal(t, uint32(5), cfgDecoded.DegradedModeThreshold())
+		assert.Equal(t, uint32(DefaultDegradedModeRounds), OffchainConfig{}.DegradedModeThreshold())
+	})
+	t.Run("encode and decode with channel priority classes", func(t *testing.T) {
+		cfg := OffchainConfig{ChannelPriorityClasses: true}
+
+		b, err := cfg.Encode()
+		require.NoError(t, err)
+
+		cfgDecoded, err := DecodeOffchainConfig(b)
+		require.NoError(t, err)
+		assert.Equal(t, cfg, cfgDecoded)
+	})
+	t.Run("encode and decode with report count limit", func(t *testing.T) {
+		cfg := OffchainConfig{MaxReportsPerRound: 100}
+
+		b, err := cfg.Encode()
+		require.NoError(t, err)
+
+		cfgDecoded, err := DecodeOffchainConfig(b)
+		require.NoError(t, err)
+		assert.Equal(t, cfg, cfgDecoded)
+		assert.Equal(t, 100, cfgDecoded.ReportCountLimit())
+		assert.Equal(t, MaxReportCount, OffchainConfig{}.ReportCountLimit())
+	})
+	t.Run("report count limit above MaxReportCount is invalid", func(t *testing.T) {
+		_, err := OffchainConfig{MaxReportsPerRound: MaxReportCount + 1}.Encode()
+		assert.EqualError(t, err, fmt.Sprintf("MaxReportsPerRound must be between 0 and %d; got: %d", MaxReportCount, MaxReportCount+1))
+	})
+	t.Run("encodes the lowest version that represents the config", func(t *testing.T) {
+		for _, tc := range []struct {
+			cfg     OffchainConfig
+			version uint32
+		}{
+			{OffchainConfig{SkipUnchangedStreamValues: true}, 2},
+			{OffchainConfig{UnchangedStreamValueEpsilon: decimal.RequireFromString("0.01")}, 2},
+			{OffchainConfig{SkipUnchangedStreamValues: true, StreamStalenessBound: time.Second}, 3},
+			{OffchainConfig{MaxObservationBytes: 1}, 4},
+			{OffchainConfig{RetirementWindDownRounds: 1}, 5},
+			{OffchainConfig{DegradedModeRounds: 1}, 6},
+			{OffchainConfig{ReducedConfidenceReports: true}, 6},
+			{OffchainConfig{ChannelPriorityClasses: true}, 7},
+			{OffchainConfig{MaxReportsPerRound: 1}, 8},
+			{OffchainConfig{MaxReportsPerRound: 1, NewChannelGracePeriodSeconds: 1}, 9},
+			{OffchainConfig{ValidAfterSecondsOverlapSeconds: 1}, 9},
+			{OffchainConfig{RequireStreamAggregates: true, MaxObservationBytes: 1}, 10},
+		} {
+			var pbuf LLOOffchainConfigProto
+			b, err := tc.cfg.Encode()
+			require.NoError(t, err)
+			require.NoError(t, proto.Unmarshal(b, &pbuf))
+			assert.Equal(t, tc.version, pbuf.Version, "%+v", tc.cfg)
+		}
+	})
+	t.Run("encode and decode with ValidAfterSeconds policy", func(t *testing.T) {
+		cfg := OffchainConfig{ValidAfterSecondsOverlapSeconds: 10, NewChannelGracePeriodSeconds: 60}
+
+		b, err := cfg.Encode()
+		require.NoError(t, err)
+
+		cfgDecoded, err := DecodeOffchainConfig(b)
+		require.NoError(t, err)
+		assert.Equal(t, cfg, cfgDecoded)
+	})
+	t.Run("unparseable epsilon is invalid", func(t *testing.T) {
+		b, err := proto.Marshal(&LLOOffchainConfigProto{UnchangedStreamValueEpsilon: "foo"})
+		require.NoError(t, err)
+		_, err = DecodeOffchainConfig(b)
+		assert.ErrorContains(t, err, "failed to decode offchain config: invalid UnchangedStreamValueEpsilon")
+	})
+}
+
+func Test_DecodeOffchainConfigForDigest(t *testing.T) {
+	b, err := OffchainConfig{}.Encode()
+	require.NoError(t, err)
+
+	_, err = DecodeOffchainConfigForDigest(types.ConfigDigest{0x00, 0x09}, b)
+	require.NoError(t, err)
+
+	_, err = DecodeOffchainConfigForDigest(types.ConfigDigest{0x00, 0x06}, b)
+	assert.EqualError(t, err, "refusing to decode offchain config: config digest 0006000000000000000000000000000000000000000000000000000000000000 has prefix 0x0006, expected one of: [0x0009]")
+}
+
+func Test_OffchainConfig_ValidAfterSecondsPolicy(t *testing.T) {
+	assert.Equal(t, GaplessValidAfterSecondsPolicy{}, OffchainConfig{}.ValidAfterSecondsPolicy())
+	assert.Equal(t, FixedOverlapValidAfterSecondsPolicy{OverlapSeconds: 10}, OffchainConfig{ValidAfterSecondsOverlapSeconds: 10}.ValidAfterSecondsPolicy())
+	assert.Equal(t, NewChannelGracePeriodPolicy{GaplessValidAfterSecondsPolicy{}, 60}, OffchainConfig{NewChannelGracePeriodSeconds: 60}.ValidAfterSecondsPolicy())
+	assert.Equal(t, NewChannelGracePeriodPolicy{FixedOverlapValidAfterSecondsPolicy{OverlapSeconds: 10}, 60}, OffchainConfig{ValidAfterSecondsOverlapSeconds: 10, NewChannelGracePeriodSeconds: 60}.ValidAfterSecondsPolicy())
 }
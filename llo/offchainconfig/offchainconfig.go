@@ -0,0 +1,74 @@
+// Package offchainconfig is the stable API for the offchain config of LLO
+// protocol instances. Tooling that generates configs for the configuration
+// contract (e.g. deployment scripts) should depend on it rather than on the
+// internals of package llo, or on its own copy of the encoding.
+//
+// The package makes the following compatibility guarantees:
+//   - Encode, Decode and Validate keep their signatures, and fields are only
+//     ever added to Config, never removed or repurposed.
+//   - A config encoded with any version of the schema decodes the same with
+//     every later version, so configs that are already set onchain remain
+//     valid when nodes are upgraded.
+//   - Every field added to the schema bumps Version. Nodes reject configs
+//     of a newer version than they support with ErrUnsupportedVersion,
+//     instead of silently ignoring the settings that they do not know about.
+//     Encode uses the lowest version that has all of the settings a config
+//     uses, and tooling can use EncodedVersion to check that all nodes of a
+//     DON have been upgraded before setting a config. Decode rejects configs
+//     whose version lacks any of the settings they use.
+//   - Version 1 is the original schema, which has no settings. Nodes that
+//     predate versioning ignore every field, so configs of later versions
+//     must only be set once all nodes decode versioned configs.
+//   - The zero Config encodes to empty bytes.
+package offchainconfig
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/smartcontractkit/chainlink-data-streams/llo"
+)
+
+// Version is the newest version of the schema, up to which Decode accepts
+// configs
+const Version = llo.OffchainConfigVersion
+
+// ErrUnsupportedVersion is returned by Decode for configs of a newer version
+// than Version
+var ErrUnsupportedVersion = llo.ErrUnsupportedOffchainConfigVersion
+
+// Config is the offchain config of an LLO protocol instance. See
+// llo.OffchainConfig for the meaning of its fields.
+type Config = llo.OffchainConfig
+
+// Encode validates the config and encodes it for the configuration
+// contract
+func Encode(c Config) ([]byte, error) {
+	return c.Encode()
+}
+
+// Decode decodes and validates a config that was encoded with any version up
+// to Version
+func Decode(b []byte) (Config, error) {
+	return llo.DecodeOffchainConfig(b)
+}
+
+// Validate checks that the config is valid, i.e. that Encode will succeed
+func Validate(c Config) error {
+	return c.Validate()
+}
+
+// EncodedVersion returns the version of the schema that an encoded config
+// was encoded with, without decoding the rest of it. Configs of the
+// original schema, i.e. the empty config, are version 1.
+func EncodedVersion(b []byte) (uint32, error) {
+	pbuf := &llo.LLOOffchainConfigProto{}
+	if err := proto.Unmarshal(b, pbuf); err != nil {
+		return 0, fmt.Errorf("failed to decode offchain config: expected protobuf (got: 0x%x); %w", b, err)
+	}
+	if pbuf.Version == 0 {
+		return 1, nil
+	}
+	return pbuf.Version, nil
+}
@@ -0,0 +1,135 @@
+package offchainconfig
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/smartcontractkit/chainlink-data-streams/llo"
+)
+
+// The encoding is part of the API; changing it breaks configs that are
+// already set onchain
+func Test_Encode_Golden(t *testing.T) {
+	cfg := Config{
+		SkipUnchangedStreamValues:   true,
+		UnchangedStreamValueEpsilon: decimal.RequireFromString("0.0001"),
+		StreamStalenessBound:        5 * time.Second,
+		MaxObservationBytes:         100_000,
+		RetirementWindDownRounds:    10,
+		DegradedModeRounds:          5,
+		ReducedConfidenceReports:    true,
+		ChannelPriorityClasses:      true,
+	}
+	golden := "08011206302e303030311880e497d01220a08d06280a3005380140014807"
+
+	b, err := Encode(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, golden, hex.EncodeToString(b))
+
+	b, err = hex.DecodeString(golden)
+	require.NoError(t, err)
+	decoded, err := Decode(b)
+	require.NoError(t, err)
+	assert.True(t, cfg.UnchangedStreamValueEpsilon.Equal(decoded.UnchangedStreamValueEpsilon))
+	decoded.UnchangedStreamValueEpsilon = cfg.UnchangedStreamValueEpsilon
+	assert.Equal(t, cfg, decoded)
+
+	version, err := EncodedVersion(b)
+	require.NoError(t, err)
+	// configs that do not use settings added later keep an older version,
+	// so older nodes can decode them
+	assert.Equal(t, uint32(7), version)
+
+	cfg = Config{MaxReportsPerRound: 2}
+	golden = "48085002"
+	b, err = Encode(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, golden, hex.EncodeToString(b))
+	decoded, err = Decode(b)
+	require.NoError(t, err)
+	assert.Equal(t, cfg, decoded)
+	version, err = EncodedVersion(b)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(8), version)
+
+	cfg = Config{ValidAfterSecondsOverlapSeconds: 5, NewChannelGracePeriodSeconds: 60}
+	golden = "48095805603c"
+	b, err = Encode(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, golden, hex.EncodeToString(b))
+	decoded, err = Decode(b)
+	require.NoError(t, err)
+	assert.Equal(t, cfg, decoded)
+	version, err = EncodedVersion(b)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(9), version)
+}
+
+func Test_Encode_Zero(t *testing.T) {
+	b, err := Encode(Config{})
+	require.NoError(t, err)
+	assert.Empty(t, b)
+
+	// even if the epsilon is a different representation of zero
+	b, err = Encode(Config{UnchangedStreamValueEpsilon: decimal.RequireFromString("0.000")})
+	require.NoError(t, err)
+	assert.Empty(t, b)
+
+	cfg, err := Decode(nil)
+	require.NoError(t, err)
+	assert.Equal(t, Config{}, cfg)
+}
+
+func Test_Decode_Versions(t *testing.T) {
+	t.Run("configs of the original schema are version 1", func(t *testing.T) {
+		version, err := EncodedVersion(nil)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(1), version)
+
+		cfg, err := Decode(nil)
+		require.NoError(t, err)
+		assert.Equal(t, Config{}, cfg)
+	})
+	t.Run("versions that lack the settings of the config are rejected", func(t *testing.T) {
+		b, err := proto.Marshal(&llo.LLOOffchainConfigProto{RetirementWindDownRounds: 3})
+		require.NoError(t, err)
+		_, err = Decode(b)
+		assert.EqualError(t, err, "failed to decode offchain config: settings require version 5; got: 1")
+
+		b, err = proto.Marshal(&llo.LLOOffchainConfigProto{Version: 5, RetirementWindDownRounds: 3})
+		require.NoError(t, err)
+		cfg, err := Decode(b)
+		require.NoError(t, err)
+		assert.Equal(t, Config{RetirementWindDownRounds: 3}, cfg)
+	})
+	t.Run("newer versions are rejected", func(t *testing.T) {
+		b, err := proto.Marshal(&llo.LLOOffchainConfigProto{Version: Version + 1, RetirementWindDownRounds: 3})
+		require.NoError(t, err)
+
+		version, err := EncodedVersion(b)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(Version+1), version)
+
+		_, err = Decode(b)
+		assert.ErrorIs(t, err, ErrUnsupportedVersion)
+		assert.EqualError(t, err, "failed to decode offchain config: unsupported offchain config version; got: 11, max supported: 10")
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := EncodedVersion([]byte{0xff})
+		assert.ErrorContains(t, err, "failed to decode offchain config: expected protobuf")
+
+		_, err = Decode([]byte{0xff})
+		assert.ErrorContains(t, err, "failed to decode offchain config: expected protobuf")
+	})
+}
+
+func Test_Validate(t *testing.T) {
+	require.NoError(t, Validate(Config{}))
+	assert.EqualError(t, Validate(Config{StreamStalenessBound: -time.Second}), "StreamStalenessBound must not be negative; got: -1s")
+}
@@ -0,0 +1,27 @@
+package llo
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+// ReportSigningHash is the digest that non-EVM onchain keyrings sign. It
+// commits to the report and its context (config digest and sequence number)
+// so that a signature cannot be replayed for another protocol instance or
+// round.
+//
+// The encoding is <report><configDigest><seqNr> where seqNr is big-endian;
+// it is unambiguous because only the report has variable length.
+func ReportSigningHash(cd types.ConfigDigest, seqNr uint64, report []byte) [32]byte {
+	h := sha256.New()
+	h.Write(report)
+	h.Write(cd[:])
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], seqNr)
+	h.Write(b[:])
+	var out [32]byte
+	copy(out[:], h.Sum(nil))
+	return out
+}
@@ -0,0 +1,252 @@
+package llo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	bn256 "github.com/umbracle/go-eth-bn256"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+const (
+	blsG1Length = 64
+	blsG2Length = 128
+
+	// BLSSignatureLength is the length of an encoded G1 signature
+	BLSSignatureLength = blsG1Length
+	// BLSPublicKeyLength is the length of an encoded <G1><G2> public key
+	BLSPublicKeyLength = blsG1Length + blsG2Length
+)
+
+// Domain separation tags for hashing onto G1, in the format of the BLS
+// signature draft (draft-irtf-cfrg-bls-signature) for the proof of
+// possession scheme with signatures in G1
+var (
+	blsSignatureDST  = []byte("BLS_SIG_BN254G1_XMD:SHA-256_SVDW_RO_POP_")
+	blsPossessionDST = []byte("BLS_POP_BN254G1_XMD:SHA-256_SVDW_RO_POP_")
+)
+
+var _ ocr3types.OnchainKeyring[llotypes.ReportInfo] = &BLSOnchainKeyring{}
+
+// BLSOnchainKeyring attests reports with BLS signatures over BN254
+// (alt_bn128), so that the signatures of all oracles can be aggregated into
+// one and verified with the EVM pairing precompile (see
+// EVMBLSPayloadPacker).
+//
+// Signatures are points in G1 over EVMReportSigningHash hashed onto the
+// curve as specified by RFC 9380 (see hashToG1). The public key is the secret
+// key times both
+// generators, encoded as <G1><G2>: verifiers can cheaply aggregate the G1
+// keys onchain, and need the G2 keys to check signatures.
+//
+// Aggregation is only secure against rogue key attacks if every public key
+// is checked with VerifyBLSPublicKey before it is accepted.
+type BLSOnchainKeyring struct {
+	privateKey *big.Int
+	publicKey  []byte
+}
+
+func NewBLSOnchainKeyring(privateKey *big.Int) (*BLSOnchainKeyring, error) {
+	if privateKey == nil || privateKey.Sign() <= 0 || privateKey.Cmp(bn256.Order) >= 0 {
+		return nil, fmt.Errorf("invalid BLS private key; must be in range [1, n)")
+	}
+	pk := make([]byte, 0, BLSPublicKeyLength)
+	pk = append(pk, new(bn256.G1).ScalarBaseMult(privateKey).Marshal()...)
+	pk = append(pk, new(bn256.G2).ScalarBaseMult(privateKey).Marshal()...)
+	return &BLSOnchainKeyring{privateKey, pk}, nil
+}
+
+// GenerateBLSPrivateKey returns a private key suitable for
+// NewBLSOnchainKeyring
+func GenerateBLSPrivateKey(r io.Reader) (*big.Int, error) {
+	k, _, err := bn256.RandomG1(r)
+	return k, err
+}
+
+func (k *BLSOnchainKeyring) PublicKey() types.OnchainPublicKey {
+	return types.OnchainPublicKey(k.publicKey)
+}
+
+func (k *BLSOnchainKeyring) Sign(cd types.ConfigDigest, seqNr uint64, r ocr3types.ReportWithInfo[llotypes.ReportInfo]) ([]byte, error) {
+	h, err := blsMessagePoint(cd, seqNr, r.Report)
+	if err != nil {
+		return nil, err
+	}
+	return new(bn256.G1).ScalarMult(h, k.privateKey).Marshal(), nil
+}
+
+func (k *BLSOnchainKeyring) Verify(pk types.OnchainPublicKey, cd types.ConfigDigest, seqNr uint64, r ocr3types.ReportWithInfo[llotypes.ReportInfo], signature []byte) bool {
+	return VerifyBLSReportSignature(pk, cd, seqNr, r.Report, signature)
+}
+
+func (k *BLSOnchainKeyring) MaxSignatureLength() int {
+	return BLSSignatureLength
+}
+
+// ProofOfPossession signs the keyring's own public key, proving knowledge of
+// the private key. See VerifyBLSPublicKey.
+func (k *BLSOnchainKeyring) ProofOfPossession() []byte {
+	return new(bn256.G1).ScalarMult(blsPossessionPoint(k.publicKey), k.privateKey).Marshal()
+}
+
+// VerifyBLSPublicKey checks that a public key produced by BLSOnchainKeyring
+// is well-formed, that its G1 and G2 halves belong to the same private key,
+// and that proof is a valid proof of possession for it.
+func VerifyBLSPublicKey(pk types.OnchainPublicKey, proof []byte) bool {
+	pk1, pk2, ok := decodeBLSPublicKey(pk)
+	if !ok {
+		return false
+	}
+	sig, ok := unmarshalG1(proof)
+	if !ok {
+		return false
+	}
+	// e(pk1, -g2)·e(g1, pk2) = 1
+	g1 := new(bn256.G1).ScalarBaseMult(big.NewInt(1))
+	if !blsPairingCheck(pk1, g1, pk2) {
+		return false
+	}
+	return blsVerify(pk2, blsPossessionPoint(pk), sig)
+}
+
+// VerifyBLSReportSignature checks a signature produced by BLSOnchainKeyring.
+// It returns false for malformed keys or signatures.
+func VerifyBLSReportSignature(pk types.OnchainPublicKey, cd types.ConfigDigest, seqNr uint64, report []byte, signature []byte) bool {
+	_, pk2, ok := decodeBLSPublicKey(pk)
+	if !ok {
+		return false
+	}
+	sig, ok := unmarshalG1(signature)
+	if !ok {
+		return false
+	}
+	h, err := blsMessagePoint(cd, seqNr, report)
+	if err != nil {
+		return false
+	}
+	return blsVerify(pk2, h, sig)
+}
+
+// AggregateBLSSignatures sums signatures produced by BLSOnchainKeyring into
+// a single signature of the same length
+func AggregateBLSSignatures(signatures [][]byte) ([]byte, error) {
+	if len(signatures) == 0 {
+		return nil, errors.New("no signatures to aggregate")
+	}
+	agg := new(bn256.G1).ScalarBaseMult(big.NewInt(0))
+	for i, s := range signatures {
+		sig, ok := unmarshalG1(s)
+		if !ok {
+			return nil, fmt.Errorf("invalid BLS signature at index %d", i)
+		}
+		agg.Add(agg, sig)
+	}
+	return agg.Marshal(), nil
+}
+
+// AggregateBLSPublicKeys sums public keys produced by BLSOnchainKeyring into
+// a single public key of the same length. An aggregate signature by the same
+// keys verifies against it with VerifyBLSReportSignature.
+func AggregateBLSPublicKeys(pks []types.OnchainPublicKey) (types.OnchainPublicKey, error) {
+	if len(pks) == 0 {
+		return nil, errors.New("no public keys to aggregate")
+	}
+	agg1 := new(bn256.G1).ScalarBaseMult(big.NewInt(0))
+	agg2 := new(bn256.G2).ScalarBaseMult(big.NewInt(0))
+	for i, pk := range pks {
+		pk1, pk2, ok := decodeBLSPublicKey(pk)
+		if !ok {
+			return nil, fmt.Errorf("invalid BLS public key at index %d", i)
+		}
+		agg1.Add(agg1, pk1)
+		agg2.Add(agg2, pk2)
+	}
+	out := make([]byte, 0, BLSPublicKeyLength)
+	out = append(out, agg1.Marshal()...)
+	out = append(out, agg2.Marshal()...)
+	return out, nil
+}
+
+func decodeBLSPublicKey(pk types.OnchainPublicKey) (pk1 *bn256.G1, pk2 *bn256.G2, ok bool) {
+	if len(pk) != BLSPublicKeyLength {
+		return nil, nil, false
+	}
+	if pk1, ok = unmarshalG1(pk[:blsG1Length]); !ok {
+		return nil, nil, false
+	}
+	if pk2, ok = unmarshalG2(pk[blsG1Length:]); !ok {
+		return nil, nil, false
+	}
+	return pk1, pk2, true
+}
+
+// blsVerify checks e(sig, -g2)·e(h, pk2) = 1
+func blsVerify(pk2 *bn256.G2, h, sig *bn256.G1) bool {
+	return blsPairingCheck(sig, h, pk2)
+}
+
+// blsPairingCheck checks e(a, -g2)·e(b, q) = 1, the form of check that
+// verifier contracts make with the ecPairing precompile. It is computed as
+// e(-a, g2)·e(b, q) = 1, since negating a point in G2 leaves it unusable for
+// pairings in the bn256 package.
+func blsPairingCheck(a, b *bn256.G1, q *bn256.G2) bool {
+	g2 := new(bn256.G2).ScalarBaseMult(big.NewInt(1))
+	return bn256.PairingCheck([]*bn256.G1{new(bn256.G1).Neg(a), b}, []*bn256.G2{g2, q})
+}
+
+func blsMessagePoint(cd types.ConfigDigest, seqNr uint64, report []byte) (*bn256.G1, error) {
+	h, err := EVMReportSigningHash(cd, seqNr, report)
+	if err != nil {
+		return nil, err
+	}
+	return hashToG1(h[:], blsSignatureDST), nil
+}
+
+func blsPossessionPoint(pk []byte) *bn256.G1 {
+	return hashToG1(pk, blsPossessionDST)
+}
+
+// unmarshalG1 decodes a G1 point encoded as for the EIP-196 precompiles
+func unmarshalG1(b []byte) (*bn256.G1, bool) {
+	if len(b) != blsG1Length {
+		return nil, false
+	}
+	g1 := new(bn256.G1)
+	if _, err := g1.Unmarshal(b); err != nil {
+		return nil, false
+	}
+	return g1, true
+}
+
+// unmarshalG2 decodes a G2 point encoded as for the EIP-197 precompile.
+// Unlike G1, the twist has points outside of the order-n subgroup, which are
+// rejected.
+func unmarshalG2(b []byte) (*bn256.G2, bool) {
+	if len(b) != blsG2Length {
+		return nil, false
+	}
+	g2 := new(bn256.G2)
+	if _, err := g2.Unmarshal(b); err != nil {
+		return nil, false
+	}
+	// The point at infinity marshals to zeros, but not necessarily to
+	// blsG2Length of them
+	if !isZero(new(bn256.G2).ScalarMult(g2, bn256.Order).Marshal()) {
+		return nil, false
+	}
+	return g2, true
+}
+
+func isZero(b []byte) bool {
+	for _, c := range b {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,136 @@
+package llo
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+
+	bn256 "github.com/umbracle/go-eth-bn256"
+)
+
+func newTestBLSOnchainKeyring(t *testing.T) *BLSOnchainKeyring {
+	k, err := GenerateBLSPrivateKey(rand.Reader)
+	require.NoError(t, err)
+	kr, err := NewBLSOnchainKeyring(k)
+	require.NoError(t, err)
+	return kr
+}
+
+func Test_BLSOnchainKeyring(t *testing.T) {
+	kr := newTestBLSOnchainKeyring(t)
+
+	cd := types.ConfigDigest{1}
+	rwi := ocr3types.ReportWithInfo[llotypes.ReportInfo]{Report: []byte("report")}
+
+	t.Run("sign and verify", func(t *testing.T) {
+		sig, err := kr.Sign(cd, 42, rwi)
+		require.NoError(t, err)
+		assert.Len(t, sig, kr.MaxSignatureLength())
+		assert.Len(t, kr.PublicKey(), BLSPublicKeyLength)
+
+		assert.True(t, kr.Verify(kr.PublicKey(), cd, 42, rwi, sig))
+		assert.True(t, VerifyBLSReportSignature(kr.PublicKey(), cd, 42, rwi.Report, sig))
+
+		assert.False(t, kr.Verify(kr.PublicKey(), types.ConfigDigest{2}, 42, rwi, sig), "wrong config digest")
+		assert.False(t, kr.Verify(kr.PublicKey(), cd, 43, rwi, sig), "wrong seqNr")
+		assert.False(t, kr.Verify(kr.PublicKey(), cd, 42, ocr3types.ReportWithInfo[llotypes.ReportInfo]{Report: []byte("other")}, sig), "wrong report")
+
+		other := newTestBLSOnchainKeyring(t)
+		assert.False(t, kr.Verify(other.PublicKey(), cd, 42, rwi, sig), "wrong public key")
+	})
+	t.Run("signs over the EVM report signing hash", func(t *testing.T) {
+		sig, err := kr.Sign(cd, 42, rwi)
+		require.NoError(t, err)
+		h, err := EVMReportSigningHash(cd, 42, rwi.Report)
+		require.NoError(t, err)
+		expected := new(bn256.G1).ScalarMult(hashToG1(h[:], blsSignatureDST), kr.privateKey)
+		assert.Equal(t, expected.Marshal(), sig)
+	})
+	t.Run("rejects malformed inputs", func(t *testing.T) {
+		sig, err := kr.Sign(cd, 42, rwi)
+		require.NoError(t, err)
+		assert.False(t, kr.Verify(nil, cd, 42, rwi, sig))
+		assert.False(t, kr.Verify(kr.PublicKey()[1:], cd, 42, rwi, sig))
+		assert.False(t, kr.Verify(kr.PublicKey(), cd, 42, rwi, nil))
+		assert.False(t, kr.Verify(kr.PublicKey(), cd, 42, rwi, sig[1:]))
+		assert.False(t, kr.Verify(kr.PublicKey(), cd, 42, rwi, append(sig, 0)), "trailing bytes")
+		assert.False(t, kr.Verify(append(kr.PublicKey(), 0), cd, 42, rwi, sig), "trailing bytes")
+
+		garbage := make([]byte, BLSSignatureLength)
+		garbage[0] = 1
+		assert.False(t, kr.Verify(kr.PublicKey(), cd, 42, rwi, garbage))
+	})
+	t.Run("invalid private key", func(t *testing.T) {
+		_, err := NewBLSOnchainKeyring(big.NewInt(0))
+		assert.EqualError(t, err, "invalid BLS private key; must be in range [1, n)")
+		_, err = NewBLSOnchainKeyring(bn256.Order)
+		assert.EqualError(t, err, "invalid BLS private key; must be in range [1, n)")
+		_, err = NewBLSOnchainKeyring(nil)
+		assert.EqualError(t, err, "invalid BLS private key; must be in range [1, n)")
+	})
+}
+
+func Test_VerifyBLSPublicKey(t *testing.T) {
+	kr := newTestBLSOnchainKeyring(t)
+	other := newTestBLSOnchainKeyring(t)
+
+	assert.True(t, VerifyBLSPublicKey(kr.PublicKey(), kr.ProofOfPossession()))
+	assert.False(t, VerifyBLSPublicKey(kr.PublicKey(), other.ProofOfPossession()), "proof for another key")
+	assert.False(t, VerifyBLSPublicKey(kr.PublicKey(), nil))
+	assert.False(t, VerifyBLSPublicKey(kr.PublicKey()[1:], kr.ProofOfPossession()))
+
+	t.Run("rejects mismatched G1 and G2 keys", func(t *testing.T) {
+		pk := append(append([]byte{}, other.PublicKey()[:blsG1Length]...), kr.PublicKey()[blsG1Length:]...)
+		assert.False(t, VerifyBLSPublicKey(pk, kr.ProofOfPossession()))
+	})
+}
+
+func Test_AggregateBLS(t *testing.T) {
+	cd := types.ConfigDigest{1}
+	report := []byte("report")
+	rwi := ocr3types.ReportWithInfo[llotypes.ReportInfo]{Report: report}
+
+	var sigs [][]byte
+	var pks []types.OnchainPublicKey
+	for i := 0; i < 4; i++ {
+		kr := newTestBLSOnchainKeyring(t)
+		sig, err := kr.Sign(cd, 42, rwi)
+		require.NoError(t, err)
+		sigs = append(sigs, sig)
+		pks = append(pks, kr.PublicKey())
+	}
+
+	agg, err := AggregateBLSSignatures(sigs)
+	require.NoError(t, err)
+	assert.Len(t, agg, BLSSignatureLength)
+	apk, err := AggregateBLSPublicKeys(pks)
+	require.NoError(t, err)
+	assert.Len(t, apk, BLSPublicKeyLength)
+
+	assert.True(t, VerifyBLSReportSignature(apk, cd, 42, report, agg))
+
+	t.Run("fails if a signer is missing", func(t *testing.T) {
+		agg, err := AggregateBLSSignatures(sigs[1:])
+		require.NoError(t, err)
+		assert.False(t, VerifyBLSReportSignature(apk, cd, 42, report, agg))
+	})
+	t.Run("rejects invalid inputs", func(t *testing.T) {
+		_, err := AggregateBLSSignatures(nil)
+		assert.EqualError(t, err, "no signatures to aggregate")
+		_, err = AggregateBLSSignatures([][]byte{sigs[0], {1}})
+		assert.EqualError(t, err, "invalid BLS signature at index 1")
+
+		_, err = AggregateBLSPublicKeys(nil)
+		assert.EqualError(t, err, "no public keys to aggregate")
+		_, err = AggregateBLSPublicKeys([]types.OnchainPublicKey{pks[0], {1}})
+		assert.EqualError(t, err, "invalid BLS public key at index 1")
+	})
+}
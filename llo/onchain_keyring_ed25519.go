@@ -0,0 +1,56 @@
+package llo
+
+import (
+	"crypto/ed25519"
+	"fmt"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+var _ ocr3types.OnchainKeyring[llotypes.ReportInfo] = &Ed25519OnchainKeyring{}
+
+// Ed25519OnchainKeyring attests reports for chains that verify ed25519
+// signatures, e.g. Solana.
+//
+// Signatures are over ReportSigningHash and are 64 bytes long; the public
+// key is the raw 32-byte ed25519 public key.
+type Ed25519OnchainKeyring struct {
+	privateKey ed25519.PrivateKey
+}
+
+func NewEd25519OnchainKeyring(privateKey ed25519.PrivateKey) (*Ed25519OnchainKeyring, error) {
+	if len(privateKey) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("invalid ed25519 private key length; got: %d, expected: %d", len(privateKey), ed25519.PrivateKeySize)
+	}
+	return &Ed25519OnchainKeyring{privateKey}, nil
+}
+
+func (k *Ed25519OnchainKeyring) PublicKey() types.OnchainPublicKey {
+	return types.OnchainPublicKey(k.privateKey.Public().(ed25519.PublicKey))
+}
+
+func (k *Ed25519OnchainKeyring) Sign(cd types.ConfigDigest, seqNr uint64, r ocr3types.ReportWithInfo[llotypes.ReportInfo]) ([]byte, error) {
+	h := ReportSigningHash(cd, seqNr, r.Report)
+	return ed25519.Sign(k.privateKey, h[:]), nil
+}
+
+func (k *Ed25519OnchainKeyring) Verify(pk types.OnchainPublicKey, cd types.ConfigDigest, seqNr uint64, r ocr3types.ReportWithInfo[llotypes.ReportInfo], signature []byte) bool {
+	return VerifyEd25519ReportSignature(pk, cd, seqNr, r.Report, signature)
+}
+
+func (k *Ed25519OnchainKeyring) MaxSignatureLength() int {
+	return ed25519.SignatureSize
+}
+
+// VerifyEd25519ReportSignature checks a signature produced by
+// Ed25519OnchainKeyring. It returns false for malformed keys or signatures.
+func VerifyEd25519ReportSignature(pk types.OnchainPublicKey, cd types.ConfigDigest, seqNr uint64, report []byte, signature []byte) bool {
+	if len(pk) != ed25519.PublicKeySize || len(signature) != ed25519.SignatureSize {
+		return false
+	}
+	h := ReportSigningHash(cd, seqNr, report)
+	return ed25519.Verify(ed25519.PublicKey(pk), h[:], signature)
+}
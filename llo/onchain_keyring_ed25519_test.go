@@ -0,0 +1,54 @@
+package llo
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+func Test_Ed25519OnchainKeyring(t *testing.T) {
+	seed := make([]byte, ed25519.SeedSize)
+	seed[0] = 1
+	kr, err := NewEd25519OnchainKeyring(ed25519.NewKeyFromSeed(seed))
+	require.NoError(t, err)
+
+	cd := types.ConfigDigest{1}
+	rwi := ocr3types.ReportWithInfo[llotypes.ReportInfo]{Report: []byte("report")}
+
+	t.Run("sign and verify", func(t *testing.T) {
+		sig, err := kr.Sign(cd, 42, rwi)
+		require.NoError(t, err)
+		assert.Len(t, sig, kr.MaxSignatureLength())
+		assert.Len(t, kr.PublicKey(), ed25519.PublicKeySize)
+
+		assert.True(t, kr.Verify(kr.PublicKey(), cd, 42, rwi, sig))
+		assert.True(t, VerifyEd25519ReportSignature(kr.PublicKey(), cd, 42, rwi.Report, sig))
+
+		assert.False(t, kr.Verify(kr.PublicKey(), types.ConfigDigest{2}, 42, rwi, sig), "wrong config digest")
+		assert.False(t, kr.Verify(kr.PublicKey(), cd, 43, rwi, sig), "wrong seqNr")
+		assert.False(t, kr.Verify(kr.PublicKey(), cd, 42, ocr3types.ReportWithInfo[llotypes.ReportInfo]{Report: []byte("other")}, sig), "wrong report")
+
+		other, err := NewEd25519OnchainKeyring(ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize)))
+		require.NoError(t, err)
+		assert.False(t, kr.Verify(other.PublicKey(), cd, 42, rwi, sig), "wrong public key")
+	})
+	t.Run("rejects malformed inputs", func(t *testing.T) {
+		sig, err := kr.Sign(cd, 42, rwi)
+		require.NoError(t, err)
+		assert.False(t, kr.Verify(nil, cd, 42, rwi, sig))
+		assert.False(t, kr.Verify(kr.PublicKey()[1:], cd, 42, rwi, sig))
+		assert.False(t, kr.Verify(kr.PublicKey(), cd, 42, rwi, nil))
+		assert.False(t, kr.Verify(kr.PublicKey(), cd, 42, rwi, sig[1:]))
+	})
+	t.Run("invalid private key", func(t *testing.T) {
+		_, err := NewEd25519OnchainKeyring(seed)
+		assert.EqualError(t, err, "invalid ed25519 private key length; got: 32, expected: 64")
+	})
+}
@@ -0,0 +1,258 @@
+package llo
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+// STARK curve parameters, as used by Starknet for ECDSA signatures:
+// y^2 = x^3 + alpha*x + beta (mod p)
+var (
+	starkP     = mustParseHex("800000000000011000000000000000000000000000000000000000000000001")
+	starkAlpha = big.NewInt(1)
+	starkBeta  = mustParseHex("6f21413efbe40de150e596d72f7a8c5609ad26c15c915c1f4cdfcb99cee9e89")
+	starkN     = mustParseHex("800000000000010ffffffffffffffffb781126dcae7b2321e66a241adc64d2f")
+	starkG     = starkPoint{
+		mustParseHex("1ef15c18599971b7beced415a40f0c7deacfd9b0d1819e03d723d8bc943cfca"),
+		mustParseHex("5668060aa49730b7be4801df46ec62de53ecd11abe43a32873000c36e8dc1f"),
+	}
+
+	// r, s^-1 and the message hash must all be smaller than 2^251
+	starkMaxElement = new(big.Int).Lsh(big.NewInt(1), 251)
+	// Message hashes are masked to 250 bits, as with starknet_keccak
+	starkHashMask = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 250), big.NewInt(1))
+)
+
+const (
+	// starkElementLength is the length of an encoded field element
+	starkElementLength = 32
+	// StarkSignatureLength is the length of an encoded <r><s> signature
+	StarkSignatureLength = 2 * starkElementLength
+)
+
+var _ ocr3types.OnchainKeyring[llotypes.ReportInfo] = &StarkOnchainKeyring{}
+
+// StarkOnchainKeyring attests reports for Starknet, using ECDSA over the
+// STARK curve.
+//
+// Signatures are over ReportSigningHash (masked to 250 bits) and are encoded
+// as <r><s>, each as a 32-byte big-endian integer. Following Starknet
+// convention, the public key is the 32-byte x coordinate of the public point.
+type StarkOnchainKeyring struct {
+	privateKey *big.Int
+	publicKey  starkPoint
+	// rand is the source of signing nonces
+	rand io.Reader
+}
+
+func NewStarkOnchainKeyring(privateKey *big.Int) (*StarkOnchainKeyring, error) {
+	if privateKey == nil || privateKey.Sign() <= 0 || privateKey.Cmp(starkN) >= 0 {
+		return nil, fmt.Errorf("invalid STARK private key; must be in range [1, n)")
+	}
+	return &StarkOnchainKeyring{privateKey, starkG.mul(privateKey), rand.Reader}, nil
+}
+
+// GenerateStarkPrivateKey returns a private key suitable for
+// NewStarkOnchainKeyring
+func GenerateStarkPrivateKey(r io.Reader) (*big.Int, error) {
+	return randStarkScalar(r)
+}
+
+func (k *StarkOnchainKeyring) PublicKey() types.OnchainPublicKey {
+	return types.OnchainPublicKey(k.publicKey.x.FillBytes(make([]byte, starkElementLength)))
+}
+
+func (k *StarkOnchainKeyring) Sign(cd types.ConfigDigest, seqNr uint64, r ocr3types.ReportWithInfo[llotypes.ReportInfo]) ([]byte, error) {
+	z := starkMessageHash(cd, seqNr, r.Report)
+	for {
+		nonce, err := randStarkScalar(k.rand)
+		if err != nil {
+			return nil, err
+		}
+		sigR, sigS, ok := starkSign(k.privateKey, z, nonce)
+		if !ok {
+			// Negligible probability; try again with another nonce
+			continue
+		}
+		sig := make([]byte, StarkSignatureLength)
+		sigR.FillBytes(sig[:starkElementLength])
+		sigS.FillBytes(sig[starkElementLength:])
+		return sig, nil
+	}
+}
+
+func (k *StarkOnchainKeyring) Verify(pk types.OnchainPublicKey, cd types.ConfigDigest, seqNr uint64, r ocr3types.ReportWithInfo[llotypes.ReportInfo], signature []byte) bool {
+	return VerifyStarkReportSignature(pk, cd, seqNr, r.Report, signature)
+}
+
+func (k *StarkOnchainKeyring) MaxSignatureLength() int {
+	return StarkSignatureLength
+}
+
+// VerifyStarkReportSignature checks a signature produced by
+// StarkOnchainKeyring. It returns false for malformed keys or signatures.
+func VerifyStarkReportSignature(pk types.OnchainPublicKey, cd types.ConfigDigest, seqNr uint64, report []byte, signature []byte) bool {
+	if len(pk) != starkElementLength || len(signature) != StarkSignatureLength {
+		return false
+	}
+	x := new(big.Int).SetBytes(pk)
+	pub, ok := starkPointFromX(x)
+	if !ok {
+		return false
+	}
+	r := new(big.Int).SetBytes(signature[:starkElementLength])
+	s := new(big.Int).SetBytes(signature[starkElementLength:])
+	z := starkMessageHash(cd, seqNr, report)
+	// The public key only encodes x, so either y may be the right one
+	return starkVerify(pub, z, r, s) || starkVerify(pub.neg(), z, r, s)
+}
+
+func starkMessageHash(cd types.ConfigDigest, seqNr uint64, report []byte) *big.Int {
+	h := ReportSigningHash(cd, seqNr, report)
+	return new(big.Int).And(new(big.Int).SetBytes(h[:]), starkHashMask)
+}
+
+// starkSign returns false if the nonce yields an invalid signature, in which
+// case the caller should retry with another one
+func starkSign(privateKey, z, nonce *big.Int) (r, s *big.Int, ok bool) {
+	r = starkG.mul(nonce).x
+	if r.Sign() == 0 || r.Cmp(starkMaxElement) >= 0 {
+		return nil, nil, false
+	}
+	// s = k^-1 * (z + r*d) mod n
+	zrd := new(big.Int).Mul(r, privateKey)
+	zrd.Add(zrd, z).Mod(zrd, starkN)
+	if zrd.Sign() == 0 {
+		return nil, nil, false
+	}
+	w := new(big.Int).ModInverse(zrd, starkN)
+	w.Mul(w, nonce).Mod(w, starkN)
+	if w.Sign() == 0 || w.Cmp(starkMaxElement) >= 0 {
+		return nil, nil, false
+	}
+	return r, new(big.Int).ModInverse(w, starkN), true
+}
+
+func starkVerify(pub starkPoint, z, r, s *big.Int) bool {
+	if z.Sign() < 0 || z.Cmp(starkMaxElement) >= 0 {
+		return false
+	}
+	if r.Sign() <= 0 || r.Cmp(starkMaxElement) >= 0 {
+		return false
+	}
+	if s.Sign() <= 0 || s.Cmp(starkN) >= 0 {
+		return false
+	}
+	w := new(big.Int).ModInverse(s, starkN)
+	if w == nil || w.Cmp(starkMaxElement) >= 0 {
+		return false
+	}
+	u1 := new(big.Int).Mul(z, w)
+	u1.Mod(u1, starkN)
+	u2 := new(big.Int).Mul(r, w)
+	u2.Mod(u2, starkN)
+	p := starkG.mul(u1).add(pub.mul(u2))
+	return !p.isInfinity() && p.x.Cmp(r) == 0
+}
+
+func randStarkScalar(r io.Reader) (*big.Int, error) {
+	max := new(big.Int).Sub(starkN, big.NewInt(1))
+	k, err := rand.Int(r, max)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate random scalar: %w", err)
+	}
+	// [0, n-1) -> [1, n)
+	return k.Add(k, big.NewInt(1)), nil
+}
+
+// starkPoint is a point on the STARK curve in affine coordinates; the point
+// at infinity has nil coordinates
+type starkPoint struct {
+	x, y *big.Int
+}
+
+func (p starkPoint) isInfinity() bool { return p.x == nil }
+
+// starkPointFromX returns one of the two points with the given x coordinate
+func starkPointFromX(x *big.Int) (starkPoint, bool) {
+	if x.Cmp(starkP) >= 0 {
+		return starkPoint{}, false
+	}
+	// y^2 = x^3 + alpha*x + beta
+	y2 := new(big.Int).Exp(x, big.NewInt(3), starkP)
+	y2.Add(y2, new(big.Int).Mul(starkAlpha, x))
+	y2.Add(y2, starkBeta).Mod(y2, starkP)
+	y := new(big.Int).ModSqrt(y2, starkP)
+	if y == nil {
+		return starkPoint{}, false
+	}
+	return starkPoint{new(big.Int).Set(x), y}, true
+}
+
+func (p starkPoint) neg() starkPoint {
+	if p.isInfinity() {
+		return p
+	}
+	return starkPoint{p.x, new(big.Int).Sub(starkP, p.y)}
+}
+
+func (p starkPoint) add(q starkPoint) starkPoint {
+	if p.isInfinity() {
+		return q
+	}
+	if q.isInfinity() {
+		return p
+	}
+	var l *big.Int
+	if p.x.Cmp(q.x) == 0 {
+		sum := new(big.Int).Add(p.y, q.y)
+		if sum.Mod(sum, starkP).Sign() == 0 {
+			return starkPoint{}
+		}
+		// l = (3x^2 + alpha) / 2y
+		num := new(big.Int).Mul(p.x, p.x)
+		num.Mul(num, big.NewInt(3)).Add(num, starkAlpha)
+		den := new(big.Int).Lsh(p.y, 1)
+		l = num.Mul(num, den.ModInverse(den, starkP))
+	} else {
+		// l = (qy - py) / (qx - px)
+		num := new(big.Int).Sub(q.y, p.y)
+		den := new(big.Int).Sub(q.x, p.x)
+		den.Mod(den, starkP)
+		l = num.Mul(num, den.ModInverse(den, starkP))
+	}
+	l.Mod(l, starkP)
+	x := new(big.Int).Mul(l, l)
+	x.Sub(x, p.x).Sub(x, q.x).Mod(x, starkP)
+	y := new(big.Int).Sub(p.x, x)
+	y.Mul(y, l).Sub(y, p.y).Mod(y, starkP)
+	return starkPoint{x, y}
+}
+
+// mul computes k*p by double-and-add. Note that it is not constant-time.
+func (p starkPoint) mul(k *big.Int) starkPoint {
+	var result starkPoint
+	addend := p
+	for i := 0; i < k.BitLen(); i++ {
+		if k.Bit(i) == 1 {
+			result = result.add(addend)
+		}
+		addend = addend.add(addend)
+	}
+	return result
+}
+
+func mustParseHex(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("invalid hex: " + s)
+	}
+	return n
+}
@@ -0,0 +1,90 @@
+package llo
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+func Test_starkCurve(t *testing.T) {
+	g, ok := starkPointFromX(starkG.x)
+	require.True(t, ok, "generator must be on the curve")
+	assert.True(t, g.y.Cmp(starkG.y) == 0 || g.neg().y.Cmp(starkG.y) == 0)
+
+	assert.True(t, starkG.mul(starkN).isInfinity(), "generator must have order n")
+	assert.Equal(t, starkG, starkG.mul(new(big.Int).Add(starkN, big.NewInt(1))))
+	assert.True(t, starkG.add(starkG.neg()).isInfinity())
+}
+
+func Test_StarkOnchainKeyring(t *testing.T) {
+	// Private/public key pair from StarkWare's test vectors
+	privateKey := mustParseHex("3c1e9550e66958296d11b60f8e8e7a7ad990d07fa65d5f7652c4a6c87d4e3cc")
+	kr, err := NewStarkOnchainKeyring(privateKey)
+	require.NoError(t, err)
+	assert.Equal(t, "077a3b314db07c45076d11f62b6f9e748a39790441823307743cf00d6597ea43", hex.EncodeToString(kr.PublicKey()))
+
+	cd := types.ConfigDigest{1}
+	rwi := ocr3types.ReportWithInfo[llotypes.ReportInfo]{Report: []byte("report")}
+
+	t.Run("known signature", func(t *testing.T) {
+		nonce := mustParseHex("2a7f9b7a1f4c0e5d3b2a19081726354453627180a9b8c7d6e5f4031221304050")
+		r, s, ok := starkSign(privateKey, starkMessageHash(cd, 42, rwi.Report), nonce)
+		require.True(t, ok)
+		assert.Equal(t, "5ff66f383d28a154fc71a2787add48ac56878f2441d24cb72a12f4588bf68a6", r.Text(16))
+		assert.Equal(t, "69b4523c3e866c8f72b86351a67d4405e2e16fae12515607291f097e11633e9", s.Text(16))
+
+		sig := append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+		assert.True(t, VerifyStarkReportSignature(kr.PublicKey(), cd, 42, rwi.Report, sig))
+	})
+	t.Run("sign and verify", func(t *testing.T) {
+		sig, err := kr.Sign(cd, 42, rwi)
+		require.NoError(t, err)
+		assert.Len(t, sig, kr.MaxSignatureLength())
+
+		assert.True(t, kr.Verify(kr.PublicKey(), cd, 42, rwi, sig))
+		assert.False(t, kr.Verify(kr.PublicKey(), types.ConfigDigest{2}, 42, rwi, sig), "wrong config digest")
+		assert.False(t, kr.Verify(kr.PublicKey(), cd, 43, rwi, sig), "wrong seqNr")
+		assert.False(t, kr.Verify(kr.PublicKey(), cd, 42, ocr3types.ReportWithInfo[llotypes.ReportInfo]{Report: []byte("other")}, sig), "wrong report")
+
+		otherKey, err := GenerateStarkPrivateKey(rand.Reader)
+		require.NoError(t, err)
+		other, err := NewStarkOnchainKeyring(otherKey)
+		require.NoError(t, err)
+		assert.False(t, kr.Verify(other.PublicKey(), cd, 42, rwi, sig), "wrong public key")
+	})
+	t.Run("rejects malformed inputs", func(t *testing.T) {
+		sig, err := kr.Sign(cd, 42, rwi)
+		require.NoError(t, err)
+		assert.False(t, kr.Verify(nil, cd, 42, rwi, sig))
+		assert.False(t, kr.Verify(kr.PublicKey()[1:], cd, 42, rwi, sig))
+		assert.False(t, kr.Verify(kr.PublicKey(), cd, 42, rwi, sig[1:]))
+		assert.False(t, kr.Verify(kr.PublicKey(), cd, 42, rwi, make([]byte, StarkSignatureLength)), "zero signature")
+
+		// x coordinate larger than the field
+		pk := make([]byte, 32)
+		for i := range pk {
+			pk[i] = 0xff
+		}
+		assert.False(t, kr.Verify(pk, cd, 42, rwi, sig))
+
+		// s >= n
+		tampered := append([]byte{}, sig...)
+		starkN.FillBytes(tampered[32:])
+		assert.False(t, kr.Verify(kr.PublicKey(), cd, 42, rwi, tampered))
+	})
+	t.Run("invalid private key", func(t *testing.T) {
+		for _, k := range []*big.Int{nil, big.NewInt(0), big.NewInt(-1), starkN} {
+			_, err := NewStarkOnchainKeyring(k)
+			assert.EqualError(t, err, "invalid STARK private key; must be in range [1, n)")
+		}
+	})
+}
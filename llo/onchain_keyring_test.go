@@ -0,0 +1,19 @@
+package llo
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+func Test_ReportSigningHash(t *testing.T) {
+	h := ReportSigningHash(types.ConfigDigest{1}, 42, []byte("report"))
+	assert.Equal(t, "2625c11575e775050f5b0effe281ff0c5af7d96cde3458f30c81eee10d5d2de8", hex.EncodeToString(h[:]))
+
+	assert.NotEqual(t, h, ReportSigningHash(types.ConfigDigest{2}, 42, []byte("report")), "must commit to config digest")
+	assert.NotEqual(t, h, ReportSigningHash(types.ConfigDigest{1}, 43, []byte("report")), "must commit to seqNr")
+	assert.NotEqual(t, h, ReportSigningHash(types.ConfigDigest{1}, 42, []byte("report2")), "must commit to report")
+}
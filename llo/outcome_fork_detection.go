@@ -0,0 +1,39 @@
+package llo
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+)
+
+// previousOutcomeHash hashes the canonical encoding of the decoded previous
+// outcome. The raw outcome is agreed on by consensus, so hashing it would
+// never differ between oracles; re-encoding the decoded outcome catches
+// oracles that decode it differently, e.g. because of a bug or a version
+// skew, before they silently diverge in what they observe and report.
+func (p *Plugin) previousOutcomeHash(previousOutcome Outcome) ([32]byte, error) {
+	encoded, err := p.OutcomeCodec.Encode(previousOutcome)
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("failed to encode previous outcome: %w", err)
+	}
+	return sha256.Sum256(encoded), nil
+}
+
+// checkPreviousOutcomeHashes flags the observers whose previous outcome hash
+// differs from this oracle's. Observers that did not set a hash, e.g. because
+// they run an older version, are not counted. A few divergent observers may
+// be faulty; more than f means that this oracle, or the network, is in a
+// state that the protocol does not tolerate.
+func (p *Plugin) checkPreviousOutcomeHashes(seqNr uint64, expected [32]byte, divergent []commontypes.OracleID) {
+	promPreviousOutcomeDivergentObservers.WithLabelValues(p.ConfigDigest.String()).Set(float64(len(divergent)))
+	if len(divergent) == 0 {
+		return
+	}
+	if len(divergent) > p.F {
+		promPreviousOutcomeForksTotal.WithLabelValues(p.ConfigDigest.String()).Inc()
+		p.Logger.Errorw("More than f observers decoded a different previous outcome; oracle state has diverged", "divergentObservers", divergent, "f", p.F, "previousOutcomeHash", fmt.Sprintf("%x", expected), "stage", "Outcome", "seqNr", seqNr)
+		return
+	}
+	p.Logger.Warnw("Observers decoded a different previous outcome", "divergentObservers", divergent, "f", p.F, "previousOutcomeHash", fmt.Sprintf("%x", expected), "stage", "Outcome", "seqNr", seqNr)
+}
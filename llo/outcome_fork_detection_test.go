@@ -0,0 +1,104 @@
+package llo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+)
+
+func Test_PreviousOutcomeForkDetection(t *testing.T) {
+	ctx := tests.Context(t)
+	p := &Plugin{
+		ConfigDigest:      types.ConfigDigest{51},
+		Config:            Config{true},
+		OutcomeCodec:      protoOutcomeCodec{},
+		ObservationCodec:  protoObservationCodec{},
+		Logger:            logger.Test(t),
+		F:                 1,
+		ShouldRetireCache: &mockShouldRetireCache{},
+		ChannelDefinitionCache: &mockChannelDefinitionCache{
+			definitions: llotypes.ChannelDefinitions{},
+		},
+		DataSource: &mockDataSource{},
+	}
+	previousOutcome := Outcome{
+		LifeCycleStage:                   LifeCycleStageProduction,
+		ObservationsTimestampNanoseconds: time.Now().UnixNano(),
+	}
+	encodedPreviousOutcome, err := p.OutcomeCodec.Encode(previousOutcome)
+	require.NoError(t, err)
+	outctx := ocr3types.OutcomeContext{SeqNr: 3, PreviousOutcome: encodedPreviousOutcome}
+	expected, err := p.previousOutcomeHash(previousOutcome)
+	require.NoError(t, err)
+
+	t.Run("observations include the previous outcome hash", func(t *testing.T) {
+		encoded, err2 := p.Observation(ctx, outctx, nil)
+		require.NoError(t, err2)
+		obs, err2 := p.ObservationCodec.Decode(encoded)
+		require.NoError(t, err2)
+		assert.Equal(t, expected, obs.PreviousOutcomeHash)
+	})
+
+	aos := func(hashes ...[32]byte) (aos []types.AttributedObservation) {
+		for i, h := range hashes {
+			encoded, err2 := p.ObservationCodec.Encode(Observation{UnixTimestampNanoseconds: time.Now().UnixNano(), PreviousOutcomeHash: h})
+			require.NoError(t, err2)
+			aos = append(aos, types.AttributedObservation{Observation: encoded, Observer: commontypes.OracleID(i)})
+		}
+		return aos
+	}
+	divergent := promPreviousOutcomeDivergentObservers.WithLabelValues(p.ConfigDigest.String())
+	forks := promPreviousOutcomeForksTotal.WithLabelValues(p.ConfigDigest.String())
+	other := [32]byte{1}
+
+	t.Run("does not flag matching or unset hashes", func(t *testing.T) {
+		before := testutil.ToFloat64(forks)
+		_, err2 := p.Outcome(ctx, outctx, nil, aos(expected, expected, [32]byte{}, expected))
+		require.NoError(t, err2)
+		assert.Equal(t, float64(0), testutil.ToFloat64(divergent))
+		assert.Equal(t, before, testutil.ToFloat64(forks))
+	})
+	t.Run("counts up to f divergent observers without flagging a fork", func(t *testing.T) {
+		before := testutil.ToFloat64(forks)
+		_, err2 := p.Outcome(ctx, outctx, nil, aos(expected, other, expected, expected))
+		require.NoError(t, err2)
+		assert.Equal(t, float64(1), testutil.ToFloat64(divergent))
+		assert.Equal(t, before, testutil.ToFloat64(forks))
+	})
+	t.Run("flags a fork if more than f observers diverge", func(t *testing.T) {
+		before := testutil.ToFloat64(forks)
+		_, err2 := p.Outcome(ctx, outctx, nil, aos(expected, other, other, expected))
+		require.NoError(t, err2)
+		assert.Equal(t, float64(2), testutil.ToFloat64(divergent))
+		assert.Equal(t, before+1, testutil.ToFloat64(forks))
+	})
+	t.Run("hash does not depend on map ordering", func(t *testing.T) {
+		withChannels := Outcome{
+			LifeCycleStage: LifeCycleStageProduction,
+			ChannelDefinitions: llotypes.ChannelDefinitions{
+				1: {ReportFormat: llotypes.ReportFormatJSON},
+				2: {ReportFormat: llotypes.ReportFormatJSON},
+				3: {ReportFormat: llotypes.ReportFormatJSON},
+			},
+			ValidAfterSeconds: map[llotypes.ChannelID]uint32{1: 1, 2: 2, 3: 3},
+		}
+		h, err2 := p.previousOutcomeHash(withChannels)
+		require.NoError(t, err2)
+		for i := 0; i < 10; i++ {
+			h2, err3 := p.previousOutcomeHash(withChannels)
+			require.NoError(t, err3)
+			assert.Equal(t, h, h2)
+		}
+	})
+}
@@ -0,0 +1,201 @@
+package llo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+)
+
+// OutcomeSnapshot is the latest committed outcome that a plugin generated
+// reports for
+type OutcomeSnapshot struct {
+	ConfigDigest types.ConfigDigest `json:"configDigest"`
+	SeqNr        uint64             `json:"seqNr"`
+	// Outcome is encoded with the plugin's OutcomeCodec
+	Outcome []byte         `json:"outcome"`
+	Summary OutcomeSummary `json:"summary"`
+}
+
+// UnmarshalJSON decodes the hex ConfigDigest written by its MarshalText
+func (s *OutcomeSnapshot) UnmarshalJSON(b []byte) error {
+	type outcomeSnapshot OutcomeSnapshot
+	var d struct {
+		outcomeSnapshot
+		ConfigDigest string `json:"configDigest"`
+	}
+	if err := json.Unmarshal(b, &d); err != nil {
+		return err
+	}
+	cd, err := decodeConfigDigestHex(d.ConfigDigest)
+	if err != nil {
+		return err
+	}
+	*s = OutcomeSnapshot(d.outcomeSnapshot)
+	s.ConfigDigest = cd
+	return nil
+}
+
+// OutcomeSnapshotStore persists the latest outcome snapshot, so that a
+// restarting node can restore its state without waiting for the next
+// outcome
+type OutcomeSnapshotStore interface {
+	// LatestOutcomeSnapshot returns nil if no snapshot was stored yet
+	LatestOutcomeSnapshot() (*OutcomeSnapshot, error)
+	// SetLatestOutcomeSnapshot persists the snapshot, replacing any
+	// previous one
+	SetLatestOutcomeSnapshot(OutcomeSnapshot) error
+}
+
+var _ OutcomeSnapshotStore = &FileOutcomeSnapshotStore{}
+
+// FileOutcomeSnapshotStore persists the outcome snapshot as a JSON file
+type FileOutcomeSnapshotStore struct {
+	path string
+}
+
+func NewFileOutcomeSnapshotStore(path string) *FileOutcomeSnapshotStore {
+	return &FileOutcomeSnapshotStore{path}
+}
+
+// LatestOutcomeSnapshot returns nil if the file does not exist yet
+func (s *FileOutcomeSnapshotStore) LatestOutcomeSnapshot() (*OutcomeSnapshot, error) {
+	b, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	snapshot := &OutcomeSnapshot{}
+	if err := json.Unmarshal(b, snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", s.path, err)
+	}
+	return snapshot, nil
+}
+
+// SetLatestOutcomeSnapshot replaces the file atomically
+func (s *FileOutcomeSnapshotStore) SetLatestOutcomeSnapshot(snapshot OutcomeSnapshot) error {
+	b, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(s.path, b)
+}
+
+// outcomeSnapshotPersistInterval limits how often OutcomeSnapshots writes to
+// its store; outcomes are committed several times per second, and a
+// snapshot that is a few seconds old restores the same channel state
+const outcomeSnapshotPersistInterval = 10 * time.Second
+
+// OutcomeSnapshots periodically persists the latest committed outcome.
+// Plugins created after a restart restore it to surface their last known
+// state through metrics and the introspection API, and to pre-warm their
+// caches, rather than waiting for the next outcome to arrive.
+//
+// A single OutcomeSnapshots may be shared by plugins for several config
+// digests; plugins only restore snapshots of their own config digest.
+type OutcomeSnapshots struct {
+	lggr  logger.Logger
+	store OutcomeSnapshotStore
+
+	mu            sync.Mutex
+	latest        *OutcomeSnapshot
+	lastPersisted time.Time
+	dirty         bool
+}
+
+// NewOutcomeSnapshots loads the latest snapshot from the store
+func NewOutcomeSnapshots(lggr logger.Logger, store OutcomeSnapshotStore) (*OutcomeSnapshots, error) {
+	latest, err := store.LatestOutcomeSnapshot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load outcome snapshot: %w", err)
+	}
+	return &OutcomeSnapshots{
+		lggr:   logger.Named(lggr, "OutcomeSnapshots"),
+		store:  store,
+		latest: latest,
+	}, nil
+}
+
+// Latest returns the latest snapshot, or nil if there is none
+func (s *OutcomeSnapshots) Latest() *OutcomeSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.latest == nil {
+		return nil
+	}
+	snapshot := *s.latest
+	return &snapshot
+}
+
+// Flush persists the latest snapshot if it has not been written to the
+// store yet, e.g. on shutdown
+func (s *OutcomeSnapshots) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.persist()
+}
+
+// record remembers a committed outcome. Outcomes older than the latest one
+// of the same config digest are ignored.
+func (s *OutcomeSnapshots) record(digest types.ConfigDigest, seqNr uint64, rawOutcome []byte, summary OutcomeSummary) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.latest != nil && s.latest.ConfigDigest == digest && s.latest.SeqNr >= seqNr {
+		return
+	}
+	// OCR may reuse the outcome's buffer
+	s.latest = &OutcomeSnapshot{digest, seqNr, append([]byte{}, rawOutcome...), summary}
+	s.dirty = true
+
+	if time.Since(s.lastPersisted) >= outcomeSnapshotPersistInterval {
+		if err := s.persist(); err != nil {
+			s.lggr.Errorw("Failed to persist outcome snapshot", "err", err)
+		}
+	}
+}
+
+func (s *OutcomeSnapshots) persist() error {
+	if !s.dirty {
+		return nil
+	}
+	if err := s.store.SetLatestOutcomeSnapshot(*s.latest); err != nil {
+		return err
+	}
+	s.lastPersisted = time.Now()
+	s.dirty = false
+	return nil
+}
+
+// restoreOutcomeSnapshot restores the state of a newly created plugin from
+// the latest snapshot of its config digest, if any
+func (p *Plugin) restoreOutcomeSnapshot() {
+	if p.OutcomeSnapshots == nil {
+		return
+	}
+	snapshot := p.OutcomeSnapshots.Latest()
+	if snapshot == nil || snapshot.ConfigDigest != p.ConfigDigest {
+		return
+	}
+	outcome, err := p.OutcomeCodec.Decode(snapshot.Outcome)
+	if err != nil {
+		p.Logger.Warnw("Failed to decode outcome snapshot, not restoring it", "err", err, "seqNr", snapshot.SeqNr)
+		return
+	}
+
+	promLatestOutcomeSeqNr.WithLabelValues(p.ConfigDigest.String()).Set(float64(snapshot.SeqNr))
+	if p.Introspector != nil {
+		p.Introspector.restoreOutcome(p.ConfigDigest, snapshot.Summary)
+	}
+	// Rounds missed while the node was down show up as a seqNr gap
+	if observationsTimestampSeconds, err := outcome.ObservationsTimestampSeconds(); err == nil {
+		p.seqNrs.restore(snapshot.SeqNr, observationsTimestampSeconds)
+	}
+	p.Logger.Infow("Restored outcome snapshot", "seqNr", snapshot.SeqNr, "channels", len(outcome.ChannelDefinitions))
+}
@@ -0,0 +1,142 @@
+package llo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+func Test_FileOutcomeSnapshotStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outcome_snapshot.json")
+	s := NewFileOutcomeSnapshotStore(path)
+
+	t.Run("returns nil if file does not exist", func(t *testing.T) {
+		snapshot, err := s.LatestOutcomeSnapshot()
+		require.NoError(t, err)
+		assert.Nil(t, snapshot)
+	})
+	t.Run("round trips", func(t *testing.T) {
+		snapshot := OutcomeSnapshot{types.ConfigDigest{1}, 2, []byte{3, 4}, OutcomeSummary{SeqNr: 2, ChannelCount: 5, RecordedAt: time.Unix(6, 0).UTC()}}
+		require.NoError(t, s.SetLatestOutcomeSnapshot(snapshot))
+		actual, err := s.LatestOutcomeSnapshot()
+		require.NoError(t, err)
+		assert.Equal(t, &snapshot, actual)
+
+		// no temporary files are left behind
+		entries, err := os.ReadDir(filepath.Dir(path))
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+	t.Run("errors on corrupt file", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte("foo"), 0o600))
+		_, err := s.LatestOutcomeSnapshot()
+		assert.ErrorContains(t, err, "failed to decode")
+	})
+}
+
+func Test_OutcomeSnapshots(t *testing.T) {
+	ctx := tests.Context(t)
+	store := NewFileOutcomeSnapshotStore(filepath.Join(t.TempDir(), "outcome_snapshot.json"))
+	snapshots, err := NewOutcomeSnapshots(logger.Test(t), store)
+	require.NoError(t, err)
+	assert.Nil(t, snapshots.Latest())
+
+	digest := types.ConfigDigest{0x00, 0x09, 0x5a}
+	definitions := llotypes.ChannelDefinitions{
+		1: {
+			ReportFormat: llotypes.ReportFormatJSON,
+			Streams:      []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}},
+			Opts:         []byte(`{"streamValuePolicies":{"1":"positive"}}`),
+		},
+	}
+	newPlugin := func(snapshots *OutcomeSnapshots, introspector *PluginIntrospector) *Plugin {
+		return &Plugin{
+			ConfigDigest: digest,
+			OutcomeCodec: protoOutcomeCodec{},
+			Logger:       logger.Test(t),
+			ReportCodecs: map[llotypes.ReportFormat]ReportCodec{
+				llotypes.ReportFormatJSON: JSONReportCodec{},
+			},
+			Introspector:     introspector,
+			OutcomeSnapshots: snapshots,
+			seqNrs:           &seqNrTracker{},
+		}
+	}
+	p := newPlugin(snapshots, nil)
+	encodeOutcome := func(observationsTimestampSeconds uint32) ocr3types.Outcome {
+		encoded, err2 := p.OutcomeCodec.Encode(Outcome{
+			LifeCycleStage:                   LifeCycleStageProduction,
+			ObservationsTimestampNanoseconds: int64(observationsTimestampSeconds) * int64(time.Second),
+			ValidAfterSeconds:                map[llotypes.ChannelID]uint32{1: observationsTimestampSeconds - 1},
+			ChannelDefinitions:               definitions,
+		})
+		require.NoError(t, err2)
+		return encoded
+	}
+
+	// The first outcome is persisted immediately, later ones are rate
+	// limited
+	_, err = p.Reports(ctx, 10, encodeOutcome(100))
+	require.NoError(t, err)
+	_, err = p.Reports(ctx, 11, encodeOutcome(101))
+	require.NoError(t, err)
+	latest := snapshots.Latest()
+	require.NotNil(t, latest)
+	assert.Equal(t, uint64(11), latest.SeqNr)
+	persisted, err := store.LatestOutcomeSnapshot()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), persisted.SeqNr)
+
+	// Older outcomes never replace newer ones
+	_, err = p.Reports(ctx, 9, encodeOutcome(99))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(11), snapshots.Latest().SeqNr)
+
+	require.NoError(t, snapshots.Flush())
+	persisted, err = store.LatestOutcomeSnapshot()
+	require.NoError(t, err)
+	assert.Equal(t, latest.SeqNr, persisted.SeqNr)
+	assert.Equal(t, latest.Outcome, persisted.Outcome)
+
+	t.Run("restores the snapshot of the plugin's config digest after a restart", func(t *testing.T) {
+		snapshots, err := NewOutcomeSnapshots(logger.Test(t), store)
+		require.NoError(t, err)
+		introspector := NewPluginIntrospector()
+		p := newPlugin(snapshots, introspector)
+		p.restoreOutcomeSnapshot()
+
+		state := introspector.State()
+		assert.Equal(t, digest, state.ConfigDigest)
+		require.NotNil(t, state.LatestOutcome)
+		assert.Equal(t, uint64(11), state.LatestOutcome.SeqNr)
+		assert.Equal(t, 1, state.LatestOutcome.ChannelCount)
+		assert.Equal(t, float64(11), testutil.ToFloat64(promLatestOutcomeSeqNr.WithLabelValues(digest.String())))
+
+		// Rounds missed while down are detected
+		gap, ok := p.seqNrs.observe(20, 120, &Outcome{}, nil)
+		require.True(t, ok)
+		assert.Equal(t, uint64(8), gap.Missed())
+	})
+	t.Run("ignores snapshots of other config digests", func(t *testing.T) {
+		snapshots, err := NewOutcomeSnapshots(logger.Test(t), store)
+		require.NoError(t, err)
+		introspector := NewPluginIntrospector()
+		p := newPlugin(snapshots, introspector)
+		p.ConfigDigest = types.ConfigDigest{0x00, 0x09, 0x5b}
+		p.restoreOutcomeSnapshot()
+
+		assert.Nil(t, introspector.State().LatestOutcome)
+	})
+}
@@ -90,9 +90,28 @@ type DataSource interface {
 	// passed streamValues.
 	// If an observation fails, or the stream is unknown, no value should be
 	// set.
+	// Observe should return once ctx is done. Calls that have not returned
+	// by the observation deadline are abandoned, and any values they set
+	// are discarded.
 	Observe(ctx context.Context, streamValues StreamValues, opts DSOpts) error
 }
 
+// StreamTimestamps maps stream IDs to the time (in nanoseconds since the unix
+// epoch) at which the upstream source produced the observed value
+type StreamTimestamps map[llotypes.StreamID]int64
+
+// TimestampedDataSource is an optional extension of DataSource for sources
+// that know when each value was produced upstream. If the DataSource
+// implements it, ObserveWithTimestamps is called instead of Observe.
+//
+// Values without a source timestamp are never considered stale.
+type TimestampedDataSource interface {
+	DataSource
+	// ObserveWithTimestamps behaves like Observe, and additionally sets the
+	// source timestamp of each observed value in the passed timestamps.
+	ObserveWithTimestamps(ctx context.Context, streamValues StreamValues, timestamps StreamTimestamps, opts DSOpts) error
+}
+
 // Protocol instances start in either the staging or production stage. They
 // may later be retired and "hand over" their work to another protocol instance
 // that will move from the staging to the production stage.
@@ -198,7 +217,15 @@ var _ ocr3types.ReportingPluginFactory[llotypes.ReportInfo] = &PluginFactory{}
 
 func NewPluginFactory(cfg Config, prrc PredecessorRetirementReportCache, src ShouldRetireCache, rcodec RetirementReportCodec, cdc ChannelDefinitionCache, ds DataSource, lggr logger.Logger, oncc OnchainConfigCodec, reportCodecs map[llotypes.ReportFormat]ReportCodec) *PluginFactory {
 	return &PluginFactory{
-		cfg, prrc, src, rcodec, cdc, ds, lggr, oncc, reportCodecs,
+		Config:                           cfg,
+		PredecessorRetirementReportCache: prrc,
+		ShouldRetireCache:                src,
+		RetirementReportCodec:            rcodec,
+		ChannelDefinitionCache:           cdc,
+		DataSource:                       ds,
+		Logger:                           lggr,
+		OnchainConfigCodec:               oncc,
+		ReportCodecs:                     reportCodecs,
 	}
 }
 
@@ -218,6 +245,34 @@ type PluginFactory struct {
 	Logger                           logger.Logger
 	OnchainConfigCodec               OnchainConfigCodec
 	ReportCodecs                     map[llotypes.ReportFormat]ReportCodec
+	// TransmissionTargets optionally records the targets set in channel
+	// opts for the transmitter
+	TransmissionTargets *TransmissionTargets
+	// Introspector optionally records the plugins' state for operators
+	Introspector *PluginIntrospector
+	// LastTransmissions optionally records the last transmitted report of
+	// every channel for operators
+	LastTransmissions *LastTransmissions
+	// ObservationProvenance optionally signs the stream values observed by
+	// this oracle, as an audit trail outside of consensus
+	ObservationProvenance *ObservationProvenanceSigner
+	// ObservationAttributions optionally reports which providers and
+	// licenses the DataSource attributed the observed stream values to, for
+	// billing and compliance
+	ObservationAttributions *ObservationAttributions
+	// OutcomeSnapshots optionally persists the latest committed outcome, so
+	// that plugins restore their state quickly after a restart
+	OutcomeSnapshots *OutcomeSnapshots
+	// LogRedaction controls whether report payloads and stream values are
+	// logged. They are redacted by default.
+	LogRedaction LogRedactionPolicy
+	// TransmissionFilter selects which accepted reports this node transmits.
+	// All reports are transmitted by default.
+	TransmissionFilter TransmissionFilter
+	// RuntimeParams optionally overrides Config.VerboseLogging and
+	// TransmissionFilter with parameters that operators can change while
+	// the plugins are running
+	RuntimeParams *RuntimeParamsStore
 }
 
 func (f *PluginFactory) NewReportingPlugin(ctx context.Context, cfg ocr3types.ReportingPluginConfig) (ocr3types.ReportingPlugin[llotypes.ReportInfo], ocr3types.ReportingPluginInfo, error) {
@@ -225,33 +280,58 @@ func (f *PluginFactory) NewReportingPlugin(ctx context.Context, cfg ocr3types.Re
 	if err != nil {
 		return nil, ocr3types.ReportingPluginInfo{}, fmt.Errorf("NewReportingPlugin failed to decode onchain config; got: 0x%x (len: %d); %w", cfg.OnchainConfig, len(cfg.OnchainConfig), err)
 	}
+	if onchainConfig.PredecessorConfigDigest != nil {
+		if err = VerifyConfigDigestPrefix(*onchainConfig.PredecessorConfigDigest); err != nil {
+			return nil, ocr3types.ReportingPluginInfo{}, fmt.Errorf("NewReportingPlugin got invalid predecessor config digest: %w", err)
+		}
+	}
+	offchainConfig, err := DecodeOffchainConfigForDigest(cfg.ConfigDigest, cfg.OffchainConfig)
+	if err != nil {
+		return nil, ocr3types.ReportingPluginInfo{}, fmt.Errorf("NewReportingPlugin failed to decode offchain config: %w", err)
+	}
 
-	return &Plugin{
-			f.Config,
-			onchainConfig.PredecessorConfigDigest,
-			cfg.ConfigDigest,
-			f.PredecessorRetirementReportCache,
-			f.ShouldRetireCache,
-			f.ChannelDefinitionCache,
-			f.DataSource,
-			f.Logger,
-			cfg.N,
-			cfg.F,
-			protoObservationCodec{},
-			protoOutcomeCodec{},
-			f.RetirementReportCodec,
-			f.ReportCodecs,
-			cfg.MaxDurationObservation,
-		}, ocr3types.ReportingPluginInfo{
-			Name: "LLO",
-			Limits: ocr3types.ReportingPluginLimits{
-				MaxQueryLength:       0,
-				MaxObservationLength: MaxObservationLength,
-				MaxOutcomeLength:     MaxOutcomeLength,
-				MaxReportLength:      MaxReportLength,
-				MaxReportCount:       MaxReportCount,
-			},
-		}, nil
+	p := &Plugin{
+		Config:                           f.Config,
+		PredecessorConfigDigest:          onchainConfig.PredecessorConfigDigest,
+		ConfigDigest:                     cfg.ConfigDigest,
+		PredecessorRetirementReportCache: f.PredecessorRetirementReportCache,
+		ShouldRetireCache:                f.ShouldRetireCache,
+		ChannelDefinitionCache:           f.ChannelDefinitionCache,
+		DataSource:                       f.DataSource,
+		Logger:                           f.Logger,
+		N:                                cfg.N,
+		F:                                cfg.F,
+		ObservationCodec:                 protoObservationCodec{},
+		OutcomeCodec:                     protoOutcomeCodec{},
+		RetirementReportCodec:            f.RetirementReportCodec,
+		ReportCodecs:                     f.ReportCodecs,
+		TransmissionTargets:              f.TransmissionTargets,
+		Introspector:                     f.Introspector,
+		LastTransmissions:                f.LastTransmissions,
+		ObservationProvenance:            f.ObservationProvenance,
+		ObservationAttributions:          f.ObservationAttributions,
+		OutcomeSnapshots:                 f.OutcomeSnapshots,
+		LogRedaction:                     f.LogRedaction,
+		TransmissionFilter:               f.TransmissionFilter,
+		RuntimeParams:                    f.RuntimeParams,
+		MaxDurationObservation:           cfg.MaxDurationObservation,
+		OffchainConfig:                   offchainConfig,
+		seqNrs:                           &seqNrTracker{},
+		degraded:                         &degradedModeTracker{},
+		outcomeTimes:                     &outcomeTimeTracker{},
+		channelOpts:                      newChannelOptsCache(),
+	}
+	p.restoreOutcomeSnapshot()
+	return p, ocr3types.ReportingPluginInfo{
+		Name: "LLO",
+		Limits: ocr3types.ReportingPluginLimits{
+			MaxQueryLength:       0,
+			MaxObservationLength: MaxObservationLength,
+			MaxOutcomeLength:     MaxOutcomeLength,
+			MaxReportLength:      MaxReportLength,
+			MaxReportCount:       MaxReportCount,
+		},
+	}, nil
 }
 
 var _ ocr3types.ReportingPlugin[llotypes.ReportInfo] = &Plugin{}
@@ -278,8 +358,28 @@ type Plugin struct {
 	OutcomeCodec                     OutcomeCodec
 	RetirementReportCodec            RetirementReportCodec
 	ReportCodecs                     map[llotypes.ReportFormat]ReportCodec
+	TransmissionTargets              *TransmissionTargets
+	Introspector                     *PluginIntrospector
+	LastTransmissions                *LastTransmissions
+	ObservationProvenance            *ObservationProvenanceSigner
+	ObservationAttributions          *ObservationAttributions
+	OutcomeSnapshots                 *OutcomeSnapshots
+	LogRedaction                     LogRedactionPolicy
+	TransmissionFilter               TransmissionFilter
+	RuntimeParams                    *RuntimeParamsStore
 
 	MaxDurationObservation time.Duration
+	OffchainConfig         OffchainConfig
+
+	// seqNrs detects rounds missed by this oracle, if set
+	seqNrs *seqNrTracker
+	// degraded tracks whether this oracle is in degraded mode, if set
+	degraded *degradedModeTracker
+	// outcomeTimes remembers when this oracle computed recent outcomes, if
+	// set
+	outcomeTimes *outcomeTimeTracker
+	// channelOpts caches the parsed opts of channel definitions, if set
+	channelOpts *channelOptsCache
 }
 
 // Query creates a Query that is sent from the leader to all follower nodes
@@ -309,7 +409,9 @@ func (p *Plugin) Query(ctx context.Context, outctx ocr3types.OutcomeContext) (ty
 //
 // Should return a serialized Observation struct.
 func (p *Plugin) Observation(ctx context.Context, outctx ocr3types.OutcomeContext, query types.Query) (types.Observation, error) {
-	return p.observation(ctx, outctx, query)
+	obs, err := p.observation(ctx, outctx, query)
+	p.recordError("Observation", outctx.SeqNr, err)
+	return obs, err
 }
 
 // Should return an error if an observation isn't well-formed.
@@ -353,8 +455,28 @@ func (p *Plugin) ValidateObservation(ctx context.Context, outctx ocr3types.Outco
 		return fmt.Errorf("UpdateChannelDefinitions is invalid: %w", err)
 	}
 
-	if len(observation.StreamValues) > MaxObservationStreamValuesLength {
-		return fmt.Errorf("StreamValues is too long: %v vs %v", len(observation.StreamValues), MaxObservationStreamValuesLength)
+	if len(observation.StreamValues)+len(observation.UnchangedStreamIDs) > MaxObservationStreamValuesLength {
+		return fmt.Errorf("StreamValues is too long: %v (+%v unchanged) vs %v", len(observation.StreamValues), len(observation.UnchangedStreamIDs), MaxObservationStreamValuesLength)
+	}
+
+	if len(observation.UnchangedStreamIDs) > 0 && !p.OffchainConfig.SkipUnchangedStreamValues {
+		return fmt.Errorf("UnchangedStreamIDs is not empty even though SkipUnchangedStreamValues is disabled")
+	}
+	for id := range observation.UnchangedStreamIDs {
+		if _, exists := observation.StreamValues[id]; exists {
+			return fmt.Errorf("stream %d is in both StreamValues and UnchangedStreamIDs", id)
+		}
+	}
+
+	if len(observation.StreamTimestamps) > 0 && p.OffchainConfig.StreamStalenessBound == 0 {
+		return fmt.Errorf("StreamTimestamps is not empty even though StreamStalenessBound is disabled")
+	}
+	for id := range observation.StreamTimestamps {
+		_, observed := observation.StreamValues[id]
+		_, unchanged := observation.UnchangedStreamIDs[id]
+		if !observed && !unchanged {
+			return fmt.Errorf("StreamTimestamps contains stream %d which is neither in StreamValues nor in UnchangedStreamIDs", id)
+		}
 	}
 
 	return nil
@@ -374,7 +496,12 @@ func (p *Plugin) ValidateObservation(ctx context.Context, outctx ocr3types.Outco
 // libocr guarantees that this will always be called with at least 2f+1
 // AttributedObservations
 func (p *Plugin) Outcome(ctx context.Context, outctx ocr3types.OutcomeContext, query types.Query, aos []types.AttributedObservation) (ocr3types.Outcome, error) {
-	return p.outcome(outctx, query, aos)
+	outcome, err := p.outcome(outctx, query, aos)
+	p.recordError("Outcome", outctx.SeqNr, err)
+	if err == nil {
+		p.outcomeTimes.record(outctx.SeqNr, time.Now())
+	}
+	return outcome, err
 }
 
 // Generates a (possibly empty) list of reports from an outcome. Each report
@@ -391,7 +518,9 @@ func (p *Plugin) Outcome(ctx context.Context, outctx ocr3types.OutcomeContext, q
 // outctx.previousOutcome contains the consensus outcome with sequence
 // number (outctx.SeqNr-1).
 func (p *Plugin) Reports(ctx context.Context, seqNr uint64, rawOutcome ocr3types.Outcome) ([]ocr3types.ReportPlus[llotypes.ReportInfo], error) {
-	return p.reports(ctx, seqNr, rawOutcome)
+	rwis, err := p.reports(ctx, seqNr, rawOutcome)
+	p.recordError("Report", seqNr, err)
+	return rwis, err
 }
 
 func (p *Plugin) ShouldAcceptAttestedReport(context.Context, uint64, ocr3types.ReportWithInfo[llotypes.ReportInfo]) (bool, error) {
@@ -399,8 +528,15 @@ func (p *Plugin) ShouldAcceptAttestedReport(context.Context, uint64, ocr3types.R
 	return true, nil
 }
 
-func (p *Plugin) ShouldTransmitAcceptedReport(context.Context, uint64, ocr3types.ReportWithInfo[llotypes.ReportInfo]) (bool, error) {
-	// Transmit it all to the Mercury server
+func (p *Plugin) ShouldTransmitAcceptedReport(_ context.Context, seqNr uint64, r ocr3types.ReportWithInfo[llotypes.ReportInfo]) (bool, error) {
+	if !p.transmissionFilter().Allows(r.Info) {
+		promFilteredTransmissionsTotal.WithLabelValues(p.ConfigDigest.String(), r.Info.ReportFormat.String(), string(r.Info.LifeCycleStage)).Inc()
+		return false, nil
+	}
+	// Transmit the rest to the Mercury server
+	if p.LastTransmissions != nil {
+		p.LastTransmissions.transmitted(p.ConfigDigest, seqNr, r.Report)
+	}
 	return true, nil
 }
 
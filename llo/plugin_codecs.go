@@ -3,6 +3,7 @@ package llo
 import (
 	"errors"
 	"fmt"
+	"math"
 	"sort"
 
 	"github.com/smartcontractkit/libocr/offchainreporting2/types"
@@ -11,6 +12,7 @@ import (
 
 	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
 
+	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -50,6 +52,20 @@ func (c protoObservationCodec) Encode(obs Observation) (types.Observation, error
 		}
 	}
 
+	var streamTimestamps map[uint32]int64
+	if len(obs.StreamTimestamps) > 0 {
+		streamTimestamps = make(map[uint32]int64, len(obs.StreamTimestamps))
+		for id, ts := range obs.StreamTimestamps {
+			// Drop timestamps of streams that are not encoded (e.g. nil
+			// values), since they have nothing to apply to
+			_, observed := streamValues[id]
+			_, unchanged := obs.UnchangedStreamIDs[id]
+			if observed || unchanged {
+				streamTimestamps[id] = ts
+			}
+		}
+	}
+
 	pbuf := &LLOObservationProto{
 		AttestedPredecessorRetirement: obs.AttestedPredecessorRetirement,
 		ShouldRetire:                  obs.ShouldRetire,
@@ -57,11 +73,62 @@ func (c protoObservationCodec) Encode(obs Observation) (types.Observation, error
 		RemoveChannelIDs:              maps.Keys(obs.RemoveChannelIDs),
 		UpdateChannelDefinitions:      dfns,
 		StreamValues:                  streamValues,
+		UnchangedStreamIDs:            maps.Keys(obs.UnchangedStreamIDs),
+		StreamTimestamps:              streamTimestamps,
+	}
+	if obs.PreviousOutcomeHash != ([32]byte{}) {
+		pbuf.PreviousOutcomeHash = obs.PreviousOutcomeHash[:]
 	}
 
 	return proto.Marshal(pbuf)
 }
 
+var _ observationSizeEstimator = protoObservationCodec{}
+
+func (c protoObservationCodec) estimateBaseSize(obs Observation) int {
+	size := proto.Size(&LLOObservationProto{
+		AttestedPredecessorRetirement: obs.AttestedPredecessorRetirement,
+		ShouldRetire:                  obs.ShouldRetire,
+		UnixTimestampNanoseconds:      obs.UnixTimestampNanoseconds,
+		RemoveChannelIDs:              maps.Keys(obs.RemoveChannelIDs),
+	})
+	if obs.PreviousOutcomeHash != ([32]byte{}) {
+		size += protowire.SizeTag(9) + protowire.SizeBytes(len(obs.PreviousOutcomeHash))
+	}
+	if len(obs.UnchangedStreamIDs) > 0 {
+		// Tag and length prefix of the packed unchangedStreamIDs; the IDs
+		// themselves are counted by estimateStreamSize
+		size += protowire.SizeTag(7) + protowire.SizeVarint(uint64(len(obs.UnchangedStreamIDs)*protowire.SizeVarint(math.MaxUint32)))
+	}
+	return size
+}
+
+func (c protoObservationCodec) estimateStreamSize(obs Observation, id llotypes.StreamID) int {
+	pbuf := &LLOObservationProto{}
+	if sv := obs.StreamValues[id]; sv != nil {
+		// Values that fail to encode are counted as empty; Encode will fail
+		// on them anyway
+		if enc, err := sv.MarshalBinary(); err == nil {
+			pbuf.StreamValues = map[uint32]*LLOStreamValue{id: {Type: sv.Type(), Value: enc}}
+		}
+	}
+	_, unchanged := obs.UnchangedStreamIDs[id]
+	if ts, ok := obs.StreamTimestamps[id]; ok && (pbuf.StreamValues != nil || unchanged) {
+		pbuf.StreamTimestamps = map[uint32]int64{id: ts}
+	}
+	size := proto.Size(pbuf)
+	if unchanged {
+		size += protowire.SizeVarint(uint64(id))
+	}
+	return size
+}
+
+func (c protoObservationCodec) estimateChannelDefinitionSize(id llotypes.ChannelID, cd llotypes.ChannelDefinition) int {
+	return proto.Size(&LLOObservationProto{
+		UpdateChannelDefinitions: channelDefinitionsToProtoObservation(llotypes.ChannelDefinitions{id: cd}),
+	})
+}
+
 func channelDefinitionsToProtoObservation(in llotypes.ChannelDefinitions) (out map[uint32]*LLOChannelDefinitionProto) {
 	if len(in) > 0 {
 		out = make(map[uint32]*LLOChannelDefinitionProto, len(in))
@@ -116,6 +183,26 @@ func (c protoObservationCodec) Decode(b types.Observation) (Observation, error)
 			streamValues[id] = sv
 		}
 	}
+	var unchangedStreamIDs map[llotypes.StreamID]struct{}
+	if len(pbuf.UnchangedStreamIDs) > 0 {
+		unchangedStreamIDs = make(map[llotypes.StreamID]struct{}, len(pbuf.UnchangedStreamIDs))
+		for _, id := range pbuf.UnchangedStreamIDs {
+			if _, exists := unchangedStreamIDs[id]; exists {
+				// Byzantine behavior makes this observation invalid; a
+				// well-behaved node should never encode duplicates here
+				return Observation{}, fmt.Errorf("failed to decode observation; duplicate stream ID in UnchangedStreamIDs: %d", id)
+			}
+			unchangedStreamIDs[id] = struct{}{}
+		}
+	}
+	var previousOutcomeHash [32]byte
+	switch len(pbuf.PreviousOutcomeHash) {
+	case 0:
+	case len(previousOutcomeHash):
+		copy(previousOutcomeHash[:], pbuf.PreviousOutcomeHash)
+	default:
+		return Observation{}, fmt.Errorf("failed to decode observation; invalid PreviousOutcomeHash: expected %d bytes, got %d", len(previousOutcomeHash), len(pbuf.PreviousOutcomeHash))
+	}
 	obs := Observation{
 		AttestedPredecessorRetirement: pbuf.AttestedPredecessorRetirement,
 		ShouldRetire:                  pbuf.ShouldRetire,
@@ -123,6 +210,11 @@ func (c protoObservationCodec) Decode(b types.Observation) (Observation, error)
 		RemoveChannelIDs:              removeChannelIDs,
 		UpdateChannelDefinitions:      dfns,
 		StreamValues:                  streamValues,
+		UnchangedStreamIDs:            unchangedStreamIDs,
+		PreviousOutcomeHash:           previousOutcomeHash,
+	}
+	if len(pbuf.StreamTimestamps) > 0 {
+		obs.StreamTimestamps = pbuf.StreamTimestamps
 	}
 	return obs, nil
 }
@@ -168,6 +260,11 @@ func (protoOutcomeCodec) Encode(outcome Outcome) (ocr3types.Outcome, error) {
 		return nil, err
 	}
 
+	streamDispersions, err := streamDispersionsToProtoOutcome(outcome.StreamDispersions)
+	if err != nil {
+		return nil, err
+	}
+
 	validAfterSeconds := validAfterSecondsToProtoOutcome(outcome.ValidAfterSeconds)
 
 	pbuf := &LLOOutcomeProto{
@@ -176,6 +273,9 @@ func (protoOutcomeCodec) Encode(outcome Outcome) (ocr3types.Outcome, error) {
 		ChannelDefinitions:               dfns,
 		ValidAfterSeconds:                validAfterSeconds,
 		StreamAggregates:                 streamAggregates,
+		StreamDispersions:                streamDispersions,
+		WindDownRoundsRemaining:          outcome.WindDownRoundsRemaining,
+		DegradedRounds:                   outcome.DegradedRounds,
 	}
 
 	// It's very important that Outcome serialization be deterministic across all nodes!
@@ -243,6 +343,29 @@ func StreamAggregatesToProtoOutcome(in StreamAggregates) (out []*LLOStreamAggreg
 	return
 }
 
+func streamDispersionsToProtoOutcome(in map[llotypes.StreamID]StreamValue) (out []*LLOStreamDispersion, err error) {
+	if len(in) > 0 {
+		out = make([]*LLOStreamDispersion, 0, len(in))
+		for sid, v := range in {
+			if v == nil {
+				return nil, fmt.Errorf("cannot marshal protobuf; nil dispersion for stream ID: %d", sid)
+			}
+			value, err := v.MarshalBinary()
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, &LLOStreamDispersion{
+				StreamID:    sid,
+				StreamValue: &LLOStreamValue{Type: v.Type(), Value: value},
+			})
+		}
+		sort.Slice(out, func(i, j int) bool {
+			return out[i].StreamID < out[j].StreamID
+		})
+	}
+	return
+}
+
 func validAfterSecondsToProtoOutcome(in map[llotypes.ChannelID]uint32) (out []*LLOChannelIDAndValidAfterSecondsProto) {
 	if len(in) > 0 {
 		out = make([]*LLOChannelIDAndValidAfterSecondsProto, 0, len(in))
@@ -273,6 +396,10 @@ func (protoOutcomeCodec) Decode(b ocr3types.Outcome) (outcome Outcome, err error
 	if err != nil {
 		return Outcome{}, err
 	}
+	streamDispersions, err := streamDispersionsFromProtoOutcome(pbuf.StreamDispersions)
+	if err != nil {
+		return Outcome{}, err
+	}
 	validAfterSeconds := validAfterSecondsFromProtoOutcome(pbuf.ValidAfterSeconds)
 	outcome = Outcome{
 		LifeCycleStage:                   llotypes.LifeCycleStage(pbuf.LifeCycleStage),
@@ -280,6 +407,9 @@ func (protoOutcomeCodec) Decode(b ocr3types.Outcome) (outcome Outcome, err error
 		ChannelDefinitions:               dfns,
 		ValidAfterSeconds:                validAfterSeconds,
 		StreamAggregates:                 streamAggregates,
+		StreamDispersions:                streamDispersions,
+		WindDownRoundsRemaining:          pbuf.WindDownRoundsRemaining,
+		DegradedRounds:                   pbuf.DegradedRounds,
 	}
 	return outcome, nil
 }
@@ -330,6 +460,21 @@ func streamAggregatesFromProtoOutcome(in []*LLOStreamAggregate) (out StreamAggre
 	return
 }
 
+func streamDispersionsFromProtoOutcome(in []*LLOStreamDispersion) (out map[llotypes.StreamID]StreamValue, err error) {
+	if len(in) > 0 {
+		out = make(map[llotypes.StreamID]StreamValue, len(in))
+		for _, enc := range in {
+			var sv StreamValue
+			sv, err = UnmarshalProtoStreamValue(enc.StreamValue)
+			if err != nil {
+				return
+			}
+			out[enc.StreamID] = sv
+		}
+	}
+	return
+}
+
 func validAfterSecondsFromProtoOutcome(in []*LLOChannelIDAndValidAfterSecondsProto) (out map[llotypes.ChannelID]uint32) {
 	if len(in) > 0 {
 		out = make(map[llotypes.ChannelID]uint32, len(in))
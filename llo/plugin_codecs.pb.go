@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.28.1
+// 	protoc-gen-go v1.34.2
 // 	protoc        v4.23.2
 // source: plugin_codecs.proto
 
@@ -23,8 +23,9 @@ const (
 type LLOStreamValue_Type int32
 
 const (
-	LLOStreamValue_Decimal LLOStreamValue_Type = 0
-	LLOStreamValue_Quote   LLOStreamValue_Type = 1
+	LLOStreamValue_Decimal      LLOStreamValue_Type = 0
+	LLOStreamValue_Quote        LLOStreamValue_Type = 1
+	LLOStreamValue_PriceVolumes LLOStreamValue_Type = 2
 )
 
 // Enum value maps for LLOStreamValue_Type.
@@ -32,10 +33,12 @@ var (
 	LLOStreamValue_Type_name = map[int32]string{
 		0: "Decimal",
 		1: "Quote",
+		2: "PriceVolumes",
 	}
 	LLOStreamValue_Type_value = map[string]int32{
-		"Decimal": 0,
-		"Quote":   1,
+		"Decimal":      0,
+		"Quote":        1,
+		"PriceVolumes": 2,
 	}
 )
 
@@ -86,6 +89,14 @@ type LLOObservationProto struct {
 	// uniqueness.
 	UpdateChannelDefinitions map[uint32]*LLOChannelDefinitionProto `protobuf:"bytes,5,rep,name=updateChannelDefinitions,proto3" json:"updateChannelDefinitions,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	StreamValues             map[uint32]*LLOStreamValue            `protobuf:"bytes,6,rep,name=streamValues,proto3" json:"streamValues,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Streams whose value is unchanged from the previous outcome's median and
+	// was therefore omitted from streamValues
+	UnchangedStreamIDs []uint32 `protobuf:"varint,7,rep,packed,name=unchangedStreamIDs,proto3" json:"unchangedStreamIDs,omitempty"`
+	// Source timestamps (unix nanoseconds) of observed and unchanged streams
+	StreamTimestamps map[uint32]int64 `protobuf:"bytes,8,rep,name=streamTimestamps,proto3" json:"streamTimestamps,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// SHA-256 hash of the previous outcome as decoded by the observer, for
+	// fork detection. Empty if not set.
+	PreviousOutcomeHash []byte `protobuf:"bytes,9,opt,name=previousOutcomeHash,proto3" json:"previousOutcomeHash,omitempty"`
 }
 
 func (x *LLOObservationProto) Reset() {
@@ -162,6 +173,27 @@ func (x *LLOObservationProto) GetStreamValues() map[uint32]*LLOStreamValue {
 	return nil
 }
 
+func (x *LLOObservationProto) GetUnchangedStreamIDs() []uint32 {
+	if x != nil {
+		return x.UnchangedStreamIDs
+	}
+	return nil
+}
+
+func (x *LLOObservationProto) GetStreamTimestamps() map[uint32]int64 {
+	if x != nil {
+		return x.StreamTimestamps
+	}
+	return nil
+}
+
+func (x *LLOObservationProto) GetPreviousOutcomeHash() []byte {
+	if x != nil {
+		return x.PreviousOutcomeHash
+	}
+	return nil
+}
+
 type LLOStreamValue struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -280,6 +312,108 @@ func (x *LLOStreamValueQuote) GetAsk() []byte {
 	return nil
 }
 
+type LLOStreamValuePriceVolumes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pairs []*LLOStreamValuePriceVolume `protobuf:"bytes,1,rep,name=pairs,proto3" json:"pairs,omitempty"`
+}
+
+func (x *LLOStreamValuePriceVolumes) Reset() {
+	*x = LLOStreamValuePriceVolumes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plugin_codecs_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LLOStreamValuePriceVolumes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LLOStreamValuePriceVolumes) ProtoMessage() {}
+
+func (x *LLOStreamValuePriceVolumes) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_codecs_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LLOStreamValuePriceVolumes.ProtoReflect.Descriptor instead.
+func (*LLOStreamValuePriceVolumes) Descriptor() ([]byte, []int) {
+	return file_plugin_codecs_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LLOStreamValuePriceVolumes) GetPairs() []*LLOStreamValuePriceVolume {
+	if x != nil {
+		return x.Pairs
+	}
+	return nil
+}
+
+type LLOStreamValuePriceVolume struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Price  []byte `protobuf:"bytes,1,opt,name=price,proto3" json:"price,omitempty"`
+	Volume []byte `protobuf:"bytes,2,opt,name=volume,proto3" json:"volume,omitempty"`
+}
+
+func (x *LLOStreamValuePriceVolume) Reset() {
+	*x = LLOStreamValuePriceVolume{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plugin_codecs_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LLOStreamValuePriceVolume) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LLOStreamValuePriceVolume) ProtoMessage() {}
+
+func (x *LLOStreamValuePriceVolume) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_codecs_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LLOStreamValuePriceVolume.ProtoReflect.Descriptor instead.
+func (*LLOStreamValuePriceVolume) Descriptor() ([]byte, []int) {
+	return file_plugin_codecs_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *LLOStreamValuePriceVolume) GetPrice() []byte {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *LLOStreamValuePriceVolume) GetVolume() []byte {
+	if x != nil {
+		return x.Volume
+	}
+	return nil
+}
+
 type LLOChannelDefinitionProto struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -293,7 +427,7 @@ type LLOChannelDefinitionProto struct {
 func (x *LLOChannelDefinitionProto) Reset() {
 	*x = LLOChannelDefinitionProto{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_plugin_codecs_proto_msgTypes[3]
+		mi := &file_plugin_codecs_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -306,7 +440,7 @@ func (x *LLOChannelDefinitionProto) String() string {
 func (*LLOChannelDefinitionProto) ProtoMessage() {}
 
 func (x *LLOChannelDefinitionProto) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_codecs_proto_msgTypes[3]
+	mi := &file_plugin_codecs_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -319,7 +453,7 @@ func (x *LLOChannelDefinitionProto) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LLOChannelDefinitionProto.ProtoReflect.Descriptor instead.
 func (*LLOChannelDefinitionProto) Descriptor() ([]byte, []int) {
-	return file_plugin_codecs_proto_rawDescGZIP(), []int{3}
+	return file_plugin_codecs_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *LLOChannelDefinitionProto) GetReportFormat() uint32 {
@@ -355,7 +489,7 @@ type LLOStreamDefinition struct {
 func (x *LLOStreamDefinition) Reset() {
 	*x = LLOStreamDefinition{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_plugin_codecs_proto_msgTypes[4]
+		mi := &file_plugin_codecs_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -368,7 +502,7 @@ func (x *LLOStreamDefinition) String() string {
 func (*LLOStreamDefinition) ProtoMessage() {}
 
 func (x *LLOStreamDefinition) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_codecs_proto_msgTypes[4]
+	mi := &file_plugin_codecs_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -381,7 +515,7 @@ func (x *LLOStreamDefinition) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LLOStreamDefinition.ProtoReflect.Descriptor instead.
 func (*LLOStreamDefinition) Descriptor() ([]byte, []int) {
-	return file_plugin_codecs_proto_rawDescGZIP(), []int{4}
+	return file_plugin_codecs_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *LLOStreamDefinition) GetStreamID() uint32 {
@@ -410,7 +544,7 @@ type LLOStreamObservationProto struct {
 func (x *LLOStreamObservationProto) Reset() {
 	*x = LLOStreamObservationProto{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_plugin_codecs_proto_msgTypes[5]
+		mi := &file_plugin_codecs_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -423,7 +557,7 @@ func (x *LLOStreamObservationProto) String() string {
 func (*LLOStreamObservationProto) ProtoMessage() {}
 
 func (x *LLOStreamObservationProto) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_codecs_proto_msgTypes[5]
+	mi := &file_plugin_codecs_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -436,7 +570,7 @@ func (x *LLOStreamObservationProto) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LLOStreamObservationProto.ProtoReflect.Descriptor instead.
 func (*LLOStreamObservationProto) Descriptor() ([]byte, []int) {
-	return file_plugin_codecs_proto_rawDescGZIP(), []int{5}
+	return file_plugin_codecs_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *LLOStreamObservationProto) GetValid() bool {
@@ -464,12 +598,15 @@ type LLOOutcomeProto struct {
 	ChannelDefinitions               []*LLOChannelIDAndDefinitionProto        `protobuf:"bytes,3,rep,name=channelDefinitions,proto3" json:"channelDefinitions,omitempty"`
 	ValidAfterSeconds                []*LLOChannelIDAndValidAfterSecondsProto `protobuf:"bytes,4,rep,name=validAfterSeconds,proto3" json:"validAfterSeconds,omitempty"`
 	StreamAggregates                 []*LLOStreamAggregate                    `protobuf:"bytes,5,rep,name=streamAggregates,proto3" json:"streamAggregates,omitempty"`
+	StreamDispersions                []*LLOStreamDispersion                   `protobuf:"bytes,6,rep,name=streamDispersions,proto3" json:"streamDispersions,omitempty"`
+	WindDownRoundsRemaining          uint32                                   `protobuf:"varint,7,opt,name=windDownRoundsRemaining,proto3" json:"windDownRoundsRemaining,omitempty"`
+	DegradedRounds                   uint32                                   `protobuf:"varint,8,opt,name=degradedRounds,proto3" json:"degradedRounds,omitempty"`
 }
 
 func (x *LLOOutcomeProto) Reset() {
 	*x = LLOOutcomeProto{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_plugin_codecs_proto_msgTypes[6]
+		mi := &file_plugin_codecs_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -482,7 +619,7 @@ func (x *LLOOutcomeProto) String() string {
 func (*LLOOutcomeProto) ProtoMessage() {}
 
 func (x *LLOOutcomeProto) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_codecs_proto_msgTypes[6]
+	mi := &file_plugin_codecs_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -495,7 +632,7 @@ func (x *LLOOutcomeProto) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LLOOutcomeProto.ProtoReflect.Descriptor instead.
 func (*LLOOutcomeProto) Descriptor() ([]byte, []int) {
-	return file_plugin_codecs_proto_rawDescGZIP(), []int{6}
+	return file_plugin_codecs_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *LLOOutcomeProto) GetLifeCycleStage() string {
@@ -533,6 +670,27 @@ func (x *LLOOutcomeProto) GetStreamAggregates() []*LLOStreamAggregate {
 	return nil
 }
 
+func (x *LLOOutcomeProto) GetStreamDispersions() []*LLOStreamDispersion {
+	if x != nil {
+		return x.StreamDispersions
+	}
+	return nil
+}
+
+func (x *LLOOutcomeProto) GetWindDownRoundsRemaining() uint32 {
+	if x != nil {
+		return x.WindDownRoundsRemaining
+	}
+	return 0
+}
+
+func (x *LLOOutcomeProto) GetDegradedRounds() uint32 {
+	if x != nil {
+		return x.DegradedRounds
+	}
+	return 0
+}
+
 type LLOChannelIDAndDefinitionProto struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -545,7 +703,7 @@ type LLOChannelIDAndDefinitionProto struct {
 func (x *LLOChannelIDAndDefinitionProto) Reset() {
 	*x = LLOChannelIDAndDefinitionProto{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_plugin_codecs_proto_msgTypes[7]
+		mi := &file_plugin_codecs_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -558,7 +716,7 @@ func (x *LLOChannelIDAndDefinitionProto) String() string {
 func (*LLOChannelIDAndDefinitionProto) ProtoMessage() {}
 
 func (x *LLOChannelIDAndDefinitionProto) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_codecs_proto_msgTypes[7]
+	mi := &file_plugin_codecs_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -571,7 +729,7 @@ func (x *LLOChannelIDAndDefinitionProto) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LLOChannelIDAndDefinitionProto.ProtoReflect.Descriptor instead.
 func (*LLOChannelIDAndDefinitionProto) Descriptor() ([]byte, []int) {
-	return file_plugin_codecs_proto_rawDescGZIP(), []int{7}
+	return file_plugin_codecs_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *LLOChannelIDAndDefinitionProto) GetChannelID() uint32 {
@@ -600,7 +758,7 @@ type LLOChannelIDAndValidAfterSecondsProto struct {
 func (x *LLOChannelIDAndValidAfterSecondsProto) Reset() {
 	*x = LLOChannelIDAndValidAfterSecondsProto{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_plugin_codecs_proto_msgTypes[8]
+		mi := &file_plugin_codecs_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -613,7 +771,7 @@ func (x *LLOChannelIDAndValidAfterSecondsProto) String() string {
 func (*LLOChannelIDAndValidAfterSecondsProto) ProtoMessage() {}
 
 func (x *LLOChannelIDAndValidAfterSecondsProto) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_codecs_proto_msgTypes[8]
+	mi := &file_plugin_codecs_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -626,7 +784,7 @@ func (x *LLOChannelIDAndValidAfterSecondsProto) ProtoReflect() protoreflect.Mess
 
 // Deprecated: Use LLOChannelIDAndValidAfterSecondsProto.ProtoReflect.Descriptor instead.
 func (*LLOChannelIDAndValidAfterSecondsProto) Descriptor() ([]byte, []int) {
-	return file_plugin_codecs_proto_rawDescGZIP(), []int{8}
+	return file_plugin_codecs_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *LLOChannelIDAndValidAfterSecondsProto) GetChannelID() uint32 {
@@ -656,7 +814,7 @@ type LLOStreamAggregate struct {
 func (x *LLOStreamAggregate) Reset() {
 	*x = LLOStreamAggregate{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_plugin_codecs_proto_msgTypes[9]
+		mi := &file_plugin_codecs_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -669,7 +827,7 @@ func (x *LLOStreamAggregate) String() string {
 func (*LLOStreamAggregate) ProtoMessage() {}
 
 func (x *LLOStreamAggregate) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_codecs_proto_msgTypes[9]
+	mi := &file_plugin_codecs_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -682,7 +840,7 @@ func (x *LLOStreamAggregate) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LLOStreamAggregate.ProtoReflect.Descriptor instead.
 func (*LLOStreamAggregate) Descriptor() ([]byte, []int) {
-	return file_plugin_codecs_proto_rawDescGZIP(), []int{9}
+	return file_plugin_codecs_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *LLOStreamAggregate) GetStreamID() uint32 {
@@ -706,11 +864,66 @@ func (x *LLOStreamAggregate) GetAggregator() uint32 {
 	return 0
 }
 
+type LLOStreamDispersion struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StreamID    uint32          `protobuf:"varint,1,opt,name=streamID,proto3" json:"streamID,omitempty"`
+	StreamValue *LLOStreamValue `protobuf:"bytes,2,opt,name=streamValue,proto3" json:"streamValue,omitempty"`
+}
+
+func (x *LLOStreamDispersion) Reset() {
+	*x = LLOStreamDispersion{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_plugin_codecs_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LLOStreamDispersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LLOStreamDispersion) ProtoMessage() {}
+
+func (x *LLOStreamDispersion) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_codecs_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LLOStreamDispersion.ProtoReflect.Descriptor instead.
+func (*LLOStreamDispersion) Descriptor() ([]byte, []int) {
+	return file_plugin_codecs_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *LLOStreamDispersion) GetStreamID() uint32 {
+	if x != nil {
+		return x.StreamID
+	}
+	return 0
+}
+
+func (x *LLOStreamDispersion) GetStreamValue() *LLOStreamValue {
+	if x != nil {
+		return x.StreamValue
+	}
+	return nil
+}
+
 var File_plugin_codecs_proto protoreflect.FileDescriptor
 
 var file_plugin_codecs_proto_rawDesc = []byte{
 	0x0a, 0x13, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x73, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x76, 0x31, 0x22, 0xea, 0x04, 0x0a, 0x13, 0x4c, 0x4c,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x76, 0x31, 0x22, 0xec, 0x06, 0x0a, 0x13, 0x4c, 0x4c,
 	0x4f, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x74,
 	0x6f, 0x12, 0x44, 0x0a, 0x1d, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x50, 0x72, 0x65,
 	0x64, 0x65, 0x63, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x52, 0x65, 0x74, 0x69, 0x72, 0x65, 0x6d, 0x65,
@@ -737,99 +950,144 @@ var file_plugin_codecs_proto_rawDesc = []byte{
 	0x31, 0x2e, 0x4c, 0x4c, 0x4f, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e,
 	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x56, 0x61, 0x6c, 0x75,
 	0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x56,
-	0x61, 0x6c, 0x75, 0x65, 0x73, 0x1a, 0x6a, 0x0a, 0x1d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x12, 0x75, 0x6e, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x64, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x44, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28,
+	0x0d, 0x52, 0x12, 0x75, 0x6e, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x49, 0x44, 0x73, 0x12, 0x59, 0x0a, 0x10, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x2d, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x4c, 0x4f, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x10,
+	0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73,
+	0x12, 0x30, 0x0a, 0x13, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x4f, 0x75, 0x74, 0x63,
+	0x6f, 0x6d, 0x65, 0x48, 0x61, 0x73, 0x68, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x13, 0x70,
+	0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x48, 0x61,
+	0x73, 0x68, 0x1a, 0x6a, 0x0a, 0x1d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x33, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x4c, 0x4f, 0x43, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72,
+	0x6f, 0x74, 0x6f, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x53,
+	0x0a, 0x11, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x28, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x4c, 0x4f, 0x53, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x1a, 0x43, 0x0a, 0x15, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x85, 0x01, 0x0a, 0x0e, 0x4c, 0x4c, 0x4f,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x2b, 0x0a, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x76, 0x31, 0x2e, 0x4c,
+	0x4c, 0x4f, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x2e, 0x54, 0x79,
+	0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x30,
+	0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61,
+	0x6c, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x10, 0x01, 0x12, 0x10,
+	0x0a, 0x0c, 0x50, 0x72, 0x69, 0x63, 0x65, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x10, 0x02,
+	0x22, 0x57, 0x0a, 0x13, 0x4c, 0x4c, 0x4f, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x62, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x65, 0x6e,
+	0x63, 0x68, 0x6d, 0x61, 0x72, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x62, 0x65,
+	0x6e, 0x63, 0x68, 0x6d, 0x61, 0x72, 0x6b, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x73, 0x6b, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x61, 0x73, 0x6b, 0x22, 0x51, 0x0a, 0x1a, 0x4c, 0x4c, 0x4f,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65,
+	0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x12, 0x33, 0x0a, 0x05, 0x70, 0x61, 0x69, 0x72, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x4c, 0x4f, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x56,
+	0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x52, 0x05, 0x70, 0x61, 0x69, 0x72, 0x73, 0x22, 0x49, 0x0a, 0x19,
+	0x4c, 0x4c, 0x4f, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x50, 0x72,
+	0x69, 0x63, 0x65, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69,
+	0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x22, 0x86, 0x01, 0x0a, 0x19, 0x4c, 0x4c, 0x4f, 0x43,
 	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x33, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x4c, 0x4f,
-	0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f,
-	0x6e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
-	0x01, 0x1a, 0x53, 0x0a, 0x11, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65,
-	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x28, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x4c, 0x4f,
-	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x73, 0x0a, 0x0e, 0x4c, 0x4c, 0x4f, 0x53, 0x74, 0x72,
-	0x65, 0x61, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x2b, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x4c, 0x4f, 0x53,
-	0x74, 0x72, 0x65, 0x61, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52,
-	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x1e, 0x0a, 0x04, 0x54,
-	0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x10, 0x00,
-	0x12, 0x09, 0x0a, 0x05, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x10, 0x01, 0x22, 0x57, 0x0a, 0x13, 0x4c,
-	0x4c, 0x4f, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x51, 0x75, 0x6f,
-	0x74, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x03, 0x62, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x65, 0x6e, 0x63, 0x68, 0x6d, 0x61, 0x72,
-	0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x62, 0x65, 0x6e, 0x63, 0x68, 0x6d, 0x61,
-	0x72, 0x6b, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x73, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x03, 0x61, 0x73, 0x6b, 0x22, 0x86, 0x01, 0x0a, 0x19, 0x4c, 0x4c, 0x4f, 0x43, 0x68, 0x61, 0x6e,
-	0x6e, 0x65, 0x6c, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f,
-	0x74, 0x6f, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x46, 0x6f, 0x72, 0x6d,
-	0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
-	0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x31, 0x0a, 0x07, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d,
-	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x4c, 0x4f,
-	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e,
-	0x52, 0x07, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6f, 0x70, 0x74,
-	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x6f, 0x70, 0x74, 0x73, 0x22, 0x51, 0x0a,
-	0x13, 0x4c, 0x4c, 0x4f, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69,
-	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x44,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x44,
-	0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72,
-	0x22, 0x47, 0x0a, 0x19, 0x4c, 0x4c, 0x4f, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x62, 0x73,
-	0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x14, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xf6, 0x02, 0x0a, 0x0f, 0x4c, 0x4c,
-	0x4f, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x26, 0x0a,
-	0x0e, 0x6c, 0x69, 0x66, 0x65, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x67, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6c, 0x69, 0x66, 0x65, 0x43, 0x79, 0x63, 0x6c, 0x65,
-	0x53, 0x74, 0x61, 0x67, 0x65, 0x12, 0x4a, 0x0a, 0x20, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x4e, 0x61,
-	0x6e, 0x6f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x20, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x54, 0x69, 0x6d,
-	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64,
-	0x73, 0x12, 0x52, 0x0a, 0x12, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x65, 0x66, 0x69,
-	0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e,
-	0x76, 0x31, 0x2e, 0x4c, 0x4c, 0x4f, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x44, 0x41,
+	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x46,
+	0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x72, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x31, 0x0a, 0x07, 0x73, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x4c, 0x4f, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x07, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x12, 0x12, 0x0a, 0x04,
+	0x6f, 0x70, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x6f, 0x70, 0x74, 0x73,
+	0x22, 0x51, 0x0a, 0x13, 0x4c, 0x4c, 0x4f, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x44, 0x65, 0x66,
+	0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x73, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x49, 0x44, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x6f,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61,
+	0x74, 0x6f, 0x72, 0x22, 0x47, 0x0a, 0x19, 0x4c, 0x4c, 0x4f, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x9f, 0x04, 0x0a,
+	0x0f, 0x4c, 0x4c, 0x4f, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x26, 0x0a, 0x0e, 0x6c, 0x69, 0x66, 0x65, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x53, 0x74, 0x61,
+	0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6c, 0x69, 0x66, 0x65, 0x43, 0x79,
+	0x63, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x67, 0x65, 0x12, 0x4a, 0x0a, 0x20, 0x6f, 0x62, 0x73, 0x65,
+	0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x20, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x73, 0x12, 0x52, 0x0a, 0x12, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x44,
+	0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x22, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x4c, 0x4f, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
+	0x49, 0x44, 0x41, 0x6e, 0x64, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x50,
+	0x72, 0x6f, 0x74, 0x6f, 0x52, 0x12, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x65, 0x66,
+	0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x57, 0x0a, 0x11, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x41, 0x66, 0x74, 0x65, 0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x4c, 0x4f, 0x43, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x49, 0x44, 0x41, 0x6e, 0x64, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x41, 0x66, 0x74,
+	0x65, 0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x52, 0x11,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x41, 0x66, 0x74, 0x65, 0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x73, 0x12, 0x42, 0x0a, 0x10, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x41, 0x67, 0x67, 0x72, 0x65,
+	0x67, 0x61, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x76, 0x31,
+	0x2e, 0x4c, 0x4c, 0x4f, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67,
+	0x61, 0x74, 0x65, 0x52, 0x10, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x41, 0x67, 0x67, 0x72, 0x65,
+	0x67, 0x61, 0x74, 0x65, 0x73, 0x12, 0x45, 0x0a, 0x11, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x44,
+	0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x4c, 0x4f, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x44,
+	0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x11, 0x73, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x44, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x38, 0x0a, 0x17,
+	0x77, 0x69, 0x6e, 0x64, 0x44, 0x6f, 0x77, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x52, 0x65,
+	0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x17, 0x77,
+	0x69, 0x6e, 0x64, 0x44, 0x6f, 0x77, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x52, 0x65, 0x6d,
+	0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x26, 0x0a, 0x0e, 0x64, 0x65, 0x67, 0x72, 0x61, 0x64,
+	0x65, 0x64, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e,
+	0x64, 0x65, 0x67, 0x72, 0x61, 0x64, 0x65, 0x64, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x22, 0x8b,
+	0x01, 0x0a, 0x1e, 0x4c, 0x4c, 0x4f, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x44, 0x41,
 	0x6e, 0x64, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x74,
-	0x6f, 0x52, 0x12, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x57, 0x0a, 0x11, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x41, 0x66,
-	0x74, 0x65, 0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x29, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x4c, 0x4f, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
-	0x49, 0x44, 0x41, 0x6e, 0x64, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x41, 0x66, 0x74, 0x65, 0x72, 0x53,
-	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x52, 0x11, 0x76, 0x61, 0x6c,
-	0x69, 0x64, 0x41, 0x66, 0x74, 0x65, 0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x42,
-	0x0a, 0x10, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
-	0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x4c,
-	0x4f, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65,
-	0x52, 0x10, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
-	0x65, 0x73, 0x22, 0x8b, 0x01, 0x0a, 0x1e, 0x4c, 0x4c, 0x4f, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65,
-	0x6c, 0x49, 0x44, 0x41, 0x6e, 0x64, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x6f, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x44, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x44, 0x12,
+	0x4b, 0x0a, 0x11, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x4c, 0x4f, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x52, 0x11, 0x63, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x73, 0x0a, 0x25,
+	0x4c, 0x4c, 0x4f, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x44, 0x41, 0x6e, 0x64, 0x56,
+	0x61, 0x6c, 0x69, 0x64, 0x41, 0x66, 0x74, 0x65, 0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73,
 	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
 	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65,
-	0x6c, 0x49, 0x44, 0x12, 0x4b, 0x0a, 0x11, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x65,
-	0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d,
-	0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x4c, 0x4f, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x65,
-	0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x52, 0x11, 0x63,
-	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e,
-	0x22, 0x73, 0x0a, 0x25, 0x4c, 0x4c, 0x4f, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x44,
-	0x41, 0x6e, 0x64, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x41, 0x66, 0x74, 0x65, 0x72, 0x53, 0x65, 0x63,
-	0x6f, 0x6e, 0x64, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x68, 0x61,
-	0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x63, 0x68,
-	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x44, 0x12, 0x2c, 0x0a, 0x11, 0x76, 0x61, 0x6c, 0x69, 0x64,
-	0x41, 0x66, 0x74, 0x65, 0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x11, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x41, 0x66, 0x74, 0x65, 0x72, 0x53, 0x65,
-	0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0x86, 0x01, 0x0a, 0x12, 0x4c, 0x4c, 0x4f, 0x53, 0x74, 0x72,
-	0x65, 0x61, 0x6d, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08,
-	0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08,
-	0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x44, 0x12, 0x34, 0x0a, 0x0b, 0x73, 0x74, 0x72, 0x65,
-	0x61, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
-	0x76, 0x31, 0x2e, 0x4c, 0x4c, 0x4f, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x56, 0x61, 0x6c, 0x75,
-	0x65, 0x52, 0x0b, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1e,
-	0x0a, 0x0a, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x0a, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x42, 0x07,
-	0x5a, 0x05, 0x2e, 0x3b, 0x6c, 0x6c, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6c, 0x49, 0x44, 0x12, 0x2c, 0x0a, 0x11, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x41, 0x66, 0x74, 0x65,
+	0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x41, 0x66, 0x74, 0x65, 0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x73, 0x22, 0x86, 0x01, 0x0a, 0x12, 0x4c, 0x4c, 0x4f, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x41,
+	0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x73, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x49, 0x44, 0x12, 0x34, 0x0a, 0x0b, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x4c,
+	0x4c, 0x4f, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x0b, 0x73,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x67,
+	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a,
+	0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x22, 0x67, 0x0a, 0x13, 0x4c, 0x4c,
+	0x4f, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x44, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x44, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x08, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x44, 0x12, 0x34, 0x0a,
+	0x0b, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x4c, 0x4f, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x0b, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x42, 0x07, 0x5a, 0x05, 0x2e, 0x3b, 0x6c, 0x6c, 0x6f, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -845,39 +1103,47 @@ func file_plugin_codecs_proto_rawDescGZIP() []byte {
 }
 
 var file_plugin_codecs_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_plugin_codecs_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
-var file_plugin_codecs_proto_goTypes = []interface{}{
+var file_plugin_codecs_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_plugin_codecs_proto_goTypes = []any{
 	(LLOStreamValue_Type)(0),                      // 0: v1.LLOStreamValue.Type
 	(*LLOObservationProto)(nil),                   // 1: v1.LLOObservationProto
 	(*LLOStreamValue)(nil),                        // 2: v1.LLOStreamValue
 	(*LLOStreamValueQuote)(nil),                   // 3: v1.LLOStreamValueQuote
-	(*LLOChannelDefinitionProto)(nil),             // 4: v1.LLOChannelDefinitionProto
-	(*LLOStreamDefinition)(nil),                   // 5: v1.LLOStreamDefinition
-	(*LLOStreamObservationProto)(nil),             // 6: v1.LLOStreamObservationProto
-	(*LLOOutcomeProto)(nil),                       // 7: v1.LLOOutcomeProto
-	(*LLOChannelIDAndDefinitionProto)(nil),        // 8: v1.LLOChannelIDAndDefinitionProto
-	(*LLOChannelIDAndValidAfterSecondsProto)(nil), // 9: v1.LLOChannelIDAndValidAfterSecondsProto
-	(*LLOStreamAggregate)(nil),                    // 10: v1.LLOStreamAggregate
-	nil,                                           // 11: v1.LLOObservationProto.UpdateChannelDefinitionsEntry
-	nil,                                           // 12: v1.LLOObservationProto.StreamValuesEntry
+	(*LLOStreamValuePriceVolumes)(nil),            // 4: v1.LLOStreamValuePriceVolumes
+	(*LLOStreamValuePriceVolume)(nil),             // 5: v1.LLOStreamValuePriceVolume
+	(*LLOChannelDefinitionProto)(nil),             // 6: v1.LLOChannelDefinitionProto
+	(*LLOStreamDefinition)(nil),                   // 7: v1.LLOStreamDefinition
+	(*LLOStreamObservationProto)(nil),             // 8: v1.LLOStreamObservationProto
+	(*LLOOutcomeProto)(nil),                       // 9: v1.LLOOutcomeProto
+	(*LLOChannelIDAndDefinitionProto)(nil),        // 10: v1.LLOChannelIDAndDefinitionProto
+	(*LLOChannelIDAndValidAfterSecondsProto)(nil), // 11: v1.LLOChannelIDAndValidAfterSecondsProto
+	(*LLOStreamAggregate)(nil),                    // 12: v1.LLOStreamAggregate
+	(*LLOStreamDispersion)(nil),                   // 13: v1.LLOStreamDispersion
+	nil,                                           // 14: v1.LLOObservationProto.UpdateChannelDefinitionsEntry
+	nil,                                           // 15: v1.LLOObservationProto.StreamValuesEntry
+	nil,                                           // 16: v1.LLOObservationProto.StreamTimestampsEntry
 }
 var file_plugin_codecs_proto_depIdxs = []int32{
-	11, // 0: v1.LLOObservationProto.updateChannelDefinitions:type_name -> v1.LLOObservationProto.UpdateChannelDefinitionsEntry
-	12, // 1: v1.LLOObservationProto.streamValues:type_name -> v1.LLOObservationProto.StreamValuesEntry
-	0,  // 2: v1.LLOStreamValue.type:type_name -> v1.LLOStreamValue.Type
-	5,  // 3: v1.LLOChannelDefinitionProto.streams:type_name -> v1.LLOStreamDefinition
-	8,  // 4: v1.LLOOutcomeProto.channelDefinitions:type_name -> v1.LLOChannelIDAndDefinitionProto
-	9,  // 5: v1.LLOOutcomeProto.validAfterSeconds:type_name -> v1.LLOChannelIDAndValidAfterSecondsProto
-	10, // 6: v1.LLOOutcomeProto.streamAggregates:type_name -> v1.LLOStreamAggregate
-	4,  // 7: v1.LLOChannelIDAndDefinitionProto.channelDefinition:type_name -> v1.LLOChannelDefinitionProto
-	2,  // 8: v1.LLOStreamAggregate.streamValue:type_name -> v1.LLOStreamValue
-	4,  // 9: v1.LLOObservationProto.UpdateChannelDefinitionsEntry.value:type_name -> v1.LLOChannelDefinitionProto
-	2,  // 10: v1.LLOObservationProto.StreamValuesEntry.value:type_name -> v1.LLOStreamValue
-	11, // [11:11] is the sub-list for method output_type
-	11, // [11:11] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	14, // 0: v1.LLOObservationProto.updateChannelDefinitions:type_name -> v1.LLOObservationProto.UpdateChannelDefinitionsEntry
+	15, // 1: v1.LLOObservationProto.streamValues:type_name -> v1.LLOObservationProto.StreamValuesEntry
+	16, // 2: v1.LLOObservationProto.streamTimestamps:type_name -> v1.LLOObservationProto.StreamTimestampsEntry
+	0,  // 3: v1.LLOStreamValue.type:type_name -> v1.LLOStreamValue.Type
+	5,  // 4: v1.LLOStreamValuePriceVolumes.pairs:type_name -> v1.LLOStreamValuePriceVolume
+	7,  // 5: v1.LLOChannelDefinitionProto.streams:type_name -> v1.LLOStreamDefinition
+	10, // 6: v1.LLOOutcomeProto.channelDefinitions:type_name -> v1.LLOChannelIDAndDefinitionProto
+	11, // 7: v1.LLOOutcomeProto.validAfterSeconds:type_name -> v1.LLOChannelIDAndValidAfterSecondsProto
+	12, // 8: v1.LLOOutcomeProto.streamAggregates:type_name -> v1.LLOStreamAggregate
+	13, // 9: v1.LLOOutcomeProto.streamDispersions:type_name -> v1.LLOStreamDispersion
+	6,  // 10: v1.LLOChannelIDAndDefinitionProto.channelDefinition:type_name -> v1.LLOChannelDefinitionProto
+	2,  // 11: v1.LLOStreamAggregate.streamValue:type_name -> v1.LLOStreamValue
+	2,  // 12: v1.LLOStreamDispersion.streamValue:type_name -> v1.LLOStreamValue
+	6,  // 13: v1.LLOObservationProto.UpdateChannelDefinitionsEntry.value:type_name -> v1.LLOChannelDefinitionProto
+	2,  // 14: v1.LLOObservationProto.StreamValuesEntry.value:type_name -> v1.LLOStreamValue
+	15, // [15:15] is the sub-list for method output_type
+	15, // [15:15] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_plugin_codecs_proto_init() }
@@ -886,7 +1152,7 @@ func file_plugin_codecs_proto_init() {
 		return
 	}
 	if !protoimpl.UnsafeEnabled {
-		file_plugin_codecs_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+		file_plugin_codecs_proto_msgTypes[0].Exporter = func(v any, i int) any {
 			switch v := v.(*LLOObservationProto); i {
 			case 0:
 				return &v.state
@@ -898,7 +1164,7 @@ func file_plugin_codecs_proto_init() {
 				return nil
 			}
 		}
-		file_plugin_codecs_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+		file_plugin_codecs_proto_msgTypes[1].Exporter = func(v any, i int) any {
 			switch v := v.(*LLOStreamValue); i {
 			case 0:
 				return &v.state
@@ -910,7 +1176,7 @@ func file_plugin_codecs_proto_init() {
 				return nil
 			}
 		}
-		file_plugin_codecs_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+		file_plugin_codecs_proto_msgTypes[2].Exporter = func(v any, i int) any {
 			switch v := v.(*LLOStreamValueQuote); i {
 			case 0:
 				return &v.state
@@ -922,7 +1188,31 @@ func file_plugin_codecs_proto_init() {
 				return nil
 			}
 		}
-		file_plugin_codecs_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+		file_plugin_codecs_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*LLOStreamValuePriceVolumes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plugin_codecs_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*LLOStreamValuePriceVolume); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_plugin_codecs_proto_msgTypes[5].Exporter = func(v any, i int) any {
 			switch v := v.(*LLOChannelDefinitionProto); i {
 			case 0:
 				return &v.state
@@ -934,7 +1224,7 @@ func file_plugin_codecs_proto_init() {
 				return nil
 			}
 		}
-		file_plugin_codecs_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+		file_plugin_codecs_proto_msgTypes[6].Exporter = func(v any, i int) any {
 			switch v := v.(*LLOStreamDefinition); i {
 			case 0:
 				return &v.state
@@ -946,7 +1236,7 @@ func file_plugin_codecs_proto_init() {
 				return nil
 			}
 		}
-		file_plugin_codecs_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+		file_plugin_codecs_proto_msgTypes[7].Exporter = func(v any, i int) any {
 			switch v := v.(*LLOStreamObservationProto); i {
 			case 0:
 				return &v.state
@@ -958,7 +1248,7 @@ func file_plugin_codecs_proto_init() {
 				return nil
 			}
 		}
-		file_plugin_codecs_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+		file_plugin_codecs_proto_msgTypes[8].Exporter = func(v any, i int) any {
 			switch v := v.(*LLOOutcomeProto); i {
 			case 0:
 				return &v.state
@@ -970,7 +1260,7 @@ func file_plugin_codecs_proto_init() {
 				return nil
 			}
 		}
-		file_plugin_codecs_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+		file_plugin_codecs_proto_msgTypes[9].Exporter = func(v any, i int) any {
 			switch v := v.(*LLOChannelIDAndDefinitionProto); i {
 			case 0:
 				return &v.state
@@ -982,7 +1272,7 @@ func file_plugin_codecs_proto_init() {
 				return nil
 			}
 		}
-		file_plugin_codecs_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+		file_plugin_codecs_proto_msgTypes[10].Exporter = func(v any, i int) any {
 			switch v := v.(*LLOChannelIDAndValidAfterSecondsProto); i {
 			case 0:
 				return &v.state
@@ -994,7 +1284,7 @@ func file_plugin_codecs_proto_init() {
 				return nil
 			}
 		}
-		file_plugin_codecs_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+		file_plugin_codecs_proto_msgTypes[11].Exporter = func(v any, i int) any {
 			switch v := v.(*LLOStreamAggregate); i {
 			case 0:
 				return &v.state
@@ -1006,6 +1296,18 @@ func file_plugin_codecs_proto_init() {
 				return nil
 			}
 		}
+		file_plugin_codecs_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*LLOStreamDispersion); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1013,7 +1315,7 @@ func file_plugin_codecs_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_plugin_codecs_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   12,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
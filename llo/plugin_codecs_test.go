@@ -56,6 +56,12 @@ func Fuzz_protoObservationCodec_Decode(f *testing.F) {
 			},
 			9:  &Quote{},
 			10: (*Quote)(nil),
+			11: &PriceVolumes{
+				{Price: decimal.NewFromInt(1010), Volume: decimal.NewFromInt(3)},
+				{Price: decimal.NewFromInt(1012), Volume: decimal.NewFromInt(1)},
+			},
+			12: &PriceVolumes{},
+			13: (*PriceVolumes)(nil),
 		},
 	}
 
@@ -155,6 +161,9 @@ func Test_protoObservationCodec_Properties(t *testing.T) {
 			"RemoveChannelIDs":              genRemoveChannelIDs(),
 			"UpdateChannelDefinitions":      genChannelDefinitions(),
 			"StreamValues":                  genStreamValuesMap(),
+			"UnchangedStreamIDs":            genUnchangedStreamIDs(),
+			"StreamTimestamps":              genStreamTimestamps(),
+			"PreviousOutcomeHash":           genPreviousOutcomeHash(),
 		}),
 	))
 
@@ -181,6 +190,9 @@ func Test_protoOutcomeCodec_Properties(t *testing.T) {
 			"ChannelDefinitions":               genChannelDefinitions(),
 			"ValidAfterSeconds":                gen.MapOf(gen.UInt32(), gen.UInt32()),
 			"StreamAggregates":                 genStreamAggregates(),
+			"StreamDispersions":                genStreamDispersions(),
+			"WindDownRoundsRemaining":          gen.UInt32(),
+			"DegradedRounds":                   gen.UInt32(),
 		}),
 	))
 
@@ -201,6 +213,24 @@ func genRemoveChannelIDs() gopter.Gen {
 	return gen.MapOf(gen.UInt32(), gen.Const(struct{}{}))
 }
 
+func genUnchangedStreamIDs() gopter.Gen {
+	return gen.MapOf(gen.UInt32(), gen.Const(struct{}{}))
+}
+
+func genStreamTimestamps() gopter.Gen {
+	return gen.MapOf(gen.UInt32(), gen.Int64()).Map(func(m map[uint32]int64) StreamTimestamps {
+		return m
+	})
+}
+
+func genPreviousOutcomeHash() gopter.Gen {
+	return func(p *gopter.GenParameters) *gopter.GenResult {
+		var h [32]byte
+		p.Rng.Read(h[:])
+		return gopter.NewGenResult(h, gopter.NoShrinker)
+	}
+}
+
 func genChannelDefinitions() gopter.Gen {
 	return gen.MapOf(gen.UInt32(), genChannelDefinition())
 }
@@ -233,6 +263,19 @@ func genStreamValuesMap() gopter.Gen {
 	})
 }
 
+func genStreamDispersions() gopter.Gen {
+	return genStreamValues().Map(func(values []StreamValue) map[llotypes.StreamID]StreamValue {
+		m := make(map[llotypes.StreamID]StreamValue)
+		for i, v := range values {
+			// dispersions are never nil
+			if v != nil {
+				m[llotypes.StreamID(i)] = v
+			}
+		}
+		return m
+	})
+}
+
 func genChannelDefinition() gopter.Gen {
 	return gen.StrictStruct(reflect.TypeOf(llotypes.ChannelDefinition{}), map[string]gopter.Gen{
 		"ReportFormat": genReportFormat(),
@@ -270,6 +313,9 @@ func equalObservations(obs, obs2 Observation) bool {
 	if obs.UnixTimestampNanoseconds != obs2.UnixTimestampNanoseconds {
 		return false
 	}
+	if obs.PreviousOutcomeHash != obs2.PreviousOutcomeHash {
+		return false
+	}
 	if len(obs.RemoveChannelIDs) != len(obs2.RemoveChannelIDs) {
 		return false
 	}
@@ -310,6 +356,31 @@ func equalObservations(obs, obs2 Observation) bool {
 			return false
 		}
 	}
+
+	if len(obs.UnchangedStreamIDs) != len(obs2.UnchangedStreamIDs) {
+		return false
+	}
+	for k := range obs.UnchangedStreamIDs {
+		if _, ok := obs2.UnchangedStreamIDs[k]; !ok {
+			return false
+		}
+	}
+
+	// Timestamps of streams that are neither observed nor unchanged are
+	// dropped by the codec
+	for k, v := range obs.StreamTimestamps {
+		_, observed := obs2.StreamValues[k]
+		_, unchanged := obs2.UnchangedStreamIDs[k]
+		v2, ok := obs2.StreamTimestamps[k]
+		if (observed || unchanged) != ok || (ok && v != v2) {
+			return false
+		}
+	}
+	for k := range obs2.StreamTimestamps {
+		if _, ok := obs.StreamTimestamps[k]; !ok {
+			return false
+		}
+	}
 	return true
 }
 
@@ -320,6 +391,12 @@ func equalOutcomes(outcome, outcome2 Outcome) bool {
 	if outcome.ObservationsTimestampNanoseconds != outcome2.ObservationsTimestampNanoseconds {
 		return false
 	}
+	if outcome.WindDownRoundsRemaining != outcome2.WindDownRoundsRemaining {
+		return false
+	}
+	if outcome.DegradedRounds != outcome2.DegradedRounds {
+		return false
+	}
 	if len(outcome.ChannelDefinitions) != len(outcome2.ChannelDefinitions) {
 		return false
 	}
@@ -378,6 +455,19 @@ func equalOutcomes(outcome, outcome2 Outcome) bool {
 			return false
 		}
 	}
+
+	if len(outcome.StreamDispersions) != len(outcome2.StreamDispersions) {
+		return false
+	}
+	for k, v := range outcome.StreamDispersions {
+		v2, ok := outcome2.StreamDispersions[k]
+		if !ok {
+			return false
+		}
+		if !equalStreamValues(v, v2) {
+			return false
+		}
+	}
 	return true
 }
 
@@ -430,6 +520,17 @@ func Test_protoObservationCodec(t *testing.T) {
 				6: (*Decimal)(nil),
 				7: nil,
 			},
+			UnchangedStreamIDs: map[llotypes.StreamID]struct{}{
+				8: {},
+				9: {},
+			},
+			StreamTimestamps: StreamTimestamps{
+				4:  1234567000,
+				6:  1234567001,
+				8:  1234567002,
+				10: 1234567003,
+			},
+			PreviousOutcomeHash: [32]byte{11, 12, 13},
 		}
 
 		obsBytes, err := (protoObservationCodec{}).Encode(obs)
@@ -441,6 +542,8 @@ func Test_protoObservationCodec(t *testing.T) {
 		expectedObs := obs
 		delete(expectedObs.StreamValues, 6) // nils will be dropped
 		delete(expectedObs.StreamValues, 7) // nils will be dropped
+		// timestamps for dropped or unknown streams will be dropped
+		expectedObs.StreamTimestamps = StreamTimestamps{4: 1234567000, 8: 1234567002}
 
 		assert.Equal(t, expectedObs, obs2)
 	})
@@ -462,6 +565,28 @@ func Test_protoObservationCodec(t *testing.T) {
 			_, err = (protoObservationCodec{}).Decode(obsBytes)
 			require.EqualError(t, err, "failed to decode observation; duplicate channel ID in RemoveChannelIDs: 1")
 		})
+		t.Run("duplicate UnchangedStreamIDs", func(t *testing.T) {
+			pbuf := &LLOObservationProto{
+				UnchangedStreamIDs: []uint32{2, 2},
+			}
+
+			obsBytes, err := proto.Marshal(pbuf)
+			require.NoError(t, err)
+
+			_, err = (protoObservationCodec{}).Decode(obsBytes)
+			require.EqualError(t, err, "failed to decode observation; duplicate stream ID in UnchangedStreamIDs: 2")
+		})
+		t.Run("PreviousOutcomeHash of wrong length", func(t *testing.T) {
+			pbuf := &LLOObservationProto{
+				PreviousOutcomeHash: []byte{1, 2, 3},
+			}
+
+			obsBytes, err := proto.Marshal(pbuf)
+			require.NoError(t, err)
+
+			_, err = (protoObservationCodec{}).Decode(obsBytes)
+			require.EqualError(t, err, "failed to decode observation; invalid PreviousOutcomeHash: expected 32 bytes, got 3")
+		})
 		t.Run("invalid LLOStreamValue", func(t *testing.T) {
 			t.Run("nil/missing value", func(t *testing.T) {
 				pbuf := &LLOObservationProto{
@@ -543,6 +668,10 @@ func Test_protoOutcomeCodec(t *testing.T) {
 					},
 				},
 			},
+			StreamDispersions: map[llotypes.StreamID]StreamValue{
+				1: ToDecimal(decimal.NewFromInt(3)),
+				4: ToDecimal(decimal.NewFromInt(2)),
+			},
 		}
 
 		outcomeBytes, err := (protoOutcomeCodec{}).Encode(outcome)
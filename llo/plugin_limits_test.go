@@ -0,0 +1,154 @@
+package llo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+)
+
+// These tests construct the largest Observations and Outcomes that the
+// protocol is expected to handle and check that they encode within the
+// limits advertised by NewReportingPlugin. If they fail, either the limits or
+// the LLO-specific constants in plugin.go need to change, otherwise the
+// protocol can halt in production.
+
+const (
+	// Number of stream values observed in a worst-case Observation
+	worstCaseObservedStreams = 1_000
+	// Number of channels in a worst-case Outcome
+	worstCaseChannels = 500
+	// Number of streams in each channel of a worst-case Outcome
+	worstCaseStreamsPerChannel = 20
+)
+
+// worstCaseDecimal is a value at the limit of what data sources are expected
+// to produce: 38 significant digits with a large negative exponent
+var worstCaseDecimal = decimal.RequireFromString("-1234567890123456789.0123456789012345678")
+
+// worstCaseQuote is a Quote filled with worst-case decimals
+func worstCaseQuote() *Quote {
+	return &Quote{Bid: worstCaseDecimal, Benchmark: worstCaseDecimal, Ask: worstCaseDecimal}
+}
+
+// worstCasePriceVolumes is the largest StreamValue type: the maximum number of
+// pairs, filled with worst-case decimals
+func worstCasePriceVolumes() *PriceVolumes {
+	pvs := make(PriceVolumes, MaxPriceVolumePairs)
+	for i := range pvs {
+		pvs[i] = PriceVolume{Price: worstCaseDecimal, Volume: worstCaseDecimal}
+	}
+	return &pvs
+}
+
+// worstCaseChannelDefinition uses the largest IDs so that every varint takes
+// its maximum encoded length
+func worstCaseChannelDefinition(nStreams int, firstStreamID llotypes.StreamID) llotypes.ChannelDefinition {
+	streams := make([]llotypes.Stream, nStreams)
+	for i := range streams {
+		streams[i] = llotypes.Stream{StreamID: firstStreamID - llotypes.StreamID(i), Aggregator: llotypes.AggregatorQuote}
+	}
+	return llotypes.ChannelDefinition{
+		ReportFormat: llotypes.ReportFormat(math.MaxUint32 - 1),
+		Streams:      streams,
+		Opts:         []byte(`{"baseUSDFee":"1.5","expirationWindow":86400,"feedId":"0x0003a7c5a2c6b2d7d9c5d8a7f8b5e5b9c3c0b5c9d2b2c7f1d0f3c9d5d2b2c7f1","multiplier":"1000000000000000000"}`),
+	}
+}
+
+func Test_ProtocolLimits(t *testing.T) {
+	ctx := tests.Context(t)
+	f := &PluginFactory{OnchainConfigCodec: EVMOnchainConfigCodec{}}
+	onchainConfig, err := EVMOnchainConfigCodec{}.Encode(OnchainConfig{Version: onchainConfigVersion})
+	require.NoError(t, err)
+	_, info, err := f.NewReportingPlugin(ctx, ocr3types.ReportingPluginConfig{ConfigDigest: types.ConfigDigest{0x00, 0x09}, OnchainConfig: onchainConfig, N: 4, F: 1})
+	require.NoError(t, err)
+	limits := info.Limits
+
+	t.Run("worst-case Observation fits in MaxObservationLength", func(t *testing.T) {
+		obs := Observation{
+			ShouldRetire:             true,
+			UnixTimestampNanoseconds: math.MaxInt64,
+			RemoveChannelIDs:         make(map[llotypes.ChannelID]struct{}, MaxObservationRemoveChannelIDsLength),
+			UpdateChannelDefinitions: make(llotypes.ChannelDefinitions, MaxObservationUpdateChannelDefinitionsLength),
+			StreamValues:             make(StreamValues, worstCaseObservedStreams),
+			StreamTimestamps:         make(StreamTimestamps, worstCaseObservedStreams),
+		}
+		for i := 0; i < MaxObservationRemoveChannelIDsLength; i++ {
+			obs.RemoveChannelIDs[math.MaxUint32-llotypes.ChannelID(i)] = struct{}{}
+		}
+		// Each added channel may reference every observable stream
+		for i := 0; i < MaxObservationUpdateChannelDefinitionsLength; i++ {
+			obs.UpdateChannelDefinitions[math.MaxUint32-llotypes.ChannelID(i)] = worstCaseChannelDefinition(MaxObservationStreamValuesLength, math.MaxUint32)
+		}
+		for i := 0; i < worstCaseObservedStreams; i++ {
+			obs.StreamValues[math.MaxUint32-llotypes.StreamID(i)] = worstCasePriceVolumes()
+			// Negative timestamps take the maximum varint length
+			obs.StreamTimestamps[math.MaxUint32-llotypes.StreamID(i)] = math.MinInt64
+		}
+
+		encoded, err := protoObservationCodec{}.Encode(obs)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(encoded), limits.MaxObservationLength, "worst-case Observation exceeds MaxObservationLength")
+		t.Logf("worst-case Observation is %d bytes (%.1f%% of MaxObservationLength)", len(encoded), percent(len(encoded), limits.MaxObservationLength))
+	})
+
+	t.Run("worst-case Outcome fits in MaxOutcomeLength", func(t *testing.T) {
+		require.LessOrEqual(t, worstCaseChannels, MaxOutcomeChannelDefinitionsLength)
+		require.LessOrEqual(t, worstCaseChannels*worstCaseStreamsPerChannel, MaxObservationStreamValuesLength)
+
+		outcome := Outcome{
+			LifeCycleStage:                   LifeCycleStageProduction,
+			ObservationsTimestampNanoseconds: math.MaxInt64,
+			ChannelDefinitions:               make(llotypes.ChannelDefinitions, worstCaseChannels),
+			ValidAfterSeconds:                make(map[llotypes.ChannelID]uint32, worstCaseChannels),
+			StreamAggregates:                 make(StreamAggregates, worstCaseChannels*worstCaseStreamsPerChannel),
+			StreamDispersions:                make(map[llotypes.StreamID]StreamValue, worstCaseChannels),
+		}
+		for i := 0; i < worstCaseChannels; i++ {
+			cid := math.MaxUint32 - llotypes.ChannelID(i)
+			// Streams are not shared between channels so that every stream
+			// has its own aggregate
+			firstStreamID := math.MaxUint32 - llotypes.StreamID(i*worstCaseStreamsPerChannel)
+			cd := worstCaseChannelDefinition(worstCaseStreamsPerChannel, firstStreamID)
+			outcome.ChannelDefinitions[cid] = cd
+			outcome.ValidAfterSeconds[cid] = math.MaxUint32
+			// Every channel requests the dispersion of its primary stream
+			outcome.StreamDispersions[firstStreamID] = ToDecimal(worstCaseDecimal)
+			for _, strm := range cd.Streams {
+				outcome.StreamAggregates[strm.StreamID] = map[llotypes.Aggregator]StreamValue{
+					llotypes.AggregatorMedian: ToDecimal(worstCaseDecimal),
+					llotypes.AggregatorMode:   ToDecimal(worstCaseDecimal),
+					llotypes.AggregatorQuote:  worstCaseQuote(),
+					AggregatorVolumeWeighted:  ToDecimal(worstCaseDecimal),
+				}
+			}
+		}
+		require.NoError(t, VerifyChannelDefinitions(outcome.ChannelDefinitions))
+
+		encoded, err := protoOutcomeCodec{}.Encode(outcome)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(encoded), limits.MaxOutcomeLength, "worst-case Outcome exceeds MaxOutcomeLength")
+		t.Logf("worst-case Outcome is %d bytes (%.1f%% of MaxOutcomeLength)", len(encoded), percent(len(encoded), limits.MaxOutcomeLength))
+	})
+
+	t.Run("advertised limits match constants", func(t *testing.T) {
+		assert.Equal(t, 0, limits.MaxQueryLength)
+		assert.Equal(t, MaxObservationLength, limits.MaxObservationLength)
+		assert.Equal(t, MaxOutcomeLength, limits.MaxOutcomeLength)
+		assert.Equal(t, MaxReportLength, limits.MaxReportLength)
+		assert.Equal(t, MaxReportCount, limits.MaxReportCount)
+		assert.GreaterOrEqual(t, limits.MaxReportCount, MaxOutcomeChannelDefinitionsLength, "every channel must be able to report in the same round")
+	})
+}
+
+func percent(n, limit int) float64 {
+	return 100 * float64(n) / float64(limit)
+}
@@ -2,10 +2,13 @@ package llo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"time"
 
+	"github.com/shopspring/decimal"
+
 	"github.com/smartcontractkit/libocr/offchainreporting2/types"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
 	"golang.org/x/exp/maps"
@@ -38,9 +41,24 @@ func (p *Plugin) observation(ctx context.Context, outctx ocr3types.OutcomeContex
 		// closer to the source?
 		UnixTimestampNanoseconds: observationTimestamp.UnixNano(),
 	}
+	if obs.PreviousOutcomeHash, err = p.previousOutcomeHash(previousOutcome); err != nil {
+		// Fork detection is best effort and must not prevent observing
+		p.Logger.Warnw("Failed to hash previous outcome", "err", err, "stage", "Observation", "seqNr", outctx.SeqNr)
+	}
+	// Streams to observe, from highest to lowest priority
+	var streamIDs []llotypes.StreamID
 
 	if previousOutcome.LifeCycleStage == LifeCycleStageRetired {
-		p.Logger.Debugw("Node is retired, will generate empty observation", "stage", "Observation", "seqNr", outctx.SeqNr)
+		// The next outcome is still winding down if the previous one had
+		// more than one round to go, so it needs stream values to report
+		if previousOutcome.WindDownRoundsRemaining > 1 {
+			p.Logger.Debugw("Node is retired but winding down, will only observe streams", "stage", "Observation", "seqNr", outctx.SeqNr, "windDownRoundsRemaining", previousOutcome.WindDownRoundsRemaining-1)
+			if streamIDs, err = p.observeStreams(ctx, outctx, previousOutcome, &obs, observationTimestamp); err != nil {
+				return nil, err
+			}
+		} else {
+			p.Logger.Debugw("Node is retired, will generate empty observation", "stage", "Observation", "seqNr", outctx.SeqNr)
+		}
 	} else {
 		if err = VerifyChannelDefinitions(previousOutcome.ChannelDefinitions); err != nil {
 			// This is not expected, unless the majority of nodes are using a
@@ -65,7 +83,7 @@ func (p *Plugin) observation(ctx context.Context, outctx ocr3types.OutcomeContex
 		if err != nil {
 			return nil, fmt.Errorf("error fetching shouldRetire from cache: %w", err)
 		}
-		if obs.ShouldRetire && p.Config.VerboseLogging {
+		if obs.ShouldRetire && p.verboseLogging() {
 			p.Logger.Debugw("Voting to retire", "seqNr", outctx.SeqNr, "stage", "Observation")
 		}
 
@@ -101,21 +119,35 @@ func (p *Plugin) observation(ctx context.Context, outctx ocr3types.OutcomeContex
 				// spinup, then after that every 10 or 100 rounds.
 				obs.UpdateChannelDefinitions = make(llotypes.ChannelDefinitions)
 				expectedChannelIDs := maps.Keys(expectedChannelDefs)
-				// Sort so we cut off deterministically
+				// Sort so we cut off deterministically, voting for the
+				// highest priority channels first
 				sortChannelIDs(expectedChannelIDs)
+				p.prioritizeChannels(expectedChannelIDs, expectedChannelDefs)
+				// All channels are compared, even beyond the cut off, to
+				// report how far along the rollout is
+				syncStatus := ChannelDefinitionsSyncStatus{
+					SeqNr:          outctx.SeqNr,
+					PendingRemoves: countRemovedChannels(previousOutcome.ChannelDefinitions, expectedChannelDefs),
+				}
 				for _, channelID := range expectedChannelIDs {
 					prev, exists := previousOutcome.ChannelDefinitions[channelID]
 					channelDefinition := expectedChannelDefs[channelID]
 					if exists && prev.Equals(channelDefinition) {
 						continue
 					}
-					// Add or replace channel
-					obs.UpdateChannelDefinitions[channelID] = channelDefinition
-					if len(obs.UpdateChannelDefinitions) >= MaxObservationUpdateChannelDefinitionsLength {
-						// Never add more than MaxObservationUpdateChannelDefinitionsLength
-						break
+					if exists {
+						syncStatus.PendingUpdates++
+					} else {
+						syncStatus.PendingAdds++
+					}
+					// Never add more than MaxObservationUpdateChannelDefinitionsLength
+					if len(obs.UpdateChannelDefinitions) < MaxObservationUpdateChannelDefinitionsLength {
+						// Add or replace channel
+						obs.UpdateChannelDefinitions[channelID] = channelDefinition
 					}
 				}
+				syncStatus.computeRoundsRemaining()
+				p.recordChannelDefinitionsSync(syncStatus)
 			}
 
 			if len(obs.UpdateChannelDefinitions) > 0 {
@@ -133,24 +165,17 @@ func (p *Plugin) observation(ctx context.Context, outctx ocr3types.OutcomeContex
 			}
 		}
 
-		if len(previousOutcome.ChannelDefinitions) == 0 {
-			p.Logger.Debugw("ChannelDefinitions is empty, will not generate any observations", "stage", "Observation", "seqNr", outctx.SeqNr)
-		} else {
-			obs.StreamValues = make(StreamValues)
-			for _, channelDefinition := range previousOutcome.ChannelDefinitions {
-				for _, strm := range channelDefinition.Streams {
-					obs.StreamValues[strm.StreamID] = nil
-				}
-			}
+		if streamIDs, err = p.observeStreams(ctx, outctx, previousOutcome, &obs, observationTimestamp); err != nil {
+			return nil, err
+		}
+	}
 
-			// NOTE: Timeouts/context cancelations are likely to be rather
-			// common here, since Observe may have to query 100s of streams,
-			// any one of which could be slow.
-			observationCtx, cancel := context.WithTimeout(ctx, p.MaxDurationObservation)
-			defer cancel()
-			if err = p.DataSource.Observe(observationCtx, obs.StreamValues, &dsOpts{p.Config.VerboseLogging, outctx, p.ConfigDigest, observationTimestamp}); err != nil {
-				return nil, fmt.Errorf("DataSource.Observe error: %w", err)
-			}
+	if estimator, ok := p.ObservationCodec.(observationSizeEstimator); ok {
+		channelIDs := sortedKeys(obs.UpdateChannelDefinitions)
+		p.prioritizeChannels(channelIDs, obs.UpdateChannelDefinitions)
+		droppedChannelIDs, droppedStreamIDs := trimObservation(estimator, &obs, channelIDs, streamIDs, p.OffchainConfig.ObservationBudget())
+		if len(droppedChannelIDs) > 0 || len(droppedStreamIDs) > 0 {
+			p.Logger.Warnw("Observation exceeds size budget, dropped lowest priority channel definition votes and stream values", "stage", "Observation", "seqNr", outctx.SeqNr, "budget", p.OffchainConfig.ObservationBudget(), "droppedChannelIDs", droppedChannelIDs, "droppedStreamIDs", droppedStreamIDs)
 		}
 	}
 
@@ -162,6 +187,88 @@ func (p *Plugin) observation(ctx context.Context, outctx ocr3types.OutcomeContex
 	return serialized, nil
 }
 
+// observeStreams observes the streams of the previous outcome's channels
+// into obs. It returns the IDs of the observed streams, from highest to
+// lowest priority.
+func (p *Plugin) observeStreams(ctx context.Context, outctx ocr3types.OutcomeContext, previousOutcome Outcome, obs *Observation, observationTimestamp time.Time) (streamIDs []llotypes.StreamID, err error) {
+	if len(previousOutcome.ChannelDefinitions) == 0 {
+		p.Logger.Debugw("ChannelDefinitions is empty, will not generate any observations", "stage", "Observation", "seqNr", outctx.SeqNr)
+		return nil, nil
+	}
+
+	// Sort by channel priority so that, if there are too many streams
+	// to observe, the least important ones are dropped
+	// deterministically
+	streamIDs = prioritizedStreamIDs(p.channelOpts, previousOutcome.ChannelDefinitions)
+	if len(streamIDs) > MaxObservationStreamValuesLength {
+		p.Logger.Warnw("Too many streams to observe, dropping lowest priority streams", "stage", "Observation", "seqNr", outctx.SeqNr, "streams", len(streamIDs), "max", MaxObservationStreamValuesLength, "droppedStreamIDs", streamIDs[MaxObservationStreamValuesLength:])
+		streamIDs = streamIDs[:MaxObservationStreamValuesLength]
+	}
+	obs.StreamValues = make(StreamValues, len(streamIDs))
+	for _, streamID := range streamIDs {
+		obs.StreamValues[streamID] = nil
+	}
+
+	// NOTE: Timeouts/context cancelations are likely to be rather
+	// common here, since Observe may have to query 100s of streams,
+	// any one of which could be slow. The DataSource is abandoned shortly
+	// before the end of the phase, so that a hung upstream cannot make us
+	// miss the round; the streams are then left without a value.
+	observationCtx, cancel := WithPhaseDeadline(ctx, observationTimestamp, p.MaxDurationObservation, DefaultPhaseSafetyMargin)
+	defer cancel()
+	baseOpts := &dsOpts{p.verboseLogging(), outctx, p.ConfigDigest, observationTimestamp}
+	var opts DSOpts = baseOpts
+	// Telemetry is sent for sampled rounds only
+	sampled := p.telemetrySampled(outctx.SeqNr)
+	var attributions *attributionRecorder
+	if p.ObservationAttributions != nil && sampled {
+		attributions = &attributionRecorder{}
+		opts = dsOptsWithAttributions{baseOpts, attributions}
+	}
+	method := "Observe"
+	if tds, ok := p.DataSource.(TimestampedDataSource); ok && p.OffchainConfig.StreamStalenessBound > 0 {
+		obs.StreamTimestamps = make(StreamTimestamps)
+		method = "ObserveWithTimestamps"
+		err = observeWithinDeadline(observationCtx, obs.StreamValues, obs.StreamTimestamps, func(ctx context.Context, sv StreamValues, ts StreamTimestamps) error {
+			return tds.ObserveWithTimestamps(ctx, sv, ts, opts)
+		})
+	} else {
+		err = observeWithinDeadline(observationCtx, obs.StreamValues, nil, func(ctx context.Context, sv StreamValues, _ StreamTimestamps) error {
+			return p.DataSource.Observe(ctx, sv, opts)
+		})
+	}
+	var abandoned *errObservationAbandoned
+	if errors.As(err, &abandoned) {
+		promObservationDeadlineExceededTotal.WithLabelValues(p.ConfigDigest.String()).Inc()
+		p.Logger.Warnw("DataSource did not return before the observation deadline, observing no stream values", "err", err, "stage", "Observation", "seqNr", outctx.SeqNr, "streams", len(streamIDs))
+	} else if err != nil {
+		return nil, fmt.Errorf("DataSource.%s error: %w", method, err)
+	}
+
+	dropValuePolicyViolations(obs.StreamValues, streamValuePolicies(p.channelOpts, previousOutcome.ChannelDefinitions), func(id llotypes.StreamID, vp ValuePolicy, err error) {
+		p.Logger.Warnw("Observed value violates stream value policy, dropping it", "streamID", id, "policy", vp, "stage", "Observation", "seqNr", outctx.SeqNr, "err", p.LogRedaction.Err(err))
+	})
+
+	if p.ObservationProvenance != nil && sampled {
+		// Provenance is an audit trail only, so failing to sign must not
+		// fail the observation
+		if err := p.ObservationProvenance.sign(p.ConfigDigest, outctx.SeqNr, observationTimestamp.UnixNano(), obs.StreamValues); err != nil {
+			p.Logger.Errorw("Failed to sign observation provenance", "err", err, "stage", "Observation", "seqNr", outctx.SeqNr)
+		}
+	}
+
+	if attributions != nil {
+		// Only streams with a value are reported, so an abandoned
+		// DataSource's records are dropped along with its values
+		p.ObservationAttributions.report(p.ConfigDigest, outctx.SeqNr, observationTimestamp.UnixNano(), obs.StreamValues, attributions.close())
+	}
+
+	if p.OffchainConfig.SkipUnchangedStreamValues {
+		obs.UnchangedStreamIDs = skipUnchangedStreamValues(obs.StreamValues, previousOutcome.StreamAggregates, p.OffchainConfig.UnchangedStreamValueEpsilon)
+	}
+	return streamIDs, nil
+}
+
 type Observation struct {
 	// Attested (i.e. signed by f+1 oracles) retirement report from predecessor
 	// protocol instance
@@ -179,6 +286,18 @@ type Observation struct {
 	// Observed (numeric) stream values. Subject to
 	// MaxObservationStreamValuesLength limit
 	StreamValues StreamValues
+	// Streams that were omitted from StreamValues because their value is
+	// unchanged from the previous outcome's median (only if
+	// OffchainConfig.SkipUnchangedStreamValues is enabled). Counts towards
+	// the MaxObservationStreamValuesLength limit.
+	UnchangedStreamIDs map[llotypes.StreamID]struct{}
+	// Source timestamps of the streams in StreamValues and
+	// UnchangedStreamIDs, if reported by the DataSource (only if
+	// OffchainConfig.StreamStalenessBound is set)
+	StreamTimestamps StreamTimestamps
+	// PreviousOutcomeHash is the hash of the previous outcome as decoded by
+	// the observer, for fork detection. Zero if not set.
+	PreviousOutcomeHash [32]byte
 }
 
 // deterministic sort of channel IDs
@@ -187,3 +306,43 @@ func sortChannelIDs(cids []llotypes.ChannelID) {
 		return cids[i] < cids[j]
 	})
 }
+
+// skipUnchangedStreamValues removes values from streamValues that are within
+// epsilon of the previous outcome's median, and returns their IDs
+func skipUnchangedStreamValues(streamValues StreamValues, previous StreamAggregates, epsilon decimal.Decimal) map[llotypes.StreamID]struct{} {
+	var unchanged map[llotypes.StreamID]struct{}
+	for id, sv := range streamValues {
+		d, ok := sv.(*Decimal)
+		if !ok || d == nil {
+			continue
+		}
+		prev, ok := previousMedian(previous, id)
+		if !ok || !withinEpsilon(d.Decimal(), prev.Decimal(), epsilon) {
+			continue
+		}
+		if unchanged == nil {
+			unchanged = make(map[llotypes.StreamID]struct{})
+		}
+		unchanged[id] = struct{}{}
+		delete(streamValues, id)
+	}
+	return unchanged
+}
+
+// previousMedian returns the previous outcome's median for a stream, if the
+// stream is aggregated exclusively with AggregatorMedian. Streams with other
+// aggregators need the actual observed value, so they never count as
+// unchanged.
+func previousMedian(previous StreamAggregates, id llotypes.StreamID) (*Decimal, bool) {
+	aggs := previous[id]
+	if len(aggs) != 1 {
+		return nil, false
+	}
+	d, ok := aggs[llotypes.AggregatorMedian].(*Decimal)
+	return d, ok && d != nil
+}
+
+// withinEpsilon returns true if |v - prev| <= epsilon * |prev|
+func withinEpsilon(v, prev, epsilon decimal.Decimal) bool {
+	return v.Sub(prev).Abs().LessThanOrEqual(epsilon.Mul(prev.Abs()))
+}
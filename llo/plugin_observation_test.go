@@ -529,6 +529,40 @@ func Test_Observation(t *testing.T) {
 		assert.Len(t, decoded.StreamValues, 0)
 	})
 
+	t.Run("if previous outcome is retired but winding down, only observes streams", func(t *testing.T) {
+		previousOutcome := Outcome{
+			LifeCycleStage:          LifeCycleStageRetired,
+			ChannelDefinitions:      smallDefinitions,
+			WindDownRoundsRemaining: 2,
+		}
+		encodedPreviousOutcome, err := p.OutcomeCodec.Encode(previousOutcome)
+		require.NoError(t, err)
+
+		outctx := ocr3types.OutcomeContext{SeqNr: 2, PreviousOutcome: encodedPreviousOutcome}
+		obs, err := p.Observation(context.Background(), outctx, query)
+		require.NoError(t, err)
+		decoded, err := p.ObservationCodec.Decode(obs)
+		require.NoError(t, err)
+
+		assert.False(t, decoded.ShouldRetire)
+		assert.Len(t, decoded.UpdateChannelDefinitions, 0)
+		assert.Len(t, decoded.RemoveChannelIDs, 0)
+		assert.Equal(t, ds.s, decoded.StreamValues)
+
+		t.Run("does not observe streams for the last round of the wind-down", func(t *testing.T) {
+			previousOutcome.WindDownRoundsRemaining = 1
+			encodedPreviousOutcome, err := p.OutcomeCodec.Encode(previousOutcome)
+			require.NoError(t, err)
+
+			outctx := ocr3types.OutcomeContext{SeqNr: 2, PreviousOutcome: encodedPreviousOutcome}
+			obs, err := p.Observation(context.Background(), outctx, query)
+			require.NoError(t, err)
+			decoded, err := p.ObservationCodec.Decode(obs)
+			require.NoError(t, err)
+			assert.Len(t, decoded.StreamValues, 0)
+		})
+	})
+
 	invalidDefinitions := map[llotypes.ChannelID]llotypes.ChannelDefinition{
 		1: {
 			ReportFormat: llotypes.ReportFormatJSON,
@@ -558,4 +592,142 @@ func Test_Observation(t *testing.T) {
 		assert.GreaterOrEqual(t, decoded.UnixTimestampNanoseconds, testStartTS.UnixNano())
 		assert.Equal(t, ds.s, decoded.StreamValues)
 	})
+	t.Run("if SkipUnchangedStreamValues is enabled, lists unchanged streams instead of observing them", func(t *testing.T) {
+		cdc.definitions = smallDefinitions
+		p := &Plugin{
+			Config:                 Config{true},
+			OutcomeCodec:           protoOutcomeCodec{},
+			ShouldRetireCache:      &mockShouldRetireCache{},
+			ChannelDefinitionCache: cdc,
+			Logger:                 logger.Test(t),
+			ObservationCodec:       protoObservationCodec{},
+			DataSource:             ds,
+			OffchainConfig: OffchainConfig{
+				SkipUnchangedStreamValues:   true,
+				UnchangedStreamValueEpsilon: decimal.RequireFromString("0.001"),
+			},
+		}
+		previousOutcome := Outcome{
+			LifeCycleStage:     LifeCycleStageProduction,
+			ChannelDefinitions: smallDefinitions,
+			StreamAggregates: StreamAggregates{
+				// identical
+				1: {llotypes.AggregatorMedian: ToDecimal(decimal.NewFromInt(1000))},
+				// within epsilon
+				3: {llotypes.AggregatorMedian: ToDecimal(decimal.NewFromInt(2999))},
+				// outside epsilon
+				4: {llotypes.AggregatorMedian: ToDecimal(decimal.NewFromInt(3000))},
+			},
+		}
+		encodedPreviousOutcome, err := p.OutcomeCodec.Encode(previousOutcome)
+		require.NoError(t, err)
+
+		outctx := ocr3types.OutcomeContext{SeqNr: 2, PreviousOutcome: encodedPreviousOutcome}
+		obs, err := p.Observation(context.Background(), outctx, query)
+		require.NoError(t, err)
+		decoded, err := p.ObservationCodec.Decode(obs)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[llotypes.StreamID]struct{}{1: {}, 3: {}}, decoded.UnchangedStreamIDs)
+		assert.Equal(t, StreamValues{4: ToDecimal(decimal.NewFromInt(4000))}, decoded.StreamValues)
+
+		t.Run("streams with other aggregators are always observed", func(t *testing.T) {
+			previousOutcome.StreamAggregates = StreamAggregates{
+				1: {
+					llotypes.AggregatorMedian: ToDecimal(decimal.NewFromInt(1000)),
+					llotypes.AggregatorMode:   ToDecimal(decimal.NewFromInt(1000)),
+				},
+				3: {llotypes.AggregatorMode: ToDecimal(decimal.NewFromInt(3000))},
+			}
+			encodedPreviousOutcome, err := p.OutcomeCodec.Encode(previousOutcome)
+			require.NoError(t, err)
+
+			outctx := ocr3types.OutcomeContext{SeqNr: 2, PreviousOutcome: encodedPreviousOutcome}
+			obs, err := p.Observation(context.Background(), outctx, query)
+			require.NoError(t, err)
+			decoded, err := p.ObservationCodec.Decode(obs)
+			require.NoError(t, err)
+
+			assert.Len(t, decoded.UnchangedStreamIDs, 0)
+			assert.Equal(t, ds.s, decoded.StreamValues)
+		})
+	})
+	t.Run("drops observed values that violate their stream value policy", func(t *testing.T) {
+		cdc.definitions = smallDefinitions
+		p := &Plugin{
+			Config:                 Config{true},
+			OutcomeCodec:           protoOutcomeCodec{},
+			ShouldRetireCache:      &mockShouldRetireCache{},
+			ChannelDefinitionCache: cdc,
+			Logger:                 logger.Test(t),
+			ObservationCodec:       protoObservationCodec{},
+			DataSource: &mockDataSource{s: map[llotypes.StreamID]StreamValue{
+				1: ToDecimal(decimal.NewFromInt(-1)),
+				3: ToDecimal(decimal.NewFromInt(-3)),
+			}},
+		}
+		definitions := maps.Clone(smallDefinitions)
+		cd := definitions[1]
+		cd.Opts = []byte(`{"streamValuePolicies":{"1":"positive","3":"any"}}`)
+		definitions[1] = cd
+		encodedPreviousOutcome, err := p.OutcomeCodec.Encode(Outcome{LifeCycleStage: LifeCycleStageProduction, ChannelDefinitions: definitions})
+		require.NoError(t, err)
+
+		outctx := ocr3types.OutcomeContext{SeqNr: 2, PreviousOutcome: encodedPreviousOutcome}
+		obs, err := p.Observation(context.Background(), outctx, query)
+		require.NoError(t, err)
+		decoded, err := p.ObservationCodec.Decode(obs)
+		require.NoError(t, err)
+
+		assert.Equal(t, StreamValues{3: ToDecimal(decimal.NewFromInt(-3))}, decoded.StreamValues)
+	})
+	t.Run("if StreamStalenessBound is enabled, includes source timestamps from a TimestampedDataSource", func(t *testing.T) {
+		cdc.definitions = smallDefinitions
+		ds := &mockDataSource{
+			s: ds.s,
+			ts: StreamTimestamps{
+				1: 1_000,
+				// 2 has no value, so its timestamp is dropped
+				2: 2_000,
+				3: 3_000,
+			},
+		}
+		p := &Plugin{
+			Config:                 Config{true},
+			OutcomeCodec:           protoOutcomeCodec{},
+			ShouldRetireCache:      &mockShouldRetireCache{},
+			ChannelDefinitionCache: cdc,
+			Logger:                 logger.Test(t),
+			ObservationCodec:       protoObservationCodec{},
+			DataSource:             ds,
+		}
+		previousOutcome := Outcome{
+			LifeCycleStage:     LifeCycleStageProduction,
+			ChannelDefinitions: smallDefinitions,
+		}
+		encodedPreviousOutcome, err := p.OutcomeCodec.Encode(previousOutcome)
+		require.NoError(t, err)
+		outctx := ocr3types.OutcomeContext{SeqNr: 2, PreviousOutcome: encodedPreviousOutcome}
+
+		t.Run("disabled", func(t *testing.T) {
+			obs, err := p.Observation(context.Background(), outctx, query)
+			require.NoError(t, err)
+			decoded, err := p.ObservationCodec.Decode(obs)
+			require.NoError(t, err)
+
+			assert.Nil(t, decoded.StreamTimestamps)
+			assert.Equal(t, ds.s, decoded.StreamValues)
+		})
+		t.Run("enabled", func(t *testing.T) {
+			p.OffchainConfig.StreamStalenessBound = time.Minute
+
+			obs, err := p.Observation(context.Background(), outctx, query)
+			require.NoError(t, err)
+			decoded, err := p.ObservationCodec.Decode(obs)
+			require.NoError(t, err)
+
+			assert.Equal(t, StreamTimestamps{1: 1_000, 3: 3_000}, decoded.StreamTimestamps)
+			assert.Equal(t, ds.s, decoded.StreamValues)
+		})
+	})
 }
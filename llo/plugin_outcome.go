@@ -1,13 +1,17 @@
 package llo
 
 import (
+	"cmp"
 	"crypto/sha256"
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"maps"
 	"sort"
+	"strconv"
 	"time"
 
+	"github.com/smartcontractkit/libocr/commontypes"
 	"github.com/smartcontractkit/libocr/offchainreporting2/types"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
 
@@ -32,11 +36,7 @@ func (p *Plugin) outcome(outctx ocr3types.OutcomeContext, query types.Query, aos
 			lifeCycleStage = LifeCycleStageStaging
 		}
 		outcome := Outcome{
-			lifeCycleStage,
-			0,
-			nil,
-			nil,
-			nil,
+			LifeCycleStage: lifeCycleStage,
 		}
 		return p.OutcomeCodec.Encode(outcome)
 	}
@@ -49,10 +49,15 @@ func (p *Plugin) outcome(outctx ocr3types.OutcomeContext, query types.Query, aos
 		return nil, fmt.Errorf("error decoding previous outcome: %v", err)
 	}
 
+	previousOutcomeHash, err := p.previousOutcomeHash(previousOutcome)
+	if err != nil {
+		p.Logger.Warnw("Failed to hash previous outcome, skipping fork detection", "err", err, "stage", "Outcome", "seqNr", outctx.SeqNr)
+	}
+
 	/////////////////////////////////
 	// Decode observations
 	/////////////////////////////////
-	timestampsNanoseconds, validPredecessorRetirementReport, shouldRetireVotes, removeChannelVotesByID, updateChannelDefinitionsByHash, updateChannelVotesByHash, streamObservations := p.decodeObservations(aos, outctx)
+	timestampsNanoseconds, validPredecessorRetirementReport, shouldRetireVotes, removeChannelVotesByID, updateChannelDefinitionsByHash, updateChannelVotesByHash, streamObservations := p.decodeObservations(aos, outctx, previousOutcome, previousOutcomeHash)
 
 	if len(timestampsNanoseconds) == 0 {
 		return nil, errors.New("no valid observations")
@@ -81,14 +86,22 @@ func (p *Plugin) outcome(outctx ocr3types.OutcomeContext, query types.Query, aos
 	}
 
 	if outcome.LifeCycleStage == LifeCycleStageProduction && shouldRetireVotes > p.F {
-		p.Logger.Infow("Retiring production protocol instance ⚰️", "seqNr", outctx.SeqNr, "stage", "Outcome")
+		p.Logger.Infow("Retiring production protocol instance ⚰️", "seqNr", outctx.SeqNr, "stage", "Outcome", "retirementWindDownRounds", p.OffchainConfig.RetirementWindDownRounds)
 		outcome.LifeCycleStage = LifeCycleStageRetired
+		outcome.WindDownRoundsRemaining = p.OffchainConfig.RetirementWindDownRounds
+	} else if previousOutcome.WindDownRoundsRemaining > 0 {
+		outcome.WindDownRoundsRemaining = previousOutcome.WindDownRoundsRemaining - 1
+		if outcome.WindDownRoundsRemaining == 0 {
+			p.Logger.Infow("Retired protocol instance finished winding down, will no longer generate channel reports", "seqNr", outctx.SeqNr, "stage", "Outcome")
+		}
 	}
 
 	/////////////////////////////////
 	// outcome.ChannelDefinitions
 	/////////////////////////////////
-	outcome.ChannelDefinitions = previousOutcome.ChannelDefinitions
+	// Copy rather than alias, since previousOutcome must keep its own
+	// channel definitions to determine which channels it reported on
+	outcome.ChannelDefinitions = maps.Clone(previousOutcome.ChannelDefinitions)
 	if outcome.ChannelDefinitions == nil {
 		outcome.ChannelDefinitions = llotypes.ChannelDefinitions{}
 	}
@@ -99,31 +112,27 @@ func (p *Plugin) outcome(outctx ocr3types.OutcomeContext, query types.Query, aos
 	}
 
 	var removedChannelIDs []llotypes.ChannelID
-	for channelID, voteCount := range removeChannelVotesByID {
-		if voteCount <= p.F {
+	for _, channelID := range sortedKeys(removeChannelVotesByID) {
+		if removeChannelVotesByID[channelID] <= p.F {
 			continue
 		}
 		removedChannelIDs = append(removedChannelIDs, channelID)
 		delete(outcome.ChannelDefinitions, channelID)
 	}
 
-	type hashWithID struct {
-		ChannelHash
-		ChannelDefinitionWithID
-	}
-	orderedHashes := make([]hashWithID, 0, len(updateChannelDefinitionsByHash))
-	for channelHash, dfnWithID := range updateChannelDefinitionsByHash {
-		orderedHashes = append(orderedHashes, hashWithID{channelHash, dfnWithID})
-	}
 	// Use predictable order for adding channels (id asc) so that extras that
 	// exceed the max are consistent across all nodes
-	sort.Slice(orderedHashes, func(i, j int) bool { return orderedHashes[i].ChannelID < orderedHashes[j].ChannelID })
-	for _, hwid := range orderedHashes {
-		voteCount := updateChannelVotesByHash[hwid.ChannelHash]
-		if voteCount <= p.F {
+	updates := sortedChannelDefinitionUpdates(updateChannelDefinitionsByHash, updateChannelVotesByHash)
+	if p.OffchainConfig.ChannelPriorityClasses {
+		// Add the highest priority channels first, so that they win if
+		// there is not enough room for all of them
+		prioritizeChannelDefinitionUpdates(p.channelOpts, updates)
+	}
+	for _, update := range updates {
+		if update.Votes <= p.F {
 			continue
 		}
-		defWithID := hwid.ChannelDefinitionWithID
+		defWithID := update.ChannelDefinitionWithID
 		if original, exists := outcome.ChannelDefinitions[defWithID.ChannelID]; exists {
 			p.Logger.Debugw("Adding channel (replacement)",
 				"channelID", defWithID.ChannelID,
@@ -166,17 +175,32 @@ func (p *Plugin) outcome(outctx ocr3types.OutcomeContext, query types.Query, aos
 			return nil, fmt.Errorf("error getting previous outcome's observations timestamp: %v", err2)
 		}
 
+		policy := p.OffchainConfig.ValidAfterSecondsPolicy()
+		var deferredChannelIDs map[llotypes.ChannelID]struct{}
+		if previousOutcome.LifeCycleStage != LifeCycleStageRetired {
+			deferredChannelIDs = p.deferredChannels(&previousOutcome)
+		}
 		outcome.ValidAfterSeconds = map[llotypes.ChannelID]uint32{}
-		for channelID, previousValidAfterSeconds := range previousOutcome.ValidAfterSeconds {
-			if err3 := previousOutcome.IsReportable(channelID); err3 != nil {
-				if p.Config.VerboseLogging {
-					p.Logger.Debugw("Channel is not reportable", "channelID", channelID, "err", err3, "stage", "Outcome", "seqNr", outctx.SeqNr)
-				}
-				// previous outcome did not report; keep the same validAfterSeconds
+		for _, channelID := range sortedKeys(previousOutcome.ValidAfterSeconds) {
+			previousValidAfterSeconds := previousOutcome.ValidAfterSeconds[channelID]
+			if previousOutcome.LifeCycleStage == LifeCycleStageRetired {
+				// reports generated while winding down are specimens, and
+				// the successor continues from the retirement report; keep
+				// the same validAfterSeconds so that there is no gap
 				outcome.ValidAfterSeconds[channelID] = previousValidAfterSeconds
+			} else if err3 := p.isReportable(&previousOutcome, channelID); err3 != nil {
+				if !err3.Cause.Expected() {
+					p.Logger.Warnw("Channel is unexpectedly not reportable", "channelID", channelID, "cause", err3.Cause, "err", err3, "stage", "Outcome", "seqNr", outctx.SeqNr)
+				} else if p.verboseLogging() {
+					p.Logger.Debugw("Channel is not reportable", "channelID", channelID, "cause", err3.Cause, "err", err3, "stage", "Outcome", "seqNr", outctx.SeqNr)
+				}
+				outcome.ValidAfterSeconds[channelID] = policy.Next(channelID, previousValidAfterSeconds, previousObservationsTimestampSeconds, false)
+			} else if _, deferred := deferredChannelIDs[channelID]; deferred {
+				// the previous outcome's report was deferred by the report
+				// count limit, so the next report covers its window too
+				outcome.ValidAfterSeconds[channelID] = policy.Next(channelID, previousValidAfterSeconds, previousObservationsTimestampSeconds, false)
 			} else {
-				// previous outcome reported; update validAfterSeconds to the previousObservationsTimestamp
-				outcome.ValidAfterSeconds[channelID] = previousObservationsTimestampSeconds
+				outcome.ValidAfterSeconds[channelID] = policy.Next(channelID, previousValidAfterSeconds, previousObservationsTimestampSeconds, true)
 			}
 		}
 	}
@@ -186,10 +210,10 @@ func (p *Plugin) outcome(outctx ocr3types.OutcomeContext, query types.Query, aos
 		return nil, fmt.Errorf("error getting outcome's observations timestamp: %w", err)
 	}
 
-	for channelID := range outcome.ChannelDefinitions {
+	for _, channelID := range sortedKeys(outcome.ChannelDefinitions) {
 		if _, ok := outcome.ValidAfterSeconds[channelID]; !ok {
-			// new channel, set validAfterSeconds to observations timestamp
-			outcome.ValidAfterSeconds[channelID] = observationsTimestampSeconds
+			// new channel, by default valid from the observations timestamp
+			outcome.ValidAfterSeconds[channelID] = p.OffchainConfig.ValidAfterSecondsPolicy().New(channelID, observationsTimestampSeconds)
 		}
 	}
 
@@ -212,8 +236,9 @@ func (p *Plugin) outcome(outctx ocr3types.OutcomeContext, query types.Query, aos
 	// to do the minimum necessary number of aggregations (one per stream/aggregator
 	// pair) and re-use the same result, in case multiple channels share the
 	// same stream/aggregator pair.
-	for cid, cd := range outcome.ChannelDefinitions {
-		for _, strm := range cd.Streams {
+	for _, cid := range sortedKeys(outcome.ChannelDefinitions) {
+		cd := outcome.ChannelDefinitions[cid]
+		for _, strm := range channelStreams(p.channelOpts, cd) {
 			sid, agg := strm.StreamID, strm.Aggregator
 			if _, exists := outcome.StreamAggregates[sid][agg]; exists {
 				// Should only happen in the case of duplicate
@@ -234,7 +259,7 @@ func (p *Plugin) outcome(outctx ocr3types.OutcomeContext, query types.Query, aos
 			}
 			result, err := aggF(streamObservations[sid], p.F)
 			if err != nil {
-				if p.Config.VerboseLogging {
+				if p.verboseLogging() {
 					p.Logger.Warnw("Aggregation failed", "aggregator", agg, "channelID", cid, "f", p.F, "streamID", sid, "observations", streamObservations[sid], "stage", "Outcome", "seqNr", outctx.SeqNr, "err", err)
 				}
 				// Ignore stream that cannot be aggregated; this stream
@@ -245,17 +270,57 @@ func (p *Plugin) outcome(outctx ocr3types.OutcomeContext, query types.Query, aos
 		}
 	}
 
-	if p.Config.VerboseLogging {
-		p.Logger.Debugw("Generated outcome", "outcome", outcome, "stage", "Outcome", "seqNr", outctx.SeqNr)
+	/////////////////////////////////
+	// outcome.StreamDispersions
+	/////////////////////////////////
+	for _, cid := range sortedKeys(outcome.ChannelDefinitions) {
+		cd := outcome.ChannelDefinitions[cid]
+		opts := p.channelOpts.of(cd)
+		metric := opts.dispersion
+		if opts.dispersionErr != nil {
+			if p.verboseLogging() {
+				p.Logger.Warnw("Ignoring dispersion opts", "channelID", cid, "stage", "Outcome", "seqNr", outctx.SeqNr, "err", opts.dispersionErr)
+			}
+			continue
+		} else if metric == "" {
+			continue
+		}
+		// Channel definitions are verified to have at least one stream
+		sid := cd.Streams[0].StreamID
+		if _, exists := outcome.StreamDispersions[sid]; exists {
+			continue
+		}
+		result, err := GetDispersionFunc(metric)(streamObservations[sid], p.F)
+		if err != nil {
+			if p.verboseLogging() {
+				p.Logger.Warnw("Dispersion calculation failed", "metric", metric, "channelID", cid, "f", p.F, "streamID", sid, "observations", streamObservations[sid], "stage", "Outcome", "seqNr", outctx.SeqNr, "err", err)
+			}
+			// Ignore; the dispersion will be missing from the outcome
+			continue
+		}
+		if outcome.StreamDispersions == nil {
+			outcome.StreamDispersions = make(map[llotypes.StreamID]StreamValue)
+		}
+		outcome.StreamDispersions[sid] = result
+	}
+
+	/////////////////////////////////
+	// outcome.DegradedRounds
+	/////////////////////////////////
+	outcome.DegradedRounds = p.degradedRounds(previousOutcome, roundContributors(p.channelOpts, len(timestampsNanoseconds), &outcome, streamObservations))
+
+	if p.verboseLogging() {
+		p.Logger.Debugw("Generated outcome", "outcome", p.LogRedaction.Outcome(outcome), "stage", "Outcome", "seqNr", outctx.SeqNr)
 	}
 	return p.OutcomeCodec.Encode(outcome)
 }
 
-func (p *Plugin) decodeObservations(aos []types.AttributedObservation, outctx ocr3types.OutcomeContext) (timestampsNanoseconds []int64, validPredecessorRetirementReport *RetirementReport, shouldRetireVotes int, removeChannelVotesByID map[llotypes.ChannelID]int, updateChannelDefinitionsByHash map[ChannelHash]ChannelDefinitionWithID, updateChannelVotesByHash map[ChannelHash]int, streamObservations map[llotypes.StreamID][]StreamValue) {
-	removeChannelVotesByID = make(map[llotypes.ChannelID]int)
-	updateChannelDefinitionsByHash = make(map[ChannelHash]ChannelDefinitionWithID)
-	updateChannelVotesByHash = make(map[ChannelHash]int)
+func (p *Plugin) decodeObservations(aos []types.AttributedObservation, outctx ocr3types.OutcomeContext, previousOutcome Outcome, previousOutcomeHash [32]byte) (timestampsNanoseconds []int64, validPredecessorRetirementReport *RetirementReport, shouldRetireVotes int, removeChannelVotesByID map[llotypes.ChannelID]int, updateChannelDefinitionsByHash map[ChannelHash]ChannelDefinitionWithID, updateChannelVotesByHash map[ChannelHash]int, streamObservations map[llotypes.StreamID][]StreamValue) {
+	votes := newChannelVotes()
+	shouldRetireOracles := make(map[commontypes.OracleID]struct{})
 	streamObservations = make(map[llotypes.StreamID][]StreamValue)
+	var divergentObservers []commontypes.OracleID
+	policies := streamValuePolicies(p.channelOpts, previousOutcome.ChannelDefinitions)
 
 	for _, ao := range aos {
 		observation, err2 := p.ObservationCodec.Decode(ao.Observation)
@@ -275,37 +340,86 @@ func (p *Plugin) decodeObservations(aos []types.AttributedObservation, outctx oc
 			validPredecessorRetirementReport = &retirementReport
 		}
 
+		if observation.PreviousOutcomeHash != ([32]byte{}) && observation.PreviousOutcomeHash != previousOutcomeHash {
+			divergentObservers = append(divergentObservers, ao.Observer)
+		}
+
 		if observation.ShouldRetire {
-			shouldRetireVotes++
+			shouldRetireOracles[ao.Observer] = struct{}{}
 		}
 
 		timestampsNanoseconds = append(timestampsNanoseconds, observation.UnixTimestampNanoseconds)
 
 		for channelID := range observation.RemoveChannelIDs {
-			removeChannelVotesByID[channelID]++
+			votes.voteRemove(ao.Observer, channelID)
 		}
 
-		// for each channelId count number of votes that mention it and count number of votes that include it.
+		// each oracle may vote for at most one definition per channel
 		for channelID, channelDefinition := range observation.UpdateChannelDefinitions {
-			defWithID := ChannelDefinitionWithID{channelDefinition, channelID}
-			channelHash := MakeChannelHash(defWithID)
-			updateChannelVotesByHash[channelHash]++
-			updateChannelDefinitionsByHash[channelHash] = defWithID
+			if !votes.voteUpdate(ao.Observer, ChannelDefinitionWithID{channelDefinition, channelID}) {
+				p.Logger.Warnw("ignoring repeated vote for channel definition", "oracleID", ao.Observer, "channelID", channelID, "stage", "Outcome", "seqNr", outctx.SeqNr)
+			}
 		}
 
+		// Illegal values are dropped rather than rejecting the observation,
+		// so that the oracle's other values still count
+		dropValuePolicyViolations(observation.StreamValues, policies, func(id llotypes.StreamID, vp ValuePolicy, err error) {
+			promObservationValuePolicyViolationsTotal.WithLabelValues(p.ConfigDigest.String(), strconv.FormatUint(uint64(ao.Observer), 10), FormatStreamID(id)).Inc()
+			p.Logger.Warnw("Observation violates stream value policy, dropping value", "oracleID", ao.Observer, "streamID", id, "policy", vp, "stage", "Outcome", "seqNr", outctx.SeqNr, "err", p.LogRedaction.Err(err))
+		})
 		for id, sv := range observation.StreamValues {
 			// sv can never be nil here; validation is handled in the decoding
 			// of the observation
+			if p.isStale(observation, id) {
+				continue
+			}
 			streamObservations[id] = append(streamObservations[id], sv)
 		}
-		if p.Config.VerboseLogging {
-			p.Logger.Debugw("Got observations from peer", "stage", "Outcome", "sv", streamObservations, "oracleID", ao.Observer, "seqNr", outctx.SeqNr)
+
+		if p.OffchainConfig.SkipUnchangedStreamValues {
+			// An unchanged stream counts as an observation of the previous
+			// median. Streams that are omitted without being listed here
+			// (e.g. because the oracle failed to observe them) do not count,
+			// so a stale value cannot reach quorum on its own.
+			for id := range observation.UnchangedStreamIDs {
+				if p.isStale(observation, id) {
+					continue
+				}
+				if prev, ok := previousMedian(previousOutcome.StreamAggregates, id); ok {
+					streamObservations[id] = append(streamObservations[id], prev)
+				}
+			}
 		}
+		if p.verboseLogging() {
+			p.Logger.Debugw("Got observations from peer", "stage", "Outcome", "sv", p.LogRedaction.StreamValues(streamObservations), "oracleID", ao.Observer, "seqNr", outctx.SeqNr)
+		}
+	}
+
+	if previousOutcomeHash != ([32]byte{}) {
+		p.checkPreviousOutcomeHashes(outctx.SeqNr, previousOutcomeHash, divergentObservers)
 	}
 
+	shouldRetireVotes = len(shouldRetireOracles)
+	removeChannelVotesByID = votes.removeVotesByID()
+	updateChannelDefinitionsByHash = votes.definitions
+	updateChannelVotesByHash = votes.updateVotesByHash()
 	return
 }
 
+// isStale returns true if the source timestamp of the stream is older than
+// OffchainConfig.StreamStalenessBound at the time of the observation. Values
+// without a source timestamp are never stale.
+func (p *Plugin) isStale(observation Observation, id llotypes.StreamID) bool {
+	if p.OffchainConfig.StreamStalenessBound <= 0 {
+		return false
+	}
+	ts, ok := observation.StreamTimestamps[id]
+	if !ok {
+		return false
+	}
+	return observation.UnixTimestampNanoseconds-ts > p.OffchainConfig.StreamStalenessBound.Nanoseconds()
+}
+
 type Outcome struct {
 	// LifeCycleStage the protocol is in
 	LifeCycleStage llotypes.LifeCycleStage
@@ -323,6 +437,20 @@ type Outcome struct {
 	// channels can define different aggregation methods, sometimes we will
 	// need multiple.
 	StreamAggregates StreamAggregates
+	// StreamDispersions contains, for each stream that is the primary stream
+	// of a channel with DispersionOpts, the dispersion of the oracles'
+	// observations of that stream.
+	StreamDispersions map[llotypes.StreamID]StreamValue
+	// WindDownRoundsRemaining is the number of rounds, including this one,
+	// for which a retired protocol instance still generates (specimen)
+	// reports for its channels. Always zero unless retired.
+	WindDownRoundsRemaining uint32
+	// DegradedRounds is the number of consecutive outcomes, up to and
+	// including this one, that had a stream whose value was based on fewer
+	// than 2f+1 oracles (see roundContributors). It is part of the outcome
+	// so that all oracles mark reports and enter degraded mode based on the
+	// same rounds.
+	DegradedRounds uint32
 }
 
 // The Outcome's ObservationsTimestamp rounded down to seconds precision
@@ -346,29 +474,29 @@ func (out *Outcome) GenRetirementReport() RetirementReport {
 // values. The report codec is expected to handle nils and act accordingly
 // (e.g. some values may be optional).
 func (out *Outcome) IsReportable(channelID llotypes.ChannelID) *ErrUnreportableChannel {
-	if out.LifeCycleStage == LifeCycleStageRetired {
-		return &ErrUnreportableChannel{nil, "IsReportable=false; retired channel", channelID}
+	if out.LifeCycleStage == LifeCycleStageRetired && out.WindDownRoundsRemaining == 0 {
+		return &ErrUnreportableChannel{nil, "IsReportable=false; retired channel", channelID, UnreportableCauseRetired}
 	}
 
 	observationsTimestampSeconds, err := out.ObservationsTimestampSeconds()
 	if err != nil {
-		return &ErrUnreportableChannel{err, "IsReportable=false; invalid observations timestamp", channelID}
+		return &ErrUnreportableChannel{err, "IsReportable=false; invalid observations timestamp", channelID, UnreportableCauseInvalidObservationsTimestamp}
 	}
 
 	_, exists := out.ChannelDefinitions[channelID]
 	if !exists {
-		return &ErrUnreportableChannel{nil, "IsReportable=false; no channel definition with this ID", channelID}
+		return &ErrUnreportableChannel{nil, "IsReportable=false; no channel definition with this ID", channelID, UnreportableCauseNoChannelDefinition}
 	}
 
 	if _, ok := out.ValidAfterSeconds[channelID]; !ok {
 		// No validAfterSeconds entry yet, this must be a new channel.
 		// validAfterSeconds will be populated in Outcome() so the channel
 		// becomes reportable in later protocol rounds.
-		return &ErrUnreportableChannel{nil, "IsReportable=false; no validAfterSeconds entry yet, this must be a new channel", channelID}
+		return &ErrUnreportableChannel{nil, "IsReportable=false; no validAfterSeconds entry yet, this must be a new channel", channelID, UnreportableCauseNewChannel}
 	}
 
 	if validAfterSeconds := out.ValidAfterSeconds[channelID]; validAfterSeconds >= observationsTimestampSeconds {
-		return &ErrUnreportableChannel{nil, fmt.Sprintf("IsReportable=false; not valid yet (observationsTimestampSeconds=%d < validAfterSeconds=%d)", observationsTimestampSeconds, validAfterSeconds), channelID}
+		return &ErrUnreportableChannel{nil, fmt.Sprintf("IsReportable=false; not valid yet (observationsTimestampSeconds=%d < validAfterSeconds=%d)", observationsTimestampSeconds, validAfterSeconds), channelID, UnreportableCauseNotValidYet}
 	}
 
 	return nil
@@ -392,10 +520,96 @@ func (out *Outcome) ReportableChannels() (reportable []llotypes.ChannelID, unrep
 	return
 }
 
+// isReportable is Outcome.IsReportable with the additional requirements of
+// the offchain config
+func (p *Plugin) isReportable(out *Outcome, channelID llotypes.ChannelID) *ErrUnreportableChannel {
+	if err := out.IsReportable(channelID); err != nil {
+		return err
+	}
+	if p.OffchainConfig.RequireStreamAggregates && !out.hasStreamAggregates(p.channelOpts, out.ChannelDefinitions[channelID]) {
+		return &ErrUnreportableChannel{nil, "IsReportable=false; missing median, none of the channel's streams were aggregated", channelID, UnreportableCauseMissingMedian}
+	}
+	return nil
+}
+
+// reportableChannels is Outcome.ReportableChannels with the additional
+// requirements of the offchain config
+func (p *Plugin) reportableChannels(out *Outcome) (reportable []llotypes.ChannelID, unreportable []*ErrUnreportableChannel) {
+	reportable, unreportable = out.ReportableChannels()
+	if !p.OffchainConfig.RequireStreamAggregates {
+		return
+	}
+	n := 0
+	for _, channelID := range reportable {
+		if err := p.isReportable(out, channelID); err != nil {
+			unreportable = append(unreportable, err)
+		} else {
+			reportable[n] = channelID
+			n++
+		}
+	}
+	return reportable[:n], unreportable
+}
+
+// hasStreamAggregates returns false if the channel has streams but none of
+// them, including its fee streams, were aggregated
+func (out *Outcome) hasStreamAggregates(cache *channelOptsCache, cd llotypes.ChannelDefinition) bool {
+	streams := channelStreams(cache, cd)
+	for _, strm := range streams {
+		if _, ok := out.StreamAggregates[strm.StreamID][strm.Aggregator]; ok {
+			return true
+		}
+	}
+	return len(streams) == 0
+}
+
+// UnreportableCause classifies why IsReportable considers a channel
+// unreportable, so that callers can log and count the causes differently
+type UnreportableCause string
+
+const (
+	// UnreportableCauseRetired means that the protocol instance is retired
+	// and has finished winding down
+	UnreportableCauseRetired UnreportableCause = "Retired"
+	// UnreportableCauseInvalidObservationsTimestamp means that the outcome's
+	// observations timestamp is out of range
+	UnreportableCauseInvalidObservationsTimestamp UnreportableCause = "InvalidObservationsTimestamp"
+	// UnreportableCauseNoChannelDefinition means that the outcome has no
+	// definition for the channel
+	UnreportableCauseNoChannelDefinition UnreportableCause = "NoChannelDefinition"
+	// UnreportableCauseNewChannel means that the channel was only just added
+	// and will become reportable in a later round
+	UnreportableCauseNewChannel UnreportableCause = "NewChannel"
+	// UnreportableCauseNotValidYet means that the channel has already been
+	// reported on for the outcome's observations timestamp
+	UnreportableCauseNotValidYet UnreportableCause = "NotValidYet"
+	// UnreportableCauseMissingMedian means that none of the channel's
+	// streams could be aggregated (see
+	// OffchainConfig.RequireStreamAggregates)
+	UnreportableCauseMissingMedian UnreportableCause = "MissingMedian"
+	// UnreportableCauseReportCountLimit means that the channel is reportable,
+	// but its reports were deferred to a later round because the round has
+	// more reports than OffchainConfig.MaxReportsPerRound
+	UnreportableCauseReportCountLimit UnreportableCause = "ReportCountLimit"
+)
+
+// Expected returns true if the cause occurs in normal operation, e.g. while
+// a channel is being added, and false if it indicates a bug or
+// misconfiguration worth alerting on
+func (c UnreportableCause) Expected() bool {
+	switch c {
+	case UnreportableCauseRetired, UnreportableCauseNewChannel, UnreportableCauseNotValidYet, UnreportableCauseReportCountLimit:
+		return true
+	default:
+		return false
+	}
+}
+
 type ErrUnreportableChannel struct {
 	Inner     error `json:",omitempty"`
 	Reason    string
 	ChannelID llotypes.ChannelID
+	Cause     UnreportableCause
 }
 
 func (e *ErrUnreportableChannel) Error() string {
@@ -437,6 +651,5 @@ func MakeChannelHash(cd ChannelDefinitionWithID) ChannelHash {
 }
 
 func medianTimestamp(timestampsNanoseconds []int64) int64 {
-	sort.Slice(timestampsNanoseconds, func(i, j int) bool { return timestampsNanoseconds[i] < timestampsNanoseconds[j] })
-	return timestampsNanoseconds[len(timestampsNanoseconds)/2]
+	return selectKth(timestampsNanoseconds, len(timestampsNanoseconds)/2, cmp.Compare[int64])
 }
@@ -133,6 +133,53 @@ func Test_Outcome(t *testing.T) {
 			assert.Equal(t, newCd, decoded.ChannelDefinitions[42])
 		})
 
+		t.Run("replacing a channel definition does not change the previous outcome's definition", func(t *testing.T) {
+			obs, err := p.ObservationCodec.Encode(Observation{
+				UnixTimestampNanoseconds: int64(210 * time.Second),
+				UpdateChannelDefinitions: map[llotypes.ChannelID]llotypes.ChannelDefinition{
+					42: {
+						ReportFormat: llotypes.ReportFormatJSON,
+						Streams:      []llotypes.Stream{{StreamID: 2, Aggregator: llotypes.AggregatorMedian}},
+					},
+				},
+			})
+			require.NoError(t, err)
+			aos := []types.AttributedObservation{}
+			for i := 0; i < 4; i++ {
+				aos = append(aos,
+					types.AttributedObservation{
+						Observation: obs,
+						Observer:    commontypes.OracleID(i),
+					})
+			}
+
+			previousOutcome, err := p.OutcomeCodec.Encode(Outcome{
+				LifeCycleStage:                   LifeCycleStageProduction,
+				ObservationsTimestampNanoseconds: int64(200 * time.Second),
+				ChannelDefinitions: map[llotypes.ChannelID]llotypes.ChannelDefinition{
+					42: {
+						ReportFormat: llotypes.ReportFormatJSON,
+						Streams:      []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}},
+					},
+				},
+				ValidAfterSeconds: map[llotypes.ChannelID]uint32{42: 100},
+				StreamAggregates: map[llotypes.StreamID]map[llotypes.Aggregator]StreamValue{
+					1: {llotypes.AggregatorMedian: ToDecimal(decimal.NewFromInt(100))},
+				},
+			})
+			require.NoError(t, err)
+
+			outcome, err := p.Outcome(ctx, ocr3types.OutcomeContext{PreviousOutcome: previousOutcome, SeqNr: 2}, types.Query{}, aos)
+			require.NoError(t, err)
+
+			decoded, err := p.OutcomeCodec.Decode(outcome)
+			require.NoError(t, err)
+
+			// the previous outcome reported on channel 42 with its old
+			// definition, so ValidAfterSeconds advances
+			assert.Equal(t, uint32(200), decoded.ValidAfterSeconds[42])
+		})
+
 		t.Run("does not add channels beyond MaxOutcomeChannelDefinitionsLength", func(t *testing.T) {
 			newCd := llotypes.ChannelDefinition{
 				ReportFormat: llotypes.ReportFormat(2),
@@ -166,6 +213,44 @@ func Test_Outcome(t *testing.T) {
 			assert.NotContains(t, decoded.ChannelDefinitions, llotypes.ChannelID(MaxOutcomeChannelDefinitionsLength))
 			assert.NotContains(t, decoded.ChannelDefinitions, llotypes.ChannelID(MaxOutcomeChannelDefinitionsLength+1))
 		})
+
+		t.Run("counts at most one vote per oracle per channel", func(t *testing.T) {
+			newCd := llotypes.ChannelDefinition{
+				ReportFormat: llotypes.ReportFormat(2),
+				Streams:      []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}},
+			}
+			existingCd := llotypes.ChannelDefinition{
+				ReportFormat: llotypes.ReportFormat(1),
+				Streams:      []llotypes.Stream{{StreamID: 2, Aggregator: llotypes.AggregatorMedian}},
+			}
+			previousOutcome, err := p.OutcomeCodec.Encode(Outcome{
+				LifeCycleStage:     LifeCycleStageProduction,
+				ChannelDefinitions: map[llotypes.ChannelID]llotypes.ChannelDefinition{1: existingCd},
+			})
+			require.NoError(t, err)
+			obs, err := p.ObservationCodec.Encode(Observation{
+				ShouldRetire:             true,
+				RemoveChannelIDs:         map[llotypes.ChannelID]struct{}{1: {}},
+				UpdateChannelDefinitions: map[llotypes.ChannelID]llotypes.ChannelDefinition{42: newCd},
+			})
+			require.NoError(t, err)
+			// oracle 3 votes f+1 times; the others don't vote
+			aos := []types.AttributedObservation{}
+			for i := 0; i < 3; i++ {
+				aos = append(aos, types.AttributedObservation{Observation: []byte{}, Observer: commontypes.OracleID(i)})
+			}
+			for i := 0; i < p.F+1; i++ {
+				aos = append(aos, types.AttributedObservation{Observation: obs, Observer: commontypes.OracleID(3)})
+			}
+			outcome, err := p.Outcome(ctx, ocr3types.OutcomeContext{PreviousOutcome: previousOutcome, SeqNr: 2}, types.Query{}, aos)
+			require.NoError(t, err)
+
+			decoded, err := p.OutcomeCodec.Decode(outcome)
+			require.NoError(t, err)
+
+			assert.Equal(t, llotypes.ChannelDefinitions{1: existingCd}, decoded.ChannelDefinitions)
+			assert.Equal(t, LifeCycleStageProduction, decoded.LifeCycleStage)
+		})
 	})
 
 	t.Run("stream observations", func(t *testing.T) {
@@ -448,6 +533,290 @@ func Test_Outcome(t *testing.T) {
 				llotypes.AggregatorQuote: &Quote{Bid: decimal.NewFromInt(320), Benchmark: decimal.NewFromInt(330), Ask: decimal.NewFromInt(340)},
 			}, decoded.StreamAggregates[3])
 		})
+		t.Run("counts unchanged streams as observations of the previous median if SkipUnchangedStreamValues is enabled", func(t *testing.T) {
+			p := &Plugin{
+				Config:           Config{true},
+				OutcomeCodec:     protoOutcomeCodec{},
+				Logger:           logger.Test(t),
+				ObservationCodec: protoObservationCodec{},
+				F:                1,
+				OffchainConfig:   OffchainConfig{SkipUnchangedStreamValues: true},
+			}
+			previousOutcome := Outcome{
+				LifeCycleStage:                   llotypes.LifeCycleStage("test"),
+				ObservationsTimestampNanoseconds: testStartTS.UnixNano(),
+				ChannelDefinitions:               cdc.definitions,
+				StreamAggregates: StreamAggregates{
+					1: {llotypes.AggregatorMedian: ToDecimal(decimal.NewFromInt(100))},
+					2: {llotypes.AggregatorMedian: ToDecimal(decimal.NewFromInt(200))},
+				},
+			}
+			encodedPreviousOutcome, err := p.OutcomeCodec.Encode(previousOutcome)
+			require.NoError(t, err)
+			outctx := ocr3types.OutcomeContext{SeqNr: 2, PreviousOutcome: encodedPreviousOutcome}
+
+			observations := []Observation{
+				{StreamValues: StreamValues{1: ToDecimal(decimal.NewFromInt(130))}, UnchangedStreamIDs: map[llotypes.StreamID]struct{}{}},
+				{StreamValues: StreamValues{}, UnchangedStreamIDs: map[llotypes.StreamID]struct{}{1: {}}},
+				{StreamValues: StreamValues{}, UnchangedStreamIDs: map[llotypes.StreamID]struct{}{1: {}, 2: {}}},
+				// failed to observe anything
+				{},
+			}
+			aos := []types.AttributedObservation{}
+			for i, obs := range observations {
+				obs.UnixTimestampNanoseconds = testStartTS.UnixNano() + int64(time.Second)
+				encoded, err2 := p.ObservationCodec.Encode(obs)
+				require.NoError(t, err2)
+				aos = append(aos,
+					types.AttributedObservation{
+						Observation: encoded,
+						Observer:    commontypes.OracleID(i),
+					})
+			}
+			outcome, err := p.Outcome(ctx, outctx, types.Query{}, aos)
+			require.NoError(t, err)
+
+			decoded, err := p.OutcomeCodec.Decode(outcome)
+			require.NoError(t, err)
+
+			// NOTE: `2` is missing because only one oracle listed it as
+			// unchanged; omissions do not count
+			require.Len(t, decoded.StreamAggregates, 1)
+			assert.Equal(t, map[llotypes.Aggregator]StreamValue{
+				llotypes.AggregatorMedian: ToDecimal(decimal.NewFromInt(100)),
+			}, decoded.StreamAggregates[1])
+
+			t.Run("ignores unchanged streams if SkipUnchangedStreamValues is disabled", func(t *testing.T) {
+				p.OffchainConfig = OffchainConfig{}
+
+				outcome, err := p.Outcome(ctx, outctx, types.Query{}, aos)
+				require.NoError(t, err)
+
+				decoded, err := p.OutcomeCodec.Decode(outcome)
+				require.NoError(t, err)
+
+				assert.Len(t, decoded.StreamAggregates, 0)
+			})
+		})
+		t.Run("excludes stale values if StreamStalenessBound is enabled", func(t *testing.T) {
+			p := &Plugin{
+				Config:           Config{true},
+				OutcomeCodec:     protoOutcomeCodec{},
+				Logger:           logger.Test(t),
+				ObservationCodec: protoObservationCodec{},
+				F:                1,
+				OffchainConfig:   OffchainConfig{StreamStalenessBound: time.Minute},
+			}
+			previousOutcome := Outcome{
+				LifeCycleStage:                   llotypes.LifeCycleStage("test"),
+				ObservationsTimestampNanoseconds: testStartTS.UnixNano(),
+				ChannelDefinitions:               cdc.definitions,
+			}
+			encodedPreviousOutcome, err := p.OutcomeCodec.Encode(previousOutcome)
+			require.NoError(t, err)
+			outctx := ocr3types.OutcomeContext{SeqNr: 2, PreviousOutcome: encodedPreviousOutcome}
+
+			observationTS := testStartTS.UnixNano() + int64(time.Second)
+			fresh := observationTS - int64(time.Minute)
+			stale := observationTS - int64(time.Minute) - 1
+			aos := []types.AttributedObservation{}
+			for i := 0; i < 4; i++ {
+				obs := Observation{
+					UnixTimestampNanoseconds: observationTS,
+					StreamValues: map[llotypes.StreamID]StreamValue{
+						1: ToDecimal(decimal.NewFromInt(int64(100 + i))),
+						2: ToDecimal(decimal.NewFromInt(int64(200 + i))),
+						// no source timestamp; never stale
+						3: &Quote{Bid: decimal.NewFromInt(int64(320)), Benchmark: decimal.NewFromInt(int64(330)), Ask: decimal.NewFromInt(int64(340))},
+					},
+					StreamTimestamps: StreamTimestamps{1: fresh, 2: stale},
+				}
+				if i < 2 {
+					// stuck upstream source
+					obs.StreamValues[1] = ToDecimal(decimal.NewFromInt(1))
+					obs.StreamTimestamps[1] = stale
+				}
+				if i == 0 {
+					obs.StreamTimestamps[2] = fresh
+				}
+				encoded, err2 := p.ObservationCodec.Encode(obs)
+				require.NoError(t, err2)
+				aos = append(aos,
+					types.AttributedObservation{
+						Observation: encoded,
+						Observer:    commontypes.OracleID(i),
+					})
+			}
+			outcome, err := p.Outcome(ctx, outctx, types.Query{}, aos)
+			require.NoError(t, err)
+
+			decoded, err := p.OutcomeCodec.Decode(outcome)
+			require.NoError(t, err)
+
+			// NOTE: `2` is missing because only one value is fresh
+			require.Len(t, decoded.StreamAggregates, 2)
+			assert.Equal(t, map[llotypes.Aggregator]StreamValue{
+				llotypes.AggregatorMedian: ToDecimal(decimal.NewFromInt(103)),
+			}, decoded.StreamAggregates[1])
+			assert.Equal(t, map[llotypes.Aggregator]StreamValue{
+				llotypes.AggregatorQuote: &Quote{Bid: decimal.NewFromInt(320), Benchmark: decimal.NewFromInt(330), Ask: decimal.NewFromInt(340)},
+			}, decoded.StreamAggregates[3])
+		})
+		t.Run("aggregates fee streams of channels with FeeStreamsOpts", func(t *testing.T) {
+			dfns := llotypes.ChannelDefinitions{
+				1: {
+					ReportFormat: llotypes.ReportFormatJSON,
+					Streams:      []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}},
+					Opts:         []byte(`{"nativeFeeStreamID":2,"linkFeeStreamID":3}`),
+				},
+			}
+			previousOutcome := Outcome{
+				LifeCycleStage:                   llotypes.LifeCycleStage("test"),
+				ObservationsTimestampNanoseconds: testStartTS.UnixNano(),
+				ChannelDefinitions:               dfns,
+			}
+			encodedPreviousOutcome, err := p.OutcomeCodec.Encode(previousOutcome)
+			require.NoError(t, err)
+			outctx := ocr3types.OutcomeContext{SeqNr: 2, PreviousOutcome: encodedPreviousOutcome}
+			aos := []types.AttributedObservation{}
+			for i := 0; i < 4; i++ {
+				obs := Observation{
+					UnixTimestampNanoseconds: testStartTS.UnixNano() + int64(time.Second),
+					StreamValues: map[llotypes.StreamID]StreamValue{
+						1: ToDecimal(decimal.NewFromInt(int64(100 + i))),
+						2: ToDecimal(decimal.NewFromInt(int64(2000 + i))),
+						3: ToDecimal(decimal.NewFromInt(int64(20 + i))),
+					}}
+				encoded, err2 := p.ObservationCodec.Encode(obs)
+				require.NoError(t, err2)
+				aos = append(aos,
+					types.AttributedObservation{
+						Observation: encoded,
+						Observer:    commontypes.OracleID(i),
+					})
+			}
+			outcome, err := p.Outcome(ctx, outctx, types.Query{}, aos)
+			require.NoError(t, err)
+
+			decoded, err := p.OutcomeCodec.Decode(outcome)
+			require.NoError(t, err)
+
+			assert.Equal(t, StreamAggregates{
+				1: {llotypes.AggregatorMedian: ToDecimal(decimal.NewFromInt(102))},
+				2: {llotypes.AggregatorMedian: ToDecimal(decimal.NewFromInt(2002))},
+				3: {llotypes.AggregatorMedian: ToDecimal(decimal.NewFromInt(22))},
+			}, decoded.StreamAggregates)
+		})
+		t.Run("calculates dispersion of the primary stream for channels with DispersionOpts", func(t *testing.T) {
+			dfns := llotypes.ChannelDefinitions{
+				1: {
+					ReportFormat: llotypes.ReportFormatJSON,
+					Streams:      []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}, {StreamID: 2, Aggregator: llotypes.AggregatorMedian}},
+					Opts:         []byte(`{"dispersion":"iqr"}`),
+				},
+				2: {
+					ReportFormat: llotypes.ReportFormatJSON,
+					Streams:      []llotypes.Stream{{StreamID: 2, Aggregator: llotypes.AggregatorMedian}},
+				},
+				3: {
+					ReportFormat: llotypes.ReportFormatJSON,
+					Streams:      []llotypes.Stream{{StreamID: 3, Aggregator: llotypes.AggregatorMedian}},
+					Opts:         []byte(`{"dispersion":"unknown"}`),
+				},
+			}
+			previousOutcome := Outcome{
+				LifeCycleStage:                   llotypes.LifeCycleStage("test"),
+				ObservationsTimestampNanoseconds: testStartTS.UnixNano(),
+				ChannelDefinitions:               dfns,
+			}
+			encodedPreviousOutcome, err := p.OutcomeCodec.Encode(previousOutcome)
+			require.NoError(t, err)
+			outctx := ocr3types.OutcomeContext{SeqNr: 2, PreviousOutcome: encodedPreviousOutcome}
+			aos := []types.AttributedObservation{}
+			for i := 0; i < 4; i++ {
+				obs := Observation{
+					UnixTimestampNanoseconds: testStartTS.UnixNano() + int64(time.Second),
+					StreamValues: map[llotypes.StreamID]StreamValue{
+						1: ToDecimal(decimal.NewFromInt(int64(100 + i*10))),
+						2: ToDecimal(decimal.NewFromInt(int64(200 + i*10))),
+						3: ToDecimal(decimal.NewFromInt(int64(300 + i*10))),
+					}}
+				encoded, err2 := p.ObservationCodec.Encode(obs)
+				require.NoError(t, err2)
+				aos = append(aos,
+					types.AttributedObservation{
+						Observation: encoded,
+						Observer:    commontypes.OracleID(i),
+					})
+			}
+			outcome, err := p.Outcome(ctx, outctx, types.Query{}, aos)
+			require.NoError(t, err)
+
+			decoded, err := p.OutcomeCodec.Decode(outcome)
+			require.NoError(t, err)
+
+			// 100 110 120 130 with f=1: Q1=110, Q3=120
+			assert.Equal(t, map[llotypes.StreamID]StreamValue{
+				1: ToDecimal(decimal.NewFromInt(10)),
+			}, decoded.StreamDispersions)
+		})
+	})
+	t.Run("retired instance stays reportable for RetirementWindDownRounds without advancing ValidAfterSeconds", func(t *testing.T) {
+		p := *p
+		p.OffchainConfig = OffchainConfig{RetirementWindDownRounds: 2}
+		cd := llotypes.ChannelDefinition{
+			ReportFormat: llotypes.ReportFormatJSON,
+			Streams:      []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}},
+		}
+		previousOutcome, err := p.OutcomeCodec.Encode(Outcome{
+			LifeCycleStage:                   LifeCycleStageProduction,
+			ObservationsTimestampNanoseconds: int64(102030409 * time.Second),
+			ChannelDefinitions:               llotypes.ChannelDefinitions{1: cd},
+			ValidAfterSeconds:                map[llotypes.ChannelID]uint32{1: 102030400},
+			StreamAggregates: map[llotypes.StreamID]map[llotypes.Aggregator]StreamValue{
+				1: {llotypes.AggregatorMedian: ToDecimal(decimal.NewFromInt(100))},
+			},
+		})
+		require.NoError(t, err)
+
+		round := func(seqNr uint64, ts time.Duration, shouldRetire bool) Outcome {
+			aos := []types.AttributedObservation{}
+			for i := 0; i < 4; i++ {
+				encoded, err2 := p.ObservationCodec.Encode(Observation{
+					UnixTimestampNanoseconds: int64(ts),
+					ShouldRetire:             shouldRetire,
+					StreamValues:             StreamValues{1: ToDecimal(decimal.NewFromInt(100))},
+				})
+				require.NoError(t, err2)
+				aos = append(aos, types.AttributedObservation{Observation: encoded, Observer: commontypes.OracleID(i)})
+			}
+			outcome, err2 := p.Outcome(ctx, ocr3types.OutcomeContext{SeqNr: seqNr, PreviousOutcome: previousOutcome}, types.Query{}, aos)
+			require.NoError(t, err2)
+			previousOutcome = outcome
+			decoded, err2 := p.OutcomeCodec.Decode(outcome)
+			require.NoError(t, err2)
+			return decoded
+		}
+
+		// retires; the previous production outcome was reportable so
+		// ValidAfterSeconds advances one last time
+		decoded := round(2, 102030415*time.Second, true)
+		assert.Equal(t, LifeCycleStageRetired, decoded.LifeCycleStage)
+		assert.Equal(t, uint32(2), decoded.WindDownRoundsRemaining)
+		assert.Equal(t, map[llotypes.ChannelID]uint32{1: 102030409}, decoded.ValidAfterSeconds)
+		assert.Nil(t, decoded.IsReportable(1))
+
+		decoded = round(3, 102030420*time.Second, false)
+		assert.Equal(t, uint32(1), decoded.WindDownRoundsRemaining)
+		assert.Equal(t, map[llotypes.ChannelID]uint32{1: 102030409}, decoded.ValidAfterSeconds)
+		assert.Equal(t, ToDecimal(decimal.NewFromInt(100)), decoded.StreamAggregates[1][llotypes.AggregatorMedian])
+		assert.Nil(t, decoded.IsReportable(1))
+
+		decoded = round(4, 102030425*time.Second, false)
+		assert.Zero(t, decoded.WindDownRoundsRemaining)
+		assert.Equal(t, map[llotypes.ChannelID]uint32{1: 102030409}, decoded.ValidAfterSeconds)
+		require.NotNil(t, decoded.IsReportable(1))
+		assert.Equal(t, UnreportableCauseRetired, decoded.IsReportable(1).Cause)
 	})
 	t.Run("if previousOutcome is retired, returns outcome as normal", func(t *testing.T) {
 		previousOutcome := Outcome{
@@ -569,6 +938,15 @@ func Test_MakeChannelHash(t *testing.T) {
 	})
 }
 
+func Test_UnreportableCause_Expected(t *testing.T) {
+	for _, c := range []UnreportableCause{UnreportableCauseRetired, UnreportableCauseNewChannel, UnreportableCauseNotValidYet, UnreportableCauseReportCountLimit} {
+		assert.True(t, c.Expected(), c)
+	}
+	for _, c := range []UnreportableCause{UnreportableCauseInvalidObservationsTimestamp, UnreportableCauseNoChannelDefinition, UnreportableCauseMissingMedian} {
+		assert.False(t, c.Expected(), c)
+	}
+}
+
 func Test_Outcome_Methods(t *testing.T) {
 	t.Run("IsReportable", func(t *testing.T) {
 		outcome := Outcome{}
@@ -577,26 +955,36 @@ func Test_Outcome_Methods(t *testing.T) {
 		// Not reportable if retired
 		outcome.LifeCycleStage = LifeCycleStageRetired
 		assert.EqualError(t, outcome.IsReportable(cid), "ChannelID: 1; Reason: IsReportable=false; retired channel")
+		assert.Equal(t, UnreportableCauseRetired, outcome.IsReportable(cid).Cause)
 
 		// Timestamp overflow
 		outcome.LifeCycleStage = LifeCycleStageProduction
 		outcome.ObservationsTimestampNanoseconds = time.Unix(math.MaxInt64, 0).UnixNano()
 		outcome.ChannelDefinitions = map[llotypes.ChannelID]llotypes.ChannelDefinition{}
 		assert.EqualError(t, outcome.IsReportable(cid), "ChannelID: 1; Reason: IsReportable=false; invalid observations timestamp; Err: timestamp doesn't fit into uint32: -1")
+		assert.Equal(t, UnreportableCauseInvalidObservationsTimestamp, outcome.IsReportable(cid).Cause)
 
 		// No channel definition with ID
 		outcome.LifeCycleStage = LifeCycleStageProduction
 		outcome.ObservationsTimestampNanoseconds = time.Unix(1726670490, 0).UnixNano()
 		outcome.ChannelDefinitions = map[llotypes.ChannelID]llotypes.ChannelDefinition{}
 		assert.EqualError(t, outcome.IsReportable(cid), "ChannelID: 1; Reason: IsReportable=false; no channel definition with this ID")
+		assert.Equal(t, UnreportableCauseNoChannelDefinition, outcome.IsReportable(cid).Cause)
 
 		// No ValidAfterSeconds yet
 		outcome.ChannelDefinitions[cid] = llotypes.ChannelDefinition{}
 		assert.EqualError(t, outcome.IsReportable(cid), "ChannelID: 1; Reason: IsReportable=false; no validAfterSeconds entry yet, this must be a new channel")
+		assert.Equal(t, UnreportableCauseNewChannel, outcome.IsReportable(cid).Cause)
 
 		// ValidAfterSeconds is in the future
 		outcome.ValidAfterSeconds = map[llotypes.ChannelID]uint32{cid: uint32(1726670491)}
 		assert.EqualError(t, outcome.IsReportable(cid), "ChannelID: 1; Reason: IsReportable=false; not valid yet (observationsTimestampSeconds=1726670490 < validAfterSeconds=1726670491)")
+		assert.Equal(t, UnreportableCauseNotValidYet, outcome.IsReportable(cid).Cause)
+
+		// Reportable even if none of the channel's streams were aggregated
+		outcome.ValidAfterSeconds = map[llotypes.ChannelID]uint32{cid: uint32(1726670489)}
+		outcome.ChannelDefinitions[cid] = llotypes.ChannelDefinition{Streams: []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}, {StreamID: 2, Aggregator: llotypes.AggregatorMedian}}}
+		assert.Nil(t, outcome.IsReportable(cid))
 	})
 	t.Run("ReportableChannels", func(t *testing.T) {
 		outcome := Outcome{
@@ -615,5 +1003,38 @@ func Test_Outcome_Methods(t *testing.T) {
 		assert.Equal(t, []llotypes.ChannelID{1, 3}, reportable)
 		require.Len(t, unreportable, 1)
 		assert.Equal(t, "ChannelID: 2; Reason: IsReportable=false; no validAfterSeconds entry yet, this must be a new channel", unreportable[0].Error())
+		assert.Equal(t, UnreportableCauseNewChannel, unreportable[0].Cause)
+	})
+	t.Run("RequireStreamAggregates", func(t *testing.T) {
+		outcome := Outcome{
+			ObservationsTimestampNanoseconds: time.Unix(1726670490, 0).UnixNano(),
+			ChannelDefinitions: map[llotypes.ChannelID]llotypes.ChannelDefinition{
+				1: {Streams: []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}, {StreamID: 2, Aggregator: llotypes.AggregatorMedian}}},
+				2: {Streams: []llotypes.Stream{{StreamID: 3, Aggregator: llotypes.AggregatorMedian}}},
+				3: {},
+			},
+			ValidAfterSeconds: map[llotypes.ChannelID]uint32{
+				1: 1726670489,
+				2: 1726670489,
+				3: 1726670489,
+			},
+			StreamAggregates: map[llotypes.StreamID]map[llotypes.Aggregator]StreamValue{
+				2: {llotypes.AggregatorMedian: ToDecimal(decimal.NewFromInt(1))},
+			},
+		}
+
+		p := &Plugin{}
+		reportable, unreportable := p.reportableChannels(&outcome)
+		assert.Equal(t, []llotypes.ChannelID{1, 2, 3}, reportable)
+		assert.Empty(t, unreportable)
+		assert.Nil(t, p.isReportable(&outcome, 2))
+
+		p.OffchainConfig.RequireStreamAggregates = true
+		reportable, unreportable = p.reportableChannels(&outcome)
+		assert.Equal(t, []llotypes.ChannelID{1, 3}, reportable, "channels without streams do not need aggregates")
+		require.Len(t, unreportable, 1)
+		assert.Equal(t, "ChannelID: 2; Reason: IsReportable=false; missing median, none of the channel's streams were aggregated", unreportable[0].Error())
+		assert.Equal(t, UnreportableCauseMissingMedian, unreportable[0].Cause)
+		assert.Equal(t, unreportable[0], p.isReportable(&outcome, 2))
 	})
 }
@@ -3,6 +3,8 @@ package llo
 import (
 	"context"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/smartcontractkit/libocr/offchainreporting2/types"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
@@ -51,62 +53,179 @@ func (p *Plugin) reports(ctx context.Context, seqNr uint64, rawOutcome ocr3types
 		})
 	}
 
-	reportableChannels, unreportableChannels := outcome.ReportableChannels()
-	if p.Config.VerboseLogging {
+	reportableChannels, unreportableChannels := p.reportableChannels(&outcome)
+	reportableChannels, deferredChannels := outcome.capReports(p.channelOpts, reportableChannels, p.OffchainConfig.ReportCountLimit(), p.OffchainConfig.ChannelPriorityClasses)
+	if len(deferredChannels) > 0 {
+		p.Logger.Warnw("Too many reports for one round, deferring lowest priority channels to later rounds", "lifeCycleStage", outcome.LifeCycleStage, "maxReportsPerRound", p.OffchainConfig.ReportCountLimit(), "reportedChannels", len(reportableChannels), "deferredChannels", len(deferredChannels), "stage", "Report", "seqNr", seqNr)
+		unreportableChannels = append(unreportableChannels, deferredChannels...)
+	}
+	p.prioritizeChannels(reportableChannels, outcome.ChannelDefinitions)
+	p.detectSeqNrGap(seqNr, observationsTimestampSeconds, &outcome, reportableChannels)
+	p.recordDegradedOutcome(seqNr, &outcome)
+	if p.verboseLogging() {
 		p.Logger.Debugw("Reportable channels", "lifeCycleStage", outcome.LifeCycleStage, "reportableChannels", reportableChannels, "unreportableChannels", unreportableChannels, "stage", "Report", "seqNr", seqNr)
 	}
+	for _, unreportable := range unreportableChannels {
+		promUnreportableChannelsTotal.WithLabelValues(p.ConfigDigest.String(), string(unreportable.Cause)).Inc()
+		if !unreportable.Cause.Expected() {
+			p.Logger.Warnw("Channel is unexpectedly not reportable", "lifeCycleStage", outcome.LifeCycleStage, "channelID", unreportable.ChannelID, "cause", unreportable.Cause, "err", unreportable, "stage", "Report", "seqNr", seqNr)
+		}
+	}
 
+	reducedConfidence := p.reducedConfidence(&outcome)
+	observedAt := time.Unix(0, outcome.ObservationsTimestampNanoseconds)
+	outcomeAt := p.outcomeTimes.get(seqNr)
 	for _, cid := range reportableChannels {
 		cd := outcome.ChannelDefinitions[cid]
 		values := make([]StreamValue, 0, len(cd.Streams))
 		for _, strm := range cd.Streams {
 			values = append(values, outcome.StreamAggregates[strm.StreamID][strm.Aggregator])
 		}
-
-		report := Report{
-			p.ConfigDigest,
-			seqNr,
-			cid,
-			outcome.ValidAfterSeconds[cid],
-			observationsTimestampSeconds,
-			values,
-			outcome.LifeCycleStage != LifeCycleStageProduction,
+		opts := p.channelOpts.of(cd)
+		if opts.dispersionErr == nil && opts.dispersion != "" {
+			values = append(values, outcome.StreamDispersions[cd.Streams[0].StreamID])
+		}
+		if opts.feeStreamsErr == nil {
+			for _, strm := range opts.feeStreams {
+				values = append(values, outcome.StreamAggregates[strm.StreamID][strm.Aggregator])
+			}
 		}
 
-		if p.Config.VerboseLogging {
-			p.Logger.Debugw("Emitting report", "lifeCycleStage", outcome.LifeCycleStage, "channelID", cid, "report", report, "stage", "Report", "seqNr", seqNr)
+		report := Report{
+			ConfigDigest:                p.ConfigDigest,
+			SeqNr:                       seqNr,
+			ChannelID:                   cid,
+			ValidAfterSeconds:           outcome.ValidAfterSeconds[cid],
+			ObservationTimestampSeconds: observationsTimestampSeconds,
+			Values:                      values,
+			Specimen:                    outcome.LifeCycleStage != LifeCycleStageProduction,
+			ReducedConfidence:           reducedConfidence,
 		}
 
-		encoded, err := p.encodeReport(ctx, report, cd)
-		if err != nil {
-			if ctx.Err() != nil {
-				return nil, context.Cause(ctx)
+		// Values are validated once for all of the channel's report formats
+		if err := checkChannelValuePolicies(p.channelOpts, cd, outcome.StreamAggregates); err != nil {
+			for _, rf := range channelReportFormats(p.channelOpts, cd) {
+				promReportEncodeErrorsTotal.WithLabelValues(p.ConfigDigest.String(), FormatChannelID(cid), rf.String()).Inc()
 			}
-			p.Logger.Warnw("Error encoding report", "lifeCycleStage", outcome.LifeCycleStage, "reportFormat", cd.ReportFormat, "err", err, "channelID", cid, "stage", "Report", "seqNr", seqNr)
+			p.Logger.Errorw("Report values violate stream value policy, skipping channel", "lifeCycleStage", outcome.LifeCycleStage, "reportFormat", cd.ReportFormat, "err", p.LogRedaction.Err(err), "channelID", cid, "stage", "Report", "seqNr", seqNr)
 			continue
 		}
-		rwis = append(rwis, ocr3types.ReportPlus[llotypes.ReportInfo]{
-			ReportWithInfo: ocr3types.ReportWithInfo[llotypes.ReportInfo]{
-				Report: encoded,
-				Info: llotypes.ReportInfo{
-					LifeCycleStage: outcome.LifeCycleStage,
-					ReportFormat:   cd.ReportFormat,
+
+		for _, rf := range channelReportFormats(p.channelOpts, cd) {
+			fcd := withReportFormat(cd, rf)
+			if p.verboseLogging() {
+				p.Logger.Debugw("Emitting report", "lifeCycleStage", outcome.LifeCycleStage, "channelID", cid, "report", p.LogRedaction.Report(report), "reportFormat", rf, "schemaVersion", p.schemaVersion(fcd), "stage", "Report", "seqNr", seqNr)
+			}
+
+			encoded, err := p.encodeReport(ctx, report, fcd)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil, context.Cause(ctx)
+				}
+				// Skip only this report; the reports of other channels and
+				// formats are still valid
+				promReportEncodeErrorsTotal.WithLabelValues(p.ConfigDigest.String(), FormatChannelID(cid), rf.String()).Inc()
+				p.Logger.Errorw("Error encoding report, skipping report", "lifeCycleStage", outcome.LifeCycleStage, "reportFormat", rf, "err", err, "channelID", cid, "stage", "Report", "seqNr", seqNr)
+				continue
+			}
+			p.recordTransmissionTargets(seqNr, encoded, report, cd)
+			p.recordReportTimestamps(seqNr, encoded, ReportTimestamps{cid, observedAt, outcomeAt, time.Now()}, rf == cd.ReportFormat)
+			if p.LastTransmissions != nil && rf == cd.ReportFormat {
+				p.LastTransmissions.generated(p.ConfigDigest, seqNr, encoded, cid, observationsTimestampSeconds)
+			}
+			rwis = append(rwis, ocr3types.ReportPlus[llotypes.ReportInfo]{
+				ReportWithInfo: ocr3types.ReportWithInfo[llotypes.ReportInfo]{
+					Report: encoded,
+					Info: llotypes.ReportInfo{
+						LifeCycleStage: outcome.LifeCycleStage,
+						ReportFormat:   rf,
+					},
 				},
-			},
-		})
+			})
+		}
 	}
 
-	if p.Config.VerboseLogging && len(rwis) == 0 {
+	if p.verboseLogging() && len(rwis) == 0 {
 		p.Logger.Debugw("No reports, will not transmit anything", "lifeCycleStage", outcome.LifeCycleStage, "reportableChannels", reportableChannels, "stage", "Report", "seqNr", seqNr)
 	}
 
+	summary := OutcomeSummary{
+		SeqNr:                            seqNr,
+		LifeCycleStage:                   outcome.LifeCycleStage,
+		ObservationsTimestampNanoseconds: outcome.ObservationsTimestampNanoseconds,
+		ChannelCount:                     len(outcome.ChannelDefinitions),
+		ReportableChannelCount:           len(reportableChannels),
+		StreamAggregateCount:             len(outcome.StreamAggregates),
+		ReportCount:                      len(rwis),
+	}
+	promLatestOutcomeSeqNr.WithLabelValues(p.ConfigDigest.String()).Set(float64(seqNr))
+	if p.Introspector != nil {
+		p.Introspector.recordOutcome(p.ConfigDigest, summary)
+	}
+	if p.OutcomeSnapshots != nil {
+		summary.RecordedAt = time.Now()
+		p.OutcomeSnapshots.record(p.ConfigDigest, seqNr, rawOutcome, summary)
+	}
+
 	return rwis, nil
 }
 
-func (p *Plugin) encodeReport(ctx context.Context, r Report, cd llotypes.ChannelDefinition) (types.Report, error) {
+// encodeReport encodes the report with the codec of the channel's report
+// format. A codec that panics, e.g. on unexpected stream values, only fails
+// the report of its channel instead of the whole round.
+func (p *Plugin) encodeReport(ctx context.Context, r Report, cd llotypes.ChannelDefinition) (encoded types.Report, err error) {
 	codec, exists := p.ReportCodecs[cd.ReportFormat]
 	if !exists {
 		return nil, fmt.Errorf("codec missing for ReportFormat=%q", cd.ReportFormat)
 	}
+	defer func() {
+		if rec := recover(); rec != nil {
+			encoded, err = nil, fmt.Errorf("codec for ReportFormat=%q panicked: %v", cd.ReportFormat, rec)
+		}
+	}()
 	return codec.Encode(ctx, r, cd)
 }
+
+// schemaVersion returns the schema version used to encode reports for the
+// channel, or zero if its codec does not support versioning
+func (p *Plugin) schemaVersion(cd llotypes.ChannelDefinition) uint32 {
+	if sv, ok := p.ReportCodecs[cd.ReportFormat].(schemaVersioner); ok {
+		if version, err := sv.SchemaVersion(cd); err == nil {
+			return version
+		}
+	}
+	return 0
+}
+
+// recordTransmissionTargets makes the channel's transmission targets and
+// hints for the encoded report available to the transmitter, along with
+// whether the report is marked ReducedConfidence. Channels with
+// invalid targets fall back to the default targets, and channels with
+// invalid hints to the default priority. If
+// OffchainConfig.ChannelPriorityClasses is set, reports of channels without
+// a transmission priority of their own have the channel's priority.
+func (p *Plugin) recordTransmissionTargets(seqNr uint64, encoded types.Report, report Report, cd llotypes.ChannelDefinition) {
+	if p.TransmissionTargets == nil {
+		return
+	}
+	if report.ReducedConfidence {
+		p.TransmissionTargets.setReducedConfidence(p.ConfigDigest, seqNr, encoded)
+	}
+	opts := p.channelOpts.of(cd)
+	if opts.targetsErr != nil {
+		p.Logger.Warnw("Ignoring transmission targets opts", "channelID", report.ChannelID, "stage", "Report", "seqNr", seqNr, "err", opts.targetsErr)
+	} else if len(opts.targets) > 0 {
+		p.TransmissionTargets.set(p.ConfigDigest, seqNr, encoded, slices.Clone(opts.targets))
+	}
+	if opts.hintsErr != nil {
+		p.Logger.Warnw("Ignoring transmission hints opts", "channelID", report.ChannelID, "stage", "Report", "seqNr", seqNr, "err", opts.hintsErr)
+		return
+	}
+	hintsOpts := opts.hints
+	if p.OffchainConfig.ChannelPriorityClasses && hintsOpts.TransmissionPriority == 0 {
+		hintsOpts.TransmissionPriority = opts.priority
+	}
+	if hints := hintsOpts.hints(report); hints != (TransmissionHints{}) {
+		p.TransmissionTargets.setHints(p.ConfigDigest, seqNr, encoded, hints)
+	}
+}
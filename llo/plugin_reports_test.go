@@ -1,9 +1,11 @@
 package llo
 
 import (
+	"context"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/shopspring/decimal"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
 
@@ -65,6 +67,45 @@ func Test_Reports(t *testing.T) {
 		})
 	})
 
+	t.Run("emits specimen reports alongside the retirement report while winding down", func(t *testing.T) {
+		ctx := tests.Context(t)
+		outcome := Outcome{
+			LifeCycleStage:                   LifeCycleStageRetired,
+			ObservationsTimestampNanoseconds: int64(200 * time.Second),
+			ValidAfterSeconds:                map[llotypes.ChannelID]uint32{1: 100},
+			ChannelDefinitions: map[llotypes.ChannelID]llotypes.ChannelDefinition{
+				1: {
+					ReportFormat: llotypes.ReportFormatJSON,
+					Streams:      []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}},
+				},
+			},
+			StreamAggregates: map[llotypes.StreamID]map[llotypes.Aggregator]StreamValue{
+				1: {
+					llotypes.AggregatorMedian: ToDecimal(decimal.NewFromFloat(1.1)),
+				},
+			},
+			WindDownRoundsRemaining: 1,
+		}
+		encoded, err := p.OutcomeCodec.Encode(outcome)
+		require.NoError(t, err)
+		rwis, err := p.Reports(ctx, 2, encoded)
+		require.NoError(t, err)
+		require.Len(t, rwis, 2)
+		assert.Equal(t, llo.ReportInfo{LifeCycleStage: LifeCycleStageRetired, ReportFormat: llotypes.ReportFormatRetirement}, rwis[0].ReportWithInfo.Info)
+		assert.Equal(t, `{"ValidAfterSeconds":{"1":100}}`, string(rwis[0].ReportWithInfo.Report))
+		assert.Equal(t, llo.ReportInfo{LifeCycleStage: LifeCycleStageRetired, ReportFormat: llotypes.ReportFormatJSON}, rwis[1].ReportWithInfo.Info)
+		assert.Equal(t, `{"ConfigDigest":"0000000000000000000000000000000000000000000000000000000000000000","SeqNr":2,"ChannelID":1,"ValidAfterSeconds":100,"ObservationTimestampSeconds":200,"Values":[{"Type":0,"Value":"1.1"}],"Specimen":true}`, string(rwis[1].ReportWithInfo.Report))
+
+		// once wound down, only the retirement report is emitted
+		outcome.WindDownRoundsRemaining = 0
+		encoded, err = p.OutcomeCodec.Encode(outcome)
+		require.NoError(t, err)
+		rwis, err = p.Reports(ctx, 2, encoded)
+		require.NoError(t, err)
+		require.Len(t, rwis, 1)
+		assert.Equal(t, llotypes.ReportFormatRetirement, rwis[0].ReportWithInfo.Info.ReportFormat)
+	})
+
 	smallDefinitions := map[llotypes.ChannelID]llotypes.ChannelDefinition{
 		1: {
 			ReportFormat: llotypes.ReportFormatJSON,
@@ -164,6 +205,49 @@ func Test_Reports(t *testing.T) {
 		require.Len(t, rwis, 0)
 	})
 
+	t.Run("skips only the channels whose codec fails", func(t *testing.T) {
+		ctx := tests.Context(t)
+		outcome := Outcome{
+			LifeCycleStage:                   LifeCycleStageProduction,
+			ObservationsTimestampNanoseconds: int64(200 * time.Second),
+			ValidAfterSeconds: map[llotypes.ChannelID]uint32{
+				1: 100,
+				2: 100,
+			},
+			ChannelDefinitions: map[llotypes.ChannelID]llotypes.ChannelDefinition{
+				1: {
+					ReportFormat: llotypes.ReportFormatEVMPremiumLegacy,
+					Streams:      []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}},
+				},
+				2: {
+					ReportFormat: llotypes.ReportFormatJSON,
+					Streams:      []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}},
+				},
+			},
+			StreamAggregates: map[llotypes.StreamID]map[llotypes.Aggregator]StreamValue{
+				1: {
+					llotypes.AggregatorMedian: ToDecimal(decimal.NewFromFloat(1.1)),
+				},
+			},
+		}
+		encoded, err := p.OutcomeCodec.Encode(outcome)
+		require.NoError(t, err)
+
+		p := *p
+		p.ReportCodecs = map[llotypes.ReportFormat]ReportCodec{
+			llotypes.ReportFormatJSON:             JSONReportCodec{},
+			llotypes.ReportFormatEVMPremiumLegacy: panickingReportCodec{},
+		}
+		failures := promReportEncodeErrorsTotal.WithLabelValues(p.ConfigDigest.String(), "1", llotypes.ReportFormatEVMPremiumLegacy.String())
+		before := testutil.ToFloat64(failures)
+
+		rwis, err := p.Reports(ctx, 2, encoded)
+		require.NoError(t, err)
+		require.Len(t, rwis, 1)
+		assert.Equal(t, `{"ConfigDigest":"0000000000000000000000000000000000000000000000000000000000000000","SeqNr":2,"ChannelID":2,"ValidAfterSeconds":100,"ObservationTimestampSeconds":200,"Values":[{"Type":0,"Value":"1.1"}],"Specimen":false}`, string(rwis[0].ReportWithInfo.Report))
+		assert.Equal(t, before+1, testutil.ToFloat64(failures))
+	})
+
 	t.Run("generates specimen report for non-production LifeCycleStage", func(t *testing.T) {
 		ctx := tests.Context(t)
 		outcome := Outcome{
@@ -235,6 +319,166 @@ func Test_Reports(t *testing.T) {
 		assert.Equal(t, `{"ConfigDigest":"0000000000000000000000000000000000000000000000000000000000000000","SeqNr":2,"ChannelID":2,"ValidAfterSeconds":100,"ObservationTimestampSeconds":200,"Values":[{"Type":0,"Value":"1.1"},{"Type":0,"Value":"2.2"},{"Type":1,"Value":"Q{Bid: 8.8, Benchmark: 7.7, Ask: 6.6}"}],"Specimen":false}`, string(rwis[1].ReportWithInfo.Report))
 		assert.Equal(t, llo.ReportInfo{LifeCycleStage: "production", ReportFormat: llotypes.ReportFormatJSON}, rwis[1].ReportWithInfo.Info)
 	})
+	t.Run("appends dispersion of the primary stream for channels with DispersionOpts", func(t *testing.T) {
+		ctx := tests.Context(t)
+		outcome := Outcome{
+			LifeCycleStage:                   LifeCycleStageProduction,
+			ObservationsTimestampNanoseconds: int64(200 * time.Second),
+			ValidAfterSeconds: map[llotypes.ChannelID]uint32{
+				1: 100,
+				2: 100,
+			},
+			ChannelDefinitions: map[llotypes.ChannelID]llotypes.ChannelDefinition{
+				1: {
+					ReportFormat: llotypes.ReportFormatJSON,
+					Streams:      []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}},
+					Opts:         []byte(`{"dispersion":"iqr"}`),
+				},
+				2: {
+					ReportFormat: llotypes.ReportFormatJSON,
+					Streams:      []llotypes.Stream{{StreamID: 2, Aggregator: llotypes.AggregatorMedian}},
+					Opts:         []byte(`{"dispersion":"iqr"}`),
+				},
+			},
+			StreamAggregates: map[llotypes.StreamID]map[llotypes.Aggregator]StreamValue{
+				1: {
+					llotypes.AggregatorMedian: ToDecimal(decimal.NewFromFloat(1.1)),
+				},
+				2: {
+					llotypes.AggregatorMedian: ToDecimal(decimal.NewFromFloat(2.2)),
+				},
+			},
+			// no dispersion for stream 2
+			StreamDispersions: map[llotypes.StreamID]StreamValue{
+				1: ToDecimal(decimal.NewFromFloat(0.01)),
+			},
+		}
+		encoded, err := p.OutcomeCodec.Encode(outcome)
+		require.NoError(t, err)
+		rwis, err := p.Reports(ctx, 2, encoded)
+		require.NoError(t, err)
+		// channel 2 cannot be encoded without its dispersion
+		require.Len(t, rwis, 1)
+		assert.Equal(t, `{"ConfigDigest":"0000000000000000000000000000000000000000000000000000000000000000","SeqNr":2,"ChannelID":1,"ValidAfterSeconds":100,"ObservationTimestampSeconds":200,"Values":[{"Type":0,"Value":"1.1"},{"Type":0,"Value":"0.01"}],"Specimen":false}`, string(rwis[0].ReportWithInfo.Report))
+	})
+	t.Run("appends fee stream values for channels with FeeStreamsOpts", func(t *testing.T) {
+		ctx := tests.Context(t)
+		outcome := Outcome{
+			LifeCycleStage:                   LifeCycleStageProduction,
+			ObservationsTimestampNanoseconds: int64(200 * time.Second),
+			ValidAfterSeconds: map[llotypes.ChannelID]uint32{
+				1: 100,
+			},
+			ChannelDefinitions: map[llotypes.ChannelID]llotypes.ChannelDefinition{
+				1: {
+					ReportFormat: llotypes.ReportFormatJSON,
+					Streams:      []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}},
+					Opts:         []byte(`{"nativeFeeStreamID":2,"linkFeeStreamID":3}`),
+				},
+			},
+			StreamAggregates: map[llotypes.StreamID]map[llotypes.Aggregator]StreamValue{
+				1: {
+					llotypes.AggregatorMedian: ToDecimal(decimal.NewFromFloat(1.1)),
+				},
+				2: {
+					llotypes.AggregatorMedian: ToDecimal(decimal.NewFromFloat(2000)),
+				},
+				3: {
+					llotypes.AggregatorMedian: ToDecimal(decimal.NewFromFloat(20)),
+				},
+			},
+		}
+		encoded, err := p.OutcomeCodec.Encode(outcome)
+		require.NoError(t, err)
+		rwis, err := p.Reports(ctx, 2, encoded)
+		require.NoError(t, err)
+		require.Len(t, rwis, 1)
+		assert.Equal(t, `{"ConfigDigest":"0000000000000000000000000000000000000000000000000000000000000000","SeqNr":2,"ChannelID":1,"ValidAfterSeconds":100,"ObservationTimestampSeconds":200,"Values":[{"Type":0,"Value":"1.1"},{"Type":0,"Value":"2000"},{"Type":0,"Value":"20"}],"Specimen":false}`, string(rwis[0].ReportWithInfo.Report))
+	})
+	t.Run("skips channels whose values violate their value policies", func(t *testing.T) {
+		ctx := tests.Context(t)
+		outcome := Outcome{
+			LifeCycleStage:                   LifeCycleStageProduction,
+			ObservationsTimestampNanoseconds: int64(200 * time.Second),
+			ValidAfterSeconds: map[llotypes.ChannelID]uint32{
+				1: 100,
+				2: 100,
+			},
+			ChannelDefinitions: map[llotypes.ChannelID]llotypes.ChannelDefinition{
+				1: {
+					ReportFormat: llotypes.ReportFormatJSON,
+					Streams:      []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}},
+					Opts:         []byte(`{"streamValuePolicies":{"1":"positive"}}`),
+				},
+				2: {
+					ReportFormat: llotypes.ReportFormatJSON,
+					Streams:      []llotypes.Stream{{StreamID: 2, Aggregator: llotypes.AggregatorMedian}},
+					Opts:         []byte(`{"streamValuePolicies":{"2":"any"}}`),
+				},
+			},
+			StreamAggregates: map[llotypes.StreamID]map[llotypes.Aggregator]StreamValue{
+				1: {
+					llotypes.AggregatorMedian: ToDecimal(decimal.Zero),
+				},
+				2: {
+					llotypes.AggregatorMedian: ToDecimal(decimal.NewFromFloat(-0.01)),
+				},
+			},
+		}
+		encoded, err := p.OutcomeCodec.Encode(outcome)
+		require.NoError(t, err)
+		errs := testutil.ToFloat64(promReportEncodeErrorsTotal.WithLabelValues(p.ConfigDigest.String(), "1", llotypes.ReportFormatJSON.String()))
+		rwis, err := p.Reports(ctx, 2, encoded)
+		require.NoError(t, err)
+		require.Len(t, rwis, 1)
+		assert.Contains(t, string(rwis[0].ReportWithInfo.Report), `"ChannelID":2`)
+		assert.Equal(t, errs+1, testutil.ToFloat64(promReportEncodeErrorsTotal.WithLabelValues(p.ConfigDigest.String(), "1", llotypes.ReportFormatJSON.String())))
+	})
+	t.Run("records transmission targets of channels with TransmissionTargetsOpts", func(t *testing.T) {
+		ctx := tests.Context(t)
+		outcome := Outcome{
+			LifeCycleStage:                   LifeCycleStageProduction,
+			ObservationsTimestampNanoseconds: int64(200 * time.Second),
+			ValidAfterSeconds: map[llotypes.ChannelID]uint32{
+				1: 100,
+				2: 100,
+				3: 100,
+			},
+			ChannelDefinitions: map[llotypes.ChannelID]llotypes.ChannelDefinition{
+				1: {
+					ReportFormat: llotypes.ReportFormatJSON,
+					Streams:      []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}},
+					Opts:         []byte(`{"transmissionTargets":["premium","public"]}`),
+				},
+				2: {
+					ReportFormat: llotypes.ReportFormatJSON,
+					Streams:      []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}},
+				},
+				3: {
+					ReportFormat: llotypes.ReportFormatJSON,
+					Streams:      []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}},
+					Opts:         []byte(`{"transmissionTargets":[""]}`),
+				},
+			},
+			StreamAggregates: map[llotypes.StreamID]map[llotypes.Aggregator]StreamValue{
+				1: {
+					llotypes.AggregatorMedian: ToDecimal(decimal.NewFromFloat(1.1)),
+				},
+			},
+		}
+		encoded, err := p.OutcomeCodec.Encode(outcome)
+		require.NoError(t, err)
+
+		p := *p
+		p.TransmissionTargets = NewTransmissionTargets()
+		rwis, err := p.Reports(ctx, 2, encoded)
+		require.NoError(t, err)
+		require.Len(t, rwis, 3)
+		assert.Equal(t, []string{"premium", "public"}, p.TransmissionTargets.Get(p.ConfigDigest, 2, rwis[0].ReportWithInfo.Report))
+		assert.Nil(t, p.TransmissionTargets.Get(p.ConfigDigest, 2, rwis[1].ReportWithInfo.Report))
+		// invalid targets fall back to the defaults
+		assert.Nil(t, p.TransmissionTargets.Get(p.ConfigDigest, 2, rwis[2].ReportWithInfo.Report))
+	})
 	t.Run("does not produce reports with overlapping timestamps (where IsReportable returns false)", func(t *testing.T) {
 		ctx := tests.Context(t)
 		outcome := Outcome{
@@ -271,3 +515,9 @@ func Test_Reports(t *testing.T) {
 		assert.Equal(t, llo.ReportInfo{LifeCycleStage: "production", ReportFormat: llotypes.ReportFormatJSON}, rwis[0].ReportWithInfo.Info)
 	})
 }
+
+type panickingReportCodec struct{}
+
+func (panickingReportCodec) Encode(context.Context, Report, llotypes.ChannelDefinition) ([]byte, error) {
+	panic("boom")
+}
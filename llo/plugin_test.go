@@ -2,15 +2,22 @@ package llo
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/smartcontractkit/libocr/offchainreporting2/types"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
 
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
 	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 )
 
 type mockShouldRetireCache struct {
@@ -32,6 +39,7 @@ func (m *mockChannelDefinitionCache) Definitions() llotypes.ChannelDefinitions {
 
 type mockDataSource struct {
 	s   StreamValues
+	ts  StreamTimestamps
 	err error
 }
 
@@ -42,6 +50,13 @@ func (m *mockDataSource) Observe(ctx context.Context, streamValues StreamValues,
 	return m.err
 }
 
+func (m *mockDataSource) ObserveWithTimestamps(ctx context.Context, streamValues StreamValues, timestamps StreamTimestamps, opts DSOpts) error {
+	for k, v := range m.ts {
+		timestamps[k] = v
+	}
+	return m.Observe(ctx, streamValues, opts)
+}
+
 func Test_ValidateObservation(t *testing.T) {
 	p := &Plugin{
 		Config: Config{true},
@@ -57,4 +72,177 @@ func Test_ValidateObservation(t *testing.T) {
 		err := p.ValidateObservation(ctx, ocr3types.OutcomeContext{SeqNr: 1}, types.Query{}, types.AttributedObservation{Observation: []byte{1}})
 		assert.EqualError(t, err, "Expected empty observation for first round, got: 0x01")
 	})
+	t.Run("UnchangedStreamIDs", func(t *testing.T) {
+		ctx := tests.Context(t)
+		p := &Plugin{
+			Config:           Config{true},
+			ObservationCodec: protoObservationCodec{},
+		}
+		encode := func(t *testing.T, obs Observation) types.AttributedObservation {
+			b, err := p.ObservationCodec.Encode(obs)
+			require.NoError(t, err)
+			return types.AttributedObservation{Observation: b}
+		}
+		outctx := ocr3types.OutcomeContext{SeqNr: 2}
+
+		t.Run("rejected if SkipUnchangedStreamValues is disabled", func(t *testing.T) {
+			ao := encode(t, Observation{UnchangedStreamIDs: map[llotypes.StreamID]struct{}{1: {}}})
+			err := p.ValidateObservation(ctx, outctx, types.Query{}, ao)
+			assert.EqualError(t, err, "UnchangedStreamIDs is not empty even though SkipUnchangedStreamValues is disabled")
+		})
+
+		p.OffchainConfig.SkipUnchangedStreamValues = true
+
+		t.Run("accepted if SkipUnchangedStreamValues is enabled", func(t *testing.T) {
+			ao := encode(t, Observation{
+				StreamValues:       StreamValues{2: ToDecimal(decimal.NewFromInt(2))},
+				UnchangedStreamIDs: map[llotypes.StreamID]struct{}{1: {}},
+			})
+			err := p.ValidateObservation(ctx, outctx, types.Query{}, ao)
+			assert.NoError(t, err)
+		})
+		t.Run("rejected if a stream is also in StreamValues", func(t *testing.T) {
+			ao := encode(t, Observation{
+				StreamValues:       StreamValues{1: ToDecimal(decimal.NewFromInt(1))},
+				UnchangedStreamIDs: map[llotypes.StreamID]struct{}{1: {}},
+			})
+			err := p.ValidateObservation(ctx, outctx, types.Query{}, ao)
+			assert.EqualError(t, err, "stream 1 is in both StreamValues and UnchangedStreamIDs")
+		})
+		t.Run("counts towards the StreamValues limit", func(t *testing.T) {
+			obs := Observation{
+				StreamValues:       StreamValues{},
+				UnchangedStreamIDs: map[llotypes.StreamID]struct{}{},
+			}
+			for i := 0; i < MaxObservationStreamValuesLength; i++ {
+				obs.UnchangedStreamIDs[llotypes.StreamID(i)] = struct{}{}
+			}
+			obs.StreamValues[MaxObservationStreamValuesLength] = ToDecimal(decimal.NewFromInt(1))
+			err := p.ValidateObservation(ctx, outctx, types.Query{}, encode(t, obs))
+			assert.EqualError(t, err, fmt.Sprintf("StreamValues is too long: 1 (+%d unchanged) vs %d", MaxObservationStreamValuesLength, MaxObservationStreamValuesLength))
+		})
+	})
+	t.Run("StreamTimestamps", func(t *testing.T) {
+		ctx := tests.Context(t)
+		p := &Plugin{
+			Config:           Config{true},
+			ObservationCodec: protoObservationCodec{},
+		}
+		encode := func(t *testing.T, obs Observation) types.AttributedObservation {
+			b, err := p.ObservationCodec.Encode(obs)
+			require.NoError(t, err)
+			return types.AttributedObservation{Observation: b}
+		}
+		outctx := ocr3types.OutcomeContext{SeqNr: 2}
+		obs := Observation{
+			StreamValues:     StreamValues{1: ToDecimal(decimal.NewFromInt(1))},
+			StreamTimestamps: StreamTimestamps{1: 100},
+		}
+
+		t.Run("rejected if StreamStalenessBound is disabled", func(t *testing.T) {
+			err := p.ValidateObservation(ctx, outctx, types.Query{}, encode(t, obs))
+			assert.EqualError(t, err, "StreamTimestamps is not empty even though StreamStalenessBound is disabled")
+		})
+
+		p.OffchainConfig.StreamStalenessBound = time.Minute
+
+		t.Run("accepted if StreamStalenessBound is enabled", func(t *testing.T) {
+			err := p.ValidateObservation(ctx, outctx, types.Query{}, encode(t, obs))
+			assert.NoError(t, err)
+		})
+		t.Run("rejected for streams that were not observed", func(t *testing.T) {
+			pbuf := &LLOObservationProto{StreamTimestamps: map[uint32]int64{2: 100}}
+			b, err := proto.Marshal(pbuf)
+			require.NoError(t, err)
+			err = p.ValidateObservation(ctx, outctx, types.Query{}, types.AttributedObservation{Observation: b})
+			assert.EqualError(t, err, "StreamTimestamps contains stream 2 which is neither in StreamValues nor in UnchangedStreamIDs")
+		})
+	})
+	t.Run("StreamValuePolicies", func(t *testing.T) {
+		ctx := tests.Context(t)
+		p := &Plugin{
+			Config:           Config{true},
+			Logger:           logger.Test(t),
+			ObservationCodec: protoObservationCodec{},
+			OutcomeCodec:     protoOutcomeCodec{},
+		}
+		previousOutcome, err := p.OutcomeCodec.Encode(Outcome{
+			ChannelDefinitions: llotypes.ChannelDefinitions{
+				1: {
+					ReportFormat: llotypes.ReportFormatJSON,
+					Streams:      []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}, {StreamID: 2, Aggregator: llotypes.AggregatorMedian}},
+					Opts:         []byte(`{"streamValuePolicies":{"1":"nonNegative"}}`),
+				},
+				2: {
+					ReportFormat: llotypes.ReportFormatJSON,
+					Streams:      []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}},
+					Opts:         []byte(`{"streamValuePolicies":{"1":"positive"}}`),
+				},
+			},
+		})
+		require.NoError(t, err)
+		outctx := ocr3types.OutcomeContext{SeqNr: 3, PreviousOutcome: previousOutcome}
+		encode := func(t *testing.T, obs Observation) types.AttributedObservation {
+			b, err := p.ObservationCodec.Encode(obs)
+			require.NoError(t, err)
+			return types.AttributedObservation{Observation: b, Observer: 4}
+		}
+
+		t.Run("accepts illegal values, which are dropped in Outcome", func(t *testing.T) {
+			err := p.ValidateObservation(ctx, outctx, types.Query{}, encode(t, Observation{StreamValues: StreamValues{
+				1: ToDecimal(decimal.Zero),
+				2: ToDecimal(decimal.NewFromInt(-1)),
+			}}))
+			assert.NoError(t, err)
+		})
+		t.Run("Outcome drops illegal values under the strictest policy", func(t *testing.T) {
+			previous, err := p.OutcomeCodec.Decode(previousOutcome)
+			require.NoError(t, err)
+			violations := testutil.ToFloat64(promObservationValuePolicyViolationsTotal.WithLabelValues(p.ConfigDigest.String(), "4", "1"))
+			aos := []types.AttributedObservation{
+				encode(t, Observation{StreamValues: StreamValues{1: ToDecimal(decimal.Zero), 2: ToDecimal(decimal.NewFromInt(-1))}}),
+				encode(t, Observation{StreamValues: StreamValues{1: ToDecimal(decimal.NewFromInt(1)), 2: ToDecimal(decimal.NewFromInt(-2))}}),
+			}
+			aos[1].Observer = 5
+			_, _, _, _, _, _, streamObservations := p.decodeObservations(aos, outctx, previous, [32]byte{})
+			assert.Equal(t, map[llotypes.StreamID][]StreamValue{
+				1: {ToDecimal(decimal.NewFromInt(1))},
+				2: {ToDecimal(decimal.NewFromInt(-1)), ToDecimal(decimal.NewFromInt(-2))},
+			}, streamObservations, "the other values of the violating oracle still count")
+			assert.Equal(t, violations+1, testutil.ToFloat64(promObservationValuePolicyViolationsTotal.WithLabelValues(p.ConfigDigest.String(), "4", "1")))
+		})
+	})
+}
+
+func Test_PluginFactory_NewReportingPlugin(t *testing.T) {
+	f := &PluginFactory{OnchainConfigCodec: EVMOnchainConfigCodec{}}
+	encodeOnchainConfig := func(predecessor *types.ConfigDigest) []byte {
+		b, err := EVMOnchainConfigCodec{}.Encode(OnchainConfig{Version: 1, PredecessorConfigDigest: predecessor})
+		require.NoError(t, err)
+		return b
+	}
+
+	t.Run("accepts LLO config digests", func(t *testing.T) {
+		ctx := tests.Context(t)
+		predecessor := types.ConfigDigest{0x00, 0x09, 1}
+		p, _, err := f.NewReportingPlugin(ctx, ocr3types.ReportingPluginConfig{ConfigDigest: types.ConfigDigest{0x00, 0x09, 2}, OnchainConfig: encodeOnchainConfig(&predecessor)})
+		require.NoError(t, err)
+		assert.Equal(t, &predecessor, p.(*Plugin).PredecessorConfigDigest)
+	})
+	t.Run("rejects config digests with the wrong prefix", func(t *testing.T) {
+		ctx := tests.Context(t)
+		_, _, err := f.NewReportingPlugin(ctx, ocr3types.ReportingPluginConfig{ConfigDigest: types.ConfigDigest{0x00, 0x06}, OnchainConfig: encodeOnchainConfig(nil)})
+		assert.EqualError(t, err, "NewReportingPlugin failed to decode offchain config: refusing to decode offchain config: config digest 0006000000000000000000000000000000000000000000000000000000000000 has prefix 0x0006, expected one of: [0x0009]")
+	})
+	t.Run("rejects predecessor config digests with the wrong prefix", func(t *testing.T) {
+		ctx := tests.Context(t)
+		predecessor := types.ConfigDigest{0x00, 0x01}
+		_, _, err := f.NewReportingPlugin(ctx, ocr3types.ReportingPluginConfig{ConfigDigest: types.ConfigDigest{0x00, 0x09}, OnchainConfig: encodeOnchainConfig(&predecessor)})
+		assert.ErrorContains(t, err, "NewReportingPlugin got invalid predecessor config digest: config digest 0001")
+	})
+	t.Run("rejects invalid offchain config", func(t *testing.T) {
+		ctx := tests.Context(t)
+		_, _, err := f.NewReportingPlugin(ctx, ocr3types.ReportingPluginConfig{ConfigDigest: types.ConfigDigest{0x00, 0x09}, OnchainConfig: encodeOnchainConfig(nil), OffchainConfig: []byte{0xff}})
+		assert.ErrorContains(t, err, "NewReportingPlugin failed to decode offchain config: failed to decode offchain config: expected protobuf")
+	})
 }
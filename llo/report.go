@@ -26,4 +26,9 @@ type Report struct {
 	// protocol instance will generate specimen reports so we can validate it
 	// works properly without any risk of misreports landing on chain.
 	Specimen bool
+	// ReducedConfidence is set if the report is based on outcomes that
+	// repeatedly had streams with fewer than 2f+1 contributors, i.e. the
+	// protocol instance could not tolerate f faulty oracles. See
+	// OffchainConfig.ReducedConfidenceReports.
+	ReducedConfidence bool
 }
@@ -0,0 +1,75 @@
+package llo
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+
+	"github.com/smartcontractkit/chainlink-data-streams/llo/internal/poseidon"
+)
+
+// ReportCommitter is optionally implemented by ReportCodecs that commit to
+// their encoded reports with a zk-friendly hash, so that consumers can prove
+// inclusion of a report inside a circuit without hashing it with keccak256
+type ReportCommitter interface {
+	// ReportCommitment returns the commitment to a report returned by Encode
+	ReportCommitment(report []byte) ([32]byte, error)
+}
+
+// reportCommitmentChunkSize is the number of report bytes absorbed per
+// Poseidon call; 31 bytes always fit in a BN254 scalar
+const reportCommitmentChunkSize = 31
+
+// PoseidonReportCommitment commits to an encoded report with the circomlib
+// Poseidon hash over the BN254 scalar field:
+//
+//	acc = 0
+//	for each 31-byte big-endian chunk c of report: acc = Poseidon(acc, c)
+//	commitment = Poseidon(acc, len(report))
+//
+// The final chunk may be shorter than 31 bytes. The commitment is the
+// resulting field element as a 32-byte big-endian word.
+func PoseidonReportCommitment(report []byte) ([32]byte, error) {
+	var out [32]byte
+	acc := new(big.Int)
+	chunk := new(big.Int)
+	var err error
+	for i := 0; i < len(report); i += reportCommitmentChunkSize {
+		end := min(i+reportCommitmentChunkSize, len(report))
+		if acc, err = poseidon.Hash(acc, chunk.SetBytes(report[i:end])); err != nil {
+			return out, err
+		}
+	}
+	if acc, err = poseidon.Hash(acc, new(big.Int).SetUint64(uint64(len(report)))); err != nil {
+		return out, err
+	}
+	acc.FillBytes(out[:])
+	return out, nil
+}
+
+var _ ReportCodec = PoseidonCommitmentReportCodec{}
+var _ ReportCommitter = PoseidonCommitmentReportCodec{}
+var _ schemaVersioner = PoseidonCommitmentReportCodec{}
+
+// PoseidonCommitmentReportCodec wraps a ReportCodec, leaving its encoding
+// unchanged, and commits to its reports with PoseidonReportCommitment
+type PoseidonCommitmentReportCodec struct {
+	ReportCodec
+}
+
+func (c PoseidonCommitmentReportCodec) Encode(ctx context.Context, report Report, cd llotypes.ChannelDefinition) ([]byte, error) {
+	return c.ReportCodec.Encode(ctx, report, cd)
+}
+
+func (c PoseidonCommitmentReportCodec) ReportCommitment(report []byte) ([32]byte, error) {
+	return PoseidonReportCommitment(report)
+}
+
+func (c PoseidonCommitmentReportCodec) SchemaVersion(cd llotypes.ChannelDefinition) (uint32, error) {
+	if sv, ok := c.ReportCodec.(schemaVersioner); ok {
+		return sv.SchemaVersion(cd)
+	}
+	return 0, errors.New("codec does not support schema versions")
+}
@@ -0,0 +1,74 @@
+package llo
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+func Test_PoseidonReportCommitment(t *testing.T) {
+	report := make([]byte, 64)
+	for i := range report {
+		report[i] = byte(i)
+	}
+	for _, tc := range []struct {
+		name     string
+		report   []byte
+		expected string
+	}{
+		{"empty", nil, "2098f5fb9e239eab3ceac3f27b81e481dc3124d55ffed523a839ee8446b64864"},
+		{"partial final chunk", report, "11cf8f8d1c05bf4610577fa5d771a90f667d6f1c36c01e2194f06df0cec6802f"},
+		// trailing zeroes do not change the chunks' values, only the length
+		{"trailing zero", append(report, 0), "15098111f5588ae39e9de5bfd2f6a69dfb48febe6b5f3bf74c869694d01f590f"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := PoseidonReportCommitment(tc.report)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, hex.EncodeToString(c[:]))
+		})
+	}
+}
+
+func Test_PoseidonCommitmentReportCodec(t *testing.T) {
+	ctx := tests.Context(t)
+	report := Report{
+		ObservationTimestampSeconds: 1726670490,
+		Values:                      []StreamValue{ToDecimal(decimal.NewFromInt(2000))},
+	}
+	cd := llotypes.ChannelDefinition{ReportFormat: llotypes.ReportFormatJSON, Streams: []llotypes.Stream{{}}}
+
+	t.Run("encodes reports with the wrapped codec", func(t *testing.T) {
+		var codec ReportCodec = PoseidonCommitmentReportCodec{JSONReportCodec{}}
+		expected, err := JSONReportCodec{}.Encode(ctx, report, cd)
+		require.NoError(t, err)
+		b, err := codec.Encode(ctx, report, cd)
+		require.NoError(t, err)
+		assert.Equal(t, expected, b)
+
+		committer, ok := codec.(ReportCommitter)
+		require.True(t, ok)
+		c, err := committer.ReportCommitment(b)
+		require.NoError(t, err)
+		expectedC, err := PoseidonReportCommitment(b)
+		require.NoError(t, err)
+		assert.Equal(t, expectedC, c)
+
+		_, err = codec.(schemaVersioner).SchemaVersion(cd)
+		assert.EqualError(t, err, "codec does not support schema versions")
+	})
+	t.Run("passes through schema versions", func(t *testing.T) {
+		r := NewReportCodecRegistry()
+		require.NoError(t, r.Register(llotypes.ReportFormatJSON, 2, JSONReportCodec{}))
+		codec := PoseidonCommitmentReportCodec{r.ReportCodecs()[llotypes.ReportFormatJSON]}
+		version, err := codec.SchemaVersion(cd)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(2), version)
+	})
+}
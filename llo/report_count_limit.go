@@ -0,0 +1,85 @@
+package llo
+
+import (
+	"cmp"
+	"slices"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+// capReports selects which of the outcome's reportable channels are
+// reported on if they would generate more than limit reports, counting one
+// report per report format of each channel and one for the retirement
+// report of a retired outcome. The rest are deferred to a later round.
+//
+// Channels are selected deterministically, since Outcome must know which
+// channels the previous outcome deferred: by priority class if prioritize is
+// set, then the channels that have gone unreported the longest (i.e. have
+// the lowest ValidAfterSeconds) first, then by channel ID. Deferred
+// channels keep their ValidAfterSeconds, so their next report covers the
+// rounds they missed, and they move up the queue as other channels are
+// reported.
+//
+// Selected channels are returned in the order of reportable.
+func (out *Outcome) capReports(cache *channelOptsCache, reportable []llotypes.ChannelID, limit int, prioritize bool) (selected []llotypes.ChannelID, deferred []*ErrUnreportableChannel) {
+	if out.LifeCycleStage == LifeCycleStageRetired {
+		limit--
+	}
+	counts := make(map[llotypes.ChannelID]int, len(reportable))
+	total := 0
+	for _, cid := range reportable {
+		counts[cid] = len(channelReportFormats(cache, out.ChannelDefinitions[cid]))
+		total += counts[cid]
+	}
+	if total <= limit {
+		return reportable, nil
+	}
+
+	candidates := slices.Clone(reportable)
+	priorities := make(map[llotypes.ChannelID]int32, len(candidates))
+	if prioritize {
+		for _, cid := range candidates {
+			priorities[cid] = cache.of(out.ChannelDefinitions[cid]).priority
+		}
+	}
+	slices.SortFunc(candidates, func(a, b llotypes.ChannelID) int {
+		if c := cmp.Compare(priorities[b], priorities[a]); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(out.ValidAfterSeconds[a], out.ValidAfterSeconds[b]); c != 0 {
+			return c
+		}
+		return cmp.Compare(a, b)
+	})
+	isSelected := make(map[llotypes.ChannelID]bool, len(candidates))
+	remaining := limit
+	for _, cid := range candidates {
+		if counts[cid] <= remaining {
+			isSelected[cid] = true
+			remaining -= counts[cid]
+		}
+	}
+	for _, cid := range reportable {
+		if isSelected[cid] {
+			selected = append(selected, cid)
+		} else {
+			deferred = append(deferred, &ErrUnreportableChannel{nil, "IsReportable=false; deferred to a later round because there are more reports than MaxReportsPerRound", cid, UnreportableCauseReportCountLimit})
+		}
+	}
+	return selected, deferred
+}
+
+// deferredChannels returns the channels that Reports deferred to a later
+// round when it generated reports for outcome
+func (p *Plugin) deferredChannels(outcome *Outcome) map[llotypes.ChannelID]struct{} {
+	reportable, _ := p.reportableChannels(outcome)
+	_, deferred := outcome.capReports(p.channelOpts, reportable, p.OffchainConfig.ReportCountLimit(), p.OffchainConfig.ChannelPriorityClasses)
+	if len(deferred) == 0 {
+		return nil
+	}
+	m := make(map[llotypes.ChannelID]struct{}, len(deferred))
+	for _, d := range deferred {
+		m[d.ChannelID] = struct{}{}
+	}
+	return m
+}
@@ -0,0 +1,139 @@
+package llo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+func Test_Outcome_capReports(t *testing.T) {
+	streams := []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}}
+	out := &Outcome{
+		LifeCycleStage:    LifeCycleStageProduction,
+		ValidAfterSeconds: map[llotypes.ChannelID]uint32{1: 100, 2: 90, 3: 100, 4: 100},
+		ChannelDefinitions: llotypes.ChannelDefinitions{
+			1: {ReportFormat: llotypes.ReportFormatJSON, Streams: streams},
+			2: {ReportFormat: llotypes.ReportFormatJSON, Streams: streams},
+			3: {ReportFormat: llotypes.ReportFormatEVMPremiumLegacy, Streams: streams, Opts: llotypes.ChannelOpts(`{"additionalReportFormats":["json"]}`)},
+			4: {ReportFormat: llotypes.ReportFormatJSON, Streams: streams, Opts: llotypes.ChannelOpts(`{"priority":1}`)},
+		},
+	}
+	reportable := []llotypes.ChannelID{1, 2, 3, 4}
+	deferredIDs := func(deferred []*ErrUnreportableChannel) (ids []llotypes.ChannelID) {
+		for _, d := range deferred {
+			assert.Equal(t, UnreportableCauseReportCountLimit, d.Cause)
+			ids = append(ids, d.ChannelID)
+		}
+		return ids
+	}
+
+	t.Run("selects all channels if under the limit", func(t *testing.T) {
+		selected, deferred := out.capReports(nil, reportable, 5, false)
+		assert.Equal(t, reportable, selected)
+		assert.Empty(t, deferred)
+	})
+	t.Run("selects the channels that have gone unreported the longest first", func(t *testing.T) {
+		selected, deferred := out.capReports(nil, reportable, 3, false)
+		// 2 has the lowest ValidAfterSeconds; 3 would need two reports
+		assert.Equal(t, []llotypes.ChannelID{1, 2, 4}, selected)
+		assert.Equal(t, []llotypes.ChannelID{3}, deferredIDs(deferred))
+
+		selected, deferred = out.capReports(nil, reportable, 2, false)
+		assert.Equal(t, []llotypes.ChannelID{1, 2}, selected)
+		assert.Equal(t, []llotypes.ChannelID{3, 4}, deferredIDs(deferred))
+	})
+	t.Run("selects higher priority channels first", func(t *testing.T) {
+		selected, deferred := out.capReports(nil, reportable, 2, true)
+		assert.Equal(t, []llotypes.ChannelID{2, 4}, selected)
+		assert.Equal(t, []llotypes.ChannelID{1, 3}, deferredIDs(deferred))
+	})
+	t.Run("reserves a report for the retirement report", func(t *testing.T) {
+		retired := *out
+		retired.LifeCycleStage = LifeCycleStageRetired
+		selected, deferred := retired.capReports(nil, reportable, 5, false)
+		// 4 reports are left: 2, 1 and 3 (two reports) fit in that order
+		assert.Equal(t, []llotypes.ChannelID{1, 2, 3}, selected)
+		assert.Equal(t, []llotypes.ChannelID{4}, deferredIDs(deferred))
+	})
+}
+
+func Test_Plugin_ReportCountLimit(t *testing.T) {
+	ctx := tests.Context(t)
+	streams := []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}}
+	p := &Plugin{
+		Config:           Config{true},
+		ConfigDigest:     types.ConfigDigest{1},
+		F:                1,
+		OffchainConfig:   OffchainConfig{MaxReportsPerRound: 2},
+		OutcomeCodec:     protoOutcomeCodec{},
+		ObservationCodec: protoObservationCodec{},
+		Logger:           logger.Test(t),
+		ReportCodecs: map[llotypes.ReportFormat]ReportCodec{
+			llotypes.ReportFormatJSON: JSONReportCodec{},
+		},
+	}
+	previousOutcome := Outcome{
+		LifeCycleStage:                   LifeCycleStageProduction,
+		ObservationsTimestampNanoseconds: int64(200 * time.Second),
+		ValidAfterSeconds:                map[llotypes.ChannelID]uint32{1: 100, 2: 150, 3: 100},
+		ChannelDefinitions: llotypes.ChannelDefinitions{
+			1: {ReportFormat: llotypes.ReportFormatJSON, Streams: streams},
+			2: {ReportFormat: llotypes.ReportFormatJSON, Streams: streams},
+			3: {ReportFormat: llotypes.ReportFormatJSON, Streams: streams},
+		},
+		StreamAggregates: StreamAggregates{1: {llotypes.AggregatorMedian: ToDecimal(decimal.NewFromInt(1))}},
+	}
+	encodedPreviousOutcome, err := p.OutcomeCodec.Encode(previousOutcome)
+	require.NoError(t, err)
+
+	t.Run("Reports defers the channels that were reported on most recently", func(t *testing.T) {
+		rwis, err := p.Reports(ctx, 2, encodedPreviousOutcome)
+		require.NoError(t, err)
+		var channelIDs []llotypes.ChannelID
+		for _, rwi := range rwis {
+			r, err := JSONReportCodec{}.Decode(rwi.ReportWithInfo.Report)
+			require.NoError(t, err)
+			channelIDs = append(channelIDs, r.ChannelID)
+			assert.Equal(t, uint32(100), r.ValidAfterSeconds)
+		}
+		assert.Equal(t, []llotypes.ChannelID{1, 3}, channelIDs)
+	})
+	t.Run("Outcome keeps the ValidAfterSeconds of deferred channels", func(t *testing.T) {
+		aos := []types.AttributedObservation{}
+		for i := 0; i < 4; i++ {
+			encoded, err := p.ObservationCodec.Encode(Observation{
+				UnixTimestampNanoseconds: int64(201 * time.Second),
+				StreamValues:             map[llotypes.StreamID]StreamValue{1: ToDecimal(decimal.NewFromInt(1))},
+			})
+			require.NoError(t, err)
+			aos = append(aos, types.AttributedObservation{Observation: encoded, Observer: commontypes.OracleID(i)})
+		}
+		outcome, err := p.Outcome(ctx, ocr3types.OutcomeContext{SeqNr: 3, PreviousOutcome: encodedPreviousOutcome}, types.Query{}, aos)
+		require.NoError(t, err)
+		decoded, err := p.OutcomeCodec.Decode(outcome)
+		require.NoError(t, err)
+		assert.Equal(t, map[llotypes.ChannelID]uint32{1: 200, 2: 150, 3: 200}, decoded.ValidAfterSeconds)
+
+		// the deferred channel is now the one that has gone unreported the
+		// longest, so it is reported on next
+		reportable, _ := decoded.ReportableChannels()
+		selected, _ := decoded.capReports(nil, reportable, p.OffchainConfig.ReportCountLimit(), false)
+		assert.Contains(t, selected, llotypes.ChannelID(2))
+	})
+	t.Run("without a limit, every channel's ValidAfterSeconds advances", func(t *testing.T) {
+		p := *p
+		p.OffchainConfig = OffchainConfig{}
+		assert.Nil(t, p.deferredChannels(&previousOutcome))
+	})
+}
@@ -0,0 +1,89 @@
+package llo
+
+import (
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+// outcomeTimeRetention is the number of sequence numbers for which
+// outcomeTimeTracker remembers when their outcome was computed
+const outcomeTimeRetention = 10
+
+// ReportTimestamps record when a report passed through the stages of the
+// plugin on this oracle, so that its latency can be measured end-to-end. The
+// transmitter adds the time of transmission and the server the time of
+// persistence, e.g. by forwarding them in the TransmitRequest (see
+// rpc.ReportTimestamps). All stages are measured from Observation.
+type ReportTimestamps struct {
+	// ChannelID is the channel that the report is for
+	ChannelID llotypes.ChannelID
+	// Observation is the observations timestamp of the outcome that the
+	// report was generated from, i.e. the time the oracles agreed the
+	// values were observed at
+	Observation time.Time
+	// Outcome is when this oracle computed the outcome, or zero if it did
+	// not, e.g. because it restarted in between
+	Outcome time.Time
+	// ReportEncoded is when this oracle encoded the report
+	ReportEncoded time.Time
+}
+
+// outcomeTimeTracker remembers when this oracle computed the outcomes of
+// recent rounds, so that Reports can tell how long after the observations
+// the outcome was ready. A nil tracker remembers nothing.
+type outcomeTimeTracker struct {
+	mu    sync.Mutex
+	times map[uint64]time.Time
+}
+
+// record records that the outcome of seqNr was computed at t. Outcomes
+// may be computed more than once per round; the first one counts.
+func (t *outcomeTimeTracker) record(seqNr uint64, at time.Time) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.times == nil {
+		t.times = make(map[uint64]time.Time)
+	}
+	if _, exists := t.times[seqNr]; exists {
+		return
+	}
+	t.times[seqNr] = at
+	for s := range t.times {
+		if s+outcomeTimeRetention <= seqNr {
+			delete(t.times, s)
+		}
+	}
+}
+
+// get returns when the outcome of seqNr was computed, or zero if unknown
+func (t *outcomeTimeTracker) get(seqNr uint64) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.times[seqNr]
+}
+
+// recordReportTimestamps makes the timestamps of the encoded report
+// available to the transmitter and, for the report in the channel's
+// primary format, records the latency of each stage
+func (p *Plugin) recordReportTimestamps(seqNr uint64, encoded types.Report, ts ReportTimestamps, primary bool) {
+	if primary {
+		channelID := FormatChannelID(ts.ChannelID)
+		if !ts.Outcome.IsZero() {
+			promReportStageLatency.WithLabelValues(p.ConfigDigest.String(), channelID, "outcome").Observe(ts.Outcome.Sub(ts.Observation).Seconds())
+		}
+		promReportStageLatency.WithLabelValues(p.ConfigDigest.String(), channelID, "report_encoded").Observe(ts.ReportEncoded.Sub(ts.Observation).Seconds())
+	}
+	if p.TransmissionTargets != nil {
+		p.TransmissionTargets.setTimestamps(p.ConfigDigest, seqNr, encoded, ts)
+	}
+}
@@ -0,0 +1,83 @@
+package llo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+func Test_outcomeTimeTracker(t *testing.T) {
+	var nilTracker *outcomeTimeTracker
+	nilTracker.record(1, time.Unix(1, 0))
+	assert.True(t, nilTracker.get(1).IsZero())
+
+	tr := &outcomeTimeTracker{}
+	tr.record(1, time.Unix(1, 0))
+	// the first outcome of a round counts
+	tr.record(1, time.Unix(2, 0))
+	assert.Equal(t, time.Unix(1, 0), tr.get(1))
+	assert.True(t, tr.get(2).IsZero())
+
+	tr.record(outcomeTimeRetention, time.Unix(3, 0))
+	assert.Equal(t, time.Unix(1, 0), tr.get(1))
+	tr.record(outcomeTimeRetention+1, time.Unix(4, 0))
+	assert.True(t, tr.get(1).IsZero())
+	assert.Equal(t, time.Unix(3, 0), tr.get(outcomeTimeRetention))
+}
+
+func Test_Plugin_ReportTimestamps(t *testing.T) {
+	ctx := tests.Context(t)
+	streams := []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}}
+	p := &Plugin{
+		ConfigDigest: types.ConfigDigest{1},
+		OutcomeCodec: protoOutcomeCodec{},
+		Logger:       logger.Test(t),
+		ReportCodecs: map[llotypes.ReportFormat]ReportCodec{
+			llotypes.ReportFormatJSON: JSONReportCodec{},
+		},
+		TransmissionTargets: NewTransmissionTargets(),
+		outcomeTimes:        &outcomeTimeTracker{},
+	}
+	observedAt := time.Unix(200, 0)
+	encoded, err := p.OutcomeCodec.Encode(Outcome{
+		LifeCycleStage:                   LifeCycleStageProduction,
+		ObservationsTimestampNanoseconds: observedAt.UnixNano(),
+		ValidAfterSeconds:                map[llotypes.ChannelID]uint32{1: 100, 2: 100},
+		ChannelDefinitions: llotypes.ChannelDefinitions{
+			1: {ReportFormat: llotypes.ReportFormatJSON, Streams: streams},
+			2: {ReportFormat: llotypes.ReportFormatJSON, Streams: streams},
+		},
+		StreamAggregates: StreamAggregates{1: {llotypes.AggregatorMedian: ToDecimal(decimal.NewFromInt(1))}},
+	})
+	require.NoError(t, err)
+	outcomeAt := observedAt.Add(300 * time.Millisecond)
+	p.outcomeTimes.record(2, outcomeAt)
+
+	before := time.Now()
+	rwis, err := p.Reports(ctx, 2, encoded)
+	require.NoError(t, err)
+	require.Len(t, rwis, 2)
+	for i, rwi := range rwis {
+		ts := p.TransmissionTargets.Timestamps(p.ConfigDigest, 2, rwi.ReportWithInfo.Report)
+		assert.Equal(t, llotypes.ChannelID(i+1), ts.ChannelID)
+		assert.True(t, observedAt.Equal(ts.Observation))
+		assert.Equal(t, outcomeAt, ts.Outcome)
+		assert.False(t, ts.ReportEncoded.Before(before))
+	}
+
+	// outcomes that this oracle did not compute have no outcome timestamp
+	rwis, err = p.Reports(ctx, 3, encoded)
+	require.NoError(t, err)
+	require.NotEmpty(t, rwis)
+	assert.True(t, p.TransmissionTargets.Timestamps(p.ConfigDigest, 3, rwis[0].ReportWithInfo.Report).Outcome.IsZero())
+	assert.Equal(t, ReportTimestamps{}, p.TransmissionTargets.Timestamps(p.ConfigDigest, 4, rwis[0].ReportWithInfo.Report))
+}
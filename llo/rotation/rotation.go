@@ -0,0 +1,223 @@
+// Package rotation contains helpers for blue/green config rotations, where a
+// new (successor) protocol instance takes over from a running (predecessor)
+// instance.
+//
+// A rotation proceeds as follows:
+//  1. The successor is configured with the predecessor's config digest
+//     embedded in its onchain config (see SuccessorConfig). It starts in the
+//     staging stage.
+//  2. The predecessor is marked for retirement in the configuration contract.
+//     Once a quorum of its oracles agrees, it retires and produces an attested
+//     retirement report.
+//  3. The successor picks up the attested retirement report and moves to the
+//     production stage, carrying over ValidAfterSeconds so that there are no
+//     gaps in reports.
+//
+// CheckHandover can be used at any point to see which of these steps are
+// complete.
+package rotation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+
+	"github.com/smartcontractkit/chainlink-data-streams/llo"
+)
+
+// SuccessorConfig returns the onchain and offchain config for a protocol
+// instance that takes over from the instance with the given config digest
+func SuccessorConfig(predecessor types.ConfigDigest) (onchainConfig, offchainConfig []byte, err error) {
+	if err = llo.VerifyConfigDigestPrefix(predecessor); err != nil {
+		return nil, nil, fmt.Errorf("invalid predecessor: %w", err)
+	}
+	onchainConfig, err = llo.EVMOnchainConfigCodec{}.Encode(llo.OnchainConfig{
+		Version:                 1,
+		PredecessorConfigDigest: &predecessor,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode onchain config: %w", err)
+	}
+	offchainConfig, err = llo.OffchainConfig{}.Encode()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode offchain config: %w", err)
+	}
+	return onchainConfig, offchainConfig, nil
+}
+
+// VerifySuccessorConfig checks that onchainConfig designates predecessor as
+// the predecessor instance
+func VerifySuccessorConfig(onchainConfig []byte, predecessor types.ConfigDigest) error {
+	c, err := llo.EVMOnchainConfigCodec{}.Decode(onchainConfig)
+	if err != nil {
+		return fmt.Errorf("failed to decode onchain config: %w", err)
+	}
+	if c.PredecessorConfigDigest == nil {
+		return fmt.Errorf("onchain config has no predecessor config digest; expected: %s", predecessor)
+	}
+	if *c.PredecessorConfigDigest != predecessor {
+		return fmt.Errorf("onchain config has wrong predecessor config digest; got: %s, expected: %s", c.PredecessorConfigDigest, predecessor)
+	}
+	return nil
+}
+
+// PredecessorState describes how far the predecessor has progressed towards
+// retirement
+type PredecessorState struct {
+	// ShouldRetire is true if the predecessor has been marked for retirement
+	// in the configuration contract
+	ShouldRetire bool
+	// RetirementReport is the verified retirement report, or nil if the
+	// predecessor has not retired yet
+	RetirementReport *llo.RetirementReport
+}
+
+// Retired returns true if the predecessor has produced a retirement report
+func (s PredecessorState) Retired() bool { return s.RetirementReport != nil }
+
+// CheckPredecessor returns the retirement state of the predecessor, as seen
+// by this node
+func CheckPredecessor(predecessor types.ConfigDigest, src llo.ShouldRetireCache, prrc llo.PredecessorRetirementReportCache) (s PredecessorState, err error) {
+	s.ShouldRetire, err = src.ShouldRetire(predecessor)
+	if err != nil {
+		return s, fmt.Errorf("failed to fetch shouldRetire: %w", err)
+	}
+	attested, err := prrc.AttestedRetirementReport(predecessor)
+	if err != nil {
+		return s, fmt.Errorf("failed to fetch attested retirement report: %w", err)
+	}
+	if attested == nil {
+		return s, nil
+	}
+	rr, err := prrc.CheckAttestedRetirementReport(predecessor, attested)
+	if err != nil {
+		return s, fmt.Errorf("invalid attested retirement report: %w", err)
+	}
+	s.RetirementReport = &rr
+	return s, nil
+}
+
+// Handover describes a rotation from predecessor to successor
+type Handover struct {
+	Predecessor types.ConfigDigest
+	// SuccessorOnchainConfig is the onchain config applied (or about to be
+	// applied) to the successor
+	SuccessorOnchainConfig []byte
+	// ChannelDefinitions are the channels that the successor will report on
+	ChannelDefinitions llotypes.ChannelDefinitions
+
+	ShouldRetireCache                llo.ShouldRetireCache
+	PredecessorRetirementReportCache llo.PredecessorRetirementReportCache
+}
+
+// Check is a single item of a ReadinessReport
+type Check struct {
+	Name string
+	OK   bool
+	// Advisory checks are informational and do not block the handover
+	Advisory bool
+	Detail   string
+}
+
+// ReadinessReport is a checklist of the conditions for a successful handover
+type ReadinessReport struct {
+	Checks []Check
+}
+
+// Ready returns true if all non-advisory checks passed
+func (r ReadinessReport) Ready() bool {
+	for _, c := range r.Checks {
+		if !c.OK && !c.Advisory {
+			return false
+		}
+	}
+	return true
+}
+
+// String renders the report as a checklist for operators
+func (r ReadinessReport) String() string {
+	var sb strings.Builder
+	for _, c := range r.Checks {
+		switch {
+		case c.OK:
+			sb.WriteString("[x] ")
+		case c.Advisory:
+			sb.WriteString("[!] ")
+		default:
+			sb.WriteString("[ ] ")
+		}
+		sb.WriteString(c.Name)
+		if c.Detail != "" {
+			sb.WriteString(": ")
+			sb.WriteString(c.Detail)
+		}
+		sb.WriteByte('\n')
+	}
+	if r.Ready() {
+		sb.WriteString("Ready for handover\n")
+	} else {
+		sb.WriteString("NOT ready for handover\n")
+	}
+	return sb.String()
+}
+
+// CheckHandover evaluates whether the successor is ready to take over from
+// the predecessor
+func CheckHandover(h Handover) ReadinessReport {
+	var r ReadinessReport
+	add := func(c Check) { r.Checks = append(r.Checks, c) }
+
+	c := Check{Name: "Successor onchain config designates predecessor", OK: true}
+	if err := VerifySuccessorConfig(h.SuccessorOnchainConfig, h.Predecessor); err != nil {
+		c.OK, c.Detail = false, err.Error()
+	}
+	add(c)
+
+	c = Check{Name: "Successor channel definitions are valid", OK: true}
+	if err := llo.VerifyChannelDefinitions(h.ChannelDefinitions); err != nil {
+		c.OK, c.Detail = false, err.Error()
+	}
+	add(c)
+
+	state, err := CheckPredecessor(h.Predecessor, h.ShouldRetireCache, h.PredecessorRetirementReportCache)
+	if err != nil {
+		add(Check{Name: "Predecessor state is readable", Detail: err.Error()})
+		return r
+	}
+
+	c = Check{Name: "Predecessor is marked for retirement", OK: state.ShouldRetire}
+	if !c.OK {
+		c.Detail = "set the predecessor to retire in the configuration contract"
+	}
+	add(c)
+
+	c = Check{Name: "Predecessor has produced an attested retirement report", OK: state.Retired()}
+	if !c.OK {
+		c.Detail = "waiting for the predecessor to retire"
+	}
+	add(c)
+	if !state.Retired() {
+		return r
+	}
+
+	// Channels without a ValidAfterSeconds in the retirement report start
+	// fresh in the successor, which is expected for new channels only
+	var missing []llotypes.ChannelID
+	for cid := range h.ChannelDefinitions {
+		if _, ok := state.RetirementReport.ValidAfterSeconds[cid]; !ok {
+			missing = append(missing, cid)
+		}
+	}
+	sort.Slice(missing, func(i, j int) bool { return missing[i] < missing[j] })
+	c = Check{Name: "Retirement report covers all successor channels", OK: len(missing) == 0, Advisory: true}
+	if !c.OK {
+		c.Detail = fmt.Sprintf("no ValidAfterSeconds for channels %v; these will be treated as new channels", missing)
+	}
+	add(c)
+
+	return r
+}
@@ -0,0 +1,143 @@
+package rotation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+
+	"github.com/smartcontractkit/chainlink-data-streams/llo"
+)
+
+type mockShouldRetireCache struct {
+	shouldRetire bool
+	err          error
+}
+
+func (m *mockShouldRetireCache) ShouldRetire(types.ConfigDigest) (bool, error) {
+	return m.shouldRetire, m.err
+}
+
+type mockRetirementReportCache struct {
+	attested []byte
+	report   llo.RetirementReport
+	err      error
+}
+
+func (m *mockRetirementReportCache) AttestedRetirementReport(types.ConfigDigest) ([]byte, error) {
+	return m.attested, nil
+}
+
+func (m *mockRetirementReportCache) CheckAttestedRetirementReport(types.ConfigDigest, []byte) (llo.RetirementReport, error) {
+	return m.report, m.err
+}
+
+func Test_SuccessorConfig(t *testing.T) {
+	predecessor := types.ConfigDigest{0x00, 0x09, 1, 2, 3}
+	onchainConfig, offchainConfig, err := SuccessorConfig(predecessor)
+	require.NoError(t, err)
+
+	c, err := llo.EVMOnchainConfigCodec{}.Decode(onchainConfig)
+	require.NoError(t, err)
+	require.NotNil(t, c.PredecessorConfigDigest)
+	assert.Equal(t, predecessor, *c.PredecessorConfigDigest)
+	_, err = llo.DecodeOffchainConfig(offchainConfig)
+	require.NoError(t, err)
+
+	require.NoError(t, VerifySuccessorConfig(onchainConfig, predecessor))
+	assert.ErrorContains(t, VerifySuccessorConfig(onchainConfig, types.ConfigDigest{0x00, 0x09, 4}), "onchain config has wrong predecessor config digest")
+	assert.ErrorContains(t, VerifySuccessorConfig([]byte{1}, predecessor), "failed to decode onchain config")
+
+	noPredecessor, err := llo.EVMOnchainConfigCodec{}.Encode(llo.OnchainConfig{Version: 1})
+	require.NoError(t, err)
+	assert.ErrorContains(t, VerifySuccessorConfig(noPredecessor, predecessor), "onchain config has no predecessor config digest")
+
+	_, _, err = SuccessorConfig(types.ConfigDigest{})
+	assert.ErrorContains(t, err, "invalid predecessor: config digest 0000")
+}
+
+func Test_CheckHandover(t *testing.T) {
+	predecessor := types.ConfigDigest{0x00, 0x09, 1}
+	onchainConfig, _, err := SuccessorConfig(predecessor)
+	require.NoError(t, err)
+	cds := llotypes.ChannelDefinitions{
+		1: {ReportFormat: llotypes.ReportFormatJSON, Streams: []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}}},
+		2: {ReportFormat: llotypes.ReportFormatJSON, Streams: []llotypes.Stream{{StreamID: 2, Aggregator: llotypes.AggregatorMedian}}},
+	}
+
+	t.Run("ready", func(t *testing.T) {
+		r := CheckHandover(Handover{
+			Predecessor:                      predecessor,
+			SuccessorOnchainConfig:           onchainConfig,
+			ChannelDefinitions:               cds,
+			ShouldRetireCache:                &mockShouldRetireCache{shouldRetire: true},
+			PredecessorRetirementReportCache: &mockRetirementReportCache{attested: []byte("attested"), report: llo.RetirementReport{ValidAfterSeconds: map[llotypes.ChannelID]uint32{1: 100, 2: 100}}},
+		})
+		assert.True(t, r.Ready())
+		assert.Equal(t, `[x] Successor onchain config designates predecessor
+[x] Successor channel definitions are valid
+[x] Predecessor is marked for retirement
+[x] Predecessor has produced an attested retirement report
+[x] Retirement report covers all successor channels
+Ready for handover
+`, r.String())
+	})
+	t.Run("ready with new channels", func(t *testing.T) {
+		r := CheckHandover(Handover{
+			Predecessor:                      predecessor,
+			SuccessorOnchainConfig:           onchainConfig,
+			ChannelDefinitions:               cds,
+			ShouldRetireCache:                &mockShouldRetireCache{shouldRetire: true},
+			PredecessorRetirementReportCache: &mockRetirementReportCache{attested: []byte("attested"), report: llo.RetirementReport{ValidAfterSeconds: map[llotypes.ChannelID]uint32{1: 100}}},
+		})
+		assert.True(t, r.Ready())
+		assert.Contains(t, r.String(), "[!] Retirement report covers all successor channels: no ValidAfterSeconds for channels [2]; these will be treated as new channels\n")
+	})
+	t.Run("predecessor not retired yet", func(t *testing.T) {
+		r := CheckHandover(Handover{
+			Predecessor:                      predecessor,
+			SuccessorOnchainConfig:           onchainConfig,
+			ChannelDefinitions:               cds,
+			ShouldRetireCache:                &mockShouldRetireCache{},
+			PredecessorRetirementReportCache: &mockRetirementReportCache{},
+		})
+		assert.False(t, r.Ready())
+		assert.Equal(t, `[x] Successor onchain config designates predecessor
+[x] Successor channel definitions are valid
+[ ] Predecessor is marked for retirement: set the predecessor to retire in the configuration contract
+[ ] Predecessor has produced an attested retirement report: waiting for the predecessor to retire
+NOT ready for handover
+`, r.String())
+	})
+	t.Run("wrong predecessor and unreadable state", func(t *testing.T) {
+		r := CheckHandover(Handover{
+			Predecessor:                      types.ConfigDigest{0x00, 0x09, 2},
+			SuccessorOnchainConfig:           onchainConfig,
+			ChannelDefinitions:               cds,
+			ShouldRetireCache:                &mockShouldRetireCache{err: errors.New("rpc down")},
+			PredecessorRetirementReportCache: &mockRetirementReportCache{},
+		})
+		assert.False(t, r.Ready())
+		require.Len(t, r.Checks, 3)
+		assert.False(t, r.Checks[0].OK)
+		assert.Equal(t, "Predecessor state is readable", r.Checks[2].Name)
+		assert.Equal(t, "failed to fetch shouldRetire: rpc down", r.Checks[2].Detail)
+	})
+}
+
+func Test_CheckPredecessor(t *testing.T) {
+	predecessor := types.ConfigDigest{0x00, 0x09, 1}
+
+	s, err := CheckPredecessor(predecessor, &mockShouldRetireCache{shouldRetire: true}, &mockRetirementReportCache{})
+	require.NoError(t, err)
+	assert.True(t, s.ShouldRetire)
+	assert.False(t, s.Retired())
+
+	_, err = CheckPredecessor(predecessor, &mockShouldRetireCache{}, &mockRetirementReportCache{attested: []byte("bad"), err: errors.New("invalid signature")})
+	assert.EqualError(t, err, "invalid attested retirement report: invalid signature")
+}
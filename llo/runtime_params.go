@@ -0,0 +1,75 @@
+package llo
+
+import (
+	"sync/atomic"
+)
+
+// RuntimeParams are operational knobs of the local node that can be changed
+// while plugins are running. Unlike the OffchainConfig, changing them does
+// not require a new OCR config, since they never affect consensus.
+type RuntimeParams struct {
+	// VerboseLogging overrides Config.VerboseLogging
+	VerboseLogging bool
+	// TelemetrySamplingInterval sends observation telemetry, i.e.
+	// provenance and attributions, for one in every
+	// TelemetrySamplingInterval rounds. Rounds are sampled by seqNr, so
+	// that all oracles with the same interval sample the same rounds. Zero
+	// or one samples every round.
+	TelemetrySamplingInterval uint64
+	// TransmissionFilter overrides the factory's TransmissionFilter
+	TransmissionFilter TransmissionFilter
+}
+
+// sampled returns true if telemetry of the round with seqNr is sent
+func (r RuntimeParams) sampled(seqNr uint64) bool {
+	return r.TelemetrySamplingInterval <= 1 || seqNr%r.TelemetrySamplingInterval == 0
+}
+
+// RuntimeParamsStore holds the current RuntimeParams. It is shared by a
+// PluginFactory and every plugin it creates, so that updates take effect
+// immediately, including for the running protocol instance.
+type RuntimeParamsStore struct {
+	params atomic.Pointer[RuntimeParams]
+}
+
+func NewRuntimeParamsStore(params RuntimeParams) *RuntimeParamsStore {
+	s := &RuntimeParamsStore{}
+	s.Set(params)
+	return s
+}
+
+// Get returns the current params
+func (s *RuntimeParamsStore) Get() RuntimeParams {
+	return *s.params.Load()
+}
+
+// Set replaces the current params. It is safe to call concurrently with
+// running plugins.
+func (s *RuntimeParamsStore) Set(params RuntimeParams) {
+	s.params.Store(&params)
+}
+
+// verboseLogging returns whether expensive debug logging is enabled
+func (p *Plugin) verboseLogging() bool {
+	if p.RuntimeParams != nil {
+		return p.RuntimeParams.Get().VerboseLogging
+	}
+	return p.Config.VerboseLogging
+}
+
+// transmissionFilter returns the filter of accepted reports to transmit
+func (p *Plugin) transmissionFilter() TransmissionFilter {
+	if p.RuntimeParams != nil {
+		return p.RuntimeParams.Get().TransmissionFilter
+	}
+	return p.TransmissionFilter
+}
+
+// telemetrySampled returns true if observation telemetry of the round with
+// seqNr is sent
+func (p *Plugin) telemetrySampled(seqNr uint64) bool {
+	if p.RuntimeParams != nil {
+		return p.RuntimeParams.Get().sampled(seqNr)
+	}
+	return true
+}
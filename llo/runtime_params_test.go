@@ -0,0 +1,120 @@
+package llo
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+func Test_RuntimeParams_sampled(t *testing.T) {
+	for _, interval := range []uint64{0, 1} {
+		for seqNr := uint64(1); seqNr <= 3; seqNr++ {
+			assert.True(t, RuntimeParams{TelemetrySamplingInterval: interval}.sampled(seqNr))
+		}
+	}
+	r := RuntimeParams{TelemetrySamplingInterval: 3}
+	assert.False(t, r.sampled(1))
+	assert.False(t, r.sampled(2))
+	assert.True(t, r.sampled(3))
+	assert.False(t, r.sampled(4))
+	assert.True(t, r.sampled(6))
+}
+
+func Test_Plugin_RuntimeParams(t *testing.T) {
+	t.Run("without a store, uses the factory's settings", func(t *testing.T) {
+		f := TransmissionFilter{ReportFormats: []llotypes.ReportFormat{llotypes.ReportFormatJSON}}
+		p := &Plugin{Config: Config{VerboseLogging: true}, TransmissionFilter: f}
+		assert.True(t, p.verboseLogging())
+		assert.Equal(t, f, p.transmissionFilter())
+		assert.True(t, p.telemetrySampled(7))
+	})
+	t.Run("updates of the store take effect on running plugins", func(t *testing.T) {
+		ctx := tests.Context(t)
+		store := NewRuntimeParamsStore(RuntimeParams{})
+		p := &Plugin{
+			Config:             Config{VerboseLogging: true},
+			ConfigDigest:       types.ConfigDigest{3},
+			TransmissionFilter: TransmissionFilter{ReportFormats: []llotypes.ReportFormat{llotypes.ReportFormatEVMPremiumLegacy}},
+			RuntimeParams:      store,
+		}
+		rwi := ocr3types.ReportWithInfo[llotypes.ReportInfo]{
+			Report: []byte(`{"ChannelID":1}`),
+			Info:   llotypes.ReportInfo{LifeCycleStage: LifeCycleStageProduction, ReportFormat: llotypes.ReportFormatJSON},
+		}
+
+		// the store overrides the factory's settings
+		assert.False(t, p.verboseLogging())
+		ok, err := p.ShouldTransmitAcceptedReport(ctx, 1, rwi)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		store.Set(RuntimeParams{
+			VerboseLogging:            true,
+			TelemetrySamplingInterval: 2,
+			TransmissionFilter:        TransmissionFilter{LifeCycleStages: []llotypes.LifeCycleStage{LifeCycleStageStaging}},
+		})
+		assert.True(t, p.verboseLogging())
+		assert.False(t, p.telemetrySampled(1))
+		assert.True(t, p.telemetrySampled(2))
+		ok, err = p.ShouldTransmitAcceptedReport(ctx, 2, rwi)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
+
+func Test_Observation_TelemetrySampling(t *testing.T) {
+	ctx := tests.Context(t)
+	_, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	provenanceSink := &mockObservationProvenanceSink{}
+	signer, err := NewObservationProvenanceSigner(privateKey, provenanceSink)
+	require.NoError(t, err)
+	attributionSink := &mockObservationAttributionSink{}
+	attributions, err := NewObservationAttributions(attributionSink)
+	require.NoError(t, err)
+
+	definitions := llotypes.ChannelDefinitions{
+		1: {
+			ReportFormat: llotypes.ReportFormatJSON,
+			Streams:      []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}},
+		},
+	}
+	p := &Plugin{
+		ConfigDigest:            types.ConfigDigest{1},
+		OutcomeCodec:            protoOutcomeCodec{},
+		ShouldRetireCache:       &mockShouldRetireCache{},
+		ChannelDefinitionCache:  &mockChannelDefinitionCache{definitions: definitions},
+		Logger:                  logger.Test(t),
+		ObservationCodec:        protoObservationCodec{},
+		DataSource:              &mockDataSource{s: StreamValues{1: ToDecimal(decimal.NewFromInt(1000))}},
+		ObservationProvenance:   signer,
+		ObservationAttributions: attributions,
+		RuntimeParams:           NewRuntimeParamsStore(RuntimeParams{TelemetrySamplingInterval: 2}),
+	}
+	previousOutcome, err := p.OutcomeCodec.Encode(Outcome{
+		LifeCycleStage:     LifeCycleStageProduction,
+		ChannelDefinitions: definitions,
+	})
+	require.NoError(t, err)
+
+	for seqNr := uint64(2); seqNr <= 5; seqNr++ {
+		_, err = p.Observation(ctx, ocr3types.OutcomeContext{SeqNr: seqNr, PreviousOutcome: previousOutcome}, types.Query{})
+		require.NoError(t, err)
+	}
+	require.Len(t, provenanceSink.sent, 2)
+	assert.Equal(t, uint64(2), provenanceSink.sent[0].SeqNr)
+	assert.Equal(t, uint64(4), provenanceSink.sent[1].SeqNr)
+	require.Len(t, attributionSink.sent, 2)
+	assert.Equal(t, uint64(2), attributionSink.sent[0].SeqNr)
+	assert.Equal(t, uint64(4), attributionSink.sent[1].SeqNr)
+}
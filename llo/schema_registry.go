@@ -0,0 +1,170 @@
+package llo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+// SchemaVersionOpts are channel opts that select which schema version of a
+// report format is used for the channel. They may be combined with any
+// codec-specific opts.
+type SchemaVersionOpts struct {
+	// SchemaVersion selects the codec used for the channel. If zero, the
+	// default version for the channel's ReportFormat is used.
+	SchemaVersion uint32 `json:"schemaVersion,omitempty"`
+}
+
+// ParseSchemaVersion extracts the schema version from a channel's opts,
+// returning zero if it is not set. Other fields in the opts are ignored.
+func ParseSchemaVersion(opts llotypes.ChannelOpts) (uint32, error) {
+	if len(opts) == 0 {
+		return 0, nil
+	}
+	var o SchemaVersionOpts
+	if err := json.Unmarshal(opts, &o); err != nil {
+		return 0, fmt.Errorf("invalid channel opts: %w", err)
+	}
+	return o.SchemaVersion, nil
+}
+
+// ReportCodecRegistry maps (ReportFormat, schema version) pairs to codecs so
+// that several schema versions of the same format family can be served side
+// by side, e.g. while consumers migrate from one version to the next.
+type ReportCodecRegistry struct {
+	mu       sync.RWMutex
+	codecs   map[llotypes.ReportFormat]map[uint32]ReportCodec
+	defaults map[llotypes.ReportFormat]uint32
+}
+
+func NewReportCodecRegistry() *ReportCodecRegistry {
+	return &ReportCodecRegistry{
+		codecs:   make(map[llotypes.ReportFormat]map[uint32]ReportCodec),
+		defaults: make(map[llotypes.ReportFormat]uint32),
+	}
+}
+
+// Register adds a codec for the given format and schema version. The first
+// version registered for a format becomes its default.
+func (r *ReportCodecRegistry) Register(rf llotypes.ReportFormat, version uint32, codec ReportCodec) error {
+	if version == 0 {
+		return fmt.Errorf("cannot register codec for ReportFormat=%q: schema version 0 is reserved for the default", rf)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	versions, exists := r.codecs[rf]
+	if !exists {
+		versions = make(map[uint32]ReportCodec)
+		r.codecs[rf] = versions
+		r.defaults[rf] = version
+	}
+	if _, exists := versions[version]; exists {
+		return fmt.Errorf("codec already registered for ReportFormat=%q, schemaVersion=%d", rf, version)
+	}
+	versions[version] = codec
+	return nil
+}
+
+// SetDefault changes the version used for channels that do not specify one
+func (r *ReportCodecRegistry) SetDefault(rf llotypes.ReportFormat, version uint32) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.codecs[rf][version]; !exists {
+		return fmt.Errorf("no codec registered for ReportFormat=%q, schemaVersion=%d", rf, version)
+	}
+	r.defaults[rf] = version
+	return nil
+}
+
+// Get returns the codec for the given format and schema version. A version
+// of zero returns the default codec for the format.
+func (r *ReportCodecRegistry) Get(rf llotypes.ReportFormat, version uint32) (ReportCodec, uint32, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	versions, exists := r.codecs[rf]
+	if !exists {
+		return nil, 0, fmt.Errorf("no codecs registered for ReportFormat=%q", rf)
+	}
+	if version == 0 {
+		version = r.defaults[rf]
+	}
+	codec, exists := versions[version]
+	if !exists {
+		return nil, 0, fmt.Errorf("unsupported schemaVersion=%d for ReportFormat=%q; supported versions: %v", version, rf, r.versions(rf))
+	}
+	return codec, version, nil
+}
+
+// Versions returns the schema versions registered for the given format,
+// in ascending order
+func (r *ReportCodecRegistry) Versions(rf llotypes.ReportFormat) []uint32 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.versions(rf)
+}
+
+func (r *ReportCodecRegistry) versions(rf llotypes.ReportFormat) []uint32 {
+	versions := make([]uint32, 0, len(r.codecs[rf]))
+	for v := range r.codecs[rf] {
+		versions = append(versions, v)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+	return versions
+}
+
+// SchemaVersion resolves the schema version that will be used to encode
+// reports for the given channel
+func (r *ReportCodecRegistry) SchemaVersion(cd llotypes.ChannelDefinition) (uint32, error) {
+	version, err := ParseSchemaVersion(cd.Opts)
+	if err != nil {
+		return 0, err
+	}
+	_, version, err = r.Get(cd.ReportFormat, version)
+	return version, err
+}
+
+// ReportCodecs returns a codec for every registered format that dispatches
+// to the schema version selected by each channel's opts, suitable for
+// passing to NewPluginFactory
+func (r *ReportCodecRegistry) ReportCodecs() map[llotypes.ReportFormat]ReportCodec {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	codecs := make(map[llotypes.ReportFormat]ReportCodec, len(r.codecs))
+	for rf := range r.codecs {
+		codecs[rf] = versionedReportCodec{r}
+	}
+	return codecs
+}
+
+// schemaVersioner is implemented by codecs that serve multiple schema
+// versions of a format
+type schemaVersioner interface {
+	SchemaVersion(llotypes.ChannelDefinition) (uint32, error)
+}
+
+var _ ReportCodec = versionedReportCodec{}
+var _ schemaVersioner = versionedReportCodec{}
+
+type versionedReportCodec struct {
+	registry *ReportCodecRegistry
+}
+
+func (c versionedReportCodec) Encode(ctx context.Context, report Report, cd llotypes.ChannelDefinition) ([]byte, error) {
+	version, err := ParseSchemaVersion(cd.Opts)
+	if err != nil {
+		return nil, err
+	}
+	codec, _, err := c.registry.Get(cd.ReportFormat, version)
+	if err != nil {
+		return nil, err
+	}
+	return codec.Encode(ctx, report, cd)
+}
+
+func (c versionedReportCodec) SchemaVersion(cd llotypes.ChannelDefinition) (uint32, error) {
+	return c.registry.SchemaVersion(cd)
+}
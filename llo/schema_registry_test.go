@@ -0,0 +1,107 @@
+package llo
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+func Test_ParseSchemaVersion(t *testing.T) {
+	v, err := ParseSchemaVersion(nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0), v)
+
+	v, err = ParseSchemaVersion([]byte(`{"schemaVersion":3,"other":"field"}`))
+	require.NoError(t, err)
+	assert.Equal(t, uint32(3), v)
+
+	_, err = ParseSchemaVersion([]byte(`{"schemaVersion":-1}`))
+	assert.ErrorContains(t, err, "invalid channel opts")
+}
+
+func Test_ReportCodecRegistry(t *testing.T) {
+	ctx := tests.Context(t)
+	rf := llotypes.ReportFormatEVMPremiumLegacy
+
+	r := NewReportCodecRegistry()
+	require.NoError(t, r.Register(rf, 3, EVMPremiumLegacyReportCodec{}))
+	require.NoError(t, r.Register(rf, 2, EVMMercuryV2ReportCodec{}))
+	require.NoError(t, r.Register(llotypes.ReportFormatJSON, 1, JSONReportCodec{}))
+
+	t.Run("Register", func(t *testing.T) {
+		assert.EqualError(t, r.Register(rf, 3, EVMPremiumLegacyReportCodec{}), `codec already registered for ReportFormat="evm_premium_legacy", schemaVersion=3`)
+		assert.EqualError(t, r.Register(rf, 0, EVMPremiumLegacyReportCodec{}), `cannot register codec for ReportFormat="evm_premium_legacy": schema version 0 is reserved for the default`)
+		assert.Equal(t, []uint32{2, 3}, r.Versions(rf))
+	})
+	t.Run("Get", func(t *testing.T) {
+		codec, version, err := r.Get(rf, 0)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(3), version, "first registered version is the default")
+		assert.IsType(t, EVMPremiumLegacyReportCodec{}, codec)
+
+		codec, version, err = r.Get(rf, 2)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(2), version)
+		assert.IsType(t, EVMMercuryV2ReportCodec{}, codec)
+
+		_, _, err = r.Get(rf, 4)
+		assert.EqualError(t, err, `unsupported schemaVersion=4 for ReportFormat="evm_premium_legacy"; supported versions: [2 3]`)
+		_, _, err = r.Get(llotypes.ReportFormatRetirement, 0)
+		assert.EqualError(t, err, `no codecs registered for ReportFormat="retirement"`)
+	})
+	t.Run("SetDefault", func(t *testing.T) {
+		r := NewReportCodecRegistry()
+		require.NoError(t, r.Register(rf, 3, EVMPremiumLegacyReportCodec{}))
+		require.NoError(t, r.Register(rf, 2, EVMMercuryV2ReportCodec{}))
+		require.NoError(t, r.SetDefault(rf, 2))
+		_, version, err := r.Get(rf, 0)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(2), version)
+
+		assert.EqualError(t, r.SetDefault(rf, 1), `no codec registered for ReportFormat="evm_premium_legacy", schemaVersion=1`)
+	})
+	t.Run("ReportCodecs dispatches on channel schema version", func(t *testing.T) {
+		codecs := r.ReportCodecs()
+		require.Len(t, codecs, 2)
+		cdc := codecs[rf]
+
+		report := Report{
+			ObservationTimestampSeconds: 1726670490,
+			Values: []StreamValue{
+				ToDecimal(decimal.NewFromInt(2000)),
+				ToDecimal(decimal.NewFromInt(20)),
+				&Quote{Bid: decimal.NewFromInt(1), Benchmark: decimal.NewFromInt(2), Ask: decimal.NewFromInt(3)},
+			},
+		}
+		opts := func(version string) []byte {
+			return []byte(`{"feedID":"0x0003000000000000000000000000000000000000000000000000000000000001","multiplier":"1"` + version + `}`)
+		}
+		cd := llotypes.ChannelDefinition{ReportFormat: rf, Streams: []llotypes.Stream{{}, {}, {}}}
+
+		cd.Opts = opts(``)
+		b, err := cdc.Encode(ctx, report, cd)
+		require.NoError(t, err)
+		assert.Len(t, b, 9*32, "default is v3")
+		version, err := r.SchemaVersion(cd)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(3), version)
+
+		cd.Opts = opts(`,"schemaVersion":2`)
+		b, err = cdc.Encode(ctx, report, cd)
+		require.NoError(t, err)
+		assert.Len(t, b, 7*32)
+		version, err = cdc.(schemaVersioner).SchemaVersion(cd)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(2), version)
+
+		cd.Opts = opts(`,"schemaVersion":9`)
+		_, err = cdc.Encode(ctx, report, cd)
+		assert.EqualError(t, err, `unsupported schemaVersion=9 for ReportFormat="evm_premium_legacy"; supported versions: [2 3]`)
+	})
+}
@@ -0,0 +1,59 @@
+package llo
+
+import "github.com/shopspring/decimal"
+
+// selectKth partially reorders s so that s[k] is the element that would be
+// at index k if s were sorted by cmp, with s[:k] <= s[k] <= s[k+1:], and
+// returns it. It runs in expected O(n) time, compared to O(n log n) for
+// sorting.
+//
+// Pivots are chosen deterministically, so that every oracle computes the
+// same result for the same observations. k must be in [0, len(s)).
+func selectKth[T any](s []T, k int, cmp func(a, b T) int) T {
+	lo, hi := 0, len(s)-1
+	for lo < hi {
+		p := partition(s, lo, hi, medianOfThree(s, lo, hi, cmp), cmp)
+		switch {
+		case k < p:
+			hi = p - 1
+		case k > p:
+			lo = p + 1
+		default:
+			return s[k]
+		}
+	}
+	return s[k]
+}
+
+// medianOfThree returns the index of the median of s[lo], s[mid] and s[hi],
+// which avoids quadratic behavior on sorted input
+func medianOfThree[T any](s []T, lo, hi int, cmp func(a, b T) int) int {
+	mid := lo + (hi-lo)/2
+	if cmp(s[mid], s[lo]) < 0 {
+		lo, mid = mid, lo
+	}
+	if cmp(s[hi], s[mid]) < 0 {
+		mid = hi
+		if cmp(s[mid], s[lo]) < 0 {
+			mid = lo
+		}
+	}
+	return mid
+}
+
+// partition moves s[pivot] to its sorted position within s[lo:hi+1] and
+// returns that position (Lomuto scheme)
+func partition[T any](s []T, lo, hi, pivot int, cmp func(a, b T) int) int {
+	s[pivot], s[hi] = s[hi], s[pivot]
+	i := lo
+	for j := lo; j < hi; j++ {
+		if cmp(s[j], s[hi]) < 0 {
+			s[i], s[j] = s[j], s[i]
+			i++
+		}
+	}
+	s[i], s[hi] = s[hi], s[i]
+	return i
+}
+
+func compareDecimals(a, b decimal.Decimal) int { return a.Cmp(b) }
@@ -0,0 +1,128 @@
+package llo
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+func Test_selectKth(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	inputs := map[string]func(n int) []int64{
+		"random": func(n int) []int64 {
+			s := make([]int64, n)
+			for i := range s {
+				s[i] = r.Int64N(100)
+			}
+			return s
+		},
+		"sorted": func(n int) []int64 {
+			s := make([]int64, n)
+			for i := range s {
+				s[i] = int64(i)
+			}
+			return s
+		},
+		"reversed": func(n int) []int64 {
+			s := make([]int64, n)
+			for i := range s {
+				s[i] = int64(n - i)
+			}
+			return s
+		},
+		"equal": func(n int) []int64 {
+			return make([]int64, n)
+		},
+	}
+	for name, gen := range inputs {
+		t.Run(name, func(t *testing.T) {
+			for n := 1; n <= 40; n++ {
+				for k := 0; k < n; k++ {
+					s := gen(n)
+					sorted := slices.Clone(s)
+					slices.Sort(sorted)
+
+					require.Equal(t, sorted[k], selectKth(s, k, cmp.Compare[int64]), fmt.Sprintf("n=%d, k=%d", n, k))
+					assert.Equal(t, sorted[k], s[k])
+					for i := range s {
+						if i < k {
+							assert.LessOrEqual(t, s[i], s[k])
+						} else if i > k {
+							assert.GreaterOrEqual(t, s[i], s[k])
+						}
+					}
+					slices.Sort(s)
+					assert.Equal(t, sorted, s, "is a permutation")
+				}
+			}
+		})
+	}
+}
+
+// 1,000 streams observed by 31 oracles is the largest outcome
+const benchmarkStreams, benchmarkOracles = 1000, 31
+
+func benchmarkObservations() [][]decimal.Decimal {
+	r := rand.New(rand.NewPCG(1, 2))
+	observations := make([][]decimal.Decimal, benchmarkStreams)
+	for i := range observations {
+		observations[i] = make([]decimal.Decimal, benchmarkOracles)
+		for j := range observations[i] {
+			observations[i][j] = decimal.New(r.Int64N(1_000_000_000), -8)
+		}
+	}
+	return observations
+}
+
+// Benchmark_Median compares the medians of a round's worth of stream
+// observations computed by sorting and by selection
+func Benchmark_Median(b *testing.B) {
+	observations := benchmarkObservations()
+	scratch := make([]decimal.Decimal, benchmarkOracles)
+	b.Run("sort", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, obs := range observations {
+				copy(scratch, obs)
+				sort.Slice(scratch, func(i, j int) bool { return scratch[i].Cmp(scratch[j]) < 0 })
+				_ = scratch[len(scratch)/2]
+			}
+		}
+	})
+	b.Run("select", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, obs := range observations {
+				copy(scratch, obs)
+				_ = selectKth(scratch, len(scratch)/2, compareDecimals)
+			}
+		}
+	})
+}
+
+func Benchmark_MedianAggregator(b *testing.B) {
+	observations := benchmarkObservations()
+	values := make([][]StreamValue, len(observations))
+	for i, obs := range observations {
+		values[i] = make([]StreamValue, len(obs))
+		for j, d := range obs {
+			values[i][j] = ToDecimal(d)
+		}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, vs := range values {
+			_, err := GetAggregatorFunc(llotypes.AggregatorMedian)(vs, 10)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
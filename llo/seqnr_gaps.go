@@ -0,0 +1,113 @@
+package llo
+
+import (
+	"sync"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+const (
+	// SeqNrGapWarnThreshold is the number of consecutive rounds that an
+	// oracle must miss for the gap to be logged as a warning. Smaller gaps
+	// are only counted in metrics.
+	SeqNrGapWarnThreshold = 10
+	// abnormalValidityWindowFactor is how many round intervals a channel's
+	// validity window may span before it counts as abnormally grown
+	abnormalValidityWindowFactor = 2
+)
+
+// SeqNrGap describes rounds that an oracle did not generate reports for,
+// i.e. that it missed, between two rounds that it did
+type SeqNrGap struct {
+	// From is the last seqNr seen before the gap
+	From uint64
+	// To is the first seqNr seen after the gap
+	To uint64
+	// AbnormalValidityWindows is the number of reportable channels whose
+	// validity window, in the round after the gap, spans more than
+	// abnormalValidityWindowFactor round intervals
+	AbnormalValidityWindows int
+}
+
+// Missed is the number of rounds in the gap
+func (g SeqNrGap) Missed() uint64 {
+	return g.To - g.From - 1
+}
+
+// seqNrTracker tracks the progression of the seqNrs that an oracle generates
+// reports for, to detect the rounds it missed. A nil tracker detects
+// nothing.
+type seqNrTracker struct {
+	mu    sync.Mutex
+	seqNr uint64
+	// observationsTimestampSeconds of the round with seqNr
+	observationsTimestampSeconds uint32
+	// roundIntervalSeconds is the time between the last two consecutive
+	// rounds seen, or zero if there were none yet
+	roundIntervalSeconds uint32
+}
+
+// observe records a round and returns the gap since the previous round, if
+// any. Rounds older than the last one seen, e.g. after a restart, reset the
+// tracker.
+func (t *seqNrTracker) observe(seqNr uint64, observationsTimestampSeconds uint32, outcome *Outcome, reportableChannels []llotypes.ChannelID) (gap SeqNrGap, ok bool) {
+	if t == nil {
+		return gap, false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	prevSeqNr, prevTimestamp := t.seqNr, t.observationsTimestampSeconds
+	t.seqNr, t.observationsTimestampSeconds = seqNr, observationsTimestampSeconds
+
+	switch {
+	case prevSeqNr == 0 || seqNr <= prevSeqNr:
+		return gap, false
+	case seqNr == prevSeqNr+1:
+		if observationsTimestampSeconds > prevTimestamp {
+			t.roundIntervalSeconds = observationsTimestampSeconds - prevTimestamp
+		}
+		return gap, false
+	}
+
+	gap = SeqNrGap{From: prevSeqNr, To: seqNr}
+	// Timestamps have second granularity, so rounds within the same second
+	// still have a window of up to a second. Widened so that long round
+	// intervals cannot overflow.
+	maxWindow := abnormalValidityWindowFactor * uint64(max(t.roundIntervalSeconds, 1))
+	for _, cid := range reportableChannels {
+		validAfterSeconds := outcome.ValidAfterSeconds[cid]
+		if observationsTimestampSeconds > validAfterSeconds && uint64(observationsTimestampSeconds-validAfterSeconds) > maxWindow {
+			gap.AbnormalValidityWindows++
+		}
+	}
+	return gap, true
+}
+
+// restore sets the last round seen, e.g. from before a restart
+func (t *seqNrTracker) restore(seqNr uint64, observationsTimestampSeconds uint32) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.seqNr, t.observationsTimestampSeconds = seqNr, observationsTimestampSeconds
+}
+
+// detectSeqNrGap records the round of a committed outcome and reports the
+// rounds missed since the previous one through metrics and logs
+func (p *Plugin) detectSeqNrGap(seqNr uint64, observationsTimestampSeconds uint32, outcome *Outcome, reportableChannels []llotypes.ChannelID) {
+	gap, ok := p.seqNrs.observe(seqNr, observationsTimestampSeconds, outcome, reportableChannels)
+	if !ok {
+		return
+	}
+	configDigest := p.ConfigDigest.String()
+	promSeqNrGapsTotal.WithLabelValues(configDigest).Inc()
+	promMissedRoundsTotal.WithLabelValues(configDigest).Add(float64(gap.Missed()))
+	promSeqNrGapAbnormalValidityWindows.WithLabelValues(configDigest).Set(float64(gap.AbnormalValidityWindows))
+	p.recordDegradedRounds(seqNr-1, gap.Missed(), false)
+	if gap.Missed() >= SeqNrGapWarnThreshold {
+		p.Logger.Warnw("Missed rounds, reports of this oracle have a gap", "stage", "Report", "seqNr", seqNr, "lastSeqNr", gap.From, "missedRounds", gap.Missed(), "abnormalValidityWindows", gap.AbnormalValidityWindows, "reportableChannels", len(reportableChannels))
+	} else if p.verboseLogging() {
+		p.Logger.Debugw("Missed rounds", "stage", "Report", "seqNr", seqNr, "lastSeqNr", gap.From, "missedRounds", gap.Missed(), "abnormalValidityWindows", gap.AbnormalValidityWindows)
+	}
+}
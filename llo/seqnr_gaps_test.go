@@ -0,0 +1,68 @@
+package llo
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+func Test_seqNrTracker(t *testing.T) {
+	outcome := &Outcome{ValidAfterSeconds: map[llotypes.ChannelID]uint32{}}
+	channels := []llotypes.ChannelID{1, 2, 3}
+
+	t.Run("nil tracker detects nothing", func(t *testing.T) {
+		var tr *seqNrTracker
+		_, ok := tr.observe(10, 100, outcome, channels)
+		assert.False(t, ok)
+	})
+
+	tr := &seqNrTracker{}
+	t.Run("consecutive rounds have no gap", func(t *testing.T) {
+		for i := uint64(0); i < 3; i++ {
+			_, ok := tr.observe(10+i, 100+uint32(i), outcome, channels)
+			assert.False(t, ok)
+		}
+		assert.Equal(t, uint32(1), tr.roundIntervalSeconds)
+	})
+	t.Run("detects missed rounds and channels with grown validity windows", func(t *testing.T) {
+		outcome.ValidAfterSeconds[1] = 119 // reported in the previous round
+		outcome.ValidAfterSeconds[2] = 102 // last reported before the gap
+		outcome.ValidAfterSeconds[3] = 118 // exactly at the limit
+		gap, ok := tr.observe(30, 120, outcome, channels)
+		require.True(t, ok)
+		assert.Equal(t, SeqNrGap{From: 12, To: 30, AbnormalValidityWindows: 1}, gap)
+		assert.Equal(t, uint64(17), gap.Missed())
+	})
+	t.Run("older rounds reset the tracker", func(t *testing.T) {
+		_, ok := tr.observe(5, 200, outcome, channels)
+		assert.False(t, ok)
+		_, ok = tr.observe(6, 201, outcome, channels)
+		assert.False(t, ok)
+	})
+}
+
+func Test_Plugin_detectSeqNrGap(t *testing.T) {
+	p := &Plugin{
+		ConfigDigest: types.ConfigDigest{0x00, 0x09, 0x5e},
+		Logger:       logger.Test(t),
+		seqNrs:       &seqNrTracker{},
+	}
+	configDigest := p.ConfigDigest.String()
+	outcome := &Outcome{ValidAfterSeconds: map[llotypes.ChannelID]uint32{1: 100}}
+
+	p.detectSeqNrGap(2, 100, outcome, []llotypes.ChannelID{1})
+	p.detectSeqNrGap(3, 101, outcome, []llotypes.ChannelID{1})
+	assert.Equal(t, float64(0), testutil.ToFloat64(promSeqNrGapsTotal.WithLabelValues(configDigest)))
+
+	p.detectSeqNrGap(20, 120, outcome, []llotypes.ChannelID{1})
+	assert.Equal(t, float64(1), testutil.ToFloat64(promSeqNrGapsTotal.WithLabelValues(configDigest)))
+	assert.Equal(t, float64(16), testutil.ToFloat64(promMissedRoundsTotal.WithLabelValues(configDigest)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(promSeqNrGapAbnormalValidityWindows.WithLabelValues(configDigest)))
+}
@@ -0,0 +1,81 @@
+package llo
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+// StreamDecimalsOpts are channel opts that give the number of decimal places
+// with which the values of each stream are represented onchain. Codecs that
+// render decimal values as integers scale the values of the listed streams
+// by 10^decimals before truncating them, so that DataSources can return
+// values in their natural units instead of pre-scaling them.
+//
+// They may be combined with any codec-specific opts, and take precedence
+// over codec-wide scaling such as a Multiplier for the streams they list.
+type StreamDecimalsOpts struct {
+	StreamDecimals map[llotypes.StreamID]uint8 `json:"streamDecimals,omitempty"`
+}
+
+// ParseStreamDecimals extracts the decimals of each stream from a channel's
+// opts. Other fields in the opts are ignored.
+func ParseStreamDecimals(opts llotypes.ChannelOpts) (map[llotypes.StreamID]uint8, error) {
+	if len(opts) == 0 {
+		return nil, nil
+	}
+	var o StreamDecimalsOpts
+	if err := json.Unmarshal(opts, &o); err != nil {
+		return nil, fmt.Errorf("invalid channel opts: %w", err)
+	}
+	return o.StreamDecimals, nil
+}
+
+// verify checks that decimals are only set for streams of the channel
+func (o StreamDecimalsOpts) verify(cd llotypes.ChannelDefinition) error {
+	streams := channelStreams(nil, cd)
+	for id := range o.StreamDecimals {
+		found := false
+		for _, strm := range streams {
+			if strm.StreamID == id {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("invalid channel opts: streamDecimals set for stream %d, which is not a stream of the channel", id)
+		}
+	}
+	return nil
+}
+
+// multiplier returns the factor by which values of the stream are scaled:
+// 10^decimals if the stream has decimals, or else the given fallback
+func (o StreamDecimalsOpts) multiplier(streamID llotypes.StreamID, fallback decimal.Decimal) decimal.Decimal {
+	if decimals, ok := o.StreamDecimals[streamID]; ok {
+		return decimal.New(1, int32(decimals))
+	}
+	return fallback
+}
+
+// priceMultiplier returns the factor by which the price being reported,
+// which is the value of the idx'th stream of the channel, is scaled. It
+// must be positive.
+func (o StreamDecimalsOpts) priceMultiplier(cd llotypes.ChannelDefinition, idx int, multiplier decimal.Decimal, priceName string) (decimal.Decimal, error) {
+	if idx < 0 || idx >= len(cd.Streams) {
+		// Encoding without the channel's streams, the multiplier applies
+		if !multiplier.IsPositive() {
+			return multiplier, fmt.Errorf("invalid channel opts: multiplier must be positive unless streamDecimals are set for the %s stream", priceName)
+		}
+		return multiplier, nil
+	}
+	streamID := cd.Streams[idx].StreamID
+	m := o.multiplier(streamID, multiplier)
+	if !m.IsPositive() {
+		return m, fmt.Errorf("invalid channel opts: multiplier must be positive unless streamDecimals are set for the %s stream (%d)", priceName, streamID)
+	}
+	return m, nil
+}
@@ -0,0 +1,47 @@
+package llo
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+func Test_ParseStreamDecimals(t *testing.T) {
+	decimals, err := ParseStreamDecimals(nil)
+	require.NoError(t, err)
+	assert.Nil(t, decimals)
+
+	decimals, err = ParseStreamDecimals([]byte(`{"streamDecimals":{"1":8,"2":18},"foo":"bar"}`))
+	require.NoError(t, err)
+	assert.Equal(t, map[llotypes.StreamID]uint8{1: 8, 2: 18}, decimals)
+
+	_, err = ParseStreamDecimals([]byte(`{"streamDecimals":{"1":-1}}`))
+	assert.ErrorContains(t, err, "invalid channel opts")
+}
+
+func Test_StreamDecimalsOpts(t *testing.T) {
+	opts := StreamDecimalsOpts{StreamDecimals: map[llotypes.StreamID]uint8{1: 8, 3: 0}}
+	fallback := decimal.NewFromInt(100)
+
+	assert.True(t, decimal.NewFromInt(100_000_000).Equal(opts.multiplier(1, fallback)))
+	assert.True(t, decimal.NewFromInt(1).Equal(opts.multiplier(3, fallback)))
+	assert.True(t, fallback.Equal(opts.multiplier(2, fallback)))
+
+	cd := llotypes.ChannelDefinition{Streams: []llotypes.Stream{{StreamID: 1}, {StreamID: 2}}}
+	assert.EqualError(t, opts.verify(cd), "invalid channel opts: streamDecimals set for stream 3, which is not a stream of the channel")
+	cd.Opts = []byte(`{"nativeFeeStreamID":3,"linkFeeStreamID":4}`)
+	// fee streams are streams of the channel too
+	require.NoError(t, opts.verify(cd))
+
+	m, err := opts.priceMultiplier(cd, 0, decimal.Zero, "quote")
+	require.NoError(t, err)
+	assert.True(t, decimal.NewFromInt(100_000_000).Equal(m))
+	_, err = opts.priceMultiplier(cd, 1, decimal.Zero, "quote")
+	assert.EqualError(t, err, "invalid channel opts: multiplier must be positive unless streamDecimals are set for the quote stream (2)")
+	_, err = opts.priceMultiplier(llotypes.ChannelDefinition{}, -1, decimal.Zero, "quote")
+	assert.EqualError(t, err, "invalid channel opts: multiplier must be positive unless streamDecimals are set for the quote stream")
+}
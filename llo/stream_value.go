@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strings"
 
 	"google.golang.org/protobuf/proto"
 
@@ -40,6 +41,8 @@ func UnmarshalProtoStreamValue(enc *LLOStreamValue) (sv StreamValue, err error)
 		sv = new(Quote)
 	case LLOStreamValue_Decimal:
 		sv = new(Decimal)
+	case LLOStreamValue_PriceVolumes:
+		sv = new(PriceVolumes)
 	default:
 		return nil, fmt.Errorf("cannot unmarshal protobuf stream value; unknown StreamValueType %d", enc.Type)
 	}
@@ -93,13 +96,13 @@ func (v *Quote) UnmarshalBinary(data []byte) error {
 	if err := proto.Unmarshal(data, q); err != nil {
 		return err
 	}
-	if err := (&v.Bid).UnmarshalBinary(q.Bid); err != nil {
+	if err := unmarshalDecimalBinary(&v.Bid, q.Bid); err != nil {
 		return err
 	}
-	if err := (&v.Benchmark).UnmarshalBinary(q.Benchmark); err != nil {
+	if err := unmarshalDecimalBinary(&v.Benchmark, q.Benchmark); err != nil {
 		return err
 	}
-	return (&v.Ask).UnmarshalBinary(q.Ask)
+	return unmarshalDecimalBinary(&v.Ask, q.Ask)
 }
 
 func (v *Quote) MarshalText() ([]byte, error) {
@@ -164,7 +167,7 @@ func (v *Decimal) MarshalBinary() ([]byte, error) {
 }
 
 func (v *Decimal) UnmarshalBinary(data []byte) error {
-	return (*decimal.Decimal)(v).UnmarshalBinary(data)
+	return unmarshalDecimalBinary((*decimal.Decimal)(v), data)
 }
 
 func (v *Decimal) String() string {
@@ -188,3 +191,137 @@ func (v *Decimal) UnmarshalText(data []byte) error {
 func (v *Decimal) Type() LLOStreamValue_Type {
 	return LLOStreamValue_Decimal
 }
+
+// MaxPriceVolumePairs is the maximum number of pairs in a PriceVolumes
+const MaxPriceVolumePairs = 8
+
+type PriceVolume struct {
+	Price  decimal.Decimal
+	Volume decimal.Decimal
+}
+
+// PriceVolumes implements StreamValue for a list of {Price, Volume} pairs,
+// e.g. the last traded price and traded volume on each of several venues.
+// Use with AggregatorVolumeWeighted.
+type PriceVolumes []PriceVolume
+
+var _ StreamValue = (*PriceVolumes)(nil)
+
+func (v *PriceVolumes) MarshalBinary() (b []byte, err error) {
+	if v == nil {
+		return nil, ErrNilStreamValue
+	}
+	if len(*v) > MaxPriceVolumePairs {
+		return nil, fmt.Errorf("too many price/volume pairs; got: %d, max: %d", len(*v), MaxPriceVolumePairs)
+	}
+	pvs := LLOStreamValuePriceVolumes{Pairs: make([]*LLOStreamValuePriceVolume, len(*v))}
+	for i, pv := range *v {
+		enc := new(LLOStreamValuePriceVolume)
+		enc.Price, err = pv.Price.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		enc.Volume, err = pv.Volume.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		pvs.Pairs[i] = enc
+	}
+	return proto.Marshal(&pvs)
+}
+
+func (v *PriceVolumes) UnmarshalBinary(data []byte) error {
+	if v == nil {
+		return ErrNilStreamValue
+	}
+	pvs := new(LLOStreamValuePriceVolumes)
+	if err := proto.Unmarshal(data, pvs); err != nil {
+		return err
+	}
+	if len(pvs.Pairs) > MaxPriceVolumePairs {
+		// Byzantine behavior; a well-behaved node never encodes more
+		return fmt.Errorf("too many price/volume pairs; got: %d, max: %d", len(pvs.Pairs), MaxPriceVolumePairs)
+	}
+	out := make(PriceVolumes, len(pvs.Pairs))
+	for i, enc := range pvs.Pairs {
+		if enc == nil {
+			return fmt.Errorf("nil price/volume pair at index %d", i)
+		}
+		if err := unmarshalDecimalBinary(&out[i].Price, enc.Price); err != nil {
+			return err
+		}
+		if err := unmarshalDecimalBinary(&out[i].Volume, enc.Volume); err != nil {
+			return err
+		}
+	}
+	*v = out
+	return nil
+}
+
+func (v *PriceVolumes) MarshalText() ([]byte, error) {
+	if v == nil {
+		return nil, ErrNilStreamValue
+	}
+	pairs := make([]string, len(*v))
+	for i, pv := range *v {
+		pairs[i] = fmt.Sprintf("Price: %s, Volume: %s", pv.Price.String(), pv.Volume.String())
+	}
+	return []byte("PV{" + strings.Join(pairs, "; ") + "}"), nil
+}
+
+var priceVolumeRegex = regexp.MustCompile(`^Price: (-?[0-9.]+), Volume: (-?[0-9.]+)$`)
+
+func (v *PriceVolumes) UnmarshalText(data []byte) error {
+	if v == nil {
+		return ErrNilStreamValue
+	}
+
+	s := string(data)
+	if !strings.HasPrefix(s, "PV{") || !strings.HasSuffix(s, "}") {
+		return fmt.Errorf("unexpected input for price/volumes, expected format PV{Price: <price>, Volume: <volume>; ...}, got %s", s)
+	}
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "PV{"), "}")
+	out := PriceVolumes{}
+	if s != "" {
+		for _, pair := range strings.Split(s, "; ") {
+			matches := priceVolumeRegex.FindStringSubmatch(pair)
+			if len(matches) != 3 {
+				return fmt.Errorf("unexpected input for price/volume pair, expected format Price: <price>, Volume: <volume>, got %s", pair)
+			}
+			var pv PriceVolume
+			if err := pv.Price.UnmarshalText([]byte(matches[1])); err != nil {
+				return err
+			}
+			if err := pv.Volume.UnmarshalText([]byte(matches[2])); err != nil {
+				return err
+			}
+			out = append(out, pv)
+		}
+	}
+	*v = out
+	return nil
+}
+
+func (v *PriceVolumes) Type() LLOStreamValue_Type {
+	return LLOStreamValue_PriceVolumes
+}
+
+// VolumeWeightedPrice returns sum(price*volume)/sum(volume). It returns
+// false if any volume is negative or the total volume is zero.
+func (v *PriceVolumes) VolumeWeightedPrice() (decimal.Decimal, bool) {
+	if v == nil {
+		return decimal.Decimal{}, false
+	}
+	var weighted, total decimal.Decimal
+	for _, pv := range *v {
+		if pv.Volume.IsNegative() {
+			return decimal.Decimal{}, false
+		}
+		weighted = weighted.Add(pv.Price.Mul(pv.Volume))
+		total = total.Add(pv.Volume)
+	}
+	if !total.IsPositive() {
+		return decimal.Decimal{}, false
+	}
+	return weighted.Div(total), true
+}
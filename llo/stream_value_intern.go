@@ -0,0 +1,98 @@
+package llo
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// smallIntMax bounds the integers that are interned. Many streams, e.g.
+// market status or counters, only ever have small integer values, which
+// would otherwise allocate a big.Int every time they are decoded.
+const smallIntMax = 1024
+
+// bigIntGobVersion is the version byte that big.Int.GobEncode writes
+const bigIntGobVersion = 1
+
+var (
+	// smallInts[i] is the integer i-smallIntMax. Sharing their coefficients
+	// is safe because decimal.Decimal never mutates them.
+	smallInts [2*smallIntMax + 1]decimal.Decimal
+	// smallIntKeys[i] is the binary encoding of smallInts[i]
+	smallIntKeys [2*smallIntMax + 1]string
+)
+
+func init() {
+	for i := range smallInts {
+		smallInts[i] = decimal.NewFromInt(int64(i - smallIntMax))
+		b, err := smallInts[i].MarshalBinary()
+		if err != nil {
+			panic(fmt.Sprintf("failed to encode small integer %d: %v", i-smallIntMax, err))
+		}
+		smallIntKeys[i] = string(b)
+	}
+}
+
+// smallIntIndex returns the index of d in smallInts, if it is an interned
+// integer. Only integers with exponent 0 are interned, so that re-encoding
+// an interned value yields the same bytes.
+func smallIntIndex(d decimal.Decimal) (int, bool) {
+	// NumDigits is cheap for values that fit in an int64, and bounds the
+	// coefficient so that CoefficientInt64 is exact
+	if d.Exponent() != 0 || d.NumDigits() > 4 {
+		return 0, false
+	}
+	n := d.CoefficientInt64()
+	if n < -smallIntMax || n > smallIntMax {
+		return 0, false
+	}
+	return int(n + smallIntMax), true
+}
+
+// smallIntFromBinary returns the interned integer that data encodes, if
+// any. The binary encoding of a decimal is its exponent as a big-endian
+// int32 followed by the gob encoding of its coefficient: a byte with the
+// version and sign, then the big-endian magnitude.
+func smallIntFromBinary(data []byte) (decimal.Decimal, bool) {
+	if len(data) < 5 || len(data) > 7 || binary.BigEndian.Uint32(data) != 0 || data[4]>>1 != bigIntGobVersion {
+		return decimal.Decimal{}, false
+	}
+	var n int64
+	for _, b := range data[5:] {
+		n = n<<8 | int64(b)
+	}
+	if n > smallIntMax {
+		return decimal.Decimal{}, false
+	}
+	if data[4]&1 == 1 {
+		n = -n
+	}
+	return smallInts[n+smallIntMax], true
+}
+
+// unmarshalDecimalBinary is decimal.Decimal.UnmarshalBinary with a fast path
+// that does not allocate for small integers
+func unmarshalDecimalBinary(d *decimal.Decimal, data []byte) error {
+	if sd, ok := smallIntFromBinary(data); ok {
+		*d = sd
+		return nil
+	}
+	return d.UnmarshalBinary(data)
+}
+
+// streamValueKey returns the binary encoding of a stream value as a string,
+// for comparing values by equality. Small integer decimals use interned
+// keys, so they do not allocate.
+func streamValueKey(sv StreamValue) (string, error) {
+	if d, ok := sv.(*Decimal); ok && d != nil {
+		if i, ok := smallIntIndex(d.Decimal()); ok {
+			return smallIntKeys[i], nil
+		}
+	}
+	b, err := sv.MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
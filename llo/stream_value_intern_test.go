@@ -0,0 +1,123 @@
+package llo
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+func Test_smallIntFromBinary(t *testing.T) {
+	t.Run("decodes small integers like UnmarshalBinary", func(t *testing.T) {
+		for _, n := range []int64{-smallIntMax, -256, -255, -1, 0, 1, 255, 256, smallIntMax} {
+			b, err := decimal.NewFromInt(n).MarshalBinary()
+			require.NoError(t, err)
+			d, ok := smallIntFromBinary(b)
+			require.True(t, ok, n)
+			var expected decimal.Decimal
+			require.NoError(t, expected.UnmarshalBinary(b))
+			assert.True(t, expected.Equal(d), n)
+			assert.Equal(t, int32(0), d.Exponent())
+		}
+	})
+	t.Run("does not intern other values", func(t *testing.T) {
+		for _, d := range []decimal.Decimal{
+			decimal.NewFromInt(smallIntMax + 1),
+			decimal.NewFromInt(-smallIntMax - 1),
+			decimal.NewFromInt(1 << 40),
+			decimal.New(1, -2),
+			decimal.New(1, 2),
+			decimal.RequireFromString("1.00"),
+		} {
+			b, err := d.MarshalBinary()
+			require.NoError(t, err)
+			_, ok := smallIntFromBinary(b)
+			assert.False(t, ok, d.String())
+		}
+		_, ok := smallIntFromBinary([]byte{0, 0, 0})
+		assert.False(t, ok)
+	})
+	t.Run("interned values are not shared mutably", func(t *testing.T) {
+		var d Decimal
+		require.NoError(t, d.UnmarshalBinary([]byte(smallIntKeys[smallIntMax+1])))
+		sum := d.Decimal().Add(decimal.NewFromInt(1))
+		assert.Equal(t, "2", sum.String())
+		assert.Equal(t, "1", smallInts[smallIntMax+1].String())
+
+		// Decoding into an interned value replaces it rather than writing
+		// to its coefficient
+		b, err := decimal.NewFromInt(1 << 40).MarshalBinary()
+		require.NoError(t, err)
+		require.NoError(t, d.UnmarshalBinary(b))
+		assert.Equal(t, "1", smallInts[smallIntMax+1].String())
+	})
+}
+
+func Test_streamValueKey(t *testing.T) {
+	for _, sv := range []StreamValue{
+		ToDecimal(decimal.NewFromInt(7)),
+		ToDecimal(decimal.NewFromInt(-smallIntMax)),
+		ToDecimal(decimal.NewFromInt(1 << 40)),
+		ToDecimal(decimal.RequireFromString("1.5")),
+		&Quote{Bid: decimal.NewFromInt(1), Benchmark: decimal.NewFromInt(2), Ask: decimal.NewFromInt(3)},
+	} {
+		b, err := sv.MarshalBinary()
+		require.NoError(t, err)
+		key, err := streamValueKey(sv)
+		require.NoError(t, err)
+		assert.Equal(t, string(b), key)
+	}
+	_, err := streamValueKey((*Quote)(nil))
+	assert.ErrorIs(t, err, ErrNilStreamValue)
+}
+
+func benchmarkSmallIntValues() (values []StreamValue, encoded [][]byte) {
+	for i := 0; i < benchmarkOracles; i++ {
+		sv := ToDecimal(decimal.NewFromInt(int64(i % 3)))
+		b, err := sv.MarshalBinary()
+		if err != nil {
+			panic(err)
+		}
+		values = append(values, sv)
+		encoded = append(encoded, b)
+	}
+	return values, encoded
+}
+
+func Benchmark_DecimalUnmarshalBinary(b *testing.B) {
+	_, small := benchmarkSmallIntValues()
+	large, err := decimal.RequireFromString("123456789.123456789").MarshalBinary()
+	require.NoError(b, err)
+	b.Run("small integer", func(b *testing.B) {
+		b.ReportAllocs()
+		var d Decimal
+		for i := 0; i < b.N; i++ {
+			if err := d.UnmarshalBinary(small[i%len(small)]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("other", func(b *testing.B) {
+		b.ReportAllocs()
+		var d Decimal
+		for i := 0; i < b.N; i++ {
+			if err := d.UnmarshalBinary(large); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func Benchmark_ModeAggregator(b *testing.B) {
+	values, _ := benchmarkSmallIntValues()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := GetAggregatorFunc(llotypes.AggregatorMode)(values, 1); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package llo
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+// ValuePolicy declares which signs the values of a stream may legally have
+type ValuePolicy string
+
+const (
+	// ValuePolicyAny allows any value, e.g. for funding rates. It is the
+	// default for streams without a policy.
+	ValuePolicyAny ValuePolicy = "any"
+	// ValuePolicyNonNegative allows zero and positive values, e.g. for
+	// volumes or open interest
+	ValuePolicyNonNegative ValuePolicy = "nonNegative"
+	// ValuePolicyPositive only allows positive values, e.g. for spot prices
+	ValuePolicyPositive ValuePolicy = "positive"
+)
+
+// strictness ranks policies, stricter policies ranking higher
+func (vp ValuePolicy) strictness() int {
+	switch vp {
+	case ValuePolicyNonNegative:
+		return 1
+	case ValuePolicyPositive:
+		return 2
+	default:
+		return 0
+	}
+}
+
+func (vp ValuePolicy) validate() error {
+	switch vp {
+	case ValuePolicyAny, ValuePolicyNonNegative, ValuePolicyPositive:
+		return nil
+	default:
+		return fmt.Errorf("unknown value policy: %q", vp)
+	}
+}
+
+// Check returns an error if any price in the stream value is illegal under
+// the policy. Nil values, and volumes, which are never negative, are not
+// checked.
+func (vp ValuePolicy) Check(sv StreamValue) error {
+	if vp.strictness() == 0 {
+		return nil
+	}
+	switch v := sv.(type) {
+	case *Decimal:
+		if v != nil {
+			return vp.check(v.Decimal(), "value")
+		}
+	case *Quote:
+		if v != nil {
+			if err := vp.check(v.Bid, "bid"); err != nil {
+				return err
+			}
+			if err := vp.check(v.Benchmark, "benchmark"); err != nil {
+				return err
+			}
+			return vp.check(v.Ask, "ask")
+		}
+	case *PriceVolumes:
+		if v != nil {
+			for i, pv := range *v {
+				if err := vp.check(pv.Price, fmt.Sprintf("price %d", i)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (vp ValuePolicy) check(d decimal.Decimal, name string) error {
+	if (vp == ValuePolicyPositive && !d.IsPositive()) || (vp == ValuePolicyNonNegative && d.IsNegative()) {
+		return &ValuePolicyViolation{vp, name, d}
+	}
+	return nil
+}
+
+// ValuePolicyViolation is the error returned by ValuePolicy.Check
+type ValuePolicyViolation struct {
+	Policy ValuePolicy
+	// Name of the violating part of the stream value, e.g. "bid"
+	Name  string
+	Value decimal.Decimal
+}
+
+func (v *ValuePolicyViolation) Error() string {
+	return fmt.Sprintf("%s, got: %s", v.redacted(), v.Value)
+}
+
+// redacted describes the violation without quoting the value
+func (v *ValuePolicyViolation) redacted() string {
+	if v.Policy == ValuePolicyPositive {
+		return v.Name + " must be positive"
+	}
+	return v.Name + " must not be negative"
+}
+
+// StreamValuePolicyOpts are channel opts that declare whether zero or
+// negative values of the channel's streams are legal. Illegal observed
+// values are dropped, so that they don't count towards the stream's quorum,
+// and reports with illegal values are not encoded.
+//
+// They may be combined with any codec-specific opts. If several channels
+// declare a policy for the same stream, the strictest one applies to its
+// observations.
+type StreamValuePolicyOpts struct {
+	StreamValuePolicies map[llotypes.StreamID]ValuePolicy `json:"streamValuePolicies,omitempty"`
+}
+
+// ParseStreamValuePolicies extracts the value policy of each stream from a
+// channel's opts, returning nil if none are set. Other fields in the opts
+// are ignored, and opts that are not a JSON object set no policies.
+func ParseStreamValuePolicies(opts llotypes.ChannelOpts) (map[llotypes.StreamID]ValuePolicy, error) {
+	raw, ok := channelOptsField(opts, "streamValuePolicies")
+	if !ok {
+		return nil, nil
+	}
+	var policies map[llotypes.StreamID]ValuePolicy
+	if err := json.Unmarshal(raw, &policies); err != nil {
+		return nil, fmt.Errorf("invalid channel opts: streamValuePolicies: %w", err)
+	}
+	for id, vp := range policies {
+		if err := vp.validate(); err != nil {
+			return nil, fmt.Errorf("invalid channel opts: stream %d: %w", id, err)
+		}
+	}
+	return policies, nil
+}
+
+// VerifyStreamValuePolicies checks that the channel's value policies are
+// known and only set for streams of the channel
+func VerifyStreamValuePolicies(cd llotypes.ChannelDefinition) error {
+	opts := parseChannelOpts(cd)
+	if opts.valuePoliciesErr != nil {
+		return opts.valuePoliciesErr
+	}
+	policies := opts.valuePolicies
+	streams := channelStreams(nil, cd)
+	for id := range policies {
+		found := false
+		for _, strm := range streams {
+			if strm.StreamID == id {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("invalid channel opts: streamValuePolicies set for stream %d, which is not a stream of the channel", id)
+		}
+	}
+	return nil
+}
+
+// StreamValuePolicies returns the strictest value policy that any of the
+// channels declares for each stream. Channels with invalid opts are
+// ignored.
+func StreamValuePolicies(channelDefs llotypes.ChannelDefinitions) map[llotypes.StreamID]ValuePolicy {
+	return streamValuePolicies(nil, channelDefs)
+}
+
+// streamValuePolicies is StreamValuePolicies, with the opts of the channels
+// parsed through cache
+func streamValuePolicies(cache *channelOptsCache, channelDefs llotypes.ChannelDefinitions) map[llotypes.StreamID]ValuePolicy {
+	var policies map[llotypes.StreamID]ValuePolicy
+	for _, cd := range channelDefs {
+		opts := cache.of(cd)
+		if opts.valuePoliciesErr != nil {
+			continue
+		}
+		for id, vp := range opts.valuePolicies {
+			if policies == nil {
+				policies = make(map[llotypes.StreamID]ValuePolicy)
+			}
+			if vp.strictness() > policies[id].strictness() {
+				policies[id] = vp
+			}
+		}
+	}
+	return policies
+}
+
+// checkChannelValuePolicies checks the aggregated values of the channel's
+// streams against the channel's own value policies
+func checkChannelValuePolicies(cache *channelOptsCache, cd llotypes.ChannelDefinition, aggregates StreamAggregates) error {
+	opts := cache.of(cd)
+	if opts.valuePoliciesErr != nil || len(opts.valuePolicies) == 0 {
+		return opts.valuePoliciesErr
+	}
+	policies := opts.valuePolicies
+	for _, strm := range channelStreams(cache, cd) {
+		if err := policies[strm.StreamID].Check(aggregates[strm.StreamID][strm.Aggregator]); err != nil {
+			return fmt.Errorf("stream %d violates its value policy: %w", strm.StreamID, err)
+		}
+	}
+	return nil
+}
+
+// dropValuePolicyViolations removes the values that violate the policy of
+// their stream from streamValues, calling onViolation for each of them
+func dropValuePolicyViolations(streamValues StreamValues, policies map[llotypes.StreamID]ValuePolicy, onViolation func(llotypes.StreamID, ValuePolicy, error)) {
+	for id, vp := range policies {
+		sv, exists := streamValues[id]
+		if !exists {
+			continue
+		}
+		if err := vp.Check(sv); err != nil {
+			delete(streamValues, id)
+			onViolation(id, vp, err)
+		}
+	}
+}
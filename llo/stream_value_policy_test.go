@@ -0,0 +1,101 @@
+package llo
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+func Test_ParseStreamValuePolicies(t *testing.T) {
+	policies, err := ParseStreamValuePolicies(nil)
+	require.NoError(t, err)
+	assert.Nil(t, policies)
+
+	policies, err = ParseStreamValuePolicies([]byte(`{"streamValuePolicies":{"1":"positive","2":"any"},"foo":"bar"}`))
+	require.NoError(t, err)
+	assert.Equal(t, map[llotypes.StreamID]ValuePolicy{1: ValuePolicyPositive, 2: ValuePolicyAny}, policies)
+
+	_, err = ParseStreamValuePolicies([]byte(`{"streamValuePolicies":{"1":"strictlyPositive"}}`))
+	assert.EqualError(t, err, `invalid channel opts: stream 1: unknown value policy: "strictlyPositive"`)
+	_, err = ParseStreamValuePolicies([]byte(`{"streamValuePolicies":"positive"}`))
+	assert.ErrorContains(t, err, "invalid channel opts: streamValuePolicies: ")
+
+	t.Run("opts without policies set none", func(t *testing.T) {
+		for _, opts := range []string{`{"foo":"bar"}`, `not json`, `["streamValuePolicies"]`} {
+			policies, err := ParseStreamValuePolicies([]byte(opts))
+			require.NoError(t, err, opts)
+			assert.Nil(t, policies, opts)
+		}
+	})
+}
+
+func Test_VerifyStreamValuePolicies(t *testing.T) {
+	cd := llotypes.ChannelDefinition{
+		Streams: []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}},
+		Opts:    []byte(`{"streamValuePolicies":{"1":"positive","2":"positive"}}`),
+	}
+	assert.EqualError(t, VerifyStreamValuePolicies(cd), "invalid channel opts: streamValuePolicies set for stream 2, which is not a stream of the channel")
+
+	// fee streams are streams of the channel too
+	cd.Opts = []byte(`{"streamValuePolicies":{"1":"positive","2":"positive"},"nativeFeeStreamID":2,"linkFeeStreamID":3}`)
+	require.NoError(t, VerifyStreamValuePolicies(cd))
+
+	err := VerifyChannelDefinitions(llotypes.ChannelDefinitions{1: {
+		ReportFormat: llotypes.ReportFormatJSON,
+		Streams:      cd.Streams,
+		Opts:         []byte(`{"streamValuePolicies":{"1":"negative"}}`),
+	}})
+	assert.EqualError(t, err, `invalid ChannelDefinition with ID 1: invalid channel opts: stream 1: unknown value policy: "negative"`)
+}
+
+func Test_ValuePolicy_Check(t *testing.T) {
+	d := func(f float64) decimal.Decimal { return decimal.NewFromFloat(f) }
+
+	t.Run("any", func(t *testing.T) {
+		assert.NoError(t, ValuePolicyAny.Check(ToDecimal(d(-1))))
+		assert.NoError(t, ValuePolicy("").Check(&Quote{Bid: d(-1), Benchmark: d(-1), Ask: d(-1)}))
+	})
+	t.Run("nonNegative", func(t *testing.T) {
+		assert.NoError(t, ValuePolicyNonNegative.Check(ToDecimal(d(0))))
+		assert.EqualError(t, ValuePolicyNonNegative.Check(ToDecimal(d(-0.5))), "value must not be negative, got: -0.5")
+		assert.EqualError(t, ValuePolicyNonNegative.Check(&Quote{Bid: d(-1), Benchmark: d(1), Ask: d(2)}), "bid must not be negative, got: -1")
+	})
+	t.Run("positive", func(t *testing.T) {
+		assert.NoError(t, ValuePolicyPositive.Check(&Quote{Bid: d(1), Benchmark: d(1.5), Ask: d(2)}))
+		assert.EqualError(t, ValuePolicyPositive.Check(&Quote{Bid: d(1), Benchmark: d(1.5), Ask: d(0)}), "ask must be positive, got: 0")
+		assert.EqualError(t, ValuePolicyPositive.Check(&PriceVolumes{{Price: d(1), Volume: d(0)}, {Price: d(0), Volume: d(1)}}), "price 1 must be positive, got: 0")
+		// nil values are missing rather than illegal
+		assert.NoError(t, ValuePolicyPositive.Check(nil))
+		assert.NoError(t, ValuePolicyPositive.Check((*Decimal)(nil)))
+	})
+}
+
+func Test_StreamValuePolicies(t *testing.T) {
+	policies := StreamValuePolicies(llotypes.ChannelDefinitions{
+		1: {Opts: []byte(`{"streamValuePolicies":{"1":"nonNegative","2":"any"}}`)},
+		2: {Opts: []byte(`{"streamValuePolicies":{"1":"positive","2":"nonNegative"}}`)},
+		3: {Opts: []byte(`{"streamValuePolicies":{"3":"bogus"}}`)},
+		4: {Opts: []byte(`{"streamValuePolicies":{"2":"any"}}`)},
+	})
+	assert.Equal(t, map[llotypes.StreamID]ValuePolicy{1: ValuePolicyPositive, 2: ValuePolicyNonNegative}, policies)
+}
+
+func Test_dropValuePolicyViolations(t *testing.T) {
+	sv := StreamValues{
+		1: ToDecimal(decimal.NewFromInt(-1)),
+		2: ToDecimal(decimal.NewFromInt(-1)),
+		3: ToDecimal(decimal.NewFromInt(1)),
+	}
+	var dropped []llotypes.StreamID
+	dropValuePolicyViolations(sv, map[llotypes.StreamID]ValuePolicy{1: ValuePolicyPositive, 2: ValuePolicyAny, 3: ValuePolicyPositive, 4: ValuePolicyPositive}, func(id llotypes.StreamID, vp ValuePolicy, err error) {
+		assert.Equal(t, ValuePolicyPositive, vp)
+		assert.EqualError(t, err, "value must be positive, got: -1")
+		dropped = append(dropped, id)
+	})
+	assert.Equal(t, []llotypes.StreamID{1}, dropped)
+	assert.Equal(t, StreamValues{2: ToDecimal(decimal.NewFromInt(-1)), 3: ToDecimal(decimal.NewFromInt(1))}, sv)
+}
@@ -0,0 +1,61 @@
+package llo
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+// TransmissionFilter selects which of the accepted reports this node
+// transmits, e.g. so that an operator can skip JSON debug reports or
+// staging reports. It only affects this node: reports are still generated
+// and attested by all oracles. Retirement reports are always transmitted,
+// since the successor protocol instance depends on them. The zero value
+// transmits every report.
+type TransmissionFilter struct {
+	// ReportFormats to transmit. If empty, reports of all formats are
+	// transmitted.
+	ReportFormats []llotypes.ReportFormat
+	// LifeCycleStages to transmit. If empty, reports of all life cycle
+	// stages are transmitted.
+	LifeCycleStages []llotypes.LifeCycleStage
+}
+
+// ParseTransmissionFilter parses a filter from the report formats and life
+// cycle stages named in the node's config, e.g. "evm_premium_legacy" and
+// "production"
+func ParseTransmissionFilter(reportFormats, lifeCycleStages []string) (f TransmissionFilter, err error) {
+	for _, s := range reportFormats {
+		rf, err := llotypes.ReportFormatFromString(strings.TrimSpace(s))
+		if err != nil {
+			return f, fmt.Errorf("invalid transmission filter: %w", err)
+		}
+		f.ReportFormats = append(f.ReportFormats, rf)
+	}
+	for _, s := range lifeCycleStages {
+		stage := llotypes.LifeCycleStage(strings.TrimSpace(s))
+		switch stage {
+		case LifeCycleStageStaging, LifeCycleStageProduction, LifeCycleStageRetired:
+		default:
+			return f, fmt.Errorf("invalid transmission filter: unknown life cycle stage: %q", s)
+		}
+		f.LifeCycleStages = append(f.LifeCycleStages, stage)
+	}
+	return f, nil
+}
+
+// Allows returns true if reports with the given info should be transmitted
+func (f TransmissionFilter) Allows(info llotypes.ReportInfo) bool {
+	if info.ReportFormat == llotypes.ReportFormatRetirement {
+		return true
+	}
+	if len(f.ReportFormats) > 0 && !slices.Contains(f.ReportFormats, info.ReportFormat) {
+		return false
+	}
+	if len(f.LifeCycleStages) > 0 && !slices.Contains(f.LifeCycleStages, info.LifeCycleStage) {
+		return false
+	}
+	return true
+}
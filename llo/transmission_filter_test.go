@@ -0,0 +1,87 @@
+package llo
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+func Test_ParseTransmissionFilter(t *testing.T) {
+	f, err := ParseTransmissionFilter(nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, TransmissionFilter{}, f)
+
+	f, err = ParseTransmissionFilter([]string{"evm_premium_legacy", " json "}, []string{"production"})
+	require.NoError(t, err)
+	assert.Equal(t, TransmissionFilter{
+		ReportFormats:   []llotypes.ReportFormat{llotypes.ReportFormatEVMPremiumLegacy, llotypes.ReportFormatJSON},
+		LifeCycleStages: []llotypes.LifeCycleStage{LifeCycleStageProduction},
+	}, f)
+
+	_, err = ParseTransmissionFilter([]string{"xml"}, nil)
+	assert.EqualError(t, err, `invalid transmission filter: unknown report format: "xml"`)
+	_, err = ParseTransmissionFilter(nil, []string{"prod"})
+	assert.EqualError(t, err, `invalid transmission filter: unknown life cycle stage: "prod"`)
+}
+
+func Test_TransmissionFilter(t *testing.T) {
+	premium := llotypes.ReportInfo{LifeCycleStage: LifeCycleStageProduction, ReportFormat: llotypes.ReportFormatEVMPremiumLegacy}
+	json := llotypes.ReportInfo{LifeCycleStage: LifeCycleStageProduction, ReportFormat: llotypes.ReportFormatJSON}
+	staging := llotypes.ReportInfo{LifeCycleStage: LifeCycleStageStaging, ReportFormat: llotypes.ReportFormatEVMPremiumLegacy}
+	retirement := llotypes.ReportInfo{LifeCycleStage: LifeCycleStageRetired, ReportFormat: llotypes.ReportFormatRetirement}
+
+	t.Run("zero value allows everything", func(t *testing.T) {
+		var f TransmissionFilter
+		for _, info := range []llotypes.ReportInfo{premium, json, staging, retirement} {
+			assert.True(t, f.Allows(info), info)
+		}
+	})
+	t.Run("filters by report format", func(t *testing.T) {
+		f := TransmissionFilter{ReportFormats: []llotypes.ReportFormat{llotypes.ReportFormatEVMPremiumLegacy}}
+		assert.True(t, f.Allows(premium))
+		assert.False(t, f.Allows(json))
+		assert.True(t, f.Allows(staging))
+	})
+	t.Run("filters by life cycle stage", func(t *testing.T) {
+		f := TransmissionFilter{LifeCycleStages: []llotypes.LifeCycleStage{LifeCycleStageProduction}}
+		assert.True(t, f.Allows(premium))
+		assert.True(t, f.Allows(json))
+		assert.False(t, f.Allows(staging))
+	})
+	t.Run("always allows retirement reports", func(t *testing.T) {
+		f := TransmissionFilter{ReportFormats: []llotypes.ReportFormat{llotypes.ReportFormatJSON}, LifeCycleStages: []llotypes.LifeCycleStage{LifeCycleStageProduction}}
+		assert.True(t, f.Allows(retirement))
+	})
+}
+
+func Test_Plugin_ShouldTransmitAcceptedReport_TransmissionFilter(t *testing.T) {
+	ctx := tests.Context(t)
+	lt, err := NewLastTransmissions(logger.Test(t), NewFileLastTransmissionStore(filepath.Join(t.TempDir(), "last_transmissions.json")))
+	require.NoError(t, err)
+	p := &Plugin{
+		ConfigDigest:       types.ConfigDigest{2},
+		LastTransmissions:  lt,
+		TransmissionFilter: TransmissionFilter{ReportFormats: []llotypes.ReportFormat{llotypes.ReportFormatEVMPremiumLegacy}},
+	}
+	filtered := promFilteredTransmissionsTotal.WithLabelValues(p.ConfigDigest.String(), llotypes.ReportFormatJSON.String(), string(LifeCycleStageProduction))
+	before := testutil.ToFloat64(filtered)
+
+	ok, err := p.ShouldTransmitAcceptedReport(ctx, 1, ocr3types.ReportWithInfo[llotypes.ReportInfo]{
+		Report: []byte(`{"ChannelID":1}`),
+		Info:   llotypes.ReportInfo{LifeCycleStage: LifeCycleStageProduction, ReportFormat: llotypes.ReportFormatJSON},
+	})
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, before+1, testutil.ToFloat64(filtered))
+	assert.Empty(t, lt.Get(), "filtered reports are not recorded as transmitted")
+}
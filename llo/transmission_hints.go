@@ -0,0 +1,59 @@
+package llo
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+// TransmissionHintsOpts are channel opts that tell the transmitter how
+// urgent the channel's reports are, so that it can reorder its queue when
+// it falls behind. They may be combined with any codec-specific opts.
+type TransmissionHintsOpts struct {
+	// TransmissionPriority of the channel's reports; higher are transmitted
+	// first. Defaults to 0. Specimen reports never have a priority above the
+	// default, so that they cannot delay reports that are verified onchain.
+	TransmissionPriority int32 `json:"transmissionPriority,omitempty"`
+	// TransmissionDeadlineSeconds is how long after its observations
+	// timestamp a report should be transmitted. Among reports of the same
+	// priority, the one with the earliest deadline is transmitted first.
+	// Zero means no deadline.
+	TransmissionDeadlineSeconds uint32 `json:"transmissionDeadlineSeconds,omitempty"`
+}
+
+// ParseTransmissionHints extracts the transmission hints from a channel's
+// opts, returning the zero value if none are set. Other fields in the opts
+// are ignored.
+func ParseTransmissionHints(opts llotypes.ChannelOpts) (TransmissionHintsOpts, error) {
+	var o TransmissionHintsOpts
+	if len(opts) == 0 {
+		return o, nil
+	}
+	if err := json.Unmarshal(opts, &o); err != nil {
+		return o, fmt.Errorf("invalid channel opts: %w", err)
+	}
+	return o, nil
+}
+
+// TransmissionHints tell the transmitter how urgent a report is
+type TransmissionHints struct {
+	// Priority of the report; higher is transmitted first
+	Priority int32
+	// Deadline by which the report should be transmitted, or zero if it has
+	// none
+	Deadline time.Time
+}
+
+// hints returns the hints of a report of the channel
+func (o TransmissionHintsOpts) hints(r Report) TransmissionHints {
+	h := TransmissionHints{Priority: o.TransmissionPriority}
+	if r.Specimen {
+		h.Priority = min(h.Priority, 0)
+	}
+	if o.TransmissionDeadlineSeconds > 0 {
+		h.Deadline = time.Unix(int64(r.ObservationTimestampSeconds)+int64(o.TransmissionDeadlineSeconds), 0)
+	}
+	return h
+}
@@ -0,0 +1,76 @@
+package llo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+func Test_ParseTransmissionHints(t *testing.T) {
+	o, err := ParseTransmissionHints(nil)
+	require.NoError(t, err)
+	assert.Equal(t, TransmissionHintsOpts{}, o)
+
+	o, err = ParseTransmissionHints(llotypes.ChannelOpts(`{"transmissionPriority":-2,"transmissionDeadlineSeconds":5,"feedID":"0x01"}`))
+	require.NoError(t, err)
+	assert.Equal(t, TransmissionHintsOpts{TransmissionPriority: -2, TransmissionDeadlineSeconds: 5}, o)
+
+	_, err = ParseTransmissionHints(llotypes.ChannelOpts(`{"transmissionDeadlineSeconds":-1}`))
+	assert.ErrorContains(t, err, "invalid channel opts")
+}
+
+func Test_TransmissionHintsOpts_hints(t *testing.T) {
+	r := Report{ObservationTimestampSeconds: 1000}
+	assert.Equal(t, TransmissionHints{}, TransmissionHintsOpts{}.hints(r))
+	assert.Equal(t, TransmissionHints{Priority: 3, Deadline: time.Unix(1005, 0)}, TransmissionHintsOpts{TransmissionPriority: 3, TransmissionDeadlineSeconds: 5}.hints(r))
+
+	t.Run("specimen reports are not prioritized", func(t *testing.T) {
+		r.Specimen = true
+		assert.Equal(t, TransmissionHints{}, TransmissionHintsOpts{TransmissionPriority: 3}.hints(r))
+		assert.Equal(t, TransmissionHints{Priority: -1}, TransmissionHintsOpts{TransmissionPriority: -1}.hints(r))
+	})
+}
+
+func Test_Reports_TransmissionHints(t *testing.T) {
+	ctx := tests.Context(t)
+	p := &Plugin{
+		ConfigDigest: types.ConfigDigest{4},
+		OutcomeCodec: protoOutcomeCodec{},
+		Logger:       logger.Test(t),
+		ReportCodecs: map[llotypes.ReportFormat]ReportCodec{
+			llotypes.ReportFormatJSON: JSONReportCodec{},
+		},
+		TransmissionTargets: NewTransmissionTargets(),
+	}
+	streams := []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}}
+	encoded, err := p.OutcomeCodec.Encode(Outcome{
+		LifeCycleStage:                   LifeCycleStageProduction,
+		ObservationsTimestampNanoseconds: int64(200 * time.Second),
+		ValidAfterSeconds:                map[llotypes.ChannelID]uint32{1: 100, 2: 100, 3: 100},
+		ChannelDefinitions: llotypes.ChannelDefinitions{
+			1: {ReportFormat: llotypes.ReportFormatJSON, Streams: streams, Opts: []byte(`{"transmissionPriority":1,"transmissionDeadlineSeconds":2,"transmissionTargets":["premium"]}`)},
+			2: {ReportFormat: llotypes.ReportFormatJSON, Streams: streams},
+			3: {ReportFormat: llotypes.ReportFormatJSON, Streams: streams, Opts: []byte(`{"transmissionPriority":"high"}`)},
+		},
+		StreamAggregates: StreamAggregates{1: {llotypes.AggregatorMedian: ToDecimal(decimal.NewFromFloat(1.1))}},
+	})
+	require.NoError(t, err)
+
+	rwis, err := p.Reports(ctx, 2, encoded)
+	require.NoError(t, err)
+	require.Len(t, rwis, 3)
+	assert.Equal(t, TransmissionHints{Priority: 1, Deadline: time.Unix(202, 0)}, p.TransmissionTargets.Hints(p.ConfigDigest, 2, rwis[0].ReportWithInfo.Report))
+	assert.Equal(t, []string{"premium"}, p.TransmissionTargets.Get(p.ConfigDigest, 2, rwis[0].ReportWithInfo.Report))
+	assert.Equal(t, TransmissionHints{}, p.TransmissionTargets.Hints(p.ConfigDigest, 2, rwis[1].ReportWithInfo.Report))
+	// invalid hints fall back to the defaults
+	assert.Equal(t, TransmissionHints{}, p.TransmissionTargets.Hints(p.ConfigDigest, 2, rwis[2].ReportWithInfo.Report))
+}
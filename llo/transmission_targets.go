@@ -0,0 +1,172 @@
+package llo
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+// TransmissionTargetsOpts are channel opts that select which of the
+// transmitter's configured servers the channel's reports are sent to, e.g.
+// so that premium and public feeds can be served by different
+// infrastructure. They may be combined with any codec-specific opts.
+type TransmissionTargetsOpts struct {
+	// TransmissionTargets name the servers to send reports to. If empty,
+	// reports are sent to the transmitter's default servers.
+	TransmissionTargets []string `json:"transmissionTargets,omitempty"`
+}
+
+// ParseTransmissionTargets extracts the transmission targets from a
+// channel's opts, returning nil if none are set. Other fields in the opts
+// are ignored.
+func ParseTransmissionTargets(opts llotypes.ChannelOpts) ([]string, error) {
+	if len(opts) == 0 {
+		return nil, nil
+	}
+	var o TransmissionTargetsOpts
+	if err := json.Unmarshal(opts, &o); err != nil {
+		return nil, fmt.Errorf("invalid channel opts: %w", err)
+	}
+	seen := make(map[string]struct{}, len(o.TransmissionTargets))
+	for _, target := range o.TransmissionTargets {
+		if target == "" {
+			return nil, fmt.Errorf("invalid channel opts: empty transmission target")
+		}
+		if _, exists := seen[target]; exists {
+			return nil, fmt.Errorf("invalid channel opts: duplicate transmission target: %q", target)
+		}
+		seen[target] = struct{}{}
+	}
+	return o.TransmissionTargets, nil
+}
+
+// transmissionTargetsRetention is the number of sequence numbers for which
+// TransmissionTargets remembers what is known about a report. OCR transmits
+// reports within a few rounds of generating them, so this is generous.
+const transmissionTargetsRetention = 100
+
+// TransmissionTargets remembers the transmission targets, hints, timestamps
+// and confidence of the reports generated by Plugin.Reports, so that the node's
+// transmitter can look them up when the reports are transmitted. ReportInfo
+// is shared with other products and cannot carry them.
+//
+// A single TransmissionTargets may be shared by plugins for several config
+// digests.
+type TransmissionTargets struct {
+	mu sync.Mutex
+	// config digest => seqNr => report hash => transmission
+	transmissions map[types.ConfigDigest]map[uint64]map[[32]byte]*reportTransmission
+}
+
+// reportTransmission is what is known about the transmission of a report
+type reportTransmission struct {
+	targets           []string
+	hints             TransmissionHints
+	timestamps        ReportTimestamps
+	reducedConfidence bool
+}
+
+func NewTransmissionTargets() *TransmissionTargets {
+	return &TransmissionTargets{transmissions: make(map[types.ConfigDigest]map[uint64]map[[32]byte]*reportTransmission)}
+}
+
+// Get returns the transmission targets of a report, or nil if it should be
+// sent to the default targets
+func (t *TransmissionTargets) Get(digest types.ConfigDigest, seqNr uint64, report types.Report) []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if rt := t.transmissions[digest][seqNr][sha256.Sum256(report)]; rt != nil {
+		return rt.targets
+	}
+	return nil
+}
+
+// Hints returns the transmission hints of a report, or the zero value if
+// it has none
+func (t *TransmissionTargets) Hints(digest types.ConfigDigest, seqNr uint64, report types.Report) TransmissionHints {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if rt := t.transmissions[digest][seqNr][sha256.Sum256(report)]; rt != nil {
+		return rt.hints
+	}
+	return TransmissionHints{}
+}
+
+// Timestamps returns the timestamps of the stages that a report passed
+// through in the plugin, or the zero value if they are unknown
+func (t *TransmissionTargets) Timestamps(digest types.ConfigDigest, seqNr uint64, report types.Report) ReportTimestamps {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if rt := t.transmissions[digest][seqNr][sha256.Sum256(report)]; rt != nil {
+		return rt.timestamps
+	}
+	return ReportTimestamps{}
+}
+
+// ReducedConfidence returns whether a report is marked ReducedConfidence.
+// The transmitter should pass it on with the report, since not every
+// report format can encode it (e.g. EVM reports).
+func (t *TransmissionTargets) ReducedConfidence(digest types.ConfigDigest, seqNr uint64, report types.Report) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if rt := t.transmissions[digest][seqNr][sha256.Sum256(report)]; rt != nil {
+		return rt.reducedConfidence
+	}
+	return false
+}
+
+func (t *TransmissionTargets) set(digest types.ConfigDigest, seqNr uint64, report types.Report, targets []string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.transmission(digest, seqNr, report).targets = targets
+}
+
+func (t *TransmissionTargets) setHints(digest types.ConfigDigest, seqNr uint64, report types.Report, hints TransmissionHints) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.transmission(digest, seqNr, report).hints = hints
+}
+
+func (t *TransmissionTargets) setReducedConfidence(digest types.ConfigDigest, seqNr uint64, report types.Report) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.transmission(digest, seqNr, report).reducedConfidence = true
+}
+
+func (t *TransmissionTargets) setTimestamps(digest types.ConfigDigest, seqNr uint64, report types.Report, timestamps ReportTimestamps) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.transmission(digest, seqNr, report).timestamps = timestamps
+}
+
+// transmission returns the transmission of a report, adding it if it does
+// not exist yet. t.mu must be held.
+func (t *TransmissionTargets) transmission(digest types.ConfigDigest, seqNr uint64, report types.Report) *reportTransmission {
+	bySeqNr, exists := t.transmissions[digest]
+	if !exists {
+		bySeqNr = make(map[uint64]map[[32]byte]*reportTransmission)
+		t.transmissions[digest] = bySeqNr
+	}
+	byReport, exists := bySeqNr[seqNr]
+	if !exists {
+		byReport = make(map[[32]byte]*reportTransmission)
+		bySeqNr[seqNr] = byReport
+		for s := range bySeqNr {
+			if s+transmissionTargetsRetention <= seqNr {
+				delete(bySeqNr, s)
+			}
+		}
+	}
+	h := sha256.Sum256(report)
+	rt, exists := byReport[h]
+	if !exists {
+		rt = &reportTransmission{}
+		byReport[h] = rt
+	}
+	return rt
+}
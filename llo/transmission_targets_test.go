@@ -0,0 +1,57 @@
+package llo
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+func Test_ParseTransmissionTargets(t *testing.T) {
+	targets, err := ParseTransmissionTargets(nil)
+	require.NoError(t, err)
+	assert.Nil(t, targets)
+
+	targets, err = ParseTransmissionTargets(llotypes.ChannelOpts(`{"feedID":"0x01"}`))
+	require.NoError(t, err)
+	assert.Nil(t, targets)
+
+	targets, err = ParseTransmissionTargets(llotypes.ChannelOpts(`{"transmissionTargets":["premium","public"]}`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"premium", "public"}, targets)
+
+	_, err = ParseTransmissionTargets(llotypes.ChannelOpts(`{"transmissionTargets":["premium",""]}`))
+	assert.EqualError(t, err, "invalid channel opts: empty transmission target")
+	_, err = ParseTransmissionTargets(llotypes.ChannelOpts(`{"transmissionTargets":["premium","premium"]}`))
+	assert.EqualError(t, err, `invalid channel opts: duplicate transmission target: "premium"`)
+	_, err = ParseTransmissionTargets(llotypes.ChannelOpts(`{"transmissionTargets":"premium"}`))
+	assert.ErrorContains(t, err, "invalid channel opts: json: cannot unmarshal string")
+}
+
+func Test_TransmissionTargets(t *testing.T) {
+	tt := NewTransmissionTargets()
+	cd1, cd2 := types.ConfigDigest{1}, types.ConfigDigest{2}
+
+	tt.set(cd1, 1, types.Report("a"), []string{"premium"})
+	tt.set(cd1, 1, types.Report("b"), []string{"public"})
+	tt.set(cd2, 1, types.Report("a"), []string{"public"})
+
+	assert.Equal(t, []string{"premium"}, tt.Get(cd1, 1, types.Report("a")))
+	assert.Equal(t, []string{"public"}, tt.Get(cd1, 1, types.Report("b")))
+	assert.Equal(t, []string{"public"}, tt.Get(cd2, 1, types.Report("a")))
+	assert.Nil(t, tt.Get(cd1, 2, types.Report("a")))
+	assert.Nil(t, tt.Get(cd1, 1, types.Report("c")))
+
+	t.Run("forgets old reports", func(t *testing.T) {
+		tt.set(cd1, transmissionTargetsRetention, types.Report("c"), []string{"premium"})
+		assert.Equal(t, []string{"premium"}, tt.Get(cd1, 1, types.Report("a")))
+		tt.set(cd1, transmissionTargetsRetention+1, types.Report("c"), []string{"premium"})
+		assert.Nil(t, tt.Get(cd1, 1, types.Report("a")))
+		assert.Equal(t, []string{"premium"}, tt.Get(cd1, transmissionTargetsRetention, types.Report("c")))
+		// other config digests are unaffected
+		assert.Equal(t, []string{"public"}, tt.Get(cd2, 1, types.Report("a")))
+	})
+}
@@ -0,0 +1,100 @@
+package llo
+
+import (
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+)
+
+// ValidAfterSecondsPolicy decides how the validity ranges of consecutive
+// reports on a channel are chained. A report covers observations after its
+// validAfterSeconds, up to and including its observationsTimestampSeconds.
+//
+// The policy is part of the consensus logic, so it is selected by the
+// OffchainConfig that all oracles of a protocol instance share (see
+// OffchainConfig.ValidAfterSecondsPolicy). Policies must be deterministic.
+type ValidAfterSecondsPolicy interface {
+	// Next returns the channel's validAfterSeconds in the next outcome.
+	// reported is true if the previous outcome reported on the channel,
+	// covering previousValidAfterSeconds to
+	// previousObservationsTimestampSeconds.
+	Next(channelID llotypes.ChannelID, previousValidAfterSeconds, previousObservationsTimestampSeconds uint32, reported bool) uint32
+	// New returns the validAfterSeconds of a channel that is added in an
+	// outcome with the given observations timestamp
+	New(channelID llotypes.ChannelID, observationsTimestampSeconds uint32) uint32
+}
+
+var (
+	_ ValidAfterSecondsPolicy = GaplessValidAfterSecondsPolicy{}
+	_ ValidAfterSecondsPolicy = FixedOverlapValidAfterSecondsPolicy{}
+	_ ValidAfterSecondsPolicy = NewChannelGracePeriodPolicy{}
+)
+
+// GaplessValidAfterSecondsPolicy is the default policy: each report is valid
+// from where the previous report ended, so that reports on a channel never
+// leave gaps and never overlap (except for reports within the same second).
+// New channels are valid from the time they are added.
+type GaplessValidAfterSecondsPolicy struct{}
+
+func (GaplessValidAfterSecondsPolicy) Next(_ llotypes.ChannelID, previousValidAfterSeconds, previousObservationsTimestampSeconds uint32, reported bool) uint32 {
+	if !reported {
+		// previous outcome did not report; keep the same validAfterSeconds
+		return previousValidAfterSeconds
+	}
+	// previous outcome reported; continue from its observations timestamp
+	return previousObservationsTimestampSeconds
+}
+
+func (GaplessValidAfterSecondsPolicy) New(_ llotypes.ChannelID, observationsTimestampSeconds uint32) uint32 {
+	return observationsTimestampSeconds
+}
+
+// FixedOverlapValidAfterSecondsPolicy is like GaplessValidAfterSecondsPolicy,
+// but each report overlaps the previous report by up to OverlapSeconds, e.g.
+// for consumers that tolerate clock skew by requiring overlapping ranges. A
+// report never starts before the previous one.
+type FixedOverlapValidAfterSecondsPolicy struct {
+	OverlapSeconds uint32
+}
+
+func (p FixedOverlapValidAfterSecondsPolicy) Next(channelID llotypes.ChannelID, previousValidAfterSeconds, previousObservationsTimestampSeconds uint32, reported bool) uint32 {
+	next := GaplessValidAfterSecondsPolicy{}.Next(channelID, previousValidAfterSeconds, previousObservationsTimestampSeconds, reported)
+	if !reported {
+		return next
+	}
+	return max(saturatingSub(next, p.OverlapSeconds), previousValidAfterSeconds)
+}
+
+func (FixedOverlapValidAfterSecondsPolicy) New(channelID llotypes.ChannelID, observationsTimestampSeconds uint32) uint32 {
+	return GaplessValidAfterSecondsPolicy{}.New(channelID, observationsTimestampSeconds)
+}
+
+// NewChannelGracePeriodPolicy makes the first report of a new channel valid
+// from GracePeriodSeconds before the channel was added, so that consumers
+// can use it for requests that were made while the channel was being rolled
+// out. Channels that already exist are chained by the embedded policy, or
+// by GaplessValidAfterSecondsPolicy if it is nil.
+type NewChannelGracePeriodPolicy struct {
+	ValidAfterSecondsPolicy
+	GracePeriodSeconds uint32
+}
+
+func (p NewChannelGracePeriodPolicy) Next(channelID llotypes.ChannelID, previousValidAfterSeconds, previousObservationsTimestampSeconds uint32, reported bool) uint32 {
+	return p.policy().Next(channelID, previousValidAfterSeconds, previousObservationsTimestampSeconds, reported)
+}
+
+func (p NewChannelGracePeriodPolicy) New(channelID llotypes.ChannelID, observationsTimestampSeconds uint32) uint32 {
+	return saturatingSub(p.policy().New(channelID, observationsTimestampSeconds), p.GracePeriodSeconds)
+}
+
+func (p NewChannelGracePeriodPolicy) policy() ValidAfterSecondsPolicy {
+	if p.ValidAfterSecondsPolicy == nil {
+		return GaplessValidAfterSecondsPolicy{}
+	}
+	return p.ValidAfterSecondsPolicy
+}
+
+func saturatingSub(a, b uint32) uint32 {
+	if b > a {
+		return 0
+	}
+	return a - b
+}
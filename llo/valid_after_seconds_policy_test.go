@@ -0,0 +1,96 @@
+package llo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+)
+
+func Test_ValidAfterSecondsPolicies(t *testing.T) {
+	t.Run("GaplessValidAfterSecondsPolicy", func(t *testing.T) {
+		var p GaplessValidAfterSecondsPolicy
+		assert.Equal(t, uint32(200), p.Next(1, 100, 200, true))
+		assert.Equal(t, uint32(100), p.Next(1, 100, 200, false))
+		assert.Equal(t, uint32(300), p.New(1, 300))
+	})
+	t.Run("FixedOverlapValidAfterSecondsPolicy", func(t *testing.T) {
+		p := FixedOverlapValidAfterSecondsPolicy{OverlapSeconds: 5}
+		assert.Equal(t, uint32(195), p.Next(1, 100, 200, true))
+		assert.Equal(t, uint32(100), p.Next(1, 100, 200, false))
+		// never starts before the previous report
+		assert.Equal(t, uint32(198), p.Next(1, 198, 200, true))
+		assert.Equal(t, uint32(0), FixedOverlapValidAfterSecondsPolicy{OverlapSeconds: 500}.Next(1, 0, 200, true))
+		assert.Equal(t, uint32(300), p.New(1, 300))
+	})
+	t.Run("NewChannelGracePeriodPolicy", func(t *testing.T) {
+		p := NewChannelGracePeriodPolicy{GracePeriodSeconds: 60}
+		assert.Equal(t, uint32(200), p.Next(1, 100, 200, true))
+		assert.Equal(t, uint32(240), p.New(1, 300))
+		assert.Equal(t, uint32(0), p.New(1, 30))
+
+		p.ValidAfterSecondsPolicy = FixedOverlapValidAfterSecondsPolicy{OverlapSeconds: 5}
+		assert.Equal(t, uint32(195), p.Next(1, 100, 200, true))
+		assert.Equal(t, uint32(240), p.New(1, 300))
+	})
+}
+
+func Test_Outcome_ValidAfterSecondsPolicy(t *testing.T) {
+	ctx := tests.Context(t)
+	definitions := llotypes.ChannelDefinitions{
+		1: {ReportFormat: llotypes.ReportFormatJSON, Streams: []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}}},
+		2: {ReportFormat: llotypes.ReportFormatJSON, Streams: []llotypes.Stream{{StreamID: 1, Aggregator: llotypes.AggregatorMedian}}},
+	}
+	previousOutcome := Outcome{
+		LifeCycleStage:                   LifeCycleStageProduction,
+		ObservationsTimestampNanoseconds: int64(200 * time.Second),
+		ChannelDefinitions:               definitions,
+		// channel 2 is new
+		ValidAfterSeconds: map[llotypes.ChannelID]uint32{1: 100},
+		StreamAggregates: map[llotypes.StreamID]map[llotypes.Aggregator]StreamValue{
+			1: {llotypes.AggregatorMedian: ToDecimal(decimal.NewFromInt(1))},
+		},
+	}
+
+	outcome := func(t *testing.T, cfg OffchainConfig) Outcome {
+		p := &Plugin{
+			Config:           Config{true},
+			OutcomeCodec:     protoOutcomeCodec{},
+			ObservationCodec: protoObservationCodec{},
+			Logger:           logger.Test(t),
+			F:                1,
+			OffchainConfig:   cfg,
+		}
+		encodedPreviousOutcome, err := p.OutcomeCodec.Encode(previousOutcome)
+		require.NoError(t, err)
+		var aos []types.AttributedObservation
+		for i := 0; i < 3; i++ {
+			encoded, err2 := p.ObservationCodec.Encode(Observation{UnixTimestampNanoseconds: int64(300 * time.Second)})
+			require.NoError(t, err2)
+			aos = append(aos, types.AttributedObservation{Observation: encoded, Observer: commontypes.OracleID(i)})
+		}
+		encoded, err := p.Outcome(ctx, ocr3types.OutcomeContext{SeqNr: 3, PreviousOutcome: encodedPreviousOutcome}, nil, aos)
+		require.NoError(t, err)
+		decoded, err := p.OutcomeCodec.Decode(encoded)
+		require.NoError(t, err)
+		return decoded
+	}
+
+	t.Run("defaults to gapless", func(t *testing.T) {
+		assert.Equal(t, map[llotypes.ChannelID]uint32{1: 200, 2: 300}, outcome(t, OffchainConfig{}).ValidAfterSeconds)
+	})
+	t.Run("uses the policy of the offchain config", func(t *testing.T) {
+		cfg := OffchainConfig{ValidAfterSecondsOverlapSeconds: 10, NewChannelGracePeriodSeconds: 60}
+		assert.Equal(t, map[llotypes.ChannelID]uint32{1: 190, 2: 240}, outcome(t, cfg).ValidAfterSeconds)
+	})
+}
@@ -0,0 +1,41 @@
+package rpc
+
+import (
+	"hash/fnv"
+	"math"
+
+	"google.golang.org/grpc"
+)
+
+// CanaryConfig configures a secondary server that receives a copy of a
+// fraction of reports, to validate a new server deployment with real
+// traffic before cutting over to it.
+type CanaryConfig struct {
+	// ServerURL identifies the canary server in metrics
+	ServerURL string
+	// Conn is the connection to the canary server
+	Conn grpc.ClientConnInterface
+	// Fraction of reports, between 0 and 1, that are also transmitted to the
+	// canary. Values outside this range are clamped.
+	Fraction float64
+}
+
+// canarySampler selects reports for the canary by their idempotency key.
+// Every node of a DON derives the same key for a report, so all nodes select
+// the same reports and the canary receives the same deliveries for them as
+// the primary does.
+type canarySampler struct {
+	fraction float64
+}
+
+func (s canarySampler) sample(idempotencyKey string) bool {
+	switch {
+	case s.fraction <= 0:
+		return false
+	case s.fraction >= 1:
+		return true
+	}
+	h := fnv.New64a()
+	h.Write([]byte(idempotencyKey))
+	return float64(h.Sum64()) < s.fraction*math.MaxUint64
+}
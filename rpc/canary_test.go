@@ -0,0 +1,100 @@
+package rpc
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+)
+
+func Test_canarySampler(t *testing.T) {
+	keys := make([]string, 10_000)
+	for i := range keys {
+		keys[i] = IdempotencyKey([]byte(fmt.Sprintf("report %d", i)), 1)
+	}
+	count := func(s canarySampler) (n int) {
+		for _, k := range keys {
+			if s.sample(k) {
+				n++
+			}
+		}
+		return n
+	}
+
+	assert.Zero(t, count(canarySampler{0}))
+	assert.Zero(t, count(canarySampler{-1}))
+	assert.Equal(t, len(keys), count(canarySampler{1}))
+	assert.Equal(t, len(keys), count(canarySampler{2}))
+	assert.InDelta(t, len(keys)/10, count(canarySampler{0.1}), float64(len(keys))/100)
+
+	s := canarySampler{0.5}
+	for _, k := range keys[:100] {
+		assert.Equal(t, s.sample(k), s.sample(k), "sampling is deterministic")
+	}
+}
+
+func Test_Client_Canary(t *testing.T) {
+	ctx := tests.Context(t)
+
+	t.Run("mirrors sampled reports to the canary", func(t *testing.T) {
+		primary, canary := &mockConn{}, &mockConn{}
+		c := NewClient(logger.Test(t), primary, ClientConfig{
+			ServerURL: "canary-primary.example",
+			Canary:    &CanaryConfig{ServerURL: "canary-canary.example", Conn: canary, Fraction: 0.5},
+		})
+		require.NoError(t, c.Start(ctx))
+		t.Cleanup(func() { assert.NoError(t, c.Close()) })
+
+		var sampled []string
+		for i := 0; i < 20; i++ {
+			req := &TransmitRequest{Payload: []byte(fmt.Sprintf("report %d", i))}
+			if i%2 == 0 {
+				c.Enqueue(req)
+			} else {
+				_, err := c.Transmit(ctx, req)
+				require.NoError(t, err)
+			}
+			if c.canarySampler.sample(req.IdempotencyKey) {
+				sampled = append(sampled, req.IdempotencyKey)
+			}
+		}
+		require.NotEmpty(t, sampled)
+
+		require.Eventually(t, func() bool { return len(primary.getReceived()) == 20 }, tests.WaitTimeout(t), 10*time.Millisecond)
+		require.Eventually(t, func() bool { return len(canary.getReceived()) == len(sampled) }, tests.WaitTimeout(t), 10*time.Millisecond)
+		var received []string
+		for _, req := range canary.getReceived() {
+			received = append(received, req.IdempotencyKey)
+		}
+		assert.ElementsMatch(t, sampled, received)
+
+		health := c.HealthReport()
+		assert.NoError(t, health[c.Name()])
+		assert.NoError(t, health[c.Name()+"Canary"])
+	})
+
+	t.Run("canary failures do not affect the primary", func(t *testing.T) {
+		primary := &mockConn{}
+		canary := &mockConn{responses: []error{status.Error(codes.Unavailable, "down"), status.Error(codes.Unavailable, "down")}}
+		c := NewClient(logger.Test(t), primary, ClientConfig{
+			ServerURL: "canary-failing-primary.example",
+			Canary:    &CanaryConfig{ServerURL: "canary-failing-canary.example", Conn: canary, Fraction: 1},
+		})
+		require.NoError(t, c.Start(ctx))
+		t.Cleanup(func() { assert.NoError(t, c.Close()) })
+
+		c.Enqueue(&TransmitRequest{Payload: []byte("report 1")})
+		c.Enqueue(&TransmitRequest{Payload: []byte("report 2")})
+
+		require.Eventually(t, func() bool { return len(primary.getReceived()) == 2 }, tests.WaitTimeout(t), 10*time.Millisecond)
+		// the canary retries until it recovers
+		require.Eventually(t, func() bool { return len(canary.getReceived()) == 2 }, tests.WaitTimeout(t), 10*time.Millisecond)
+	})
+}
@@ -0,0 +1,378 @@
+package rpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	_ "google.golang.org/grpc/encoding/gzip" // register the gzip compressor
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+)
+
+const (
+	// DefaultMaxQueueSize is the number of reports that can be waiting for
+	// transmission before the oldest are dropped
+	DefaultMaxQueueSize = 10_000
+
+	minRetryBackoff = 100 * time.Millisecond
+	maxRetryBackoff = 10 * time.Second
+
+	metricsUpdateInterval = time.Second
+	serverInfoTimeout     = 10 * time.Second
+)
+
+// IdempotencyKey deterministically derives the idempotency key for a
+// transmission, so that retries of the same report (even across node
+// restarts) always map to the same key.
+func IdempotencyKey(payload []byte, reportFormat uint32) string {
+	h := sha256.New()
+	var rf [4]byte
+	binary.BigEndian.PutUint32(rf[:], reportFormat)
+	h.Write(rf[:])
+	h.Write(payload)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+var _ TransmitterClient = (*Client)(nil)
+
+type ClientConfig struct {
+	// ServerURL identifies the server in metrics
+	ServerURL string
+	// MaxQueueSize bounds the number of reports waiting to be transmitted.
+	// Defaults to DefaultMaxQueueSize if zero.
+	MaxQueueSize int
+	// Canary optionally mirrors a fraction of reports to a second server
+	Canary *CanaryConfig
+	// Compressor optionally names a gRPC compressor, e.g. "gzip", to compress
+	// transmissions with. It is only used once the server has advertised
+	// support for it through ServerInfo.
+	Compressor string
+}
+
+// Client wraps a TransmitterClient and ensures that every transmission
+// carries an idempotency key, so that its delivery can later be confirmed
+// using TransmissionStatus.
+//
+// All requests are instrumented with Prometheus metrics. Reports passed to
+// Enqueue are transmitted in the background, retrying on transient failures,
+// once the client is started.
+//
+// On start, the client queries ServerInfo, warning if the server's schema
+// revision differs from SchemaRevision, and adapts to the server's
+// capabilities: transmissions are only compressed if the server supports the
+// compressor, and queued reports that exceed the server's request size limit
+// are dropped instead of being retried forever.
+//
+// If a canary is configured, sampled reports are also transmitted to the
+// canary server through a separate queue, so that failures of the canary
+// never delay transmission to the primary.
+type Client struct {
+	services.StateMachine
+	TransmitterClient
+
+	lggr       logger.Logger
+	serverURL  string
+	compressor string
+	queue      *transmitQueue
+	serverInfo atomic.Pointer[ServerInfoResponse]
+
+	canary        *Client
+	canarySampler canarySampler
+
+	stopCh services.StopChan
+	wg     sync.WaitGroup
+}
+
+func NewClient(lggr logger.Logger, cc grpc.ClientConnInterface, cfg ClientConfig) *Client {
+	maxQueueSize := cfg.MaxQueueSize
+	if maxQueueSize <= 0 {
+		maxQueueSize = DefaultMaxQueueSize
+	}
+	c := &Client{
+		TransmitterClient: NewTransmitterClient(&instrumentedConn{cc, cfg.ServerURL}),
+		lggr:              logger.With(logger.Named(lggr, "TransmitterClient"), "serverURL", cfg.ServerURL),
+		serverURL:         cfg.ServerURL,
+		compressor:        cfg.Compressor,
+		queue:             newTransmitQueue(cfg.ServerURL, maxQueueSize),
+		stopCh:            make(services.StopChan),
+	}
+	if cfg.Canary != nil && cfg.Canary.Conn != nil {
+		c.canary = NewClient(lggr, cfg.Canary.Conn, ClientConfig{ServerURL: cfg.Canary.ServerURL, MaxQueueSize: maxQueueSize, Compressor: cfg.Compressor})
+		c.canarySampler = canarySampler{cfg.Canary.Fraction}
+	}
+	return c
+}
+
+func (c *Client) Name() string { return c.lggr.Name() }
+
+func (c *Client) Start(context.Context) error {
+	return c.StartOnce("TransmitterClient", func() error {
+		if c.canary != nil {
+			if err := c.canary.Start(context.Background()); err != nil {
+				return fmt.Errorf("failed to start canary client: %w", err)
+			}
+			c.lggr.Infow("Transmitting a fraction of reports to canary", "canaryServerURL", c.canary.serverURL, "fraction", c.canarySampler.fraction)
+		}
+		c.wg.Add(3)
+		go c.runQueueLoop()
+		go c.runMetricsLoop()
+		go c.checkServerInfo()
+		return nil
+	})
+}
+
+func (c *Client) Close() error {
+	return c.StopOnce("TransmitterClient", func() error {
+		close(c.stopCh)
+		c.wg.Wait()
+		promQueueDepth.DeleteLabelValues(c.serverURL)
+		promQueueOldestAge.DeleteLabelValues(c.serverURL)
+		if c.canary != nil {
+			return c.canary.Close()
+		}
+		return nil
+	})
+}
+
+func (c *Client) HealthReport() map[string]error {
+	report := map[string]error{c.Name(): c.Healthy()}
+	if c.canary != nil {
+		// the canary client has the same name as the primary
+		report[c.Name()+"Canary"] = c.canary.Healthy()
+	}
+	return report
+}
+
+// Enqueue schedules a report for transmission. If the queue is full, the
+// oldest unsent report of the lowest priority is dropped.
+func (c *Client) Enqueue(req *TransmitRequest) {
+	c.EnqueueContext(context.Background(), req)
+}
+
+// EnqueueContext is Enqueue for callers that trace reports: the transmission
+// is linked to the span in ctx, and its latency metrics carry the span's
+// trace ID as an exemplar. The report is queued according to the
+// TransmitHints in ctx, if any (see WithTransmitHints). ctx is not used for
+// cancellation.
+func (c *Client) EnqueueContext(ctx context.Context, req *TransmitRequest) {
+	if req.IdempotencyKey == "" {
+		req.IdempotencyKey = IdempotencyKey(req.Payload, req.ReportFormat)
+	}
+	if evicted := c.queue.push(&queueItem{req: req, enqueuedAt: time.Now(), spanContext: trace.SpanContextFromContext(ctx), hints: transmitHintsFromContext(ctx)}); evicted != nil {
+		c.lggr.Warnw("Transmit queue full, dropped oldest report of the lowest priority", "idempotencyKey", evicted.req.IdempotencyKey, "enqueuedAt", evicted.enqueuedAt, "priority", evicted.hints.Priority)
+	}
+	c.mirrorToCanary(ctx, req)
+}
+
+// mirrorToCanary enqueues the report for the canary if it is sampled
+func (c *Client) mirrorToCanary(ctx context.Context, req *TransmitRequest) {
+	if c.canary != nil && c.canarySampler.sample(req.IdempotencyKey) {
+		c.canary.EnqueueContext(ctx, req)
+	}
+}
+
+func (c *Client) runQueueLoop() {
+	defer c.wg.Done()
+	ctx, cancel := c.stopCh.NewCtx()
+	defer cancel()
+
+	backoff := minRetryBackoff
+	for {
+		item := c.queue.pop()
+		if item == nil {
+			select {
+			case <-c.queue.notify:
+				continue
+			case <-c.stopCh:
+				return
+			}
+		}
+
+		if err := c.checkRequestSize(item.req); err != nil {
+			c.drop(item, codes.ResourceExhausted, "Dropping report that the server would not accept", err)
+			continue
+		}
+
+		// Reports were already mirrored to the canary when enqueued
+		tctx := withSpanContext(ctx, item.spanContext)
+		res, err := c.TransmitterClient.Transmit(tctx, withTransmitTimestamp(item.req, time.Now()), c.callOptions()...)
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil && codes.Code(res.Code) != codes.Unavailable {
+			if res.Code != 0 {
+				c.drop(item, codes.Code(res.Code), "Transmit rejected by server", errors.New(res.Error))
+			} else {
+				observeWithTraceExemplar(tctx, promTransmissionLatency.WithLabelValues(c.serverURL), time.Since(item.enqueuedAt).Seconds())
+			}
+			backoff = minRetryBackoff
+			continue
+		}
+		if code := status.Code(err); err != nil && !isRetryable(code) {
+			c.drop(item, code, "Transmit failed with an error that retrying would not fix", err)
+			backoff = minRetryBackoff
+			continue
+		}
+
+		if err == nil {
+			err = fmt.Errorf("server unavailable: %s", res.Error)
+		}
+		c.lggr.Warnw("Transmit failed, will retry", "idempotencyKey", item.req.IdempotencyKey, "backoff", backoff, "err", err)
+		if evicted := c.queue.pushFront(item); evicted != nil {
+			c.lggr.Warnw("Transmit queue full, dropped oldest report of the lowest priority", "idempotencyKey", evicted.req.IdempotencyKey, "enqueuedAt", evicted.enqueuedAt, "priority", evicted.hints.Priority)
+		}
+		select {
+		case <-time.After(backoff):
+		case <-c.stopCh:
+			return
+		}
+		backoff = min(2*backoff, maxRetryBackoff)
+	}
+}
+
+// isRetryable returns false for the gRPC codes with which the server would
+// reject the same request again, so retrying would only hold up the queue
+func isRetryable(code codes.Code) bool {
+	switch code {
+	case codes.InvalidArgument, codes.PermissionDenied, codes.Unauthenticated:
+		return false
+	default:
+		return true
+	}
+}
+
+// drop gives up on a report without retrying it
+func (c *Client) drop(item *queueItem, code codes.Code, msg string, err error) {
+	promDroppedTotal.WithLabelValues(c.serverURL, code.String()).Inc()
+	c.lggr.Errorw(msg, "idempotencyKey", item.req.IdempotencyKey, "code", code, "err", err)
+}
+
+func (c *Client) runMetricsLoop() {
+	defer c.wg.Done()
+	ticker := time.NewTicker(metricsUpdateInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			c.queue.updateMetrics(now)
+		case <-c.stopCh:
+			return
+		}
+	}
+}
+
+// checkServerInfo records the server's info and warns if the server was
+// built against a different schema revision than the client
+func (c *Client) checkServerInfo() {
+	defer c.wg.Done()
+	ctx, cancel := c.stopCh.CtxWithTimeout(serverInfoTimeout)
+	defer cancel()
+
+	res, err := c.TransmitterClient.ServerInfo(ctx, &ServerInfoRequest{})
+	switch {
+	case status.Code(err) == codes.Unimplemented:
+		// The server predates ServerInfo, i.e. revision 0 without any
+		// advertised capabilities
+		res = &ServerInfoResponse{}
+	case err != nil:
+		if ctx.Err() == nil {
+			c.lggr.Warnw("Failed to get server info", "err", err)
+		}
+		return
+	}
+	c.serverInfo.Store(res)
+	c.lggr.Infow("Got server info", "schemaRevision", res.SchemaRevision, "version", res.Version, "reportFormats", res.ReportFormats, "compressors", res.Compressors, "limits", res.Limits)
+	if c.compressor != "" && !slices.Contains(res.Compressors, c.compressor) {
+		c.lggr.Warnw("Server does not support the configured compressor, transmitting uncompressed", "compressor", c.compressor)
+	}
+	if err := CheckSchemaRevision(res.SchemaRevision); err != nil {
+		c.lggr.Warnw("Server schema revision differs from client", "err", err)
+		return
+	}
+	c.lggr.Debugw("Server schema revision matches client", "schemaRevision", res.SchemaRevision)
+}
+
+// ServerCapabilities returns the ServerInfo obtained when the client was
+// started, or nil if it is not known (yet)
+func (c *Client) ServerCapabilities() *ServerInfoResponse {
+	return c.serverInfo.Load()
+}
+
+// SupportsReportFormat returns false only if the server is known not to
+// accept the report format
+func (c *Client) SupportsReportFormat(reportFormat uint32) bool {
+	info := c.serverInfo.Load()
+	return info == nil || len(info.ReportFormats) == 0 || slices.Contains(info.ReportFormats, reportFormat)
+}
+
+// callOptions returns the options for transmissions, which depend on the
+// capabilities of the server
+func (c *Client) callOptions() []grpc.CallOption {
+	info := c.serverInfo.Load()
+	if c.compressor == "" || info == nil || !slices.Contains(info.Compressors, c.compressor) {
+		return nil
+	}
+	return []grpc.CallOption{grpc.UseCompressor(c.compressor)}
+}
+
+// checkRequestSize returns an error if the request exceeds the server's
+// size limit
+func (c *Client) checkRequestSize(req *TransmitRequest) error {
+	limit := c.serverInfo.Load().GetLimits().GetMaxRequestSize()
+	if limit == 0 {
+		return nil
+	}
+	if size := proto.Size(req); uint64(size) > limit {
+		return fmt.Errorf("request of %d bytes exceeds the server's limit of %d bytes", size, limit)
+	}
+	return nil
+}
+
+// Transmit sends the request to the server, populating IdempotencyKey if it
+// was not already set. If the report is sampled for the canary, it is also
+// enqueued for transmission to the canary.
+func (c *Client) Transmit(ctx context.Context, in *TransmitRequest, opts ...grpc.CallOption) (*TransmitResponse, error) {
+	if in.IdempotencyKey == "" {
+		in.IdempotencyKey = IdempotencyKey(in.Payload, in.ReportFormat)
+	}
+	c.mirrorToCanary(ctx, in)
+	return c.TransmitterClient.Transmit(ctx, in, append(c.callOptions(), opts...)...)
+}
+
+// WaitForDelivery polls the server until the transmission with the given
+// idempotency key has either been persisted or rejected. It returns an error
+// if the context expires first.
+func (c *Client) WaitForDelivery(ctx context.Context, idempotencyKey string, pollInterval time.Duration) (*TransmissionStatusResponse, error) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		res, err := c.TransmissionStatus(ctx, &TransmissionStatusRequest{IdempotencyKey: idempotencyKey})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query transmission status: %w", err)
+		}
+		switch res.Status {
+		case TransmissionStatusResponse_Persisted, TransmissionStatusResponse_Rejected:
+			return res, nil
+		default:
+		}
+		select {
+		case <-ctx.Done():
+			return res, fmt.Errorf("transmission %s was not delivered (last status: %s): %w", idempotencyKey, res.Status, context.Cause(ctx))
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+)
+
+func Test_IdempotencyKey(t *testing.T) {
+	k := IdempotencyKey([]byte("report"), 1)
+	assert.Len(t, k, 64)
+	assert.Equal(t, k, IdempotencyKey([]byte("report"), 1))
+	assert.NotEqual(t, k, IdempotencyKey([]byte("report"), 2))
+	assert.NotEqual(t, k, IdempotencyKey([]byte("other report"), 1))
+}
+
+type statusServer struct {
+	UnimplementedTransmitterServer
+
+	mu       sync.Mutex
+	received []*TransmitRequest
+	polls    int
+}
+
+func (s *statusServer) Transmit(_ context.Context, req *TransmitRequest) (*TransmitResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.received = append(s.received, req)
+	return &TransmitResponse{}, nil
+}
+
+func (s *statusServer) TransmissionStatus(_ context.Context, req *TransmissionStatusRequest) (*TransmissionStatusResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.polls++
+	switch {
+	case req.IdempotencyKey == "rejected":
+		return &TransmissionStatusResponse{Status: TransmissionStatusResponse_Rejected, Reason: "bad report"}, nil
+	case req.IdempotencyKey == "persisted" && s.polls > 2:
+		return &TransmissionStatusResponse{Status: TransmissionStatusResponse_Persisted}, nil
+	case req.IdempotencyKey == "persisted":
+		return &TransmissionStatusResponse{Status: TransmissionStatusResponse_Received}, nil
+	default:
+		return &TransmissionStatusResponse{Status: TransmissionStatusResponse_Unknown}, nil
+	}
+}
+
+func Test_Client(t *testing.T) {
+	srv := &statusServer{}
+	s := grpc.NewServer()
+	RegisterTransmitterServer(s, srv)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() {
+		_ = s.Serve(lis)
+	}()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	c := NewClient(logger.Test(t), conn, ClientConfig{ServerURL: lis.Addr().String()})
+
+	t.Run("Transmit populates idempotency key", func(t *testing.T) {
+		ctx := tests.Context(t)
+		_, err := c.Transmit(ctx, &TransmitRequest{Payload: []byte("report"), ReportFormat: 1})
+		require.NoError(t, err)
+		_, err = c.Transmit(ctx, &TransmitRequest{Payload: []byte("report"), ReportFormat: 1, IdempotencyKey: "custom"})
+		require.NoError(t, err)
+
+		srv.mu.Lock()
+		defer srv.mu.Unlock()
+		require.Len(t, srv.received, 2)
+		assert.Equal(t, IdempotencyKey([]byte("report"), 1), srv.received[0].IdempotencyKey)
+		assert.Equal(t, "custom", srv.received[1].IdempotencyKey)
+	})
+	t.Run("WaitForDelivery returns on terminal status", func(t *testing.T) {
+		ctx := tests.Context(t)
+		res, err := c.WaitForDelivery(ctx, "rejected", time.Millisecond)
+		require.NoError(t, err)
+		assert.Equal(t, TransmissionStatusResponse_Rejected, res.Status)
+		assert.Equal(t, "bad report", res.Reason)
+
+		res, err = c.WaitForDelivery(ctx, "persisted", time.Millisecond)
+		require.NoError(t, err)
+		assert.Equal(t, TransmissionStatusResponse_Persisted, res.Status)
+	})
+	t.Run("WaitForDelivery errors if context expires first", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(tests.Context(t), 20*time.Millisecond)
+		defer cancel()
+		_, err := c.WaitForDelivery(ctx, "unknown", time.Millisecond)
+		require.Error(t, err)
+	})
+}
+
+func Test_Client_ServerCapabilities(t *testing.T) {
+	t.Run("unknown until the server info is received", func(t *testing.T) {
+		c := NewClient(logger.Test(t), &mockConn{}, ClientConfig{ServerURL: "capabilities-unknown.example", Compressor: "gzip"})
+		assert.Nil(t, c.ServerCapabilities())
+		assert.True(t, c.SupportsReportFormat(1))
+		assert.Empty(t, c.callOptions())
+		require.NoError(t, c.checkRequestSize(&TransmitRequest{Payload: make([]byte, 1024)}))
+	})
+	t.Run("adapts to the server", func(t *testing.T) {
+		conn := &serverInfoConn{info: &ServerInfoResponse{
+			SchemaRevision: SchemaRevision,
+			ReportFormats:  []uint32{1, 2},
+			Compressors:    []string{"gzip"},
+			Limits:         &ServerLimits{MaxRequestSize: 100},
+		}}
+		c := NewClient(logger.Test(t), conn, ClientConfig{ServerURL: "capabilities.example", Compressor: "gzip"})
+		require.NoError(t, c.Start(tests.Context(t)))
+		t.Cleanup(func() { assert.NoError(t, c.Close()) })
+		require.Eventually(t, func() bool { return c.ServerCapabilities() != nil }, tests.WaitTimeout(t), 10*time.Millisecond)
+
+		assert.True(t, c.SupportsReportFormat(2))
+		assert.False(t, c.SupportsReportFormat(3))
+		assert.Len(t, c.callOptions(), 1)
+
+		require.NoError(t, c.checkRequestSize(&TransmitRequest{Payload: []byte("report")}))
+		require.ErrorContains(t, c.checkRequestSize(&TransmitRequest{Payload: make([]byte, 100)}), "exceeds the server's limit of 100 bytes")
+
+		// oversized reports are dropped instead of retried
+		c.Enqueue(&TransmitRequest{Payload: make([]byte, 100)})
+		c.Enqueue(&TransmitRequest{Payload: []byte("report")})
+		require.Eventually(t, func() bool { return len(conn.getReceived()) == 1 }, tests.WaitTimeout(t), 10*time.Millisecond)
+		assert.Equal(t, []byte("report"), conn.getReceived()[0].Payload)
+		assert.Zero(t, c.queue.len())
+	})
+	t.Run("does not compress if the server lacks the compressor", func(t *testing.T) {
+		conn := &serverInfoConn{info: &ServerInfoResponse{SchemaRevision: SchemaRevision}}
+		c := NewClient(logger.Test(t), conn, ClientConfig{ServerURL: "capabilities-uncompressed.example", Compressor: "gzip"})
+		require.NoError(t, c.Start(tests.Context(t)))
+		t.Cleanup(func() { assert.NoError(t, c.Close()) })
+		require.Eventually(t, func() bool { return c.ServerCapabilities() != nil }, tests.WaitTimeout(t), 10*time.Millisecond)
+		assert.Empty(t, c.callOptions())
+	})
+}
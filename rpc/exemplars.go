@@ -0,0 +1,34 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traceIDExemplarLabel is the exemplar label that dashboards (e.g. Grafana)
+// follow to the trace of an observation
+const traceIDExemplarLabel = "trace_id"
+
+// observeWithTraceExemplar records v, attaching the trace ID of the span in
+// ctx as an exemplar if it is sampled. Without OTel tracing, contexts carry
+// no valid span and this is equivalent to o.Observe(v).
+func observeWithTraceExemplar(ctx context.Context, o prometheus.Observer, v float64) {
+	sc := trace.SpanContextFromContext(ctx)
+	if eo, ok := o.(prometheus.ExemplarObserver); ok && sc.IsValid() && sc.IsSampled() {
+		eo.ObserveWithExemplar(v, prometheus.Labels{traceIDExemplarLabel: sc.TraceID().String()})
+		return
+	}
+	o.Observe(v)
+}
+
+// withSpanContext returns a context carrying sc, e.g. the span of the report
+// that is being transmitted in the background, so that exemplars and
+// outgoing requests are linked to its trace
+func withSpanContext(ctx context.Context, sc trace.SpanContext) context.Context {
+	if !sc.IsValid() {
+		return ctx
+	}
+	return trace.ContextWithRemoteSpanContext(ctx, sc)
+}
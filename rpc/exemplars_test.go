@@ -0,0 +1,78 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+)
+
+func exemplars(t *testing.T, h prometheus.Histogram) (labels []map[string]string) {
+	var m dto.Metric
+	require.NoError(t, h.Write(&m))
+	for _, b := range m.GetHistogram().GetBucket() {
+		if e := b.GetExemplar(); e != nil {
+			l := map[string]string{}
+			for _, lp := range e.GetLabel() {
+				l[lp.GetName()] = lp.GetValue()
+			}
+			labels = append(labels, l)
+		}
+	}
+	return labels
+}
+
+func Test_observeWithTraceExemplar(t *testing.T) {
+	newHistogram := func() prometheus.Histogram {
+		return prometheus.NewHistogram(prometheus.HistogramOpts{Name: "test", Buckets: []float64{1, 10}})
+	}
+	traceID := trace.TraceID{1, 2, 3}
+	spanContext := func(flags trace.TraceFlags) trace.SpanContext {
+		return trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: trace.SpanID{4}, TraceFlags: flags})
+	}
+
+	t.Run("attaches the trace ID of a sampled span", func(t *testing.T) {
+		h := newHistogram()
+		ctx := withSpanContext(context.Background(), spanContext(trace.FlagsSampled))
+		observeWithTraceExemplar(ctx, h, 0.5)
+		assert.Equal(t, []map[string]string{{"trace_id": traceID.String()}}, exemplars(t, h))
+	})
+	t.Run("observes without exemplar if the span is not sampled", func(t *testing.T) {
+		h := newHistogram()
+		ctx := withSpanContext(context.Background(), spanContext(0))
+		observeWithTraceExemplar(ctx, h, 0.5)
+		assert.Empty(t, exemplars(t, h))
+		var m dto.Metric
+		require.NoError(t, h.Write(&m))
+		assert.Equal(t, uint64(1), m.GetHistogram().GetSampleCount())
+	})
+	t.Run("observes without exemplar if tracing is disabled", func(t *testing.T) {
+		h := newHistogram()
+		ctx := withSpanContext(context.Background(), trace.SpanContext{})
+		observeWithTraceExemplar(ctx, h, 0.5)
+		assert.Empty(t, exemplars(t, h))
+	})
+}
+
+func Test_Client_TraceExemplars(t *testing.T) {
+	serverURL := "exemplars.example"
+	conn := &mockConn{}
+	c := NewClient(logger.Test(t), conn, ClientConfig{ServerURL: serverURL})
+	traceID := trace.TraceID{5, 6, 7}
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: trace.SpanID{8}, TraceFlags: trace.FlagsSampled}))
+
+	c.EnqueueContext(ctx, &TransmitRequest{Payload: []byte("traced report")})
+	item := c.queue.pop()
+	require.NotNil(t, item)
+	assert.Equal(t, traceID, item.spanContext.TraceID(), "span is carried through the queue")
+
+	_, err := c.Transmit(withSpanContext(context.Background(), item.spanContext), item.req)
+	require.NoError(t, err)
+	assert.Contains(t, exemplars(t, promRequestDuration.WithLabelValues(serverURL, "Transmit").(prometheus.Histogram)), map[string]string{"trace_id": traceID.String()})
+}
@@ -0,0 +1,120 @@
+package rpc
+
+import (
+	"context"
+	"path"
+	"runtime/debug"
+	"time"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+)
+
+const (
+	// DefaultMaxRecvMsgSize fits the largest report the LLO plugin can
+	// generate, plus headroom for its signatures and the other fields of the
+	// request
+	DefaultMaxRecvMsgSize = ocr3types.MaxMaxReportLength + 64*1024
+	// DefaultKeepaliveMinTime is the most frequent client keepalive ping
+	// that the server tolerates before closing the connection
+	DefaultKeepaliveMinTime = 5 * time.Second
+	// DefaultKeepaliveTime is the time after which the server pings an idle
+	// client to check that the connection is still alive
+	DefaultKeepaliveTime = 30 * time.Second
+	// DefaultKeepaliveTimeout is the time the server waits for a ping
+	// response before closing the connection
+	DefaultKeepaliveTimeout = 20 * time.Second
+)
+
+// GRPCServerConfig configures NewGRPCServer. Zero values select the defaults.
+type GRPCServerConfig struct {
+	MaxRecvMsgSize   int
+	KeepaliveMinTime time.Duration
+	KeepaliveTime    time.Duration
+	KeepaliveTimeout time.Duration
+	// DisableReflection disables the gRPC reflection service, which is
+	// otherwise registered so that tools like grpcurl can inspect the server
+	DisableReflection bool
+}
+
+// NewGRPCServer creates a gRPC server for the Transmitter service with
+// consistent defaults:
+//
+//   - the gRPC reflection service
+//   - keepalive enforcement, which permits pings without active streams
+//     since transmitter clients keep idle connections open
+//   - a receive limit that fits the largest report
+//   - an interceptor that turns panics in handlers into Internal errors,
+//     instead of crashing the server
+//
+// Additional options, e.g. transport credentials, are applied after the
+// defaults and take precedence.
+func NewGRPCServer(lggr logger.Logger, srv TransmitterServer, cfg GRPCServerConfig, opts ...grpc.ServerOption) *grpc.Server {
+	if cfg.MaxRecvMsgSize <= 0 {
+		cfg.MaxRecvMsgSize = DefaultMaxRecvMsgSize
+	}
+	if cfg.KeepaliveMinTime <= 0 {
+		cfg.KeepaliveMinTime = DefaultKeepaliveMinTime
+	}
+	if cfg.KeepaliveTime <= 0 {
+		cfg.KeepaliveTime = DefaultKeepaliveTime
+	}
+	if cfg.KeepaliveTimeout <= 0 {
+		cfg.KeepaliveTimeout = DefaultKeepaliveTimeout
+	}
+
+	lggr = logger.Named(lggr, "TransmitterGRPCServer")
+	defaults := []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(cfg.MaxRecvMsgSize),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             cfg.KeepaliveMinTime,
+			PermitWithoutStream: true,
+		}),
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    cfg.KeepaliveTime,
+			Timeout: cfg.KeepaliveTimeout,
+		}),
+		grpc.ChainUnaryInterceptor(recoveryUnaryInterceptor(lggr)),
+		grpc.ChainStreamInterceptor(recoveryStreamInterceptor(lggr)),
+	}
+	s := grpc.NewServer(append(defaults, opts...)...)
+	RegisterTransmitterServer(s, srv)
+	if !cfg.DisableReflection {
+		reflection.Register(s)
+	}
+	return s
+}
+
+func recoveryUnaryInterceptor(lggr logger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(lggr, info.FullMethod, r)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+func recoveryStreamInterceptor(lggr logger.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(lggr, info.FullMethod, r)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+func recovered(lggr logger.Logger, method string, r any) error {
+	promServerPanicsTotal.WithLabelValues(path.Base(method)).Inc()
+	lggr.Errorw("Recovered from panic in handler", "method", method, "panic", r, "stack", string(debug.Stack()))
+	return status.Error(codes.Internal, "internal error")
+}
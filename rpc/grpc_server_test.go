@@ -0,0 +1,69 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+)
+
+type panickingServer struct {
+	statusServer
+}
+
+func (s *panickingServer) LatestReport(context.Context, *LatestReportRequest) (*LatestReportResponse, error) {
+	panic("boom")
+}
+
+func Test_NewGRPCServer(t *testing.T) {
+	t.Run("registers reflection unless disabled", func(t *testing.T) {
+		s := NewGRPCServer(logger.Test(t), &statusServer{}, GRPCServerConfig{})
+		info := s.GetServiceInfo()
+		assert.Contains(t, info, Transmitter_ServiceDesc.ServiceName)
+		assert.Contains(t, info, "grpc.reflection.v1.ServerReflection")
+
+		s = NewGRPCServer(logger.Test(t), &statusServer{}, GRPCServerConfig{DisableReflection: true})
+		info = s.GetServiceInfo()
+		assert.Contains(t, info, Transmitter_ServiceDesc.ServiceName)
+		assert.NotContains(t, info, "grpc.reflection.v1.ServerReflection")
+	})
+
+	t.Run("recovers from panics in handlers", func(t *testing.T) {
+		ctx := tests.Context(t)
+		srv := &panickingServer{}
+		s := NewGRPCServer(logger.Test(t), srv, GRPCServerConfig{})
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		go func() {
+			_ = s.Serve(lis)
+		}()
+		t.Cleanup(s.Stop)
+
+		conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		require.NoError(t, err)
+		t.Cleanup(func() { conn.Close() })
+		c := NewTransmitterClient(conn)
+		panics := testutil.ToFloat64(promServerPanicsTotal.WithLabelValues("LatestReport"))
+
+		_, err = c.LatestReport(ctx, &LatestReportRequest{})
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.Equal(t, panics+1, testutil.ToFloat64(promServerPanicsTotal.WithLabelValues("LatestReport")))
+
+		// the server keeps serving after a panic
+		_, err = c.Transmit(ctx, &TransmitRequest{Payload: []byte("report")})
+		require.NoError(t, err)
+		srv.mu.Lock()
+		defer srv.mu.Unlock()
+		assert.Len(t, srv.received, 1)
+	})
+}
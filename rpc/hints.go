@@ -0,0 +1,47 @@
+package rpc
+
+import (
+	"context"
+	"time"
+)
+
+// TransmitHints tell a Client how urgent a report is, so that it can
+// reorder its queue when it falls behind. The zero value is the default
+// priority without a deadline.
+type TransmitHints struct {
+	// Priority of the report; higher is transmitted first
+	Priority int32
+	// Deadline by which the report should be transmitted. Among reports of
+	// the same priority, the one with the earliest deadline is transmitted
+	// first, before any without a deadline.
+	Deadline time.Time
+}
+
+// before returns true if a report with hints h should be transmitted before
+// one with hints o
+func (h TransmitHints) before(o TransmitHints) bool {
+	if h.Priority != o.Priority {
+		return h.Priority > o.Priority
+	}
+	switch {
+	case h.Deadline.IsZero():
+		return false
+	case o.Deadline.IsZero():
+		return true
+	default:
+		return h.Deadline.Before(o.Deadline)
+	}
+}
+
+type transmitHintsKey struct{}
+
+// WithTransmitHints returns a copy of ctx carrying hints, for
+// Client.EnqueueContext and Router.EnqueueContext
+func WithTransmitHints(ctx context.Context, hints TransmitHints) context.Context {
+	return context.WithValue(ctx, transmitHintsKey{}, hints)
+}
+
+func transmitHintsFromContext(ctx context.Context) TransmitHints {
+	hints, _ := ctx.Value(transmitHintsKey{}).(TransmitHints)
+	return hints
+}
@@ -0,0 +1,139 @@
+package rpc
+
+import (
+	"context"
+	"math/rand"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+)
+
+func Test_TransmitHints_before(t *testing.T) {
+	now := time.Unix(1000, 0)
+	assert.True(t, TransmitHints{Priority: 1}.before(TransmitHints{}))
+	assert.False(t, TransmitHints{}.before(TransmitHints{Priority: 1}))
+	assert.True(t, TransmitHints{Deadline: now}.before(TransmitHints{Deadline: now.Add(time.Second)}))
+	assert.True(t, TransmitHints{Deadline: now}.before(TransmitHints{}), "reports with a deadline go first")
+	assert.False(t, TransmitHints{}.before(TransmitHints{Deadline: now}))
+	assert.False(t, TransmitHints{}.before(TransmitHints{}))
+	assert.False(t, TransmitHints{Deadline: now}.before(TransmitHints{Priority: 1}), "priority goes before deadline")
+}
+
+func Test_transmitQueue_Hints(t *testing.T) {
+	now := time.Unix(1000, 0)
+	item := func(key string, hints TransmitHints) *queueItem {
+		return &queueItem{req: &TransmitRequest{IdempotencyKey: key}, enqueuedAt: now, hints: hints}
+	}
+	keys := func(q *transmitQueue) (keys []string) {
+		for item := q.pop(); item != nil; item = q.pop() {
+			keys = append(keys, item.req.IdempotencyKey)
+		}
+		return keys
+	}
+
+	t.Run("pops the most urgent item, then the oldest", func(t *testing.T) {
+		q := newTransmitQueue("hints.example", 10)
+		q.push(item("a", TransmitHints{}))
+		q.push(item("b", TransmitHints{Deadline: now.Add(2 * time.Second)}))
+		q.push(item("c", TransmitHints{Priority: 1}))
+		q.push(item("d", TransmitHints{Deadline: now.Add(time.Second)}))
+		q.push(item("e", TransmitHints{}))
+		q.push(item("f", TransmitHints{Priority: 1}))
+		assert.Equal(t, []string{"c", "f", "d", "b", "a", "e"}, keys(q))
+	})
+	t.Run("evicts the oldest item of the lowest priority", func(t *testing.T) {
+		q := newTransmitQueue("hints.example", 3)
+		q.push(item("a", TransmitHints{Priority: 1}))
+		q.push(item("b", TransmitHints{}))
+		q.push(item("c", TransmitHints{}))
+		evicted := q.push(item("d", TransmitHints{Priority: 1}))
+		require.NotNil(t, evicted)
+		assert.Equal(t, "b", evicted.req.IdempotencyKey)
+		evicted = q.push(item("e", TransmitHints{Priority: -1}))
+		require.NotNil(t, evicted)
+		assert.Equal(t, "e", evicted.req.IdempotencyKey, "a new report of the lowest priority is dropped at once")
+		assert.Equal(t, []string{"a", "d", "c"}, keys(q))
+	})
+	t.Run("evicts when an item returned after a failed attempt overfills the queue", func(t *testing.T) {
+		q := newTransmitQueue("hints.example", 2)
+		q.push(item("a", TransmitHints{}))
+		q.push(item("b", TransmitHints{Priority: 1}))
+		evicted := q.pushFront(item("c", TransmitHints{Priority: 1}))
+		require.NotNil(t, evicted)
+		assert.Equal(t, "a", evicted.req.IdempotencyKey)
+		evicted = q.pushFront(item("d", TransmitHints{}))
+		require.NotNil(t, evicted)
+		assert.Equal(t, "d", evicted.req.IdempotencyKey)
+		assert.Equal(t, []string{"c", "b"}, keys(q))
+	})
+	t.Run("matches a linear scan of the items in queue order", func(t *testing.T) {
+		rnd := rand.New(rand.NewSource(1))
+		q := newTransmitQueue("hints.example", 5)
+		// model holds the items in queue order, oldest first
+		var model []*queueItem
+		scan := func(better func(a, b *queueItem) bool) int {
+			best := 0
+			for i := range model {
+				if better(model[i], model[best]) {
+					best = i
+				}
+			}
+			return best
+		}
+		evict := func() string {
+			if len(model) <= 5 {
+				return ""
+			}
+			i := scan(func(a, b *queueItem) bool { return a.hints.Priority < b.hints.Priority })
+			key := model[i].req.IdempotencyKey
+			model = append(model[:i], model[i+1:]...)
+			return key
+		}
+		keyOf := func(item *queueItem) string {
+			if item == nil {
+				return ""
+			}
+			return item.req.IdempotencyKey
+		}
+		for i := 0; i < 1000; i++ {
+			it := item(strconv.Itoa(i), TransmitHints{Priority: int32(rnd.Intn(3))})
+			if rnd.Intn(2) == 0 {
+				it.hints.Deadline = now.Add(time.Duration(rnd.Intn(3)) * time.Second)
+			}
+			switch rnd.Intn(3) {
+			case 0:
+				model = append(model, it)
+				assert.Equal(t, evict(), keyOf(q.push(it)))
+			case 1:
+				model = append([]*queueItem{it}, model...)
+				assert.Equal(t, evict(), keyOf(q.pushFront(it)))
+			default:
+				var expected string
+				if len(model) > 0 {
+					j := scan(func(a, b *queueItem) bool { return a.hints.before(b.hints) })
+					expected = model[j].req.IdempotencyKey
+					model = append(model[:j], model[j+1:]...)
+				}
+				assert.Equal(t, expected, keyOf(q.pop()))
+			}
+			require.Equal(t, len(model), q.len())
+		}
+	})
+}
+
+func Test_Client_EnqueueContext_TransmitHints(t *testing.T) {
+	c := NewClient(logger.Test(t), &mockConn{}, ClientConfig{ServerURL: "hints.example"})
+	hints := TransmitHints{Priority: 2, Deadline: time.Unix(1000, 0)}
+	c.EnqueueContext(context.Background(), &TransmitRequest{Payload: []byte("default")})
+	c.EnqueueContext(WithTransmitHints(context.Background(), hints), &TransmitRequest{Payload: []byte("urgent")})
+
+	item := c.queue.pop()
+	require.NotNil(t, item)
+	assert.Equal(t, []byte("urgent"), item.req.Payload)
+	assert.Equal(t, hints, item.hints)
+}
@@ -0,0 +1,47 @@
+package rpc
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// NewTimestamp converts t, returning nil if t is zero
+func NewTimestamp(t time.Time) *Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return &Timestamp{Seconds: t.Unix(), Nanos: int32(t.Nanosecond())}
+}
+
+// AsTime converts ts, returning the zero time if ts is nil
+func (ts *Timestamp) AsTime() time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return time.Unix(ts.Seconds, int64(ts.Nanos))
+}
+
+// NewReportTimestamps returns the timestamps of the stages that a report
+// passed through before it was enqueued, e.g. those recorded by the LLO
+// plugin (see llo.ReportTimestamps). Zero times are left unset. The client
+// stamps the time of transmission.
+func NewReportTimestamps(observation, outcome, reportEncoded time.Time) *ReportTimestamps {
+	return &ReportTimestamps{
+		Observation:   NewTimestamp(observation),
+		Outcome:       NewTimestamp(outcome),
+		ReportEncoded: NewTimestamp(reportEncoded),
+	}
+}
+
+// withTransmitTimestamp returns req with its transmit timestamp set to now,
+// if it carries report timestamps. Requests may be queued by several
+// clients at once, so req is copied rather than modified.
+func withTransmitTimestamp(req *TransmitRequest, now time.Time) *TransmitRequest {
+	if req.Timestamps == nil {
+		return req
+	}
+	stamped := proto.Clone(req).(*TransmitRequest)
+	stamped.Timestamps.Transmit = NewTimestamp(now)
+	return stamped
+}
@@ -0,0 +1,49 @@
+package rpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+)
+
+func Test_Timestamp(t *testing.T) {
+	now := time.Unix(1726670491, 123)
+	assert.True(t, now.Equal(NewTimestamp(now).AsTime()))
+	assert.Nil(t, NewTimestamp(time.Time{}))
+	assert.True(t, (*Timestamp)(nil).AsTime().IsZero())
+}
+
+func Test_withTransmitTimestamp(t *testing.T) {
+	now := time.Unix(200, 0)
+
+	req := &TransmitRequest{Payload: []byte("report")}
+	assert.Same(t, req, withTransmitTimestamp(req, now))
+
+	req = &TransmitRequest{Payload: []byte("report"), ChannelId: 7, Timestamps: NewReportTimestamps(time.Unix(100, 0), time.Time{}, time.Unix(150, 0))}
+	stamped := withTransmitTimestamp(req, now)
+	assert.Equal(t, uint32(7), stamped.ChannelId)
+	assert.True(t, now.Equal(stamped.Timestamps.Transmit.AsTime()))
+	assert.True(t, time.Unix(150, 0).Equal(stamped.Timestamps.ReportEncoded.AsTime()))
+	assert.Nil(t, stamped.Timestamps.Outcome)
+	// the enqueued request may be shared with other clients
+	assert.Nil(t, req.Timestamps.Transmit)
+}
+
+func Test_Client_StampsTransmitTimestamp(t *testing.T) {
+	conn := &mockConn{}
+	c := NewClient(logger.Test(t), conn, ClientConfig{ServerURL: "latency.example"})
+	require.NoError(t, c.Start(tests.Context(t)))
+	t.Cleanup(func() { assert.NoError(t, c.Close()) })
+
+	enqueuedAt := time.Now()
+	c.Enqueue(&TransmitRequest{Payload: []byte("report"), Timestamps: NewReportTimestamps(enqueuedAt, enqueuedAt, enqueuedAt)})
+
+	require.Eventually(t, func() bool { return len(conn.getReceived()) == 1 }, tests.WaitTimeout(t), 10*time.Millisecond)
+	transmittedAt := conn.getReceived()[0].Timestamps.Transmit.AsTime()
+	assert.False(t, transmittedAt.Before(enqueuedAt))
+}
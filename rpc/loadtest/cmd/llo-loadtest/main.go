@@ -0,0 +1,94 @@
+// llo-loadtest transmits synthetic signed LLO reports to a Transmitter
+// server at a configurable rate and size, and prints the transmit latency
+// percentiles and loss.
+//
+// Example:
+//
+//	llo-loadtest -server localhost:1338 -rate 500 -duration 1m -values 10 -verify
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink-data-streams/llo"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc/loadtest"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc/mtls"
+)
+
+func main() {
+	serverURL := flag.String("server", "localhost:1338", "address of the Transmitter server")
+	clientKey := flag.String("client-key", "", "hex-encoded ed25519 private key to authenticate with over mTLS; connects without TLS if empty")
+	serverPubKey := flag.String("server-pubkey", "", "hex-encoded ed25519 public key of the server, required with -client-key")
+	compressor := flag.String("compressor", "", "gRPC compressor to use if the server supports it, e.g. gzip")
+	var cfg loadtest.Config
+	flag.Func("channel-id", "channel of the generated reports, in decimal or 0x-prefixed hex (default 1)", func(s string) (err error) {
+		cfg.ChannelID, err = llo.ParseChannelID(s)
+		return err
+	})
+	flag.Float64Var(&cfg.Rate, "rate", 100, "reports per second")
+	flag.DurationVar(&cfg.Duration, "duration", 30*time.Second, "how long to generate reports for")
+	flag.IntVar(&cfg.Values, "values", 1, "number of values per report, which determines the report size")
+	flag.IntVar(&cfg.Signers, "signers", loadtest.DefaultSigners, "number of signatures per report (f+1)")
+	flag.IntVar(&cfg.Concurrency, "concurrency", loadtest.DefaultConcurrency, "maximum number of transmissions in flight")
+	flag.DurationVar(&cfg.TransmitTimeout, "timeout", loadtest.DefaultTransmitTimeout, "timeout of each transmission")
+	flag.BoolVar(&cfg.VerifyDelivery, "verify", false, "check that every acknowledged report was persisted once done")
+	flag.Parse()
+
+	lggr, err := logger.New()
+	if err != nil {
+		log.Fatalf("failed to create logger: %v", err)
+	}
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	creds, err := transportCredentials(*clientKey, *serverPubKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+	conn, err := grpc.NewClient(*serverURL, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		log.Fatalf("failed to dial server: %v", err)
+	}
+	defer conn.Close()
+	client := rpc.NewClient(lggr, conn, rpc.ClientConfig{ServerURL: *serverURL, Compressor: *compressor})
+	if err = client.Start(ctx); err != nil {
+		log.Fatalf("failed to start client: %v", err)
+	}
+	defer client.Close()
+
+	res, err := loadtest.Run(ctx, client, cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(res)
+}
+
+func transportCredentials(clientKey, serverPubKey string) (credentials.TransportCredentials, error) {
+	if clientKey == "" {
+		return insecure.NewCredentials(), nil
+	}
+	priv, err := hex.DecodeString(clientKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid client key: %w", err)
+	}
+	pub, err := hex.DecodeString(serverPubKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid server public key: %w", err)
+	}
+	return mtls.NewTransportCredentials(ed25519.PrivateKey(priv), []ed25519.PublicKey{pub})
+}
@@ -0,0 +1,321 @@
+// Package loadtest generates synthetic signed LLO reports at a fixed rate
+// against a Transmitter server and measures transmit latency and loss, to
+// base server capacity planning and client queue tuning on measurements.
+package loadtest
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+
+	"github.com/smartcontractkit/chainlink-data-streams/llo"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc"
+)
+
+const (
+	DefaultConcurrency     = 16
+	DefaultTransmitTimeout = 5 * time.Second
+	DefaultSigners         = 2
+	DefaultChannelID       = 1
+
+	transmissionStatusTimeout = 5 * time.Second
+)
+
+// ConfigDigest is the config digest of the generated reports. It has the LLO
+// config digest prefix so that servers route it like real LLO reports.
+var ConfigDigest = types.ConfigDigest{0x00, 0x09, 0x10, 0xad}
+
+type Config struct {
+	// ChannelID is the channel of the generated reports. Defaults to
+	// DefaultChannelID if zero.
+	ChannelID llotypes.ChannelID
+	// Rate is the number of reports transmitted per second
+	Rate float64
+	// Duration is how long reports are generated for
+	Duration time.Duration
+	// Values is the number of values in each report, which determines its
+	// size: every value adds roughly 40 bytes to the JSON report. At least
+	// one value is always reported.
+	Values int
+	// Signers is the number of signatures attached to each report, i.e.
+	// f+1 of the DON being simulated. Defaults to DefaultSigners if zero.
+	Signers int
+	// Concurrency is the maximum number of transmissions in flight.
+	// Defaults to DefaultConcurrency if zero.
+	Concurrency int
+	// TransmitTimeout bounds each transmission. Defaults to
+	// DefaultTransmitTimeout if zero.
+	TransmitTimeout time.Duration
+	// VerifyDelivery queries the status of every acknowledged report once
+	// generation has finished, counting reports that were not persisted as
+	// lost
+	VerifyDelivery bool
+}
+
+// Result summarizes a load test
+type Result struct {
+	// Sent is the number of reports transmitted
+	Sent int
+	// Acknowledged is the number of reports accepted by the server
+	Acknowledged int
+	// Rejected is the number of reports answered with a non-zero code
+	Rejected int
+	// Failed is the number of transmissions that returned an error,
+	// including timeouts
+	Failed int
+	// Undelivered is the number of acknowledged reports that the server did
+	// not report as persisted. Only counted if VerifyDelivery is set.
+	Undelivered int
+	// PayloadBytes is the size of each report payload
+	PayloadBytes int
+	// Elapsed is the time from the first to the last transmission completing
+	Elapsed time.Duration
+	// Latency percentiles of transmissions, measured from when they were
+	// scheduled rather than sent, so that transmissions delayed by earlier
+	// slow ones count towards the latency instead of lowering the rate
+	P50, P90, P99, Max time.Duration
+}
+
+// Loss is the fraction of sent reports that were not delivered
+func (r Result) Loss() float64 {
+	if r.Sent == 0 {
+		return 0
+	}
+	return float64(r.Rejected+r.Failed+r.Undelivered) / float64(r.Sent)
+}
+
+// Throughput is the number of reports acknowledged per second
+func (r Result) Throughput() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return float64(r.Acknowledged) / r.Elapsed.Seconds()
+}
+
+func (r Result) String() string {
+	return fmt.Sprintf("sent=%d acknowledged=%d rejected=%d failed=%d undelivered=%d loss=%.4f%% payloadBytes=%d throughput=%.1f/s p50=%s p90=%s p99=%s max=%s",
+		r.Sent, r.Acknowledged, r.Rejected, r.Failed, r.Undelivered, 100*r.Loss(), r.PayloadBytes, r.Throughput(), r.P50, r.P90, r.P99, r.Max)
+}
+
+// Run transmits reports to client at cfg.Rate for cfg.Duration. Every report
+// is unique, so servers persist each of them. It returns early with an
+// error only if ctx is cancelled.
+func Run(ctx context.Context, client rpc.TransmitterClient, cfg Config) (Result, error) {
+	if cfg.Rate <= 0 {
+		return Result{}, errors.New("rate must be positive")
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = DefaultConcurrency
+	}
+	if cfg.TransmitTimeout <= 0 {
+		cfg.TransmitTimeout = DefaultTransmitTimeout
+	}
+	if cfg.ChannelID == 0 {
+		cfg.ChannelID = DefaultChannelID
+	}
+	g, err := newGenerator(cfg.ChannelID, cfg.Values, cfg.Signers)
+	if err != nil {
+		return Result{}, err
+	}
+
+	type job struct {
+		seqNr uint64
+		due   time.Time
+	}
+	jobs := make(chan job, cfg.Concurrency)
+
+	var (
+		mu        sync.Mutex
+		res       Result
+		latencies []time.Duration
+		acked     []string
+		lastDone  time.Time
+	)
+	var wg sync.WaitGroup
+	for i := 0; i < cfg.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				var resp *rpc.TransmitResponse
+				req, err := g.generate(j.seqNr)
+				if err == nil {
+					tctx, cancel := context.WithTimeout(ctx, cfg.TransmitTimeout)
+					resp, err = client.Transmit(tctx, req)
+					cancel()
+				}
+				done := time.Now()
+
+				mu.Lock()
+				res.Sent++
+				if req != nil {
+					res.PayloadBytes = len(req.Payload)
+				}
+				switch {
+				case err != nil:
+					res.Failed++
+				case resp.Code != 0:
+					res.Rejected++
+				default:
+					res.Acknowledged++
+					acked = append(acked, req.IdempotencyKey)
+					latencies = append(latencies, done.Sub(j.due))
+				}
+				lastDone = done
+				mu.Unlock()
+			}
+		}()
+	}
+
+	interval := time.Duration(float64(time.Second) / cfg.Rate)
+	start := time.Now()
+schedule:
+	for i := 0; ; i++ {
+		due := start.Add(time.Duration(i) * interval)
+		if due.Sub(start) >= cfg.Duration {
+			break
+		}
+		if wait := time.Until(due); wait > 0 {
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+				break schedule
+			}
+		}
+		select {
+		case jobs <- job{uint64(i + 1), due}:
+		case <-ctx.Done():
+			break schedule
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	if ctx.Err() != nil {
+		return res, context.Cause(ctx)
+	}
+
+	if !lastDone.IsZero() {
+		res.Elapsed = lastDone.Sub(start)
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	res.P50, res.P90, res.P99 = percentile(latencies, 0.5), percentile(latencies, 0.9), percentile(latencies, 0.99)
+	if len(latencies) > 0 {
+		res.Max = latencies[len(latencies)-1]
+	}
+
+	if cfg.VerifyDelivery {
+		res.Undelivered, err = countUndelivered(ctx, client, acked)
+		if err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+// percentile returns the q'th quantile of the sorted durations, using the
+// nearest-rank method
+func percentile(sorted []time.Duration, q float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(math.Ceil(q*float64(len(sorted)))) - 1
+	return sorted[max(0, min(idx, len(sorted)-1))]
+}
+
+// countUndelivered returns how many of the reports are not persisted
+func countUndelivered(ctx context.Context, client rpc.TransmitterClient, keys []string) (n int, err error) {
+	for _, key := range keys {
+		sctx, cancel := context.WithTimeout(ctx, transmissionStatusTimeout)
+		res, err := client.TransmissionStatus(sctx, &rpc.TransmissionStatusRequest{IdempotencyKey: key})
+		cancel()
+		if err != nil {
+			return n, fmt.Errorf("failed to query transmission status: %w", err)
+		}
+		if res.Status != rpc.TransmissionStatusResponse_Persisted {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// generator builds JSON reports attested by ed25519 keyrings, like those of a
+// DON running the LLO plugin with the JSON report codec
+type generator struct {
+	channelID llotypes.ChannelID
+	values    []llo.StreamValue
+	keyrings  []*llo.Ed25519OnchainKeyring
+}
+
+func newGenerator(channelID llotypes.ChannelID, values, signers int) (*generator, error) {
+	if signers <= 0 {
+		signers = DefaultSigners
+	}
+	g := &generator{channelID: channelID}
+	for i := 0; i < max(values, 1); i++ {
+		g.values = append(g.values, llo.ToDecimal(decimal.New(int64(1_000_000+i), -6)))
+	}
+	for i := 0; i < signers; i++ {
+		_, privateKey, err := ed25519.GenerateKey(nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate key: %w", err)
+		}
+		kr, err := llo.NewEd25519OnchainKeyring(privateKey)
+		if err != nil {
+			return nil, err
+		}
+		g.keyrings = append(g.keyrings, kr)
+	}
+	return g, nil
+}
+
+func (g *generator) generate(seqNr uint64) (*rpc.TransmitRequest, error) {
+	now := uint32(time.Now().Unix())
+	report := llo.Report{
+		ConfigDigest:                ConfigDigest,
+		SeqNr:                       seqNr,
+		ChannelID:                   g.channelID,
+		ValidAfterSeconds:           now - 1,
+		ObservationTimestampSeconds: now,
+		Values:                      g.values,
+	}
+	encoded, err := llo.JSONReportCodec{}.Encode(context.Background(), report, llotypes.ChannelDefinition{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode report: %w", err)
+	}
+	r := ocr3types.ReportWithInfo[llotypes.ReportInfo]{
+		Report: encoded,
+		Info:   llotypes.ReportInfo{LifeCycleStage: llo.LifeCycleStageProduction, ReportFormat: llotypes.ReportFormatJSON},
+	}
+	sigs := make([]types.AttributedOnchainSignature, 0, len(g.keyrings))
+	for i, kr := range g.keyrings {
+		sig, err := kr.Sign(ConfigDigest, seqNr, r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign report: %w", err)
+		}
+		sigs = append(sigs, types.AttributedOnchainSignature{Signature: sig, Signer: commontypes.OracleID(i)})
+	}
+	payload, err := llo.JSONReportCodec{}.Pack(ConfigDigest, seqNr, encoded, sigs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack report: %w", err)
+	}
+	reportFormat := uint32(llotypes.ReportFormatJSON)
+	return &rpc.TransmitRequest{
+		Payload:        payload,
+		ReportFormat:   reportFormat,
+		ConfigDigest:   ConfigDigest[:],
+		IdempotencyKey: rpc.IdempotencyKey(payload, reportFormat),
+	}, nil
+}
@@ -0,0 +1,90 @@
+package loadtest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink-data-streams/llo"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc/server"
+)
+
+// serverClient calls a Server directly, as if over gRPC
+type serverClient struct {
+	rpc.TransmitterClient
+	srv *server.Server
+}
+
+func (c serverClient) Transmit(ctx context.Context, in *rpc.TransmitRequest, _ ...grpc.CallOption) (*rpc.TransmitResponse, error) {
+	return c.srv.Transmit(ctx, in)
+}
+
+func (c serverClient) TransmissionStatus(ctx context.Context, in *rpc.TransmissionStatusRequest, _ ...grpc.CallOption) (*rpc.TransmissionStatusResponse, error) {
+	return c.srv.TransmissionStatus(ctx, in)
+}
+
+func Test_Run(t *testing.T) {
+	ctx := tests.Context(t)
+	store := server.NewInMemoryReportStore()
+	srv, err := server.NewServer(logger.Test(t), server.Config{}, store)
+	require.NoError(t, err)
+
+	res, err := Run(ctx, serverClient{srv: srv}, Config{Rate: 200, Duration: 250 * time.Millisecond, Values: 3, VerifyDelivery: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, 50, res.Sent)
+	assert.Equal(t, 50, res.Acknowledged)
+	assert.Zero(t, res.Rejected+res.Failed+res.Undelivered)
+	assert.Zero(t, res.Loss())
+	assert.Positive(t, res.PayloadBytes)
+	assert.Positive(t, res.Throughput())
+	assert.LessOrEqual(t, res.P50, res.P99)
+	assert.LessOrEqual(t, res.P99, res.Max)
+
+	// every report is a signed JSON report with the configured values
+	keys := store.Keys("")
+	require.Len(t, keys, 50)
+	req, ok := store.Get(keys[0])
+	require.True(t, ok)
+	digest, _, report, sigs, err := llo.JSONReportCodec{}.UnpackDecode(req.Payload)
+	require.NoError(t, err)
+	assert.Equal(t, ConfigDigest, digest)
+	assert.Len(t, report.Values, 3)
+	assert.Len(t, sigs, DefaultSigners)
+}
+
+func Test_Run_Loss(t *testing.T) {
+	ctx := tests.Context(t)
+	// the server only accepts half of the reports
+	srv, err := server.NewServer(logger.Test(t), server.Config{Tenants: []server.TenantConfig{{Name: "loadtest", ConfigDigests: []types.ConfigDigest{ConfigDigest}, MaxReports: 10}}}, server.NewInMemoryReportStore())
+	require.NoError(t, err)
+
+	res, err := Run(ctx, serverClient{srv: srv}, Config{Rate: 400, Duration: 50 * time.Millisecond})
+	require.NoError(t, err)
+	assert.Equal(t, 20, res.Sent)
+	assert.Equal(t, 10, res.Acknowledged)
+	assert.Equal(t, 10, res.Rejected)
+	assert.InDelta(t, 0.5, res.Loss(), 0.0001)
+}
+
+func Test_percentile(t *testing.T) {
+	var sorted []time.Duration
+	assert.Zero(t, percentile(sorted, 0.99))
+	for i := 1; i <= 100; i++ {
+		sorted = append(sorted, time.Duration(i))
+	}
+	assert.Equal(t, time.Duration(50), percentile(sorted, 0.5))
+	assert.Equal(t, time.Duration(99), percentile(sorted, 0.99))
+	assert.Equal(t, time.Duration(1), percentile(sorted, 0))
+	assert.Equal(t, time.Duration(100), percentile(sorted, 1))
+}
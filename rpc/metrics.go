@@ -0,0 +1,90 @@
+package rpc
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	promRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "llo_transmitter_request_duration_seconds",
+		Help:    "Latency of requests made to the transmitter server, by endpoint",
+		Buckets: []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+	},
+		[]string{"serverURL", "endpoint"},
+	)
+	promTransmissionLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "llo_transmitter_transmission_latency_seconds",
+		Help:    "Time from enqueueing a report until the server accepted it, including time spent queued and retrying",
+		Buckets: []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+	},
+		[]string{"serverURL"},
+	)
+	promResponsesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "llo_transmitter_responses_total",
+		Help: "Number of responses received from the transmitter server, by endpoint and gRPC code",
+	},
+		[]string{"serverURL", "endpoint", "code"},
+	)
+	promQueueDepth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "llo_transmitter_queue_depth",
+		Help: "Number of reports waiting to be transmitted",
+	},
+		[]string{"serverURL"},
+	)
+	promQueueOldestAge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "llo_transmitter_queue_oldest_unsent_age_seconds",
+		Help: "Time since the oldest report still waiting to be transmitted was enqueued",
+	},
+		[]string{"serverURL"},
+	)
+	promQueueEvictedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "llo_transmitter_queue_evicted_total",
+		Help: "Number of reports dropped without being transmitted because the queue was full",
+	},
+		[]string{"serverURL"},
+	)
+	promDroppedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "llo_transmitter_dropped_total",
+		Help: "Number of reports dropped without retrying because the server would not accept them, by gRPC code",
+	},
+		[]string{"serverURL", "code"},
+	)
+	promServerPanicsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "llo_transmitter_server_panics_total",
+		Help: "Number of panics recovered from in transmitter server handlers, by endpoint",
+	},
+		[]string{"endpoint"},
+	)
+)
+
+var _ grpc.ClientConnInterface = (*instrumentedConn)(nil)
+
+// instrumentedConn records latency and response codes for every unary call
+// made through it. Latencies carry the caller's trace ID as an exemplar.
+type instrumentedConn struct {
+	grpc.ClientConnInterface
+	serverURL string
+}
+
+func (c *instrumentedConn) Invoke(ctx context.Context, method string, args any, reply any, opts ...grpc.CallOption) error {
+	endpoint := path.Base(method)
+	start := time.Now()
+	err := c.ClientConnInterface.Invoke(ctx, method, args, reply, opts...)
+	observeWithTraceExemplar(ctx, promRequestDuration.WithLabelValues(c.serverURL, endpoint), time.Since(start).Seconds())
+
+	code := status.Code(err)
+	if res, ok := reply.(*TransmitResponse); ok && err == nil {
+		// Transmit reports application-level failures in the response body
+		code = codes.Code(res.Code)
+	}
+	promResponsesTotal.WithLabelValues(c.serverURL, endpoint, code.String()).Inc()
+	return err
+}
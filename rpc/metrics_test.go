@@ -0,0 +1,127 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+)
+
+type mockConn struct {
+	grpc.ClientConnInterface
+
+	mu        sync.Mutex
+	responses []error // consumed in order; nil means success
+	received  []*TransmitRequest
+}
+
+func (m *mockConn) Invoke(_ context.Context, _ string, args any, reply any, _ ...grpc.CallOption) error {
+	if res, ok := reply.(*ServerInfoResponse); ok {
+		// answered without consuming responses, which are meant for reports
+		res.SchemaRevision = SchemaRevision
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var err error
+	if len(m.responses) > 0 {
+		err, m.responses = m.responses[0], m.responses[1:]
+	}
+	if err == nil {
+		if req, ok := args.(*TransmitRequest); ok {
+			m.received = append(m.received, req)
+		}
+	}
+	return err
+}
+
+func (m *mockConn) getReceived() []*TransmitRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*TransmitRequest{}, m.received...)
+}
+
+func Test_Client_Metrics(t *testing.T) {
+	ctx := tests.Context(t)
+
+	t.Run("records responses by endpoint and code", func(t *testing.T) {
+		serverURL := "metrics-responses.example"
+		conn := &mockConn{responses: []error{nil, status.Error(codes.Unavailable, "down"), nil}}
+		c := NewClient(logger.Test(t), conn, ClientConfig{ServerURL: serverURL})
+		count := func(endpoint, code string) float64 {
+			return testutil.ToFloat64(promResponsesTotal.WithLabelValues(serverURL, endpoint, code))
+		}
+		transmitOK, transmitUnavailable, latestReportOK := count("Transmit", "OK"), count("Transmit", "Unavailable"), count("LatestReport", "OK")
+
+		_, err := c.Transmit(ctx, &TransmitRequest{Payload: []byte("report")})
+		require.NoError(t, err)
+		_, err = c.Transmit(ctx, &TransmitRequest{Payload: []byte("report")})
+		require.Error(t, err)
+		_, err = c.LatestReport(ctx, &LatestReportRequest{})
+		require.NoError(t, err)
+
+		assert.Equal(t, transmitOK+1, count("Transmit", "OK"))
+		assert.Equal(t, transmitUnavailable+1, count("Transmit", "Unavailable"))
+		assert.Equal(t, latestReportOK+1, count("LatestReport", "OK"))
+	})
+	t.Run("queue depth and oldest unsent age", func(t *testing.T) {
+		serverURL := "metrics-queue.example"
+		c := NewClient(logger.Test(t), &mockConn{}, ClientConfig{ServerURL: serverURL, MaxQueueSize: 2})
+		evicted := testutil.ToFloat64(promQueueEvictedTotal.WithLabelValues(serverURL))
+
+		c.Enqueue(&TransmitRequest{Payload: []byte("report 1")})
+		c.Enqueue(&TransmitRequest{Payload: []byte("report 2")})
+		c.Enqueue(&TransmitRequest{Payload: []byte("report 3")})
+
+		assert.Equal(t, float64(2), testutil.ToFloat64(promQueueDepth.WithLabelValues(serverURL)))
+		assert.Equal(t, evicted+1, testutil.ToFloat64(promQueueEvictedTotal.WithLabelValues(serverURL)))
+
+		c.queue.updateMetrics(time.Now().Add(time.Minute))
+		assert.GreaterOrEqual(t, testutil.ToFloat64(promQueueOldestAge.WithLabelValues(serverURL)), float64(60))
+	})
+}
+
+func Test_Client_Queue(t *testing.T) {
+	t.Run("transmits enqueued reports in order, retrying transient failures", func(t *testing.T) {
+		conn := &mockConn{responses: []error{errors.New("connection reset"), nil, nil}}
+		c := NewClient(logger.Test(t), conn, ClientConfig{ServerURL: "queue.example"})
+		require.NoError(t, c.Start(tests.Context(t)))
+		t.Cleanup(func() { assert.NoError(t, c.Close()) })
+
+		c.Enqueue(&TransmitRequest{Payload: []byte("report 1")})
+		c.Enqueue(&TransmitRequest{Payload: []byte("report 2")})
+
+		require.Eventually(t, func() bool { return len(conn.getReceived()) == 2 }, tests.WaitTimeout(t), 10*time.Millisecond)
+		received := conn.getReceived()
+		assert.Equal(t, []byte("report 1"), received[0].Payload)
+		assert.Equal(t, []byte("report 2"), received[1].Payload)
+		assert.Equal(t, IdempotencyKey([]byte("report 1"), 0), received[0].IdempotencyKey)
+		assert.Zero(t, c.queue.len())
+	})
+	t.Run("drops reports that the server would reject again", func(t *testing.T) {
+		serverURL := "queue-drop.example"
+		conn := &mockConn{responses: []error{status.Error(codes.PermissionDenied, "denied"), nil}}
+		c := NewClient(logger.Test(t), conn, ClientConfig{ServerURL: serverURL})
+		dropped := testutil.ToFloat64(promDroppedTotal.WithLabelValues(serverURL, codes.PermissionDenied.String()))
+		require.NoError(t, c.Start(tests.Context(t)))
+		t.Cleanup(func() { assert.NoError(t, c.Close()) })
+
+		c.Enqueue(&TransmitRequest{Payload: []byte("report 1")})
+		c.Enqueue(&TransmitRequest{Payload: []byte("report 2")})
+
+		require.Eventually(t, func() bool { return len(conn.getReceived()) == 1 }, tests.WaitTimeout(t), 10*time.Millisecond)
+		assert.Equal(t, []byte("report 2"), conn.getReceived()[0].Payload)
+		assert.Equal(t, dropped+1, testutil.ToFloat64(promDroppedTotal.WithLabelValues(serverURL, codes.PermissionDenied.String())))
+	})
+}
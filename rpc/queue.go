@@ -0,0 +1,211 @@
+package rpc
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+type queueItem struct {
+	req        *TransmitRequest
+	enqueuedAt time.Time
+	// spanContext is the span of the caller that enqueued the report, if
+	// tracing is enabled
+	spanContext trace.SpanContext
+	hints       TransmitHints
+
+	// seq orders items by age; items returned to the head of the queue get
+	// a lower seq than any item in it
+	seq int64
+	// index is the position of the item in each of the queue's heaps
+	index [numQueueHeaps]int
+}
+
+const (
+	// urgentHeap orders items by hints, then oldest first
+	urgentHeap = iota
+	// evictionHeap orders items by priority, lowest first, then oldest first
+	evictionHeap
+	// ageHeap orders items oldest first
+	ageHeap
+	numQueueHeaps
+)
+
+// queueHeap is a heap.Interface over the items of a transmitQueue. Every
+// item is in all of the queue's heaps, and tracks its position in each so
+// that it can be removed from the others when it is popped or evicted.
+type queueHeap struct {
+	items []*queueItem
+	which int
+	less  func(a, b *queueItem) bool
+}
+
+func (h *queueHeap) Len() int           { return len(h.items) }
+func (h *queueHeap) Less(i, j int) bool { return h.less(h.items[i], h.items[j]) }
+func (h *queueHeap) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].index[h.which] = i
+	h.items[j].index[h.which] = j
+}
+func (h *queueHeap) Push(x any) {
+	item := x.(*queueItem)
+	item.index[h.which] = len(h.items)
+	h.items = append(h.items, item)
+}
+func (h *queueHeap) Pop() any {
+	n := len(h.items) - 1
+	item := h.items[n]
+	h.items[n] = nil
+	h.items = h.items[:n]
+	return item
+}
+
+func (h *queueHeap) peek() *queueItem {
+	if len(h.items) == 0 {
+		return nil
+	}
+	return h.items[0]
+}
+
+// transmitQueue is a bounded queue of reports waiting to be transmitted.
+// Reports are transmitted in order of their hints, then first in first out.
+// When full, the oldest report of the lowest priority is evicted to make
+// room for the newest since stale reports are the least valuable.
+type transmitQueue struct {
+	mu      sync.Mutex
+	maxSize int
+	heaps   [numQueueHeaps]*queueHeap
+	// frontSeq and backSeq are the seqs of the oldest and newest items
+	// ever queued
+	frontSeq  int64
+	backSeq   int64
+	serverURL string
+	notify    chan struct{}
+}
+
+func newTransmitQueue(serverURL string, maxSize int) *transmitQueue {
+	q := &transmitQueue{
+		maxSize:   maxSize,
+		serverURL: serverURL,
+		notify:    make(chan struct{}, 1),
+	}
+	q.heaps[urgentHeap] = &queueHeap{which: urgentHeap, less: func(a, b *queueItem) bool {
+		if a.hints.before(b.hints) {
+			return true
+		} else if b.hints.before(a.hints) {
+			return false
+		}
+		return a.seq < b.seq
+	}}
+	q.heaps[evictionHeap] = &queueHeap{which: evictionHeap, less: func(a, b *queueItem) bool {
+		if a.hints.Priority != b.hints.Priority {
+			return a.hints.Priority < b.hints.Priority
+		}
+		return a.seq < b.seq
+	}}
+	q.heaps[ageHeap] = &queueHeap{which: ageHeap, less: func(a, b *queueItem) bool {
+		return a.seq < b.seq
+	}}
+	return q
+}
+
+// push adds an item to the back of the queue, returning the evicted item if
+// the queue was full
+func (q *transmitQueue) push(item *queueItem) (evicted *queueItem) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.backSeq++
+	item.seq = q.backSeq
+	q.add(item)
+	evicted = q.evict()
+	q.observe(time.Now())
+
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+	return evicted
+}
+
+// pushFront returns an item to the head of the queue after a failed attempt.
+// If newer items have since filled the queue, the oldest item of the lowest
+// priority is evicted as in push, which may be the returned item itself.
+func (q *transmitQueue) pushFront(item *queueItem) (evicted *queueItem) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.frontSeq--
+	item.seq = q.frontSeq
+	q.add(item)
+	evicted = q.evict()
+	q.observe(time.Now())
+	return evicted
+}
+
+func (q *transmitQueue) add(item *queueItem) {
+	for _, h := range q.heaps {
+		heap.Push(h, item)
+	}
+}
+
+// remove removes item from every heap but the one it was popped from
+func (q *transmitQueue) remove(item *queueItem, poppedFrom int) {
+	for i, h := range q.heaps {
+		if i != poppedFrom {
+			heap.Remove(h, item.index[i])
+		}
+	}
+}
+
+// evict removes the oldest item of the lowest priority if the queue is over
+// capacity
+func (q *transmitQueue) evict() *queueItem {
+	if q.heaps[evictionHeap].Len() <= q.maxSize {
+		return nil
+	}
+	victim := heap.Pop(q.heaps[evictionHeap]).(*queueItem)
+	q.remove(victim, evictionHeap)
+	promQueueEvictedTotal.WithLabelValues(q.serverURL).Inc()
+	return victim
+}
+
+// pop removes and returns the most urgent item, or nil if the queue is
+// empty. Items that are equally urgent are popped oldest first.
+func (q *transmitQueue) pop() *queueItem {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.heaps[urgentHeap].Len() == 0 {
+		return nil
+	}
+	item := heap.Pop(q.heaps[urgentHeap]).(*queueItem)
+	q.remove(item, urgentHeap)
+	q.observe(time.Now())
+	return item
+}
+
+func (q *transmitQueue) len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.heaps[ageHeap].Len()
+}
+
+// updateMetrics refreshes the queue gauges; the age of the oldest item grows
+// even when the queue is not touched so this must be called periodically
+func (q *transmitQueue) updateMetrics(now time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.observe(now)
+}
+
+func (q *transmitQueue) observe(now time.Time) {
+	promQueueDepth.WithLabelValues(q.serverURL).Set(float64(q.heaps[ageHeap].Len()))
+	var age time.Duration
+	if oldest := q.heaps[ageHeap].peek(); oldest != nil {
+		age = now.Sub(oldest.enqueuedAt)
+	}
+	promQueueOldestAge.WithLabelValues(q.serverURL).Set(age.Seconds())
+}
@@ -0,0 +1,109 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+)
+
+// Router transmits reports to one or more of several named servers, so that
+// channels can select the infrastructure their reports are sent to (see
+// llo.TransmissionTargetsOpts). Reports without explicit targets are sent to
+// the default targets.
+//
+// The router starts and stops its clients.
+type Router struct {
+	services.StateMachine
+
+	clients        map[string]*Client
+	defaultTargets []string
+}
+
+// NewRouter returns a router over clients keyed by target name. There must
+// be at least one default target, and every default target must have a
+// client.
+func NewRouter(clients map[string]*Client, defaultTargets []string) (*Router, error) {
+	if len(defaultTargets) == 0 {
+		return nil, errors.New("at least one default target is required")
+	}
+	for _, target := range defaultTargets {
+		if _, exists := clients[target]; !exists {
+			return nil, fmt.Errorf("no client for default target %q", target)
+		}
+	}
+	return &Router{clients: clients, defaultTargets: defaultTargets}, nil
+}
+
+func (r *Router) Name() string { return "TransmitterRouter" }
+
+func (r *Router) Start(ctx context.Context) error {
+	return r.StartOnce("TransmitterRouter", func() error {
+		for _, target := range r.targetNames() {
+			if err := r.clients[target].Start(ctx); err != nil {
+				return fmt.Errorf("failed to start client for target %q: %w", target, err)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *Router) Close() error {
+	return r.StopOnce("TransmitterRouter", func() error {
+		var errs []error
+		for _, target := range r.targetNames() {
+			errs = append(errs, r.clients[target].Close())
+		}
+		return errors.Join(errs...)
+	})
+}
+
+func (r *Router) HealthReport() map[string]error {
+	report := map[string]error{r.Name(): r.Healthy()}
+	// clients may share a name, so qualify them by target
+	for target, c := range r.clients {
+		for name, err := range c.HealthReport() {
+			report[target+":"+name] = err
+		}
+	}
+	return report
+}
+
+// Enqueue schedules a report for transmission to each of the given targets,
+// or to the default targets if none are given. If any target is unknown, the
+// report is not sent to any of them.
+func (r *Router) Enqueue(req *TransmitRequest, targets []string) error {
+	return r.EnqueueContext(context.Background(), req, targets)
+}
+
+// EnqueueContext is Enqueue for callers that trace reports, see
+// Client.EnqueueContext
+func (r *Router) EnqueueContext(ctx context.Context, req *TransmitRequest, targets []string) error {
+	if len(targets) == 0 {
+		targets = r.defaultTargets
+	}
+	for _, target := range targets {
+		if _, exists := r.clients[target]; !exists {
+			return fmt.Errorf("unknown transmission target %q", target)
+		}
+	}
+	if req.IdempotencyKey == "" {
+		// set before enqueueing, since clients share the request
+		req.IdempotencyKey = IdempotencyKey(req.Payload, req.ReportFormat)
+	}
+	for _, target := range targets {
+		r.clients[target].EnqueueContext(ctx, req)
+	}
+	return nil
+}
+
+func (r *Router) targetNames() []string {
+	names := make([]string, 0, len(r.clients))
+	for name := range r.clients {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,63 @@
+package rpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+)
+
+func Test_Router(t *testing.T) {
+	ctx := tests.Context(t)
+	public, premium := &mockConn{}, &mockConn{}
+	clients := map[string]*Client{
+		"public":  NewClient(logger.Test(t), public, ClientConfig{ServerURL: "router-public.example"}),
+		"premium": NewClient(logger.Test(t), premium, ClientConfig{ServerURL: "router-premium.example"}),
+	}
+
+	t.Run("validates default targets", func(t *testing.T) {
+		_, err := NewRouter(clients, nil)
+		assert.EqualError(t, err, "at least one default target is required")
+		_, err = NewRouter(clients, []string{"public", "other"})
+		assert.EqualError(t, err, `no client for default target "other"`)
+	})
+
+	r, err := NewRouter(clients, []string{"public"})
+	require.NoError(t, err)
+	require.NoError(t, r.Start(ctx))
+	t.Cleanup(func() { assert.NoError(t, r.Close()) })
+
+	t.Run("routes reports to their targets", func(t *testing.T) {
+		require.NoError(t, r.Enqueue(&TransmitRequest{Payload: []byte("default")}, nil))
+		require.NoError(t, r.Enqueue(&TransmitRequest{Payload: []byte("premium")}, []string{"premium"}))
+		require.NoError(t, r.Enqueue(&TransmitRequest{Payload: []byte("both")}, []string{"premium", "public"}))
+
+		require.Eventually(t, func() bool { return len(public.getReceived()) == 2 && len(premium.getReceived()) == 2 }, tests.WaitTimeout(t), 10*time.Millisecond)
+		payloads := func(m *mockConn) (ps []string) {
+			for _, req := range m.getReceived() {
+				ps = append(ps, string(req.Payload))
+				assert.Equal(t, IdempotencyKey(req.Payload, req.ReportFormat), req.IdempotencyKey)
+			}
+			return ps
+		}
+		assert.ElementsMatch(t, []string{"default", "both"}, payloads(public))
+		assert.ElementsMatch(t, []string{"premium", "both"}, payloads(premium))
+	})
+	t.Run("rejects unknown targets", func(t *testing.T) {
+		err := r.Enqueue(&TransmitRequest{Payload: []byte("unknown")}, []string{"public", "other"})
+		assert.EqualError(t, err, `unknown transmission target "other"`)
+		time.Sleep(50 * time.Millisecond)
+		assert.Len(t, public.getReceived(), 2, "not sent to any target")
+	})
+	t.Run("HealthReport includes every client", func(t *testing.T) {
+		health := r.HealthReport()
+		assert.Len(t, health, 3)
+		assert.NoError(t, health[r.Name()])
+		assert.NoError(t, health["public:"+clients["public"].Name()])
+		assert.NoError(t, health["premium:"+clients["premium"].Name()])
+	})
+}
@@ -0,0 +1,69 @@
+package rpc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// SchemaRevision is the revision of transmitter.proto that this package was
+// generated from. Servers advertise it through ServerInfo, so that clients
+// can detect when they were built against a different schema.
+//
+// Whenever transmitter.proto changes, SchemaRevision must be incremented and
+// the fingerprint of the new schema registered in schemaRevisions.
+const SchemaRevision uint32 = 6
+
+// schemaRevisions maps every schema revision to its SchemaFingerprint
+var schemaRevisions = map[uint32]string{
+	// 1: adds ServerInfo
+	1: "a888841d8800da780840129d870279343f1ee3cf3eea82800bb150b97afc4bf8",
+	// 2: adds server capabilities to ServerInfo
+	2: "8c8f784346b3d8d6d4791d40ec738fd1c3ae3360bea3541c51b3efb7255d4bd5",
+	// 3: adds the Replication service
+	3: "dd39855149600bb2fad2645b9372258050963484fc040c5986c233cf6260220a",
+	// 4: adds the channel and stage timestamps of reports to TransmitRequest
+	4: "62f3d90ac16a7b05d1d1144f60a71b6f10053f65a359e433b1661ce442ecf5a2",
+	// 5: adds the reduced confidence flag of reports
+	5: "a3859d56f98fe359e465de646acf19cc916840d80927109243bec5f867185aeb",
+	// 6: adds the config digest that LatestReport requests are routed by
+	6: "6f04141eae2f1a2c5ddc82e0473776f0187a4d2f6438a24869309cf7c1b68a30",
+}
+
+// Schema returns the descriptor of transmitter.proto
+func Schema() protoreflect.FileDescriptor {
+	return File_transmitter_proto
+}
+
+// SchemaFingerprint returns a hash of the generated schema. Comments and
+// formatting do not affect it, but any change to the messages or the
+// service does.
+func SchemaFingerprint() (string, error) {
+	fdp := protodesc.ToFileDescriptorProto(Schema())
+	fdp.SourceCodeInfo = nil
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(fdp)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal schema: %w", err)
+	}
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:]), nil
+}
+
+// CheckSchemaRevision compares the schema revision advertised by a server
+// with SchemaRevision. Schema changes are backwards compatible, so a
+// mismatch is not fatal, but fields and methods that only one side knows
+// about will be ignored or rejected by the other.
+func CheckSchemaRevision(serverRevision uint32) error {
+	switch {
+	case serverRevision < SchemaRevision:
+		return fmt.Errorf("server uses older schema revision %d (client: %d); newer fields will be ignored by the server", serverRevision, SchemaRevision)
+	case serverRevision > SchemaRevision:
+		return fmt.Errorf("server uses newer schema revision %d (client: %d); fields added since will not be populated by the client", serverRevision, SchemaRevision)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,84 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+)
+
+func Test_SchemaFingerprint(t *testing.T) {
+	fingerprint, err := SchemaFingerprint()
+	require.NoError(t, err)
+	// If this fails, transmitter.proto was changed: increment SchemaRevision
+	// and register the new fingerprint in schemaRevisions
+	assert.Equal(t, schemaRevisions[SchemaRevision], fingerprint, "schema changed without bumping SchemaRevision")
+	for revision := uint32(1); revision <= SchemaRevision; revision++ {
+		assert.Contains(t, schemaRevisions, revision, "missing fingerprint for revision %d", revision)
+	}
+}
+
+func Test_CheckSchemaRevision(t *testing.T) {
+	require.NoError(t, CheckSchemaRevision(SchemaRevision))
+	assert.ErrorContains(t, CheckSchemaRevision(SchemaRevision-1), "server uses older schema revision")
+	assert.ErrorContains(t, CheckSchemaRevision(SchemaRevision+1), "server uses newer schema revision")
+}
+
+type serverInfoConn struct {
+	mockConn
+	info *ServerInfoResponse
+	err  error
+}
+
+func (c *serverInfoConn) Invoke(ctx context.Context, method string, args any, reply any, opts ...grpc.CallOption) error {
+	if res, ok := reply.(*ServerInfoResponse); ok {
+		if c.info != nil {
+			proto.Merge(res, c.info)
+		}
+		return c.err
+	}
+	return c.mockConn.Invoke(ctx, method, args, reply, opts...)
+}
+
+func Test_Client_ServerInfo(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		conn *serverInfoConn
+		warn string
+	}{
+		{"same revision", &serverInfoConn{info: &ServerInfoResponse{SchemaRevision: SchemaRevision}}, ""},
+		{"older revision", &serverInfoConn{info: &ServerInfoResponse{SchemaRevision: SchemaRevision - 1}}, "server uses older schema revision"},
+		{"newer revision", &serverInfoConn{info: &ServerInfoResponse{SchemaRevision: SchemaRevision + 1}}, "server uses newer schema revision"},
+		{"ServerInfo unimplemented", &serverInfoConn{err: status.Error(codes.Unimplemented, "")}, "server uses older schema revision 0"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			lggr, logs := logger.TestObserved(t, zapcore.DebugLevel)
+			c := NewClient(lggr, tc.conn, ClientConfig{ServerURL: "server-info.example"})
+			require.NoError(t, c.Start(tests.Context(t)))
+			t.Cleanup(func() { assert.NoError(t, c.Close()) })
+
+			if tc.warn == "" {
+				require.Eventually(t, func() bool {
+					return logs.FilterMessage("Server schema revision matches client").Len() == 1
+				}, tests.WaitTimeout(t), 10*time.Millisecond)
+				return
+			}
+			require.Eventually(t, func() bool {
+				return logs.FilterMessage("Server schema revision differs from client").Len() == 1
+			}, tests.WaitTimeout(t), 10*time.Millisecond)
+			entry := logs.FilterMessage("Server schema revision differs from client").All()[0]
+			assert.Equal(t, zapcore.WarnLevel, entry.Level)
+			assert.Contains(t, entry.ContextMap()["err"], tc.warn)
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+
+	"github.com/smartcontractkit/chainlink-data-streams/rpc"
+)
+
+type Config struct {
+	// Dir is the root directory that partitions are written under
+	Dir string
+	// ChannelDefinitions are used to map the feed IDs of EVM reports to
+	// their channels
+	ChannelDefinitions llotypes.ChannelDefinitions
+}
+
+// Exporter writes archived reports to Parquet files, partitioned by date and
+// channel using Hive-style directory names so that query engines can prune
+// partitions, e.g.
+//
+//	<dir>/date=2024-09-18/channel=1/part-123456789.parquet
+//
+// Every successful call to Export writes a new file to each partition it
+// touches; it never modifies existing files.
+type Exporter struct {
+	dir     string
+	decoder *Decoder
+	// writeRecords encodes a partition's records to w
+	writeRecords func(w io.Writer, records []Record) error
+}
+
+func NewExporter(cfg Config) (*Exporter, error) {
+	if cfg.Dir == "" {
+		return nil, errors.New("dir is required")
+	}
+	d, err := NewDecoder(cfg.ChannelDefinitions)
+	if err != nil {
+		return nil, fmt.Errorf("invalid channel definitions: %w", err)
+	}
+	return &Exporter{cfg.Dir, d, writeParquet}, nil
+}
+
+type partition struct {
+	date      string
+	channelID llotypes.ChannelID
+}
+
+func (p partition) path() string {
+	return filepath.Join(fmt.Sprintf("date=%s", p.date), fmt.Sprintf("channel=%d", p.channelID))
+}
+
+// Export decodes the given reports, keyed by idempotency key, and writes
+// them to their partitions. It returns the paths of the files written.
+//
+// Export either writes every partition or none: all reports are decoded,
+// and every partition is written to a hidden temporary file, before any
+// file is renamed into place. If an export fails, it removes the files it
+// wrote.
+func (e *Exporter) Export(ctx context.Context, reports map[string]*rpc.TransmitRequest) ([]string, error) {
+	partitions := make(map[partition][]Record)
+	for key, req := range reports {
+		r, err := e.decoder.Decode(key, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode report with idempotency key %q: %w", key, err)
+		}
+		p := partition{r.Date(), r.ChannelID}
+		partitions[p] = append(partitions[p], r)
+	}
+
+	keys := make([]partition, 0, len(partitions))
+	for p := range partitions {
+		keys = append(keys, p)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].date != keys[j].date {
+			return keys[i].date < keys[j].date
+		}
+		return keys[i].channelID < keys[j].channelID
+	})
+
+	var written []partitionFile
+	defer func() {
+		for _, f := range written {
+			os.Remove(f.tmp) //nolint:errcheck // no-op once renamed
+		}
+	}()
+	for _, p := range keys {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		records := partitions[p]
+		sort.Slice(records, func(i, j int) bool {
+			if records[i].SeqNr != records[j].SeqNr {
+				return records[i].SeqNr < records[j].SeqNr
+			}
+			return records[i].IdempotencyKey < records[j].IdempotencyKey
+		})
+		f, err := e.writePartition(p, records)
+		if err != nil {
+			return nil, fmt.Errorf("failed to write partition %s: %w", p.path(), err)
+		}
+		written = append(written, f)
+	}
+
+	paths := make([]string, 0, len(written))
+	for _, f := range written {
+		if err := os.Rename(f.tmp, f.path); err != nil {
+			for _, path := range paths {
+				os.Remove(path) //nolint:errcheck // best effort
+			}
+			return nil, fmt.Errorf("failed to rename %s: %w", f.tmp, err)
+		}
+		paths = append(paths, f.path)
+	}
+	return paths, nil
+}
+
+// partitionFile is a partition written to the temporary file tmp, to be
+// renamed to path
+type partitionFile struct {
+	tmp  string
+	path string
+}
+
+// writePartition writes records to a hidden temporary file, so readers never
+// see partially written files. The caller renames it into place.
+func (e *Exporter) writePartition(p partition, records []Record) (partitionFile, error) {
+	dir := filepath.Join(e.dir, p.path())
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return partitionFile{}, err
+	}
+	f, err := os.CreateTemp(dir, ".part-*.tmp")
+	if err != nil {
+		return partitionFile{}, err
+	}
+	tmp := f.Name()
+
+	if err := e.writeRecords(f, records); err != nil {
+		f.Close()
+		os.Remove(tmp) //nolint:errcheck // best effort
+		return partitionFile{}, err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp) //nolint:errcheck // best effort
+		return partitionFile{}, err
+	}
+	// Reuse the random part of the temporary name to avoid collisions with
+	// files written by concurrent exports
+	base := filepath.Base(tmp)
+	return partitionFile{tmp, filepath.Join(dir, base[1:len(base)-len(".tmp")]+".parquet")}, nil
+}
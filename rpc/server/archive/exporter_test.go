@@ -0,0 +1,203 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+
+	"github.com/smartcontractkit/chainlink-data-streams/llo"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc"
+)
+
+const (
+	testFeedID = "0x0003000000000000000000000000000000000000000000000000000000000001"
+	// 2024-09-18T14:41:30Z
+	testTimestamp = 1726670490
+)
+
+var testSigs = []types.AttributedOnchainSignature{{Signer: 1, Signature: make([]byte, 65)}, {Signer: 2, Signature: make([]byte, 65)}}
+
+func testChannelDefinitions() llotypes.ChannelDefinitions {
+	return llotypes.ChannelDefinitions{
+		1: {
+			ReportFormat: llotypes.ReportFormatEVMPremiumLegacy,
+			Streams:      []llotypes.Stream{{StreamID: 1}, {StreamID: 2}, {StreamID: 3}},
+			Opts:         []byte(`{"feedID":"` + testFeedID + `","baseUSDFee":"1","multiplier":"1"}`),
+		},
+		2: {
+			ReportFormat: llotypes.ReportFormatJSON,
+			Streams:      []llotypes.Stream{{StreamID: 1}},
+		},
+	}
+}
+
+func evmTransmitRequest(t *testing.T, seqNr uint64, ts uint32) *rpc.TransmitRequest {
+	t.Helper()
+	cdc := llo.EVMPremiumLegacyReportCodec{}
+	report := llo.Report{
+		ObservationTimestampSeconds: ts,
+		Values: []llo.StreamValue{
+			llo.ToDecimal(decimal.NewFromInt(2000)),
+			llo.ToDecimal(decimal.NewFromInt(20)),
+			&llo.Quote{Bid: decimal.NewFromInt(1), Benchmark: decimal.NewFromInt(2), Ask: decimal.NewFromInt(3)},
+		},
+	}
+	encoded, err := cdc.Encode(context.Background(), report, testChannelDefinitions()[1])
+	require.NoError(t, err)
+	payload, err := cdc.Pack(types.ConfigDigest{1}, seqNr, encoded, testSigs)
+	require.NoError(t, err)
+	return &rpc.TransmitRequest{Payload: payload, ReportFormat: uint32(llotypes.ReportFormatEVMPremiumLegacy)}
+}
+
+func jsonTransmitRequest(t *testing.T, seqNr uint64, ts uint32) *rpc.TransmitRequest {
+	t.Helper()
+	cdc := llo.JSONReportCodec{}
+	report := llo.Report{
+		ConfigDigest:                types.ConfigDigest{2},
+		SeqNr:                       seqNr,
+		ChannelID:                   2,
+		ObservationTimestampSeconds: ts,
+		Values:                      []llo.StreamValue{llo.ToDecimal(decimal.NewFromInt(42))},
+	}
+	encoded, err := cdc.Encode(context.Background(), report, testChannelDefinitions()[2])
+	require.NoError(t, err)
+	payload, err := cdc.Pack(types.ConfigDigest{2}, seqNr, encoded, testSigs)
+	require.NoError(t, err)
+	return &rpc.TransmitRequest{Payload: payload, ReportFormat: uint32(llotypes.ReportFormatJSON)}
+}
+
+func Test_Exporter(t *testing.T) {
+	ctx := tests.Context(t)
+
+	t.Run("NewExporter validates config", func(t *testing.T) {
+		_, err := NewExporter(Config{})
+		assert.EqualError(t, err, "dir is required")
+
+		cds := testChannelDefinitions()
+		cds[3] = cds[1]
+		_, err = NewExporter(Config{Dir: t.TempDir(), ChannelDefinitions: cds})
+		assert.ErrorContains(t, err, "share feed ID "+testFeedID)
+	})
+
+	t.Run("writes reports partitioned by date and channel", func(t *testing.T) {
+		dir := t.TempDir()
+		e, err := NewExporter(Config{Dir: dir, ChannelDefinitions: testChannelDefinitions()})
+		require.NoError(t, err)
+
+		paths, err := e.Export(ctx, map[string]*rpc.TransmitRequest{
+			"evm-2":       evmTransmitRequest(t, 2, testTimestamp),
+			"evm-1":       evmTransmitRequest(t, 1, testTimestamp),
+			"evm-nextday": evmTransmitRequest(t, 3, testTimestamp+86400),
+			"json-1":      jsonTransmitRequest(t, 1, testTimestamp),
+		})
+		require.NoError(t, err)
+		require.Len(t, paths, 3)
+		assert.Equal(t, filepath.Join(dir, "date=2024-09-18", "channel=1"), filepath.Dir(paths[0]))
+		assert.Equal(t, filepath.Join(dir, "date=2024-09-18", "channel=2"), filepath.Dir(paths[1]))
+		assert.Equal(t, filepath.Join(dir, "date=2024-09-19", "channel=1"), filepath.Dir(paths[2]))
+		for _, path := range paths {
+			assert.Regexp(t, `^part-\d+\.parquet$`, filepath.Base(path))
+		}
+
+		records, err := ReadFile(paths[0])
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		assert.Equal(t, []string{"evm-1", "evm-2"}, []string{records[0].IdempotencyKey, records[1].IdempotencyKey}, "records are sorted by seqNr")
+		r := records[0]
+		assert.Equal(t, uint32(1), r.ChannelID)
+		assert.Equal(t, "evm_premium_legacy", r.ReportFormat)
+		assert.Equal(t, testFeedID, r.FeedID)
+		assert.Equal(t, types.ConfigDigest{1}.Hex(), r.ConfigDigest)
+		assert.Equal(t, uint64(1), r.SeqNr)
+		assert.Equal(t, uint32(testTimestamp), r.ObservationTimestampSeconds)
+		assert.Contains(t, r.Fields, `"BenchmarkPrice":2`)
+		assert.Len(t, r.Report, 9*32)
+		assert.Equal(t, evmTransmitRequest(t, 1, testTimestamp).Payload, r.Payload)
+		require.Len(t, r.Signatures, 2)
+		assert.Equal(t, uint32(0), r.Signatures[0].Signer, "EVM payloads do not encode signers")
+		assert.Len(t, r.Signatures[0].Signature, 65)
+
+		records, err = ReadFile(paths[1])
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		r = records[0]
+		assert.Equal(t, uint32(2), r.ChannelID)
+		assert.Equal(t, "json", r.ReportFormat)
+		assert.Empty(t, r.FeedID)
+		assert.Equal(t, types.ConfigDigest{2}.Hex(), r.ConfigDigest)
+		assert.JSONEq(t, string(r.Report), r.Fields)
+		assert.Equal(t, []Signature{{1, make([]byte, 65)}, {2, make([]byte, 65)}}, r.Signatures)
+
+		t.Run("subsequent exports add new files", func(t *testing.T) {
+			more, err := e.Export(ctx, map[string]*rpc.TransmitRequest{"evm-4": evmTransmitRequest(t, 4, testTimestamp)})
+			require.NoError(t, err)
+			require.Len(t, more, 1)
+			assert.NotEqual(t, paths[0], more[0])
+			entries, err := os.ReadDir(filepath.Dir(more[0]))
+			require.NoError(t, err)
+			assert.Len(t, entries, 2)
+		})
+	})
+
+	t.Run("fails without writing anything if a report cannot be decoded", func(t *testing.T) {
+		dir := t.TempDir()
+		e, err := NewExporter(Config{Dir: dir})
+		require.NoError(t, err)
+
+		_, err = e.Export(ctx, map[string]*rpc.TransmitRequest{
+			"json-1": jsonTransmitRequest(t, 1, testTimestamp),
+			"evm-1":  evmTransmitRequest(t, 1, testTimestamp),
+		})
+		assert.EqualError(t, err, `failed to decode report with idempotency key "evm-1": no channel found for feed ID `+testFeedID)
+
+		_, err = e.Export(ctx, map[string]*rpc.TransmitRequest{
+			"retirement": {Payload: []byte("{}"), ReportFormat: uint32(llotypes.ReportFormatRetirement)},
+		})
+		assert.EqualError(t, err, `failed to decode report with idempotency key "retirement": unsupported ReportFormat="retirement"`)
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+	t.Run("fails without leaving files behind if a partition cannot be written", func(t *testing.T) {
+		dir := t.TempDir()
+		e, err := NewExporter(Config{Dir: dir})
+		require.NoError(t, err)
+		writes := 0
+		e.writeRecords = func(w io.Writer, records []Record) error {
+			if writes++; writes == 2 {
+				return errors.New("disk full")
+			}
+			return writeParquet(w, records)
+		}
+
+		_, err = e.Export(ctx, map[string]*rpc.TransmitRequest{
+			"json-1": jsonTransmitRequest(t, 1, testTimestamp),
+			"json-2": jsonTransmitRequest(t, 2, testTimestamp+86400),
+		})
+		require.EqualError(t, err, "failed to write partition date=2024-09-19/channel=2: disk full")
+
+		var files []string
+		require.NoError(t, filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if !d.IsDir() {
+				files = append(files, path)
+			}
+			return err
+		}))
+		assert.Empty(t, files)
+	})
+}
@@ -0,0 +1,20 @@
+package archive
+
+import (
+	"io"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func writeParquet(w io.Writer, records []Record) error {
+	pw := parquet.NewGenericWriter[Record](w, parquet.Compression(&parquet.Snappy))
+	if _, err := pw.Write(records); err != nil {
+		return err
+	}
+	return pw.Close()
+}
+
+// ReadFile reads all records from a Parquet file written by an Exporter
+func ReadFile(path string) ([]Record, error) {
+	return parquet.ReadFile[Record](path)
+}
@@ -0,0 +1,137 @@
+// Package archive exports reports persisted by the transmitter server to
+// Parquet files for analytics pipelines and long-term cold storage.
+package archive
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+
+	"github.com/smartcontractkit/chainlink-data-streams/llo"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc"
+)
+
+// Record is a single archived report, as written to Parquet
+type Record struct {
+	ChannelID    uint32 `parquet:"channel_id"`
+	ReportFormat string `parquet:"report_format"`
+	// FeedID is only set for EVM report formats
+	FeedID       string `parquet:"feed_id,optional"`
+	ConfigDigest string `parquet:"config_digest"`
+	SeqNr        uint64 `parquet:"seq_nr"`
+	// ObservationTimestampSeconds determines the date partition of the record
+	ObservationTimestampSeconds uint32 `parquet:"observation_timestamp_seconds"`
+	// Fields holds the decoded report fields, JSON-encoded
+	Fields         string      `parquet:"fields"`
+	Report         []byte      `parquet:"report"`
+	Payload        []byte      `parquet:"payload"`
+	Signatures     []Signature `parquet:"signatures,list"`
+	IdempotencyKey string      `parquet:"idempotency_key"`
+}
+
+type Signature struct {
+	Signer    uint32 `parquet:"signer"`
+	Signature []byte `parquet:"signature"`
+}
+
+// Date returns the UTC date of the report's observation timestamp, formatted
+// as YYYY-MM-DD
+func (r Record) Date() string {
+	return time.Unix(int64(r.ObservationTimestampSeconds), 0).UTC().Format(time.DateOnly)
+}
+
+// Decoder turns transmitted payloads into records
+type Decoder struct {
+	// EVM reports identify their feed but not their channel, so the channel
+	// is looked up from the channel definitions
+	feedChannels map[llo.FeedID]llotypes.ChannelID
+}
+
+// NewDecoder returns a Decoder for reports of the given channels
+func NewDecoder(cds llotypes.ChannelDefinitions) (*Decoder, error) {
+	feedChannels := make(map[llo.FeedID]llotypes.ChannelID)
+	for cid, cd := range cds {
+		if cd.ReportFormat != llotypes.ReportFormatEVMPremiumLegacy {
+			continue
+		}
+		feedID, err := llo.ParseEVMFeedID(cd.Opts)
+		if err != nil {
+			return nil, fmt.Errorf("channel %d: %w", cid, err)
+		}
+		if other, exists := feedChannels[feedID]; exists {
+			return nil, fmt.Errorf("channels %d and %d share feed ID %s", other, cid, feedID)
+		}
+		feedChannels[feedID] = cid
+	}
+	return &Decoder{feedChannels}, nil
+}
+
+// ChannelID returns the channel of the EVM feed with the given feed ID
+func (d *Decoder) ChannelID(feedID llo.FeedID) (llotypes.ChannelID, bool) {
+	cid, exists := d.feedChannels[feedID]
+	return cid, exists
+}
+
+// Decode decodes a transmitted report, stored under the given idempotency key
+func (d *Decoder) Decode(key string, req *rpc.TransmitRequest) (r Record, err error) {
+	rf := llotypes.ReportFormat(req.ReportFormat)
+	r = Record{
+		ReportFormat:   rf.String(),
+		Payload:        req.Payload,
+		IdempotencyKey: key,
+	}
+
+	var sigs []types.AttributedOnchainSignature
+	switch rf {
+	case llotypes.ReportFormatEVMPremiumLegacy:
+		var digest types.ConfigDigest
+		digest, r.SeqNr, r.Report, sigs, err = llo.UnpackEVMPayload(req.Payload)
+		if err != nil {
+			return r, err
+		}
+		r.ConfigDigest = digest.Hex()
+		feedID, fields, err := llo.EVMPremiumLegacyReportCodec{}.Decode(r.Report)
+		if err != nil {
+			return r, err
+		}
+		cid, exists := d.feedChannels[feedID]
+		if !exists {
+			return r, fmt.Errorf("no channel found for feed ID %s", feedID)
+		}
+		r.ChannelID = cid
+		r.FeedID = feedID.Hex()
+		r.ObservationTimestampSeconds = fields.Timestamp
+		b, err := json.Marshal(fields)
+		if err != nil {
+			return r, fmt.Errorf("failed to encode report fields: %w", err)
+		}
+		r.Fields = string(b)
+	case llotypes.ReportFormatJSON:
+		var digest types.ConfigDigest
+		digest, r.SeqNr, r.Report, sigs, err = llo.JSONReportCodec{}.Unpack(req.Payload)
+		if err != nil {
+			return r, err
+		}
+		r.ConfigDigest = digest.Hex()
+		report, err := llo.JSONReportCodec{}.Decode(r.Report)
+		if err != nil {
+			return r, err
+		}
+		r.ChannelID = report.ChannelID
+		r.ObservationTimestampSeconds = report.ObservationTimestampSeconds
+		// The report is already JSON-encoded
+		r.Fields = string(r.Report)
+	default:
+		return r, fmt.Errorf("unsupported ReportFormat=%q", rf)
+	}
+
+	r.Signatures = make([]Signature, len(sigs))
+	for i, sig := range sigs {
+		r.Signatures[i] = Signature{Signer: uint32(sig.Signer), Signature: sig.Signature}
+	}
+	return r, nil
+}
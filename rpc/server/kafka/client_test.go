@@ -0,0 +1,41 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+func Test_ClientWriter(t *testing.T) {
+	t.Run("requires brokers", func(t *testing.T) {
+		_, err := NewClientWriter(ClientConfig{})
+		require.EqualError(t, err, "at least one broker is required")
+	})
+	t.Run("creates a client without connecting", func(t *testing.T) {
+		w, err := NewClientWriter(ClientConfig{Brokers: []string{"localhost:9092"}, ClientID: "llo-server"})
+		require.NoError(t, err)
+		w.Close()
+	})
+	t.Run("converts messages to records", func(t *testing.T) {
+		r := toRecord(Message{
+			Topic: "reports",
+			Key:   []byte("key"),
+			Value: []byte("value"),
+			Headers: []Header{
+				{HeaderTenant, []byte("tenant-a")},
+				{HeaderReportFormat, []byte("json")},
+			},
+		})
+		assert.Equal(t, &kgo.Record{
+			Topic: "reports",
+			Key:   []byte("key"),
+			Value: []byte("value"),
+			Headers: []kgo.RecordHeader{
+				{Key: HeaderTenant, Value: []byte("tenant-a")},
+				{Key: HeaderReportFormat, Value: []byte("json")},
+			},
+		}, r)
+	})
+}
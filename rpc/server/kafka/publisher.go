@@ -0,0 +1,90 @@
+// Package kafka contains a server.Sink that publishes persisted reports to a
+// Kafka topic.
+package kafka
+
+import (
+	"context"
+	"errors"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+
+	"github.com/smartcontractkit/chainlink-data-streams/rpc"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc/server"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc/server/sink"
+)
+
+// Header names set on every message
+const (
+	HeaderTenant       = "tenant"
+	HeaderReportFormat = "reportFormat"
+)
+
+type Header struct {
+	Key   string
+	Value []byte
+}
+
+// Message is a single Kafka record
+type Message struct {
+	Topic   string
+	Key     []byte
+	Value   []byte
+	Headers []Header
+}
+
+// Writer writes messages to Kafka. ClientWriter implements it with franz-go;
+// other implementations should be configured for idempotent production so
+// that retried writes are not duplicated.
+type Writer interface {
+	WriteMessages(ctx context.Context, msgs ...Message) error
+}
+
+type Config struct {
+	// Topic that reports are published to
+	Topic string
+	// ChannelDefinitions are used to map the feed IDs of EVM reports to
+	// their channels
+	ChannelDefinitions llotypes.ChannelDefinitions
+}
+
+var _ server.Sink = (*Publisher)(nil)
+
+// Publisher publishes every persisted report to a Kafka topic as a
+// sink.ReportMessage, keyed by the report's idempotency key. The server only
+// persists the first delivery of each report, and the key is identical
+// across retries and nodes, so consumers and compacted topics can discard
+// any duplicates that are published when a write is retried.
+type Publisher struct {
+	w       Writer
+	topic   string
+	encoder *sink.Encoder
+}
+
+func NewPublisher(w Writer, cfg Config) (*Publisher, error) {
+	if cfg.Topic == "" {
+		return nil, errors.New("topic is required")
+	}
+	e, err := sink.NewEncoder(cfg.ChannelDefinitions)
+	if err != nil {
+		return nil, err
+	}
+	return &Publisher{w, cfg.Topic, e}, nil
+}
+
+func (p *Publisher) Name() string { return "KafkaPublisher" }
+
+func (p *Publisher) Publish(ctx context.Context, tenant, idempotencyKey string, req *rpc.TransmitRequest) error {
+	m, value, err := p.encoder.Encode(tenant, idempotencyKey, req)
+	if err != nil {
+		return err
+	}
+	return p.w.WriteMessages(ctx, Message{
+		Topic: p.topic,
+		Key:   []byte(idempotencyKey),
+		Value: value,
+		Headers: []Header{
+			{HeaderTenant, []byte(tenant)},
+			{HeaderReportFormat, []byte(m.ReportFormat)},
+		},
+	})
+}
@@ -0,0 +1,112 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+
+	"github.com/smartcontractkit/chainlink-data-streams/llo"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc/server"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc/server/sink"
+)
+
+type mockWriter struct {
+	err  error
+	msgs []Message
+}
+
+func (m *mockWriter) WriteMessages(_ context.Context, msgs ...Message) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.msgs = append(m.msgs, msgs...)
+	return nil
+}
+
+func jsonTransmitRequest(t *testing.T, seqNr uint64) *rpc.TransmitRequest {
+	t.Helper()
+	cdc := llo.JSONReportCodec{}
+	report := llo.Report{
+		ConfigDigest:                types.ConfigDigest{1},
+		SeqNr:                       seqNr,
+		ChannelID:                   2,
+		ObservationTimestampSeconds: 1726670490,
+		Values:                      []llo.StreamValue{llo.ToDecimal(decimal.NewFromInt(42))},
+	}
+	encoded, err := cdc.Encode(context.Background(), report, llotypes.ChannelDefinition{})
+	require.NoError(t, err)
+	payload, err := cdc.Pack(types.ConfigDigest{1}, seqNr, encoded, nil)
+	require.NoError(t, err)
+	return &rpc.TransmitRequest{
+		Payload:        payload,
+		ReportFormat:   uint32(llotypes.ReportFormatJSON),
+		IdempotencyKey: rpc.IdempotencyKey(payload, uint32(llotypes.ReportFormatJSON)),
+	}
+}
+
+func Test_Publisher(t *testing.T) {
+	ctx := tests.Context(t)
+
+	t.Run("NewPublisher requires topic", func(t *testing.T) {
+		_, err := NewPublisher(&mockWriter{}, Config{})
+		assert.EqualError(t, err, "topic is required")
+	})
+
+	t.Run("publishes each persisted report once", func(t *testing.T) {
+		w := &mockWriter{}
+		p, err := NewPublisher(w, Config{Topic: "reports"})
+		require.NoError(t, err)
+		s, err := server.NewServer(logger.Test(t), server.Config{Sinks: []server.Sink{p}}, server.NewInMemoryReportStore())
+		require.NoError(t, err)
+
+		req := jsonTransmitRequest(t, 5)
+		for i := 0; i < 3; i++ {
+			// every node of the DON delivers the same report
+			res, err := s.Transmit(ctx, req)
+			require.NoError(t, err)
+			assert.Zero(t, res.Code)
+		}
+
+		require.Len(t, w.msgs, 1)
+		msg := w.msgs[0]
+		assert.Equal(t, "reports", msg.Topic)
+		assert.Equal(t, []byte(req.IdempotencyKey), msg.Key)
+		assert.Equal(t, []Header{{HeaderTenant, []byte(server.DefaultTenantName)}, {HeaderReportFormat, []byte("json")}}, msg.Headers)
+
+		var rm sink.ReportMessage
+		require.NoError(t, json.Unmarshal(msg.Value, &rm))
+		assert.Equal(t, server.DefaultTenantName, rm.Tenant)
+		assert.Equal(t, req.IdempotencyKey, rm.IdempotencyKey)
+		assert.Equal(t, "json", rm.ReportFormat)
+		assert.Equal(t, uint32(2), rm.ChannelID)
+		assert.Empty(t, rm.FeedID)
+		assert.Equal(t, types.ConfigDigest{1}.Hex(), rm.ConfigDigest)
+		assert.Equal(t, uint64(5), rm.SeqNr)
+		assert.Equal(t, uint32(1726670490), rm.ObservationTimestampSeconds)
+		assert.Contains(t, string(rm.Fields), `"ChannelID":2`)
+		assert.Equal(t, req.Payload, rm.Payload)
+	})
+
+	t.Run("returns errors", func(t *testing.T) {
+		p, err := NewPublisher(&mockWriter{err: errors.New("broker unavailable")}, Config{Topic: "reports"})
+		require.NoError(t, err)
+		err = p.Publish(ctx, "tenant", "key", jsonTransmitRequest(t, 5))
+		assert.EqualError(t, err, "broker unavailable")
+
+		err = p.Publish(ctx, "tenant", "key", &rpc.TransmitRequest{Payload: []byte("garbage"), ReportFormat: uint32(llotypes.ReportFormatJSON)})
+		assert.ErrorContains(t, err, "failed to decode report")
+	})
+}
@@ -0,0 +1,37 @@
+package server
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/smartcontractkit/chainlink-data-streams/rpc"
+)
+
+// observeReportLatency records how long after its observations timestamp
+// the report reached each stage, ending with its persistence by this server
+// at persistedAt. Reports without an observations timestamp are not
+// measured. Latencies span the clocks of the oracle and the server, so
+// stages that appear to precede the observation due to clock skew are
+// dropped rather than recorded as zero.
+func observeReportLatency(tenant string, req *rpc.TransmitRequest, persistedAt time.Time) {
+	ts := req.Timestamps
+	observation := ts.GetObservation().AsTime()
+	if observation.IsZero() {
+		return
+	}
+	channelID := strconv.FormatUint(uint64(req.ChannelId), 10)
+	for _, stage := range []struct {
+		name string
+		at   time.Time
+	}{
+		{"outcome", ts.GetOutcome().AsTime()},
+		{"report_encoded", ts.GetReportEncoded().AsTime()},
+		{"transmit", ts.GetTransmit().AsTime()},
+		{"persisted", persistedAt},
+	} {
+		if stage.at.IsZero() || stage.at.Before(observation) {
+			continue
+		}
+		promReportLatency.WithLabelValues(tenant, channelID, stage.name).Observe(stage.at.Sub(observation).Seconds())
+	}
+}
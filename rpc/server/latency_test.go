@@ -0,0 +1,69 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink-data-streams/rpc"
+)
+
+func reportLatencySamples(t *testing.T, tenant, channelID, stage string) (count uint64, sum float64) {
+	var m dto.Metric
+	require.NoError(t, promReportLatency.WithLabelValues(tenant, channelID, stage).(prometheus.Histogram).Write(&m))
+	return m.GetHistogram().GetSampleCount(), m.GetHistogram().GetSampleSum()
+}
+
+func Test_observeReportLatency(t *testing.T) {
+	tenant := "latency-tenant"
+	observation := time.Unix(100, 0)
+	persistedAt := time.Unix(103, 0)
+
+	observeReportLatency(tenant, &rpc.TransmitRequest{
+		ChannelId: 1,
+		Timestamps: &rpc.ReportTimestamps{
+			Observation:   rpc.NewTimestamp(observation),
+			Outcome:       rpc.NewTimestamp(observation.Add(250 * time.Millisecond)),
+			ReportEncoded: rpc.NewTimestamp(observation.Add(500 * time.Millisecond)),
+			// the oracle's clock is behind the server's
+			Transmit: rpc.NewTimestamp(observation.Add(-time.Second)),
+		},
+	}, persistedAt)
+	for stage, expected := range map[string]float64{"outcome": 0.25, "report_encoded": 0.5, "persisted": 3} {
+		count, sum := reportLatencySamples(t, tenant, "1", stage)
+		assert.Equal(t, uint64(1), count, stage)
+		assert.InDelta(t, expected, sum, 1e-9, stage)
+	}
+	count, _ := reportLatencySamples(t, tenant, "1", "transmit")
+	assert.Zero(t, count, "stages before the observation are dropped")
+
+	// without an observations timestamp, nothing is measured
+	observeReportLatency(tenant, &rpc.TransmitRequest{ChannelId: 2}, persistedAt)
+	observeReportLatency(tenant, &rpc.TransmitRequest{ChannelId: 2, Timestamps: &rpc.ReportTimestamps{Outcome: rpc.NewTimestamp(observation)}}, persistedAt)
+	count, _ = reportLatencySamples(t, tenant, "2", "persisted")
+	assert.Zero(t, count)
+}
+
+func Test_Server_ReportLatency(t *testing.T) {
+	s, err := NewServer(logger.Test(t), Config{}, NewInMemoryReportStore())
+	require.NoError(t, err)
+	before, _ := reportLatencySamples(t, DefaultTenantName, "42", "persisted")
+
+	res, err := s.Transmit(tests.Context(t), &rpc.TransmitRequest{
+		Payload:    []byte("report"),
+		ChannelId:  42,
+		Timestamps: rpc.NewReportTimestamps(time.Now().Add(-time.Second), time.Time{}, time.Time{}),
+	})
+	require.NoError(t, err)
+	require.Zero(t, res.Code)
+
+	count, _ := reportLatencySamples(t, DefaultTenantName, "42", "persisted")
+	assert.Equal(t, before+1, count)
+}
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+
+	"github.com/smartcontractkit/chainlink-data-streams/llo"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc"
+)
+
+// ErrReportNotFound is returned by a LatestReportStore that has no report
+// for the requested feed
+var ErrReportNotFound = errors.New("report not found")
+
+// LatestReportStore is implemented by ReportStores that can serve the
+// LatestReport RPC, which looks up EVM reports by their feed ID
+type LatestReportStore interface {
+	// LatestFeedReport returns the most recent report stored for the feed
+	// by the tenant with the given storage prefix, or an error wrapping
+	// ErrReportNotFound. Reports of other tenants must not be returned.
+	LatestFeedReport(ctx context.Context, storagePrefix string, feedID llo.FeedID) (*rpc.TransmitRequest, error)
+}
+
+// LatestReport returns the most recent EVM report stored for a feed by the
+// tenant that the request's config digest is routed to. If requested, the response includes the report's attestation; signatures are
+// attributed in the order they appear in the payload, since EVM payloads do
+// not encode signers.
+//
+// It requires the server's ReportStore to implement LatestReportStore.
+func (s *Server) LatestReport(ctx context.Context, req *rpc.LatestReportRequest) (*rpc.LatestReportResponse, error) {
+	lrs, ok := s.store.(LatestReportStore)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "report store does not support LatestReport")
+	}
+	if len(req.FeedId) != len(llo.FeedID{}) {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("feedId must be %d bytes, got %d", len(llo.FeedID{}), len(req.FeedId)))
+	}
+	feedID := llo.FeedID(req.FeedId)
+	t, err := s.router.route(req.ConfigDigest)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	stored, err := lrs.LatestFeedReport(ctx, t.storagePrefix, feedID)
+	if errors.Is(err, ErrReportNotFound) {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("no report found for feed %s", feedID))
+	} else if err != nil {
+		s.lggr.Warnw("Failed to look up latest report", "feedID", feedID, "tenant", t.name, "err", err)
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	digest, seqNr, report, sigs, err := llo.UnpackEVMPayload(stored.Payload)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("stored report has invalid payload: %v", err))
+	}
+	res := &rpc.Report{
+		FeedId:            feedID[:],
+		Payload:           stored.Payload,
+		ConfigDigest:      digest[:],
+		ReducedConfidence: stored.ReducedConfidence,
+	}
+	if llotypes.ReportFormat(stored.ReportFormat) == llotypes.ReportFormatEVMPremiumLegacy {
+		if _, fields, err := (llo.EVMPremiumLegacyReportCodec{}).Decode(report); err == nil {
+			res.ObservationsTimestamp = int64(fields.Timestamp)
+		}
+	}
+	if req.IncludeAttestation {
+		res.Attestation = newAttestation(digest, seqNr, report, sigs)
+	}
+	return &rpc.LatestReportResponse{Report: res}, nil
+}
+
+func newAttestation(digest types.ConfigDigest, seqNr uint64, report types.Report, sigs []types.AttributedOnchainSignature) *rpc.Attestation {
+	a := &rpc.Attestation{
+		ConfigDigest: digest[:],
+		SeqNr:        seqNr,
+		Report:       report,
+		Signatures:   make([]*rpc.AttributedSignature, len(sigs)),
+	}
+	for i, sig := range sigs {
+		a.Signatures[i] = &rpc.AttributedSignature{Signer: uint32(sig.Signer), Signature: sig.Signature}
+	}
+	return a
+}
+
+// feedIDOfEVMPayload returns the feed ID of the report in an EVM payload, if
+// it is one
+func feedIDOfEVMPayload(payload []byte) (llo.FeedID, bool) {
+	_, _, report, _, err := llo.UnpackEVMPayload(payload)
+	if err != nil {
+		return llo.FeedID{}, false
+	}
+	feedID, err := llo.FeedIDFromEVMReport(report)
+	return feedID, err == nil
+}
@@ -0,0 +1,165 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink-data-streams/llo"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc"
+)
+
+func evmTransmitRequest(t *testing.T, feedID llo.FeedID, seqNr uint64, ts uint32) (*rpc.TransmitRequest, []byte) {
+	t.Helper()
+	cdc := llo.EVMPremiumLegacyReportCodec{}
+	cd := llotypes.ChannelDefinition{
+		ReportFormat: llotypes.ReportFormatEVMPremiumLegacy,
+		Opts:         []byte(`{"feedID":"` + feedID.Hex() + `","baseUSDFee":"1","multiplier":"1"}`),
+	}
+	report := llo.Report{
+		ObservationTimestampSeconds: ts,
+		Values: []llo.StreamValue{
+			llo.ToDecimal(decimal.NewFromInt(2000)),
+			llo.ToDecimal(decimal.NewFromInt(20)),
+			&llo.Quote{Bid: decimal.NewFromInt(1), Benchmark: decimal.NewFromInt(2), Ask: decimal.NewFromInt(3)},
+		},
+	}
+	encoded, err := cdc.Encode(context.Background(), report, cd)
+	require.NoError(t, err)
+	sigs := []types.AttributedOnchainSignature{{Signer: 0, Signature: bytes.Repeat([]byte{1}, 65)}, {Signer: 1, Signature: bytes.Repeat([]byte{2}, 65)}}
+	payload, err := cdc.Pack(types.ConfigDigest{1}, seqNr, encoded, sigs)
+	require.NoError(t, err)
+	return &rpc.TransmitRequest{Payload: payload, ReportFormat: uint32(llotypes.ReportFormatEVMPremiumLegacy)}, encoded
+}
+
+type failingLatestReportStore struct {
+	mockReportStore
+}
+
+func (f *failingLatestReportStore) LatestFeedReport(context.Context, string, llo.FeedID) (*rpc.TransmitRequest, error) {
+	return nil, errors.New("connection refused")
+}
+
+func Test_Server_LatestReport(t *testing.T) {
+	ctx := tests.Context(t)
+	feedID := llo.FeedID{0, 3, 1}
+
+	store := NewInMemoryReportStore()
+	s, err := NewServer(logger.Test(t), Config{}, store)
+	require.NoError(t, err)
+
+	t.Run("validates feed ID", func(t *testing.T) {
+		_, err := s.LatestReport(ctx, &rpc.LatestReportRequest{FeedId: []byte{1}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Contains(t, err.Error(), "feedId must be 32 bytes, got 1")
+	})
+	t.Run("unknown feed", func(t *testing.T) {
+		_, err := s.LatestReport(ctx, &rpc.LatestReportRequest{FeedId: feedID[:]})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	req1, _ := evmTransmitRequest(t, feedID, 1, 1726670490)
+	req2, report2 := evmTransmitRequest(t, feedID, 2, 1726670491)
+	req2.ReducedConfidence = true
+	for _, req := range []*rpc.TransmitRequest{req1, req2} {
+		res, err := s.Transmit(ctx, req)
+		require.NoError(t, err)
+		require.Zero(t, res.Code)
+	}
+	// non-EVM reports are not indexed by feed
+	_, err = s.Transmit(ctx, &rpc.TransmitRequest{Payload: []byte(`{}`), ReportFormat: uint32(llotypes.ReportFormatJSON)})
+	require.NoError(t, err)
+
+	t.Run("returns the latest report without attestation", func(t *testing.T) {
+		res, err := s.LatestReport(ctx, &rpc.LatestReportRequest{FeedId: feedID[:]})
+		require.NoError(t, err)
+		require.NotNil(t, res.Report)
+		assert.Equal(t, feedID[:], res.Report.FeedId)
+		assert.Equal(t, req2.Payload, res.Report.Payload)
+		assert.Equal(t, types.ConfigDigest{1}.Hex(), types.ConfigDigest(res.Report.ConfigDigest).Hex())
+		assert.Equal(t, int64(1726670491), res.Report.ObservationsTimestamp)
+		assert.True(t, res.Report.ReducedConfidence)
+		assert.Nil(t, res.Report.Attestation)
+	})
+	t.Run("returns the latest report with attestation", func(t *testing.T) {
+		res, err := s.LatestReport(ctx, &rpc.LatestReportRequest{FeedId: feedID[:], IncludeAttestation: true})
+		require.NoError(t, err)
+		a := res.Report.Attestation
+		require.NotNil(t, a)
+		assert.Equal(t, types.ConfigDigest{1}.Hex(), types.ConfigDigest(a.ConfigDigest).Hex())
+		assert.Equal(t, uint64(2), a.SeqNr)
+		assert.Equal(t, report2, a.Report)
+		require.Len(t, a.Signatures, 2)
+		for i, sig := range a.Signatures {
+			assert.Equal(t, uint32(i), sig.Signer)
+			assert.Equal(t, bytes.Repeat([]byte{byte(i + 1)}, 65), sig.Signature)
+		}
+	})
+	t.Run("store without LatestReportStore support", func(t *testing.T) {
+		s, err := NewServer(logger.Test(t), Config{}, &mockReportStore{})
+		require.NoError(t, err)
+		_, err = s.LatestReport(ctx, &rpc.LatestReportRequest{FeedId: feedID[:]})
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+	t.Run("store error", func(t *testing.T) {
+		s, err := NewServer(logger.Test(t), Config{}, &failingLatestReportStore{})
+		require.NoError(t, err)
+		_, err = s.LatestReport(ctx, &rpc.LatestReportRequest{FeedId: feedID[:]})
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+}
+
+func Test_Server_LatestReport_Tenants(t *testing.T) {
+	ctx := tests.Context(t)
+	feedID := llo.FeedID{0, 3, 4}
+	cdA, cdB, cdC := types.ConfigDigest{2}, types.ConfigDigest{3}, types.ConfigDigest{4}
+	s, err := NewServer(logger.Test(t), Config{Tenants: []TenantConfig{
+		{Name: "a", ConfigDigests: []types.ConfigDigest{cdA}},
+		{Name: "b", ConfigDigests: []types.ConfigDigest{cdB}},
+		{Name: "c", ConfigDigests: []types.ConfigDigest{cdC}},
+	}}, NewInMemoryReportStore())
+	require.NoError(t, err)
+
+	reqA, _ := evmTransmitRequest(t, feedID, 1, 1726670490)
+	reqA.ConfigDigest = cdA[:]
+	reqB, _ := evmTransmitRequest(t, feedID, 2, 1726670491)
+	reqB.ConfigDigest = cdB[:]
+	for _, req := range []*rpc.TransmitRequest{reqA, reqB} {
+		res, err := s.Transmit(ctx, req)
+		require.NoError(t, err)
+		require.Zero(t, res.Code, res.Error)
+	}
+
+	t.Run("returns each tenant's own report", func(t *testing.T) {
+		res, err := s.LatestReport(ctx, &rpc.LatestReportRequest{FeedId: feedID[:], ConfigDigest: cdA[:]})
+		require.NoError(t, err)
+		assert.Equal(t, reqA.Payload, res.Report.Payload)
+		res, err = s.LatestReport(ctx, &rpc.LatestReportRequest{FeedId: feedID[:], ConfigDigest: cdB[:]})
+		require.NoError(t, err)
+		assert.Equal(t, reqB.Payload, res.Report.Payload)
+	})
+	t.Run("does not return other tenants' reports", func(t *testing.T) {
+		_, err := s.LatestReport(ctx, &rpc.LatestReportRequest{FeedId: feedID[:], ConfigDigest: cdC[:]})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+	t.Run("requires a routable config digest", func(t *testing.T) {
+		_, err := s.LatestReport(ctx, &rpc.LatestReportRequest{FeedId: feedID[:]})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		_, err = s.LatestReport(ctx, &rpc.LatestReportRequest{FeedId: feedID[:], ConfigDigest: make([]byte, 32)})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Contains(t, err.Error(), "no tenant for config digest")
+	})
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	promDeliveriesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "llo_server_report_deliveries_total",
+		Help: "Number of report deliveries received, including duplicates of the same report from multiple nodes",
+	},
+		[]string{"tenant"},
+	)
+	promDuplicateDeliveriesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "llo_server_report_duplicate_deliveries_total",
+		Help: "Number of report deliveries that were identical to an already persisted report and were not stored again",
+	},
+		[]string{"tenant"},
+	)
+	promInvalidSignaturesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "llo_server_invalid_signatures_total",
+		Help: "Number of reports rejected because they were not signed by f+1 oracles of their config digest",
+	},
+		[]string{"tenant"},
+	)
+	promSinkErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "llo_server_sink_errors_total",
+		Help: "Number of persisted reports that could not be published to a sink",
+	},
+		[]string{"tenant", "sink"},
+	)
+	promPrunedReportsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "llo_server_pruned_reports_total",
+		Help: "Number of expired reports deleted from the report store",
+	},
+		[]string{"tenant"},
+	)
+	promPrunedBytesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "llo_server_pruned_bytes_total",
+		Help: "Number of payload bytes reclaimed by deleting expired reports from the report store",
+	},
+		[]string{"tenant"},
+	)
+	promReplicatedReportsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "llo_server_replicated_reports_total",
+		Help: "Number of reports mirrored from a peer server and stored",
+	},
+		[]string{"peer"},
+	)
+	promReplicationErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "llo_server_replication_errors_total",
+		Help: "Number of reports mirrored from a peer server that could not be stored",
+	},
+		[]string{"peer"},
+	)
+	promReplicationLagSeconds = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "llo_server_replication_lag_seconds",
+		Help: "Time between a peer server ingesting a report and this server receiving it, as of the last mirrored report",
+	},
+		[]string{"peer"},
+	)
+	promReplicationSubscribersDroppedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "llo_server_replication_subscribers_dropped_total",
+		Help: "Number of times a subscribed peer fell behind and was disconnected",
+	},
+		[]string{"peer"},
+	)
+	promReportLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "llo_server_report_latency_seconds",
+		Help:    "Time from the observations timestamp of a report until it reached the stage, by channel and stage (outcome, report_encoded, transmit or persisted). The persisted stage is the end-to-end latency. Only reports that carry timestamps are measured.",
+		Buckets: []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+	},
+		[]string{"tenant", "channelID", "stage"},
+	)
+)
@@ -0,0 +1,70 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+type ClientConfig struct {
+	// URL of the NATS server, or a comma-separated list of URLs of a
+	// cluster
+	URL string
+	// Name identifies this server's connection in NATS monitoring
+	Name string
+}
+
+var _ JetStream = (*Client)(nil)
+
+// Client is a JetStream backed by a connection made with the NATS client
+type Client struct {
+	nc *natsgo.Conn
+	js jetstream.JetStream
+}
+
+// Connect connects to NATS. Any additional options are applied after those
+// derived from cfg.
+func Connect(cfg ClientConfig, opts ...natsgo.Option) (*Client, error) {
+	if cfg.URL == "" {
+		return nil, errors.New("url is required")
+	}
+	if cfg.Name != "" {
+		opts = append([]natsgo.Option{natsgo.Name(cfg.Name)}, opts...)
+	}
+	nc, err := natsgo.Connect(cfg.URL, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to nats: %w", err)
+	}
+	js, err := jetstream.New(nc)
+	if err != nil {
+		nc.Close()
+		return nil, fmt.Errorf("failed to create jetstream context: %w", err)
+	}
+	return &Client{nc, js}, nil
+}
+
+// PublishMsg publishes msg and waits for the stream to acknowledge it. A
+// duplicate that the stream discarded is acknowledged as well.
+func (c *Client) PublishMsg(ctx context.Context, msg *Msg) error {
+	_, err := c.js.PublishMsg(ctx, toNATSMsg(msg))
+	return err
+}
+
+// Close closes the connection; publishes that are still in flight fail.
+func (c *Client) Close() {
+	c.nc.Close()
+}
+
+func toNATSMsg(m *Msg) *natsgo.Msg {
+	msg := natsgo.NewMsg(m.Subject)
+	msg.Data = m.Data
+	for k, vs := range m.Header {
+		for _, v := range vs {
+			msg.Header.Add(k, v)
+		}
+	}
+	return msg
+}
@@ -0,0 +1,36 @@
+package nats
+
+import (
+	"testing"
+	"time"
+
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Client(t *testing.T) {
+	t.Run("requires a url", func(t *testing.T) {
+		_, err := Connect(ClientConfig{})
+		require.EqualError(t, err, "url is required")
+	})
+	t.Run("fails if the server is unreachable", func(t *testing.T) {
+		_, err := Connect(ClientConfig{URL: "nats://127.0.0.1:1", Name: "llo-server"}, natsgo.Timeout(time.Second))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to connect to nats")
+	})
+	t.Run("converts messages", func(t *testing.T) {
+		msg := toNATSMsg(&Msg{
+			Subject: "llo.reports.1",
+			Data:    []byte("data"),
+			Header: map[string][]string{
+				HeaderMsgID:  {"key"},
+				HeaderTenant: {"tenant-a"},
+			},
+		})
+		assert.Equal(t, "llo.reports.1", msg.Subject)
+		assert.Equal(t, []byte("data"), msg.Data)
+		assert.Equal(t, "key", msg.Header.Get(HeaderMsgID))
+		assert.Equal(t, "tenant-a", msg.Header.Get(HeaderTenant))
+	})
+}
@@ -0,0 +1,108 @@
+// Package nats contains a server.Sink that publishes persisted reports to
+// NATS JetStream, as a lightweight alternative to Kafka.
+package nats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+
+	"github.com/smartcontractkit/chainlink-data-streams/rpc"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc/server"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc/server/sink"
+)
+
+// Header names set on every message
+const (
+	// HeaderMsgID is used by JetStream to discard duplicate publishes within
+	// the stream's duplicate window
+	HeaderMsgID        = "Nats-Msg-Id"
+	HeaderTenant       = "Llo-Tenant"
+	HeaderReportFormat = "Llo-Report-Format"
+)
+
+// Msg is a single JetStream message
+type Msg struct {
+	Subject string
+	Data    []byte
+	Header  map[string][]string
+}
+
+// JetStream publishes messages to a stream and waits for the server's
+// acknowledgement. Client implements it with the NATS client.
+type JetStream interface {
+	PublishMsg(ctx context.Context, msg *Msg) error
+}
+
+type Config struct {
+	// SubjectPrefix is prepended to the channel ID to form the subject of
+	// each report, e.g. "llo.reports" publishes reports for channel 1 to
+	// "llo.reports.1". The stream should be configured to capture
+	// "<SubjectPrefix>.*".
+	SubjectPrefix string
+	// ChannelDefinitions are used to map the feed IDs of EVM reports to
+	// their channels
+	ChannelDefinitions llotypes.ChannelDefinitions
+}
+
+var _ server.Sink = (*Publisher)(nil)
+
+// Publisher publishes every persisted report to a per-channel JetStream
+// subject as a sink.ReportMessage. The message ID is the report's
+// idempotency key so that JetStream discards duplicates from retried
+// publishes.
+type Publisher struct {
+	js      JetStream
+	prefix  string
+	encoder *sink.Encoder
+}
+
+func NewPublisher(js JetStream, cfg Config) (*Publisher, error) {
+	if err := validateSubjectPrefix(cfg.SubjectPrefix); err != nil {
+		return nil, err
+	}
+	e, err := sink.NewEncoder(cfg.ChannelDefinitions)
+	if err != nil {
+		return nil, err
+	}
+	return &Publisher{js, cfg.SubjectPrefix, e}, nil
+}
+
+func validateSubjectPrefix(prefix string) error {
+	if prefix == "" {
+		return errors.New("subject prefix is required")
+	}
+	for _, token := range strings.Split(prefix, ".") {
+		if token == "" || token == "*" || token == ">" || strings.ContainsAny(token, " \t\r\n") {
+			return fmt.Errorf("invalid subject prefix %q", prefix)
+		}
+	}
+	return nil
+}
+
+func (p *Publisher) Name() string { return "NATSPublisher" }
+
+// Subject returns the subject that reports for the given channel are
+// published to
+func (p *Publisher) Subject(channelID llotypes.ChannelID) string {
+	return fmt.Sprintf("%s.%d", p.prefix, channelID)
+}
+
+func (p *Publisher) Publish(ctx context.Context, tenant, idempotencyKey string, req *rpc.TransmitRequest) error {
+	m, data, err := p.encoder.Encode(tenant, idempotencyKey, req)
+	if err != nil {
+		return err
+	}
+	return p.js.PublishMsg(ctx, &Msg{
+		Subject: p.Subject(m.ChannelID),
+		Data:    data,
+		Header: map[string][]string{
+			HeaderMsgID:        {idempotencyKey},
+			HeaderTenant:       {tenant},
+			HeaderReportFormat: {m.ReportFormat},
+		},
+	})
+}
@@ -0,0 +1,108 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+
+	"github.com/smartcontractkit/chainlink-data-streams/llo"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc/server"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc/server/sink"
+)
+
+type mockJetStream struct {
+	err  error
+	msgs []*Msg
+}
+
+func (m *mockJetStream) PublishMsg(_ context.Context, msg *Msg) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.msgs = append(m.msgs, msg)
+	return nil
+}
+
+func jsonTransmitRequest(t *testing.T, channelID llotypes.ChannelID) *rpc.TransmitRequest {
+	t.Helper()
+	cdc := llo.JSONReportCodec{}
+	report := llo.Report{
+		ConfigDigest:                types.ConfigDigest{1},
+		SeqNr:                       5,
+		ChannelID:                   channelID,
+		ObservationTimestampSeconds: 1726670490,
+		Values:                      []llo.StreamValue{llo.ToDecimal(decimal.NewFromInt(42))},
+	}
+	encoded, err := cdc.Encode(context.Background(), report, llotypes.ChannelDefinition{})
+	require.NoError(t, err)
+	payload, err := cdc.Pack(types.ConfigDigest{1}, 5, encoded, nil)
+	require.NoError(t, err)
+	return &rpc.TransmitRequest{
+		Payload:        payload,
+		ReportFormat:   uint32(llotypes.ReportFormatJSON),
+		IdempotencyKey: rpc.IdempotencyKey(payload, uint32(llotypes.ReportFormatJSON)),
+	}
+}
+
+func Test_Publisher(t *testing.T) {
+	ctx := tests.Context(t)
+
+	t.Run("NewPublisher validates subject prefix", func(t *testing.T) {
+		_, err := NewPublisher(&mockJetStream{}, Config{})
+		assert.EqualError(t, err, "subject prefix is required")
+		for _, prefix := range []string{"llo.", ".llo", "llo..reports", "llo.*", "llo.>", "llo reports"} {
+			_, err = NewPublisher(&mockJetStream{}, Config{SubjectPrefix: prefix})
+			assert.EqualError(t, err, `invalid subject prefix "`+prefix+`"`)
+		}
+	})
+
+	t.Run("publishes each persisted report once to its channel's subject", func(t *testing.T) {
+		js := &mockJetStream{}
+		p, err := NewPublisher(js, Config{SubjectPrefix: "llo.reports"})
+		require.NoError(t, err)
+		s, err := server.NewServer(logger.Test(t), server.Config{Sinks: []server.Sink{p}}, server.NewInMemoryReportStore())
+		require.NoError(t, err)
+
+		req1, req2 := jsonTransmitRequest(t, 1), jsonTransmitRequest(t, 2)
+		for _, req := range []*rpc.TransmitRequest{req1, req1, req2} {
+			res, err := s.Transmit(ctx, req)
+			require.NoError(t, err)
+			assert.Zero(t, res.Code)
+		}
+
+		require.Len(t, js.msgs, 2)
+		assert.Equal(t, "llo.reports.1", js.msgs[0].Subject)
+		assert.Equal(t, "llo.reports.2", js.msgs[1].Subject)
+		assert.Equal(t, map[string][]string{
+			HeaderMsgID:        {req1.IdempotencyKey},
+			HeaderTenant:       {server.DefaultTenantName},
+			HeaderReportFormat: {"json"},
+		}, js.msgs[0].Header)
+
+		var rm sink.ReportMessage
+		require.NoError(t, json.Unmarshal(js.msgs[0].Data, &rm))
+		assert.Equal(t, req1.IdempotencyKey, rm.IdempotencyKey)
+		assert.Equal(t, uint32(1), rm.ChannelID)
+		assert.Equal(t, req1.Payload, rm.Payload)
+	})
+
+	t.Run("returns errors", func(t *testing.T) {
+		p, err := NewPublisher(&mockJetStream{err: errors.New("no responders")}, Config{SubjectPrefix: "llo"})
+		require.NoError(t, err)
+		err = p.Publish(ctx, "tenant", "key", jsonTransmitRequest(t, 1))
+		assert.EqualError(t, err, "no responders")
+	})
+}
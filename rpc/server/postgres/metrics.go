@@ -0,0 +1,21 @@
+package postgres
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	promReplicaLagSeconds = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "llo_server_postgres_replica_lag_seconds",
+		Help: "Replication lag of the read replica as of the last check",
+	},
+		[]string{"replica"},
+	)
+	promReadsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "llo_server_postgres_reads_total",
+		Help: "Number of report queries, by the database they were routed to",
+	},
+		[]string{"target"},
+	)
+)
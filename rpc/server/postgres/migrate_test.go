@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"database/sql/driver"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+)
+
+func Test_migrations(t *testing.T) {
+	ms, err := migrations()
+	require.NoError(t, err)
+	require.NotEmpty(t, ms)
+	for i, m := range ms {
+		assert.Equal(t, i+1, m.version, "migration versions must be sequential")
+		assert.NotEmpty(t, m.sql)
+	}
+	assert.Contains(t, ms[0].sql, "CREATE INDEX idx_llo_reports_channel_id_observation_timestamp")
+}
+
+func Test_Migrate(t *testing.T) {
+	ctx := tests.Context(t)
+
+	t.Run("applies pending migrations", func(t *testing.T) {
+		f, db := newFakeDB(t)
+		f.query = func(string, []any) ([]string, [][]driver.Value) {
+			return []string{"exists"}, [][]driver.Value{{false}}
+		}
+		require.NoError(t, Migrate(ctx, db))
+
+		execs := f.Execs()
+		require.Len(t, execs, 4)
+		assert.Contains(t, execs[0].query, "CREATE TABLE IF NOT EXISTS llo_schema_migrations")
+		assert.Equal(t, "SELECT pg_advisory_xact_lock($1)", execs[1].query)
+		assert.True(t, strings.HasPrefix(execs[2].query, "CREATE TABLE llo_reports"))
+		assert.Equal(t, "INSERT INTO llo_schema_migrations (version) VALUES ($1)", execs[3].query)
+		assert.Equal(t, []any{int64(1)}, execs[3].args)
+		assert.Equal(t, 1, f.commits)
+	})
+	t.Run("skips applied migrations", func(t *testing.T) {
+		f, db := newFakeDB(t)
+		f.query = func(string, []any) ([]string, [][]driver.Value) {
+			return []string{"exists"}, [][]driver.Value{{true}}
+		}
+		require.NoError(t, Migrate(ctx, db))
+		assert.Len(t, f.Execs(), 2)
+		assert.Equal(t, 1, f.commits)
+	})
+}
@@ -0,0 +1,34 @@
+CREATE TABLE llo_reports (
+    storage_key TEXT PRIMARY KEY,
+    idempotency_key TEXT NOT NULL,
+    report_format INTEGER NOT NULL,
+    config_digest BYTEA,
+    payload BYTEA NOT NULL,
+    -- After which the report is no longer valid and may be pruned
+    expires_at TIMESTAMPTZ NOT NULL,
+    -- Set if the report was transmitted as reduced confidence
+    reduced_confidence BOOLEAN NOT NULL DEFAULT FALSE,
+    -- Decoded from the payload, NULL if the report could not be decoded
+    channel_id BIGINT,
+    seq_nr BIGINT,
+    observation_timestamp TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_llo_reports_channel_id_observation_timestamp ON llo_reports (channel_id, observation_timestamp DESC) WHERE channel_id IS NOT NULL;
+CREATE INDEX idx_llo_reports_expires_at ON llo_reports (expires_at);
+
+-- Materialized pointer to the most recent report of each tenant's channels,
+-- kept up to date on insert so that LatestReport does not need to scan
+-- llo_reports. storage_key is deliberately not a foreign key, so that
+-- pruning reports does not have to update this table; pointers to pruned
+-- reports simply no longer join.
+CREATE TABLE llo_latest_reports (
+    -- Storage prefix of the tenant that the report was routed to
+    storage_prefix TEXT NOT NULL,
+    channel_id BIGINT NOT NULL,
+    storage_key TEXT NOT NULL,
+    observation_timestamp TIMESTAMPTZ NOT NULL,
+    seq_nr BIGINT NOT NULL,
+    PRIMARY KEY (storage_prefix, channel_id)
+);
@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+)
+
+const (
+	// DefaultMaxReplicaLag is the replication lag beyond which read replicas
+	// are not queried
+	DefaultMaxReplicaLag = 10 * time.Second
+	// DefaultReplicaLagCheckInterval is how often replication lag is measured
+	DefaultReplicaLagCheckInterval = time.Second
+)
+
+// replicaLagQuery returns how long ago the replica replayed the last
+// transaction from the primary, or NULL if it has not replayed any. Lag is
+// overestimated while the primary is idle, which only means that reads go
+// to the primary.
+const replicaLagQuery = `SELECT EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp())::float8`
+
+type replica struct {
+	name string
+	db   *sql.DB
+	// fresh is true if the last lag check succeeded and was within the
+	// maximum lag
+	fresh atomic.Bool
+}
+
+// replicaSet routes reads to the read replicas that are fresh enough
+type replicaSet struct {
+	lggr     logger.Logger
+	replicas []*replica
+	maxLag   time.Duration
+	next     atomic.Uint64
+}
+
+func newReplicaSet(lggr logger.Logger, dbs []*sql.DB, maxLag time.Duration) *replicaSet {
+	rs := &replicaSet{lggr: lggr, maxLag: maxLag}
+	for i, db := range dbs {
+		rs.replicas = append(rs.replicas, &replica{name: strconv.Itoa(i), db: db})
+	}
+	return rs
+}
+
+// checkLag measures the replication lag of every replica. Replicas start out
+// stale, so they are only used once their lag has been checked.
+func (rs *replicaSet) checkLag(ctx context.Context) {
+	for _, r := range rs.replicas {
+		var lagSeconds sql.NullFloat64
+		err := r.db.QueryRowContext(ctx, replicaLagQuery).Scan(&lagSeconds)
+		switch {
+		case err != nil:
+			rs.markStale(r, "err", err)
+		case !lagSeconds.Valid:
+			rs.markStale(r, "reason", "no transactions replayed")
+		default:
+			lag := time.Duration(lagSeconds.Float64 * float64(time.Second))
+			promReplicaLagSeconds.WithLabelValues(r.name).Set(lag.Seconds())
+			if lag > rs.maxLag {
+				rs.markStale(r, "lag", lag, "maxLag", rs.maxLag)
+			} else if !r.fresh.Swap(true) {
+				rs.lggr.Infow("Read replica caught up, routing reads to it", "replica", r.name, "lag", lag)
+			}
+		}
+	}
+}
+
+func (rs *replicaSet) markStale(r *replica, keyvals ...any) {
+	if r.fresh.Swap(false) {
+		rs.lggr.Warnw("Read replica is stale, routing reads to primary", append([]any{"replica", r.name}, keyvals...)...)
+	}
+}
+
+// pick returns a fresh replica, round-robin, or nil if there is none
+func (rs *replicaSet) pick() *replica {
+	n := uint64(len(rs.replicas))
+	start := rs.next.Add(1)
+	for i := uint64(0); i < n; i++ {
+		if r := rs.replicas[(start+i)%n]; r.fresh.Load() {
+			return r
+		}
+	}
+	return nil
+}
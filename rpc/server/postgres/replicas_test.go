@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+)
+
+// newFakeReplica returns a database that answers lag checks with the lag in
+// seconds held by lag (NULL if nil), and latest report queries with a
+// report stored under the given key
+func newFakeReplica(t *testing.T, key string, lag *atomic.Pointer[float64]) *sql.DB {
+	f, db := newFakeDB(t)
+	f.query = func(query string, args []any) ([]string, [][]driver.Value) {
+		if query == replicaLagQuery {
+			if l := lag.Load(); l != nil {
+				return []string{"lag"}, [][]driver.Value{{*l}}
+			}
+			return []string{"lag"}, [][]driver.Value{{nil}}
+		}
+		return []string{"storage_key", "idempotency_key", "report_format", "config_digest", "payload", "reduced_confidence"}, [][]driver.Value{
+			{key, "key", int64(2), []byte{1}, []byte("payload"), false},
+		}
+	}
+	return db
+}
+
+func Test_Store_ReadReplicas(t *testing.T) {
+	ctx := tests.Context(t)
+
+	var primaryLag, replicaLag atomic.Pointer[float64]
+	s, err := NewStore(logger.Test(t), newFakeReplica(t, "primary", &primaryLag), Config{
+		ReadReplicas:            []*sql.DB{newFakeReplica(t, "replica", &replicaLag)},
+		MaxReplicaLag:           5 * time.Second,
+		ReplicaLagCheckInterval: 10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.Start(ctx))
+	t.Cleanup(func() { assert.NoError(t, s.Close()) })
+
+	latestKey := func() string {
+		key, _, err := s.LatestReport(ctx, "tenant/", 1)
+		require.NoError(t, err)
+		return key
+	}
+
+	// the replica has not replayed any transactions yet
+	assert.Equal(t, "primary", latestKey())
+
+	primaryReads := testutil.ToFloat64(promReadsTotal.WithLabelValues("primary"))
+	replicaReads := testutil.ToFloat64(promReadsTotal.WithLabelValues("replica"))
+	replicaLag.Store(ptr(1.0))
+	require.Eventually(t, func() bool { return latestKey() == "replica" }, tests.WaitTimeout(t), 10*time.Millisecond)
+	assert.Equal(t, float64(1), testutil.ToFloat64(promReplicaLagSeconds.WithLabelValues("0")))
+	assert.Greater(t, testutil.ToFloat64(promReadsTotal.WithLabelValues("replica")), replicaReads)
+
+	// lagging beyond MaxReplicaLag
+	replicaLag.Store(ptr(6.0))
+	require.Eventually(t, func() bool { return latestKey() == "primary" }, tests.WaitTimeout(t), 10*time.Millisecond)
+	assert.Greater(t, testutil.ToFloat64(promReadsTotal.WithLabelValues("primary")), primaryReads)
+}
+
+func Test_replicaSet(t *testing.T) {
+	ctx := tests.Context(t)
+
+	var lag atomic.Pointer[float64]
+	lag.Store(ptr(0.5))
+	f, failing := newFakeDB(t)
+	f.query = func(string, []any) ([]string, [][]driver.Value) { return []string{"lag"}, nil }
+
+	rs := newReplicaSet(logger.Test(t), []*sql.DB{newFakeReplica(t, "fresh", &lag), failing}, time.Second)
+	assert.Nil(t, rs.pick(), "replicas are stale until checked")
+
+	rs.checkLag(ctx)
+	// the lag check of the second replica returns no rows
+	for i := 0; i < 4; i++ {
+		assert.Equal(t, "0", rs.pick().name)
+	}
+
+	rs.markStale(rs.replicas[0], "err", errors.New("connection refused"))
+	assert.Nil(t, rs.pick())
+}
+
+func ptr[T any](v T) *T { return &v }
@@ -0,0 +1,496 @@
+// Package postgres contains a server.ReportStore backed by Postgres.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+
+	"github.com/smartcontractkit/chainlink-data-streams/llo"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc/server"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc/server/archive"
+)
+
+const (
+	// DefaultMaxBatchSize is the maximum number of reports written per insert
+	DefaultMaxBatchSize = 100
+	// DefaultFlushInterval is how long to wait for a batch to fill up
+	DefaultFlushInterval = 10 * time.Millisecond
+)
+
+// ErrNotFound is returned by LatestReport when no report has been stored for
+// the channel
+var ErrNotFound = server.ErrReportNotFound
+
+type Config struct {
+	// ChannelDefinitions are used to map the feed IDs of EVM reports to
+	// their channels
+	ChannelDefinitions llotypes.ChannelDefinitions
+	// MaxBatchSize defaults to DefaultMaxBatchSize if zero
+	MaxBatchSize int
+	// FlushInterval defaults to DefaultFlushInterval if zero
+	FlushInterval time.Duration
+
+	// ReadReplicas are queried for latest reports instead of the primary,
+	// which receives all writes
+	ReadReplicas []*sql.DB
+	// MaxReplicaLag is the replication lag beyond which a replica is not
+	// queried, and reads fall back to the primary. It must not exceed the
+	// validity window of the reports, or replicas may serve reports that
+	// already expired. Defaults to DefaultMaxReplicaLag if zero.
+	MaxReplicaLag time.Duration
+	// ReplicaLagCheckInterval defaults to DefaultReplicaLagCheckInterval if
+	// zero
+	ReplicaLagCheckInterval time.Duration
+}
+
+var _ server.ReportStore = (*Store)(nil)
+var _ server.LatestReportStore = (*Store)(nil)
+var _ server.ExpiringReportStore = (*Store)(nil)
+var _ services.Service = (*Store)(nil)
+
+// Store persists reports to Postgres. The schema must have been created with
+// Migrate.
+//
+// Concurrent calls to Store are coalesced into batched inserts of up to
+// MaxBatchSize reports; each call returns once its batch has been committed.
+// If a batch fails, its reports are retried individually so that one report
+// that cannot be inserted does not fail the others.
+//
+// If read replicas are configured, latest report queries are spread across
+// the replicas whose replication lag is within MaxReplicaLag, and go to the
+// primary if there are none.
+type Store struct {
+	services.StateMachine
+
+	lggr          logger.Logger
+	db            *sql.DB
+	decoder       *archive.Decoder
+	maxBatchSize  int
+	flushInterval time.Duration
+
+	replicas         *replicaSet
+	lagCheckInterval time.Duration
+
+	pending chan *pendingReport
+	stopCh  services.StopChan
+	wg      sync.WaitGroup
+}
+
+type pendingReport struct {
+	row  reportRow
+	done chan error
+}
+
+type reportRow struct {
+	storageKey string
+	// storagePrefix is the storage prefix of the tenant that the report
+	// was routed to
+	storagePrefix  string
+	idempotencyKey string
+	reportFormat   uint32
+	configDigest   []byte
+	payload        []byte
+	// expiresAt is the report's expiry, as returned by server.ReportExpiry
+	expiresAt time.Time
+	// reducedConfidence is set if the report was transmitted as reduced
+	// confidence
+	reducedConfidence bool
+	// decoded is false if the report could not be decoded, in which case
+	// the fields below are unset
+	decoded              bool
+	channelID            llotypes.ChannelID
+	seqNr                uint64
+	observationTimestamp time.Time
+}
+
+func NewStore(lggr logger.Logger, db *sql.DB, cfg Config) (*Store, error) {
+	d, err := archive.NewDecoder(cfg.ChannelDefinitions)
+	if err != nil {
+		return nil, fmt.Errorf("invalid channel definitions: %w", err)
+	}
+	maxBatchSize := cfg.MaxBatchSize
+	if maxBatchSize <= 0 {
+		maxBatchSize = DefaultMaxBatchSize
+	}
+	flushInterval := cfg.FlushInterval
+	if flushInterval <= 0 {
+		flushInterval = DefaultFlushInterval
+	}
+	maxReplicaLag := cfg.MaxReplicaLag
+	if maxReplicaLag <= 0 {
+		maxReplicaLag = DefaultMaxReplicaLag
+	}
+	lagCheckInterval := cfg.ReplicaLagCheckInterval
+	if lagCheckInterval <= 0 {
+		lagCheckInterval = DefaultReplicaLagCheckInterval
+	}
+	lggr = logger.Named(lggr, "PostgresReportStore")
+	return &Store{
+		lggr:             lggr,
+		db:               db,
+		decoder:          d,
+		maxBatchSize:     maxBatchSize,
+		flushInterval:    flushInterval,
+		replicas:         newReplicaSet(lggr, cfg.ReadReplicas, maxReplicaLag),
+		lagCheckInterval: lagCheckInterval,
+		pending:          make(chan *pendingReport),
+		stopCh:           make(services.StopChan),
+	}, nil
+}
+
+func (s *Store) Name() string { return s.lggr.Name() }
+
+func (s *Store) Start(context.Context) error {
+	return s.StartOnce("PostgresReportStore", func() error {
+		s.wg.Add(1)
+		go s.runBatchLoop()
+		if len(s.replicas.replicas) > 0 {
+			s.wg.Add(1)
+			go s.runLagCheckLoop()
+		}
+		return nil
+	})
+}
+
+func (s *Store) Close() error {
+	return s.StopOnce("PostgresReportStore", func() error {
+		close(s.stopCh)
+		s.wg.Wait()
+		return nil
+	})
+}
+
+func (s *Store) HealthReport() map[string]error {
+	return map[string]error{s.Name(): s.Healthy()}
+}
+
+func (s *Store) Store(ctx context.Context, key string, req *rpc.TransmitRequest) error {
+	row := reportRow{
+		storageKey:        key,
+		storagePrefix:     storagePrefix(key, req.IdempotencyKey),
+		idempotencyKey:    req.IdempotencyKey,
+		reportFormat:      req.ReportFormat,
+		configDigest:      req.ConfigDigest,
+		payload:           req.Payload,
+		expiresAt:         server.ReportExpiry(req, time.Now()).UTC(),
+		reducedConfidence: req.ReducedConfidence,
+	}
+	if r, err := s.decoder.Decode(req.IdempotencyKey, req); err != nil {
+		// The report is stored regardless; it just can't be looked up by
+		// channel
+		s.lggr.Debugw("Failed to decode report, storing without channel", "storageKey", key, "reportFormat", req.ReportFormat, "err", err)
+	} else {
+		row.decoded = true
+		row.channelID = r.ChannelID
+		row.seqNr = r.SeqNr
+		row.observationTimestamp = time.Unix(int64(r.ObservationTimestampSeconds), 0).UTC()
+	}
+
+	p := &pendingReport{row, make(chan error, 1)}
+	select {
+	case s.pending <- p:
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-s.stopCh:
+		return errors.New("store is closed")
+	}
+	select {
+	case err := <-p.done:
+		return err
+	case <-ctx.Done():
+		// The batch may still be committed, which is fine since inserts
+		// are idempotent
+		return ctx.Err()
+	}
+}
+
+func (s *Store) runBatchLoop() {
+	defer s.wg.Done()
+	ctx, cancel := s.stopCh.NewCtx()
+	defer cancel()
+
+	for {
+		var batch []*pendingReport
+		select {
+		case p := <-s.pending:
+			batch = append(batch, p)
+		case <-s.stopCh:
+			return
+		}
+
+		timer := time.NewTimer(s.flushInterval)
+	fill:
+		for len(batch) < s.maxBatchSize {
+			select {
+			case p := <-s.pending:
+				batch = append(batch, p)
+			case <-timer.C:
+				break fill
+			case <-s.stopCh:
+				break fill
+			}
+		}
+		timer.Stop()
+
+		rows := make([]reportRow, len(batch))
+		for i, p := range batch {
+			rows[i] = p.row
+		}
+		errs := s.insertBatch(ctx, rows)
+		for i, p := range batch {
+			p.done <- errs[i]
+		}
+	}
+}
+
+// insertBatch inserts rows in a single transaction, and returns the error
+// of each row. If the transaction fails, each row is retried in its own
+// transaction so that a row that cannot be inserted only fails its own
+// caller.
+func (s *Store) insertBatch(ctx context.Context, rows []reportRow) []error {
+	errs := make([]error, len(rows))
+	err := s.insert(ctx, rows)
+	if err == nil {
+		return errs
+	}
+	if len(rows) == 1 || ctx.Err() != nil {
+		s.lggr.Warnw("Failed to insert reports", "count", len(rows), "err", err)
+		for i := range errs {
+			errs[i] = err
+		}
+		return errs
+	}
+	s.lggr.Debugw("Failed to insert batch of reports, retrying individually", "count", len(rows), "err", err)
+	for i := range rows {
+		if errs[i] = s.insert(ctx, rows[i:i+1]); errs[i] != nil {
+			s.lggr.Warnw("Failed to insert report", "storageKey", rows[i].storageKey, "err", errs[i])
+		}
+	}
+	return errs
+}
+
+func (s *Store) runLagCheckLoop() {
+	defer s.wg.Done()
+	ctx, cancel := s.stopCh.NewCtx()
+	defer cancel()
+
+	ticker := time.NewTicker(s.lagCheckInterval)
+	defer ticker.Stop()
+	for {
+		s.replicas.checkLag(ctx)
+		select {
+		case <-ticker.C:
+		case <-s.stopCh:
+			return
+		}
+	}
+}
+
+func (s *Store) insert(ctx context.Context, rows []reportRow) (err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	query, args := insertReportsQuery(rows)
+	if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to insert reports: %w", err)
+	}
+	if query, args := upsertLatestReportsQuery(rows); query != "" {
+		if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to update latest reports: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// insertReportsQuery builds a multi-row insert. Reports that were already
+// stored are ignored, so that retries are idempotent.
+func insertReportsQuery(rows []reportRow) (string, []any) {
+	const cols = 10
+	var sb strings.Builder
+	sb.WriteString(`INSERT INTO llo_reports (storage_key, idempotency_key, report_format, config_digest, payload, expires_at, reduced_confidence, channel_id, seq_nr, observation_timestamp) VALUES `)
+	args := make([]any, 0, len(rows)*cols)
+	for i, r := range rows {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		writePlaceholders(&sb, i*cols, cols)
+		args = append(args, r.storageKey, r.idempotencyKey, int64(r.reportFormat), r.configDigest, r.payload, r.expiresAt, r.reducedConfidence)
+		if r.decoded {
+			args = append(args, int64(r.channelID), int64(r.seqNr), r.observationTimestamp)
+		} else {
+			args = append(args, nil, nil, nil)
+		}
+	}
+	sb.WriteString(` ON CONFLICT (storage_key) DO NOTHING`)
+	return sb.String(), args
+}
+
+// latestKey identifies a row of llo_latest_reports
+type latestKey struct {
+	storagePrefix string
+	channelID     llotypes.ChannelID
+}
+
+// upsertLatestReportsQuery builds an upsert that advances the latest report
+// pointer of each tenant's channel in rows, unless a newer report is already
+// recorded. It returns an empty query if no rows were decoded.
+func upsertLatestReportsQuery(rows []reportRow) (string, []any) {
+	// Postgres rejects upserts that affect the same row twice, so only the
+	// newest report of each tenant's channel in the batch is included
+	latest := make(map[latestKey]reportRow)
+	var order []latestKey
+	for _, r := range rows {
+		if !r.decoded {
+			continue
+		}
+		k := latestKey{r.storagePrefix, r.channelID}
+		prev, exists := latest[k]
+		if !exists {
+			order = append(order, k)
+		}
+		if !exists || isNewer(r, prev) {
+			latest[k] = r
+		}
+	}
+	if len(latest) == 0 {
+		return "", nil
+	}
+
+	const cols = 5
+	var sb strings.Builder
+	sb.WriteString(`INSERT INTO llo_latest_reports (storage_prefix, channel_id, storage_key, observation_timestamp, seq_nr) VALUES `)
+	args := make([]any, 0, len(latest)*cols)
+	for i, k := range order {
+		r := latest[k]
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		writePlaceholders(&sb, i*cols, cols)
+		args = append(args, r.storagePrefix, int64(r.channelID), r.storageKey, r.observationTimestamp, int64(r.seqNr))
+	}
+	sb.WriteString(` ON CONFLICT (storage_prefix, channel_id) DO UPDATE SET storage_key = EXCLUDED.storage_key, observation_timestamp = EXCLUDED.observation_timestamp, seq_nr = EXCLUDED.seq_nr WHERE (llo_latest_reports.observation_timestamp, llo_latest_reports.seq_nr) < (EXCLUDED.observation_timestamp, EXCLUDED.seq_nr)`)
+	return sb.String(), args
+}
+
+// storagePrefix returns the prefix of a storage key, which is the report's
+// idempotency key prefixed with the storage prefix of its tenant
+func storagePrefix(key, idempotencyKey string) string {
+	prefix, ok := strings.CutSuffix(key, idempotencyKey)
+	if !ok {
+		return ""
+	}
+	return prefix
+}
+
+func isNewer(a, b reportRow) bool {
+	if !a.observationTimestamp.Equal(b.observationTimestamp) {
+		return a.observationTimestamp.After(b.observationTimestamp)
+	}
+	return a.seqNr > b.seqNr
+}
+
+// writePlaceholders writes a tuple of n placeholders, numbered from offset+1
+func writePlaceholders(sb *strings.Builder, offset, n int) {
+	sb.WriteByte('(')
+	for j := 1; j <= n; j++ {
+		if j > 1 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(sb, "$%d", offset+j)
+	}
+	sb.WriteByte(')')
+}
+
+// LatestReport returns the most recent report stored for the channel by the
+// tenant with the given storage prefix, by observation timestamp and then
+// sequence number, along with its storage key
+func (s *Store) LatestReport(ctx context.Context, storagePrefix string, channelID llotypes.ChannelID) (string, *rpc.TransmitRequest, error) {
+	return s.latestReport(ctx, `l.storage_prefix = $1 AND l.channel_id = $2`, storagePrefix, int64(channelID))
+}
+
+// latestReport returns the most recent report whose llo_latest_reports row
+// matches cond
+func (s *Store) latestReport(ctx context.Context, cond string, args ...any) (string, *rpc.TransmitRequest, error) {
+	if r := s.replicas.pick(); r != nil {
+		promReadsTotal.WithLabelValues("replica").Inc()
+		key, req, err := queryLatestReport(ctx, r.db, cond, args...)
+		if err == nil || errors.Is(err, ErrNotFound) {
+			return key, req, err
+		}
+		s.lggr.Warnw("Failed to query read replica, falling back to primary", "replica", r.name, "err", err)
+		s.replicas.markStale(r, "err", err)
+	}
+	promReadsTotal.WithLabelValues("primary").Inc()
+	return queryLatestReport(ctx, s.db, cond, args...)
+}
+
+func queryLatestReport(ctx context.Context, db *sql.DB, cond string, args ...any) (string, *rpc.TransmitRequest, error) {
+	var key string
+	var reportFormat int64
+	req := &rpc.TransmitRequest{}
+	err := db.QueryRowContext(ctx, `SELECT r.storage_key, r.idempotency_key, r.report_format, r.config_digest, r.payload, r.reduced_confidence
+FROM llo_latest_reports l
+JOIN llo_reports r ON r.storage_key = l.storage_key
+WHERE `+cond+`
+ORDER BY l.observation_timestamp DESC, l.seq_nr DESC
+LIMIT 1`, args...).Scan(&key, &req.IdempotencyKey, &reportFormat, &req.ConfigDigest, &req.Payload, &req.ReducedConfidence)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil, ErrNotFound
+	} else if err != nil {
+		return "", nil, fmt.Errorf("failed to query latest report: %w", err)
+	}
+	req.ReportFormat = uint32(reportFormat)
+	return key, req, nil
+}
+
+// LatestFeedReport returns the most recent report stored for the channel of
+// the EVM feed, as configured in Config.ChannelDefinitions, by the tenant
+// with the given storage prefix
+func (s *Store) LatestFeedReport(ctx context.Context, storagePrefix string, feedID llo.FeedID) (*rpc.TransmitRequest, error) {
+	cid, exists := s.decoder.ChannelID(feedID)
+	if !exists {
+		return nil, fmt.Errorf("%w: no channel found for feed ID %s", ErrNotFound, feedID)
+	}
+	_, req, err := s.LatestReport(ctx, storagePrefix, cid)
+	return req, err
+}
+
+// PruneExpired deletes the reports that expired before cutoff. Their latest
+// report pointers are left in place, but no longer join, so expired reports
+// are no longer served by LatestReport or LatestFeedReport either.
+func (s *Store) PruneExpired(ctx context.Context, cutoff time.Time) ([]server.PrunedReport, error) {
+	rows, err := s.db.QueryContext(ctx, `DELETE FROM llo_reports WHERE expires_at < $1 RETURNING storage_key, octet_length(payload)`, cutoff.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete expired reports: %w", err)
+	}
+	defer rows.Close()
+	var pruned []server.PrunedReport
+	for rows.Next() {
+		var r server.PrunedReport
+		if err := rows.Scan(&r.Key, &r.Size); err != nil {
+			return nil, fmt.Errorf("failed to scan deleted report: %w", err)
+		}
+		pruned = append(pruned, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to delete expired reports: %w", err)
+	}
+	return pruned, nil
+}
@@ -0,0 +1,284 @@
+package postgres
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+
+	"github.com/smartcontractkit/chainlink-data-streams/llo"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc/server"
+)
+
+func jsonTransmitRequest(t *testing.T, channelID llotypes.ChannelID, seqNr uint64, ts uint32) *rpc.TransmitRequest {
+	t.Helper()
+	cdc := llo.JSONReportCodec{}
+	report := llo.Report{
+		ConfigDigest:                types.ConfigDigest{1},
+		SeqNr:                       seqNr,
+		ChannelID:                   channelID,
+		ObservationTimestampSeconds: ts,
+		Values:                      []llo.StreamValue{llo.ToDecimal(decimal.NewFromInt(42))},
+	}
+	encoded, err := cdc.Encode(context.Background(), report, llotypes.ChannelDefinition{})
+	require.NoError(t, err)
+	payload, err := cdc.Pack(types.ConfigDigest{1}, seqNr, encoded, nil)
+	require.NoError(t, err)
+	digest := types.ConfigDigest{1}
+	return &rpc.TransmitRequest{
+		Payload:        payload,
+		ReportFormat:   uint32(llotypes.ReportFormatJSON),
+		IdempotencyKey: rpc.IdempotencyKey(payload, uint32(llotypes.ReportFormatJSON)),
+		ConfigDigest:   digest[:],
+	}
+}
+
+func Test_Store(t *testing.T) {
+	ctx := tests.Context(t)
+
+	t.Run("batches concurrent inserts", func(t *testing.T) {
+		f, db := newFakeDB(t)
+		// only flush when the batch is full
+		s, err := NewStore(logger.Test(t), db, Config{MaxBatchSize: 3, FlushInterval: time.Hour})
+		require.NoError(t, err)
+		require.NoError(t, s.Start(ctx))
+		t.Cleanup(func() { assert.NoError(t, s.Close()) })
+
+		reduced := jsonTransmitRequest(t, 1, 11, 1000)
+		reduced.ReducedConfidence = true
+		reqs := map[string]*rpc.TransmitRequest{
+			"a": jsonTransmitRequest(t, 1, 10, 1000),
+			"b": reduced,
+			"c": {Payload: []byte("undecodable"), ReportFormat: uint32(llotypes.ReportFormatJSON), IdempotencyKey: "c"},
+		}
+		var wg sync.WaitGroup
+		for key, req := range reqs {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, s.Store(ctx, key, req))
+			}()
+		}
+		wg.Wait()
+
+		execs := f.Execs()
+		require.Len(t, execs, 2)
+		assert.True(t, strings.HasPrefix(execs[0].query, "INSERT INTO llo_reports"))
+		assert.Contains(t, execs[0].query, "($21, $22, $23, $24, $25, $26, $27, $28, $29, $30) ON CONFLICT (storage_key) DO NOTHING")
+		require.Len(t, execs[0].args, 30)
+		for i := 0; i < 3; i++ {
+			row := execs[0].args[i*10 : (i+1)*10]
+			assert.IsType(t, time.Time{}, row[5], "every report has an expiry")
+			assert.Equal(t, row[0] == "b", row[6], "reduced confidence")
+			if row[0] == "c" {
+				assert.Equal(t, []any{nil, nil, nil}, row[7:], "undecodable reports are stored without channel")
+			} else {
+				assert.Equal(t, int64(1), row[7])
+				assert.Equal(t, time.Unix(1000, 0).UTC(), row[9])
+			}
+		}
+
+		assert.True(t, strings.HasPrefix(execs[1].query, "INSERT INTO llo_latest_reports"))
+		assert.Equal(t, []any{"", int64(1), "b", time.Unix(1000, 0).UTC(), int64(11)}, execs[1].args, "only the newest report per channel is upserted")
+		assert.Equal(t, 1, f.commits)
+	})
+
+	t.Run("returns insert errors to every caller in the batch", func(t *testing.T) {
+		f, db := newFakeDB(t)
+		f.execErr = errors.New("connection reset")
+		s, err := NewStore(logger.Test(t), db, Config{MaxBatchSize: 2, FlushInterval: time.Hour})
+		require.NoError(t, err)
+		require.NoError(t, s.Start(ctx))
+		t.Cleanup(func() { assert.NoError(t, s.Close()) })
+
+		var wg sync.WaitGroup
+		for _, key := range []string{"a", "b"} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err := s.Store(ctx, key, jsonTransmitRequest(t, 1, 10, 1000))
+				assert.EqualError(t, err, "failed to insert reports: connection reset")
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, 0, f.commits)
+		assert.Equal(t, 3, f.rollbacks, "the batch and each report are rolled back")
+	})
+
+	t.Run("retries failed batches report by report", func(t *testing.T) {
+		f, db := newFakeDB(t)
+		bad := jsonTransmitRequest(t, 1, 11, 1000)
+		f.failExec = func(query string, args []any) error {
+			for _, arg := range args {
+				if arg == "tenant/"+bad.IdempotencyKey {
+					return errors.New("value too long")
+				}
+			}
+			return nil
+		}
+		s, err := NewStore(logger.Test(t), db, Config{MaxBatchSize: 2, FlushInterval: time.Hour})
+		require.NoError(t, err)
+		require.NoError(t, s.Start(ctx))
+		t.Cleanup(func() { assert.NoError(t, s.Close()) })
+
+		good := jsonTransmitRequest(t, 1, 10, 1000)
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, s.Store(ctx, "tenant/"+good.IdempotencyKey, good))
+		}()
+		go func() {
+			defer wg.Done()
+			assert.EqualError(t, s.Store(ctx, "tenant/"+bad.IdempotencyKey, bad), "failed to insert reports: value too long")
+		}()
+		wg.Wait()
+
+		assert.Equal(t, 1, f.commits)
+		assert.Equal(t, 2, f.rollbacks)
+		execs := f.Execs()
+		require.Len(t, execs, 2)
+		assert.Equal(t, "tenant/"+good.IdempotencyKey, execs[0].args[0])
+		assert.Equal(t, []any{"tenant/", int64(1), "tenant/" + good.IdempotencyKey, time.Unix(1000, 0).UTC(), int64(10)}, execs[1].args)
+	})
+
+	t.Run("flushes partial batches after the flush interval", func(t *testing.T) {
+		f, db := newFakeDB(t)
+		s, err := NewStore(logger.Test(t), db, Config{FlushInterval: time.Millisecond})
+		require.NoError(t, err)
+		require.NoError(t, s.Start(ctx))
+		t.Cleanup(func() { assert.NoError(t, s.Close()) })
+
+		require.NoError(t, s.Store(ctx, "a", jsonTransmitRequest(t, 1, 10, 1000)))
+		assert.Len(t, f.Execs(), 2)
+	})
+
+	t.Run("Store fails after Close", func(t *testing.T) {
+		_, db := newFakeDB(t)
+		s, err := NewStore(logger.Test(t), db, Config{})
+		require.NoError(t, err)
+		require.NoError(t, s.Start(ctx))
+		require.NoError(t, s.Close())
+		assert.EqualError(t, s.Store(ctx, "a", jsonTransmitRequest(t, 1, 10, 1000)), "store is closed")
+	})
+}
+
+func Test_upsertLatestReportsQuery(t *testing.T) {
+	ts := time.Unix(1000, 0)
+	query, args := upsertLatestReportsQuery([]reportRow{
+		{storageKey: "a/old", storagePrefix: "a/", decoded: true, channelID: 1, seqNr: 12, observationTimestamp: ts},
+		{storageKey: "a/new", storagePrefix: "a/", decoded: true, channelID: 1, seqNr: 10, observationTimestamp: ts.Add(time.Second)},
+		{storageKey: "a/other", storagePrefix: "a/", decoded: true, channelID: 2, seqNr: 1, observationTimestamp: ts},
+		{storageKey: "b/old", storagePrefix: "b/", decoded: true, channelID: 1, seqNr: 12, observationTimestamp: ts},
+		{storageKey: "undecoded"},
+	})
+	assert.Equal(t, `INSERT INTO llo_latest_reports (storage_prefix, channel_id, storage_key, observation_timestamp, seq_nr) VALUES ($1, $2, $3, $4, $5), ($6, $7, $8, $9, $10), ($11, $12, $13, $14, $15) ON CONFLICT (storage_prefix, channel_id) DO UPDATE SET storage_key = EXCLUDED.storage_key, observation_timestamp = EXCLUDED.observation_timestamp, seq_nr = EXCLUDED.seq_nr WHERE (llo_latest_reports.observation_timestamp, llo_latest_reports.seq_nr) < (EXCLUDED.observation_timestamp, EXCLUDED.seq_nr)`, query)
+	assert.Equal(t, []any{
+		"a/", int64(1), "a/new", ts.Add(time.Second), int64(10),
+		"a/", int64(2), "a/other", ts, int64(1),
+		"b/", int64(1), "b/old", ts, int64(12),
+	}, args, "channels are tracked per tenant")
+
+	query, _ = upsertLatestReportsQuery([]reportRow{{storageKey: "undecoded"}})
+	assert.Empty(t, query)
+}
+
+func Test_Store_LatestReport(t *testing.T) {
+	ctx := tests.Context(t)
+	f, db := newFakeDB(t)
+	s, err := NewStore(logger.Test(t), db, Config{})
+	require.NoError(t, err)
+
+	f.query = func(query string, args []any) ([]string, [][]driver.Value) {
+		assert.Contains(t, query, "WHERE l.storage_prefix = $1 AND l.channel_id = $2")
+		if args[0] != "tenant/" || args[1] != int64(1) {
+			return []string{"storage_key", "idempotency_key", "report_format", "config_digest", "payload", "reduced_confidence"}, nil
+		}
+		return []string{"storage_key", "idempotency_key", "report_format", "config_digest", "payload", "reduced_confidence"}, [][]driver.Value{
+			{"tenant/key", "key", int64(2), []byte{1}, []byte("payload"), true},
+		}
+	}
+
+	key, req, err := s.LatestReport(ctx, "tenant/", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "tenant/key", key)
+	assert.Equal(t, "key", req.IdempotencyKey)
+	assert.Equal(t, uint32(2), req.ReportFormat)
+	assert.Equal(t, []byte{1}, req.ConfigDigest)
+	assert.Equal(t, []byte("payload"), req.Payload)
+	assert.True(t, req.ReducedConfidence)
+
+	_, _, err = s.LatestReport(ctx, "tenant/", 2)
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, _, err = s.LatestReport(ctx, "other/", 1)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func Test_Store_LatestFeedReport(t *testing.T) {
+	ctx := tests.Context(t)
+	feedID := llo.FeedID{0, 3, 1}
+	f, db := newFakeDB(t)
+	s, err := NewStore(logger.Test(t), db, Config{ChannelDefinitions: llotypes.ChannelDefinitions{
+		5: {
+			ReportFormat: llotypes.ReportFormatEVMPremiumLegacy,
+			Opts:         []byte(`{"feedID":"` + feedID.Hex() + `","baseUSDFee":"1","multiplier":"1"}`),
+		},
+	}})
+	require.NoError(t, err)
+
+	f.query = func(query string, args []any) ([]string, [][]driver.Value) {
+		assert.Contains(t, query, "WHERE l.storage_prefix = $1 AND l.channel_id = $2", "feeds are looked up per tenant")
+		if args[0] != "tenant/" || args[1] != int64(5) {
+			return []string{"storage_key", "idempotency_key", "report_format", "config_digest", "payload", "reduced_confidence"}, nil
+		}
+		return []string{"storage_key", "idempotency_key", "report_format", "config_digest", "payload", "reduced_confidence"}, [][]driver.Value{
+			{"tenant/key", "key", int64(3), []byte{1}, []byte("payload"), false},
+		}
+	}
+
+	req, err := s.LatestFeedReport(ctx, "tenant/", feedID)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("payload"), req.Payload)
+
+	_, err = s.LatestFeedReport(ctx, "other/", feedID)
+	assert.ErrorIs(t, err, server.ErrReportNotFound, "reports of other tenants are not returned")
+	_, err = s.LatestFeedReport(ctx, "tenant/", llo.FeedID{1})
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, err, server.ErrReportNotFound)
+}
+
+func Test_Store_PruneExpired(t *testing.T) {
+	ctx := tests.Context(t)
+	f, db := newFakeDB(t)
+	s, err := NewStore(logger.Test(t), db, Config{})
+	require.NoError(t, err)
+
+	cutoff := time.Unix(1000, 0)
+	f.query = func(query string, args []any) ([]string, [][]driver.Value) {
+		assert.Equal(t, "DELETE FROM llo_reports WHERE expires_at < $1 RETURNING storage_key, octet_length(payload)", query)
+		assert.Equal(t, []any{cutoff.UTC()}, args)
+		return []string{"storage_key", "octet_length"}, [][]driver.Value{
+			{"tenant/a", int64(10)},
+			{"tenant/b", int64(20)},
+		}
+	}
+
+	pruned, err := s.PruneExpired(ctx, cutoff)
+	require.NoError(t, err)
+	assert.Equal(t, []server.PrunedReport{{Key: "tenant/a", Size: 10}, {Key: "tenant/b", Size: 20}}, pruned)
+}
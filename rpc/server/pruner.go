@@ -0,0 +1,193 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+
+	"github.com/smartcontractkit/chainlink-data-streams/llo"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc"
+)
+
+const (
+	// DefaultPruneInterval is how often the Pruner deletes expired reports
+	DefaultPruneInterval = time.Minute
+	// DefaultRetentionMargin is how long reports are kept after they expire
+	DefaultRetentionMargin = 24 * time.Hour
+)
+
+// ExpiringReportStore is implemented by ReportStores that can delete
+// reports once they have expired
+type ExpiringReportStore interface {
+	// PruneExpired deletes every report whose expiry, as returned by
+	// ReportExpiry, is before cutoff, and returns the deleted reports
+	PruneExpired(ctx context.Context, cutoff time.Time) ([]PrunedReport, error)
+}
+
+// PrunedReport describes a report deleted by an ExpiringReportStore
+type PrunedReport struct {
+	// Key is the storage key of the report
+	Key string
+	// Size is the number of payload bytes reclaimed
+	Size int
+}
+
+// ReportExpiry returns the time after which a report is no longer valid.
+//
+// EVM premium legacy reports carry their expiry. Reports of other formats
+// have no validity window, so they expire as soon as they are stored and are only retained
+// for the retention margin.
+func ReportExpiry(req *rpc.TransmitRequest, storedAt time.Time) time.Time {
+	switch llotypes.ReportFormat(req.ReportFormat) {
+	case llotypes.ReportFormatEVMPremiumLegacy:
+		_, _, report, _, err := llo.UnpackEVMPayload(req.Payload)
+		if err != nil {
+			return storedAt
+		}
+		_, rf, err := (llo.EVMPremiumLegacyReportCodec{}).Decode(report)
+		if err != nil {
+			return storedAt
+		}
+		return time.Unix(int64(rf.ExpiresAt), 0)
+	default:
+		return storedAt
+	}
+}
+
+// PrunerConfig configures a Pruner. Zero values are replaced with defaults.
+type PrunerConfig struct {
+	// Interval between pruning runs
+	Interval time.Duration
+	// RetentionMargin is how long reports are kept after they expire, e.g.
+	// to allow late consumers and audits to retrieve them
+	RetentionMargin time.Duration
+}
+
+var _ services.Service = (*Pruner)(nil)
+
+// Pruner is a background job that periodically deletes reports that expired
+// more than the retention margin ago from the server's ReportStore, so that
+// storage does not grow without bound. Pruned reports no longer count
+// towards their tenant's quota.
+//
+// It requires the server's ReportStore to implement ExpiringReportStore.
+type Pruner struct {
+	services.StateMachine
+
+	lggr  logger.Logger
+	srv   *Server
+	store ExpiringReportStore
+	cfg   PrunerConfig
+
+	stopCh services.StopChan
+	wg     sync.WaitGroup
+}
+
+func NewPruner(lggr logger.Logger, srv *Server, cfg PrunerConfig) (*Pruner, error) {
+	store, ok := srv.store.(ExpiringReportStore)
+	if !ok {
+		return nil, errors.New("report store does not support pruning")
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultPruneInterval
+	}
+	if cfg.RetentionMargin <= 0 {
+		cfg.RetentionMargin = DefaultRetentionMargin
+	}
+	return &Pruner{
+		lggr:   logger.Named(lggr, "ReportPruner"),
+		srv:    srv,
+		store:  store,
+		cfg:    cfg,
+		stopCh: make(services.StopChan),
+	}, nil
+}
+
+func (p *Pruner) Name() string { return p.lggr.Name() }
+
+func (p *Pruner) Start(context.Context) error {
+	return p.StartOnce("ReportPruner", func() error {
+		p.wg.Add(1)
+		go p.run()
+		return nil
+	})
+}
+
+func (p *Pruner) Close() error {
+	return p.StopOnce("ReportPruner", func() error {
+		close(p.stopCh)
+		p.wg.Wait()
+		return nil
+	})
+}
+
+func (p *Pruner) HealthReport() map[string]error {
+	return map[string]error{p.Name(): p.Healthy()}
+}
+
+func (p *Pruner) run() {
+	defer p.wg.Done()
+	ctx, cancel := p.stopCh.NewCtx()
+	defer cancel()
+
+	ticker := time.NewTicker(p.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := p.Prune(ctx, time.Now()); err != nil {
+				p.lggr.Warnw("Failed to prune expired reports", "err", err)
+			}
+		case <-p.stopCh:
+			return
+		}
+	}
+}
+
+// Prune deletes the reports that expired more than the retention margin
+// before now, returning how many were deleted
+func (p *Pruner) Prune(ctx context.Context, now time.Time) (int, error) {
+	cutoff := now.Add(-p.cfg.RetentionMargin)
+	pruned, err := p.store.PruneExpired(ctx, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune reports expired before %s: %w", cutoff, err)
+	}
+	reclaimed := 0
+	for _, r := range pruned {
+		t := p.srv.router.tenantOfStorageKey(r.Key)
+		if t == nil {
+			// Stored before the tenant was removed from the config
+			continue
+		}
+		t.release()
+		promPrunedReportsTotal.WithLabelValues(t.name).Inc()
+		promPrunedBytesTotal.WithLabelValues(t.name).Add(float64(r.Size))
+		reclaimed += r.Size
+	}
+	if len(pruned) > 0 {
+		p.lggr.Debugw("Pruned expired reports", "cutoff", cutoff, "reports", len(pruned), "bytesReclaimed", reclaimed)
+	}
+	return len(pruned), nil
+}
+
+// tenantOfStorageKey returns the tenant whose storage prefix is the longest
+// prefix of key, or nil if there is none
+func (r *router) tenantOfStorageKey(key string) *tenant {
+	if r.fallback != nil {
+		return r.fallback
+	}
+	var match *tenant
+	for _, t := range r.byName {
+		if strings.HasPrefix(key, t.storagePrefix) && (match == nil || len(t.storagePrefix) > len(match.storagePrefix)) {
+			match = t
+		}
+	}
+	return match
+}
@@ -0,0 +1,117 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink-data-streams/llo"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc"
+)
+
+func Test_ReportExpiry(t *testing.T) {
+	storedAt := time.Unix(5000, 0)
+
+	req, _ := evmTransmitRequest(t, llo.FeedID{0, 3, 1}, 1, 1000)
+	// expirationWindow defaults to zero
+	assert.Equal(t, time.Unix(1000, 0), ReportExpiry(req, storedAt))
+
+	assert.Equal(t, storedAt, ReportExpiry(&rpc.TransmitRequest{Payload: []byte("report"), ReportFormat: 2}, storedAt))
+	assert.Equal(t, storedAt, ReportExpiry(&rpc.TransmitRequest{Payload: []byte("garbage"), ReportFormat: req.ReportFormat}, storedAt))
+}
+
+func Test_Pruner(t *testing.T) {
+	ctx := tests.Context(t)
+
+	t.Run("requires an ExpiringReportStore", func(t *testing.T) {
+		s, err := NewServer(logger.Test(t), Config{}, &mockReportStore{})
+		require.NoError(t, err)
+		_, err = NewPruner(logger.Test(t), s, PrunerConfig{})
+		assert.EqualError(t, err, "report store does not support pruning")
+	})
+
+	t.Run("prunes reports past their validity window plus retention margin", func(t *testing.T) {
+		cd := types.ConfigDigest{1}
+		store := NewInMemoryReportStore()
+		s, err := NewServer(logger.Test(t), Config{Tenants: []TenantConfig{
+			{Name: "pruned", ConfigDigests: []types.ConfigDigest{cd}, MaxReports: 2},
+		}}, store)
+		require.NoError(t, err)
+		p, err := NewPruner(logger.Test(t), s, PrunerConfig{RetentionMargin: time.Hour})
+		require.NoError(t, err)
+
+		feedID := llo.FeedID{0, 3, 2}
+		evmReq, _ := evmTransmitRequest(t, feedID, 1, 1000)
+		evmReq.ConfigDigest = cd[:]
+		otherReq := &rpc.TransmitRequest{Payload: []byte("report"), ReportFormat: 2, ConfigDigest: cd[:]}
+		for _, req := range []*rpc.TransmitRequest{evmReq, otherReq} {
+			res, err := s.Transmit(ctx, req)
+			require.NoError(t, err)
+			require.Zero(t, res.Code, res.Error)
+		}
+		// the tenant's quota is exhausted
+		anotherReq := &rpc.TransmitRequest{Payload: []byte("another report"), ReportFormat: 2, ConfigDigest: cd[:]}
+		anotherKey := rpc.IdempotencyKey(anotherReq.Payload, anotherReq.ReportFormat)
+		res, err := s.Transmit(ctx, anotherReq)
+		require.NoError(t, err)
+		assert.Equal(t, int32(codes.ResourceExhausted), res.Code)
+		st, err := s.TransmissionStatus(ctx, &rpc.TransmissionStatusRequest{IdempotencyKey: anotherKey})
+		require.NoError(t, err)
+		assert.Equal(t, rpc.TransmissionStatusResponse_Received, st.Status, "quota rejections are retryable")
+
+		reports := testutil.ToFloat64(promPrunedReportsTotal.WithLabelValues("pruned"))
+		bytes := testutil.ToFloat64(promPrunedBytesTotal.WithLabelValues("pruned"))
+
+		// the EVM report expired long ago, the other one is within its
+		// retention margin
+		n, err := p.Prune(ctx, time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		assert.Equal(t, []string{"pruned/" + rpc.IdempotencyKey(otherReq.Payload, otherReq.ReportFormat)}, store.Keys(""))
+		_, err = store.LatestFeedReport(ctx, "pruned/", feedID)
+		assert.ErrorIs(t, err, ErrReportNotFound)
+		assert.Equal(t, reports+1, testutil.ToFloat64(promPrunedReportsTotal.WithLabelValues("pruned")))
+		assert.Equal(t, bytes+float64(len(evmReq.Payload)), testutil.ToFloat64(promPrunedBytesTotal.WithLabelValues("pruned")))
+
+		// pruned reports no longer count towards the quota
+		res, err = s.Transmit(ctx, anotherReq)
+		require.NoError(t, err)
+		assert.Zero(t, res.Code, res.Error)
+		st, err = s.TransmissionStatus(ctx, &rpc.TransmissionStatusRequest{IdempotencyKey: anotherKey})
+		require.NoError(t, err)
+		assert.Equal(t, rpc.TransmissionStatusResponse_Persisted, st.Status)
+
+		n, err = p.Prune(ctx, time.Now().Add(2*time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 2, n)
+		assert.Empty(t, store.Keys(""))
+	})
+
+	t.Run("prunes in the background", func(t *testing.T) {
+		store := NewInMemoryReportStore()
+		s, err := NewServer(logger.Test(t), Config{}, store)
+		require.NoError(t, err)
+		p, err := NewPruner(logger.Test(t), s, PrunerConfig{Interval: 10 * time.Millisecond})
+		require.NoError(t, err)
+
+		req, _ := evmTransmitRequest(t, llo.FeedID{0, 3, 3}, 1, 1000)
+		res, err := s.Transmit(ctx, req)
+		require.NoError(t, err)
+		require.Zero(t, res.Code, res.Error)
+
+		require.NoError(t, p.Start(ctx))
+		t.Cleanup(func() { assert.NoError(t, p.Close()) })
+		require.Eventually(t, func() bool {
+			return len(store.Keys("")) == 0
+		}, tests.WaitTimeout(t), 10*time.Millisecond)
+	})
+}
@@ -0,0 +1,271 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+
+	"github.com/smartcontractkit/chainlink-data-streams/rpc"
+)
+
+// DefaultReplicationBufferSize is the number of reports buffered for each
+// subscribed peer before it is considered to have fallen behind
+const DefaultReplicationBufferSize = 1000
+
+const (
+	minResubscribeBackoff = 100 * time.Millisecond
+	maxResubscribeBackoff = 10 * time.Second
+)
+
+var _ Sink = (*Replicator)(nil)
+var _ rpc.ReplicationServer = (*Replicator)(nil)
+
+// Replicator serves the Replication service of a server, mirroring the
+// reports it ingests to peer servers, e.g. in other regions. It must be
+// added to the server's Sinks.
+//
+// Peers subscribe with a ReplicationFollower. Reports are buffered per peer
+// and peers that fall behind by more than the buffer are disconnected rather
+// than slowing down ingestion; they resubscribe and only receive reports
+// ingested from then on, so replication lag stays bounded at the cost of
+// gaps, which the peer's nodes fill by transmitting to it directly.
+type Replicator struct {
+	rpc.UnimplementedReplicationServer
+
+	lggr       logger.Logger
+	bufferSize int
+
+	mu          sync.Mutex
+	subscribers map[*replicationSubscriber]struct{}
+}
+
+type replicationSubscriber struct {
+	peer    string
+	reports chan *rpc.ReplicatedReport
+	// closed when the subscriber fell behind
+	dropped chan struct{}
+}
+
+// NewReplicator returns a Replicator that buffers up to bufferSize reports
+// per peer. Defaults to DefaultReplicationBufferSize if zero.
+func NewReplicator(lggr logger.Logger, bufferSize int) *Replicator {
+	if bufferSize <= 0 {
+		bufferSize = DefaultReplicationBufferSize
+	}
+	return &Replicator{
+		lggr:        logger.Named(lggr, "Replicator"),
+		bufferSize:  bufferSize,
+		subscribers: make(map[*replicationSubscriber]struct{}),
+	}
+}
+
+func (r *Replicator) Name() string { return r.lggr.Name() }
+
+// Publish queues the report for every subscribed peer. It never blocks.
+func (r *Replicator) Publish(_ context.Context, tenant, idempotencyKey string, req *rpc.TransmitRequest) error {
+	now := time.Now()
+	rr := &rpc.ReplicatedReport{
+		Tenant:         tenant,
+		IdempotencyKey: idempotencyKey,
+		Request:        req,
+		IngestedAt:     &rpc.Timestamp{Seconds: now.Unix(), Nanos: int32(now.Nanosecond())},
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for sub := range r.subscribers {
+		select {
+		case sub.reports <- rr:
+		default:
+			delete(r.subscribers, sub)
+			close(sub.dropped)
+			promReplicationSubscribersDroppedTotal.WithLabelValues(sub.peer).Inc()
+			r.lggr.Warnw("Peer fell behind, dropping replication subscription", "peer", sub.peer, "bufferSize", r.bufferSize)
+		}
+	}
+	return nil
+}
+
+// Replicate streams the reports ingested from now on to a peer
+func (r *Replicator) Replicate(req *rpc.ReplicateRequest, stream rpc.Replication_ReplicateServer) error {
+	sub := &replicationSubscriber{
+		peer:    req.Peer,
+		reports: make(chan *rpc.ReplicatedReport, r.bufferSize),
+		dropped: make(chan struct{}),
+	}
+	r.mu.Lock()
+	r.subscribers[sub] = struct{}{}
+	r.mu.Unlock()
+	defer func() {
+		r.mu.Lock()
+		delete(r.subscribers, sub)
+		r.mu.Unlock()
+	}()
+	r.lggr.Infow("Peer subscribed to replication", "peer", req.Peer)
+
+	for {
+		select {
+		case rr := <-sub.reports:
+			if err := stream.Send(rr); err != nil {
+				return err
+			}
+		case <-sub.dropped:
+			return status.Error(codes.ResourceExhausted, fmt.Sprintf("peer fell behind by more than %d reports", r.bufferSize))
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// ReplicationPeer is a server whose reports are mirrored by a
+// ReplicationFollower
+type ReplicationPeer struct {
+	// Name identifies the peer, e.g. by region, in logs and metrics
+	Name   string
+	Client rpc.ReplicationClient
+}
+
+var _ services.Service = (*ReplicationFollower)(nil)
+
+// ReplicationFollower subscribes to the Replicators of peer servers and
+// stores the reports they ingest in its own server, under the same tenant.
+// Tenants must therefore have the same names on all servers.
+//
+// Mirrored reports are not published to the server's sinks, so they are
+// not replicated any further.
+type ReplicationFollower struct {
+	services.StateMachine
+
+	lggr  logger.Logger
+	srv   *Server
+	name  string
+	peers []ReplicationPeer
+
+	stopCh services.StopChan
+	wg     sync.WaitGroup
+}
+
+// NewReplicationFollower returns a ReplicationFollower for srv, which
+// identifies itself to peers by name
+func NewReplicationFollower(lggr logger.Logger, srv *Server, name string, peers []ReplicationPeer) *ReplicationFollower {
+	return &ReplicationFollower{
+		lggr:   logger.Named(lggr, "ReplicationFollower"),
+		srv:    srv,
+		name:   name,
+		peers:  peers,
+		stopCh: make(services.StopChan),
+	}
+}
+
+func (f *ReplicationFollower) Name() string { return f.lggr.Name() }
+
+func (f *ReplicationFollower) Start(context.Context) error {
+	return f.StartOnce("ReplicationFollower", func() error {
+		for _, peer := range f.peers {
+			f.wg.Add(1)
+			go f.follow(peer)
+		}
+		return nil
+	})
+}
+
+func (f *ReplicationFollower) Close() error {
+	return f.StopOnce("ReplicationFollower", func() error {
+		close(f.stopCh)
+		f.wg.Wait()
+		return nil
+	})
+}
+
+func (f *ReplicationFollower) HealthReport() map[string]error {
+	return map[string]error{f.Name(): f.Healthy()}
+}
+
+// follow subscribes to the peer, resubscribing whenever the stream ends
+func (f *ReplicationFollower) follow(peer ReplicationPeer) {
+	defer f.wg.Done()
+	ctx, cancel := f.stopCh.NewCtx()
+	defer cancel()
+
+	backoff := minResubscribeBackoff
+	for {
+		received, err := f.replicate(ctx, peer)
+		if ctx.Err() != nil {
+			return
+		}
+		if received > 0 {
+			backoff = minResubscribeBackoff
+		}
+		f.lggr.Warnw("Replication stream ended, will resubscribe", "peer", peer.Name, "backoff", backoff, "err", err)
+		select {
+		case <-time.After(backoff):
+		case <-f.stopCh:
+			return
+		}
+		backoff = min(2*backoff, maxResubscribeBackoff)
+	}
+}
+
+// replicate stores the reports streamed by the peer until the stream ends,
+// returning how many were received
+func (f *ReplicationFollower) replicate(ctx context.Context, peer ReplicationPeer) (received int, err error) {
+	stream, err := peer.Client.Replicate(ctx, &rpc.ReplicateRequest{Peer: f.name})
+	if err != nil {
+		return 0, fmt.Errorf("failed to subscribe: %w", err)
+	}
+	for {
+		rr, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return received, errors.New("stream closed by peer")
+		} else if err != nil {
+			return received, err
+		}
+		received++
+		if rr.IngestedAt != nil {
+			lag := time.Since(time.Unix(rr.IngestedAt.Seconds, int64(rr.IngestedAt.Nanos)))
+			promReplicationLagSeconds.WithLabelValues(peer.Name).Set(lag.Seconds())
+		}
+		if err := f.srv.storeReplicated(ctx, rr); err != nil {
+			promReplicationErrorsTotal.WithLabelValues(peer.Name).Inc()
+			f.lggr.Warnw("Failed to store replicated report", "peer", peer.Name, "tenant", rr.Tenant, "idempotencyKey", rr.IdempotencyKey, "err", err)
+			continue
+		}
+		promReplicatedReportsTotal.WithLabelValues(peer.Name).Inc()
+	}
+}
+
+// storeReplicated persists a report mirrored from a peer, unless the server
+// already persisted it, e.g. because it was also transmitted directly
+func (s *Server) storeReplicated(ctx context.Context, rr *rpc.ReplicatedReport) error {
+	if rr.Request == nil || len(rr.Request.Payload) == 0 {
+		return errors.New("empty report")
+	}
+	t, exists := s.router.byName[rr.Tenant]
+	if !exists {
+		return fmt.Errorf("unknown tenant: %q", rr.Tenant)
+	}
+	key := rr.IdempotencyKey
+	release, err := s.statuses.claim(ctx, key, time.Now())
+	if err != nil || release == nil {
+		return err
+	}
+	defer release()
+	if !t.reserve() {
+		return fmt.Errorf("tenant %q has reached its quota of %d reports", t.name, t.maxReports)
+	}
+	if err := s.store.Store(ctx, t.storageKey(key), rr.Request); err != nil {
+		t.release()
+		return err
+	}
+	s.statuses.set(key, rpc.TransmissionStatusResponse_Persisted, "", time.Now())
+	return nil
+}
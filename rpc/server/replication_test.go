@@ -0,0 +1,178 @@
+package server
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink-data-streams/rpc"
+)
+
+// loopbackReplicationClient calls a Replicator directly, as if over gRPC
+type loopbackReplicationClient struct {
+	r *Replicator
+}
+
+func (c loopbackReplicationClient) Replicate(ctx context.Context, in *rpc.ReplicateRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[rpc.ReplicatedReport], error) {
+	srv := &replicateServerStream{ctx: ctx, reports: make(chan *rpc.ReplicatedReport)}
+	cli := &replicateClientStream{reports: srv.reports, done: make(chan struct{})}
+	go func() {
+		cli.err = c.r.Replicate(in, srv)
+		close(cli.done)
+	}()
+	return cli, nil
+}
+
+type replicateServerStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	reports chan *rpc.ReplicatedReport
+}
+
+func (s *replicateServerStream) Context() context.Context { return s.ctx }
+
+func (s *replicateServerStream) Send(rr *rpc.ReplicatedReport) error {
+	select {
+	case s.reports <- rr:
+		return nil
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
+
+type replicateClientStream struct {
+	grpc.ClientStream
+	reports chan *rpc.ReplicatedReport
+	done    chan struct{}
+	err     error
+}
+
+func (s *replicateClientStream) Recv() (*rpc.ReplicatedReport, error) {
+	select {
+	case rr := <-s.reports:
+		return rr, nil
+	case <-s.done:
+		if s.err != nil {
+			return nil, s.err
+		}
+		return nil, io.EOF
+	}
+}
+
+type countingSink struct {
+	published chan string
+}
+
+func (c *countingSink) Name() string { return "counting" }
+
+func (c *countingSink) Publish(_ context.Context, _, idempotencyKey string, _ *rpc.TransmitRequest) error {
+	c.published <- idempotencyKey
+	return nil
+}
+
+func (r *Replicator) subscriberCount() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.subscribers)
+}
+
+func Test_Replication(t *testing.T) {
+	ctx := tests.Context(t)
+
+	t.Run("mirrors ingested reports to followers", func(t *testing.T) {
+		replicator := NewReplicator(logger.Test(t), 0)
+		origin, err := NewServer(logger.Test(t), Config{Sinks: []Sink{replicator}}, NewInMemoryReportStore())
+		require.NoError(t, err)
+
+		followerStore := NewInMemoryReportStore()
+		followerSink := &countingSink{published: make(chan string, 1)}
+		follower, err := NewServer(logger.Test(t), Config{Sinks: []Sink{followerSink}}, followerStore)
+		require.NoError(t, err)
+		f := NewReplicationFollower(logger.Test(t), follower, "eu-west", []ReplicationPeer{{Name: "us-east", Client: loopbackReplicationClient{replicator}}})
+		require.NoError(t, f.Start(ctx))
+		t.Cleanup(func() { assert.NoError(t, f.Close()) })
+		require.Eventually(t, func() bool { return replicator.subscriberCount() == 1 }, tests.WaitTimeout(t), 10*time.Millisecond)
+
+		replicated := testutil.ToFloat64(promReplicatedReportsTotal.WithLabelValues("us-east"))
+		req := &rpc.TransmitRequest{Payload: []byte("report"), ReportFormat: 2}
+		key := rpc.IdempotencyKey(req.Payload, req.ReportFormat)
+		res, err := origin.Transmit(ctx, req)
+		require.NoError(t, err)
+		require.Zero(t, res.Code, res.Error)
+
+		require.Eventually(t, func() bool {
+			_, ok := followerStore.Get(key)
+			return ok
+		}, tests.WaitTimeout(t), 10*time.Millisecond)
+		stored, _ := followerStore.Get(key)
+		assert.Equal(t, req.Payload, stored.Payload)
+		st, err := follower.TransmissionStatus(ctx, &rpc.TransmissionStatusRequest{IdempotencyKey: key})
+		require.NoError(t, err)
+		assert.Equal(t, rpc.TransmissionStatusResponse_Persisted, st.Status)
+		assert.Equal(t, replicated+1, testutil.ToFloat64(promReplicatedReportsTotal.WithLabelValues("us-east")))
+		assert.Less(t, testutil.ToFloat64(promReplicationLagSeconds.WithLabelValues("us-east")), tests.WaitTimeout(t).Seconds())
+		// mirrored reports are not published to the follower's sinks
+		assert.Empty(t, followerSink.published)
+
+		// the report is not stored again if also transmitted directly
+		res, err = follower.Transmit(ctx, req)
+		require.NoError(t, err)
+		assert.Zero(t, res.Code, res.Error)
+		assert.Empty(t, followerSink.published)
+	})
+
+	t.Run("rejects reports of unknown tenants", func(t *testing.T) {
+		s, err := NewServer(logger.Test(t), Config{}, NewInMemoryReportStore())
+		require.NoError(t, err)
+		err = s.storeReplicated(ctx, &rpc.ReplicatedReport{Tenant: "foo", IdempotencyKey: "bar", Request: &rpc.TransmitRequest{Payload: []byte("report")}})
+		assert.EqualError(t, err, `unknown tenant: "foo"`)
+	})
+
+	t.Run("drops peers that fall behind", func(t *testing.T) {
+		replicator := NewReplicator(logger.Test(t), 1)
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		stream := &replicateServerStream{ctx: ctx, reports: make(chan *rpc.ReplicatedReport)}
+		errCh := make(chan error, 1)
+		go func() { errCh <- replicator.Replicate(&rpc.ReplicateRequest{Peer: "slow"}, stream) }()
+		require.Eventually(t, func() bool { return replicator.subscriberCount() == 1 }, tests.WaitTimeout(t), 10*time.Millisecond)
+
+		dropped := testutil.ToFloat64(promReplicationSubscribersDroppedTotal.WithLabelValues("slow"))
+		req := &rpc.TransmitRequest{Payload: []byte("report")}
+		// one report is being sent, which never completes, one is buffered,
+		// and the next one overflows the buffer
+		require.Eventually(t, func() bool {
+			assert.NoError(t, replicator.Publish(ctx, DefaultTenantName, "foo", req))
+			return replicator.subscriberCount() == 0
+		}, tests.WaitTimeout(t), 10*time.Millisecond)
+		assert.Equal(t, dropped+1, testutil.ToFloat64(promReplicationSubscribersDroppedTotal.WithLabelValues("slow")))
+
+		// unblock the pending send
+		go func() {
+			for {
+				select {
+				case <-stream.reports:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+		select {
+		case err := <-errCh:
+			assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		case <-time.After(tests.WaitTimeout(t)):
+			t.Fatal("Replicate did not return")
+		}
+	})
+}
@@ -0,0 +1,214 @@
+// Package server contains a reference implementation of the Transmitter
+// service, used for testing and as a starting point for integrators.
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/status"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink-data-streams/rpc"
+)
+
+// DefaultMaxTrackedTransmissions bounds the number of transmission statuses
+// kept in memory
+const DefaultMaxTrackedTransmissions = 100_000
+
+type Config struct {
+	// MaxTrackedTransmissions is the maximum number of transmissions whose
+	// status can be queried, older ones will report Unknown.
+	// Defaults to DefaultMaxTrackedTransmissions if zero.
+	MaxTrackedTransmissions int
+	// Tenants configures routing of reports by config digest. If empty, all
+	// reports are accepted and stored without a prefix.
+	Tenants []TenantConfig
+	// Sinks receive every report once it has been persisted. See package
+	// sinkconfig to build them from configuration.
+	Sinks []Sink
+
+	// Version of the server, advertised through ServerInfo
+	Version string
+	// ReportFormats restricts the report formats that are accepted. If
+	// empty, all formats are accepted.
+	ReportFormats []uint32
+	// Compressors names the gRPC compressors that clients may compress
+	// requests with. They must be registered with the grpc/encoding package.
+	Compressors []string
+	// MaxRequestSize is advertised to clients as the maximum size of a
+	// request, and should match the receive limit of the gRPC server.
+	// Defaults to rpc.DefaultMaxRecvMsgSize if zero.
+	MaxRequestSize int
+	// SignatureVerifier, if set, rejects EVM payloads that are not signed
+	// by f+1 oracles of their config digest
+	SignatureVerifier *SignatureVerifier
+}
+
+var _ rpc.TransmitterServer = (*Server)(nil)
+
+type Server struct {
+	rpc.UnimplementedTransmitterServer
+
+	lggr     logger.Logger
+	store    ReportStore
+	statuses *statusTracker
+	router   *router
+	sinks    []Sink
+	verifier *SignatureVerifier
+	info     *rpc.ServerInfoResponse
+}
+
+func NewServer(lggr logger.Logger, cfg Config, store ReportStore) (*Server, error) {
+	maxTracked := cfg.MaxTrackedTransmissions
+	if maxTracked <= 0 {
+		maxTracked = DefaultMaxTrackedTransmissions
+	}
+	r, err := newRouter(cfg.Tenants)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tenant config: %w", err)
+	}
+	for _, name := range cfg.Compressors {
+		if encoding.GetCompressor(name) == nil {
+			return nil, fmt.Errorf("compressor %q is not registered", name)
+		}
+	}
+	maxRequestSize := cfg.MaxRequestSize
+	if maxRequestSize <= 0 {
+		maxRequestSize = rpc.DefaultMaxRecvMsgSize
+	}
+	return &Server{
+		lggr:     logger.Named(lggr, "TransmitterServer"),
+		store:    store,
+		statuses: newStatusTracker(maxTracked),
+		router:   r,
+		sinks:    cfg.Sinks,
+		verifier: cfg.SignatureVerifier,
+		info: &rpc.ServerInfoResponse{
+			SchemaRevision: rpc.SchemaRevision,
+			Version:        cfg.Version,
+			ReportFormats:  cfg.ReportFormats,
+			Compressors:    cfg.Compressors,
+			Limits: &rpc.ServerLimits{
+				MaxRequestSize:          uint64(maxRequestSize),
+				MaxTrackedTransmissions: uint64(maxTracked),
+			},
+		},
+	}, nil
+}
+
+// Transmit persists a report. Every node of a DON transmits the same
+// attested report, so deliveries of a report that was already persisted are
+// acknowledged without being stored again, and concurrent deliveries of the
+// same report wait for the first one to be stored.
+func (s *Server) Transmit(ctx context.Context, req *rpc.TransmitRequest) (*rpc.TransmitResponse, error) {
+	// Derive the key rather than trusting the client's, since deliveries
+	// with the same key are deduplicated
+	key := rpc.IdempotencyKey(req.Payload, req.ReportFormat)
+	if req.IdempotencyKey != "" && req.IdempotencyKey != key {
+		// Not tracked under either key, since the client's key may belong
+		// to another report
+		reason := fmt.Sprintf("idempotency key %q does not match the payload, expected %q", req.IdempotencyKey, key)
+		s.lggr.Debugw("Rejected report", "idempotencyKey", req.IdempotencyKey, "code", codes.InvalidArgument, "reason", reason)
+		return &rpc.TransmitResponse{Code: int32(codes.InvalidArgument), Error: reason}, nil
+	}
+	deliveries := s.statuses.deliver(key, time.Now())
+
+	if len(req.Payload) == 0 {
+		return s.reject(key, codes.InvalidArgument, "empty payload"), nil
+	}
+	if len(s.info.ReportFormats) > 0 && !slices.Contains(s.info.ReportFormats, req.ReportFormat) {
+		return s.reject(key, codes.InvalidArgument, fmt.Sprintf("unsupported report format: %d", req.ReportFormat)), nil
+	}
+
+	t, err := s.router.route(req.ConfigDigest)
+	if err != nil {
+		return s.reject(key, codes.InvalidArgument, err.Error()), nil
+	}
+	promDeliveriesTotal.WithLabelValues(t.name).Inc()
+	release, err := s.statuses.claim(ctx, key, time.Now())
+	if err != nil {
+		return &rpc.TransmitResponse{Code: int32(codes.Unavailable), Error: fmt.Sprintf("timed out waiting for another delivery of the report to be stored: %v", err)}, nil
+	}
+	if release == nil {
+		promDuplicateDeliveriesTotal.WithLabelValues(t.name).Inc()
+		s.lggr.Debugw("Skipping duplicate report", "idempotencyKey", key, "tenant", t.name, "deliveries", deliveries)
+		return &rpc.TransmitResponse{}, nil
+	}
+	defer release()
+
+	if s.verifier != nil && s.verifier.verifies(req.ReportFormat) {
+		if err := s.verifySignatures(ctx, req); errors.Is(err, errSignerSetUnavailable) {
+			s.lggr.Warnw("Failed to verify report signatures", "idempotencyKey", key, "tenant", t.name, "err", err)
+			return &rpc.TransmitResponse{Code: int32(codes.Unavailable), Error: err.Error()}, nil
+		} else if err != nil {
+			promInvalidSignaturesTotal.WithLabelValues(t.name).Inc()
+			return s.reject(key, codes.InvalidArgument, fmt.Sprintf("invalid signatures: %v", err)), nil
+		}
+	}
+
+	if !t.reserve() {
+		// Not tracked as Rejected, since the report is accepted once
+		// pruning frees up the quota
+		reason := fmt.Sprintf("tenant %q has reached its quota of %d reports", t.name, t.maxReports)
+		s.lggr.Debugw("Rejected report", "idempotencyKey", key, "code", codes.ResourceExhausted, "reason", reason)
+		return &rpc.TransmitResponse{Code: int32(codes.ResourceExhausted), Error: reason}, nil
+	}
+
+	if err := s.store.Store(ctx, t.storageKey(key), req); err != nil {
+		t.release()
+		var rerr *RejectedError
+		if errors.As(err, &rerr) {
+			return s.reject(key, codes.InvalidArgument, rerr.Reason), nil
+		}
+		s.lggr.Warnw("Failed to persist report", "idempotencyKey", key, "tenant", t.name, "reportFormat", req.ReportFormat, "err", err)
+		return &rpc.TransmitResponse{Code: int32(codes.Unavailable), Error: err.Error()}, nil
+	}
+
+	persistedAt := time.Now()
+	s.statuses.set(key, rpc.TransmissionStatusResponse_Persisted, "", persistedAt)
+	observeReportLatency(t.name, req, persistedAt)
+	s.publish(ctx, t.name, key, req)
+	return &rpc.TransmitResponse{}, nil
+}
+
+// DeliveryCount returns how many times the transmission with the given
+// idempotency key has been received, or zero if it is unknown
+func (s *Server) DeliveryCount(idempotencyKey string) int {
+	return s.statuses.deliveries(idempotencyKey)
+}
+
+func (s *Server) reject(key string, code codes.Code, reason string) *rpc.TransmitResponse {
+	s.lggr.Debugw("Rejected report", "idempotencyKey", key, "code", code, "reason", reason)
+	s.statuses.set(key, rpc.TransmissionStatusResponse_Rejected, reason, time.Now())
+	return &rpc.TransmitResponse{Code: int32(code), Error: reason}
+}
+
+// StorageKey returns the key under which the given tenant's report with the
+// given idempotency key is stored
+func (s *Server) StorageKey(tenantName, idempotencyKey string) (string, error) {
+	t, exists := s.router.byName[tenantName]
+	if !exists {
+		return "", fmt.Errorf("unknown tenant: %q", tenantName)
+	}
+	return t.storageKey(idempotencyKey), nil
+}
+
+// ServerInfo advertises the schema revision the server was built with and
+// its capabilities
+func (s *Server) ServerInfo(context.Context, *rpc.ServerInfoRequest) (*rpc.ServerInfoResponse, error) {
+	return s.info, nil
+}
+
+func (s *Server) TransmissionStatus(ctx context.Context, req *rpc.TransmissionStatusRequest) (*rpc.TransmissionStatusResponse, error) {
+	if req.IdempotencyKey == "" {
+		return nil, status.Error(codes.InvalidArgument, "idempotencyKey is required")
+	}
+	return s.statuses.get(req.IdempotencyKey), nil
+}
@@ -0,0 +1,309 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink-data-streams/rpc"
+)
+
+type mockReportStore struct {
+	err error
+}
+
+func (m *mockReportStore) Store(context.Context, string, *rpc.TransmitRequest) error {
+	return m.err
+}
+
+func Test_Server_TransmissionStatus(t *testing.T) {
+	ctx := tests.Context(t)
+
+	t.Run("requires idempotency key", func(t *testing.T) {
+		s, err := NewServer(logger.Test(t), Config{}, NewInMemoryReportStore())
+		require.NoError(t, err)
+		_, err = s.TransmissionStatus(ctx, &rpc.TransmissionStatusRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("unknown transmission", func(t *testing.T) {
+		s, err := NewServer(logger.Test(t), Config{}, NewInMemoryReportStore())
+		require.NoError(t, err)
+		res, err := s.TransmissionStatus(ctx, &rpc.TransmissionStatusRequest{IdempotencyKey: "foo"})
+		require.NoError(t, err)
+		assert.Equal(t, rpc.TransmissionStatusResponse_Unknown, res.Status)
+		assert.Nil(t, res.UpdatedAt)
+	})
+	t.Run("persisted transmission", func(t *testing.T) {
+		store := NewInMemoryReportStore()
+		s, err := NewServer(logger.Test(t), Config{}, store)
+		require.NoError(t, err)
+		key := rpc.IdempotencyKey([]byte("report"), 2)
+		req := &rpc.TransmitRequest{Payload: []byte("report"), ReportFormat: 2, IdempotencyKey: key}
+		res, err := s.Transmit(ctx, req)
+		require.NoError(t, err)
+		assert.Zero(t, res.Code)
+		assert.Empty(t, res.Error)
+
+		stored, ok := store.Get(key)
+		require.True(t, ok)
+		assert.Equal(t, req, stored)
+
+		st, err := s.TransmissionStatus(ctx, &rpc.TransmissionStatusRequest{IdempotencyKey: key})
+		require.NoError(t, err)
+		assert.Equal(t, rpc.TransmissionStatusResponse_Persisted, st.Status)
+		assert.Empty(t, st.Reason)
+		assert.NotNil(t, st.UpdatedAt)
+	})
+	t.Run("derives idempotency key if missing", func(t *testing.T) {
+		store := NewInMemoryReportStore()
+		s, err := NewServer(logger.Test(t), Config{}, store)
+		require.NoError(t, err)
+		_, err = s.Transmit(ctx, &rpc.TransmitRequest{Payload: []byte("report"), ReportFormat: 2})
+		require.NoError(t, err)
+
+		st, err := s.TransmissionStatus(ctx, &rpc.TransmissionStatusRequest{IdempotencyKey: rpc.IdempotencyKey([]byte("report"), 2)})
+		require.NoError(t, err)
+		assert.Equal(t, rpc.TransmissionStatusResponse_Persisted, st.Status)
+	})
+	t.Run("rejects idempotency key that does not match the payload", func(t *testing.T) {
+		store := NewInMemoryReportStore()
+		s, err := NewServer(logger.Test(t), Config{}, store)
+		require.NoError(t, err)
+		key := rpc.IdempotencyKey([]byte("report"), 2)
+		res, err := s.Transmit(ctx, &rpc.TransmitRequest{Payload: []byte("report"), ReportFormat: 2, IdempotencyKey: "foo"})
+		require.NoError(t, err)
+		assert.Equal(t, int32(codes.InvalidArgument), res.Code)
+		_, ok := store.Get(key)
+		assert.False(t, ok)
+
+		for _, k := range []string{"foo", key} {
+			st, err := s.TransmissionStatus(ctx, &rpc.TransmissionStatusRequest{IdempotencyKey: k})
+			require.NoError(t, err)
+			assert.Equal(t, rpc.TransmissionStatusResponse_Unknown, st.Status, "a mismatched key must not affect the status of either key")
+		}
+	})
+	t.Run("rejects empty payload", func(t *testing.T) {
+		s, err := NewServer(logger.Test(t), Config{}, NewInMemoryReportStore())
+		require.NoError(t, err)
+		res, err := s.Transmit(ctx, &rpc.TransmitRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, int32(codes.InvalidArgument), res.Code)
+
+		st, err := s.TransmissionStatus(ctx, &rpc.TransmissionStatusRequest{IdempotencyKey: rpc.IdempotencyKey(nil, 0)})
+		require.NoError(t, err)
+		assert.Equal(t, rpc.TransmissionStatusResponse_Rejected, st.Status)
+		assert.Equal(t, "empty payload", st.Reason)
+	})
+	t.Run("rejected by store", func(t *testing.T) {
+		s, err := NewServer(logger.Test(t), Config{}, &mockReportStore{err: &RejectedError{"invalid signatures"}})
+		require.NoError(t, err)
+		res, err := s.Transmit(ctx, &rpc.TransmitRequest{Payload: []byte("report")})
+		require.NoError(t, err)
+		assert.Equal(t, int32(codes.InvalidArgument), res.Code)
+		assert.Equal(t, "invalid signatures", res.Error)
+
+		st, err := s.TransmissionStatus(ctx, &rpc.TransmissionStatusRequest{IdempotencyKey: rpc.IdempotencyKey([]byte("report"), 0)})
+		require.NoError(t, err)
+		assert.Equal(t, rpc.TransmissionStatusResponse_Rejected, st.Status)
+		assert.Equal(t, "invalid signatures", st.Reason)
+	})
+	t.Run("transient store error leaves transmission in received state", func(t *testing.T) {
+		s, err := NewServer(logger.Test(t), Config{}, &mockReportStore{err: errors.New("db down")})
+		require.NoError(t, err)
+		res, err := s.Transmit(ctx, &rpc.TransmitRequest{Payload: []byte("report")})
+		require.NoError(t, err)
+		assert.Equal(t, int32(codes.Unavailable), res.Code)
+		assert.Equal(t, "db down", res.Error)
+
+		st, err := s.TransmissionStatus(ctx, &rpc.TransmissionStatusRequest{IdempotencyKey: rpc.IdempotencyKey([]byte("report"), 0)})
+		require.NoError(t, err)
+		assert.Equal(t, rpc.TransmissionStatusResponse_Received, st.Status)
+	})
+}
+
+func Test_Server_Deduplication(t *testing.T) {
+	ctx := tests.Context(t)
+	store := &countingReportStore{InMemoryReportStore: NewInMemoryReportStore()}
+	s, err := NewServer(logger.Test(t), Config{}, store)
+	require.NoError(t, err)
+
+	duplicates := testutil.ToFloat64(promDuplicateDeliveriesTotal.WithLabelValues(DefaultTenantName))
+	deliveries := testutil.ToFloat64(promDeliveriesTotal.WithLabelValues(DefaultTenantName))
+
+	// every node of a 4 node DON delivers the same attested report
+	for i := 0; i < 4; i++ {
+		res, err := s.Transmit(ctx, &rpc.TransmitRequest{Payload: []byte("report"), ReportFormat: 1})
+		require.NoError(t, err)
+		assert.Zero(t, res.Code)
+	}
+	key := rpc.IdempotencyKey([]byte("report"), 1)
+	assert.Equal(t, 1, store.count)
+	assert.Equal(t, 4, s.DeliveryCount(key))
+	assert.Equal(t, 0, s.DeliveryCount("unknown"))
+	assert.Equal(t, deliveries+4, testutil.ToFloat64(promDeliveriesTotal.WithLabelValues(DefaultTenantName)))
+	assert.Equal(t, duplicates+3, testutil.ToFloat64(promDuplicateDeliveriesTotal.WithLabelValues(DefaultTenantName)))
+
+	st, err := s.TransmissionStatus(ctx, &rpc.TransmissionStatusRequest{IdempotencyKey: key})
+	require.NoError(t, err)
+	assert.Equal(t, rpc.TransmissionStatusResponse_Persisted, st.Status)
+
+	t.Run("retries deliveries that were not persisted", func(t *testing.T) {
+		store.err = errors.New("db down")
+		res, err := s.Transmit(ctx, &rpc.TransmitRequest{Payload: []byte("other report"), ReportFormat: 1})
+		require.NoError(t, err)
+		assert.Equal(t, int32(codes.Unavailable), res.Code)
+
+		store.err = nil
+		res, err = s.Transmit(ctx, &rpc.TransmitRequest{Payload: []byte("other report"), ReportFormat: 1})
+		require.NoError(t, err)
+		assert.Zero(t, res.Code)
+		assert.Equal(t, 3, store.count)
+	})
+}
+
+func Test_Server_ConcurrentDeliveries(t *testing.T) {
+	ctx := tests.Context(t)
+	store := &blockingReportStore{InMemoryReportStore: NewInMemoryReportStore(), unblock: make(chan struct{})}
+	s, err := NewServer(logger.Test(t), Config{}, store)
+	require.NoError(t, err)
+	duplicates := testutil.ToFloat64(promDuplicateDeliveriesTotal.WithLabelValues(DefaultTenantName))
+
+	// every node of a 4 node DON delivers the same attested report at once
+	key := rpc.IdempotencyKey([]byte("report"), 1)
+	var wg sync.WaitGroup
+	resCodes := make([]int32, 4)
+	for i := range resCodes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := s.Transmit(ctx, &rpc.TransmitRequest{Payload: []byte("report"), ReportFormat: 1})
+			assert.NoError(t, err)
+			resCodes[i] = res.GetCode()
+		}()
+	}
+	require.Eventually(t, func() bool { return s.DeliveryCount(key) == 4 }, tests.WaitTimeout(t), time.Millisecond)
+	close(store.unblock)
+	wg.Wait()
+
+	assert.Equal(t, []int32{0, 0, 0, 0}, resCodes)
+	assert.Equal(t, int32(1), store.count.Load())
+	assert.Equal(t, duplicates+3, testutil.ToFloat64(promDuplicateDeliveriesTotal.WithLabelValues(DefaultTenantName)))
+}
+
+// blockingReportStore stores reports once unblock is closed
+type blockingReportStore struct {
+	*InMemoryReportStore
+	count   atomic.Int32
+	unblock chan struct{}
+}
+
+func (b *blockingReportStore) Store(ctx context.Context, key string, req *rpc.TransmitRequest) error {
+	b.count.Add(1)
+	<-b.unblock
+	return b.InMemoryReportStore.Store(ctx, key, req)
+}
+
+type countingReportStore struct {
+	*InMemoryReportStore
+	count int
+	err   error
+}
+
+func (c *countingReportStore) Store(ctx context.Context, key string, req *rpc.TransmitRequest) error {
+	c.count++
+	if c.err != nil {
+		return c.err
+	}
+	return c.InMemoryReportStore.Store(ctx, key, req)
+}
+
+type mockSink struct {
+	err       error
+	published []string
+}
+
+func (m *mockSink) Name() string { return "mockSink" }
+
+func (m *mockSink) Publish(_ context.Context, tenant, idempotencyKey string, _ *rpc.TransmitRequest) error {
+	m.published = append(m.published, tenant+"/"+idempotencyKey)
+	return m.err
+}
+
+func Test_Server_Sinks(t *testing.T) {
+	ctx := tests.Context(t)
+	failing := &mockSink{err: errors.New("broker unavailable")}
+	ok := &mockSink{}
+	s, err := NewServer(logger.Test(t), Config{Sinks: []Sink{failing, ok}}, NewInMemoryReportStore())
+	require.NoError(t, err)
+	sinkErrors := testutil.ToFloat64(promSinkErrorsTotal.WithLabelValues(DefaultTenantName, "mockSink"))
+
+	key := rpc.IdempotencyKey([]byte("report"), 0)
+	res, err := s.Transmit(ctx, &rpc.TransmitRequest{Payload: []byte("report")})
+	require.NoError(t, err)
+	assert.Zero(t, res.Code, "sink errors do not fail the transmission")
+	assert.Equal(t, sinkErrors+1, testutil.ToFloat64(promSinkErrorsTotal.WithLabelValues(DefaultTenantName, "mockSink")))
+
+	// duplicates and rejected reports are not published
+	_, err = s.Transmit(ctx, &rpc.TransmitRequest{Payload: []byte("report")})
+	require.NoError(t, err)
+	_, err = s.Transmit(ctx, &rpc.TransmitRequest{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{DefaultTenantName + "/" + key}, failing.published)
+	assert.Equal(t, []string{DefaultTenantName + "/" + key}, ok.published)
+}
+
+func Test_Server_ServerInfo(t *testing.T) {
+	ctx := tests.Context(t)
+
+	t.Run("defaults", func(t *testing.T) {
+		s, err := NewServer(logger.Test(t), Config{}, NewInMemoryReportStore())
+		require.NoError(t, err)
+		res, err := s.ServerInfo(ctx, &rpc.ServerInfoRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, rpc.SchemaRevision, res.SchemaRevision)
+		assert.Empty(t, res.ReportFormats)
+		assert.Empty(t, res.Compressors)
+		assert.Equal(t, uint64(rpc.DefaultMaxRecvMsgSize), res.Limits.MaxRequestSize)
+		assert.Equal(t, uint64(DefaultMaxTrackedTransmissions), res.Limits.MaxTrackedTransmissions)
+	})
+	t.Run("advertises configured capabilities", func(t *testing.T) {
+		s, err := NewServer(logger.Test(t), Config{
+			Version:        "1.2.3",
+			ReportFormats:  []uint32{1, 2},
+			Compressors:    []string{"gzip"},
+			MaxRequestSize: 1024,
+		}, NewInMemoryReportStore())
+		require.NoError(t, err)
+		res, err := s.ServerInfo(ctx, &rpc.ServerInfoRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, "1.2.3", res.Version)
+		assert.Equal(t, []uint32{1, 2}, res.ReportFormats)
+		assert.Equal(t, []string{"gzip"}, res.Compressors)
+		assert.Equal(t, uint64(1024), res.Limits.MaxRequestSize)
+
+		res2, err := s.Transmit(ctx, &rpc.TransmitRequest{Payload: []byte("report"), ReportFormat: 3})
+		require.NoError(t, err)
+		assert.Equal(t, int32(codes.InvalidArgument), res2.Code)
+		assert.Equal(t, "unsupported report format: 3", res2.Error)
+		res2, err = s.Transmit(ctx, &rpc.TransmitRequest{Payload: []byte("report"), ReportFormat: 2})
+		require.NoError(t, err)
+		assert.Zero(t, res2.Code)
+	})
+	t.Run("rejects unregistered compressors", func(t *testing.T) {
+		_, err := NewServer(logger.Test(t), Config{Compressors: []string{"zstd"}}, NewInMemoryReportStore())
+		assert.EqualError(t, err, `compressor "zstd" is not registered`)
+	})
+}
@@ -0,0 +1,271 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"sync"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+
+	"github.com/smartcontractkit/chainlink-data-streams/llo"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc"
+)
+
+// DefaultMaxCachedSignerSets bounds the number of config digests whose
+// signers a SignatureVerifier keeps in memory
+const DefaultMaxCachedSignerSets = 100
+
+// EVMAddress is the address of an oracle's secp256k1 onchain signing key
+type EVMAddress [20]byte
+
+// SignerSet is the onchain signing keys of the DON with a config digest
+type SignerSet struct {
+	// Signers are the addresses of the oracles' onchain signing keys,
+	// indexed by oracle ID
+	Signers []EVMAddress
+	// F is the maximum number of faulty oracles. Reports are valid with
+	// signatures from at least f+1 distinct oracles.
+	F int
+}
+
+// SignerSets looks up the signers of a config digest, e.g. from the
+// configuration contract. It should return an error wrapping
+// ErrUnknownConfigDigest if the config digest is not known.
+type SignerSets interface {
+	SignerSet(ctx context.Context, digest types.ConfigDigest) (SignerSet, error)
+}
+
+// ErrUnknownConfigDigest is returned by SignerSets for config digests that
+// they have no signers for
+var ErrUnknownConfigDigest = errors.New("unknown config digest")
+
+// errSignerSetUnavailable wraps transient failures to look up signers, as
+// opposed to invalid payloads
+var errSignerSetUnavailable = errors.New("signers unavailable")
+
+type SignatureVerifierConfig struct {
+	// Workers is the number of payloads verified in parallel by VerifyBatch.
+	// Defaults to GOMAXPROCS if zero.
+	Workers int
+	// MaxCachedSignerSets is the number of config digests whose signers are
+	// cached. Defaults to DefaultMaxCachedSignerSets if zero.
+	MaxCachedSignerSets int
+	// ReportFormats are the formats whose payloads are verified by the
+	// server, which must be EVM payloads as packed by llo.PackEVMPayload.
+	// Defaults to ReportFormatEVMPremiumLegacy if empty.
+	ReportFormats []uint32
+}
+
+// SignatureVerifier verifies the secp256k1 signatures of attested EVM
+// payloads against the signers of their config digest. Recovering signers
+// is expensive, so VerifyBatch spreads payloads over multiple workers, and
+// the mapping from signer address to oracle is cached per config digest so
+// that SignerSets is only consulted once per DON.
+type SignatureVerifier struct {
+	signerSets    SignerSets
+	workers       int
+	maxCached     int
+	reportFormats []uint32
+
+	mu sync.Mutex
+	// oracles maps the signer addresses of each cached config digest to
+	// their oracle IDs
+	oracles map[types.ConfigDigest]*cachedSignerSet
+	// cached config digests, least recently used first
+	lru []types.ConfigDigest
+}
+
+type cachedSignerSet struct {
+	oracles map[EVMAddress]commontypes.OracleID
+	f       int
+}
+
+func NewSignatureVerifier(signerSets SignerSets, cfg SignatureVerifierConfig) *SignatureVerifier {
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	maxCached := cfg.MaxCachedSignerSets
+	if maxCached <= 0 {
+		maxCached = DefaultMaxCachedSignerSets
+	}
+	reportFormats := cfg.ReportFormats
+	if len(reportFormats) == 0 {
+		reportFormats = []uint32{uint32(llotypes.ReportFormatEVMPremiumLegacy)}
+	}
+	return &SignatureVerifier{
+		signerSets:    signerSets,
+		workers:       workers,
+		maxCached:     maxCached,
+		reportFormats: reportFormats,
+		oracles:       make(map[types.ConfigDigest]*cachedSignerSet),
+	}
+}
+
+// verifies returns true if payloads of the report format are verified
+func (v *SignatureVerifier) verifies(reportFormat uint32) bool {
+	return slices.Contains(v.reportFormats, reportFormat)
+}
+
+// Verify checks that the EVM payload is signed by at least f+1 distinct
+// oracles of its config digest, and by no one else
+func (v *SignatureVerifier) Verify(ctx context.Context, payload []byte) error {
+	digest, seqNr, report, sigs, err := llo.UnpackEVMPayload(payload)
+	if err != nil {
+		return err
+	}
+	signers, err := v.signerSet(ctx, digest)
+	if err != nil {
+		return err
+	}
+	hash, err := llo.EVMReportSigningHash(digest, seqNr, report)
+	if err != nil {
+		return err
+	}
+	signed := make(map[commontypes.OracleID]struct{}, len(sigs))
+	for i, sig := range sigs {
+		address, err := RecoverEVMSigner(hash, sig.Signature)
+		if err != nil {
+			return fmt.Errorf("invalid signature %d: %w", i, err)
+		}
+		oracle, ok := signers.oracles[address]
+		if !ok {
+			return fmt.Errorf("signature %d is by 0x%x, which is not a signer of config digest %s", i, address, digest)
+		}
+		if _, exists := signed[oracle]; exists {
+			return fmt.Errorf("signature %d is a duplicate signature by oracle %d", i, oracle)
+		}
+		signed[oracle] = struct{}{}
+	}
+	if len(signed) <= signers.f {
+		return fmt.Errorf("not enough signatures; got: %d, need at least f+1: %d", len(signed), signers.f+1)
+	}
+	return nil
+}
+
+// VerifyBatch verifies many payloads in parallel, returning the result of
+// Verify for each payload in the same order
+func (v *SignatureVerifier) VerifyBatch(ctx context.Context, payloads [][]byte) []error {
+	errs := make([]error, len(payloads))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range min(v.workers, len(payloads)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := ctx.Err(); err != nil {
+					errs[i] = err
+					continue
+				}
+				errs[i] = v.Verify(ctx, payloads[i])
+			}
+		}()
+	}
+	for i := range payloads {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return errs
+}
+
+// signerSet returns the signers of the config digest, from the cache if
+// possible
+func (v *SignatureVerifier) signerSet(ctx context.Context, digest types.ConfigDigest) (*cachedSignerSet, error) {
+	v.mu.Lock()
+	cached, ok := v.oracles[digest]
+	if ok {
+		v.touch(digest)
+	}
+	v.mu.Unlock()
+	if ok {
+		return cached, nil
+	}
+
+	// Concurrent misses for the same digest may both look it up; the
+	// results are identical so it does not matter which one is cached
+	set, err := v.signerSets.SignerSet(ctx, digest)
+	if errors.Is(err, ErrUnknownConfigDigest) {
+		return nil, fmt.Errorf("no signers for config digest %s: %w", digest, err)
+	} else if err != nil {
+		return nil, fmt.Errorf("%w: failed to look up signers of config digest %s: %w", errSignerSetUnavailable, digest, err)
+	}
+	cached = &cachedSignerSet{oracles: make(map[EVMAddress]commontypes.OracleID, len(set.Signers)), f: set.F}
+	for i, address := range set.Signers {
+		cached.oracles[address] = commontypes.OracleID(i)
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if _, exists := v.oracles[digest]; !exists {
+		if len(v.lru) >= v.maxCached {
+			delete(v.oracles, v.lru[0])
+			v.lru = v.lru[1:]
+		}
+		v.lru = append(v.lru, digest)
+	}
+	v.oracles[digest] = cached
+	return cached, nil
+}
+
+// touch marks the config digest as the most recently used; v.mu must be
+// held
+func (v *SignatureVerifier) touch(digest types.ConfigDigest) {
+	i := slices.Index(v.lru, digest)
+	if i < 0 || i == len(v.lru)-1 {
+		return
+	}
+	v.lru = append(slices.Delete(v.lru, i, i+1), digest)
+}
+
+// verifySignatures checks the signatures of the request's payload, and that
+// it was attested by the DON whose config digest the request was routed by
+func (s *Server) verifySignatures(ctx context.Context, req *rpc.TransmitRequest) error {
+	if len(req.ConfigDigest) > 0 {
+		digest, _, _, _, err := llo.UnpackEVMPayload(req.Payload)
+		if err == nil && !bytes.Equal(digest[:], req.ConfigDigest) {
+			return fmt.Errorf("payload is attested by config digest %s, but the request is for config digest %x", digest, req.ConfigDigest)
+		}
+	}
+	return s.verifier.Verify(ctx, req.Payload)
+}
+
+// RecoverEVMSigner returns the address of the key that produced an EVM
+// signature over hash. The signature is r || s || v, where v is either 0/1
+// or 27/28.
+func RecoverEVMSigner(hash [32]byte, signature []byte) (address EVMAddress, err error) {
+	if len(signature) != 65 {
+		return address, fmt.Errorf("invalid signature length; expected: 65, got: %d", len(signature))
+	}
+	v := signature[64]
+	if v >= 27 {
+		v -= 27
+	}
+	if v > 1 {
+		return address, fmt.Errorf("invalid signature recovery id: %d", signature[64])
+	}
+	// ecdsa expects the recovery code first, offset by 27
+	compact := make([]byte, 0, 65)
+	compact = append(compact, 27+v)
+	compact = append(compact, signature[:64]...)
+	pk, _, err := ecdsa.RecoverCompact(compact, hash[:])
+	if err != nil {
+		return address, err
+	}
+	h := sha3.NewLegacyKeccak256()
+	// skip the 0x04 prefix of the uncompressed encoding
+	h.Write(pk.SerializeUncompressed()[1:])
+	copy(address[:], h.Sum(nil)[12:])
+	return address, nil
+}
@@ -0,0 +1,243 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+	"google.golang.org/grpc/codes"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink-data-streams/llo"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc"
+)
+
+type mockSignerSets struct {
+	mu      sync.Mutex
+	sets    map[types.ConfigDigest]SignerSet
+	err     error
+	lookups int
+}
+
+func (m *mockSignerSets) SignerSet(_ context.Context, digest types.ConfigDigest) (SignerSet, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lookups++
+	if m.err != nil {
+		return SignerSet{}, m.err
+	}
+	set, ok := m.sets[digest]
+	if !ok {
+		return SignerSet{}, ErrUnknownConfigDigest
+	}
+	return set, nil
+}
+
+type testSigner struct {
+	key     *secp256k1.PrivateKey
+	address EVMAddress
+}
+
+func newTestSigners(t *testing.T, n int) (signers []testSigner, addresses []EVMAddress) {
+	for range n {
+		key, err := secp256k1.GeneratePrivateKey()
+		require.NoError(t, err)
+		h := sha3.NewLegacyKeccak256()
+		h.Write(key.PubKey().SerializeUncompressed()[1:])
+		var address EVMAddress
+		copy(address[:], h.Sum(nil)[12:])
+		signers = append(signers, testSigner{key, address})
+		addresses = append(addresses, address)
+	}
+	return signers, addresses
+}
+
+// sign returns an EVM signature (r || s || v, with v 0 or 1)
+func (s testSigner) sign(hash [32]byte) []byte {
+	compact := ecdsa.SignCompact(s.key, hash[:], false)
+	return append(compact[1:], compact[0]-27)
+}
+
+func signedPayload(t *testing.T, digest types.ConfigDigest, seqNr uint64, report []byte, signers ...testSigner) []byte {
+	hash, err := llo.EVMReportSigningHash(digest, seqNr, report)
+	require.NoError(t, err)
+	sigs := make([]types.AttributedOnchainSignature, len(signers))
+	for i, s := range signers {
+		sigs[i] = types.AttributedOnchainSignature{Signature: s.sign(hash), Signer: commontypes.OracleID(i)}
+	}
+	payload, err := llo.PackEVMPayload(digest, seqNr, report, sigs)
+	require.NoError(t, err)
+	return payload
+}
+
+func Test_RecoverEVMSigner(t *testing.T) {
+	signers, _ := newTestSigners(t, 1)
+	hash := [32]byte{1, 2, 3}
+	sig := signers[0].sign(hash)
+
+	address, err := RecoverEVMSigner(hash, sig)
+	require.NoError(t, err)
+	assert.Equal(t, signers[0].address, address)
+
+	// v may also be offset by 27, as expected by ecrecover
+	sig27 := append([]byte{}, sig...)
+	sig27[64] += 27
+	address, err = RecoverEVMSigner(hash, sig27)
+	require.NoError(t, err)
+	assert.Equal(t, signers[0].address, address)
+
+	address, err = RecoverEVMSigner([32]byte{4}, sig)
+	if err == nil {
+		assert.NotEqual(t, signers[0].address, address, "signature over a different hash must not recover the signer")
+	}
+
+	_, err = RecoverEVMSigner(hash, sig[:64])
+	assert.EqualError(t, err, "invalid signature length; expected: 65, got: 64")
+	sig[64] = 5
+	_, err = RecoverEVMSigner(hash, sig)
+	assert.EqualError(t, err, "invalid signature recovery id: 5")
+}
+
+func Test_SignatureVerifier(t *testing.T) {
+	ctx := tests.Context(t)
+	digest := types.ConfigDigest{1}
+	otherDigest := types.ConfigDigest{2}
+	signers, addresses := newTestSigners(t, 4)
+	outsiders, _ := newTestSigners(t, 1)
+	sets := &mockSignerSets{sets: map[types.ConfigDigest]SignerSet{
+		digest:      {Signers: addresses, F: 1},
+		otherDigest: {Signers: addresses[:1], F: 0},
+	}}
+	v := NewSignatureVerifier(sets, SignatureVerifierConfig{Workers: 3})
+	report := []byte("report")
+
+	t.Run("verifies payloads signed by f+1 oracles", func(t *testing.T) {
+		require.NoError(t, v.Verify(ctx, signedPayload(t, digest, 42, report, signers[3], signers[1])))
+		require.NoError(t, v.Verify(ctx, signedPayload(t, digest, 43, report, signers...)))
+	})
+	t.Run("rejects invalid payloads", func(t *testing.T) {
+		err := v.Verify(ctx, signedPayload(t, digest, 42, report, signers[0]))
+		assert.EqualError(t, err, "not enough signatures; got: 1, need at least f+1: 2")
+
+		err = v.Verify(ctx, signedPayload(t, digest, 42, report, signers[0], signers[0]))
+		assert.EqualError(t, err, "signature 1 is a duplicate signature by oracle 0")
+
+		err = v.Verify(ctx, signedPayload(t, digest, 42, report, signers[0], outsiders[0]))
+		assert.ErrorContains(t, err, "signature 1 is by 0x")
+		assert.ErrorContains(t, err, "which is not a signer of config digest")
+
+		// signed for a different sequence number
+		payload := signedPayload(t, digest, 42, report, signers[0], signers[1])
+		_, _, _, sigs, err := llo.UnpackEVMPayload(payload)
+		require.NoError(t, err)
+		tampered, err := llo.PackEVMPayload(digest, 44, report, sigs)
+		require.NoError(t, err)
+		assert.Error(t, v.Verify(ctx, tampered))
+
+		err = v.Verify(ctx, signedPayload(t, types.ConfigDigest{3}, 42, report, signers...))
+		assert.ErrorIs(t, err, ErrUnknownConfigDigest)
+		assert.NotErrorIs(t, err, errSignerSetUnavailable)
+
+		assert.Error(t, v.Verify(ctx, []byte("not a payload")))
+	})
+	t.Run("VerifyBatch returns results in order", func(t *testing.T) {
+		payloads := [][]byte{
+			signedPayload(t, digest, 42, report, signers[0], signers[1]),
+			signedPayload(t, digest, 42, report, signers[0]),
+			signedPayload(t, otherDigest, 42, report, signers[0]),
+			signedPayload(t, otherDigest, 42, report, signers[1]),
+			[]byte("not a payload"),
+		}
+		errs := v.VerifyBatch(ctx, payloads)
+		require.Len(t, errs, len(payloads))
+		assert.NoError(t, errs[0])
+		assert.Error(t, errs[1])
+		assert.NoError(t, errs[2])
+		assert.Error(t, errs[3])
+		assert.Error(t, errs[4])
+
+		assert.Empty(t, v.VerifyBatch(ctx, nil))
+	})
+	t.Run("caches the signers of each config digest", func(t *testing.T) {
+		// digest, otherDigest and the unknown digest; errors are not cached
+		sets.mu.Lock()
+		lookups := sets.lookups
+		sets.mu.Unlock()
+		assert.Equal(t, 3, lookups)
+	})
+	t.Run("evicts the least recently used config digest", func(t *testing.T) {
+		sets := &mockSignerSets{sets: map[types.ConfigDigest]SignerSet{
+			{1}: {Signers: addresses, F: 0},
+			{2}: {Signers: addresses, F: 0},
+			{3}: {Signers: addresses, F: 0},
+		}}
+		v := NewSignatureVerifier(sets, SignatureVerifierConfig{MaxCachedSignerSets: 2})
+		for _, d := range []types.ConfigDigest{{1}, {2}, {1}, {3}, {1}, {2}} {
+			require.NoError(t, v.Verify(ctx, signedPayload(t, d, 1, report, signers[0])))
+		}
+		// {2} was evicted by {3}, since {1} was used more recently
+		assert.Equal(t, 4, sets.lookups)
+	})
+	t.Run("lookup failures are transient", func(t *testing.T) {
+		sets := &mockSignerSets{err: errors.New("rpc down")}
+		v := NewSignatureVerifier(sets, SignatureVerifierConfig{})
+		err := v.Verify(ctx, signedPayload(t, digest, 1, report, signers[0]))
+		assert.ErrorIs(t, err, errSignerSetUnavailable)
+		assert.ErrorContains(t, err, "rpc down")
+	})
+}
+
+func Test_Server_SignatureVerification(t *testing.T) {
+	ctx := tests.Context(t)
+	digest := types.ConfigDigest{1}
+	signers, addresses := newTestSigners(t, 4)
+	sets := &mockSignerSets{sets: map[types.ConfigDigest]SignerSet{digest: {Signers: addresses, F: 1}}}
+	s, err := NewServer(logger.Test(t), Config{SignatureVerifier: NewSignatureVerifier(sets, SignatureVerifierConfig{})}, NewInMemoryReportStore())
+	require.NoError(t, err)
+	evm := uint32(llotypes.ReportFormatEVMPremiumLegacy)
+	invalid := testutil.ToFloat64(promInvalidSignaturesTotal.WithLabelValues(DefaultTenantName))
+
+	res, err := s.Transmit(ctx, &rpc.TransmitRequest{Payload: signedPayload(t, digest, 1, []byte("report"), signers[0], signers[1]), ReportFormat: evm, ConfigDigest: digest[:]})
+	require.NoError(t, err)
+	assert.Zero(t, res.Code)
+
+	res, err = s.Transmit(ctx, &rpc.TransmitRequest{Payload: signedPayload(t, digest, 2, []byte("report"), signers[0]), ReportFormat: evm, ConfigDigest: digest[:]})
+	require.NoError(t, err)
+	assert.Equal(t, int32(codes.InvalidArgument), res.Code)
+	assert.Contains(t, res.Error, "invalid signatures: not enough signatures")
+	assert.Equal(t, invalid+1, testutil.ToFloat64(promInvalidSignaturesTotal.WithLabelValues(DefaultTenantName)))
+
+	// the payload must be attested by the DON that the request is for
+	otherDigest := types.ConfigDigest{2}
+	res, err = s.Transmit(ctx, &rpc.TransmitRequest{Payload: signedPayload(t, digest, 3, []byte("report"), signers...), ReportFormat: evm, ConfigDigest: otherDigest[:]})
+	require.NoError(t, err)
+	assert.Equal(t, int32(codes.InvalidArgument), res.Code)
+	assert.Contains(t, res.Error, "but the request is for config digest")
+
+	// other formats are not verified
+	res, err = s.Transmit(ctx, &rpc.TransmitRequest{Payload: []byte("report"), ReportFormat: uint32(llotypes.ReportFormatJSON)})
+	require.NoError(t, err)
+	assert.Zero(t, res.Code)
+
+	sets.mu.Lock()
+	sets.err = errors.New("rpc down")
+	sets.mu.Unlock()
+	s, err = NewServer(logger.Test(t), Config{SignatureVerifier: NewSignatureVerifier(sets, SignatureVerifierConfig{})}, NewInMemoryReportStore())
+	require.NoError(t, err)
+	res, err = s.Transmit(ctx, &rpc.TransmitRequest{Payload: signedPayload(t, digest, 4, []byte("report"), signers...), ReportFormat: evm})
+	require.NoError(t, err)
+	assert.Equal(t, int32(codes.Unavailable), res.Code)
+}
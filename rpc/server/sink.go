@@ -0,0 +1,28 @@
+package server
+
+import (
+	"context"
+
+	"github.com/smartcontractkit/chainlink-data-streams/rpc"
+)
+
+// Sink is notified of every report persisted by the server, e.g. to publish
+// it to a message queue for downstream consumers.
+//
+// Sinks are called synchronously, in order, after the report has been
+// stored and before the transmission is acknowledged. The report has already
+// been persisted so errors do not fail the transmission; they are logged and
+// counted, and the report will not be published to the sink again.
+type Sink interface {
+	Name() string
+	Publish(ctx context.Context, tenant, idempotencyKey string, req *rpc.TransmitRequest) error
+}
+
+func (s *Server) publish(ctx context.Context, tenant, key string, req *rpc.TransmitRequest) {
+	for _, sink := range s.sinks {
+		if err := sink.Publish(ctx, tenant, key, req); err != nil {
+			promSinkErrorsTotal.WithLabelValues(tenant, sink.Name()).Inc()
+			s.lggr.Warnw("Failed to publish report to sink", "sink", sink.Name(), "idempotencyKey", key, "tenant", tenant, "err", err)
+		}
+	}
+}
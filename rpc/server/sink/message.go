@@ -0,0 +1,68 @@
+// Package sink contains the message format shared by the server.Sink
+// implementations that publish persisted reports to message brokers.
+package sink
+
+import (
+	"encoding/json"
+	"fmt"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+
+	"github.com/smartcontractkit/chainlink-data-streams/rpc"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc/server/archive"
+)
+
+// ReportMessage is the JSON body of each published message
+type ReportMessage struct {
+	Tenant                      string          `json:"tenant"`
+	IdempotencyKey              string          `json:"idempotencyKey"`
+	ReportFormat                string          `json:"reportFormat"`
+	ChannelID                   uint32          `json:"channelID"`
+	FeedID                      string          `json:"feedID,omitempty"`
+	ConfigDigest                string          `json:"configDigest"`
+	SeqNr                       uint64          `json:"seqNr"`
+	ObservationTimestampSeconds uint32          `json:"observationTimestampSeconds"`
+	Fields                      json.RawMessage `json:"fields"`
+	Payload                     []byte          `json:"payload"`
+}
+
+// Encoder decodes transmitted reports and builds their messages
+type Encoder struct {
+	decoder *archive.Decoder
+}
+
+// NewEncoder returns an Encoder for reports of the given channels, which are
+// used to map the feed IDs of EVM reports to their channels
+func NewEncoder(cds llotypes.ChannelDefinitions) (*Encoder, error) {
+	d, err := archive.NewDecoder(cds)
+	if err != nil {
+		return nil, fmt.Errorf("invalid channel definitions: %w", err)
+	}
+	return &Encoder{d}, nil
+}
+
+// Encode returns the message for a persisted report along with its
+// JSON encoding
+func (e *Encoder) Encode(tenant, idempotencyKey string, req *rpc.TransmitRequest) (ReportMessage, []byte, error) {
+	r, err := e.decoder.Decode(idempotencyKey, req)
+	if err != nil {
+		return ReportMessage{}, nil, fmt.Errorf("failed to decode report: %w", err)
+	}
+	m := ReportMessage{
+		Tenant:                      tenant,
+		IdempotencyKey:              idempotencyKey,
+		ReportFormat:                r.ReportFormat,
+		ChannelID:                   r.ChannelID,
+		FeedID:                      r.FeedID,
+		ConfigDigest:                r.ConfigDigest,
+		SeqNr:                       r.SeqNr,
+		ObservationTimestampSeconds: r.ObservationTimestampSeconds,
+		Fields:                      json.RawMessage(r.Fields),
+		Payload:                     req.Payload,
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return m, nil, fmt.Errorf("failed to encode message: %w", err)
+	}
+	return m, b, nil
+}
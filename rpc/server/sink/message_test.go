@@ -0,0 +1,59 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+
+	"github.com/smartcontractkit/chainlink-data-streams/llo"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc"
+)
+
+func Test_Encoder(t *testing.T) {
+	e, err := NewEncoder(nil)
+	require.NoError(t, err)
+
+	cdc := llo.JSONReportCodec{}
+	report := llo.Report{
+		ConfigDigest:                types.ConfigDigest{1},
+		SeqNr:                       5,
+		ChannelID:                   3,
+		ObservationTimestampSeconds: 1726670490,
+		Values:                      []llo.StreamValue{llo.ToDecimal(decimal.NewFromInt(42))},
+	}
+	encoded, err := cdc.Encode(context.Background(), report, llotypes.ChannelDefinition{})
+	require.NoError(t, err)
+	payload, err := cdc.Pack(types.ConfigDigest{1}, 5, encoded, nil)
+	require.NoError(t, err)
+
+	m, b, err := e.Encode("tenant", "key", &rpc.TransmitRequest{Payload: payload, ReportFormat: uint32(llotypes.ReportFormatJSON)})
+	require.NoError(t, err)
+	assert.Equal(t, ReportMessage{
+		Tenant:                      "tenant",
+		IdempotencyKey:              "key",
+		ReportFormat:                "json",
+		ChannelID:                   3,
+		ConfigDigest:                types.ConfigDigest{1}.Hex(),
+		SeqNr:                       5,
+		ObservationTimestampSeconds: 1726670490,
+		Fields:                      json.RawMessage(encoded),
+		Payload:                     payload,
+	}, m)
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Equal(t, "tenant", decoded["tenant"])
+	assert.Equal(t, float64(3), decoded["channelID"])
+	assert.NotContains(t, decoded, "feedID")
+
+	_, _, err = e.Encode("tenant", "key", &rpc.TransmitRequest{Payload: payload, ReportFormat: uint32(llotypes.ReportFormatRetirement)})
+	assert.EqualError(t, err, `failed to decode report: unsupported ReportFormat="retirement"`)
+}
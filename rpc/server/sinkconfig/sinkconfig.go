@@ -0,0 +1,96 @@
+// Package sinkconfig builds the server.Sinks selected by configuration, so
+// that deployments can choose between Kafka and NATS without writing code.
+package sinkconfig
+
+import (
+	"fmt"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+
+	"github.com/smartcontractkit/chainlink-data-streams/rpc/server"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc/server/kafka"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc/server/nats"
+)
+
+type Config struct {
+	// Kafka publishes every persisted report to a Kafka topic if set
+	Kafka *KafkaConfig
+	// NATS publishes every persisted report to NATS JetStream if set. It
+	// is a lightweight alternative for deployments that don't run Kafka.
+	NATS *NATSConfig
+}
+
+type KafkaConfig struct {
+	// Brokers are the seed brokers used to discover the cluster
+	Brokers []string
+	// ClientID identifies this server in the brokers' logs and quotas
+	ClientID string
+	// Topic that reports are published to
+	Topic string
+}
+
+type NATSConfig struct {
+	// URL of the NATS server, or a comma-separated list of URLs of a
+	// cluster
+	URL string
+	// Name identifies this server's connection in NATS monitoring
+	Name string
+	// SubjectPrefix is prepended to the channel ID to form the subject of
+	// each report
+	SubjectPrefix string
+}
+
+// Sinks are the sinks built from a Config, to be passed to the server as
+// server.Config.Sinks
+type Sinks struct {
+	Sinks   []server.Sink
+	closers []func()
+}
+
+// New connects to every sink in cfg. The channel definitions are used to
+// map the feed IDs of EVM reports to their channels.
+func New(cfg Config, cds llotypes.ChannelDefinitions) (*Sinks, error) {
+	s := &Sinks{}
+	if err := s.connect(cfg, cds); err != nil {
+		s.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Sinks) connect(cfg Config, cds llotypes.ChannelDefinitions) error {
+	if cfg.Kafka != nil {
+		w, err := kafka.NewClientWriter(kafka.ClientConfig{Brokers: cfg.Kafka.Brokers, ClientID: cfg.Kafka.ClientID})
+		if err != nil {
+			return fmt.Errorf("kafka: %w", err)
+		}
+		s.closers = append(s.closers, w.Close)
+		p, err := kafka.NewPublisher(w, kafka.Config{Topic: cfg.Kafka.Topic, ChannelDefinitions: cds})
+		if err != nil {
+			return fmt.Errorf("kafka: %w", err)
+		}
+		s.Sinks = append(s.Sinks, p)
+	}
+	if cfg.NATS != nil {
+		c, err := nats.Connect(nats.ClientConfig{URL: cfg.NATS.URL, Name: cfg.NATS.Name})
+		if err != nil {
+			return fmt.Errorf("nats: %w", err)
+		}
+		s.closers = append(s.closers, c.Close)
+		p, err := nats.NewPublisher(c, nats.Config{SubjectPrefix: cfg.NATS.SubjectPrefix, ChannelDefinitions: cds})
+		if err != nil {
+			return fmt.Errorf("nats: %w", err)
+		}
+		s.Sinks = append(s.Sinks, p)
+	}
+	return nil
+}
+
+// Close closes the sinks' connections. It must only be called once the
+// server has stopped publishing to them.
+func (s *Sinks) Close() {
+	for _, c := range s.closers {
+		c()
+	}
+	s.closers = nil
+}
@@ -0,0 +1,35 @@
+package sinkconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_New(t *testing.T) {
+	t.Run("no sinks", func(t *testing.T) {
+		s, err := New(Config{}, nil)
+		require.NoError(t, err)
+		assert.Empty(t, s.Sinks)
+		s.Close()
+	})
+	t.Run("kafka", func(t *testing.T) {
+		s, err := New(Config{Kafka: &KafkaConfig{Brokers: []string{"localhost:9092"}, Topic: "reports"}}, nil)
+		require.NoError(t, err)
+		t.Cleanup(s.Close)
+		require.Len(t, s.Sinks, 1)
+		assert.Equal(t, "KafkaPublisher", s.Sinks[0].Name())
+	})
+	t.Run("invalid kafka config", func(t *testing.T) {
+		_, err := New(Config{Kafka: &KafkaConfig{Brokers: []string{"localhost:9092"}}}, nil)
+		require.EqualError(t, err, "kafka: topic is required")
+	})
+	t.Run("invalid nats config", func(t *testing.T) {
+		_, err := New(Config{
+			Kafka: &KafkaConfig{Brokers: []string{"localhost:9092"}, Topic: "reports"},
+			NATS:  &NATSConfig{SubjectPrefix: "llo.reports"},
+		}, nil)
+		require.EqualError(t, err, "nats: url is required")
+	})
+}
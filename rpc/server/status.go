@@ -0,0 +1,159 @@
+package server
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink-data-streams/rpc"
+)
+
+// statusTracker keeps the delivery status of recent transmissions in memory,
+// keyed by idempotency key. It holds at most maxEntries statuses and evicts
+// the oldest transmissions first.
+type statusTracker struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	order      *list.List
+}
+
+type statusEntry struct {
+	idempotencyKey string
+	status         rpc.TransmissionStatusResponse_Status
+	reason         string
+	updatedAt      time.Time
+	// deliveries counts how many times the transmission has been received,
+	// e.g. once from every node of the DON
+	deliveries int
+	// storing is set while a delivery is storing the transmission, and is
+	// closed when it is done
+	storing chan struct{}
+}
+
+func newStatusTracker(maxEntries int) *statusTracker {
+	return &statusTracker{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+func (t *statusTracker) set(idempotencyKey string, status rpc.TransmissionStatusResponse_Status, reason string, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if elem, exists := t.entries[idempotencyKey]; exists {
+		e := elem.Value.(*statusEntry)
+		e.status, e.reason, e.updatedAt = status, reason, now
+		return
+	}
+	t.push(&statusEntry{idempotencyKey, status, reason, now, 0, nil})
+}
+
+func (t *statusTracker) push(e *statusEntry) {
+	t.entries[e.idempotencyKey] = t.order.PushBack(e)
+	for t.order.Len() > t.maxEntries {
+		oldest := t.order.Remove(t.order.Front()).(*statusEntry)
+		delete(t.entries, oldest.idempotencyKey)
+	}
+}
+
+// deliver records a delivery of a transmission, tracking it as Received if
+// it was not already known. It returns the total number of deliveries
+// including this one.
+func (t *statusTracker) deliver(idempotencyKey string, now time.Time) (deliveries int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if elem, exists := t.entries[idempotencyKey]; exists {
+		e := elem.Value.(*statusEntry)
+		e.deliveries++
+		return e.deliveries
+	}
+	t.push(&statusEntry{idempotencyKey, rpc.TransmissionStatusResponse_Received, "", now, 1, nil})
+	return 1
+}
+
+// claim gives the caller the exclusive right to store a transmission, so
+// that concurrent deliveries of the same report don't all store it. The
+// caller must call release once it has stored the transmission (or failed
+// to). If another delivery holds the claim, claim waits for it to be
+// released and tries again. It returns a nil release if the transmission
+// was already persisted, or an error if ctx expires while waiting.
+func (t *statusTracker) claim(ctx context.Context, idempotencyKey string, now time.Time) (release func(), err error) {
+	for {
+		release, storing := t.tryClaim(idempotencyKey, now)
+		if storing == nil {
+			return release, nil
+		}
+		select {
+		case <-storing:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (t *statusTracker) tryClaim(idempotencyKey string, now time.Time) (release func(), storing <-chan struct{}) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var e *statusEntry
+	if elem, exists := t.entries[idempotencyKey]; exists {
+		e = elem.Value.(*statusEntry)
+	} else {
+		// evicted since it was delivered
+		e = &statusEntry{idempotencyKey, rpc.TransmissionStatusResponse_Received, "", now, 1, nil}
+		t.push(e)
+	}
+	switch {
+	case e.storing != nil:
+		return nil, e.storing
+	case e.status == rpc.TransmissionStatusResponse_Persisted:
+		return nil, nil
+	}
+	done := make(chan struct{})
+	e.storing = done
+	return func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		if e.storing == done {
+			e.storing = nil
+		}
+		close(done)
+	}, nil
+}
+
+// deliveries returns how many times a transmission has been received
+func (t *statusTracker) deliveries(idempotencyKey string) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if elem, exists := t.entries[idempotencyKey]; exists {
+		return elem.Value.(*statusEntry).deliveries
+	}
+	return 0
+}
+
+// get returns the status of a transmission, or Unknown if the server has
+// never seen it (or it has since been evicted)
+func (t *statusTracker) get(idempotencyKey string) *rpc.TransmissionStatusResponse {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	elem, exists := t.entries[idempotencyKey]
+	if !exists {
+		return &rpc.TransmissionStatusResponse{Status: rpc.TransmissionStatusResponse_Unknown}
+	}
+	e := elem.Value.(*statusEntry)
+	return &rpc.TransmissionStatusResponse{
+		Status: e.status,
+		Reason: e.reason,
+		UpdatedAt: &rpc.Timestamp{
+			Seconds: e.updatedAt.Unix(),
+			Nanos:   int32(e.updatedAt.Nanosecond()),
+		},
+	}
+}
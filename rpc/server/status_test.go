@@ -0,0 +1,84 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink-data-streams/rpc"
+)
+
+func Test_statusTracker(t *testing.T) {
+	now := time.Unix(1726670490, 123)
+
+	t.Run("updates existing entries in place", func(t *testing.T) {
+		tr := newStatusTracker(10)
+		tr.set("foo", rpc.TransmissionStatusResponse_Received, "", now)
+		tr.set("foo", rpc.TransmissionStatusResponse_Rejected, "bad", now.Add(time.Second))
+
+		st := tr.get("foo")
+		assert.Equal(t, rpc.TransmissionStatusResponse_Rejected, st.Status)
+		assert.Equal(t, "bad", st.Reason)
+		assert.Equal(t, &rpc.Timestamp{Seconds: 1726670491, Nanos: 123}, st.UpdatedAt)
+		assert.Equal(t, 1, tr.order.Len())
+	})
+	t.Run("evicts oldest entries first", func(t *testing.T) {
+		tr := newStatusTracker(2)
+		tr.set("foo", rpc.TransmissionStatusResponse_Persisted, "", now)
+		tr.set("bar", rpc.TransmissionStatusResponse_Persisted, "", now)
+		// updating does not refresh position
+		tr.set("foo", rpc.TransmissionStatusResponse_Persisted, "", now)
+		tr.set("baz", rpc.TransmissionStatusResponse_Persisted, "", now)
+
+		assert.Equal(t, rpc.TransmissionStatusResponse_Unknown, tr.get("foo").Status)
+		assert.Equal(t, rpc.TransmissionStatusResponse_Persisted, tr.get("bar").Status)
+		assert.Equal(t, rpc.TransmissionStatusResponse_Persisted, tr.get("baz").Status)
+		assert.Len(t, tr.entries, 2)
+	})
+	t.Run("claim", func(t *testing.T) {
+		ctx := tests.Context(t)
+		tr := newStatusTracker(10)
+		tr.deliver("foo", now)
+		release, err := tr.claim(ctx, "foo", now)
+		require.NoError(t, err)
+		require.NotNil(t, release)
+
+		// another delivery waits while the first one stores the transmission
+		claimed := make(chan func())
+		go func() {
+			r, err2 := tr.claim(ctx, "foo", now)
+			assert.NoError(t, err2)
+			claimed <- r
+		}()
+		select {
+		case <-claimed:
+			t.Fatal("claimed while another delivery holds the claim")
+		case <-time.After(10 * time.Millisecond):
+		}
+		cctx, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err = tr.claim(cctx, "foo", now)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		// it takes over if the first delivery failed to store it...
+		release()
+		release = <-claimed
+		require.NotNil(t, release)
+
+		// ...and acknowledges it as a duplicate once it was persisted
+		go func() {
+			r, err2 := tr.claim(ctx, "foo", now)
+			assert.NoError(t, err2)
+			claimed <- r
+		}()
+		tr.set("foo", rpc.TransmissionStatusResponse_Persisted, "", now)
+		release()
+		assert.Nil(t, <-claimed)
+		assert.Equal(t, 1, tr.deliveries("foo"))
+	})
+}
@@ -0,0 +1,141 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink-data-streams/llo"
+	"github.com/smartcontractkit/chainlink-data-streams/rpc"
+)
+
+// ReportStore persists reports received by the server
+type ReportStore interface {
+	// Store persists a transmitted report under the given key, which is the
+	// transmission's idempotency key prefixed with the storage prefix of the
+	// tenant it was routed to. It should return a RejectedError if the report
+	// is invalid and must not be retried; any other error is considered
+	// transient.
+	Store(ctx context.Context, key string, req *rpc.TransmitRequest) error
+}
+
+// RejectedError indicates that a report was permanently rejected and
+// retrying the same transmission will not succeed
+type RejectedError struct {
+	Reason string
+}
+
+func (e *RejectedError) Error() string {
+	return fmt.Sprintf("report rejected: %s", e.Reason)
+}
+
+var _ ReportStore = (*InMemoryReportStore)(nil)
+var _ LatestReportStore = (*InMemoryReportStore)(nil)
+var _ ExpiringReportStore = (*InMemoryReportStore)(nil)
+
+// InMemoryReportStore is a reference ReportStore that keeps every report in
+// memory. It is intended for testing and development only.
+type InMemoryReportStore struct {
+	mu      sync.RWMutex
+	reports map[string]*rpc.TransmitRequest
+	// expiry of each report, see ReportExpiry
+	expiries map[string]time.Time
+	// most recently stored EVM report of each tenant's feeds
+	latest map[latestFeedKey]*rpc.TransmitRequest
+}
+
+// latestFeedKey identifies a feed of the tenant with the storage prefix
+type latestFeedKey struct {
+	storagePrefix string
+	feedID        llo.FeedID
+}
+
+// latestFeedKeyOf returns the latestFeedKey of a report stored under key,
+// and false if the report is not an EVM report
+func latestFeedKeyOf(key string, req *rpc.TransmitRequest) (latestFeedKey, bool) {
+	feedID, ok := feedIDOfEVMPayload(req.Payload)
+	if !ok {
+		return latestFeedKey{}, false
+	}
+	prefix, _ := strings.CutSuffix(key, rpc.IdempotencyKey(req.Payload, req.ReportFormat))
+	return latestFeedKey{prefix, feedID}, true
+}
+
+func NewInMemoryReportStore() *InMemoryReportStore {
+	return &InMemoryReportStore{
+		reports:  make(map[string]*rpc.TransmitRequest),
+		expiries: make(map[string]time.Time),
+		latest:   make(map[latestFeedKey]*rpc.TransmitRequest),
+	}
+}
+
+func (s *InMemoryReportStore) Store(_ context.Context, key string, req *rpc.TransmitRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reports[key] = req
+	s.expiries[key] = ReportExpiry(req, time.Now())
+	if lk, ok := latestFeedKeyOf(key, req); ok {
+		s.latest[lk] = req
+	}
+	return nil
+}
+
+// LatestFeedReport returns the most recently stored EVM report of the feed
+// by the tenant with the given storage prefix
+func (s *InMemoryReportStore) LatestFeedReport(_ context.Context, storagePrefix string, feedID llo.FeedID) (*rpc.TransmitRequest, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	req, ok := s.latest[latestFeedKey{storagePrefix, feedID}]
+	if !ok {
+		return nil, ErrReportNotFound
+	}
+	return req, nil
+}
+
+// Get returns the report stored with the given key
+func (s *InMemoryReportStore) Get(key string) (*rpc.TransmitRequest, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	req, ok := s.reports[key]
+	return req, ok
+}
+
+// Keys returns the sorted keys of all stored reports that start with prefix,
+// e.g. a tenant's storage prefix
+func (s *InMemoryReportStore) Keys(prefix string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var keys []string
+	for k := range s.reports {
+		if strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// PruneExpired deletes the reports that expired before cutoff. Expired
+// reports are no longer served by LatestFeedReport either.
+func (s *InMemoryReportStore) PruneExpired(_ context.Context, cutoff time.Time) ([]PrunedReport, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var pruned []PrunedReport
+	for key, expiry := range s.expiries {
+		if !expiry.Before(cutoff) {
+			continue
+		}
+		req := s.reports[key]
+		if lk, ok := latestFeedKeyOf(key, req); ok && s.latest[lk] == req {
+			delete(s.latest, lk)
+		}
+		delete(s.reports, key)
+		delete(s.expiries, key)
+		pruned = append(pruned, PrunedReport{Key: key, Size: len(req.Payload)})
+	}
+	sort.Slice(pruned, func(i, j int) bool { return pruned[i].Key < pruned[j].Key })
+	return pruned, nil
+}
@@ -0,0 +1,123 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// DefaultTenantName is the tenant that receives all reports when no tenants
+// are configured
+const DefaultTenantName = "default"
+
+// TenantConfig configures a tenant of the server. Each tenant ingests the
+// reports of one or more DONs, identified by config digest, and stores them
+// in isolation from other tenants.
+type TenantConfig struct {
+	// Name identifies the tenant and is the namespace under which its
+	// reports are exposed
+	Name string
+	// ConfigDigests lists the config digests whose reports are routed to
+	// this tenant
+	ConfigDigests []types.ConfigDigest
+	// StoragePrefix is prepended to the key of every report stored for this
+	// tenant. Defaults to Name + "/" if empty.
+	StoragePrefix string
+	// MaxReports is the maximum number of reports that will be stored for
+	// this tenant. Zero means unlimited.
+	MaxReports int
+}
+
+type tenant struct {
+	name          string
+	storagePrefix string
+	maxReports    int
+
+	mu     sync.Mutex
+	stored int
+}
+
+// reserve claims quota for one report, returning false if the tenant's
+// quota is exhausted
+func (t *tenant) reserve() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.maxReports > 0 && t.stored >= t.maxReports {
+		return false
+	}
+	t.stored++
+	return true
+}
+
+// release returns quota claimed for a report that was not stored
+func (t *tenant) release() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stored--
+}
+
+func (t *tenant) storageKey(idempotencyKey string) string {
+	return t.storagePrefix + idempotencyKey
+}
+
+// router maps config digests to tenants
+type router struct {
+	byName   map[string]*tenant
+	byDigest map[types.ConfigDigest]*tenant
+	// fallback receives all reports if no tenants are configured
+	fallback *tenant
+}
+
+func newRouter(cfgs []TenantConfig) (*router, error) {
+	r := &router{
+		byName:   make(map[string]*tenant),
+		byDigest: make(map[types.ConfigDigest]*tenant),
+	}
+	if len(cfgs) == 0 {
+		r.fallback = &tenant{name: DefaultTenantName}
+		r.byName[DefaultTenantName] = r.fallback
+		return r, nil
+	}
+	for _, cfg := range cfgs {
+		if cfg.Name == "" {
+			return nil, errors.New("tenant name must not be empty")
+		}
+		if _, exists := r.byName[cfg.Name]; exists {
+			return nil, fmt.Errorf("duplicate tenant name: %q", cfg.Name)
+		}
+		if cfg.MaxReports < 0 {
+			return nil, fmt.Errorf("tenant %q: MaxReports must not be negative", cfg.Name)
+		}
+		prefix := cfg.StoragePrefix
+		if prefix == "" {
+			prefix = cfg.Name + "/"
+		}
+		t := &tenant{name: cfg.Name, storagePrefix: prefix, maxReports: cfg.MaxReports}
+		r.byName[cfg.Name] = t
+		for _, cd := range cfg.ConfigDigests {
+			if existing, exists := r.byDigest[cd]; exists {
+				return nil, fmt.Errorf("config digest %s is routed to both tenant %q and tenant %q", cd, existing.name, cfg.Name)
+			}
+			r.byDigest[cd] = t
+		}
+	}
+	return r, nil
+}
+
+// route returns the tenant for the given config digest
+func (r *router) route(configDigest []byte) (*tenant, error) {
+	if r.fallback != nil {
+		return r.fallback, nil
+	}
+	cd, err := types.BytesToConfigDigest(configDigest)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config digest: %w", err)
+	}
+	t, exists := r.byDigest[cd]
+	if !exists {
+		return nil, fmt.Errorf("no tenant for config digest %s", cd)
+	}
+	return t, nil
+}
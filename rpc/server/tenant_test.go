@@ -0,0 +1,121 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+
+	"github.com/smartcontractkit/chainlink-data-streams/rpc"
+)
+
+func Test_router(t *testing.T) {
+	cd1 := types.ConfigDigest{1}
+	cd2 := types.ConfigDigest{2}
+
+	t.Run("validates config", func(t *testing.T) {
+		_, err := newRouter([]TenantConfig{{Name: ""}})
+		assert.EqualError(t, err, "tenant name must not be empty")
+
+		_, err = newRouter([]TenantConfig{{Name: "foo"}, {Name: "foo"}})
+		assert.EqualError(t, err, `duplicate tenant name: "foo"`)
+
+		_, err = newRouter([]TenantConfig{{Name: "foo", MaxReports: -1}})
+		assert.EqualError(t, err, `tenant "foo": MaxReports must not be negative`)
+
+		_, err = newRouter([]TenantConfig{{Name: "foo", ConfigDigests: []types.ConfigDigest{cd1}}, {Name: "bar", ConfigDigests: []types.ConfigDigest{cd1}}})
+		assert.EqualError(t, err, `config digest 0100000000000000000000000000000000000000000000000000000000000000 is routed to both tenant "foo" and tenant "bar"`)
+	})
+	t.Run("routes everything to default tenant if none configured", func(t *testing.T) {
+		r, err := newRouter(nil)
+		require.NoError(t, err)
+		tn, err := r.route(nil)
+		require.NoError(t, err)
+		assert.Equal(t, DefaultTenantName, tn.name)
+		assert.Equal(t, "foo", tn.storageKey("foo"))
+	})
+	t.Run("routes by config digest", func(t *testing.T) {
+		r, err := newRouter([]TenantConfig{
+			{Name: "foo", ConfigDigests: []types.ConfigDigest{cd1}},
+			{Name: "bar", ConfigDigests: []types.ConfigDigest{cd2}, StoragePrefix: "dons/bar:"},
+		})
+		require.NoError(t, err)
+
+		tn, err := r.route(cd1[:])
+		require.NoError(t, err)
+		assert.Equal(t, "foo", tn.name)
+		assert.Equal(t, "foo/key", tn.storageKey("key"))
+
+		tn, err = r.route(cd2[:])
+		require.NoError(t, err)
+		assert.Equal(t, "bar", tn.name)
+		assert.Equal(t, "dons/bar:key", tn.storageKey("key"))
+
+		_, err = r.route([]byte{1, 2, 3})
+		assert.ErrorContains(t, err, "invalid config digest")
+
+		_, err = r.route(make([]byte, 32))
+		assert.EqualError(t, err, "no tenant for config digest 0000000000000000000000000000000000000000000000000000000000000000")
+	})
+}
+
+func Test_Server_Tenants(t *testing.T) {
+	ctx := tests.Context(t)
+	cd1 := types.ConfigDigest{1}
+	cd2 := types.ConfigDigest{2}
+
+	store := NewInMemoryReportStore()
+	s, err := NewServer(logger.Test(t), Config{Tenants: []TenantConfig{
+		{Name: "foo", ConfigDigests: []types.ConfigDigest{cd1}, MaxReports: 1},
+		{Name: "bar", ConfigDigests: []types.ConfigDigest{cd2}},
+	}}, store)
+	require.NoError(t, err)
+
+	t.Run("stores reports under tenant prefix", func(t *testing.T) {
+		k1, k2 := rpc.IdempotencyKey([]byte("report 1"), 0), rpc.IdempotencyKey([]byte("report 2"), 0)
+		res, err := s.Transmit(ctx, &rpc.TransmitRequest{Payload: []byte("report 1"), ConfigDigest: cd1[:]})
+		require.NoError(t, err)
+		assert.Zero(t, res.Code)
+		res, err = s.Transmit(ctx, &rpc.TransmitRequest{Payload: []byte("report 2"), ConfigDigest: cd2[:]})
+		require.NoError(t, err)
+		assert.Zero(t, res.Code)
+
+		assert.Equal(t, []string{"foo/" + k1}, store.Keys("foo/"))
+		assert.Equal(t, []string{"bar/" + k2}, store.Keys("bar/"))
+
+		key, err := s.StorageKey("bar", k2)
+		require.NoError(t, err)
+		stored, ok := store.Get(key)
+		require.True(t, ok)
+		assert.Equal(t, []byte("report 2"), stored.Payload)
+
+		_, err = s.StorageKey("baz", k2)
+		assert.EqualError(t, err, `unknown tenant: "baz"`)
+	})
+	t.Run("rejects reports for unknown config digests", func(t *testing.T) {
+		res, err := s.Transmit(ctx, &rpc.TransmitRequest{Payload: []byte("report"), ConfigDigest: make([]byte, 32)})
+		require.NoError(t, err)
+		assert.Equal(t, int32(codes.InvalidArgument), res.Code)
+		assert.Contains(t, res.Error, "no tenant for config digest")
+	})
+	t.Run("enforces quota per tenant", func(t *testing.T) {
+		res, err := s.Transmit(ctx, &rpc.TransmitRequest{Payload: []byte("report 3"), ConfigDigest: cd1[:]})
+		require.NoError(t, err)
+		assert.Equal(t, int32(codes.ResourceExhausted), res.Code)
+		assert.Equal(t, `tenant "foo" has reached its quota of 1 reports`, res.Error)
+
+		st, err := s.TransmissionStatus(ctx, &rpc.TransmissionStatusRequest{IdempotencyKey: rpc.IdempotencyKey([]byte("report 3"), 0)})
+		require.NoError(t, err)
+		assert.Equal(t, rpc.TransmissionStatusResponse_Received, st.Status, "quota rejections are retryable")
+
+		// other tenants are unaffected
+		res, err = s.Transmit(ctx, &rpc.TransmitRequest{Payload: []byte("report 4"), ConfigDigest: cd2[:]})
+		require.NoError(t, err)
+		assert.Zero(t, res.Code)
+	})
+}
@@ -19,16 +19,32 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Transmitter_Transmit_FullMethodName     = "/rpc.Transmitter/Transmit"
-	Transmitter_LatestReport_FullMethodName = "/rpc.Transmitter/LatestReport"
+	Transmitter_Transmit_FullMethodName           = "/rpc.Transmitter/Transmit"
+	Transmitter_LatestReport_FullMethodName       = "/rpc.Transmitter/LatestReport"
+	Transmitter_TransmissionStatus_FullMethodName = "/rpc.Transmitter/TransmissionStatus"
+	Transmitter_ServerInfo_FullMethodName         = "/rpc.Transmitter/ServerInfo"
 )
 
 // TransmitterClient is the client API for Transmitter service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Transmitter is implemented by servers that receive reports from LLO nodes.
+//
+// Changes to this file must be backwards compatible (checked by `buf
+// breaking`, see buf.yaml) and bump SchemaRevision in schema.go.
 type TransmitterClient interface {
+	// Transmit delivers a report. Application-level failures are returned
+	// in the response, with codes mirroring gRPC status codes.
 	Transmit(ctx context.Context, in *TransmitRequest, opts ...grpc.CallOption) (*TransmitResponse, error)
+	// LatestReport returns the most recent report persisted for a feed.
 	LatestReport(ctx context.Context, in *LatestReportRequest, opts ...grpc.CallOption) (*LatestReportResponse, error)
+	// TransmissionStatus returns the delivery status of a transmission, by
+	// its idempotency key.
+	TransmissionStatus(ctx context.Context, in *TransmissionStatusRequest, opts ...grpc.CallOption) (*TransmissionStatusResponse, error)
+	// ServerInfo describes the server and its capabilities, so that clients
+	// can detect schema mismatches and adapt to the server.
+	ServerInfo(ctx context.Context, in *ServerInfoRequest, opts ...grpc.CallOption) (*ServerInfoResponse, error)
 }
 
 type transmitterClient struct {
@@ -59,12 +75,46 @@ func (c *transmitterClient) LatestReport(ctx context.Context, in *LatestReportRe
 	return out, nil
 }
 
+func (c *transmitterClient) TransmissionStatus(ctx context.Context, in *TransmissionStatusRequest, opts ...grpc.CallOption) (*TransmissionStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransmissionStatusResponse)
+	err := c.cc.Invoke(ctx, Transmitter_TransmissionStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transmitterClient) ServerInfo(ctx context.Context, in *ServerInfoRequest, opts ...grpc.CallOption) (*ServerInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServerInfoResponse)
+	err := c.cc.Invoke(ctx, Transmitter_ServerInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TransmitterServer is the server API for Transmitter service.
 // All implementations must embed UnimplementedTransmitterServer
 // for forward compatibility.
+//
+// Transmitter is implemented by servers that receive reports from LLO nodes.
+//
+// Changes to this file must be backwards compatible (checked by `buf
+// breaking`, see buf.yaml) and bump SchemaRevision in schema.go.
 type TransmitterServer interface {
+	// Transmit delivers a report. Application-level failures are returned
+	// in the response, with codes mirroring gRPC status codes.
 	Transmit(context.Context, *TransmitRequest) (*TransmitResponse, error)
+	// LatestReport returns the most recent report persisted for a feed.
 	LatestReport(context.Context, *LatestReportRequest) (*LatestReportResponse, error)
+	// TransmissionStatus returns the delivery status of a transmission, by
+	// its idempotency key.
+	TransmissionStatus(context.Context, *TransmissionStatusRequest) (*TransmissionStatusResponse, error)
+	// ServerInfo describes the server and its capabilities, so that clients
+	// can detect schema mismatches and adapt to the server.
+	ServerInfo(context.Context, *ServerInfoRequest) (*ServerInfoResponse, error)
 	mustEmbedUnimplementedTransmitterServer()
 }
 
@@ -81,6 +131,12 @@ func (UnimplementedTransmitterServer) Transmit(context.Context, *TransmitRequest
 func (UnimplementedTransmitterServer) LatestReport(context.Context, *LatestReportRequest) (*LatestReportResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method LatestReport not implemented")
 }
+func (UnimplementedTransmitterServer) TransmissionStatus(context.Context, *TransmissionStatusRequest) (*TransmissionStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransmissionStatus not implemented")
+}
+func (UnimplementedTransmitterServer) ServerInfo(context.Context, *ServerInfoRequest) (*ServerInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ServerInfo not implemented")
+}
 func (UnimplementedTransmitterServer) mustEmbedUnimplementedTransmitterServer() {}
 func (UnimplementedTransmitterServer) testEmbeddedByValue()                     {}
 
@@ -138,6 +194,42 @@ func _Transmitter_LatestReport_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Transmitter_TransmissionStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransmissionStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransmitterServer).TransmissionStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Transmitter_TransmissionStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransmitterServer).TransmissionStatus(ctx, req.(*TransmissionStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Transmitter_ServerInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServerInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransmitterServer).ServerInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Transmitter_ServerInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransmitterServer).ServerInfo(ctx, req.(*ServerInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Transmitter_ServiceDesc is the grpc.ServiceDesc for Transmitter service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,7 +245,134 @@ var Transmitter_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "LatestReport",
 			Handler:    _Transmitter_LatestReport_Handler,
 		},
+		{
+			MethodName: "TransmissionStatus",
+			Handler:    _Transmitter_TransmissionStatus_Handler,
+		},
+		{
+			MethodName: "ServerInfo",
+			Handler:    _Transmitter_ServerInfo_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "transmitter.proto",
 }
+
+const (
+	Replication_Replicate_FullMethodName = "/rpc.Replication/Replicate"
+)
+
+// ReplicationClient is the client API for Replication service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Replication is implemented by servers that mirror the reports they ingest
+// to peer servers, e.g. in other regions, so that consumers can read from a
+// nearby server.
+type ReplicationClient interface {
+	// Replicate streams the reports ingested by the server from the time of
+	// the call. Subscribers that fall behind are disconnected and should
+	// resubscribe.
+	Replicate(ctx context.Context, in *ReplicateRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ReplicatedReport], error)
+}
+
+type replicationClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReplicationClient(cc grpc.ClientConnInterface) ReplicationClient {
+	return &replicationClient{cc}
+}
+
+func (c *replicationClient) Replicate(ctx context.Context, in *ReplicateRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ReplicatedReport], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Replication_ServiceDesc.Streams[0], Replication_Replicate_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ReplicateRequest, ReplicatedReport]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Replication_ReplicateClient = grpc.ServerStreamingClient[ReplicatedReport]
+
+// ReplicationServer is the server API for Replication service.
+// All implementations must embed UnimplementedReplicationServer
+// for forward compatibility.
+//
+// Replication is implemented by servers that mirror the reports they ingest
+// to peer servers, e.g. in other regions, so that consumers can read from a
+// nearby server.
+type ReplicationServer interface {
+	// Replicate streams the reports ingested by the server from the time of
+	// the call. Subscribers that fall behind are disconnected and should
+	// resubscribe.
+	Replicate(*ReplicateRequest, grpc.ServerStreamingServer[ReplicatedReport]) error
+	mustEmbedUnimplementedReplicationServer()
+}
+
+// UnimplementedReplicationServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedReplicationServer struct{}
+
+func (UnimplementedReplicationServer) Replicate(*ReplicateRequest, grpc.ServerStreamingServer[ReplicatedReport]) error {
+	return status.Errorf(codes.Unimplemented, "method Replicate not implemented")
+}
+func (UnimplementedReplicationServer) mustEmbedUnimplementedReplicationServer() {}
+func (UnimplementedReplicationServer) testEmbeddedByValue()                     {}
+
+// UnsafeReplicationServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReplicationServer will
+// result in compilation errors.
+type UnsafeReplicationServer interface {
+	mustEmbedUnimplementedReplicationServer()
+}
+
+func RegisterReplicationServer(s grpc.ServiceRegistrar, srv ReplicationServer) {
+	// If the following call pancis, it indicates UnimplementedReplicationServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Replication_ServiceDesc, srv)
+}
+
+func _Replication_Replicate_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReplicateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ReplicationServer).Replicate(m, &grpc.GenericServerStream[ReplicateRequest, ReplicatedReport]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Replication_ReplicateServer = grpc.ServerStreamingServer[ReplicatedReport]
+
+// Replication_ServiceDesc is the grpc.ServiceDesc for Replication service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Replication_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "rpc.Replication",
+	HandlerType: (*ReplicationServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Replicate",
+			Handler:       _Replication_Replicate_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "transmitter.proto",
+}